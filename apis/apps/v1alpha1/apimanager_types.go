@@ -19,6 +19,8 @@ package v1alpha1
 import (
 	"fmt"
 	"reflect"
+	"strings"
+	"time"
 
 	"github.com/RHsyseng/operator-utils/pkg/olm"
 	"github.com/go-logr/logr"
@@ -26,6 +28,8 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/3scale/3scale-operator/apis/apps"
@@ -43,10 +47,34 @@ const (
 	Default3scaleAppLabel       = "3scale-api-management"
 )
 
+const (
+	// PausedAnnotation set to "true" stops the operator from reconciling the APIManager components.
+	// The APIManager status is still updated
+	PausedAnnotation = "apps.3scale.net/paused"
+
+	// MaintenanceModeAnnotation set to "true" scales down the components writing to the databases.
+	// The components are scaled back up when the annotation is removed
+	MaintenanceModeAnnotation = "apps.3scale.net/maintenance-mode"
+
+	// MaintenanceModeReplicasAnnotation records, on each component scaled down by the maintenance mode,
+	// the replicas to restore when the maintenance mode is disabled
+	MaintenanceModeReplicasAnnotation = "apps.3scale.net/maintenance-mode-replicas"
+
+	// PauseWorkersAnnotation set to "true" scales down the workers processing background jobs,
+	// so the databases can be dumped consistently. Set by the APIManagerBackup while the databases are backed up
+	PauseWorkersAnnotation = "apps.3scale.net/pause-workers"
+
+	// PauseWorkersOwnerAnnotation records the UID of the resource that set the PauseWorkersAnnotation,
+	// so the workers are only resumed by that resource
+	PauseWorkersOwnerAnnotation = "apps.3scale.net/pause-workers-owner"
+)
+
 const (
 	defaultTenantName                  = "3scale"
 	defaultImageStreamImportInsecure   = false
 	defaultResourceRequirementsEnabled = true
+	defaultSecretRotationInterval      = 90 * 24 * time.Hour
+	minSecretRotationInterval          = time.Hour
 )
 
 const (
@@ -61,6 +89,14 @@ const (
 	DefaultHTTPSPort int32 = 8443
 )
 
+// DefaultSidekiqPoolName identifies the system-sidekiq pods, processing all the queues, in the sidekiq metrics
+const DefaultSidekiqPoolName = "default"
+
+const (
+	DeploymentTypeDeploymentConfig = "DeploymentConfig"
+	DeploymentTypeDeployment       = "Deployment"
+)
+
 // APIManagerSpec defines the desired state of APIManager
 type APIManagerSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
@@ -84,6 +120,33 @@ type APIManagerSpec struct {
 	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
 	// +optional
 	Monitoring *MonitoringSpec `json:"monitoring,omitempty"`
+
+	// DeploymentType selects the workload kind used to deploy the 3scale components.
+	// Defaults to OpenShift DeploymentConfigs. When set to Deployment, existing
+	// DeploymentConfigs are migrated to Kubernetes Deployments.
+	// +optional
+	// +kubebuilder:validation:Enum=DeploymentConfig;Deployment
+	DeploymentType *string `json:"deploymentType,omitempty"`
+
+	// Ingress makes the operator expose the 3scale hosts with Kubernetes Ingress
+	// resources instead of OpenShift Routes
+	// +optional
+	Ingress *IngressSpec `json:"ingress,omitempty"`
+
+	// NetworkPolicies makes the operator create NetworkPolicies allowing
+	// only the traffic each internal 3scale component needs
+	// +optional
+	NetworkPolicies *NetworkPoliciesSpec `json:"networkPolicies,omitempty"`
+
+	// SecretRotation makes the operator periodically rotate the credentials
+	// of the internal databases, the backend internal API and the system access tokens
+	// +optional
+	SecretRotation *SecretRotationSpec `json:"secretRotation,omitempty"`
+
+	// CertManager makes the operator request the TLS certificates of the
+	// APIcast HTTPS port and of the 3scale routes from cert-manager
+	// +optional
+	CertManager *CertManagerSpec `json:"certManager,omitempty"`
 }
 
 // APIManagerStatus defines the observed state of APIManager
@@ -101,6 +164,91 @@ type APIManagerStatus struct {
 	// APIManager Deployment Configs
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Deployments",xDescriptors="urn:alm:descriptor:com.tectonic.ui:podStatuses"
 	Deployments olm.DeploymentStatus `json:"deployments"`
+
+	// ObservedGeneration is the most recent APIManager generation observed by the operator
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// OperatorVersion is the version of the operator that last reconciled the APIManager
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Operator Version"
+	// +optional
+	OperatorVersion string `json:"operatorVersion,omitempty"`
+
+	// ThreescaleRelease is the 3scale release deployed by the operator
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="3scale Release"
+	// +optional
+	ThreescaleRelease string `json:"threescaleRelease,omitempty"`
+
+	// Images deployed for each 3scale component
+	// +optional
+	Images []ComponentImage `json:"images,omitempty"`
+
+	// PinnedImages are the image digests the imagestreams are pinned to
+	// when image digest pinning is enabled
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Pinned Images"
+	// +optional
+	PinnedImages []PinnedImage `json:"pinnedImages,omitempty"`
+
+	// SecretRotation reports the progress of the secret rotation
+	// when the secret rotation is enabled
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Secret Rotation"
+	// +optional
+	SecretRotation *SecretRotationStatus `json:"secretRotation,omitempty"`
+
+	// Certificates reports the cert-manager certificates requested by the operator
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Certificates"
+	// +optional
+	Certificates []CertificateStatus `json:"certificates,omitempty"`
+}
+
+// SecretRotationStatus reports the progress of the secret rotation
+type SecretRotationStatus struct {
+	// LastRotationTime is the time the last rotation completed
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+	// NextRotationTime is the time the next rotation is due
+	// +optional
+	NextRotationTime *metav1.Time `json:"nextRotationTime,omitempty"`
+	// Phase of the rotation in progress. Empty when no rotation is in progress
+	// +optional
+	Phase string `json:"phase,omitempty"`
+}
+
+// CertificateStatus reports the state of a cert-manager certificate
+type CertificateStatus struct {
+	// Name of the cert-manager Certificate
+	Name string `json:"name"`
+	// SecretName is the secret holding the issued certificate and key
+	SecretName string `json:"secretName"`
+	// Ready is true when the issued certificate is up to date
+	Ready bool `json:"ready"`
+	// NotAfter is the expiry time of the issued certificate
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+	// RenewalTime is the time cert-manager will renew the certificate
+	// +optional
+	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
+}
+
+// ComponentImage is the container image deployed for a 3scale component
+type ComponentImage struct {
+	// Name of the component DeploymentConfig or Deployment
+	Name string `json:"name"`
+
+	// Image of the component container, as set in the pod template
+	Image string `json:"image"`
+}
+
+// PinnedImage is the image digest an imagestream is pinned to
+type PinnedImage struct {
+	// Name of the imagestream
+	Name string `json:"name"`
+
+	// Image the digest was resolved from, after applying the registry mirrors
+	Image string `json:"image"`
+
+	// Digest is the image pull spec by digest
+	Digest string `json:"digest"`
 }
 
 func (s *APIManagerStatus) Equals(other *APIManagerStatus, logger logr.Logger) bool {
@@ -121,6 +269,40 @@ func (s *APIManagerStatus) Equals(other *APIManagerStatus, logger logr.Logger) b
 		return false
 	}
 
+	if s.ObservedGeneration != other.ObservedGeneration ||
+		s.OperatorVersion != other.OperatorVersion ||
+		s.ThreescaleRelease != other.ThreescaleRelease {
+		logger.V(1).Info("Versions not equal")
+		return false
+	}
+
+	// Images are sorted by name
+	if !reflect.DeepEqual(s.Images, other.Images) {
+		diff := cmp.Diff(s.Images, other.Images)
+		logger.V(1).Info("Images not equal", "difference", diff)
+		return false
+	}
+
+	// Pinned images are sorted by name
+	if !reflect.DeepEqual(s.PinnedImages, other.PinnedImages) {
+		diff := cmp.Diff(s.PinnedImages, other.PinnedImages)
+		logger.V(1).Info("Pinned images not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(s.SecretRotation, other.SecretRotation) {
+		diff := cmp.Diff(s.SecretRotation, other.SecretRotation)
+		logger.V(1).Info("Secret rotation not equal", "difference", diff)
+		return false
+	}
+
+	// Certificates are sorted by name
+	if !reflect.DeepEqual(s.Certificates, other.Certificates) {
+		diff := cmp.Diff(s.Certificates, other.Certificates)
+		logger.V(1).Info("Certificates not equal", "difference", diff)
+		return false
+	}
+
 	return true
 }
 
@@ -150,6 +332,47 @@ type APIManager struct {
 
 const (
 	APIManagerAvailableConditionType common.ConditionType = "Available"
+
+	// Per subsystem conditions. Reason and message are taken from the first failing resource
+	APIManagerSystemAvailableConditionType     common.ConditionType = "SystemAvailable"
+	APIManagerBackendAvailableConditionType    common.ConditionType = "BackendAvailable"
+	APIManagerApicastAvailableConditionType    common.ConditionType = "ApicastAvailable"
+	APIManagerZyncAvailableConditionType       common.ConditionType = "ZyncAvailable"
+	APIManagerDatabasesAvailableConditionType  common.ConditionType = "DatabasesAvailable"
+	APIManagerMonitoringAvailableConditionType common.ConditionType = "MonitoringAvailable"
+
+	APIManagerReconciliationPausedConditionType common.ConditionType = "ReconciliationPaused"
+	APIManagerMaintenanceModeConditionType      common.ConditionType = "MaintenanceMode"
+
+	// Result of the system-smtp-check job. Only set when spec.system.smtp.connectivityCheck is enabled
+	APIManagerSMTPReachableConditionType common.ConditionType = "SMTPReachable"
+)
+
+const (
+	APIManagerComponentAvailableReason           common.ConditionReason = "Available"
+	APIManagerDeploymentConfigNotFoundReason     common.ConditionReason = "DeploymentConfigNotFound"
+	APIManagerDeploymentConfigNotAvailableReason common.ConditionReason = "DeploymentConfigNotAvailable"
+	APIManagerDeploymentNotFoundReason           common.ConditionReason = "DeploymentNotFound"
+	APIManagerDeploymentNotAvailableReason       common.ConditionReason = "DeploymentNotAvailable"
+	APIManagerPVCNotFoundReason                  common.ConditionReason = "PersistentVolumeClaimNotFound"
+	APIManagerPVCNotBoundReason                  common.ConditionReason = "PersistentVolumeClaimNotBound"
+	APIManagerRouteNotFoundReason                common.ConditionReason = "RouteNotFound"
+	APIManagerRouteNotAdmittedReason             common.ConditionReason = "RouteNotAdmitted"
+	APIManagerIngressNotFoundReason              common.ConditionReason = "IngressNotFound"
+	APIManagerIngressNotReadyReason              common.ConditionReason = "IngressNotReady"
+	APIManagerMonitoringAPINotFoundReason        common.ConditionReason = "MonitoringAPINotFound"
+
+	APIManagerReconciliationPausedReason   common.ConditionReason = "Paused"
+	APIManagerReconciliationActiveReason   common.ConditionReason = "Reconciling"
+	APIManagerMaintenanceScalingDownReason common.ConditionReason = "ScalingDown"
+	APIManagerMaintenanceActiveReason      common.ConditionReason = "Active"
+	APIManagerMaintenanceRestoringReason   common.ConditionReason = "Restoring"
+	APIManagerMaintenanceInactiveReason    common.ConditionReason = "Inactive"
+	APIManagerWorkersPausedReason          common.ConditionReason = "WorkersPaused"
+
+	APIManagerSMTPCheckInProgressReason common.ConditionReason = "CheckInProgress"
+	APIManagerSMTPCheckSucceededReason  common.ConditionReason = "CheckSucceeded"
+	APIManagerSMTPCheckFailedReason     common.ConditionReason = "CheckFailed"
 )
 
 type APIManagerCommonSpec struct {
@@ -166,6 +389,22 @@ type APIManagerCommonSpec struct {
 	ResourceRequirementsEnabled *bool `json:"resourceRequirementsEnabled,omitempty"`
 	// +optional
 	ImagePullSecrets []v1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// ImageRegistryMirrors rewrites the registry or repository prefix of every image
+	// deployed by the operator, including the images set in the APIManager spec
+	// +optional
+	ImageRegistryMirrors []ImageRegistryMirrorSpec `json:"imageRegistryMirrors,omitempty"`
+	// ImageDigestPinningEnabled pins the imagestreams to the image digests resolved
+	// on the first import. Pinned digests are recorded in the status
+	// +optional
+	ImageDigestPinningEnabled *bool `json:"imageDigestPinningEnabled,omitempty"`
+}
+
+// ImageRegistryMirrorSpec replaces the Source prefix of the image URLs by the Mirror prefix
+type ImageRegistryMirrorSpec struct {
+	// Source registry or repository prefix. For example, quay.io/3scale
+	Source string `json:"source"`
+	// Mirror registry or repository prefix. For example, mirror.example.com/3scale
+	Mirror string `json:"mirror"`
 }
 
 // CustomEnvironmentSpec contains or has reference to an APIcast custom environment
@@ -187,6 +426,47 @@ func (c *CustomPolicySpec) VersionName() string {
 	return fmt.Sprintf("%s%s", c.Name, c.Version)
 }
 
+// PodCustomizationSpec extends the pods managed by the operator. The items are merged with
+// the desired state of the component and kept across reconciliations
+type PodCustomizationSpec struct {
+	// ExtraEnv adds environment variables to the containers of the component.
+	// Variables with the same name as the ones set by the operator override them
+	// +optional
+	ExtraEnv []v1.EnvVar `json:"extraEnv,omitempty"`
+	// ExtraVolumes adds volumes to the pods of the component
+	// +optional
+	ExtraVolumes []v1.Volume `json:"extraVolumes,omitempty"`
+	// ExtraVolumeMounts adds volume mounts to the containers of the component.
+	// Mounts with the same mount path as the ones set by the operator override them
+	// +optional
+	ExtraVolumeMounts []v1.VolumeMount `json:"extraVolumeMounts,omitempty"`
+	// Sidecars adds containers to the pods of the component
+	// +optional
+	Sidecars []v1.Container `json:"sidecars,omitempty"`
+	// InitContainers adds init containers to the pods of the component.
+	// They run after the init containers set by the operator
+	// +optional
+	InitContainers []v1.Container `json:"initContainers,omitempty"`
+	// PodSecurityContext replaces the security context of the pods of the component
+	// +optional
+	PodSecurityContext *v1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+	// SecurityContext replaces the security context of the containers of the component
+	// +optional
+	SecurityContext *v1.SecurityContext `json:"securityContext,omitempty"`
+	// LivenessProbe overrides the liveness probe of the containers of the component.
+	// When no handler is set, only the timing and threshold fields set are overridden
+	// +optional
+	LivenessProbe *v1.Probe `json:"livenessProbe,omitempty"`
+	// ReadinessProbe overrides the readiness probe of the containers of the component.
+	// When no handler is set, only the timing and threshold fields set are overridden
+	// +optional
+	ReadinessProbe *v1.Probe `json:"readinessProbe,omitempty"`
+	// StartupProbe overrides the startup probe of the containers of the component.
+	// When no handler is set, only the timing and threshold fields set are overridden
+	// +optional
+	StartupProbe *v1.Probe `json:"startupProbe,omitempty"`
+}
+
 type ApicastSpec struct {
 	// +optional
 	ApicastManagementAPI *string `json:"managementAPI,omitempty"`
@@ -207,6 +487,10 @@ type ApicastSpec struct {
 type ApicastProductionSpec struct {
 	// +optional
 	Replicas *int64 `json:"replicas,omitempty"`
+	// HPA configures a HorizontalPodAutoscaler for the component.
+	// Replicas is ignored when enabled
+	// +optional
+	HPA *HorizontalPodAutoscalerSpec `json:"hpa,omitempty"`
 	// +optional
 	Affinity *v1.Affinity `json:"affinity,omitempty"`
 	// +optional
@@ -271,6 +555,8 @@ type ApicastProductionSpec struct {
 	Labels map[string]string `json:"labels,omitempty"`
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
+	// PodCustomizationSpec adds containers, volumes and environment variables to the pods of the component
+	PodCustomizationSpec `json:",inline"`
 }
 
 type ApicastStagingSpec struct {
@@ -337,6 +623,8 @@ type ApicastStagingSpec struct {
 	Labels map[string]string `json:"labels,omitempty"`
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
+	// PodCustomizationSpec adds containers, volumes and environment variables to the pods of the component
+	PodCustomizationSpec `json:",inline"`
 }
 
 type BackendSpec struct {
@@ -377,6 +665,10 @@ type BackendRedisPersistentVolumeClaimSpec struct {
 type BackendListenerSpec struct {
 	// +optional
 	Replicas *int64 `json:"replicas,omitempty"`
+	// HPA configures a HorizontalPodAutoscaler for the component.
+	// Replicas is ignored when enabled
+	// +optional
+	HPA *HorizontalPodAutoscalerSpec `json:"hpa,omitempty"`
 	// +optional
 	Affinity *v1.Affinity `json:"affinity,omitempty"`
 	// +optional
@@ -391,11 +683,17 @@ type BackendListenerSpec struct {
 	Labels map[string]string `json:"labels,omitempty"`
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
+	// PodCustomizationSpec adds containers, volumes and environment variables to the pods of the component
+	PodCustomizationSpec `json:",inline"`
 }
 
 type BackendWorkerSpec struct {
 	// +optional
 	Replicas *int64 `json:"replicas,omitempty"`
+	// HPA configures a HorizontalPodAutoscaler for the component.
+	// Replicas is ignored when enabled
+	// +optional
+	HPA *HorizontalPodAutoscalerSpec `json:"hpa,omitempty"`
 	// +optional
 	Affinity *v1.Affinity `json:"affinity,omitempty"`
 	// +optional
@@ -410,6 +708,8 @@ type BackendWorkerSpec struct {
 	Labels map[string]string `json:"labels,omitempty"`
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
+	// PodCustomizationSpec adds containers, volumes and environment variables to the pods of the component
+	PodCustomizationSpec `json:",inline"`
 }
 
 type BackendCronSpec struct {
@@ -429,6 +729,8 @@ type BackendCronSpec struct {
 	Labels map[string]string `json:"labels,omitempty"`
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
+	// PodCustomizationSpec adds containers, volumes and environment variables to the pods of the component
+	PodCustomizationSpec `json:",inline"`
 }
 
 type SystemSpec struct {
@@ -491,6 +793,10 @@ type SystemSpec struct {
 	AppSpec *SystemAppSpec `json:"appSpec,omitempty"`
 	// +optional
 	SidekiqSpec *SystemSidekiqSpec `json:"sidekiqSpec,omitempty"`
+	// SidekiqPools deploys a system-sidekiq-<name> DeploymentConfig for each pool,
+	// processing only the queues of the pool. system-sidekiq keeps processing all the queues
+	// +optional
+	SidekiqPools []SystemSidekiqPoolSpec `json:"sidekiqPools,omitempty"`
 
 	// +optional
 	// Deprecated
@@ -498,11 +804,25 @@ type SystemSpec struct {
 
 	// +optional
 	SearchdSpec *SystemSearchdSpec `json:"searchdSpec,omitempty"`
+
+	// SMTPSpec configures the system-smtp secret. When set, the secret is managed by the operator
+	// +optional
+	SMTPSpec *SystemSMTPSpec `json:"smtp,omitempty"`
+	// RecaptchaSpec configures the system-recaptcha secret. When set, the secret is managed by the operator
+	// +optional
+	RecaptchaSpec *SystemRecaptchaSpec `json:"recaptcha,omitempty"`
+	// EventsHookSpec configures the system-events-hook secret. When set, the secret is managed by the operator
+	// +optional
+	EventsHookSpec *SystemEventsHookSpec `json:"eventsHook,omitempty"`
 }
 
 type SystemAppSpec struct {
 	// +optional
 	Replicas *int64 `json:"replicas,omitempty"`
+	// HPA configures a HorizontalPodAutoscaler for the component.
+	// Replicas is ignored when enabled
+	// +optional
+	HPA *HorizontalPodAutoscalerSpec `json:"hpa,omitempty"`
 	// +optional
 	Affinity *v1.Affinity `json:"affinity,omitempty"`
 	// +optional
@@ -513,6 +833,11 @@ type SystemAppSpec struct {
 	ProviderContainerResources *v1.ResourceRequirements `json:"providerContainerResources,omitempty"`
 	// +optional
 	DeveloperContainerResources *v1.ResourceRequirements `json:"developerContainerResources,omitempty"`
+	// SplitDeployments deploys the master, provider and developer containers
+	// as the system-master, system-provider and system-developer deployments,
+	// scaled independently. Not compatible with HPA
+	// +optional
+	SplitDeployments *SystemAppSplitDeploymentsSpec `json:"splitDeployments,omitempty"`
 	// +optional
 	PriorityClassName *string `json:"priorityClassName,omitempty"`
 	// +optional
@@ -521,6 +846,22 @@ type SystemAppSpec struct {
 	Labels map[string]string `json:"labels,omitempty"`
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
+	// PodCustomizationSpec adds containers, volumes and environment variables to the pods of the component
+	PodCustomizationSpec `json:",inline"`
+}
+
+// SystemAppSplitDeploymentsSpec configures the deployments replacing system-app
+type SystemAppSplitDeploymentsSpec struct {
+	Enabled bool `json:"enabled"`
+	// Replicas of system-master. Defaults to the system-app replicas
+	// +optional
+	MasterReplicas *int64 `json:"masterReplicas,omitempty"`
+	// Replicas of system-provider. Defaults to the system-app replicas
+	// +optional
+	ProviderReplicas *int64 `json:"providerReplicas,omitempty"`
+	// Replicas of system-developer. Defaults to the system-app replicas
+	// +optional
+	DeveloperReplicas *int64 `json:"developerReplicas,omitempty"`
 }
 
 type SystemSidekiqSpec struct {
@@ -540,6 +881,115 @@ type SystemSidekiqSpec struct {
 	Labels map[string]string `json:"labels,omitempty"`
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
+	// PodCustomizationSpec adds containers, volumes and environment variables to the pods of the component
+	PodCustomizationSpec `json:",inline"`
+}
+
+type SMTPTLSMode string
+
+const (
+	// SMTPTLSModeNone disables TLS
+	SMTPTLSModeNone SMTPTLSMode = "None"
+	// SMTPTLSModeTLS connects with implicit TLS, usually on port 465
+	SMTPTLSModeTLS SMTPTLSMode = "TLS"
+	// SMTPTLSModeSTARTTLS requires STARTTLS
+	SMTPTLSModeSTARTTLS SMTPTLSMode = "STARTTLS"
+	// SMTPTLSModeSTARTTLSAuto uses STARTTLS when the server supports it
+	SMTPTLSModeSTARTTLSAuto SMTPTLSMode = "STARTTLSAuto"
+)
+
+// SystemSMTPSpec defines the SMTP server used by system to send emails
+type SystemSMTPSpec struct {
+	// Address of the SMTP server
+	// +kubebuilder:validation:MinLength=1
+	Address string `json:"address"`
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+	// Domain sent in the HELO command
+	// +optional
+	Domain *string `json:"domain,omitempty"`
+	// Authentication type. Requires username and passwordSecretRef
+	// +kubebuilder:validation:Enum=plain;login;cram_md5
+	// +optional
+	Authentication *string `json:"authentication,omitempty"`
+	// +optional
+	Username *string `json:"username,omitempty"`
+	// PasswordSecretRef selects the secret key holding the SMTP password
+	// +optional
+	PasswordSecretRef *v1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+	// TLSMode defaults to STARTTLSAuto
+	// +kubebuilder:validation:Enum=None;TLS;STARTTLS;STARTTLSAuto
+	// +optional
+	TLSMode *SMTPTLSMode `json:"tlsMode,omitempty"`
+	// OpenSSLVerifyMode sets how the server certificate is checked
+	// +kubebuilder:validation:Enum=none;peer
+	// +optional
+	OpenSSLVerifyMode *string `json:"opensslVerifyMode,omitempty"`
+	// FromAddress is the sender address of the emails
+	// +optional
+	FromAddress *string `json:"fromAddress,omitempty"`
+	// ConnectivityCheck runs the system-smtp-check job when the SMTP configuration changes.
+	// The result is reported in the SMTPReachable condition
+	// +optional
+	ConnectivityCheck bool `json:"connectivityCheck,omitempty"`
+}
+
+// SystemRecaptchaSpec defines the reCAPTCHA keys of the developer portal
+type SystemRecaptchaSpec struct {
+	// +kubebuilder:validation:MinLength=1
+	PublicKey string `json:"publicKey"`
+	// PrivateKeySecretRef selects the secret key holding the reCAPTCHA private key
+	PrivateKeySecretRef v1.SecretKeySelector `json:"privateKeySecretRef"`
+}
+
+// SystemEventsHookSpec defines the hook backend-worker calls to send the events to system
+type SystemEventsHookSpec struct {
+	// URL of the hook. Defaults to http://system-master:3000/master/events/import
+	// +optional
+	URL *string `json:"url,omitempty"`
+	// PasswordSecretRef selects the secret key holding the secret shared by backend and system
+	PasswordSecretRef v1.SecretKeySelector `json:"passwordSecretRef"`
+}
+
+// SystemSidekiqPoolSpec defines a sidekiq deployment dedicated to a set of queues
+type SystemSidekiqPoolSpec struct {
+	// Name of the pool. The DeploymentConfig is named system-sidekiq-<name>
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=40
+	Name string `json:"name"`
+	// Queues processed by the pool
+	// +kubebuilder:validation:MinItems=1
+	Queues []SidekiqQueueSpec `json:"queues"`
+	// +optional
+	Replicas *int64 `json:"replicas,omitempty"`
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Affinity *v1.Affinity `json:"affinity,omitempty"`
+	// +optional
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
+	// +optional
+	Resources *v1.ResourceRequirements `json:"resources,omitempty"`
+	// +optional
+	PriorityClassName *string `json:"priorityClassName,omitempty"`
+	// +optional
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// SidekiqQueueSpec defines a queue processed by a sidekiq pool
+type SidekiqQueueSpec struct {
+	// Name of the queue. For example, zync or billing
+	Name string `json:"name"`
+	// Weight of the queue. Queues with higher weight are checked more often.
+	// Queues without weight are checked in order
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
 }
 
 type SystemSearchdSpec struct {
@@ -561,6 +1011,8 @@ type SystemSearchdSpec struct {
 	Labels map[string]string `json:"labels,omitempty"`
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
+	// PodCustomizationSpec adds containers, volumes and environment variables to the pods of the component
+	PodCustomizationSpec `json:",inline"`
 }
 
 type SystemSphinxSpec struct {
@@ -727,6 +1179,8 @@ type ZyncAppSpec struct {
 	Labels map[string]string `json:"labels,omitempty"`
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
+	// PodCustomizationSpec adds containers, volumes and environment variables to the pods of the component
+	PodCustomizationSpec `json:",inline"`
 }
 
 type ZyncQueSpec struct {
@@ -746,6 +1200,8 @@ type ZyncQueSpec struct {
 	Labels map[string]string `json:"labels,omitempty"`
 	// +optional
 	Annotations map[string]string `json:"annotations,omitempty"`
+	// PodCustomizationSpec adds containers, volumes and environment variables to the pods of the component
+	PodCustomizationSpec `json:",inline"`
 }
 
 type HighAvailabilitySpec struct {
@@ -780,10 +1236,100 @@ type ExternalZyncComponents struct {
 	Database *bool `json:"database,omitempty"`
 }
 
+type HorizontalPodAutoscalerSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// MinReplicas is the lower limit for the number of replicas. Defaults to 1
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+	// MaxReplicas is the upper limit for the number of replicas
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+	// CPUAverageUtilization is the target average CPU utilization,
+	// as a percentage of the requested CPU. Defaults to 80 when no other target is set
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	CPUAverageUtilization *int32 `json:"cpuAverageUtilization,omitempty"`
+	// MemoryAverageUtilization is the target average memory utilization,
+	// as a percentage of the requested memory
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MemoryAverageUtilization *int32 `json:"memoryAverageUtilization,omitempty"`
+	// PodsMetrics are custom metrics exposed by the component pods,
+	// e.g. the Prometheus exporter metrics served through a custom metrics API adapter
+	// +optional
+	PodsMetrics []HorizontalPodAutoscalerPodsMetricSpec `json:"podsMetrics,omitempty"`
+}
+
+type HorizontalPodAutoscalerPodsMetricSpec struct {
+	// Name of the metric in the custom metrics API
+	Name string `json:"name"`
+	// AverageValue is the target value of the metric averaged across all the pods
+	AverageValue resource.Quantity `json:"averageValue"`
+}
+
+func (h *HorizontalPodAutoscalerSpec) IsEnabled() bool {
+	return h != nil && h.Enabled
+}
+
 type PodDisruptionBudgetSpec struct {
 	Enabled bool `json:"enabled,omitempty"`
 }
 
+type IngressSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// IngressClassName is the name of the IngressClass cluster resource
+	// implementing the ingresses. The cluster default class is used when not set
+	// +optional
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+	// TLSSecretRef references the secret holding the TLS certificate and key
+	// used for all the ingress hosts. TLS is not configured when not set
+	// +optional
+	TLSSecretRef *v1.LocalObjectReference `json:"tlsSecretRef,omitempty"`
+	// Annotations added to all the ingresses, e.g. ingress controller settings
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// ControllerNamespace is the namespace of the ingress controller pods.
+	// Allowed to reach backend-listener when the network policies are enabled
+	// +optional
+	ControllerNamespace *string `json:"controllerNamespace,omitempty"`
+}
+
+type NetworkPoliciesSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// AllowedNamespaces can reach all the isolated components on any port,
+	// e.g. the namespace of the monitoring stack
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+}
+
+type SecretRotationSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// Interval between two rotations, e.g. 720h. Defaults to 2160h (90 days).
+	// The first rotation is due one interval after the APIManager creation
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+type CertManagerSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// IssuerRef references the cert-manager issuer signing the certificates.
+	// Required when enabled
+	// +optional
+	IssuerRef *CertManagerIssuerReference `json:"issuerRef,omitempty"`
+}
+
+type CertManagerIssuerReference struct {
+	Name string `json:"name"`
+	// Kind of the issuer. Defaults to Issuer
+	// +kubebuilder:validation:Enum=Issuer;ClusterIssuer
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// Group of the issuer. Defaults to cert-manager.io
+	// +optional
+	Group string `json:"group,omitempty"`
+}
+
 type MonitoringSpec struct {
 	Enabled bool `json:"enabled,omitempty"`
 	// +optional
@@ -1158,6 +1704,179 @@ func (apimanager *APIManager) IsPDBEnabled() bool {
 	return apimanager.Spec.PodDisruptionBudget != nil && apimanager.Spec.PodDisruptionBudget.Enabled
 }
 
+func (apimanager *APIManager) IsReconciliationPaused() bool {
+	return apimanager.GetAnnotations()[PausedAnnotation] == "true"
+}
+
+func (apimanager *APIManager) IsMaintenanceModeEnabled() bool {
+	return apimanager.GetAnnotations()[MaintenanceModeAnnotation] == "true"
+}
+
+func (apimanager *APIManager) IsPauseWorkersEnabled() bool {
+	return apimanager.GetAnnotations()[PauseWorkersAnnotation] == "true"
+}
+
+// PauseWorkersOwner returns the UID of the resource that paused the workers.
+// Empty when the workers were not paused by a resource
+func (apimanager *APIManager) PauseWorkersOwner() types.UID {
+	return types.UID(apimanager.GetAnnotations()[PauseWorkersOwnerAnnotation])
+}
+
+// PauseWorkers pauses the workers on behalf of the owner resource
+func (apimanager *APIManager) PauseWorkers(owner types.UID) {
+	if apimanager.Annotations == nil {
+		apimanager.Annotations = map[string]string{}
+	}
+	apimanager.Annotations[PauseWorkersAnnotation] = "true"
+	apimanager.Annotations[PauseWorkersOwnerAnnotation] = string(owner)
+}
+
+// ResumeWorkers resumes the workers paused by the owner resource.
+// Returns false when the workers were not paused by the owner
+func (apimanager *APIManager) ResumeWorkers(owner types.UID) bool {
+	if owner == "" || apimanager.PauseWorkersOwner() != owner {
+		return false
+	}
+	delete(apimanager.Annotations, PauseWorkersAnnotation)
+	delete(apimanager.Annotations, PauseWorkersOwnerAnnotation)
+	return true
+}
+
+func (apimanager *APIManager) IsSecretRotationEnabled() bool {
+	return apimanager.Spec.SecretRotation != nil && apimanager.Spec.SecretRotation.Enabled
+}
+
+// SecretRotationInterval returns the interval between two secret rotations
+func (apimanager *APIManager) SecretRotationInterval() time.Duration {
+	if apimanager.Spec.SecretRotation == nil || apimanager.Spec.SecretRotation.Interval == nil {
+		return defaultSecretRotationInterval
+	}
+	return apimanager.Spec.SecretRotation.Interval.Duration
+}
+
+func (apimanager *APIManager) IsCertManagerEnabled() bool {
+	return apimanager.Spec.CertManager != nil && apimanager.Spec.CertManager.Enabled
+}
+
+func (apimanager *APIManager) IsImageDigestPinningEnabled() bool {
+	return apimanager.Spec.ImageDigestPinningEnabled != nil && *apimanager.Spec.ImageDigestPinningEnabled
+}
+
+// MirroredImageURL replaces the image registry or repository prefix
+// by the mirror of the longest matching source prefix.
+// Images without registry, like "centos/mysql-80-centos7", match docker.io prefixes
+func (apimanager *APIManager) MirroredImageURL(image string) string {
+	return MirroredImageURL(apimanager.Spec.ImageRegistryMirrors, image)
+}
+
+// MirroredImageURL replaces the image registry or repository prefix
+// by the mirror of the longest matching source prefix
+func MirroredImageURL(mirrors []ImageRegistryMirrorSpec, image string) string {
+	candidates := []string{image}
+	if fullName := dockerHubImageURL(image); fullName != image {
+		candidates = append(candidates, fullName)
+	}
+
+	result := image
+	longestSource := 0
+	for _, mirror := range mirrors {
+		source := strings.TrimSuffix(mirror.Source, "/")
+		if source == "" || len(source) <= longestSource {
+			continue
+		}
+		for _, candidate := range candidates {
+			if imageURLHasPrefix(candidate, source) {
+				result = strings.TrimSuffix(mirror.Mirror, "/") + candidate[len(source):]
+				longestSource = len(source)
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// PinnedImageURL returns the digest the imagestream is pinned to when it was resolved from the given image.
+// Otherwise, the image is returned
+func (apimanager *APIManager) PinnedImageURL(imageStreamName, image string) string {
+	if !apimanager.IsImageDigestPinningEnabled() {
+		return image
+	}
+
+	for _, pinned := range apimanager.Status.PinnedImages {
+		if pinned.Name == imageStreamName && pinned.Image == image && pinned.Digest != "" {
+			return pinned.Digest
+		}
+	}
+
+	return image
+}
+
+// imageURLHasPrefix matches prefixes on registry, repository, tag or digest boundaries
+func imageURLHasPrefix(image, prefix string) bool {
+	if !strings.HasPrefix(image, prefix) {
+		return false
+	}
+	if len(image) == len(prefix) {
+		return true
+	}
+	return strings.ContainsAny(image[len(prefix):len(prefix)+1], "/:@")
+}
+
+// dockerHubImageURL returns the fully qualified URL of images without registry
+func dockerHubImageURL(image string) string {
+	parts := strings.SplitN(image, "/", 2)
+	if len(parts) == 1 {
+		return "docker.io/library/" + image
+	}
+	if strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost" {
+		return image
+	}
+	return "docker.io/" + image
+}
+
+func (apimanager *APIManager) IsKubernetesDeploymentEnabled() bool {
+	return apimanager.Spec.DeploymentType != nil && *apimanager.Spec.DeploymentType == DeploymentTypeDeployment
+}
+
+func (apimanager *APIManager) IsAPIcastProductionHPAEnabled() bool {
+	return apimanager.Spec.Apicast != nil && apimanager.Spec.Apicast.ProductionSpec != nil &&
+		apimanager.Spec.Apicast.ProductionSpec.HPA.IsEnabled()
+}
+
+func (apimanager *APIManager) IsBackendListenerHPAEnabled() bool {
+	return apimanager.Spec.Backend != nil && apimanager.Spec.Backend.ListenerSpec != nil &&
+		apimanager.Spec.Backend.ListenerSpec.HPA.IsEnabled()
+}
+
+func (apimanager *APIManager) IsBackendWorkerHPAEnabled() bool {
+	return apimanager.Spec.Backend != nil && apimanager.Spec.Backend.WorkerSpec != nil &&
+		apimanager.Spec.Backend.WorkerSpec.HPA.IsEnabled()
+}
+
+func (apimanager *APIManager) IsSystemAppHPAEnabled() bool {
+	return apimanager.Spec.System != nil && apimanager.Spec.System.AppSpec != nil &&
+		apimanager.Spec.System.AppSpec.HPA.IsEnabled()
+}
+
+func (apimanager *APIManager) IsSystemAppSplitEnabled() bool {
+	return apimanager.Spec.System != nil && apimanager.Spec.System.AppSpec != nil &&
+		apimanager.Spec.System.AppSpec.SplitDeployments != nil && apimanager.Spec.System.AppSpec.SplitDeployments.Enabled
+}
+
+func (apimanager *APIManager) IsSystemSMTPCheckEnabled() bool {
+	return apimanager.Spec.System != nil && apimanager.Spec.System.SMTPSpec != nil &&
+		apimanager.Spec.System.SMTPSpec.ConnectivityCheck
+}
+
+func (apimanager *APIManager) IsIngressEnabled() bool {
+	return apimanager.Spec.Ingress != nil && apimanager.Spec.Ingress.Enabled
+}
+
+func (apimanager *APIManager) IsNetworkPoliciesEnabled() bool {
+	return apimanager.Spec.NetworkPolicies != nil && apimanager.Spec.NetworkPolicies.Enabled
+}
+
 func (apimanager *APIManager) IsSystemPostgreSQLEnabled() bool {
 	return !apimanager.IsExternal(SystemDatabase) &&
 		apimanager.Spec.System.DatabaseSpec != nil &&
@@ -1214,6 +1933,10 @@ func (apimanager *APIManager) Validate() field.ErrorList {
 
 	specFldPath := field.NewPath("spec")
 
+	if errs := validation.IsDNS1123Subdomain(apimanager.Spec.WildcardDomain); len(errs) > 0 {
+		fieldErrors = append(fieldErrors, field.Invalid(specFldPath.Child("wildcardDomain"), apimanager.Spec.WildcardDomain, strings.Join(errs, ", ")))
+	}
+
 	if apimanager.Spec.Apicast != nil {
 		apicastFldPath := specFldPath.Child("apicast")
 
@@ -1361,6 +2084,113 @@ func (apimanager *APIManager) Validate() field.ErrorList {
 		}
 	}
 
+	if apimanager.Spec.NetworkPolicies != nil {
+		allowedNamespacesFldPath := specFldPath.Child("networkPolicies").Child("allowedNamespaces")
+		for idx, namespace := range apimanager.Spec.NetworkPolicies.AllowedNamespaces {
+			if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+				fieldErrors = append(fieldErrors, field.Invalid(allowedNamespacesFldPath.Index(idx), namespace, strings.Join(errs, ", ")))
+			}
+		}
+	}
+
+	if apimanager.Spec.SecretRotation != nil && apimanager.Spec.SecretRotation.Interval != nil &&
+		apimanager.Spec.SecretRotation.Interval.Duration < minSecretRotationInterval {
+		intervalFldPath := specFldPath.Child("secretRotation").Child("interval")
+		fieldErrors = append(fieldErrors, field.Invalid(intervalFldPath, apimanager.Spec.SecretRotation.Interval.Duration.String(), fmt.Sprintf("interval must be at least %s", minSecretRotationInterval)))
+	}
+
+	if apimanager.IsCertManagerEnabled() {
+		issuerRefFldPath := specFldPath.Child("certManager").Child("issuerRef")
+		if apimanager.Spec.CertManager.IssuerRef == nil {
+			fieldErrors = append(fieldErrors, field.Required(issuerRefFldPath, "issuer reference is mandatory when cert-manager is enabled"))
+		} else if apimanager.Spec.CertManager.IssuerRef.Name == "" {
+			fieldErrors = append(fieldErrors, field.Invalid(issuerRefFldPath.Child("name"), apimanager.Spec.CertManager.IssuerRef.Name, "issuer name is empty"))
+		}
+	}
+
+	if apimanager.Spec.System != nil {
+		sidekiqPoolsFldPath := specFldPath.Child("system").Child("sidekiqPools")
+		duplicatePoolMap := make(map[string]int)
+		for idx, pool := range apimanager.Spec.System.SidekiqPools {
+			sidekiqPoolsIdxFldPath := sidekiqPoolsFldPath.Index(idx)
+			if pool.Name == DefaultSidekiqPoolName {
+				fieldErrors = append(fieldErrors, field.Invalid(sidekiqPoolsIdxFldPath.Child("name"), pool.Name, "pool name is reserved for system-sidekiq"))
+			}
+			if _, ok := duplicatePoolMap[pool.Name]; ok {
+				fieldErrors = append(fieldErrors, field.Invalid(sidekiqPoolsIdxFldPath.Child("name"), pool.Name, "pool name is duplicated"))
+			}
+			duplicatePoolMap[pool.Name] = 0
+		}
+	}
+
+	mirrorsFldPath := specFldPath.Child("imageRegistryMirrors")
+	duplicateSourceMap := make(map[string]int)
+	for idx, mirror := range apimanager.Spec.ImageRegistryMirrors {
+		mirrorsIdxFldPath := mirrorsFldPath.Index(idx)
+		if mirror.Source == "" {
+			fieldErrors = append(fieldErrors, field.Invalid(mirrorsIdxFldPath.Child("source"), mirror.Source, "source is empty"))
+		}
+		if mirror.Mirror == "" {
+			fieldErrors = append(fieldErrors, field.Invalid(mirrorsIdxFldPath.Child("mirror"), mirror.Mirror, "mirror is empty"))
+		}
+		if _, ok := duplicateSourceMap[mirror.Source]; ok {
+			fieldErrors = append(fieldErrors, field.Invalid(mirrorsIdxFldPath.Child("source"), mirror.Source, "source is duplicated"))
+		}
+		duplicateSourceMap[mirror.Source] = 0
+	}
+
+	if apimanager.IsAPIcastProductionHPAEnabled() {
+		hpaFldPath := specFldPath.Child("apicast").Child("productionSpec").Child("hpa")
+		fieldErrors = append(fieldErrors, validateHorizontalPodAutoscalerSpec(hpaFldPath, apimanager.Spec.Apicast.ProductionSpec.HPA)...)
+	}
+
+	if apimanager.IsBackendListenerHPAEnabled() {
+		hpaFldPath := specFldPath.Child("backend").Child("listenerSpec").Child("hpa")
+		fieldErrors = append(fieldErrors, validateHorizontalPodAutoscalerSpec(hpaFldPath, apimanager.Spec.Backend.ListenerSpec.HPA)...)
+	}
+
+	if apimanager.IsBackendWorkerHPAEnabled() {
+		hpaFldPath := specFldPath.Child("backend").Child("workerSpec").Child("hpa")
+		fieldErrors = append(fieldErrors, validateHorizontalPodAutoscalerSpec(hpaFldPath, apimanager.Spec.Backend.WorkerSpec.HPA)...)
+	}
+
+	if apimanager.IsSystemAppHPAEnabled() {
+		hpaFldPath := specFldPath.Child("system").Child("appSpec").Child("hpa")
+		fieldErrors = append(fieldErrors, validateHorizontalPodAutoscalerSpec(hpaFldPath, apimanager.Spec.System.AppSpec.HPA)...)
+		if apimanager.IsSystemAppSplitEnabled() {
+			fieldErrors = append(fieldErrors, field.Invalid(hpaFldPath, apimanager.Spec.System.AppSpec.HPA, "hpa is not supported with split deployments"))
+		}
+	}
+
+	if apimanager.Spec.System != nil && apimanager.Spec.System.SMTPSpec != nil {
+		smtpFldPath := specFldPath.Child("system").Child("smtp")
+		smtp := apimanager.Spec.System.SMTPSpec
+		if smtp.Authentication != nil && (smtp.Username == nil || smtp.PasswordSecretRef == nil) {
+			fieldErrors = append(fieldErrors, field.Required(smtpFldPath.Child("username"), "username and passwordSecretRef are required with authentication"))
+		}
+	}
+
+	return fieldErrors
+}
+
+func validateHorizontalPodAutoscalerSpec(fldPath *field.Path, hpa *HorizontalPodAutoscalerSpec) field.ErrorList {
+	fieldErrors := field.ErrorList{}
+
+	if hpa.MaxReplicas < 1 {
+		fieldErrors = append(fieldErrors, field.Invalid(fldPath.Child("maxReplicas"), hpa.MaxReplicas, "max replicas must be greater than zero"))
+	}
+
+	if hpa.MinReplicas != nil && *hpa.MinReplicas > hpa.MaxReplicas {
+		fieldErrors = append(fieldErrors, field.Invalid(fldPath.Child("minReplicas"), *hpa.MinReplicas, "min replicas must not be greater than max replicas"))
+	}
+
+	podsMetricsFldPath := fldPath.Child("podsMetrics")
+	for idx, podsMetric := range hpa.PodsMetrics {
+		if podsMetric.Name == "" {
+			fieldErrors = append(fieldErrors, field.Invalid(podsMetricsFldPath.Index(idx), podsMetric, "metric name is empty"))
+		}
+	}
+
 	return fieldErrors
 }
 
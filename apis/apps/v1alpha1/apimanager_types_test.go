@@ -3,11 +3,13 @@ package v1alpha1
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
 	"github.com/3scale/3scale-operator/version"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -165,3 +167,307 @@ func minimumAPIManagerTest() *APIManager {
 		},
 	}
 }
+
+func TestValidateHorizontalPodAutoscaler(t *testing.T) {
+	minReplicas := int32(3)
+
+	cases := []struct {
+		testName       string
+		hpa            *HorizontalPodAutoscalerSpec
+		expectedErrors int
+	}{
+		{"Disabled", &HorizontalPodAutoscalerSpec{Enabled: false, MinReplicas: &minReplicas, MaxReplicas: 1}, 0},
+		{"Valid", &HorizontalPodAutoscalerSpec{Enabled: true, MinReplicas: &minReplicas, MaxReplicas: 5}, 0},
+		{"MinGreaterThanMax", &HorizontalPodAutoscalerSpec{Enabled: true, MinReplicas: &minReplicas, MaxReplicas: 2}, 1},
+		{"MissingMax", &HorizontalPodAutoscalerSpec{Enabled: true}, 1},
+		{"EmptyPodsMetricName", &HorizontalPodAutoscalerSpec{Enabled: true, MaxReplicas: 2,
+			PodsMetrics: []HorizontalPodAutoscalerPodsMetricSpec{{Name: ""}}}, 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apimanager := minimumAPIManagerTest()
+			_, err := apimanager.SetDefaults()
+			if err != nil {
+				subT.Fatal(err)
+			}
+			apimanager.Spec.Backend.WorkerSpec.HPA = tc.hpa
+
+			fieldErrors := apimanager.Validate()
+			if len(fieldErrors) != tc.expectedErrors {
+				subT.Errorf("Expected %d errors, got: %v", tc.expectedErrors, fieldErrors)
+			}
+		})
+	}
+}
+
+func TestMirroredImageURL(t *testing.T) {
+	mirrors := []ImageRegistryMirrorSpec{
+		{Source: "quay.io", Mirror: "mirror.example.com/quay"},
+		{Source: "quay.io/3scale", Mirror: "mirror.example.com/3scale"},
+		{Source: "quay.io/3scale/apicast", Mirror: "mirror.example.com/custom/apicast"},
+		{Source: "docker.io/centos", Mirror: "mirror.example.com/centos/"},
+		{Source: "docker.io/library", Mirror: "mirror.example.com/library"},
+	}
+
+	cases := []struct {
+		testName string
+		image    string
+		expected string
+	}{
+		{"LongestPrefix", "quay.io/3scale/porta:latest", "mirror.example.com/3scale/porta:latest"},
+		{"Repository", "quay.io/3scale/apicast:latest", "mirror.example.com/custom/apicast:latest"},
+		{"Digest", "quay.io/3scale/apicast@sha256:abc", "mirror.example.com/custom/apicast@sha256:abc"},
+		{"RepositoryBoundary", "quay.io/3scale/apicast-custom:latest", "mirror.example.com/3scale/apicast-custom:latest"},
+		{"Registry", "quay.io/centos7/redis-6-centos7:latest", "mirror.example.com/quay/centos7/redis-6-centos7:latest"},
+		{"DockerHubImplicitRegistry", "centos/mysql-80-centos7", "mirror.example.com/centos/mysql-80-centos7"},
+		{"DockerHubOfficialImage", "memcached:1.5", "mirror.example.com/library/memcached:1.5"},
+		{"NoMatch", "registry.redhat.io/3scale-amp2/apicast-gateway-rhel8:3scale2.13", "registry.redhat.io/3scale-amp2/apicast-gateway-rhel8:3scale2.13"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apimanager := minimumAPIManagerTest()
+			apimanager.Spec.ImageRegistryMirrors = mirrors
+
+			if got := apimanager.MirroredImageURL(tc.image); got != tc.expected {
+				subT.Errorf("Expected %s, got: %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestPinnedImageURL(t *testing.T) {
+	trueValue := true
+	apimanager := minimumAPIManagerTest()
+	apimanager.Status.PinnedImages = []PinnedImage{
+		{Name: "amp-apicast", Image: "quay.io/3scale/apicast:latest", Digest: "quay.io/3scale/apicast@sha256:abc"},
+	}
+
+	// Pinning disabled
+	if got := apimanager.PinnedImageURL("amp-apicast", "quay.io/3scale/apicast:latest"); got != "quay.io/3scale/apicast:latest" {
+		t.Errorf("Unexpected pinned image with pinning disabled: %s", got)
+	}
+
+	apimanager.Spec.ImageDigestPinningEnabled = &trueValue
+	if got := apimanager.PinnedImageURL("amp-apicast", "quay.io/3scale/apicast:latest"); got != "quay.io/3scale/apicast@sha256:abc" {
+		t.Errorf("Unexpected pinned image: %s", got)
+	}
+
+	// The source image changed, the digest is resolved again
+	if got := apimanager.PinnedImageURL("amp-apicast", "quay.io/3scale/apicast:nightly"); got != "quay.io/3scale/apicast:nightly" {
+		t.Errorf("Unexpected pinned image for a new source image: %s", got)
+	}
+}
+
+func TestValidateImageRegistryMirrors(t *testing.T) {
+	cases := []struct {
+		testName       string
+		mirrors        []ImageRegistryMirrorSpec
+		expectedErrors int
+	}{
+		{"Valid", []ImageRegistryMirrorSpec{{Source: "quay.io", Mirror: "mirror.example.com"}}, 0},
+		{"EmptySource", []ImageRegistryMirrorSpec{{Source: "", Mirror: "mirror.example.com"}}, 1},
+		{"EmptyMirror", []ImageRegistryMirrorSpec{{Source: "quay.io", Mirror: ""}}, 1},
+		{"DuplicatedSource", []ImageRegistryMirrorSpec{
+			{Source: "quay.io", Mirror: "mirror.example.com"},
+			{Source: "quay.io", Mirror: "other.example.com"},
+		}, 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apimanager := minimumAPIManagerTest()
+			_, err := apimanager.SetDefaults()
+			if err != nil {
+				subT.Fatal(err)
+			}
+			apimanager.Spec.ImageRegistryMirrors = tc.mirrors
+
+			fieldErrors := apimanager.Validate()
+			if len(fieldErrors) != tc.expectedErrors {
+				subT.Errorf("Expected %d errors, got: %v", tc.expectedErrors, fieldErrors)
+			}
+		})
+	}
+}
+
+func TestValidateNetworkPoliciesAllowedNamespaces(t *testing.T) {
+	apimanager := minimumAPIManagerTest()
+	_, err := apimanager.SetDefaults()
+	if err != nil {
+		t.Fatal(err)
+	}
+	apimanager.Spec.NetworkPolicies = &NetworkPoliciesSpec{
+		Enabled:           true,
+		AllowedNamespaces: []string{"openshift-monitoring", "Invalid_Namespace"},
+	}
+
+	fieldErrors := apimanager.Validate()
+	if len(fieldErrors) != 1 {
+		t.Errorf("Expected 1 error, got: %v", fieldErrors)
+	}
+}
+
+func TestSecretRotationInterval(t *testing.T) {
+	apimanager := minimumAPIManagerTest()
+	if interval := apimanager.SecretRotationInterval(); interval != 2160*time.Hour {
+		t.Errorf("default interval: expected 2160h, got %s", interval)
+	}
+
+	apimanager.Spec.SecretRotation = &SecretRotationSpec{
+		Enabled:  true,
+		Interval: &metav1.Duration{Duration: 720 * time.Hour},
+	}
+	if interval := apimanager.SecretRotationInterval(); interval != 720*time.Hour {
+		t.Errorf("interval: expected 720h, got %s", interval)
+	}
+}
+
+func TestValidateSecretRotationInterval(t *testing.T) {
+	apimanager := minimumAPIManagerTest()
+	_, err := apimanager.SetDefaults()
+	if err != nil {
+		t.Fatal(err)
+	}
+	apimanager.Spec.SecretRotation = &SecretRotationSpec{
+		Enabled:  true,
+		Interval: &metav1.Duration{Duration: 10 * time.Minute},
+	}
+
+	fieldErrors := apimanager.Validate()
+	if len(fieldErrors) != 1 {
+		t.Errorf("Expected 1 error, got: %v", fieldErrors)
+	}
+}
+
+func TestValidateCertManagerIssuerRef(t *testing.T) {
+	cases := []struct {
+		testName       string
+		certManager    *CertManagerSpec
+		expectedErrors int
+	}{
+		{"disabled", &CertManagerSpec{Enabled: false}, 0},
+		{"missingIssuerRef", &CertManagerSpec{Enabled: true}, 1},
+		{"emptyIssuerName", &CertManagerSpec{Enabled: true, IssuerRef: &CertManagerIssuerReference{}}, 1},
+		{"valid", &CertManagerSpec{Enabled: true, IssuerRef: &CertManagerIssuerReference{Name: "letsencrypt", Kind: "ClusterIssuer"}}, 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apimanager := minimumAPIManagerTest()
+			_, err := apimanager.SetDefaults()
+			if err != nil {
+				subT.Fatal(err)
+			}
+			apimanager.Spec.CertManager = tc.certManager
+
+			fieldErrors := apimanager.Validate()
+			if len(fieldErrors) != tc.expectedErrors {
+				subT.Errorf("Expected %d errors, got: %v", tc.expectedErrors, fieldErrors)
+			}
+		})
+	}
+}
+
+func TestValidateSidekiqPools(t *testing.T) {
+	queues := []SidekiqQueueSpec{{Name: "critical"}}
+
+	cases := []struct {
+		testName       string
+		pools          []SystemSidekiqPoolSpec
+		expectedErrors int
+	}{
+		{"noPools", nil, 0},
+		{"valid", []SystemSidekiqPoolSpec{{Name: "critical", Queues: queues}, {Name: "mailers", Queues: queues}}, 0},
+		{"reservedName", []SystemSidekiqPoolSpec{{Name: DefaultSidekiqPoolName, Queues: queues}}, 1},
+		{"duplicatedName", []SystemSidekiqPoolSpec{{Name: "critical", Queues: queues}, {Name: "critical", Queues: queues}}, 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apimanager := minimumAPIManagerTest()
+			_, err := apimanager.SetDefaults()
+			if err != nil {
+				subT.Fatal(err)
+			}
+			apimanager.Spec.System.SidekiqPools = tc.pools
+
+			fieldErrors := apimanager.Validate()
+			if len(fieldErrors) != tc.expectedErrors {
+				subT.Errorf("Expected %d errors, got: %v", tc.expectedErrors, fieldErrors)
+			}
+		})
+	}
+}
+
+func TestValidateSystemAppSplitDeployments(t *testing.T) {
+	hpa := &HorizontalPodAutoscalerSpec{Enabled: true, MaxReplicas: 5}
+
+	cases := []struct {
+		testName       string
+		split          *SystemAppSplitDeploymentsSpec
+		hpa            *HorizontalPodAutoscalerSpec
+		expectedErrors int
+	}{
+		{"SplitWithoutHPA", &SystemAppSplitDeploymentsSpec{Enabled: true}, nil, 0},
+		{"HPAWithoutSplit", nil, hpa, 0},
+		{"HPAWithSplitDisabled", &SystemAppSplitDeploymentsSpec{Enabled: false}, hpa, 0},
+		{"HPAWithSplit", &SystemAppSplitDeploymentsSpec{Enabled: true}, hpa, 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apimanager := minimumAPIManagerTest()
+			_, err := apimanager.SetDefaults()
+			if err != nil {
+				subT.Fatal(err)
+			}
+			apimanager.Spec.System.AppSpec.SplitDeployments = tc.split
+			apimanager.Spec.System.AppSpec.HPA = tc.hpa
+
+			fieldErrors := apimanager.Validate()
+			if len(fieldErrors) != tc.expectedErrors {
+				subT.Errorf("Expected %d errors, got: %v", tc.expectedErrors, fieldErrors)
+			}
+		})
+	}
+}
+
+func TestValidateSystemSMTP(t *testing.T) {
+	authentication := "login"
+	username := "mailer"
+	passwordSecretRef := &v1.SecretKeySelector{
+		LocalObjectReference: v1.LocalObjectReference{Name: "mycredentials"}, Key: "smtp-password",
+	}
+
+	cases := []struct {
+		testName       string
+		smtp           *SystemSMTPSpec
+		expectedErrors int
+	}{
+		{"WithoutAuthentication", &SystemSMTPSpec{Address: "smtp.example.com", Port: 25}, 0},
+		{"WithCredentials", &SystemSMTPSpec{Address: "smtp.example.com", Port: 587,
+			Authentication: &authentication, Username: &username, PasswordSecretRef: passwordSecretRef}, 0},
+		{"WithoutUsername", &SystemSMTPSpec{Address: "smtp.example.com", Port: 587,
+			Authentication: &authentication, PasswordSecretRef: passwordSecretRef}, 1},
+		{"WithoutPassword", &SystemSMTPSpec{Address: "smtp.example.com", Port: 587,
+			Authentication: &authentication, Username: &username}, 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apimanager := minimumAPIManagerTest()
+			_, err := apimanager.SetDefaults()
+			if err != nil {
+				subT.Fatal(err)
+			}
+			apimanager.Spec.System.SMTPSpec = tc.smtp
+
+			fieldErrors := apimanager.Validate()
+			if len(fieldErrors) != tc.expectedErrors {
+				subT.Errorf("Expected %d errors, got: %v", tc.expectedErrors, fieldErrors)
+			}
+		})
+	}
+}
@@ -0,0 +1,360 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/3scale/3scale-operator/apis/apps"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+const (
+	systemDatabaseTypeExternal   = "external"
+	systemDatabaseTypeMySQL      = "mysql"
+	systemDatabaseTypePostgreSQL = "postgresql"
+)
+
+var apimanagerlog = logf.Log.WithName("apimanager-resource")
+
+// +kubebuilder:object:generate=false
+type secretReference struct {
+	fldPath   *field.Path
+	secretRef *v1.LocalObjectReference
+}
+
+// SetupWebhookWithManager registers the APIManager defaulting and validating webhooks
+func (apimanager *APIManager) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(apimanager).
+		WithDefaulter(&APIManagerDefaulter{}).
+		WithValidator(&APIManagerValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-apps-3scale-net-v1alpha1-apimanager,mutating=true,failurePolicy=fail,sideEffects=None,groups=apps.3scale.net,resources=apimanagers,verbs=create;update,versions=v1alpha1,name=mapimanager.apps.3scale.net,admissionReviewVersions=v1
+
+// APIManagerDefaulter sets the APIManager defaults on admission,
+// the same way the APIManager controller does before reconciling
+// +kubebuilder:object:generate=false
+type APIManagerDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &APIManagerDefaulter{}
+
+// Default implements webhook.CustomDefaulter
+func (d *APIManagerDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	apimanager, ok := obj.(*APIManager)
+	if !ok {
+		return fmt.Errorf("%T is not a *APIManager", obj)
+	}
+
+	apimanagerlog.V(1).Info("default", "name", apimanager.Name)
+
+	apimanager.UpdateExternalComponentsFromHighAvailability()
+	_, err := apimanager.SetDefaults()
+	return err
+}
+
+// +kubebuilder:webhook:path=/validate-apps-3scale-net-v1alpha1-apimanager,mutating=false,failurePolicy=fail,sideEffects=None,groups=apps.3scale.net,resources=apimanagers,verbs=create;update,versions=v1alpha1,name=vapimanager.apps.3scale.net,admissionReviewVersions=v1
+
+// APIManagerValidator validates the APIManager spec on admission.
+// Besides the spec validation done by the APIManager controller,
+// it checks that the referenced secrets exist and rejects changes
+// that cannot be applied to a running 3scale installation.
+// +kubebuilder:object:generate=false
+type APIManagerValidator struct {
+	Client client.Client
+}
+
+var _ webhook.CustomValidator = &APIManagerValidator{}
+
+// ValidateCreate implements webhook.CustomValidator
+func (v *APIManagerValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	apimanager, ok := obj.(*APIManager)
+	if !ok {
+		return fmt.Errorf("%T is not a *APIManager", obj)
+	}
+
+	apimanagerlog.V(1).Info("validate create", "name", apimanager.Name)
+
+	fieldErrors := field.ErrorList{}
+	fieldErrors = append(fieldErrors, apimanager.Validate()...)
+	fieldErrors = append(fieldErrors, validateExternalComponentsConflicts(apimanager)...)
+
+	secretErrors, err := v.validateSecretReferences(ctx, nil, apimanager)
+	if err != nil {
+		return err
+	}
+	fieldErrors = append(fieldErrors, secretErrors...)
+
+	return v.toInvalidError(apimanager, fieldErrors)
+}
+
+// ValidateUpdate implements webhook.CustomValidator
+func (v *APIManagerValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	oldAPIManager, ok := oldObj.(*APIManager)
+	if !ok {
+		return fmt.Errorf("%T is not a *APIManager", oldObj)
+	}
+	apimanager, ok := newObj.(*APIManager)
+	if !ok {
+		return fmt.Errorf("%T is not a *APIManager", newObj)
+	}
+
+	apimanagerlog.V(1).Info("validate update", "name", apimanager.Name)
+
+	// Objects being deleted, or updates not changing the spec, like the finalizers
+	// or the annotations, must not be blocked by a spec that became invalid
+	if apimanager.GetDeletionTimestamp() != nil || equality.Semantic.DeepEqual(oldAPIManager.Spec, apimanager.Spec) {
+		return nil
+	}
+
+	fieldErrors := field.ErrorList{}
+	fieldErrors = append(fieldErrors, apimanager.Validate()...)
+
+	// Conflicts already stored are not reported,
+	// so existing resources can still be updated by the operator
+	if len(validateExternalComponentsConflicts(oldAPIManager)) == 0 {
+		fieldErrors = append(fieldErrors, validateExternalComponentsConflicts(apimanager)...)
+	}
+
+	fieldErrors = append(fieldErrors, validateImmutableDatabases(oldAPIManager, apimanager)...)
+
+	secretErrors, err := v.validateSecretReferences(ctx, oldAPIManager, apimanager)
+	if err != nil {
+		return err
+	}
+	fieldErrors = append(fieldErrors, secretErrors...)
+
+	return v.toInvalidError(apimanager, fieldErrors)
+}
+
+// ValidateDelete implements webhook.CustomValidator
+func (v *APIManagerValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func (v *APIManagerValidator) toInvalidError(apimanager *APIManager, fieldErrors field.ErrorList) error {
+	if len(fieldErrors) == 0 {
+		return nil
+	}
+
+	return errors.NewInvalid(GroupVersion.WithKind(apps.APIManagerKind).GroupKind(), apimanager.Name, fieldErrors)
+}
+
+// validateSecretReferences checks the secrets referenced by the spec.
+// On updates, only the references changed from the old object are checked
+func (v *APIManagerValidator) validateSecretReferences(ctx context.Context, oldAPIManager, apimanager *APIManager) (field.ErrorList, error) {
+	fieldErrors := field.ErrorList{}
+
+	for _, ref := range changedSecretReferences(oldAPIManager, apimanager, secretReferences) {
+		fldPath, secretRef := ref.fldPath, ref.secretRef
+		// Empty references are reported by the spec validation
+		if secretRef == nil || secretRef.Name == "" {
+			continue
+		}
+
+		err := v.Client.Get(ctx, types.NamespacedName{Name: secretRef.Name, Namespace: apimanager.Namespace}, &v1.Secret{})
+		if errors.IsNotFound(err) {
+			fieldErrors = append(fieldErrors, field.NotFound(fldPath, secretRef.Name))
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, ref := range changedSecretReferences(oldAPIManager, apimanager, tlsSecretReferences) {
+		fldPath, secretRef := ref.fldPath, ref.secretRef
+		if secretRef == nil {
+			continue
+		}
+
+		if secretRef.Name == "" {
+			fieldErrors = append(fieldErrors, field.Required(fldPath.Child("name"), "secret name not provided"))
+			continue
+		}
+
+		err := helper.ValidateTLSSecret(types.NamespacedName{Name: secretRef.Name, Namespace: apimanager.Namespace}, v.Client)
+		if errors.IsNotFound(err) {
+			fieldErrors = append(fieldErrors, field.NotFound(fldPath, secretRef.Name))
+		} else if err != nil {
+			fieldErrors = append(fieldErrors, field.Invalid(fldPath, secretRef, err.Error()))
+		}
+	}
+
+	return fieldErrors, nil
+}
+
+// changedSecretReferences returns the secret references of the APIManager
+// not found with the same value in the old APIManager, when given
+func changedSecretReferences(oldAPIManager, apimanager *APIManager, references func(*APIManager) []secretReference) []secretReference {
+	secretRefs := references(apimanager)
+	if oldAPIManager == nil {
+		return secretRefs
+	}
+
+	oldSecretRefs := map[string]*v1.LocalObjectReference{}
+	for _, ref := range references(oldAPIManager) {
+		oldSecretRefs[ref.fldPath.String()] = ref.secretRef
+	}
+
+	changed := []secretReference{}
+	for _, ref := range secretRefs {
+		if oldSecretRef, ok := oldSecretRefs[ref.fldPath.String()]; ok && reflect.DeepEqual(oldSecretRef, ref.secretRef) {
+			continue
+		}
+		changed = append(changed, ref)
+	}
+
+	return changed
+}
+
+// secretReferences returns the references to the generic secrets of the spec
+func secretReferences(apimanager *APIManager) []secretReference {
+	specFldPath := field.NewPath("spec")
+
+	secretRefs := []secretReference{}
+
+	if apimanager.Spec.Apicast != nil {
+		apicastFldPath := specFldPath.Child("apicast")
+
+		if prodSpec := apimanager.Spec.Apicast.ProductionSpec; prodSpec != nil {
+			prodSpecFldPath := apicastFldPath.Child("productionSpec")
+			for idx, customPolicySpec := range prodSpec.CustomPolicies {
+				secretRefs = append(secretRefs, secretReference{prodSpecFldPath.Child("customPolicies").Index(idx).Child("secretRef"), customPolicySpec.SecretRef})
+			}
+			if prodSpec.OpenTracing != nil {
+				secretRefs = append(secretRefs, secretReference{prodSpecFldPath.Child("openTracing").Child("tracingConfigSecretRef"), prodSpec.OpenTracing.TracingConfigSecretRef})
+			}
+		}
+
+		if stagingSpec := apimanager.Spec.Apicast.StagingSpec; stagingSpec != nil {
+			stagingSpecFldPath := apicastFldPath.Child("stagingSpec")
+			for idx, customPolicySpec := range stagingSpec.CustomPolicies {
+				secretRefs = append(secretRefs, secretReference{stagingSpecFldPath.Child("customPolicies").Index(idx).Child("secretRef"), customPolicySpec.SecretRef})
+			}
+			if stagingSpec.OpenTracing != nil {
+				secretRefs = append(secretRefs, secretReference{stagingSpecFldPath.Child("openTracing").Child("tracingConfigSecretRef"), stagingSpec.OpenTracing.TracingConfigSecretRef})
+			}
+		}
+	}
+
+	if apimanager.Spec.System != nil && apimanager.IsS3Enabled() {
+		s3FldPath := specFldPath.Child("system").Child("fileStorage").Child("simpleStorageService").Child("configurationSecretRef")
+		secretRefs = append(secretRefs, secretReference{s3FldPath, &apimanager.Spec.System.FileStorageSpec.S3.ConfigurationSecretRef})
+	}
+
+	return secretRefs
+}
+
+// tlsSecretReferences returns the references to the TLS secrets of the spec
+func tlsSecretReferences(apimanager *APIManager) []secretReference {
+	specFldPath := field.NewPath("spec")
+	tlsSecretRefs := []secretReference{}
+
+	if apimanager.Spec.Apicast != nil && apimanager.Spec.Apicast.ProductionSpec != nil {
+		tlsSecretRefs = append(tlsSecretRefs, secretReference{specFldPath.Child("apicast").Child("productionSpec").Child("httpsCertificateSecretRef"), apimanager.Spec.Apicast.ProductionSpec.HTTPSCertificateSecretRef})
+	}
+
+	if apimanager.Spec.Apicast != nil && apimanager.Spec.Apicast.StagingSpec != nil {
+		tlsSecretRefs = append(tlsSecretRefs, secretReference{specFldPath.Child("apicast").Child("stagingSpec").Child("httpsCertificateSecretRef"), apimanager.Spec.Apicast.StagingSpec.HTTPSCertificateSecretRef})
+	}
+
+	if apimanager.IsIngressEnabled() {
+		tlsSecretRefs = append(tlsSecretRefs, secretReference{specFldPath.Child("ingress").Child("tlsSecretRef"), apimanager.Spec.Ingress.TLSSecretRef})
+	}
+
+	return tlsSecretRefs
+}
+
+// validateExternalComponentsConflicts reports the deprecated highAvailability settings
+// disagreeing with the externalComponents settings, which take precedence
+func validateExternalComponentsConflicts(apimanager *APIManager) field.ErrorList {
+	fieldErrors := field.ErrorList{}
+
+	if apimanager.Spec.ExternalComponents == nil {
+		return fieldErrors
+	}
+
+	highAvailabilityComponents := mapHighAvailabilityToExternalComponents(apimanager)
+	if highAvailabilityComponents == nil {
+		return fieldErrors
+	}
+
+	selectors := []struct {
+		name     string
+		selector func(*ExternalComponentsSpec) bool
+	}{
+		{"system.database", SystemDatabase},
+		{"system.redis", SystemRedis},
+		{"backend.redis", BackendRedis},
+		{"zync.database", ZyncDatabase},
+	}
+
+	highAvailabilityFldPath := field.NewPath("spec").Child("highAvailability")
+	for _, s := range selectors {
+		if s.selector(highAvailabilityComponents) != s.selector(apimanager.Spec.ExternalComponents) {
+			fieldErrors = append(fieldErrors, field.Invalid(highAvailabilityFldPath, apimanager.Spec.HighAvailability,
+				fmt.Sprintf("conflicts with spec.externalComponents.%s. Remove the deprecated highAvailability field", s.name)))
+		}
+	}
+
+	return fieldErrors
+}
+
+// validateImmutableDatabases rejects switching the database type of a running installation,
+// as the existing data is not migrated
+func validateImmutableDatabases(oldAPIManager, apimanager *APIManager) field.ErrorList {
+	fieldErrors := field.ErrorList{}
+
+	oldSystemDatabaseType := systemDatabaseType(oldAPIManager)
+	if newSystemDatabaseType := systemDatabaseType(apimanager); newSystemDatabaseType != oldSystemDatabaseType {
+		fieldErrors = append(fieldErrors, field.Forbidden(field.NewPath("spec").Child("system").Child("database"),
+			fmt.Sprintf("system database type cannot be changed from %s to %s", oldSystemDatabaseType, newSystemDatabaseType)))
+	}
+
+	if oldAPIManager.IsExternal(ZyncDatabase) != apimanager.IsExternal(ZyncDatabase) {
+		fieldErrors = append(fieldErrors, field.Forbidden(field.NewPath("spec").Child("externalComponents").Child("zync").Child("database"),
+			"zync database cannot be switched between internal and external"))
+	}
+
+	return fieldErrors
+}
+
+func systemDatabaseType(apimanager *APIManager) string {
+	if apimanager.IsExternal(SystemDatabase) {
+		return systemDatabaseTypeExternal
+	}
+
+	if apimanager.Spec.System != nil && apimanager.Spec.System.DatabaseSpec != nil && apimanager.Spec.System.DatabaseSpec.PostgreSQL != nil {
+		return systemDatabaseTypePostgreSQL
+	}
+
+	return systemDatabaseTypeMySQL
+}
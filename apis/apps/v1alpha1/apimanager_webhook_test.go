@@ -0,0 +1,255 @@
+package v1alpha1
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func webhookTestAPIManager() *APIManager {
+	apimanager := minimumAPIManagerTest()
+	apimanager.Name = "example-apimanager"
+	apimanager.Namespace = "someNS"
+	return apimanager
+}
+
+func webhookTestValidator(objs ...runtime.Object) *APIManagerValidator {
+	s := runtime.NewScheme()
+	if err := v1.AddToScheme(s); err != nil {
+		panic(err)
+	}
+	return &APIManagerValidator{Client: fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).Build()}
+}
+
+func webhookTestSecret(name string, secretType v1.SecretType, data map[string][]byte) *v1.Secret {
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "someNS"},
+		Type:       secretType,
+		Data:       data,
+	}
+}
+
+func TestAPIManagerDefaulter(t *testing.T) {
+	apimanager := webhookTestAPIManager()
+	apimanager.Spec.HighAvailability = &HighAvailabilitySpec{Enabled: true}
+
+	expected := apimanager.DeepCopy()
+	expected.UpdateExternalComponentsFromHighAvailability()
+	if _, err := expected.SetDefaults(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := (&APIManagerDefaulter{}).Default(context.TODO(), apimanager)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(expected, apimanager) {
+		t.Errorf("Unexpected defaults (-want +got):\n%s", cmp.Diff(expected, apimanager))
+	}
+
+	if apimanager.Spec.HighAvailability != nil || !apimanager.IsExternal(SystemDatabase) {
+		t.Errorf("highAvailability not migrated to externalComponents")
+	}
+}
+
+func TestAPIManagerDefaulterConflictingDatabases(t *testing.T) {
+	apimanager := webhookTestAPIManager()
+	apimanager.Spec.System = &SystemSpec{DatabaseSpec: &SystemDatabaseSpec{
+		MySQL:      &SystemMySQLSpec{},
+		PostgreSQL: &SystemPostgreSQLSpec{},
+	}}
+
+	err := (&APIManagerDefaulter{}).Default(context.TODO(), apimanager)
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
+
+func TestAPIManagerValidatorValidateCreate(t *testing.T) {
+	trueVal := true
+	falseVal := false
+
+	tlsSecret := webhookTestSecret("tls-secret", v1.SecretTypeTLS, map[string][]byte{
+		v1.TLSCertKey:       []byte("cert"),
+		v1.TLSPrivateKeyKey: []byte("key"),
+	})
+	opaqueSecret := webhookTestSecret("opaque-secret", v1.SecretTypeOpaque, nil)
+
+	cases := []struct {
+		testName    string
+		objs        []runtime.Object
+		mutate      func(*APIManager)
+		expectError bool
+	}{
+		{"Valid", nil, func(a *APIManager) {}, false},
+		{"InvalidWildcardDomain", nil, func(a *APIManager) {
+			a.Spec.WildcardDomain = "*.Example_Domain"
+		}, true},
+		{"HighAvailabilityMatchingExternalComponents", nil, func(a *APIManager) {
+			a.Spec.HighAvailability = &HighAvailabilitySpec{Enabled: true}
+			a.Spec.ExternalComponents = &ExternalComponentsSpec{
+				System:  &ExternalSystemComponents{Redis: &trueVal, Database: &trueVal},
+				Backend: &ExternalBackendComponents{Redis: &trueVal},
+			}
+		}, false},
+		{"HighAvailabilityConflictingExternalComponents", nil, func(a *APIManager) {
+			a.Spec.HighAvailability = &HighAvailabilitySpec{Enabled: true}
+			a.Spec.ExternalComponents = &ExternalComponentsSpec{
+				System: &ExternalSystemComponents{Database: &falseVal},
+			}
+		}, true},
+		{"S3SecretFound", []runtime.Object{opaqueSecret}, func(a *APIManager) {
+			a.Spec.System = &SystemSpec{FileStorageSpec: &SystemFileStorageSpec{
+				S3: &SystemS3Spec{ConfigurationSecretRef: v1.LocalObjectReference{Name: "opaque-secret"}},
+			}}
+		}, false},
+		{"S3SecretNotFound", nil, func(a *APIManager) {
+			a.Spec.System = &SystemSpec{FileStorageSpec: &SystemFileStorageSpec{
+				S3: &SystemS3Spec{ConfigurationSecretRef: v1.LocalObjectReference{Name: "opaque-secret"}},
+			}}
+		}, true},
+		{"HTTPSCertificateFound", []runtime.Object{tlsSecret}, func(a *APIManager) {
+			a.Spec.Apicast = &ApicastSpec{ProductionSpec: &ApicastProductionSpec{
+				HTTPSCertificateSecretRef: &v1.LocalObjectReference{Name: "tls-secret"},
+			}}
+		}, false},
+		{"HTTPSCertificateNotFound", nil, func(a *APIManager) {
+			a.Spec.Apicast = &ApicastSpec{StagingSpec: &ApicastStagingSpec{
+				HTTPSCertificateSecretRef: &v1.LocalObjectReference{Name: "tls-secret"},
+			}}
+		}, true},
+		{"HTTPSCertificateNotTLS", []runtime.Object{opaqueSecret}, func(a *APIManager) {
+			a.Spec.Apicast = &ApicastSpec{ProductionSpec: &ApicastProductionSpec{
+				HTTPSCertificateSecretRef: &v1.LocalObjectReference{Name: "opaque-secret"},
+			}}
+		}, true},
+		{"IngressTLSSecretNotFound", nil, func(a *APIManager) {
+			a.Spec.Ingress = &IngressSpec{Enabled: true, TLSSecretRef: &v1.LocalObjectReference{Name: "tls-secret"}}
+		}, true},
+		{"CustomPolicySecretNotFound", nil, func(a *APIManager) {
+			a.Spec.Apicast = &ApicastSpec{ProductionSpec: &ApicastProductionSpec{
+				CustomPolicies: []CustomPolicySpec{{Name: "policy", Version: "0.1", SecretRef: &v1.LocalObjectReference{Name: "opaque-secret"}}},
+			}}
+		}, true},
+		{"TracingConfigSecretFound", []runtime.Object{opaqueSecret}, func(a *APIManager) {
+			a.Spec.Apicast = &ApicastSpec{StagingSpec: &ApicastStagingSpec{
+				OpenTracing: &APIcastOpenTracingSpec{Enabled: &trueVal, TracingConfigSecretRef: &v1.LocalObjectReference{Name: "opaque-secret"}},
+			}}
+		}, false},
+		{"TracingConfigSecretNotFound", nil, func(a *APIManager) {
+			a.Spec.Apicast = &ApicastSpec{StagingSpec: &ApicastStagingSpec{
+				OpenTracing: &APIcastOpenTracingSpec{Enabled: &trueVal, TracingConfigSecretRef: &v1.LocalObjectReference{Name: "opaque-secret"}},
+			}}
+		}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apimanager := webhookTestAPIManager()
+			tc.mutate(apimanager)
+
+			err := webhookTestValidator(tc.objs...).ValidateCreate(context.TODO(), apimanager)
+			if tc.expectError && err == nil {
+				subT.Errorf("expected error, got nil")
+			}
+			if !tc.expectError && err != nil {
+				subT.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestAPIManagerValidatorValidateUpdate(t *testing.T) {
+	trueVal := true
+	falseVal := false
+
+	cases := []struct {
+		testName    string
+		mutateOld   func(*APIManager)
+		mutateNew   func(*APIManager)
+		expectError bool
+	}{
+		{"NoChanges", func(a *APIManager) {}, func(a *APIManager) {}, false},
+		{"MySQLToPostgreSQL", func(a *APIManager) {}, func(a *APIManager) {
+			a.Spec.System = &SystemSpec{DatabaseSpec: &SystemDatabaseSpec{PostgreSQL: &SystemPostgreSQLSpec{}}}
+		}, true},
+		{"InternalToExternalSystemDatabase", func(a *APIManager) {}, func(a *APIManager) {
+			a.Spec.ExternalComponents = &ExternalComponentsSpec{System: &ExternalSystemComponents{Database: &trueVal}}
+		}, true},
+		{"HighAvailabilityToExternalComponents", func(a *APIManager) {
+			a.Spec.HighAvailability = &HighAvailabilitySpec{Enabled: true, ExternalZyncDatabaseEnabled: &trueVal}
+		}, func(a *APIManager) {
+			a.Spec.ExternalComponents = AllComponentsExternal()
+		}, false},
+		{"InternalToExternalZyncDatabase", func(a *APIManager) {}, func(a *APIManager) {
+			a.Spec.ExternalComponents = &ExternalComponentsSpec{Zync: &ExternalZyncComponents{Database: &trueVal}}
+		}, true},
+		{"NewExternalComponentsConflict", func(a *APIManager) {
+			a.Spec.HighAvailability = &HighAvailabilitySpec{Enabled: true}
+		}, func(a *APIManager) {
+			a.Spec.HighAvailability = &HighAvailabilitySpec{Enabled: true}
+			a.Spec.ExternalComponents = &ExternalComponentsSpec{
+				System:  &ExternalSystemComponents{Redis: &falseVal, Database: &trueVal},
+				Backend: &ExternalBackendComponents{Redis: &trueVal},
+			}
+		}, true},
+		{"ExistingExternalComponentsConflict", func(a *APIManager) {
+			a.Spec.HighAvailability = &HighAvailabilitySpec{Enabled: true}
+			a.Spec.ExternalComponents = &ExternalComponentsSpec{
+				System:  &ExternalSystemComponents{Redis: &falseVal, Database: &trueVal},
+				Backend: &ExternalBackendComponents{Redis: &trueVal},
+			}
+		}, func(a *APIManager) {
+			a.Spec.HighAvailability = &HighAvailabilitySpec{Enabled: true}
+			a.Spec.ExternalComponents = &ExternalComponentsSpec{
+				System:  &ExternalSystemComponents{Redis: &falseVal, Database: &trueVal},
+				Backend: &ExternalBackendComponents{Redis: &trueVal},
+			}
+		}, false},
+		{"DeletedWithInvalidChange", func(a *APIManager) {}, func(a *APIManager) {
+			a.DeletionTimestamp = &metav1.Time{}
+			a.Spec.System = &SystemSpec{DatabaseSpec: &SystemDatabaseSpec{PostgreSQL: &SystemPostgreSQLSpec{}}}
+		}, false},
+		{"MetadataOnlyWithSecretNotFound", func(a *APIManager) {
+			a.Spec.Ingress = &IngressSpec{Enabled: true, TLSSecretRef: &v1.LocalObjectReference{Name: "tls-secret"}}
+		}, func(a *APIManager) {
+			a.Annotations = map[string]string{"example": "value"}
+			a.Spec.Ingress = &IngressSpec{Enabled: true, TLSSecretRef: &v1.LocalObjectReference{Name: "tls-secret"}}
+		}, false},
+		{"UnchangedSecretNotFound", func(a *APIManager) {
+			a.Spec.Ingress = &IngressSpec{Enabled: true, TLSSecretRef: &v1.LocalObjectReference{Name: "tls-secret"}}
+		}, func(a *APIManager) {
+			a.Spec.WildcardDomain = "other.example.com"
+			a.Spec.Ingress = &IngressSpec{Enabled: true, TLSSecretRef: &v1.LocalObjectReference{Name: "tls-secret"}}
+		}, false},
+		{"ChangedSecretNotFound", func(a *APIManager) {
+			a.Spec.Ingress = &IngressSpec{Enabled: true, TLSSecretRef: &v1.LocalObjectReference{Name: "tls-secret"}}
+		}, func(a *APIManager) {
+			a.Spec.Ingress = &IngressSpec{Enabled: true, TLSSecretRef: &v1.LocalObjectReference{Name: "other-tls-secret"}}
+		}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			oldAPIManager := webhookTestAPIManager()
+			tc.mutateOld(oldAPIManager)
+			apimanager := webhookTestAPIManager()
+			tc.mutateNew(apimanager)
+
+			err := webhookTestValidator().ValidateUpdate(context.TODO(), oldAPIManager, apimanager)
+			if tc.expectError && err == nil {
+				subT.Errorf("expected error, got nil")
+			}
+			if !tc.expectError && err != nil {
+				subT.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
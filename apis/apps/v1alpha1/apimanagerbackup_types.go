@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -30,6 +31,36 @@ type APIManagerBackupSpec struct {
 
 	// Backup data destination configuration
 	BackupDestination APIManagerBackupDestination `json:"backupDestination"`
+
+	// Encryption of the backup data at rest. All the backed up files are
+	// encrypted, except the backup manifest
+	// +optional
+	Encryption *BackupEncryption `json:"encryption,omitempty"`
+}
+
+// BackupEncryption defines the key the backup data is encrypted with.
+// It is a union type. Only one of the fields can be set
+type BackupEncryption struct {
+	// KeySecretRef selects the secret key holding the symmetric key. The files are
+	// encrypted with AES-256, with a key derived from the content of the secret key
+	// +optional
+	KeySecretRef *v1.SecretKeySelector `json:"keySecretRef,omitempty"`
+	// PublicKeySecretRef selects the secret key holding the PEM encoded RSA public key.
+	// Each file is encrypted with AES-256 with a random key, encrypted with the public key
+	// +optional
+	PublicKeySecretRef *v1.SecretKeySelector `json:"publicKeySecretRef,omitempty"`
+}
+
+// BackupDecryption defines the key the backup data is decrypted with.
+// It is a union type. Only one of the fields can be set
+type BackupDecryption struct {
+	// KeySecretRef selects the secret key holding the symmetric key the backup was encrypted with
+	// +optional
+	KeySecretRef *v1.SecretKeySelector `json:"keySecretRef,omitempty"`
+	// PrivateKeySecretRef selects the secret key holding the PEM encoded RSA private key
+	// matching the public key the backup was encrypted with
+	// +optional
+	PrivateKeySecretRef *v1.SecretKeySelector `json:"privateKeySecretRef,omitempty"`
 }
 
 // APIManagerBackupDestination defines the backup data destination
@@ -39,6 +70,9 @@ type APIManagerBackupDestination struct {
 	// PersistentVolumeClaim as backup data destination configuration
 	// +optional
 	PersistentVolumeClaim *PersistentVolumeClaimBackupDestination `json:"persistentVolumeClaim,omitempty"`
+	// S3 compatible object storage as backup data destination configuration
+	// +optional
+	S3 *S3BackupDestination `json:"s3,omitempty"`
 }
 
 // PersistentVolumeClaimBackupDestination defines the configuration
@@ -62,6 +96,45 @@ type PersistentVolumeClaimBackupDestination struct {
 	StorageClass *string `json:"storageClass,omitempty"`
 }
 
+// S3BackupDestination defines the configuration of the S3 compatible
+// object storage to be used as the backup data destination.
+// The backup data is stored under <prefix>/<APIManagerBackup name>
+type S3BackupDestination struct {
+	S3ObjectStorage `json:",inline"`
+}
+
+// S3ObjectStorage defines the location and the access to an S3 compatible
+// object storage holding APIManager backups
+type S3ObjectStorage struct {
+	// Name of the bucket
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`
+	Bucket string `json:"bucket"`
+	// Prefix of the backup object keys. Made of letters, digits and the characters '.', '_', '-' and '/'
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9._/-]*$`
+	// +optional
+	Prefix *string `json:"prefix,omitempty"`
+	// Region of the bucket
+	// +optional
+	Region *string `json:"region,omitempty"`
+	// Endpoint URL of the S3 compatible object storage, e.g. https://minio.example.com:9000.
+	// Defaults to AWS S3
+	// +kubebuilder:validation:Pattern=`^https?://[a-zA-Z0-9.-]+(:[0-9]+)?(/[a-zA-Z0-9._/-]*)?$`
+	// +optional
+	Endpoint *string `json:"endpoint,omitempty"`
+	// ForcePathStyle sends path style requests, with the bucket name in the path.
+	// Usually required by MinIO
+	// +optional
+	ForcePathStyle bool `json:"forcePathStyle,omitempty"`
+	// CredentialsSecretRef references the secret with the AWS_ACCESS_KEY_ID and
+	// AWS_SECRET_ACCESS_KEY fields
+	CredentialsSecretRef v1.LocalObjectReference `json:"credentialsSecretRef"`
+	// CASecretRef selects the secret key holding the CA bundle used to verify
+	// the certificate of the endpoint
+	// +optional
+	CASecretRef *v1.SecretKeySelector `json:"caSecretRef,omitempty"`
+}
+
 // APIManagerBackupStatus defines the observed state of APIManagerBackup
 type APIManagerBackupStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
@@ -71,6 +144,10 @@ type APIManagerBackupStatus struct {
 	// +optional
 	Completed *bool `json:"completed,omitempty"`
 
+	// Set to true when a backup job has failed. The backup is not retried
+	// +optional
+	Failed *bool `json:"failed,omitempty"`
+
 	// Set to true when main steps have been completed. At this point
 	// backup still cannot be considered  fully completed due to some remaining
 	// post-backup tasks are pending (cleanup, ...)
@@ -92,6 +169,11 @@ type APIManagerBackupStatus struct {
 	// PersistentVolumeClaim is used as the backup data destination
 	// +optional
 	BackupPersistentVolumeClaimName *string `json:"backupPersistentVolumeClaimName,omitempty"`
+
+	// URL of the backup data in the object storage, in the s3://<bucket>/<key> form.
+	// Only set when S3 is used as the backup data destination
+	// +optional
+	BackupS3URL *string `json:"backupS3URL,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -120,6 +202,10 @@ func (a *APIManagerBackup) MainStepsCompleted() bool {
 	return a.Status.MainStepsCompleted != nil && *a.Status.MainStepsCompleted
 }
 
+func (a *APIManagerBackup) BackupFailed() bool {
+	return a.Status.Failed != nil && *a.Status.Failed
+}
+
 // +kubebuilder:object:root=true
 
 // APIManagerBackupList contains a list of APIManagerBackup
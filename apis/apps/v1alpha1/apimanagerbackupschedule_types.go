@@ -0,0 +1,141 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// APIManagerBackupScheduleLabel is set on the APIManagerBackups created by an
+	// APIManagerBackupSchedule, with the name of the schedule
+	APIManagerBackupScheduleLabel = "apps.3scale.net/backup-schedule"
+)
+
+// APIManagerBackupScheduleSpec defines the desired state of APIManagerBackupSchedule
+type APIManagerBackupScheduleSpec struct {
+	// Schedule of the backups in cron format, e.g. "0 2 * * *". Evaluated in UTC
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// Suspend stops creating backups. Backups already created are not affected
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// BackupTemplate is the spec of the APIManagerBackups created on schedule
+	BackupTemplate APIManagerBackupSpec `json:"backupTemplate"`
+
+	// Retention policy of the backups created on schedule.
+	// All backups are kept when not set
+	// +optional
+	Retention *APIManagerBackupRetention `json:"retention,omitempty"`
+
+	// SuccessWindow is the maximum time expected between two successful backups,
+	// e.g. "26h". The ThreescaleBackupScheduleNoRecentSuccess alert fires when it is exceeded.
+	// Not monitored when not set
+	// +optional
+	SuccessWindow *metav1.Duration `json:"successWindow,omitempty"`
+
+	// ImageRegistryMirrors rewrites the registry or repository prefix of the image
+	// of the jobs deleting the pruned backup data from the object storage.
+	// Defaults to the mirrors of the APIManager of the namespace, if any
+	// +optional
+	ImageRegistryMirrors []ImageRegistryMirrorSpec `json:"imageRegistryMirrors,omitempty"`
+}
+
+// APIManagerBackupRetention defines which successful backups are kept.
+// A backup is kept when selected by any of the fields. Failed backups are
+// pruned once a later backup succeeds
+type APIManagerBackupRetention struct {
+	// KeepLast keeps the given number of most recent backups
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	KeepLast *int32 `json:"keepLast,omitempty"`
+
+	// KeepDaily keeps the most recent backup of each of the given number
+	// of most recent days with a backup
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	KeepDaily *int32 `json:"keepDaily,omitempty"`
+
+	// KeepWeekly keeps the most recent backup of each of the given number
+	// of most recent weeks with a backup
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	KeepWeekly *int32 `json:"keepWeekly,omitempty"`
+}
+
+// APIManagerBackupScheduleStatus defines the observed state of APIManagerBackupSchedule
+type APIManagerBackupScheduleStatus struct {
+	// Last time a backup was scheduled. It is represented in RFC3339 form and is in UTC.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// Name of the backup in progress
+	// +optional
+	ActiveBackup *string `json:"activeBackup,omitempty"`
+
+	// Last successful backup
+	// +optional
+	LastSuccessfulBackup *APIManagerBackupScheduleRun `json:"lastSuccessfulBackup,omitempty"`
+
+	// Last failed backup
+	// +optional
+	LastFailedBackup *APIManagerBackupScheduleRun `json:"lastFailedBackup,omitempty"`
+
+	// Error parsing the schedule, if any
+	// +optional
+	ScheduleError *string `json:"scheduleError,omitempty"`
+}
+
+// APIManagerBackupScheduleRun identifies a backup created on schedule
+type APIManagerBackupScheduleRun struct {
+	// Name of the APIManagerBackup
+	Name string `json:"name"`
+	// Backup completion time. It is represented in RFC3339 form and is in UTC.
+	CompletionTime metav1.Time `json:"completionTime"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// APIManagerBackupSchedule represents scheduled APIManager backups
+// +kubebuilder:resource:path=apimanagerbackupschedules,scope=Namespaced
+// +kubebuilder:printcolumn:JSONPath=".spec.schedule",name=Schedule,type=string
+// +kubebuilder:printcolumn:JSONPath=".spec.suspend",name=Suspend,type=boolean
+// +kubebuilder:printcolumn:JSONPath=".status.lastSuccessfulBackup.completionTime",name="Last Success",type=date
+// +operator-sdk:csv:customresourcedefinitions:displayName="APIManagerBackupSchedule"
+type APIManagerBackupSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   APIManagerBackupScheduleSpec   `json:"spec,omitempty"`
+	Status APIManagerBackupScheduleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// APIManagerBackupScheduleList contains a list of APIManagerBackupSchedule
+type APIManagerBackupScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []APIManagerBackupSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&APIManagerBackupSchedule{}, &APIManagerBackupScheduleList{})
+}
@@ -30,6 +30,22 @@ type APIManagerRestoreSpec struct {
 	// Important: Run "make" to regenerate code after modifying this file
 
 	RestoreSource APIManagerRestoreSource `json:"restoreSource"`
+
+	// Decryption of the backup data, required when the backup is encrypted
+	// +optional
+	Decryption *BackupDecryption `json:"decryption,omitempty"`
+
+	// Restore the backup even when it was taken by a different operator minor
+	// version or 3scale release, or when it has no manifest. Backed up files not
+	// matching the checksums of the manifest always fail the restore
+	// +optional
+	AllowIncompatibleBackup *bool `json:"allowIncompatibleBackup,omitempty"`
+
+	// ImageRegistryMirrors rewrites the registry or repository prefix of the images
+	// of the restore jobs, which run before the APIManager is restored.
+	// Defaults to the mirrors of the APIManager of the namespace, if any
+	// +optional
+	ImageRegistryMirrors []ImageRegistryMirrorSpec `json:"imageRegistryMirrors,omitempty"`
 }
 
 // APIManagerRestoreSource defines the backup data restore source
@@ -39,6 +55,9 @@ type APIManagerRestoreSource struct {
 	// +optional
 	// Restore data soure configuration
 	PersistentVolumeClaim *PersistentVolumeClaimRestoreSource `json:"persistentVolumeClaim,omitempty"`
+	// +optional
+	// S3 compatible object storage restore data source configuration
+	S3 *S3RestoreSource `json:"s3,omitempty"`
 }
 
 // PersistentVolumeClaimRestoreSource defines the configuration
@@ -50,6 +69,16 @@ type PersistentVolumeClaimRestoreSource struct {
 	ClaimSource v1.PersistentVolumeClaimVolumeSource `json:"claimSource"`
 }
 
+// S3RestoreSource defines the configuration of the S3 compatible
+// object storage to be used as the restore data source. The backup data
+// is read from <prefix>/<backupName>, as written by the APIManagerBackup
+type S3RestoreSource struct {
+	S3ObjectStorage `json:",inline"`
+	// Name of the APIManagerBackup that wrote the backup data
+	// +kubebuilder:validation:MinLength=1
+	BackupName string `json:"backupName"`
+}
+
 // APIManagerRestoreStatus defines the observed state of APIManagerRestore
 type APIManagerRestoreStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
@@ -63,6 +92,14 @@ type APIManagerRestoreStatus struct {
 	// +optional
 	Completed *bool `json:"completed,omitempty"`
 
+	// Set to true when the restore has failed. The restore is not retried
+	// +optional
+	Failed *bool `json:"failed,omitempty"`
+
+	// Verification of the backup manifest, performed before any data is restored
+	// +optional
+	BackupVerification *APIManagerRestoreBackupVerification `json:"backupVerification,omitempty"`
+
 	// Set to true when main steps have been completed. At this point
 	// restore still cannot be considered fully completed due to some remaining
 	// post-backup tasks are pending (cleanup, ...)
@@ -77,6 +114,50 @@ type APIManagerRestoreStatus struct {
 	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 }
 
+// BackupVerificationResult is the result of the backup manifest verification
+type BackupVerificationResult string
+
+const (
+	// The checksums match and the backup is compatible with the operator
+	BackupVerificationVerified BackupVerificationResult = "Verified"
+	// The checksums match, but the backup is incompatible with the operator
+	// or has no manifest. Restored because allowIncompatibleBackup is set
+	BackupVerificationOverridden BackupVerificationResult = "Overridden"
+	// The backup is not restored
+	BackupVerificationFailed BackupVerificationResult = "Failed"
+)
+
+// APIManagerRestoreBackupVerification reports the verification of the
+// manifest written by the APIManagerBackup
+type APIManagerRestoreBackupVerification struct {
+	// Verification result
+	Result BackupVerificationResult `json:"result"`
+
+	// Human readable description of the result
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Version of the operator that took the backup
+	// +optional
+	OperatorVersion string `json:"operatorVersion,omitempty"`
+
+	// 3scale release of the backed up APIManager
+	// +optional
+	ThreescaleRelease string `json:"threescaleRelease,omitempty"`
+
+	// SHA-256 hash of the backed up APIManager spec
+	// +optional
+	APIManagerSpecHash string `json:"apiManagerSpecHash,omitempty"`
+
+	// Number of backed up files matching their checksum
+	// +optional
+	VerifiedFiles int32 `json:"verifiedFiles,omitempty"`
+
+	// Backed up files missing or not matching their checksum. At most 10 are reported
+	// +optional
+	InvalidFiles []string `json:"invalidFiles,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 
@@ -99,6 +180,14 @@ func (a *APIManagerRestore) RestoreCompleted() bool {
 	return a.Status.Completed != nil && *a.Status.Completed
 }
 
+func (a *APIManagerRestore) RestoreFailed() bool {
+	return a.Status.Failed != nil && *a.Status.Failed
+}
+
+func (a *APIManagerRestore) IncompatibleBackupAllowed() bool {
+	return a.Spec.AllowIncompatibleBackup != nil && *a.Spec.AllowIncompatibleBackup
+}
+
 func (a *APIManagerRestore) MainStepsCompleted() bool {
 	return a.Status.MainStepsCompleted != nil && *a.Status.MainStepsCompleted
 }
@@ -24,6 +24,7 @@ package v1alpha1
 import (
 	"github.com/3scale/3scale-operator/pkg/apispkg/common"
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -89,6 +90,11 @@ func (in *APIManagerBackupDestination) DeepCopyInto(out *APIManagerBackupDestina
 		*out = new(PersistentVolumeClaimBackupDestination)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3BackupDestination)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupDestination.
@@ -133,10 +139,190 @@ func (in *APIManagerBackupList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerBackupRetention) DeepCopyInto(out *APIManagerBackupRetention) {
+	*out = *in
+	if in.KeepLast != nil {
+		in, out := &in.KeepLast, &out.KeepLast
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeepDaily != nil {
+		in, out := &in.KeepDaily, &out.KeepDaily
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeepWeekly != nil {
+		in, out := &in.KeepWeekly, &out.KeepWeekly
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupRetention.
+func (in *APIManagerBackupRetention) DeepCopy() *APIManagerBackupRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerBackupRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerBackupSchedule) DeepCopyInto(out *APIManagerBackupSchedule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupSchedule.
+func (in *APIManagerBackupSchedule) DeepCopy() *APIManagerBackupSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerBackupSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *APIManagerBackupSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerBackupScheduleList) DeepCopyInto(out *APIManagerBackupScheduleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]APIManagerBackupSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupScheduleList.
+func (in *APIManagerBackupScheduleList) DeepCopy() *APIManagerBackupScheduleList {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerBackupScheduleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *APIManagerBackupScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerBackupScheduleRun) DeepCopyInto(out *APIManagerBackupScheduleRun) {
+	*out = *in
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupScheduleRun.
+func (in *APIManagerBackupScheduleRun) DeepCopy() *APIManagerBackupScheduleRun {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerBackupScheduleRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerBackupScheduleSpec) DeepCopyInto(out *APIManagerBackupScheduleSpec) {
+	*out = *in
+	in.BackupTemplate.DeepCopyInto(&out.BackupTemplate)
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(APIManagerBackupRetention)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SuccessWindow != nil {
+		in, out := &in.SuccessWindow, &out.SuccessWindow
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ImageRegistryMirrors != nil {
+		in, out := &in.ImageRegistryMirrors, &out.ImageRegistryMirrors
+		*out = make([]ImageRegistryMirrorSpec, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupScheduleSpec.
+func (in *APIManagerBackupScheduleSpec) DeepCopy() *APIManagerBackupScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerBackupScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerBackupScheduleStatus) DeepCopyInto(out *APIManagerBackupScheduleStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ActiveBackup != nil {
+		in, out := &in.ActiveBackup, &out.ActiveBackup
+		*out = new(string)
+		**out = **in
+	}
+	if in.LastSuccessfulBackup != nil {
+		in, out := &in.LastSuccessfulBackup, &out.LastSuccessfulBackup
+		*out = new(APIManagerBackupScheduleRun)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastFailedBackup != nil {
+		in, out := &in.LastFailedBackup, &out.LastFailedBackup
+		*out = new(APIManagerBackupScheduleRun)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScheduleError != nil {
+		in, out := &in.ScheduleError, &out.ScheduleError
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupScheduleStatus.
+func (in *APIManagerBackupScheduleStatus) DeepCopy() *APIManagerBackupScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerBackupScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIManagerBackupSpec) DeepCopyInto(out *APIManagerBackupSpec) {
 	*out = *in
 	in.BackupDestination.DeepCopyInto(&out.BackupDestination)
+	if in.Encryption != nil {
+		in, out := &in.Encryption, &out.Encryption
+		*out = new(BackupEncryption)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupSpec.
@@ -157,6 +343,11 @@ func (in *APIManagerBackupStatus) DeepCopyInto(out *APIManagerBackupStatus) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Failed != nil {
+		in, out := &in.Failed, &out.Failed
+		*out = new(bool)
+		**out = **in
+	}
 	if in.MainStepsCompleted != nil {
 		in, out := &in.MainStepsCompleted, &out.MainStepsCompleted
 		*out = new(bool)
@@ -180,6 +371,11 @@ func (in *APIManagerBackupStatus) DeepCopyInto(out *APIManagerBackupStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.BackupS3URL != nil {
+		in, out := &in.BackupS3URL, &out.BackupS3URL
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerBackupStatus.
@@ -220,6 +416,16 @@ func (in *APIManagerCommonSpec) DeepCopyInto(out *APIManagerCommonSpec) {
 		*out = make([]v1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.ImageRegistryMirrors != nil {
+		in, out := &in.ImageRegistryMirrors, &out.ImageRegistryMirrors
+		*out = make([]ImageRegistryMirrorSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImageDigestPinningEnabled != nil {
+		in, out := &in.ImageDigestPinningEnabled, &out.ImageDigestPinningEnabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerCommonSpec.
@@ -291,6 +497,26 @@ func (in *APIManagerRestore) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIManagerRestoreBackupVerification) DeepCopyInto(out *APIManagerRestoreBackupVerification) {
+	*out = *in
+	if in.InvalidFiles != nil {
+		in, out := &in.InvalidFiles, &out.InvalidFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerRestoreBackupVerification.
+func (in *APIManagerRestoreBackupVerification) DeepCopy() *APIManagerRestoreBackupVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(APIManagerRestoreBackupVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIManagerRestoreList) DeepCopyInto(out *APIManagerRestoreList) {
 	*out = *in
@@ -331,6 +557,11 @@ func (in *APIManagerRestoreSource) DeepCopyInto(out *APIManagerRestoreSource) {
 		*out = new(PersistentVolumeClaimRestoreSource)
 		**out = **in
 	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3RestoreSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerRestoreSource.
@@ -347,6 +578,21 @@ func (in *APIManagerRestoreSource) DeepCopy() *APIManagerRestoreSource {
 func (in *APIManagerRestoreSpec) DeepCopyInto(out *APIManagerRestoreSpec) {
 	*out = *in
 	in.RestoreSource.DeepCopyInto(&out.RestoreSource)
+	if in.Decryption != nil {
+		in, out := &in.Decryption, &out.Decryption
+		*out = new(BackupDecryption)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowIncompatibleBackup != nil {
+		in, out := &in.AllowIncompatibleBackup, &out.AllowIncompatibleBackup
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ImageRegistryMirrors != nil {
+		in, out := &in.ImageRegistryMirrors, &out.ImageRegistryMirrors
+		*out = make([]ImageRegistryMirrorSpec, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerRestoreSpec.
@@ -372,6 +618,16 @@ func (in *APIManagerRestoreStatus) DeepCopyInto(out *APIManagerRestoreStatus) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Failed != nil {
+		in, out := &in.Failed, &out.Failed
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BackupVerification != nil {
+		in, out := &in.BackupVerification, &out.BackupVerification
+		*out = new(APIManagerRestoreBackupVerification)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MainStepsCompleted != nil {
 		in, out := &in.MainStepsCompleted, &out.MainStepsCompleted
 		*out = new(bool)
@@ -441,6 +697,31 @@ func (in *APIManagerSpec) DeepCopyInto(out *APIManagerSpec) {
 		*out = new(MonitoringSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DeploymentType != nil {
+		in, out := &in.DeploymentType, &out.DeploymentType
+		*out = new(string)
+		**out = **in
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(IngressSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicies != nil {
+		in, out := &in.NetworkPolicies, &out.NetworkPolicies
+		*out = new(NetworkPoliciesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretRotation != nil {
+		in, out := &in.SecretRotation, &out.SecretRotation
+		*out = new(SecretRotationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(CertManagerSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerSpec.
@@ -464,6 +745,28 @@ func (in *APIManagerStatus) DeepCopyInto(out *APIManagerStatus) {
 		}
 	}
 	in.Deployments.DeepCopyInto(&out.Deployments)
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]ComponentImage, len(*in))
+		copy(*out, *in)
+	}
+	if in.PinnedImages != nil {
+		in, out := &in.PinnedImages, &out.PinnedImages
+		*out = make([]PinnedImage, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecretRotation != nil {
+		in, out := &in.SecretRotation, &out.SecretRotation
+		*out = new(SecretRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]CertificateStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIManagerStatus.
@@ -514,6 +817,11 @@ func (in *ApicastProductionSpec) DeepCopyInto(out *ApicastProductionSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.HPA != nil {
+		in, out := &in.HPA, &out.HPA
+		*out = new(HorizontalPodAutoscalerSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Affinity != nil {
 		in, out := &in.Affinity, &out.Affinity
 		*out = new(v1.Affinity)
@@ -626,6 +934,7 @@ func (in *ApicastProductionSpec) DeepCopyInto(out *ApicastProductionSpec) {
 			(*out)[key] = val
 		}
 	}
+	in.PodCustomizationSpec.DeepCopyInto(&out.PodCustomizationSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicastProductionSpec.
@@ -803,6 +1112,7 @@ func (in *ApicastStagingSpec) DeepCopyInto(out *ApicastStagingSpec) {
 			(*out)[key] = val
 		}
 	}
+	in.PodCustomizationSpec.DeepCopyInto(&out.PodCustomizationSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApicastStagingSpec.
@@ -866,6 +1176,7 @@ func (in *BackendCronSpec) DeepCopyInto(out *BackendCronSpec) {
 			(*out)[key] = val
 		}
 	}
+	in.PodCustomizationSpec.DeepCopyInto(&out.PodCustomizationSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendCronSpec.
@@ -886,6 +1197,11 @@ func (in *BackendListenerSpec) DeepCopyInto(out *BackendListenerSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.HPA != nil {
+		in, out := &in.HPA, &out.HPA
+		*out = new(HorizontalPodAutoscalerSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Affinity != nil {
 		in, out := &in.Affinity, &out.Affinity
 		*out = new(v1.Affinity)
@@ -929,6 +1245,7 @@ func (in *BackendListenerSpec) DeepCopyInto(out *BackendListenerSpec) {
 			(*out)[key] = val
 		}
 	}
+	in.PodCustomizationSpec.DeepCopyInto(&out.PodCustomizationSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendListenerSpec.
@@ -1057,6 +1374,11 @@ func (in *BackendWorkerSpec) DeepCopyInto(out *BackendWorkerSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.HPA != nil {
+		in, out := &in.HPA, &out.HPA
+		*out = new(HorizontalPodAutoscalerSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Affinity != nil {
 		in, out := &in.Affinity, &out.Affinity
 		*out = new(v1.Affinity)
@@ -1100,6 +1422,7 @@ func (in *BackendWorkerSpec) DeepCopyInto(out *BackendWorkerSpec) {
 			(*out)[key] = val
 		}
 	}
+	in.PodCustomizationSpec.DeepCopyInto(&out.PodCustomizationSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendWorkerSpec.
@@ -1113,124 +1436,247 @@ func (in *BackendWorkerSpec) DeepCopy() *BackendWorkerSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CustomEnvironmentSpec) DeepCopyInto(out *CustomEnvironmentSpec) {
+func (in *BackupDecryption) DeepCopyInto(out *BackupDecryption) {
 	*out = *in
-	if in.SecretRef != nil {
-		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(v1.LocalObjectReference)
-		**out = **in
+	if in.KeySecretRef != nil {
+		in, out := &in.KeySecretRef, &out.KeySecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrivateKeySecretRef != nil {
+		in, out := &in.PrivateKeySecretRef, &out.PrivateKeySecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomEnvironmentSpec.
-func (in *CustomEnvironmentSpec) DeepCopy() *CustomEnvironmentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupDecryption.
+func (in *BackupDecryption) DeepCopy() *BackupDecryption {
 	if in == nil {
 		return nil
 	}
-	out := new(CustomEnvironmentSpec)
+	out := new(BackupDecryption)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CustomPolicySpec) DeepCopyInto(out *CustomPolicySpec) {
+func (in *BackupEncryption) DeepCopyInto(out *BackupEncryption) {
 	*out = *in
-	if in.SecretRef != nil {
-		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(v1.LocalObjectReference)
-		**out = **in
+	if in.KeySecretRef != nil {
+		in, out := &in.KeySecretRef, &out.KeySecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PublicKeySecretRef != nil {
+		in, out := &in.PublicKeySecretRef, &out.PublicKeySecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomPolicySpec.
-func (in *CustomPolicySpec) DeepCopy() *CustomPolicySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupEncryption.
+func (in *BackupEncryption) DeepCopy() *BackupEncryption {
 	if in == nil {
 		return nil
 	}
-	out := new(CustomPolicySpec)
+	out := new(BackupEncryption)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeprecatedSystemS3Spec) DeepCopyInto(out *DeprecatedSystemS3Spec) {
+func (in *CertManagerIssuerReference) DeepCopyInto(out *CertManagerIssuerReference) {
 	*out = *in
-	out.AWSCredentials = in.AWSCredentials
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeprecatedSystemS3Spec.
-func (in *DeprecatedSystemS3Spec) DeepCopy() *DeprecatedSystemS3Spec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuerReference.
+func (in *CertManagerIssuerReference) DeepCopy() *CertManagerIssuerReference {
 	if in == nil {
 		return nil
 	}
-	out := new(DeprecatedSystemS3Spec)
+	out := new(CertManagerIssuerReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExternalBackendComponents) DeepCopyInto(out *ExternalBackendComponents) {
+func (in *CertManagerSpec) DeepCopyInto(out *CertManagerSpec) {
 	*out = *in
-	if in.Redis != nil {
-		in, out := &in.Redis, &out.Redis
-		*out = new(bool)
+	if in.IssuerRef != nil {
+		in, out := &in.IssuerRef, &out.IssuerRef
+		*out = new(CertManagerIssuerReference)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalBackendComponents.
-func (in *ExternalBackendComponents) DeepCopy() *ExternalBackendComponents {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerSpec.
+func (in *CertManagerSpec) DeepCopy() *CertManagerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ExternalBackendComponents)
+	out := new(CertManagerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExternalComponentsSpec) DeepCopyInto(out *ExternalComponentsSpec) {
+func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
 	*out = *in
-	if in.System != nil {
-		in, out := &in.System, &out.System
-		*out = new(ExternalSystemComponents)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Backend != nil {
-		in, out := &in.Backend, &out.Backend
-		*out = new(ExternalBackendComponents)
-		(*in).DeepCopyInto(*out)
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
 	}
-	if in.Zync != nil {
-		in, out := &in.Zync, &out.Zync
-		*out = new(ExternalZyncComponents)
-		(*in).DeepCopyInto(*out)
+	if in.RenewalTime != nil {
+		in, out := &in.RenewalTime, &out.RenewalTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalComponentsSpec.
-func (in *ExternalComponentsSpec) DeepCopy() *ExternalComponentsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateStatus.
+func (in *CertificateStatus) DeepCopy() *CertificateStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ExternalComponentsSpec)
+	out := new(CertificateStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExternalSystemComponents) DeepCopyInto(out *ExternalSystemComponents) {
+func (in *ComponentImage) DeepCopyInto(out *ComponentImage) {
 	*out = *in
-	if in.Redis != nil {
-		in, out := &in.Redis, &out.Redis
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Database != nil {
-		in, out := &in.Database, &out.Database
-		*out = new(bool)
-		**out = **in
-	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentImage.
+func (in *ComponentImage) DeepCopy() *ComponentImage {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomEnvironmentSpec) DeepCopyInto(out *CustomEnvironmentSpec) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomEnvironmentSpec.
+func (in *CustomEnvironmentSpec) DeepCopy() *CustomEnvironmentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomEnvironmentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomPolicySpec) DeepCopyInto(out *CustomPolicySpec) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomPolicySpec.
+func (in *CustomPolicySpec) DeepCopy() *CustomPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeprecatedSystemS3Spec) DeepCopyInto(out *DeprecatedSystemS3Spec) {
+	*out = *in
+	out.AWSCredentials = in.AWSCredentials
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeprecatedSystemS3Spec.
+func (in *DeprecatedSystemS3Spec) DeepCopy() *DeprecatedSystemS3Spec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeprecatedSystemS3Spec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalBackendComponents) DeepCopyInto(out *ExternalBackendComponents) {
+	*out = *in
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalBackendComponents.
+func (in *ExternalBackendComponents) DeepCopy() *ExternalBackendComponents {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalBackendComponents)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalComponentsSpec) DeepCopyInto(out *ExternalComponentsSpec) {
+	*out = *in
+	if in.System != nil {
+		in, out := &in.System, &out.System
+		*out = new(ExternalSystemComponents)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Backend != nil {
+		in, out := &in.Backend, &out.Backend
+		*out = new(ExternalBackendComponents)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Zync != nil {
+		in, out := &in.Zync, &out.Zync
+		*out = new(ExternalZyncComponents)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalComponentsSpec.
+func (in *ExternalComponentsSpec) DeepCopy() *ExternalComponentsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalComponentsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSystemComponents) DeepCopyInto(out *ExternalSystemComponents) {
+	*out = *in
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Database != nil {
+		in, out := &in.Database, &out.Database
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSystemComponents.
@@ -1283,6 +1729,111 @@ func (in *HighAvailabilitySpec) DeepCopy() *HighAvailabilitySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizontalPodAutoscalerPodsMetricSpec) DeepCopyInto(out *HorizontalPodAutoscalerPodsMetricSpec) {
+	*out = *in
+	out.AverageValue = in.AverageValue.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizontalPodAutoscalerPodsMetricSpec.
+func (in *HorizontalPodAutoscalerPodsMetricSpec) DeepCopy() *HorizontalPodAutoscalerPodsMetricSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizontalPodAutoscalerPodsMetricSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizontalPodAutoscalerSpec) DeepCopyInto(out *HorizontalPodAutoscalerSpec) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CPUAverageUtilization != nil {
+		in, out := &in.CPUAverageUtilization, &out.CPUAverageUtilization
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MemoryAverageUtilization != nil {
+		in, out := &in.MemoryAverageUtilization, &out.MemoryAverageUtilization
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PodsMetrics != nil {
+		in, out := &in.PodsMetrics, &out.PodsMetrics
+		*out = make([]HorizontalPodAutoscalerPodsMetricSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizontalPodAutoscalerSpec.
+func (in *HorizontalPodAutoscalerSpec) DeepCopy() *HorizontalPodAutoscalerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizontalPodAutoscalerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageRegistryMirrorSpec) DeepCopyInto(out *ImageRegistryMirrorSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageRegistryMirrorSpec.
+func (in *ImageRegistryMirrorSpec) DeepCopy() *ImageRegistryMirrorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageRegistryMirrorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressSpec) DeepCopyInto(out *IngressSpec) {
+	*out = *in
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.TLSSecretRef != nil {
+		in, out := &in.TLSSecretRef, &out.TLSSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ControllerNamespace != nil {
+		in, out := &in.ControllerNamespace, &out.ControllerNamespace
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressSpec.
+func (in *IngressSpec) DeepCopy() *IngressSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MonitoringSpec) DeepCopyInto(out *MonitoringSpec) {
 	*out = *in
@@ -1303,6 +1854,26 @@ func (in *MonitoringSpec) DeepCopy() *MonitoringSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPoliciesSpec) DeepCopyInto(out *NetworkPoliciesSpec) {
+	*out = *in
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPoliciesSpec.
+func (in *NetworkPoliciesSpec) DeepCopy() *NetworkPoliciesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPoliciesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PVCGenericSpec) DeepCopyInto(out *PVCGenericSpec) {
 	*out = *in
@@ -1395,6 +1966,96 @@ func (in *PersistentVolumeClaimRestoreSource) DeepCopy() *PersistentVolumeClaimR
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PinnedImage) DeepCopyInto(out *PinnedImage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PinnedImage.
+func (in *PinnedImage) DeepCopy() *PinnedImage {
+	if in == nil {
+		return nil
+	}
+	out := new(PinnedImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodCustomizationSpec) DeepCopyInto(out *PodCustomizationSpec) {
+	*out = *in
+	if in.ExtraEnv != nil {
+		in, out := &in.ExtraEnv, &out.ExtraEnv
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraVolumes != nil {
+		in, out := &in.ExtraVolumes, &out.ExtraVolumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraVolumeMounts != nil {
+		in, out := &in.ExtraVolumeMounts, &out.ExtraVolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(v1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(v1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(v1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCustomizationSpec.
+func (in *PodCustomizationSpec) DeepCopy() *PodCustomizationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodCustomizationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodDisruptionBudgetSpec) DeepCopyInto(out *PodDisruptionBudgetSpec) {
 	*out = *in
@@ -1410,6 +2071,74 @@ func (in *PodDisruptionBudgetSpec) DeepCopy() *PodDisruptionBudgetSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3BackupDestination) DeepCopyInto(out *S3BackupDestination) {
+	*out = *in
+	in.S3ObjectStorage.DeepCopyInto(&out.S3ObjectStorage)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3BackupDestination.
+func (in *S3BackupDestination) DeepCopy() *S3BackupDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(S3BackupDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3ObjectStorage) DeepCopyInto(out *S3ObjectStorage) {
+	*out = *in
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.Region != nil {
+		in, out := &in.Region, &out.Region
+		*out = new(string)
+		**out = **in
+	}
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(string)
+		**out = **in
+	}
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.CASecretRef != nil {
+		in, out := &in.CASecretRef, &out.CASecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3ObjectStorage.
+func (in *S3ObjectStorage) DeepCopy() *S3ObjectStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(S3ObjectStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3RestoreSource) DeepCopyInto(out *S3RestoreSource) {
+	*out = *in
+	in.S3ObjectStorage.DeepCopyInto(&out.S3ObjectStorage)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3RestoreSource.
+func (in *S3RestoreSource) DeepCopy() *S3RestoreSource {
+	if in == nil {
+		return nil
+	}
+	out := new(S3RestoreSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *STSSpec) DeepCopyInto(out *STSSpec) {
 	*out = *in
@@ -1435,6 +2164,69 @@ func (in *STSSpec) DeepCopy() *STSSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretRotationSpec) DeepCopyInto(out *SecretRotationSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretRotationSpec.
+func (in *SecretRotationSpec) DeepCopy() *SecretRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretRotationStatus) DeepCopyInto(out *SecretRotationStatus) {
+	*out = *in
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRotationTime != nil {
+		in, out := &in.NextRotationTime, &out.NextRotationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretRotationStatus.
+func (in *SecretRotationStatus) DeepCopy() *SecretRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SidekiqQueueSpec) DeepCopyInto(out *SidekiqQueueSpec) {
+	*out = *in
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidekiqQueueSpec.
+func (in *SidekiqQueueSpec) DeepCopy() *SidekiqQueueSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SidekiqQueueSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SystemAppSpec) DeepCopyInto(out *SystemAppSpec) {
 	*out = *in
@@ -1443,6 +2235,11 @@ func (in *SystemAppSpec) DeepCopyInto(out *SystemAppSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.HPA != nil {
+		in, out := &in.HPA, &out.HPA
+		*out = new(HorizontalPodAutoscalerSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Affinity != nil {
 		in, out := &in.Affinity, &out.Affinity
 		*out = new(v1.Affinity)
@@ -1470,6 +2267,11 @@ func (in *SystemAppSpec) DeepCopyInto(out *SystemAppSpec) {
 		*out = new(v1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SplitDeployments != nil {
+		in, out := &in.SplitDeployments, &out.SplitDeployments
+		*out = new(SystemAppSplitDeploymentsSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PriorityClassName != nil {
 		in, out := &in.PriorityClassName, &out.PriorityClassName
 		*out = new(string)
@@ -1496,6 +2298,7 @@ func (in *SystemAppSpec) DeepCopyInto(out *SystemAppSpec) {
 			(*out)[key] = val
 		}
 	}
+	in.PodCustomizationSpec.DeepCopyInto(&out.PodCustomizationSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemAppSpec.
@@ -1508,6 +2311,36 @@ func (in *SystemAppSpec) DeepCopy() *SystemAppSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SystemAppSplitDeploymentsSpec) DeepCopyInto(out *SystemAppSplitDeploymentsSpec) {
+	*out = *in
+	if in.MasterReplicas != nil {
+		in, out := &in.MasterReplicas, &out.MasterReplicas
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ProviderReplicas != nil {
+		in, out := &in.ProviderReplicas, &out.ProviderReplicas
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DeveloperReplicas != nil {
+		in, out := &in.DeveloperReplicas, &out.DeveloperReplicas
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemAppSplitDeploymentsSpec.
+func (in *SystemAppSplitDeploymentsSpec) DeepCopy() *SystemAppSplitDeploymentsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SystemAppSplitDeploymentsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SystemDatabaseSpec) DeepCopyInto(out *SystemDatabaseSpec) {
 	*out = *in
@@ -1533,6 +2366,27 @@ func (in *SystemDatabaseSpec) DeepCopy() *SystemDatabaseSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SystemEventsHookSpec) DeepCopyInto(out *SystemEventsHookSpec) {
+	*out = *in
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(string)
+		**out = **in
+	}
+	in.PasswordSecretRef.DeepCopyInto(&out.PasswordSecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemEventsHookSpec.
+func (in *SystemEventsHookSpec) DeepCopy() *SystemEventsHookSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SystemEventsHookSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SystemFileStorageSpec) DeepCopyInto(out *SystemFileStorageSpec) {
 	*out = *in
@@ -1699,6 +2553,22 @@ func (in *SystemPostgreSQLSpec) DeepCopy() *SystemPostgreSQLSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SystemRecaptchaSpec) DeepCopyInto(out *SystemRecaptchaSpec) {
+	*out = *in
+	in.PrivateKeySecretRef.DeepCopyInto(&out.PrivateKeySecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemRecaptchaSpec.
+func (in *SystemRecaptchaSpec) DeepCopy() *SystemRecaptchaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SystemRecaptchaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SystemRedisPersistentVolumeClaimSpec) DeepCopyInto(out *SystemRedisPersistentVolumeClaimSpec) {
 	*out = *in
@@ -1740,6 +2610,56 @@ func (in *SystemS3Spec) DeepCopy() *SystemS3Spec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SystemSMTPSpec) DeepCopyInto(out *SystemSMTPSpec) {
+	*out = *in
+	if in.Domain != nil {
+		in, out := &in.Domain, &out.Domain
+		*out = new(string)
+		**out = **in
+	}
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(string)
+		**out = **in
+	}
+	if in.Username != nil {
+		in, out := &in.Username, &out.Username
+		*out = new(string)
+		**out = **in
+	}
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSMode != nil {
+		in, out := &in.TLSMode, &out.TLSMode
+		*out = new(SMTPTLSMode)
+		**out = **in
+	}
+	if in.OpenSSLVerifyMode != nil {
+		in, out := &in.OpenSSLVerifyMode, &out.OpenSSLVerifyMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.FromAddress != nil {
+		in, out := &in.FromAddress, &out.FromAddress
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemSMTPSpec.
+func (in *SystemSMTPSpec) DeepCopy() *SystemSMTPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SystemSMTPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SystemSearchdSpec) DeepCopyInto(out *SystemSearchdSpec) {
 	*out = *in
@@ -1796,6 +2716,7 @@ func (in *SystemSearchdSpec) DeepCopyInto(out *SystemSearchdSpec) {
 			(*out)[key] = val
 		}
 	}
+	in.PodCustomizationSpec.DeepCopyInto(&out.PodCustomizationSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemSearchdSpec.
@@ -1808,6 +2729,76 @@ func (in *SystemSearchdSpec) DeepCopy() *SystemSearchdSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SystemSidekiqPoolSpec) DeepCopyInto(out *SystemSidekiqPoolSpec) {
+	*out = *in
+	if in.Queues != nil {
+		in, out := &in.Queues, &out.Queues
+		*out = make([]SidekiqQueueSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PriorityClassName != nil {
+		in, out := &in.PriorityClassName, &out.PriorityClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemSidekiqPoolSpec.
+func (in *SystemSidekiqPoolSpec) DeepCopy() *SystemSidekiqPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SystemSidekiqPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SystemSidekiqSpec) DeepCopyInto(out *SystemSidekiqSpec) {
 	*out = *in
@@ -1859,6 +2850,7 @@ func (in *SystemSidekiqSpec) DeepCopyInto(out *SystemSidekiqSpec) {
 			(*out)[key] = val
 		}
 	}
+	in.PodCustomizationSpec.DeepCopyInto(&out.PodCustomizationSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemSidekiqSpec.
@@ -2000,6 +2992,13 @@ func (in *SystemSpec) DeepCopyInto(out *SystemSpec) {
 		*out = new(SystemSidekiqSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SidekiqPools != nil {
+		in, out := &in.SidekiqPools, &out.SidekiqPools
+		*out = make([]SystemSidekiqPoolSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.SphinxSpec != nil {
 		in, out := &in.SphinxSpec, &out.SphinxSpec
 		*out = new(SystemSphinxSpec)
@@ -2010,6 +3009,21 @@ func (in *SystemSpec) DeepCopyInto(out *SystemSpec) {
 		*out = new(SystemSearchdSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SMTPSpec != nil {
+		in, out := &in.SMTPSpec, &out.SMTPSpec
+		*out = new(SystemSMTPSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RecaptchaSpec != nil {
+		in, out := &in.RecaptchaSpec, &out.RecaptchaSpec
+		*out = new(SystemRecaptchaSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EventsHookSpec != nil {
+		in, out := &in.EventsHookSpec, &out.EventsHookSpec
+		*out = new(SystemEventsHookSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SystemSpec.
@@ -2117,6 +3131,7 @@ func (in *ZyncAppSpec) DeepCopyInto(out *ZyncAppSpec) {
 			(*out)[key] = val
 		}
 	}
+	in.PodCustomizationSpec.DeepCopyInto(&out.PodCustomizationSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZyncAppSpec.
@@ -2180,6 +3195,7 @@ func (in *ZyncQueSpec) DeepCopyInto(out *ZyncQueSpec) {
 			(*out)[key] = val
 		}
 	}
+	in.PodCustomizationSpec.DeepCopyInto(&out.PodCustomizationSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZyncQueSpec.
@@ -0,0 +1,164 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	AccountPlanKind = "AccountPlan"
+
+	// AccountPlanInvalidConditionType represents that the combination of configuration
+	// in the AccountPlanSpec is not supported. This is not a transient error, but
+	// indicates a state that must be fixed before progress can be made.
+	AccountPlanInvalidConditionType common.ConditionType = "Invalid"
+
+	// AccountPlanReadyConditionType indicates the account plan has been successfully synchronized.
+	// Steady state
+	AccountPlanReadyConditionType common.ConditionType = "Ready"
+
+	// AccountPlanFailedConditionType indicates that an error occurred during synchronization.
+	// The operator will retry.
+	AccountPlanFailedConditionType common.ConditionType = "Failed"
+)
+
+// AccountPlanSpec defines the desired state of AccountPlan
+type AccountPlanSpec struct {
+	// SystemName identifies uniquely the account plan within the account provider.
+	// It cannot be changed once the account plan is created
+	SystemName string `json:"systemName"`
+
+	PlanSpec `json:",inline"`
+
+	// ProviderAccountRef references account provider credentials
+	// +optional
+	ProviderAccountRef *corev1.LocalObjectReference `json:"providerAccountRef,omitempty"`
+}
+
+// AccountPlanStatus defines the observed state of AccountPlan
+type AccountPlanStatus struct {
+	// +optional
+	// ID of the account plan
+	ID *int64 `json:"accountPlanID,omitempty"`
+
+	// Created is true when the account plan was created by this resource, false when an existing account plan was adopted.
+	// Only created account plans are deleted along with the resource
+	// +optional
+	Created bool `json:"created,omitempty"`
+
+	// ProviderAccountHost contains the 3scale account's provider URL
+	// +optional
+	ProviderAccountHost string `json:"providerAccountHost,omitempty"`
+
+	// ObservedGeneration reflects the generation of the most recently observed AccountPlan Spec.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Current state of the account plan resource.
+	// Conditions represent the latest available observations of an object's state
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	Conditions common.Conditions `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,2,rep,name=conditions"`
+}
+
+func (a *AccountPlanStatus) Equals(other *AccountPlanStatus, logger logr.Logger) bool {
+	if !reflect.DeepEqual(a.ID, other.ID) {
+		diff := cmp.Diff(a.ID, other.ID)
+		logger.V(1).Info("ID not equal", "difference", diff)
+		return false
+	}
+
+	if a.Created != other.Created {
+		diff := cmp.Diff(a.Created, other.Created)
+		logger.V(1).Info("Created not equal", "difference", diff)
+		return false
+	}
+
+	if a.ProviderAccountHost != other.ProviderAccountHost {
+		diff := cmp.Diff(a.ProviderAccountHost, other.ProviderAccountHost)
+		logger.V(1).Info("ProviderAccountHost not equal", "difference", diff)
+		return false
+	}
+
+	if a.ObservedGeneration != other.ObservedGeneration {
+		diff := cmp.Diff(a.ObservedGeneration, other.ObservedGeneration)
+		logger.V(1).Info("ObservedGeneration not equal", "difference", diff)
+		return false
+	}
+
+	// Marshalling sorts by condition type
+	currentMarshaledJSON, _ := a.Conditions.MarshalJSON()
+	otherMarshaledJSON, _ := other.Conditions.MarshalJSON()
+	if string(currentMarshaledJSON) != string(otherMarshaledJSON) {
+		diff := cmp.Diff(string(currentMarshaledJSON), string(otherMarshaledJSON))
+		logger.V(1).Info("Conditions not equal", "difference", diff)
+		return false
+	}
+
+	return true
+}
+
+func (a *AccountPlanStatus) IsReady() bool {
+	return a.Conditions.IsTrueFor(AccountPlanReadyConditionType)
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:JSONPath=".status.providerAccountHost",name="Provider Account",type=string
+// +kubebuilder:printcolumn:JSONPath=".status.conditions[?(@.type=='Ready')].status",name=Ready,type=string
+// +kubebuilder:printcolumn:JSONPath=".status.accountPlanID",name="3scale ID",type=integer
+
+// AccountPlan is the Schema for the accountplans API
+type AccountPlan struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AccountPlanSpec   `json:"spec,omitempty"`
+	Status AccountPlanStatus `json:"status,omitempty"`
+}
+
+func (a *AccountPlan) Validate() field.ErrorList {
+	errors := field.ErrorList{}
+
+	if a.Spec.SystemName == "" {
+		errors = append(errors, field.Required(field.NewPath("spec").Child("systemName"), "system name cannot be empty"))
+	}
+
+	return errors
+}
+
+// +kubebuilder:object:root=true
+
+// AccountPlanList contains a list of AccountPlan
+type AccountPlanList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AccountPlan `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AccountPlan{}, &AccountPlanList{})
+}
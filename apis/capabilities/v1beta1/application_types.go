@@ -17,16 +17,22 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
 	"github.com/3scale/3scale-operator/pkg/apispkg/common"
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"reflect"
+	"time"
 )
 
 const (
 	ApplicationReadyConditionType common.ConditionType = "Ready"
+
+	// ApplicationPendingApprovalConditionType indicates the application is pending of approval
+	// in 3scale and the approval is not decided in the Spec. It needs human action.
+	ApplicationPendingApprovalConditionType common.ConditionType = "PendingApproval"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -55,6 +61,157 @@ type ApplicationSpec struct {
 	//Suspend application if true suspends application, if false resumes application.
 	//+optional
 	Suspend bool `json:"suspend,omitempty"`
+
+	//CredentialsSecretRef name of the secret where the application credentials are written.
+	//The secret is created and owned by the application. The keys depend on the authentication mode of the product:
+	//user_key for user key authentication, app_id and app_key for app id and app key authentication,
+	//client_id and client_secret for OpenID Connect authentication
+	//+optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+
+	//ApplicationKeys keys of the application, for app id and app key or OpenID Connect authentication.
+	//When set, the keys of the application are managed by the operator, and the keys not listed are removed
+	//+optional
+	ApplicationKeys *ApplicationKeysSpec `json:"applicationKeys,omitempty"`
+
+	//UserKeySecretRef secret key holding the user key of the application, for user key authentication.
+	//It keeps the user key of applications migrated from other gateways. 3scale generates the user key when not set
+	//+optional
+	UserKeySecretRef *corev1.SecretKeySelector `json:"userKeySecretRef,omitempty"`
+
+	//ApplicationIDSecretRef secret key holding the application ID, for app id and app key or OpenID Connect authentication.
+	//It keeps the application ID of applications migrated from other gateways. 3scale generates the application ID when not set.
+	//The application ID is only set when the application is created
+	//+optional
+	ApplicationIDSecretRef *corev1.SecretKeySelector `json:"applicationIDSecretRef,omitempty"`
+
+	//RedirectURL OpenID Connect redirect URL of the application
+	//+optional
+	RedirectURL *string `json:"redirectURL,omitempty"`
+
+	//ReferrerFilters referrer filters of the application. When set, the referrer filters not listed are removed
+	//+optional
+	ReferrerFilters []string `json:"referrerFilters,omitempty"`
+
+	//PlanCustomization customizes the application plan for this application only.
+	//The application plan is decustomized when not set
+	//+optional
+	PlanCustomization *ApplicationPlanCustomizationSpec `json:"planCustomization,omitempty"`
+
+	//Approval of the application when its application plan requires applications to be approved.
+	//approved accepts the application. rejected and pending keep the application pending of approval,
+	//3scale has no operation to reject applications
+	//+optional
+	Approval *ApprovalState `json:"approval,omitempty"`
+}
+
+// ApplicationPlanCustomizationSpec defines the customization of the application plan for the application
+type ApplicationPlanCustomizationSpec struct {
+	// Trial Period (days)
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TrialPeriod *int `json:"trialPeriod,omitempty"`
+
+	// Setup fee (USD)
+	// +kubebuilder:validation:Pattern=`^\d+(\.\d{2})?$`
+	// +optional
+	SetupFee *string `json:"setupFee,omitempty"`
+
+	// Cost per Month (USD)
+	// +kubebuilder:validation:Pattern=`^\d+(\.\d{2})?$`
+	// +optional
+	CostMonth *string `json:"costMonth,omitempty"`
+
+	// Pricing Rules. They replace the pricing rules of the application plan.
+	// When not set, the pricing rules of the application plan are kept
+	// +optional
+	PricingRules []PricingRuleSpec `json:"pricingRules,omitempty"`
+
+	// Limits. They replace the limits of the application plan.
+	// When not set, the limits of the application plan are kept
+	// +optional
+	Limits []LimitSpec `json:"limits,omitempty"`
+}
+
+// ApplicationKeysSpec defines the keys of the application
+type ApplicationKeysSpec struct {
+	//SecretKeyRefs keys read from secrets. A changed key is replaced in the application
+	//+optional
+	SecretKeyRefs []corev1.SecretKeySelector `json:"secretKeyRefs,omitempty"`
+
+	//Generate adds a key generated by the operator
+	//+optional
+	Generate bool `json:"generate,omitempty"`
+
+	//Rotation policy of the keys
+	//+optional
+	Rotation *ApplicationKeyRotationSpec `json:"rotation,omitempty"`
+}
+
+// ApplicationKeyRotationSpec defines the rotation policy of the application keys
+type ApplicationKeyRotationSpec struct {
+	//IntervalDays days after which the generated key is replaced by a new one.
+	//The generated key is not rotated when not set
+	//+kubebuilder:validation:Minimum=1
+	//+optional
+	IntervalDays *int32 `json:"intervalDays,omitempty"`
+
+	//GracePeriodDays days the previous key is kept once replaced, either rotated or changed in its secret.
+	//The previous key is removed right away when not set
+	//+kubebuilder:validation:Minimum=0
+	//+optional
+	GracePeriodDays *int32 `json:"gracePeriodDays,omitempty"`
+}
+
+// RotationInterval returns the rotation interval of the generated key. Zero when it is not rotated
+func (a *ApplicationKeysSpec) RotationInterval() time.Duration {
+	if a.Rotation == nil || a.Rotation.IntervalDays == nil {
+		return 0
+	}
+	return time.Duration(*a.Rotation.IntervalDays) * 24 * time.Hour
+}
+
+// GracePeriod returns the time the previous key is kept once replaced
+func (a *ApplicationKeysSpec) GracePeriod() time.Duration {
+	if a.Rotation == nil || a.Rotation.GracePeriodDays == nil {
+		return 0
+	}
+	return time.Duration(*a.Rotation.GracePeriodDays) * 24 * time.Hour
+}
+
+const (
+	// ApplicationKeyGeneratedSource is the source of the key generated by the operator
+	ApplicationKeyGeneratedSource = "generated"
+)
+
+// ApplicationKeySecretSource returns the source of a key read from a secret
+func ApplicationKeySecretSource(selector corev1.SecretKeySelector) string {
+	return fmt.Sprintf("secret:%s/%s", selector.Name, selector.Key)
+}
+
+// ApplicationKeyStatus defines the observed state of a key managed by the operator
+type ApplicationKeyStatus struct {
+	// Source of the key: generated, or the secret key it is read from, as secret:<name>/<key>
+	Source string `json:"source"`
+
+	// Hash hex encoded SHA-256 hash of the key. It identifies the key without disclosing it
+	Hash string `json:"hash"`
+
+	// CreationTimestamp time the key was added to the application. It gives the age of the key
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
+
+	// ExpirationTimestamp time the key is removed, once replaced by a new key
+	// +optional
+	ExpirationTimestamp *metav1.Time `json:"expirationTimestamp,omitempty"`
+}
+
+// ApplicationPlanCustomizationStatus defines the observed state of the customized application plan
+type ApplicationPlanCustomizationStatus struct {
+	// PlanID ID of the customized plan of the application
+	PlanID int64 `json:"planID"`
+
+	// OriginalPlanID ID of the application plan the customized plan was created from
+	OriginalPlanID int64 `json:"originalPlanID"`
 }
 
 // ApplicationStatus defines the observed state of Application
@@ -72,6 +229,14 @@ type ApplicationStatus struct {
 	// +optional
 	State string `json:"state,omitempty"`
 
+	// Keys of the application managed by the operator
+	// +optional
+	Keys []ApplicationKeyStatus `json:"keys,omitempty"`
+
+	// Customized plan of the application
+	// +optional
+	PlanCustomization *ApplicationPlanCustomizationStatus `json:"planCustomization,omitempty"`
+
 	// ObservedGeneration reflects the generation of the most recently observed Application Spec.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
@@ -103,6 +268,18 @@ func (b *ApplicationStatus) Equals(other *ApplicationStatus, logger logr.Logger)
 		return false
 	}
 
+	if !reflect.DeepEqual(b.Keys, other.Keys) {
+		diff := cmp.Diff(b.Keys, other.Keys)
+		logger.V(1).Info("Keys not equal", "difference", diff)
+		return false
+	}
+
+	if !reflect.DeepEqual(b.PlanCustomization, other.PlanCustomization) {
+		diff := cmp.Diff(b.PlanCustomization, other.PlanCustomization)
+		logger.V(1).Info("PlanCustomization not equal", "difference", diff)
+		return false
+	}
+
 	if b.ObservedGeneration != other.ObservedGeneration {
 		diff := cmp.Diff(b.ObservedGeneration, other.ObservedGeneration)
 		logger.V(1).Info("ObservedGeneration not equal", "difference", diff)
@@ -121,6 +298,11 @@ func (b *ApplicationStatus) Equals(other *ApplicationStatus, logger logr.Logger)
 	return true
 }
 
+// IsPlanCustomized returns true when the plan is the customized plan of the application
+func (b *ApplicationStatus) IsPlanCustomized(planID int64) bool {
+	return b.PlanCustomization != nil && b.PlanCustomization.PlanID == planID
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 
@@ -51,8 +51,32 @@ const (
 	// DeveloperAccountFailedConditionType indicates that an error occurred during synchronization.
 	// The operator will retry.
 	DeveloperAccountFailedConditionType common.ConditionType = "Failed"
+
+	// DeveloperAccountPendingApprovalConditionType indicates the account is pending of approval
+	// in 3scale and the approval is not decided in the Spec. It needs human action.
+	DeveloperAccountPendingApprovalConditionType common.ConditionType = "PendingApproval"
 )
 
+// ApprovalState is the approval decision for an application or a developer account
+// +kubebuilder:validation:Enum=pending;approved;rejected
+type ApprovalState string
+
+const (
+	// ApprovalPending keeps the application or the developer account pending of approval
+	ApprovalPending ApprovalState = "pending"
+
+	// ApprovalApproved approves the application or the developer account
+	ApprovalApproved ApprovalState = "approved"
+
+	// ApprovalRejected rejects the application or the developer account
+	ApprovalRejected ApprovalState = "rejected"
+)
+
+// IsDecided returns true when the approval is either approved or rejected
+func (a *ApprovalState) IsDecided() bool {
+	return a != nil && (*a == ApprovalApproved || *a == ApprovalRejected)
+}
+
 // DeveloperAccountSpec defines the desired state of DeveloperAccount
 type DeveloperAccountSpec struct {
 	// OrgName is the organization name
@@ -69,6 +93,31 @@ type DeveloperAccountSpec struct {
 	// ProviderAccountRef references account provider credentials
 	// +optional
 	ProviderAccountRef *corev1.LocalObjectReference `json:"providerAccountRef,omitempty"`
+
+	// Approval of the account when 3scale requires developer accounts to be approved.
+	// approved approves the account, rejected rejects it and pending sets it back to pending of approval.
+	// The state of the account is not changed when not set
+	// +optional
+	Approval *ApprovalState `json:"approval,omitempty"`
+
+	// AccountPlanRef references the AccountPlan CR of the account plan of the account.
+	// The account plan is not changed when not set
+	// +optional
+	AccountPlanRef *corev1.LocalObjectReference `json:"accountPlanRef,omitempty"`
+
+	// ServicePlanRef references the service plan the account is subscribed to.
+	// The subscriptions are not changed when not set
+	// +optional
+	ServicePlanRef *ServicePlanRefSpec `json:"servicePlanRef,omitempty"`
+}
+
+// ServicePlanRefSpec references a service plan of a product
+type ServicePlanRefSpec struct {
+	// ProductCR references the Product CR of the service plan
+	ProductCR corev1.LocalObjectReference `json:"productCR"`
+
+	// SystemName is the system name of the service plan in the service plans of the product
+	SystemName string `json:"systemName"`
 }
 
 // DeveloperAccountStatus defines the observed state of DeveloperAccount
@@ -0,0 +1,37 @@
+package v1beta1
+
+// PlanSpec defines the desired state of a Service Plan or an Account Plan
+type PlanSpec struct {
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// Set whether or not the subscriptions to the product (service plans),
+	// or the developer accounts (account plans) can be created on demand
+	// or if approval is required from you before they are activated.
+	// +optional
+	ApprovalRequired *bool `json:"approvalRequired,omitempty"`
+
+	// Trial Period (days)
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TrialPeriod *int `json:"trialPeriod,omitempty"`
+
+	// Setup fee (USD)
+	// +kubebuilder:validation:Pattern=`^\d+(\.\d{2})?$`
+	// +optional
+	SetupFee *string `json:"setupFee,omitempty"`
+
+	// Cost per Month (USD)
+	// +kubebuilder:validation:Pattern=`^\d+(\.\d{2})?$`
+	// +optional
+	CostMonth *string `json:"costMonth,omitempty"`
+
+	// Controls whether the plan is published. If not specified it is
+	// hidden by default
+	// +optional
+	Published *bool `json:"published,omitempty"`
+}
+
+func (p *PlanSpec) IsPublished() bool {
+	return p.Published != nil && *p.Published
+}
@@ -924,6 +924,14 @@ type ProductSpec struct {
 	// +optional
 	ApplicationPlans map[string]ApplicationPlanSpec `json:"applicationPlans,omitempty"`
 
+	// Service Plans
+	// Map: system_name -> Service Plan Spec
+	// Service plans are not managed when not set.
+	// When set, the service plans not listed are deleted, except the "default" service plan
+	// and the service plans developer accounts are subscribed to
+	// +optional
+	ServicePlans map[string]PlanSpec `json:"servicePlans,omitempty"`
+
 	// ProviderAccountRef references account provider credentials
 	// +optional
 	ProviderAccountRef *corev1.LocalObjectReference `json:"providerAccountRef,omitempty"`
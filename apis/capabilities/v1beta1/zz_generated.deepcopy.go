@@ -27,6 +27,113 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountPlan) DeepCopyInto(out *AccountPlan) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountPlan.
+func (in *AccountPlan) DeepCopy() *AccountPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccountPlan) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountPlanList) DeepCopyInto(out *AccountPlanList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AccountPlan, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountPlanList.
+func (in *AccountPlanList) DeepCopy() *AccountPlanList {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountPlanList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AccountPlanList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountPlanSpec) DeepCopyInto(out *AccountPlanSpec) {
+	*out = *in
+	in.PlanSpec.DeepCopyInto(&out.PlanSpec)
+	if in.ProviderAccountRef != nil {
+		in, out := &in.ProviderAccountRef, &out.ProviderAccountRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountPlanSpec.
+func (in *AccountPlanSpec) DeepCopy() *AccountPlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountPlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccountPlanStatus) DeepCopyInto(out *AccountPlanStatus) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(common.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccountPlanStatus.
+func (in *AccountPlanStatus) DeepCopy() *AccountPlanStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AccountPlanStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActiveDoc) DeepCopyInto(out *ActiveDoc) {
 	*out = *in
@@ -306,6 +413,78 @@ func (in *Application) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationKeyRotationSpec) DeepCopyInto(out *ApplicationKeyRotationSpec) {
+	*out = *in
+	if in.IntervalDays != nil {
+		in, out := &in.IntervalDays, &out.IntervalDays
+		*out = new(int32)
+		**out = **in
+	}
+	if in.GracePeriodDays != nil {
+		in, out := &in.GracePeriodDays, &out.GracePeriodDays
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationKeyRotationSpec.
+func (in *ApplicationKeyRotationSpec) DeepCopy() *ApplicationKeyRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationKeyRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationKeyStatus) DeepCopyInto(out *ApplicationKeyStatus) {
+	*out = *in
+	in.CreationTimestamp.DeepCopyInto(&out.CreationTimestamp)
+	if in.ExpirationTimestamp != nil {
+		in, out := &in.ExpirationTimestamp, &out.ExpirationTimestamp
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationKeyStatus.
+func (in *ApplicationKeyStatus) DeepCopy() *ApplicationKeyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationKeyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationKeysSpec) DeepCopyInto(out *ApplicationKeysSpec) {
+	*out = *in
+	if in.SecretKeyRefs != nil {
+		in, out := &in.SecretKeyRefs, &out.SecretKeyRefs
+		*out = make([]v1.SecretKeySelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(ApplicationKeyRotationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationKeysSpec.
+func (in *ApplicationKeysSpec) DeepCopy() *ApplicationKeysSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationKeysSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationList) DeepCopyInto(out *ApplicationList) {
 	*out = *in
@@ -338,6 +517,65 @@ func (in *ApplicationList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationPlanCustomizationSpec) DeepCopyInto(out *ApplicationPlanCustomizationSpec) {
+	*out = *in
+	if in.TrialPeriod != nil {
+		in, out := &in.TrialPeriod, &out.TrialPeriod
+		*out = new(int)
+		**out = **in
+	}
+	if in.SetupFee != nil {
+		in, out := &in.SetupFee, &out.SetupFee
+		*out = new(string)
+		**out = **in
+	}
+	if in.CostMonth != nil {
+		in, out := &in.CostMonth, &out.CostMonth
+		*out = new(string)
+		**out = **in
+	}
+	if in.PricingRules != nil {
+		in, out := &in.PricingRules, &out.PricingRules
+		*out = make([]PricingRuleSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make([]LimitSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationPlanCustomizationSpec.
+func (in *ApplicationPlanCustomizationSpec) DeepCopy() *ApplicationPlanCustomizationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationPlanCustomizationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationPlanCustomizationStatus) DeepCopyInto(out *ApplicationPlanCustomizationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationPlanCustomizationStatus.
+func (in *ApplicationPlanCustomizationStatus) DeepCopy() *ApplicationPlanCustomizationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationPlanCustomizationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationPlanSpec) DeepCopyInto(out *ApplicationPlanSpec) {
 	*out = *in
@@ -410,6 +648,46 @@ func (in *ApplicationSpec) DeepCopyInto(out *ApplicationSpec) {
 		*out = new(v1.LocalObjectReference)
 		**out = **in
 	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.ApplicationKeys != nil {
+		in, out := &in.ApplicationKeys, &out.ApplicationKeys
+		*out = new(ApplicationKeysSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UserKeySecretRef != nil {
+		in, out := &in.UserKeySecretRef, &out.UserKeySecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ApplicationIDSecretRef != nil {
+		in, out := &in.ApplicationIDSecretRef, &out.ApplicationIDSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RedirectURL != nil {
+		in, out := &in.RedirectURL, &out.RedirectURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.ReferrerFilters != nil {
+		in, out := &in.ReferrerFilters, &out.ReferrerFilters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PlanCustomization != nil {
+		in, out := &in.PlanCustomization, &out.PlanCustomization
+		*out = new(ApplicationPlanCustomizationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		*out = new(ApprovalState)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSpec.
@@ -430,6 +708,18 @@ func (in *ApplicationStatus) DeepCopyInto(out *ApplicationStatus) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]ApplicationKeyStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PlanCustomization != nil {
+		in, out := &in.PlanCustomization, &out.PlanCustomization
+		*out = new(ApplicationPlanCustomizationStatus)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(common.Conditions, len(*in))
@@ -830,6 +1120,21 @@ func (in *DeveloperAccountSpec) DeepCopyInto(out *DeveloperAccountSpec) {
 		*out = new(v1.LocalObjectReference)
 		**out = **in
 	}
+	if in.Approval != nil {
+		in, out := &in.Approval, &out.Approval
+		*out = new(ApprovalState)
+		**out = **in
+	}
+	if in.AccountPlanRef != nil {
+		in, out := &in.AccountPlanRef, &out.AccountPlanRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.ServicePlanRef != nil {
+		in, out := &in.ServicePlanRef, &out.ServicePlanRef
+		*out = new(ServicePlanRefSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeveloperAccountSpec.
@@ -1395,6 +1700,51 @@ func (in *OpenAPIStatus) DeepCopy() *OpenAPIStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanSpec) DeepCopyInto(out *PlanSpec) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.ApprovalRequired != nil {
+		in, out := &in.ApprovalRequired, &out.ApprovalRequired
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TrialPeriod != nil {
+		in, out := &in.TrialPeriod, &out.TrialPeriod
+		*out = new(int)
+		**out = **in
+	}
+	if in.SetupFee != nil {
+		in, out := &in.SetupFee, &out.SetupFee
+		*out = new(string)
+		**out = **in
+	}
+	if in.CostMonth != nil {
+		in, out := &in.CostMonth, &out.CostMonth
+		*out = new(string)
+		**out = **in
+	}
+	if in.Published != nil {
+		in, out := &in.Published, &out.Published
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanSpec.
+func (in *PlanSpec) DeepCopy() *PlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PolicyConfig) DeepCopyInto(out *PolicyConfig) {
 	*out = *in
@@ -1555,6 +1905,13 @@ func (in *ProductSpec) DeepCopyInto(out *ProductSpec) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.ServicePlans != nil {
+		in, out := &in.ServicePlans, &out.ServicePlans
+		*out = make(map[string]PlanSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	if in.ProviderAccountRef != nil {
 		in, out := &in.ProviderAccountRef, &out.ProviderAccountRef
 		*out = new(v1.LocalObjectReference)
@@ -1742,6 +2099,22 @@ func (in *SecuritySpec) DeepCopy() *SecuritySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServicePlanRefSpec) DeepCopyInto(out *ServicePlanRefSpec) {
+	*out = *in
+	out.ProductCR = in.ProductCR
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicePlanRefSpec.
+func (in *ServicePlanRefSpec) DeepCopy() *ServicePlanRefSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServicePlanRefSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserKeyAuthenticationSpec) DeepCopyInto(out *UserKeyAuthenticationSpec) {
 	*out = *in
@@ -21,9 +21,13 @@ import (
 	"fmt"
 
 	appsv1 "github.com/openshift/api/apps/v1"
+	imagev1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
 
+	k8sappsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apimachinerymetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -39,6 +43,7 @@ import (
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/operator"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
+	certmanagerv1 "github.com/3scale/3scale-operator/pkg/certmanager/v1"
 	"github.com/3scale/3scale-operator/pkg/handlers"
 
 	"github.com/3scale/3scale-operator/pkg/helper"
@@ -69,9 +74,13 @@ var _ reconcile.Reconciler = &APIManagerReconciler{}
 // +kubebuilder:rbac:groups=route.openshift.io,namespace=placeholder,resources=routes/custom-host,verbs=create
 // +kubebuilder:rbac:groups=route.openshift.io,namespace=placeholder,resources=routes/status,verbs=get
 // +kubebuilder:rbac:groups=apps.openshift.io,namespace=placeholder,resources=deploymentconfigs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,namespace=placeholder,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=autoscaling,namespace=placeholder,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,namespace=placeholder,resources=ingresses;networkpolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=policy,namespace=placeholder,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=monitoring.coreos.com,namespace=placeholder,resources=podmonitors;servicemonitors;prometheusrules,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=integreatly.org,namespace=placeholder,resources=grafanadashboards,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=cert-manager.io,namespace=placeholder,resources=certificates,verbs=get;list;watch;create;update;delete
 // +kubebuilder:rbac:groups=config.openshift.io,resources=clusterversions,verbs=get;list;watch
 
 func (r *APIManagerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -105,10 +114,16 @@ func (r *APIManagerReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return res, nil
 	}
 
-	specResult, specErr := r.reconcileAPIManagerLogic(instance)
-	if specErr != nil && specResult.Requeue {
-		logger.Info("Reconciling not finished. Requeueing.")
-		return specResult, nil
+	var specResult ctrl.Result
+	var specErr error
+	if instance.IsReconciliationPaused() {
+		logger.Info("Reconciliation paused. Only the status is updated", "annotation", appsv1alpha1.PausedAnnotation)
+	} else {
+		specResult, specErr = r.reconcileAPIManagerLogic(instance)
+		if specErr != nil && specResult.Requeue {
+			logger.Info("Reconciling not finished. Requeueing.")
+			return specResult, nil
+		}
 	}
 
 	// reconcile status regardless specErr
@@ -126,6 +141,12 @@ func (r *APIManagerReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return statusResult, nil
 	}
 
+	// Scheduled work, e.g. the secret rotation
+	if specResult.Requeue || specResult.RequeueAfter > 0 {
+		logger.Info("Requeueing", "requeueAfter", specResult.RequeueAfter)
+		return ctrl.Result{Requeue: specResult.Requeue, RequeueAfter: specResult.RequeueAfter}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -146,16 +167,60 @@ func (r *APIManagerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return nil
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	// OpenShift resources are only watched when available,
+	// so the operator can run on vanilla Kubernetes clusters
+	hasDeploymentConfigs, err := r.HasDeploymentConfigs()
+	if err != nil {
+		return err
+	}
+
+	hasRoutes, err := r.HasRoutes()
+	if err != nil {
+		return err
+	}
+
+	hasImageStreams, err := r.HasImageStreams()
+	if err != nil {
+		return err
+	}
+
+	hasCertificates, err := r.HasCertificates()
+	if err != nil {
+		return err
+	}
+
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		For(&appsv1alpha1.APIManager{}).
 		Watches(
 			&source.Kind{Type: &v1.Secret{}},
 			handler.EnqueueRequestsFromMapFunc(secretToApimanagerEventMapper.Map),
 			builder.WithPredicates(labelSelectorPredicate),
 		).
-		Owns(&appsv1.DeploymentConfig{}).
-		Watches(&source.Kind{Type: &routev1.Route{}}, handler.EnqueueRequestsFromMapFunc(handlers.Map)).
-		Complete(r)
+		Owns(&k8sappsv1.Deployment{}).
+		Owns(&networkingv1.Ingress{}).
+		Owns(&networkingv1.NetworkPolicy{}).
+		Owns(&v1.PersistentVolumeClaim{}).
+		Owns(&batchv1.Job{})
+
+	if hasDeploymentConfigs {
+		controllerBuilder = controllerBuilder.Owns(&appsv1.DeploymentConfig{})
+	}
+
+	// Imagestream imports update the pinned image digests
+	if hasImageStreams {
+		controllerBuilder = controllerBuilder.Owns(&imagev1.ImageStream{})
+	}
+
+	// Certificate renewals update the routes and roll out apicast
+	if hasCertificates {
+		controllerBuilder = controllerBuilder.Owns(&certmanagerv1.Certificate{})
+	}
+
+	if hasRoutes {
+		controllerBuilder = controllerBuilder.Watches(&source.Kind{Type: &routev1.Route{}}, handler.EnqueueRequestsFromMapFunc(handlers.Map))
+	}
+
+	return controllerBuilder.Complete(r)
 }
 
 func (r *APIManagerReconciler) validateCR(cr *appsv1alpha1.APIManager) error {
@@ -207,61 +272,7 @@ func (r *APIManagerReconciler) setAPIManagerDefaults(cr *appsv1alpha1.APIManager
 
 func (r *APIManagerReconciler) reconcileAPIManagerLogic(cr *appsv1alpha1.APIManager) (reconcile.Result, error) {
 	baseAPIManagerLogicReconciler := operator.NewBaseAPIManagerLogicReconciler(r.BaseReconciler, cr)
-	imageReconciler := operator.NewAMPImagesReconciler(baseAPIManagerLogicReconciler)
-	result, err := imageReconciler.Reconcile()
-	if err != nil || result.Requeue {
-		return result, err
-	}
-
-	dependencyReconciler := r.dependencyReconcilerForComponents(cr, baseAPIManagerLogicReconciler)
-	result, err = dependencyReconciler.Reconcile()
-	if err != nil || result.Requeue {
-		return result, err
-	}
-
-	backendReconciler := operator.NewBackendReconciler(baseAPIManagerLogicReconciler)
-	result, err = backendReconciler.Reconcile()
-	if err != nil || result.Requeue {
-		return result, err
-	}
-
-	memcachedReconciler := operator.NewMemcachedReconciler(baseAPIManagerLogicReconciler)
-	result, err = memcachedReconciler.Reconcile()
-	if err != nil || result.Requeue {
-		return result, err
-	}
-
-	systemSearchdReconciler := operator.NewSystemSearchdReconciler(baseAPIManagerLogicReconciler)
-	result, err = systemSearchdReconciler.Reconcile()
-	if err != nil || result.Requeue {
-		return result, err
-	}
-
-	systemReconciler := operator.NewSystemReconciler(baseAPIManagerLogicReconciler)
-	result, err = systemReconciler.Reconcile()
-	if err != nil || result.Requeue {
-		return result, err
-	}
-
-	zyncReconciler := operator.NewZyncReconciler(baseAPIManagerLogicReconciler)
-	result, err = zyncReconciler.Reconcile()
-	if err != nil || result.Requeue {
-		return result, err
-	}
-
-	apicastReconciler := operator.NewApicastReconciler(baseAPIManagerLogicReconciler)
-	result, err = apicastReconciler.Reconcile()
-	if err != nil || result.Requeue {
-		return result, err
-	}
-
-	genericMonitoringReconciler := operator.NewGenericMonitoringReconciler(baseAPIManagerLogicReconciler)
-	result, err = genericMonitoringReconciler.Reconcile()
-	if err != nil || result.Requeue {
-		return result, err
-	}
-
-	return ctrl.Result{}, nil
+	return operator.NewAPIManagerLogicReconciler(baseAPIManagerLogicReconciler).Reconcile()
 }
 
 func (r *APIManagerReconciler) reconcileAPIManagerStatus(cr *appsv1alpha1.APIManager) (reconcile.Result, error) {
@@ -311,62 +322,3 @@ func (r *APIManagerReconciler) validateApicastTLSCertificates(cr *appsv1alpha1.A
 
 	return fieldErrors
 }
-
-func (r *APIManagerReconciler) dependencyReconcilerForComponents(cr *appsv1alpha1.APIManager, baseAPIManagerLogicReconciler *operator.BaseAPIManagerLogicReconciler) operator.DependencyReconciler {
-	// Helper type that contains the constructors for a dependency reconciler
-	// whether it's external or internal
-	type constructors struct {
-		External operator.DependencyReconcilerConstructor
-		Internal operator.DependencyReconcilerConstructor
-	}
-
-	// Helper function that instantiates a dependency reconciler depending
-	// on whether it's external or internal
-	selectReconciler := func(cs constructors, selectIsExternal func(*appsv1alpha1.ExternalComponentsSpec) bool) operator.DependencyReconciler {
-		constructor := cs.Internal
-		if selectIsExternal(cr.Spec.ExternalComponents) {
-			constructor = cs.External
-		}
-
-		return constructor(baseAPIManagerLogicReconciler)
-	}
-
-	// Select whether to use PostgreSQL or MySQL for the System database
-	var systemDatabaseReconcilerConstructor operator.DependencyReconcilerConstructor
-	if cr.Spec.System.DatabaseSpec != nil && cr.Spec.System.DatabaseSpec.PostgreSQL != nil {
-		systemDatabaseReconcilerConstructor = operator.CompositeDependencyReconcilerConstructor(
-			operator.NewSystemPostgreSQLReconciler,
-			operator.NewSystemPostgreSQLImageReconciler,
-		)
-	} else {
-		systemDatabaseReconcilerConstructor = operator.CompositeDependencyReconcilerConstructor(
-			operator.NewSystemMySQLReconciler,
-			operator.NewSystemMySQLImageReconciler,
-		)
-	}
-
-	systemDatabaseConstructors := constructors{
-		External: operator.NewSystemExternalDatabaseReconciler,
-		Internal: systemDatabaseReconcilerConstructor,
-	}
-	systemRedisConstructors := constructors{
-		External: operator.NewSystemExternalRedisReconciler,
-		Internal: operator.NewSystemRedisDependencyReconciler,
-	}
-	backendRedisConstructors := constructors{
-		External: operator.NewBackendExternalRedisReconciler,
-		Internal: operator.NewBackendRedisDependencyReconciler,
-	}
-
-	// Build the final reconciler composed by the chosen external/internal
-	// combination
-	result := []operator.DependencyReconciler{
-		selectReconciler(systemDatabaseConstructors, appsv1alpha1.SystemDatabase),
-		selectReconciler(systemRedisConstructors, appsv1alpha1.SystemRedis),
-		selectReconciler(backendRedisConstructors, appsv1alpha1.BackendRedis),
-	}
-
-	return &operator.CompositeDependencyReconciler{
-		Reconcilers: result,
-	}
-}
@@ -0,0 +1,440 @@
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	appsv1 "github.com/openshift/api/apps/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// subsystemResources are the resources checked to compute the condition of a 3scale subsystem
+type subsystemResources struct {
+	conditionType common.ConditionType
+	deployments   []string
+	pvcs          []string
+	hosts         []string
+}
+
+// resourceFailure describes a resource preventing a subsystem from being available
+type resourceFailure struct {
+	reason  common.ConditionReason
+	message string
+}
+
+func (s *APIManagerStatusReconciler) expectedSubsystems() []subsystemResources {
+	instance := s.apimanagerResource
+
+	systemPVCs := []string{component.SystemSearchdPVCName}
+	if !instance.IsS3Enabled() {
+		systemPVCs = append(systemPVCs, component.SystemFileStoragePVCName)
+	}
+
+	systemDeployments := append(component.SystemAppDeploymentNames(instance.IsSystemAppSplitEnabled()), component.SystemSidekiqName)
+	for _, pool := range sidekiqPoolNames(instance) {
+		systemDeployments = append(systemDeployments, component.SidekiqPoolName(pool))
+	}
+	systemDeployments = append(systemDeployments,
+		component.SystemSearchdDeploymentName,
+		component.SystemMemcachedDeploymentName,
+	)
+
+	databases := subsystemResources{conditionType: appsv1alpha1.APIManagerDatabasesAvailableConditionType}
+	if !instance.IsExternal(appsv1alpha1.SystemDatabase) {
+		if instance.IsSystemPostgreSQLEnabled() {
+			databases.deployments = append(databases.deployments, component.SystemPostgreSQLDeploymentName)
+			databases.pvcs = append(databases.pvcs, component.SystemPostgreSQLPVCName)
+		} else {
+			databases.deployments = append(databases.deployments, component.SystemMySQLDeploymentName)
+			databases.pvcs = append(databases.pvcs, component.SystemMySQLPVCName)
+		}
+	}
+	if !instance.IsExternal(appsv1alpha1.SystemRedis) {
+		databases.deployments = append(databases.deployments, component.SystemRedisDeploymentName)
+		databases.pvcs = append(databases.pvcs, component.SystemRedisPVCName)
+	}
+	if !instance.IsExternal(appsv1alpha1.BackendRedis) {
+		databases.deployments = append(databases.deployments, component.BackendRedisDeploymentName)
+		databases.pvcs = append(databases.pvcs, component.BackendRedisPVCName)
+	}
+	if !instance.IsExternal(appsv1alpha1.ZyncDatabase) {
+		databases.deployments = append(databases.deployments, component.ZyncDatabaseDeploymentName)
+	}
+
+	return []subsystemResources{
+		{
+			conditionType: appsv1alpha1.APIManagerSystemAvailableConditionType,
+			deployments:   systemDeployments,
+			pvcs:          systemPVCs,
+			hosts:         s.systemDefaultHosts(),
+		},
+		{
+			conditionType: appsv1alpha1.APIManagerBackendAvailableConditionType,
+			deployments: []string{
+				component.BackendListenerName,
+				component.BackendWorkerName,
+				component.BackendCronName,
+			},
+			hosts: s.backendDefaultHosts(),
+		},
+		{
+			conditionType: appsv1alpha1.APIManagerApicastAvailableConditionType,
+			deployments: []string{
+				component.ApicastStagingName,
+				component.ApicastProductionName,
+			},
+			hosts: s.apicastDefaultHosts(),
+		},
+		{
+			conditionType: appsv1alpha1.APIManagerZyncAvailableConditionType,
+			deployments: []string{
+				component.ZyncName,
+				component.ZyncQueDeploymentName,
+			},
+		},
+		databases,
+	}
+}
+
+// subsystemConditions returns one condition for each 3scale subsystem.
+// When a subsystem is not available, the condition reason is taken from the first failing resource
+// and the message lists every failing resource
+func (s *APIManagerStatusReconciler) subsystemConditions(deploymentConfigs []appsv1.DeploymentConfig, deployments []k8sappsv1.Deployment) ([]common.Condition, error) {
+	var routes []routev1.Route
+	var ingresses []networkingv1.Ingress
+	listOps := []client.ListOption{client.InNamespace(s.apimanagerResource.Namespace)}
+	if s.apimanagerResource.IsIngressEnabled() {
+		ingressList := &networkingv1.IngressList{}
+		if err := s.Client().List(s.Context(), ingressList, listOps...); err != nil {
+			return nil, fmt.Errorf("Failed to list ingresses: %w", err)
+		}
+		ingresses = ingressList.Items
+	} else {
+		routeList := &routev1.RouteList{}
+		if err := s.Client().List(s.Context(), routeList, listOps...); err != nil {
+			return nil, fmt.Errorf("Failed to list routes: %w", err)
+		}
+		routes = routeList.Items
+	}
+
+	conditions := []common.Condition{}
+	for _, subsystem := range s.expectedSubsystems() {
+		failures := []resourceFailure{}
+
+		for _, deploymentName := range subsystem.deployments {
+			if failure := s.deploymentFailure(deploymentName, deploymentConfigs, deployments); failure != nil {
+				failures = append(failures, *failure)
+			}
+		}
+
+		for _, pvcName := range subsystem.pvcs {
+			failure, err := s.pvcFailure(pvcName)
+			if err != nil {
+				return nil, err
+			}
+			if failure != nil {
+				failures = append(failures, *failure)
+			}
+		}
+
+		for _, host := range subsystem.hosts {
+			var failure *resourceFailure
+			if s.apimanagerResource.IsIngressEnabled() {
+				failure = ingressFailure(host, ingresses)
+			} else {
+				failure = routeFailure(host, routes)
+			}
+			if failure != nil {
+				failures = append(failures, *failure)
+			}
+		}
+
+		conditions = append(conditions, newSubsystemCondition(subsystem.conditionType, failures))
+	}
+
+	if s.apimanagerResource.IsMonitoringEnabled() {
+		failures, err := s.monitoringFailures()
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, newSubsystemCondition(appsv1alpha1.APIManagerMonitoringAvailableConditionType, failures))
+	}
+
+	return conditions, nil
+}
+
+func newSubsystemCondition(conditionType common.ConditionType, failures []resourceFailure) common.Condition {
+	if len(failures) == 0 {
+		return common.Condition{
+			Type:   conditionType,
+			Status: v1.ConditionTrue,
+			Reason: appsv1alpha1.APIManagerComponentAvailableReason,
+		}
+	}
+
+	messages := make([]string, 0, len(failures))
+	for _, failure := range failures {
+		messages = append(messages, failure.message)
+	}
+
+	return common.Condition{
+		Type:    conditionType,
+		Status:  v1.ConditionFalse,
+		Reason:  failures[0].reason,
+		Message: strings.Join(messages, "; "),
+	}
+}
+
+func (s *APIManagerStatusReconciler) deploymentFailure(name string, deploymentConfigs []appsv1.DeploymentConfig, deployments []k8sappsv1.Deployment) *resourceFailure {
+	var dc *appsv1.DeploymentConfig
+	for idx := range deploymentConfigs {
+		if deploymentConfigs[idx].Name == name {
+			dc = &deploymentConfigs[idx]
+			break
+		}
+	}
+
+	if !s.apimanagerResource.IsKubernetesDeploymentEnabled() {
+		if dc == nil {
+			return &resourceFailure{
+				reason:  appsv1alpha1.APIManagerDeploymentConfigNotFoundReason,
+				message: fmt.Sprintf("DeploymentConfig %s not found", name),
+			}
+		}
+		if !helper.IsDeploymentConfigAvailable(dc) {
+			return &resourceFailure{
+				reason:  appsv1alpha1.APIManagerDeploymentConfigNotAvailableReason,
+				message: fmt.Sprintf("DeploymentConfig %s not available: %s", name, deploymentConfigNotAvailableMessage(dc)),
+			}
+		}
+		return nil
+	}
+
+	var deployment *k8sappsv1.Deployment
+	for idx := range deployments {
+		if deployments[idx].Name == name {
+			deployment = &deployments[idx]
+			break
+		}
+	}
+
+	if deployment != nil && helper.IsDeploymentAvailable(deployment) {
+		return nil
+	}
+
+	// DeploymentConfigs being migrated keep serving until the Deployments are available
+	if dc != nil && helper.IsDeploymentConfigAvailable(dc) {
+		return nil
+	}
+
+	if deployment == nil {
+		return &resourceFailure{
+			reason:  appsv1alpha1.APIManagerDeploymentNotFoundReason,
+			message: fmt.Sprintf("Deployment %s not found", name),
+		}
+	}
+
+	return &resourceFailure{
+		reason:  appsv1alpha1.APIManagerDeploymentNotAvailableReason,
+		message: fmt.Sprintf("Deployment %s not available: %s", name, deploymentNotAvailableMessage(deployment)),
+	}
+}
+
+// deploymentConfigNotAvailableMessage returns the message of the failing DeploymentConfig condition.
+// A failed rollout is more relevant than the lack of minimum availability
+func deploymentConfigNotAvailableMessage(dc *appsv1.DeploymentConfig) string {
+	for _, conditionType := range []appsv1.DeploymentConditionType{appsv1.DeploymentProgressing, appsv1.DeploymentAvailable} {
+		for _, condition := range dc.Status.Conditions {
+			if condition.Type == conditionType && condition.Status == v1.ConditionFalse && condition.Message != "" {
+				return condition.Message
+			}
+		}
+	}
+
+	return fmt.Sprintf("%d of %d replicas available", dc.Status.AvailableReplicas, dc.Spec.Replicas)
+}
+
+// deploymentNotAvailableMessage returns the message of the failing Deployment condition.
+// Replica failures and failed rollouts are more relevant than the lack of minimum availability
+func deploymentNotAvailableMessage(deployment *k8sappsv1.Deployment) string {
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == k8sappsv1.DeploymentReplicaFailure && condition.Status == v1.ConditionTrue && condition.Message != "" {
+			return condition.Message
+		}
+	}
+
+	for _, conditionType := range []k8sappsv1.DeploymentConditionType{k8sappsv1.DeploymentProgressing, k8sappsv1.DeploymentAvailable} {
+		for _, condition := range deployment.Status.Conditions {
+			if condition.Type == conditionType && condition.Status == v1.ConditionFalse && condition.Message != "" {
+				return condition.Message
+			}
+		}
+	}
+
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return "rollout in progress"
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return fmt.Sprintf("%d of %d replicas available", deployment.Status.AvailableReplicas, replicas)
+}
+
+func (s *APIManagerStatusReconciler) pvcFailure(name string) (*resourceFailure, error) {
+	pvc := &v1.PersistentVolumeClaim{}
+	err := s.Client().Get(s.Context(), types.NamespacedName{Namespace: s.apimanagerResource.Namespace, Name: name}, pvc)
+	if errors.IsNotFound(err) {
+		return &resourceFailure{
+			reason:  appsv1alpha1.APIManagerPVCNotFoundReason,
+			message: fmt.Sprintf("PersistentVolumeClaim %s not found", name),
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if pvc.Status.Phase != v1.ClaimBound {
+		phase := pvc.Status.Phase
+		if phase == "" {
+			phase = v1.ClaimPending
+		}
+		return &resourceFailure{
+			reason:  appsv1alpha1.APIManagerPVCNotBoundReason,
+			message: fmt.Sprintf("PersistentVolumeClaim %s is %s", name, phase),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func routeFailure(host string, routes []routev1.Route) *resourceFailure {
+	sortedRoutes := append([]routev1.Route(nil), routes...)
+	sort.Slice(sortedRoutes, func(i, j int) bool { return sortedRoutes[i].Name < sortedRoutes[j].Name })
+
+	routeIdx := helper.RouteFindByHost(sortedRoutes, host)
+	if routeIdx == -1 {
+		return &resourceFailure{
+			reason:  appsv1alpha1.APIManagerRouteNotFoundReason,
+			message: fmt.Sprintf("Route for host %s not found", host),
+		}
+	}
+
+	route := &sortedRoutes[routeIdx]
+	if helper.IsRouteReady(route) {
+		return nil
+	}
+
+	message := fmt.Sprintf("Route %s for host %s not admitted", route.Name, host)
+	for _, routeIngress := range route.Status.Ingress {
+		for _, condition := range routeIngress.Conditions {
+			if condition.Type == routev1.RouteAdmitted && condition.Status != v1.ConditionTrue && condition.Message != "" {
+				message = fmt.Sprintf("%s: %s", message, condition.Message)
+			}
+		}
+	}
+
+	return &resourceFailure{
+		reason:  appsv1alpha1.APIManagerRouteNotAdmittedReason,
+		message: message,
+	}
+}
+
+func ingressFailure(host string, ingresses []networkingv1.Ingress) *resourceFailure {
+	sortedIngresses := append([]networkingv1.Ingress(nil), ingresses...)
+	sort.Slice(sortedIngresses, func(i, j int) bool { return sortedIngresses[i].Name < sortedIngresses[j].Name })
+
+	ingressIdx := helper.IngressFindByHost(sortedIngresses, host)
+	if ingressIdx == -1 {
+		return &resourceFailure{
+			reason:  appsv1alpha1.APIManagerIngressNotFoundReason,
+			message: fmt.Sprintf("Ingress for host %s not found", host),
+		}
+	}
+
+	ingress := &sortedIngresses[ingressIdx]
+	if helper.IsIngressReady(ingress) {
+		return nil
+	}
+
+	return &resourceFailure{
+		reason:  appsv1alpha1.APIManagerIngressNotReadyReason,
+		message: fmt.Sprintf("Ingress %s for host %s has no load balancer address", ingress.Name, host),
+	}
+}
+
+// monitoringFailures reports the monitoring APIs required by the enabled monitoring resources
+// that are not installed in the cluster
+func (s *APIManagerStatusReconciler) monitoringFailures() ([]resourceFailure, error) {
+	type monitoringAPI struct {
+		kind     string
+		operator string
+		exists   func() (bool, error)
+	}
+
+	apis := []monitoringAPI{
+		{"GrafanaDashboard", "grafana-operator", s.HasGrafanaDashboards},
+		{"PodMonitor", "prometheus-operator", s.HasPodMonitors},
+		{"ServiceMonitor", "prometheus-operator", s.HasServiceMonitors},
+	}
+	if s.apimanagerResource.IsPrometheusRulesEnabled() {
+		apis = append(apis, monitoringAPI{"PrometheusRule", "prometheus-operator", s.HasPrometheusRules})
+	}
+
+	failures := []resourceFailure{}
+	for _, api := range apis {
+		exists, err := api.exists()
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			failures = append(failures, resourceFailure{
+				reason:  appsv1alpha1.APIManagerMonitoringAPINotFoundReason,
+				message: fmt.Sprintf("%s API not found. Install %s in the cluster", api.kind, api.operator),
+			})
+		}
+	}
+
+	return failures, nil
+}
+
+// componentImages returns the image of the first container of each component, sorted by component name.
+// When Kubernetes Deployments are enabled, DeploymentConfigs being migrated are reported until
+// the Deployment replacing them exists
+func (s *APIManagerStatusReconciler) componentImages(deploymentConfigs []appsv1.DeploymentConfig, deployments []k8sappsv1.Deployment) []appsv1alpha1.ComponentImage {
+	images := map[string]string{}
+
+	for idx := range deploymentConfigs {
+		if deploymentConfigs[idx].Spec.Template == nil || len(deploymentConfigs[idx].Spec.Template.Spec.Containers) == 0 {
+			continue
+		}
+		images[deploymentConfigs[idx].Name] = deploymentConfigs[idx].Spec.Template.Spec.Containers[0].Image
+	}
+
+	for idx := range deployments {
+		if len(deployments[idx].Spec.Template.Spec.Containers) == 0 {
+			continue
+		}
+		images[deployments[idx].Name] = deployments[idx].Spec.Template.Spec.Containers[0].Image
+	}
+
+	var result []appsv1alpha1.ComponentImage
+	for name, image := range images {
+		result = append(result, appsv1alpha1.ComponentImage{Name: name, Image: image})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return result
+}
@@ -0,0 +1,384 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
+	"github.com/3scale/3scale-operator/pkg/apispkg/common"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	"github.com/3scale/3scale-operator/version"
+	appsv1 "github.com/openshift/api/apps/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func statusTestAPIManager() *appsv1alpha1.APIManager {
+	trueValue := true
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "example-apimanager",
+			Namespace:  "someNS",
+			UID:        types.UID("apimanager-uid"),
+			Generation: 3,
+		},
+		Spec: appsv1alpha1.APIManagerSpec{
+			APIManagerCommonSpec: appsv1alpha1.APIManagerCommonSpec{
+				WildcardDomain: "example.com",
+			},
+			Monitoring: &appsv1alpha1.MonitoringSpec{Enabled: true, EnablePrometheusRules: &trueValue},
+		},
+	}
+	if _, err := apimanager.SetDefaults(); err != nil {
+		panic(err)
+	}
+	return apimanager
+}
+
+func statusTestDeploymentConfig(apimanager *appsv1alpha1.APIManager, name string, available bool) *appsv1.DeploymentConfig {
+	dc := &appsv1.DeploymentConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: apimanager.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{Name: apimanager.Name, UID: apimanager.UID},
+			},
+		},
+		Spec: appsv1.DeploymentConfigSpec{
+			Replicas: 1,
+			Template: &v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{{Name: name, Image: "quay.io/3scale/" + name + "@sha256:abc"}},
+				},
+			},
+		},
+		Status: appsv1.DeploymentConfigStatus{
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentAvailable, Status: v1.ConditionTrue},
+			},
+		},
+	}
+
+	if !available {
+		dc.Status.Conditions = []appsv1.DeploymentCondition{
+			{Type: appsv1.DeploymentAvailable, Status: v1.ConditionFalse, Message: "Deployment config does not have minimum availability."},
+			{Type: appsv1.DeploymentProgressing, Status: v1.ConditionFalse, Message: "replication controller \"" + name + "-2\" has failed progressing"},
+		}
+	}
+
+	return dc
+}
+
+func statusTestPVC(apimanager *appsv1alpha1.APIManager, name string, phase v1.PersistentVolumeClaimPhase) *v1.PersistentVolumeClaim {
+	return &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: apimanager.Namespace},
+		Status:     v1.PersistentVolumeClaimStatus{Phase: phase},
+	}
+}
+
+func statusTestRoute(apimanager *appsv1alpha1.APIManager, name, host string, admitted bool) *routev1.Route {
+	admittedStatus := v1.ConditionTrue
+	message := ""
+	if !admitted {
+		admittedStatus = v1.ConditionFalse
+		message = "route host already claimed"
+	}
+
+	return &routev1.Route{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: apimanager.Namespace},
+		Spec:       routev1.RouteSpec{Host: host},
+		Status: routev1.RouteStatus{
+			Ingress: []routev1.RouteIngress{
+				{
+					Host: host,
+					Conditions: []routev1.RouteIngressCondition{
+						{Type: routev1.RouteAdmitted, Status: admittedStatus, Message: message},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestAPIManagerStatusSubsystemConditions(t *testing.T) {
+	ctx := context.TODO()
+	log := logf.Log.WithName("status_test")
+	apimanager := statusTestAPIManager()
+
+	objs := []runtime.Object{apimanager}
+
+	deploymentNames := []string{
+		component.ApicastStagingName, component.ApicastProductionName,
+		component.BackendListenerName, component.BackendWorkerName, component.BackendCronName,
+		component.SystemAppDeploymentName, component.SystemSidekiqName, component.SystemSearchdDeploymentName,
+		component.SystemMemcachedDeploymentName, component.ZyncName, component.ZyncQueDeploymentName,
+		component.SystemMySQLDeploymentName, component.SystemRedisDeploymentName,
+		component.BackendRedisDeploymentName, component.ZyncDatabaseDeploymentName,
+	}
+	for _, name := range deploymentNames {
+		objs = append(objs, statusTestDeploymentConfig(apimanager, name, name != component.SystemAppDeploymentName))
+	}
+
+	for _, name := range []string{
+		component.SystemFileStoragePVCName, component.SystemSearchdPVCName,
+		component.SystemRedisPVCName, component.BackendRedisPVCName,
+	} {
+		objs = append(objs, statusTestPVC(apimanager, name, v1.ClaimBound))
+	}
+	objs = append(objs, statusTestPVC(apimanager, component.SystemMySQLPVCName, v1.ClaimPending))
+
+	objs = append(objs,
+		statusTestRoute(apimanager, "backend", "backend-3scale.example.com", true),
+		statusTestRoute(apimanager, "apicast-production", "api-3scale-apicast-production.example.com", true),
+		statusTestRoute(apimanager, "apicast-staging", "api-3scale-apicast-staging.example.com", false),
+		statusTestRoute(apimanager, "master", "master.example.com", true),
+		statusTestRoute(apimanager, "developer", "3scale.example.com", true),
+		statusTestRoute(apimanager, "admin", "3scale-admin.example.com", true),
+	)
+
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+	if err := appsv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := routev1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	cl := fake.NewFakeClient(objs...)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+	baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, cl, log, clientset.Discovery(), recorder)
+
+	statusReconciler := NewAPIManagerStatusReconciler(baseReconciler, apimanager)
+	newStatus, err := statusReconciler.calculateStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		testName        string
+		conditionType   common.ConditionType
+		expectedStatus  v1.ConditionStatus
+		expectedReason  common.ConditionReason
+		expectedMessage string
+	}{
+		{"system", appsv1alpha1.APIManagerSystemAvailableConditionType, v1.ConditionFalse, appsv1alpha1.APIManagerDeploymentConfigNotAvailableReason, "has failed progressing"},
+		{"backend", appsv1alpha1.APIManagerBackendAvailableConditionType, v1.ConditionTrue, appsv1alpha1.APIManagerComponentAvailableReason, ""},
+		{"apicast", appsv1alpha1.APIManagerApicastAvailableConditionType, v1.ConditionFalse, appsv1alpha1.APIManagerRouteNotAdmittedReason, "route host already claimed"},
+		{"zync", appsv1alpha1.APIManagerZyncAvailableConditionType, v1.ConditionTrue, appsv1alpha1.APIManagerComponentAvailableReason, ""},
+		{"databases", appsv1alpha1.APIManagerDatabasesAvailableConditionType, v1.ConditionFalse, appsv1alpha1.APIManagerPVCNotBoundReason, "PersistentVolumeClaim mysql-storage is Pending"},
+		{"monitoring", appsv1alpha1.APIManagerMonitoringAvailableConditionType, v1.ConditionFalse, appsv1alpha1.APIManagerMonitoringAPINotFoundReason, "PodMonitor API not found"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			condition := newStatus.Conditions.GetCondition(tc.conditionType)
+			if condition == nil {
+				subT.Fatalf("condition %s not found", tc.conditionType)
+			}
+			if condition.Status != tc.expectedStatus {
+				subT.Errorf("status: expected %s, got %s", tc.expectedStatus, condition.Status)
+			}
+			if condition.Reason != tc.expectedReason {
+				subT.Errorf("reason: expected %s, got %s", tc.expectedReason, condition.Reason)
+			}
+			if !strings.Contains(condition.Message, tc.expectedMessage) {
+				subT.Errorf("message: expected to contain '%s', got '%s'", tc.expectedMessage, condition.Message)
+			}
+		})
+	}
+
+	if newStatus.ObservedGeneration != apimanager.Generation {
+		t.Errorf("observedGeneration: expected %d, got %d", apimanager.Generation, newStatus.ObservedGeneration)
+	}
+	if newStatus.OperatorVersion != version.Version {
+		t.Errorf("operatorVersion: expected %s, got %s", version.Version, newStatus.OperatorVersion)
+	}
+	if newStatus.ThreescaleRelease != product.ThreescaleRelease {
+		t.Errorf("threescaleRelease: expected %s, got %s", product.ThreescaleRelease, newStatus.ThreescaleRelease)
+	}
+	if len(newStatus.Images) != len(deploymentNames) {
+		t.Fatalf("images: expected %d, got %d", len(deploymentNames), len(newStatus.Images))
+	}
+	for idx := 1; idx < len(newStatus.Images); idx++ {
+		if newStatus.Images[idx-1].Name >= newStatus.Images[idx].Name {
+			t.Errorf("images not sorted by name")
+		}
+	}
+	if newStatus.Images[0].Name != component.ApicastProductionName ||
+		newStatus.Images[0].Image != "quay.io/3scale/"+component.ApicastProductionName+"@sha256:abc" {
+		t.Errorf("unexpected image: %v", newStatus.Images[0])
+	}
+}
+
+func TestAPIManagerStatusMonitoringDisabled(t *testing.T) {
+	ctx := context.TODO()
+	log := logf.Log.WithName("status_test")
+	apimanager := statusTestAPIManager()
+	apimanager.Spec.Monitoring = nil
+	apimanager.Status.Conditions = common.Conditions{
+		{Type: appsv1alpha1.APIManagerMonitoringAvailableConditionType, Status: v1.ConditionTrue},
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+	if err := appsv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := routev1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	cl := fake.NewFakeClient(apimanager)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+	baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, cl, log, clientset.Discovery(), recorder)
+
+	newStatus, err := NewAPIManagerStatusReconciler(baseReconciler, apimanager).calculateStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if newStatus.Conditions.GetCondition(appsv1alpha1.APIManagerMonitoringAvailableConditionType) != nil {
+		t.Errorf("unexpected monitoring condition with monitoring disabled")
+	}
+
+	condition := newStatus.Conditions.GetCondition(appsv1alpha1.APIManagerZyncAvailableConditionType)
+	if condition == nil || condition.Reason != appsv1alpha1.APIManagerDeploymentConfigNotFoundReason {
+		t.Errorf("unexpected zync condition: %v", condition)
+	}
+}
+
+func TestAPIManagerStatusSidekiqPools(t *testing.T) {
+	ctx := context.TODO()
+	log := logf.Log.WithName("status_test")
+	apimanager := statusTestAPIManager()
+	apimanager.Spec.System.SidekiqPools = []appsv1alpha1.SystemSidekiqPoolSpec{
+		{Name: "low", Queues: []appsv1alpha1.SidekiqQueueSpec{{Name: "low"}}},
+	}
+
+	objs := []runtime.Object{apimanager}
+	for _, name := range []string{
+		component.SystemAppDeploymentName, component.SystemSidekiqName,
+		component.SystemSearchdDeploymentName, component.SystemMemcachedDeploymentName,
+	} {
+		objs = append(objs, statusTestDeploymentConfig(apimanager, name, true))
+	}
+	for _, name := range []string{component.SystemFileStoragePVCName, component.SystemSearchdPVCName} {
+		objs = append(objs, statusTestPVC(apimanager, name, v1.ClaimBound))
+	}
+	objs = append(objs,
+		statusTestRoute(apimanager, "master", "master.example.com", true),
+		statusTestRoute(apimanager, "developer", "3scale.example.com", true),
+		statusTestRoute(apimanager, "admin", "3scale-admin.example.com", true),
+	)
+
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+	if err := appsv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := routev1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	cl := fake.NewFakeClient(objs...)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+	baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, cl, log, clientset.Discovery(), recorder)
+
+	statusReconciler := NewAPIManagerStatusReconciler(baseReconciler, apimanager)
+	newStatus, err := statusReconciler.calculateStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	condition := newStatus.Conditions.GetCondition(appsv1alpha1.APIManagerSystemAvailableConditionType)
+	if condition == nil || condition.Status != v1.ConditionFalse ||
+		condition.Reason != appsv1alpha1.APIManagerDeploymentConfigNotFoundReason ||
+		!strings.Contains(condition.Message, component.SidekiqPoolName("low")) {
+		t.Errorf("unexpected system condition: %v", condition)
+	}
+
+	poolExpected := false
+	for _, name := range statusReconciler.expectedDeploymentNames(apimanager) {
+		if name == component.SidekiqPoolName("low") {
+			poolExpected = true
+		}
+	}
+	if !poolExpected {
+		t.Errorf("expected deployment names do not include %s", component.SidekiqPoolName("low"))
+	}
+}
+
+func TestAPIManagerStatusReconciliationPaused(t *testing.T) {
+	ctx := context.TODO()
+	log := logf.Log.WithName("status_test")
+	apimanager := statusTestAPIManager()
+	apimanager.Annotations = map[string]string{appsv1alpha1.PausedAnnotation: "true"}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+	if err := appsv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := routev1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	cl := fake.NewFakeClient(apimanager)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10)
+	baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, cl, log, clientset.Discovery(), recorder)
+
+	if _, err := NewAPIManagerStatusReconciler(baseReconciler, apimanager).Reconcile(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !apimanager.Status.Conditions.IsTrueFor(appsv1alpha1.APIManagerReconciliationPausedConditionType) {
+		t.Errorf("ReconciliationPaused condition not true")
+	}
+	if !apimanager.Status.Conditions.IsFalseFor(appsv1alpha1.APIManagerMaintenanceModeConditionType) {
+		t.Errorf("MaintenanceMode condition not false")
+	}
+
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, "ReconciliationPaused") {
+			t.Errorf("unexpected event: %s", event)
+		}
+	default:
+		t.Errorf("ReconciliationPaused event not recorded")
+	}
+
+	// Resuming records a single event
+	delete(apimanager.Annotations, appsv1alpha1.PausedAnnotation)
+	if _, err := NewAPIManagerStatusReconciler(baseReconciler, apimanager).Reconcile(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, "ReconciliationResumed") {
+			t.Errorf("unexpected event: %s", event)
+		}
+	default:
+		t.Errorf("ReconciliationResumed event not recorded")
+	}
+
+	if len(recorder.Events) != 0 {
+		t.Errorf("unexpected events: %d", len(recorder.Events))
+	}
+}
@@ -7,15 +7,22 @@ import (
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/operator"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
 	"github.com/3scale/3scale-operator/pkg/apispkg/common"
 	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	"github.com/3scale/3scale-operator/version"
 	"github.com/RHsyseng/operator-utils/pkg/olm"
 	"github.com/go-logr/logr"
 	appsv1 "github.com/openshift/api/apps/v1"
 	routev1 "github.com/openshift/api/route/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -51,6 +58,7 @@ func (s *APIManagerStatusReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, nil
 	}
 
+	oldConditions := s.apimanagerResource.Status.Conditions.Copy()
 	s.apimanagerResource.Status = *newStatus
 	updateErr := s.Client().Status().Update(s.Context(), s.apimanagerResource)
 	if updateErr != nil {
@@ -62,31 +70,155 @@ func (s *APIManagerStatusReconciler) Reconcile() (reconcile.Result, error) {
 
 		return reconcile.Result{}, fmt.Errorf("Failed to update status: %w", updateErr)
 	}
+
+	s.recordSuspensionEvents(oldConditions, newStatus.Conditions)
+
 	return reconcile.Result{}, nil
 }
 
+// recordSuspensionEvents records an event when the reconciliation is paused or resumed
+// and when the maintenance mode changes state, so they show up in the APIManager events
+func (s *APIManagerStatusReconciler) recordSuspensionEvents(oldConditions, newConditions common.Conditions) {
+	for _, conditionType := range []common.ConditionType{
+		appsv1alpha1.APIManagerReconciliationPausedConditionType,
+		appsv1alpha1.APIManagerMaintenanceModeConditionType,
+	} {
+		newCondition := newConditions.GetCondition(conditionType)
+		if newCondition == nil {
+			continue
+		}
+
+		oldCondition := oldConditions.GetCondition(conditionType)
+		// Nothing was suspended before the condition was first set
+		if oldCondition == nil && newCondition.IsFalse() {
+			continue
+		}
+		if oldCondition != nil && oldCondition.Status == newCondition.Status && oldCondition.Reason == newCondition.Reason {
+			continue
+		}
+
+		// Event reasons: ReconciliationPaused, ReconciliationResumed and MaintenanceMode<ConditionReason>
+		eventReason := "MaintenanceMode" + string(newCondition.Reason)
+		message := newCondition.Message
+		if conditionType == appsv1alpha1.APIManagerReconciliationPausedConditionType {
+			eventReason = "ReconciliationPaused"
+			if newCondition.IsFalse() {
+				eventReason = "ReconciliationResumed"
+				message = "Reconciliation resumed"
+			}
+		} else if newCondition.IsFalse() {
+			message = "Maintenance mode finished. All components restored"
+		}
+
+		s.EventRecorder().Event(s.apimanagerResource, v1.EventTypeNormal, eventReason, message)
+	}
+}
+
 func (s *APIManagerStatusReconciler) calculateStatus() (*appsv1alpha1.APIManagerStatus, error) {
 	newStatus := &appsv1alpha1.APIManagerStatus{}
 
-	deployments, err := s.existingDeployments()
+	deploymentConfigs, err := s.existingDeployments()
 	if err != nil {
 		return nil, err
 	}
 
+	var deploymentsAvailable bool
+	var deployments []k8sappsv1.Deployment
+	if s.apimanagerResource.IsKubernetesDeploymentEnabled() {
+		deployments, err = s.existingKubernetesDeployments()
+		if err != nil {
+			return nil, err
+		}
+
+		// DeploymentConfigs being migrated keep serving until the Deployments are available
+		deploymentsAvailable = s.kubernetesDeploymentsAvailable(deployments, deploymentConfigs)
+		newStatus.Deployments = olm.GetDeploymentStatus(deployments)
+	} else {
+		deploymentsAvailable = s.deploymentsAvailable(deploymentConfigs)
+		newStatus.Deployments = olm.GetDeploymentConfigStatus(deploymentConfigs)
+	}
+
 	newStatus.Conditions = s.apimanagerResource.Status.Conditions.Copy()
 
-	availableCondition, err := s.apimanagerAvailableCondition(deployments)
+	availableCondition, err := s.apimanagerAvailableCondition(deploymentsAvailable)
 	if err != nil {
 		return nil, err
 	}
 	newStatus.Conditions.SetCondition(availableCondition)
 
-	deploymentStatus := olm.GetDeploymentConfigStatus(deployments)
-	newStatus.Deployments = deploymentStatus
+	subsystemConditions, err := s.subsystemConditions(deploymentConfigs, deployments)
+	if err != nil {
+		return nil, err
+	}
+	for _, condition := range subsystemConditions {
+		newStatus.Conditions.SetCondition(condition)
+	}
+	if !s.apimanagerResource.IsMonitoringEnabled() {
+		newStatus.Conditions.RemoveCondition(appsv1alpha1.APIManagerMonitoringAvailableConditionType)
+	}
+
+	newStatus.Conditions.SetCondition(s.reconciliationPausedCondition())
+	newStatus.Conditions.SetCondition(operator.MaintenanceModeCondition(s.apimanagerResource, deploymentConfigs, deployments))
+
+	smtpCondition, err := operator.SMTPCheckCondition(s.apimanagerResource, s.Client())
+	if err != nil {
+		return nil, err
+	}
+	if smtpCondition != nil {
+		newStatus.Conditions.SetCondition(*smtpCondition)
+	} else {
+		newStatus.Conditions.RemoveCondition(appsv1alpha1.APIManagerSMTPReachableConditionType)
+	}
+
+	newStatus.Images = s.componentImages(deploymentConfigs, deployments)
+
+	newStatus.PinnedImages, err = s.pinnedImages()
+	if err != nil {
+		return nil, err
+	}
+	newStatus.SecretRotation, err = operator.SecretRotationStatus(s.apimanagerResource, s.Client())
+	if err != nil {
+		return nil, err
+	}
+	newStatus.Certificates, err = operator.CertificatesStatus(s.apimanagerResource, s.Client())
+	if err != nil {
+		return nil, err
+	}
+
+	newStatus.ObservedGeneration = s.apimanagerResource.Generation
+	newStatus.OperatorVersion = version.Version
+	newStatus.ThreescaleRelease = product.ThreescaleRelease
 
 	return newStatus, nil
 }
 
+func (s *APIManagerStatusReconciler) pinnedImages() ([]appsv1alpha1.PinnedImage, error) {
+	// Digests are resolved from the imagestreams
+	hasImageStreams, err := s.HasImageStreams()
+	if err != nil || !hasImageStreams {
+		return nil, err
+	}
+
+	return operator.PinnedImages(s.apimanagerResource, s.Client())
+}
+
+func (s *APIManagerStatusReconciler) reconciliationPausedCondition() common.Condition {
+	if s.apimanagerResource.IsReconciliationPaused() {
+		return common.Condition{
+			Type:    appsv1alpha1.APIManagerReconciliationPausedConditionType,
+			Status:  v1.ConditionTrue,
+			Reason:  appsv1alpha1.APIManagerReconciliationPausedReason,
+			Message: fmt.Sprintf("Components are not reconciled while the %s annotation is set to true", appsv1alpha1.PausedAnnotation),
+		}
+	}
+
+	return common.Condition{
+		Type:   appsv1alpha1.APIManagerReconciliationPausedConditionType,
+		Status: v1.ConditionFalse,
+		Reason: appsv1alpha1.APIManagerReconciliationActiveReason,
+	}
+}
+
 func (s *APIManagerStatusReconciler) expectedDeploymentNames(instance *appsv1alpha1.APIManager) []string {
 	var systemDatabaseType component.SystemDatabaseType
 	var externalRedisDatabases bool
@@ -112,11 +244,26 @@ func (s *APIManagerStatusReconciler) expectedDeploymentNames(instance *appsv1alp
 		SystemDatabaseType:     systemDatabaseType,
 		ExternalRedisDatabases: externalRedisDatabases,
 		ExternalZyncDatabase:   externalZyncDatabase,
+
+		SystemAppSplitDeployments: instance.IsSystemAppSplitEnabled(),
+		SidekiqPools:              sidekiqPoolNames(instance),
 	}
 
 	return deploymentLister.DeploymentNames()
 }
 
+// sidekiqPoolNames returns the names of the system-sidekiq pools of the APIManager
+func sidekiqPoolNames(instance *appsv1alpha1.APIManager) []string {
+	names := []string{}
+	if instance.Spec.System == nil {
+		return names
+	}
+	for _, pool := range instance.Spec.System.SidekiqPools {
+		names = append(names, pool.Name)
+	}
+	return names
+}
+
 func (s *APIManagerStatusReconciler) deploymentsAvailable(existingDeployments []appsv1.DeploymentConfig) bool {
 	expectedDeploymentNames := s.expectedDeploymentNames(s.apimanagerResource)
 	for _, deploymentName := range expectedDeploymentNames {
@@ -135,6 +282,53 @@ func (s *APIManagerStatusReconciler) deploymentsAvailable(existingDeployments []
 	return true
 }
 
+func (s *APIManagerStatusReconciler) kubernetesDeploymentsAvailable(existingDeployments []k8sappsv1.Deployment, existingDeploymentConfigs []appsv1.DeploymentConfig) bool {
+	expectedDeploymentNames := s.expectedDeploymentNames(s.apimanagerResource)
+	for _, deploymentName := range expectedDeploymentNames {
+		available := false
+		for idx := range existingDeployments {
+			if existingDeployments[idx].Name == deploymentName {
+				available = helper.IsDeploymentAvailable(&existingDeployments[idx])
+				break
+			}
+		}
+		for idx := range existingDeploymentConfigs {
+			if !available && existingDeploymentConfigs[idx].Name == deploymentName {
+				available = helper.IsDeploymentConfigAvailable(&existingDeploymentConfigs[idx])
+				break
+			}
+		}
+		if !available {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *APIManagerStatusReconciler) existingKubernetesDeployments() ([]k8sappsv1.Deployment, error) {
+	expectedDeploymentNames := s.expectedDeploymentNames(s.apimanagerResource)
+
+	var deployments []k8sappsv1.Deployment
+	for _, deploymentName := range expectedDeploymentNames {
+		existingDeployment := &k8sappsv1.Deployment{}
+		err := s.Client().Get(context.Background(), types.NamespacedName{Namespace: s.apimanagerResource.Namespace, Name: deploymentName}, existingDeployment)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, err
+		}
+		if err != nil && errors.IsNotFound(err) {
+			continue
+		}
+
+		if metav1.IsControlledBy(existingDeployment, s.apimanagerResource) {
+			deployments = append(deployments, *existingDeployment)
+		}
+	}
+	sort.Slice(deployments, func(i, j int) bool { return deployments[i].Name < deployments[j].Name })
+
+	return deployments, nil
+}
+
 func (s *APIManagerStatusReconciler) existingDeployments() ([]appsv1.DeploymentConfig, error) {
 	expectedDeploymentNames := s.expectedDeploymentNames(s.apimanagerResource)
 
@@ -142,6 +336,10 @@ func (s *APIManagerStatusReconciler) existingDeployments() ([]appsv1.DeploymentC
 	for _, dcName := range expectedDeploymentNames {
 		existingDeploymentConfig := &appsv1.DeploymentConfig{}
 		err := s.Client().Get(context.Background(), types.NamespacedName{Namespace: s.apimanagerResource.Namespace, Name: dcName}, existingDeploymentConfig)
+		// DeploymentConfigs are not available out of OpenShift
+		if meta.IsNoMatchError(err) && s.apimanagerResource.IsKubernetesDeploymentEnabled() {
+			return nil, nil
+		}
 		if err != nil && !errors.IsNotFound(err) {
 			return nil, err
 		}
@@ -161,10 +359,14 @@ func (s *APIManagerStatusReconciler) existingDeployments() ([]appsv1.DeploymentC
 	return dcs, nil
 }
 
-func (s *APIManagerStatusReconciler) apimanagerAvailableCondition(existingDeployments []appsv1.DeploymentConfig) (common.Condition, error) {
-	deploymentsAvailable := s.deploymentsAvailable(existingDeployments)
-
-	defaultRoutesReady, err := s.defaultRoutesReady()
+func (s *APIManagerStatusReconciler) apimanagerAvailableCondition(deploymentsAvailable bool) (common.Condition, error) {
+	var defaultRoutesReady bool
+	var err error
+	if s.apimanagerResource.IsIngressEnabled() {
+		defaultRoutesReady, err = s.defaultIngressesReady()
+	} else {
+		defaultRoutesReady, err = s.defaultRoutesReady()
+	}
 	if err != nil {
 		return common.Condition{}, err
 	}
@@ -182,16 +384,39 @@ func (s *APIManagerStatusReconciler) apimanagerAvailableCondition(existingDeploy
 	return newAvailableCondition, nil
 }
 
-func (s *APIManagerStatusReconciler) defaultRoutesReady() (bool, error) {
+func (s *APIManagerStatusReconciler) expectedDefaultHosts() []string {
+	hosts := s.backendDefaultHosts()
+	hosts = append(hosts, s.apicastDefaultHosts()...)
+	hosts = append(hosts, s.systemDefaultHosts()...)
+	return hosts
+}
+
+func (s *APIManagerStatusReconciler) backendDefaultHosts() []string {
+	wildcardDomain := s.apimanagerResource.Spec.WildcardDomain
+	return []string{
+		fmt.Sprintf("backend-%s.%s", *s.apimanagerResource.Spec.TenantName, wildcardDomain), // Backend Listener route
+	}
+}
+
+func (s *APIManagerStatusReconciler) apicastDefaultHosts() []string {
 	wildcardDomain := s.apimanagerResource.Spec.WildcardDomain
-	expectedRouteHosts := []string{
-		fmt.Sprintf("backend-%s.%s", *s.apimanagerResource.Spec.TenantName, wildcardDomain),                // Backend Listener route
+	return []string{
 		fmt.Sprintf("api-%s-apicast-production.%s", *s.apimanagerResource.Spec.TenantName, wildcardDomain), // Apicast Production default tenant Route
 		fmt.Sprintf("api-%s-apicast-staging.%s", *s.apimanagerResource.Spec.TenantName, wildcardDomain),    // Apicast Staging default tenant Route
-		fmt.Sprintf("master.%s", wildcardDomain),                                                           // System's Master Portal Route
-		fmt.Sprintf("%s.%s", *s.apimanagerResource.Spec.TenantName, wildcardDomain),                        // System's default tenant Developer Portal Route
-		fmt.Sprintf("%s-admin.%s", *s.apimanagerResource.Spec.TenantName, wildcardDomain),                  // System's default tenant Admin Portal Route
 	}
+}
+
+func (s *APIManagerStatusReconciler) systemDefaultHosts() []string {
+	wildcardDomain := s.apimanagerResource.Spec.WildcardDomain
+	return []string{
+		fmt.Sprintf("master.%s", wildcardDomain),                                          // System's Master Portal Route
+		fmt.Sprintf("%s.%s", *s.apimanagerResource.Spec.TenantName, wildcardDomain),       // System's default tenant Developer Portal Route
+		fmt.Sprintf("%s-admin.%s", *s.apimanagerResource.Spec.TenantName, wildcardDomain), // System's default tenant Admin Portal Route
+	}
+}
+
+func (s *APIManagerStatusReconciler) defaultRoutesReady() (bool, error) {
+	expectedRouteHosts := s.expectedDefaultHosts()
 
 	listOps := []client.ListOption{
 		client.InNamespace(s.apimanagerResource.Namespace),
@@ -224,3 +449,34 @@ func (s *APIManagerStatusReconciler) defaultRoutesReady() (bool, error) {
 
 	return allDefaultRoutesReady, nil
 }
+
+func (s *APIManagerStatusReconciler) defaultIngressesReady() (bool, error) {
+	expectedIngressHosts := s.expectedDefaultHosts()
+
+	listOps := []client.ListOption{
+		client.InNamespace(s.apimanagerResource.Namespace),
+	}
+
+	ingressList := &networkingv1.IngressList{}
+	err := s.Client().List(context.TODO(), ingressList, listOps...)
+	if err != nil {
+		return false, fmt.Errorf("Failed to list ingresses: %w", err)
+	}
+
+	ingresses := append([]networkingv1.Ingress(nil), ingressList.Items...)
+	sort.Slice(ingresses, func(i, j int) bool { return ingresses[i].Name < ingresses[j].Name })
+
+	allDefaultIngressesReady := true
+	for _, expectedIngressHost := range expectedIngressHosts {
+		ingressIdx := helper.IngressFindByHost(ingresses, expectedIngressHost)
+		if ingressIdx == -1 {
+			s.logger.V(1).Info("Status defaultIngressesReady: ingress not found", "expectedIngressHost", expectedIngressHost)
+			allDefaultIngressesReady = false
+		} else if !helper.IsIngressReady(&ingresses[ingressIdx]) {
+			s.logger.V(1).Info("Status defaultIngressesReady: ingress not ready", "expectedIngressHost", expectedIngressHost)
+			allDefaultIngressesReady = false
+		}
+	}
+
+	return allDefaultIngressesReady, nil
+}
@@ -21,12 +21,16 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 )
 
+// apiManagerBackupFinalizer resumes the APIManager workers paused by a backup deleted while running
+const apiManagerBackupFinalizer = "apimanagerbackup.apps.3scale.net/finalizer"
+
 // APIManagerBackupReconciler reconciles a APIManagerBackup object
 type APIManagerBackupReconciler struct {
 	*reconcilers.BaseReconciler
@@ -56,6 +60,33 @@ func (r *APIManagerBackupReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, err
 	}
 
+	// APIManagerBackup has been marked for deletion
+	if instance.GetDeletionTimestamp() != nil {
+		if controllerutil.ContainsFinalizer(instance, apiManagerBackupFinalizer) {
+			err = r.resumeWorkersOfDeletedBackup(instance)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+
+			controllerutil.RemoveFinalizer(instance, apiManagerBackupFinalizer)
+			err = r.UpdateResource(instance)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(instance, apiManagerBackupFinalizer) {
+		controllerutil.AddFinalizer(instance, apiManagerBackupFinalizer)
+		err = r.UpdateResource(instance)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
 	res, err := r.setAPIManagerBackupDefaults(instance)
 	if err != nil {
 		logger.Error(err, "Error")
@@ -112,6 +143,23 @@ func (r *APIManagerBackupReconciler) setAPIManagerBackupDefaults(cr *appsv1alpha
 	return reconcile.Result{Requeue: changed}, err
 }
 
+// resumeWorkersOfDeletedBackup resumes the APIManager workers when the backup is deleted while running.
+// Without a single APIManager in the namespace, there are no workers to resume
+func (r *APIManagerBackupReconciler) resumeWorkersOfDeletedBackup(cr *appsv1alpha1.APIManagerBackup) error {
+	if cr.BackupCompleted() || cr.BackupFailed() {
+		return nil
+	}
+
+	apiManagerBackupLogicReconciler, err := r.apiManagerBackupLogicReconciler(cr)
+	if err != nil {
+		r.Logger().Info("APIManager workers not resumed on backup deletion", "apimanagerbackup", cr.Name, "reason", err.Error())
+		return nil
+	}
+
+	_, err = apiManagerBackupLogicReconciler.ReconcileDeletion()
+	return err
+}
+
 func (r *APIManagerBackupReconciler) apiManagerBackupLogicReconciler(cr *appsv1alpha1.APIManagerBackup) (*APIManagerBackupLogicReconciler, error) {
 	return NewAPIManagerBackupLogicReconciler(r.BaseReconciler, cr)
 }
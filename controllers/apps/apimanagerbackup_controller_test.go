@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+)
+
+func TestAPIManagerBackupReconcilerDeletionResumesWorkers(t *testing.T) {
+	s := scheme.Scheme
+	if err := appsv1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name          string
+		owner         string
+		expectResumed bool
+	}{
+		{"PausedByBackup", "backup-uid", true},
+		{"PausedByOtherResource", "other-uid", false},
+		{"PausedManually", "", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			namespace := "operator-unittest"
+			apimanager := &appsv1alpha1.APIManager{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "example-apimanager",
+					Namespace:   namespace,
+					Annotations: map[string]string{appsv1alpha1.PauseWorkersAnnotation: "true"},
+				},
+			}
+			if tc.owner != "" {
+				apimanager.Annotations[appsv1alpha1.PauseWorkersOwnerAnnotation] = tc.owner
+			}
+			deletionTimestamp := metav1.Now()
+			backupCR := &appsv1alpha1.APIManagerBackup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "mybackup",
+					Namespace:         namespace,
+					UID:               "backup-uid",
+					Finalizers:        []string{apiManagerBackupFinalizer},
+					DeletionTimestamp: &deletionTimestamp,
+				},
+				Spec: appsv1alpha1.APIManagerBackupSpec{
+					BackupDestination: appsv1alpha1.APIManagerBackupDestination{
+						PersistentVolumeClaim: &appsv1alpha1.PersistentVolumeClaimBackupDestination{},
+					},
+				},
+			}
+
+			cl := fake.NewClientBuilder().WithScheme(s).WithObjects(apimanager, backupCR).Build()
+			clientset := fakeclientset.NewSimpleClientset()
+			recorder := record.NewFakeRecorder(10000)
+			log := logf.Log.WithName("apimanagerbackup_test")
+			baseReconciler := reconcilers.NewBaseReconciler(context.TODO(), cl, s, cl, log, clientset.Discovery(), recorder)
+			r := &APIManagerBackupReconciler{BaseReconciler: baseReconciler}
+
+			key := types.NamespacedName{Name: backupCR.Name, Namespace: namespace}
+			_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: key})
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			updatedAPIManager := &appsv1alpha1.APIManager{}
+			if err := cl.Get(context.TODO(), client.ObjectKeyFromObject(apimanager), updatedAPIManager); err != nil {
+				subT.Fatal(err)
+			}
+			if updatedAPIManager.IsPauseWorkersEnabled() == tc.expectResumed {
+				subT.Errorf("expected workers resumed %t on backup deletion, got annotations %v", tc.expectResumed, updatedAPIManager.Annotations)
+			}
+
+			updatedBackup := &appsv1alpha1.APIManagerBackup{}
+			err = cl.Get(context.TODO(), key, updatedBackup)
+			if err == nil && len(updatedBackup.Finalizers) > 0 {
+				subT.Errorf("expected finalizer removed, got %v", updatedBackup.Finalizers)
+			} else if err != nil && !errors.IsNotFound(err) {
+				subT.Fatal(err)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ import (
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/backup"
 	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 )
 
@@ -70,6 +71,11 @@ func (r *APIManagerBackupLogicReconciler) Reconcile() (reconcile.Result, error)
 		return reconcile.Result{}, nil
 	}
 
+	if r.cr.BackupFailed() {
+		r.Logger().Info("Backup failed. End of reconciliation")
+		return r.reconcileResumeWorkers()
+	}
+
 	if !r.cr.MainStepsCompleted() {
 		r.Logger().Info("Reconciling backup steps")
 		result, err := r.reconcileMainSteps()
@@ -88,6 +94,27 @@ func (r *APIManagerBackupLogicReconciler) Reconcile() (reconcile.Result, error)
 	return reconcile.Result{}, nil
 }
 
+// ReconcileDeletion resumes the APIManager workers when the backup is deleted
+// after pausing them and before the databases backup job succeeded
+func (r *APIManagerBackupLogicReconciler) ReconcileDeletion() (reconcile.Result, error) {
+	desired := r.apiManagerBackup.BackupDatabasesJob()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	existing := &batchv1.Job{}
+	err := r.GetResource(types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	if err != nil && !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+	if err == nil && existing.Status.Succeeded == *desired.Spec.Completions {
+		// Workers already resumed
+		return reconcile.Result{}, nil
+	}
+
+	return r.reconcileResumeWorkers()
+}
+
 func (r *APIManagerBackupLogicReconciler) reconcileMainSteps() (reconcile.Result, error) {
 	result, err := r.reconcileAPIManagerSourceStatusField()
 	if result.Requeue || err != nil {
@@ -99,7 +126,7 @@ func (r *APIManagerBackupLogicReconciler) reconcileMainSteps() (reconcile.Result
 		return result, err
 	}
 
-	result, err = r.reconcileBackupInPVCDestination()
+	result, err = r.reconcileBackupInDestination()
 	if result.Requeue || err != nil {
 		return result, err
 	}
@@ -140,7 +167,7 @@ func (r *APIManagerBackupLogicReconciler) reconcileSetMainStepsCompleted() (reco
 	return reconcile.Result{}, nil
 }
 
-func (r *APIManagerBackupLogicReconciler) reconcileBackupInPVCDestination() (reconcile.Result, error) {
+func (r *APIManagerBackupLogicReconciler) reconcileBackupInDestination() (reconcile.Result, error) {
 	var res reconcile.Result
 	var err error
 
@@ -154,22 +181,37 @@ func (r *APIManagerBackupLogicReconciler) reconcileBackupInPVCDestination() (rec
 		return res, err
 	}
 
+	res, err = r.reconcileBackupDestinationS3Status()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
 	res, err = r.reconcileBackupJobsPermissions()
 	if res.Requeue || err != nil {
 		return res, err
 	}
 
-	res, err = r.reconcileBackupSecretsAndConfigMapsToPVCJob()
+	res, err = r.reconcileBackupSecretsAndConfigMapsJob()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	res, err = r.reconcileAPIManagerCustomResourceBackupJob()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	res, err = r.reconcileBackupSystemFileStorageJob()
 	if res.Requeue || err != nil {
 		return res, err
 	}
 
-	res, err = r.reconcileAPIManagerCustomResourceBackupToPVCJob()
+	res, err = r.reconcileBackupDatabasesJob()
 	if res.Requeue || err != nil {
 		return res, err
 	}
 
-	res, err = r.reconcileBackupSystemFileStoragePVCToPVCJob()
+	res, err = r.reconcileBackupManifestJob()
 	if res.Requeue || err != nil {
 		return res, err
 	}
@@ -223,6 +265,11 @@ func (r *APIManagerBackupLogicReconciler) reconcileJob(desired *batchv1.Job) (re
 	// Jobs ownerReference or labels nor annotations not reconciled
 	// Jobs are one-shot so there's not much point on making updates to them
 
+	if helper.JobConditionTrue(existing, batchv1.JobFailed) {
+		r.Logger().Info("Job failed", "Job Name", desired.Name, "Failed pods", existing.Status.Failed)
+		return r.reconcileBackupFailed()
+	}
+
 	if existing.Status.Succeeded != *desired.Spec.Completions {
 		r.Logger().Info("Job has still not finished", "Job Name", desired.Name, "Actively running Pods", existing.Status.Active, "Failed pods", existing.Status.Failed)
 		return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
@@ -232,8 +279,8 @@ func (r *APIManagerBackupLogicReconciler) reconcileJob(desired *batchv1.Job) (re
 	return reconcile.Result{}, nil
 }
 
-func (r *APIManagerBackupLogicReconciler) reconcileBackupSecretsAndConfigMapsToPVCJob() (reconcile.Result, error) {
-	desired := r.apiManagerBackup.BackupSecretsAndConfigMapsToPVCJob()
+func (r *APIManagerBackupLogicReconciler) reconcileBackupSecretsAndConfigMapsJob() (reconcile.Result, error) {
+	desired := r.apiManagerBackup.BackupSecretsAndConfigMapsJob()
 	if desired == nil {
 		return reconcile.Result{}, nil
 	}
@@ -241,8 +288,8 @@ func (r *APIManagerBackupLogicReconciler) reconcileBackupSecretsAndConfigMapsToP
 	return r.reconcileJob(desired)
 }
 
-func (r *APIManagerBackupLogicReconciler) reconcileAPIManagerCustomResourceBackupToPVCJob() (reconcile.Result, error) {
-	desired := r.apiManagerBackup.BackupAPIManagerCustomResourceToPVCJob()
+func (r *APIManagerBackupLogicReconciler) reconcileAPIManagerCustomResourceBackupJob() (reconcile.Result, error) {
+	desired := r.apiManagerBackup.BackupAPIManagerCustomResourceJob()
 	if desired == nil {
 		return reconcile.Result{}, nil
 	}
@@ -250,8 +297,8 @@ func (r *APIManagerBackupLogicReconciler) reconcileAPIManagerCustomResourceBacku
 	return r.reconcileJob(desired)
 }
 
-func (r *APIManagerBackupLogicReconciler) reconcileBackupSystemFileStoragePVCToPVCJob() (reconcile.Result, error) {
-	desired := r.apiManagerBackup.BackupSystemFileStoragePVCToPVCJob()
+func (r *APIManagerBackupLogicReconciler) reconcileBackupSystemFileStorageJob() (reconcile.Result, error) {
+	desired := r.apiManagerBackup.BackupSystemFileStorageJob()
 	if desired == nil {
 		return reconcile.Result{}, nil
 	}
@@ -259,6 +306,80 @@ func (r *APIManagerBackupLogicReconciler) reconcileBackupSystemFileStoragePVCToP
 	return r.reconcileJob(desired)
 }
 
+// reconcileBackupDatabasesJob pauses the APIManager workers while the databases are dumped,
+// and resumes them once the job has finished
+func (r *APIManagerBackupLogicReconciler) reconcileBackupDatabasesJob() (reconcile.Result, error) {
+	desired := r.apiManagerBackup.BackupDatabasesJob()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	existing := &batchv1.Job{}
+	err := r.GetResource(types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	if err != nil && !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+	if err == nil && existing.Status.Succeeded == *desired.Spec.Completions {
+		return r.reconcileResumeWorkers()
+	}
+
+	res, err := r.reconcilePauseWorkers()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	return r.reconcileJob(desired)
+}
+
+// reconcileBackupManifestJob writes the backup manifest once all the backup data has been written
+func (r *APIManagerBackupLogicReconciler) reconcileBackupManifestJob() (reconcile.Result, error) {
+	desired := r.apiManagerBackup.BackupManifestJob()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	return r.reconcileJob(desired)
+}
+
+// reconcilePauseWorkers pauses the APIManager workers on behalf of the backup.
+// Workers paused by another resource are waited for, as that resource resumes them.
+// Workers paused manually are left paused after the backup
+func (r *APIManagerBackupLogicReconciler) reconcilePauseWorkers() (reconcile.Result, error) {
+	apiManager := r.apiManagerBackup.APIManager()
+	owner := apiManager.PauseWorkersOwner()
+	if apiManager.IsPauseWorkersEnabled() && owner != "" && owner != r.cr.UID {
+		r.Logger().Info("APIManager workers paused by another resource. Waiting", "APIManager", apiManager.Name, "owner", owner)
+		return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
+	}
+
+	if !apiManager.IsPauseWorkersEnabled() {
+		apiManager.PauseWorkers(r.cr.UID)
+		r.Logger().Info("Pausing APIManager workers", "APIManager", apiManager.Name)
+		err := r.UpdateResource(apiManager)
+		return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, err
+	}
+
+	condition := apiManager.Status.Conditions.GetCondition(appsv1alpha1.APIManagerMaintenanceModeConditionType)
+	if condition == nil || (condition.Reason != appsv1alpha1.APIManagerWorkersPausedReason && condition.Reason != appsv1alpha1.APIManagerMaintenanceActiveReason) {
+		r.Logger().Info("APIManager workers not paused yet. Waiting", "APIManager", apiManager.Name)
+		return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// reconcileResumeWorkers resumes the APIManager workers only when paused by the backup
+func (r *APIManagerBackupLogicReconciler) reconcileResumeWorkers() (reconcile.Result, error) {
+	apiManager := r.apiManagerBackup.APIManager()
+	if apiManager.ResumeWorkers(r.cr.UID) {
+		r.Logger().Info("Resuming APIManager workers", "APIManager", apiManager.Name)
+		err := r.UpdateResource(apiManager)
+		return reconcile.Result{Requeue: true}, err
+	}
+
+	return reconcile.Result{}, nil
+}
+
 func (r *APIManagerBackupLogicReconciler) reconcileBackupCompletion() (reconcile.Result, error) {
 	if !r.cr.BackupCompleted() {
 		// TODO make this more robust only setting it in case all substeps have been completed?
@@ -275,6 +396,17 @@ func (r *APIManagerBackupLogicReconciler) reconcileBackupCompletion() (reconcile
 	return reconcile.Result{}, nil
 }
 
+// reconcileBackupFailed marks the backup as failed. The jobs are kept,
+// so the logs of the failed pods can be inspected
+func (r *APIManagerBackupLogicReconciler) reconcileBackupFailed() (reconcile.Result, error) {
+	backupFailed := true
+	completionTimeUTC := metav1.Time{Time: apimanagerbackupClock.Now().UTC()}
+	r.cr.Status.Failed = &backupFailed
+	r.cr.Status.CompletionTime = &completionTimeUTC
+	err := r.UpdateResourceStatus(r.cr)
+	return reconcile.Result{Requeue: true}, err
+}
+
 func (r *APIManagerBackupLogicReconciler) reconcileAPIManagerSourceStatusField() (reconcile.Result, error) {
 	apiManager := r.apiManagerBackup.APIManager()
 
@@ -322,19 +454,38 @@ func (r *APIManagerBackupLogicReconciler) reconcileBackupDestinationPVCStatus()
 	return reconcile.Result{}, nil
 }
 
+func (r *APIManagerBackupLogicReconciler) reconcileBackupDestinationS3Status() (reconcile.Result, error) {
+	if r.cr.Spec.BackupDestination.S3 == nil {
+		return reconcile.Result{}, nil
+	}
+
+	if r.cr.Status.BackupS3URL == nil {
+		backupS3URL := r.apiManagerBackup.BackupS3URL()
+		r.cr.Status.BackupS3URL = &backupS3URL
+		err := r.UpdateResourceStatus(r.cr)
+		return reconcile.Result{Requeue: true}, err
+	}
+	return reconcile.Result{}, nil
+}
+
 // Delete all K8s jobs created during the backup. The reason for this is that
 // some PVCs are referenced in the K8s Jobs and those PVCs cannot be deleted
 // while some pods reference them, even if in state Completed. By deleting the
 // K8s jobs we allow the cleanup to be possible
 func (r *APIManagerBackupLogicReconciler) reconcileJobsCleanup() (reconcile.Result, error) {
 	jobsToDelete := []*batchv1.Job{
-		r.apiManagerBackup.BackupSecretsAndConfigMapsToPVCJob(),
-		r.apiManagerBackup.BackupAPIManagerCustomResourceToPVCJob(),
-		r.apiManagerBackup.BackupSystemFileStoragePVCToPVCJob(),
+		r.apiManagerBackup.BackupSecretsAndConfigMapsJob(),
+		r.apiManagerBackup.BackupAPIManagerCustomResourceJob(),
+		r.apiManagerBackup.BackupSystemFileStorageJob(),
+		r.apiManagerBackup.BackupDatabasesJob(),
+		r.apiManagerBackup.BackupManifestJob(),
 	}
 
 	existingJobFound := false
 	for _, job := range jobsToDelete {
+		if job == nil {
+			continue
+		}
 		existingJob := &batchv1.Job{}
 		err := r.GetResource(types.NamespacedName{Name: job.Name, Namespace: job.Namespace}, existingJob)
 		if err != nil && !errors.IsNotFound(err) {
@@ -386,7 +537,7 @@ func (r *APIManagerBackupLogicReconciler) reconcileBackupJobsServiceAccount() (r
 }
 
 func (r *APIManagerBackupLogicReconciler) reconcileBackupJobsRole() (reconcile.Result, error) {
-	err := r.ReconcileResource(&rbacv1.Role{}, r.apiManagerBackup.Role(), reconcilers.CreateOnlyMutator)
+	err := r.ReconcileResource(&rbacv1.Role{}, r.apiManagerBackup.Role(), reconcilers.RoleRulesMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -0,0 +1,285 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kubeclock "k8s.io/utils/clock"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/backup"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+)
+
+var apimanagerbackupscheduleClock kubeclock.Clock = &kubeclock.RealClock{}
+
+// APIManagerBackupScheduleReconciler reconciles a APIManagerBackupSchedule object
+type APIManagerBackupScheduleReconciler struct {
+	*reconcilers.BaseReconciler
+}
+
+// blank assignment to verify that APIManagerBackupScheduleReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &APIManagerBackupScheduleReconciler{}
+
+// +kubebuilder:rbac:groups=apps.3scale.net,namespace=placeholder,resources=apimanagerbackupschedules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps.3scale.net,namespace=placeholder,resources=apimanagerbackupschedules/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=apps.3scale.net,namespace=placeholder,resources=apimanagerbackupschedules/finalizers,verbs=get;list;watch;create;update;patch;delete
+
+func (r *APIManagerBackupScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := r.Logger().WithValues("apimanagerbackupschedule", req.NamespacedName)
+	logger.Info("Reconciling APIManagerBackupSchedule")
+
+	instance := &appsv1alpha1.APIManagerBackupSchedule{}
+	err := r.Client().Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("APIManagerBackupSchedule not found")
+			deleteBackupScheduleMetrics(req.Namespace, req.Name)
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error getting APIManagerBackupSchedule")
+		return ctrl.Result{}, err
+	}
+
+	backups := &appsv1alpha1.APIManagerBackupList{}
+	err = r.Client().List(ctx, backups, client.InNamespace(instance.Namespace),
+		client.MatchingLabels{appsv1alpha1.APIManagerBackupScheduleLabel: instance.Name})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	res, err := r.reconcileStatus(instance, backups.Items)
+	if res.Requeue || err != nil {
+		return res, err
+	}
+	updateBackupScheduleMetrics(instance)
+
+	res, err = r.reconcileRetention(logger, instance, backups.Items)
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	res, err = r.reconcileSchedule(logger, instance)
+	if err != nil {
+		logger.Error(err, "Error during reconciliation")
+		return res, err
+	}
+
+	logger.Info("Reconciliation finished", "RequeueAfter", res.RequeueAfter)
+	return res, nil
+}
+
+func (r *APIManagerBackupScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&appsv1alpha1.APIManagerBackupSchedule{}).
+		Owns(&appsv1alpha1.APIManagerBackup{}).
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
+
+// reconcileStatus reports the backup in progress and the last successful and failed backups.
+// The last backups are kept in the status when they are pruned
+func (r *APIManagerBackupScheduleReconciler) reconcileStatus(schedule *appsv1alpha1.APIManagerBackupSchedule, backups []appsv1alpha1.APIManagerBackup) (reconcile.Result, error) {
+	desired := schedule.Status.DeepCopy()
+	desired.ActiveBackup = nil
+
+	for idx := range backups {
+		backup := &backups[idx]
+		switch {
+		case backup.BackupCompleted() && backup.Status.CompletionTime != nil:
+			if desired.LastSuccessfulBackup == nil || desired.LastSuccessfulBackup.CompletionTime.Before(backup.Status.CompletionTime) {
+				desired.LastSuccessfulBackup = &appsv1alpha1.APIManagerBackupScheduleRun{Name: backup.Name, CompletionTime: *backup.Status.CompletionTime}
+			}
+		case backup.BackupFailed() && backup.Status.CompletionTime != nil:
+			if desired.LastFailedBackup == nil || desired.LastFailedBackup.CompletionTime.Before(backup.Status.CompletionTime) {
+				desired.LastFailedBackup = &appsv1alpha1.APIManagerBackupScheduleRun{Name: backup.Name, CompletionTime: *backup.Status.CompletionTime}
+			}
+		case !backup.BackupCompleted() && !backup.BackupFailed():
+			desired.ActiveBackup = &backup.Name
+		}
+	}
+
+	if reflect.DeepEqual(&schedule.Status, desired) {
+		return reconcile.Result{}, nil
+	}
+
+	schedule.Status = *desired
+	return reconcile.Result{Requeue: true}, r.UpdateResourceStatus(schedule)
+}
+
+// reconcileRetention prunes the backups not kept by the retention policy, with their data.
+// The PersistentVolumeClaim of the backup is deleted, or the data in the object storage is
+// deleted by a job, before the APIManagerBackup is deleted
+func (r *APIManagerBackupScheduleReconciler) reconcileRetention(logger logr.Logger, schedule *appsv1alpha1.APIManagerBackupSchedule, backups []appsv1alpha1.APIManagerBackup) (reconcile.Result, error) {
+	pending := false
+	for _, cr := range backup.BackupsToPrune(backups, schedule.Spec.Retention) {
+		if cr.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		dataDeleted, err := r.deleteBackupData(logger, schedule, cr)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if !dataDeleted {
+			pending = true
+			continue
+		}
+
+		logger.Info("Pruning backup", "APIManagerBackup", cr.Name)
+		err = r.DeleteResource(cr)
+		if err != nil && !errors.IsNotFound(err) {
+			return reconcile.Result{}, err
+		}
+		r.EventRecorder().Eventf(schedule, v1.EventTypeNormal, "BackupPruned", "Pruned backup %s", cr.Name)
+	}
+
+	if pending {
+		return reconcile.Result{Requeue: true, RequeueAfter: 10 * time.Second}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+func (r *APIManagerBackupScheduleReconciler) deleteBackupData(logger logr.Logger, schedule *appsv1alpha1.APIManagerBackupSchedule, cr *appsv1alpha1.APIManagerBackup) (bool, error) {
+	if cr.Spec.BackupDestination.PersistentVolumeClaim != nil {
+		pvcName := cr.Name
+		if cr.Status.BackupPersistentVolumeClaimName != nil {
+			pvcName = *cr.Status.BackupPersistentVolumeClaimName
+		}
+		pvc := &v1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: pvcName, Namespace: cr.Namespace}}
+		logger.Info("Deleting backup PersistentVolumeClaim", "APIManagerBackup", cr.Name, "PersistentVolumeClaim", pvcName)
+		err := r.DeleteResource(pvc)
+		if err != nil && !errors.IsNotFound(err) {
+			return false, err
+		}
+		return true, nil
+	}
+
+	desired := backup.S3PruneJob(cr, r.awsCLIImageURL(schedule))
+	if desired == nil {
+		return true, nil
+	}
+
+	existing := &batchv1.Job{}
+	err := r.GetResource(types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	if errors.IsNotFound(err) {
+		if err := r.SetOwnerReference(schedule, desired); err != nil {
+			return false, err
+		}
+		logger.Info("Deleting backup data from the object storage", "APIManagerBackup", cr.Name, "Job", desired.Name)
+		return false, r.CreateResource(desired)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case helper.JobConditionTrue(existing, batchv1.JobComplete):
+		err := r.DeleteResource(existing, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !errors.IsNotFound(err) {
+			return false, err
+		}
+		return true, nil
+	case helper.JobConditionTrue(existing, batchv1.JobFailed):
+		// Kept to inspect the logs of the failed pods. Deleting the job retries the pruning
+		logger.Info("Job deleting the backup data failed", "APIManagerBackup", cr.Name, "Job", existing.Name)
+	}
+	return false, nil
+}
+
+// awsCLIImageURL returns the image deleting the backup data, mirrored as configured in the
+// schedule or, when not set, in the APIManager of the namespace, if any
+func (r *APIManagerBackupScheduleReconciler) awsCLIImageURL(schedule *appsv1alpha1.APIManagerBackupSchedule) string {
+	if len(schedule.Spec.ImageRegistryMirrors) > 0 {
+		return appsv1alpha1.MirroredImageURL(schedule.Spec.ImageRegistryMirrors, backup.AWSCLIImageURL())
+	}
+
+	apiManagers := &appsv1alpha1.APIManagerList{}
+	err := r.Client().List(context.TODO(), apiManagers, client.InNamespace(schedule.Namespace))
+	if err != nil || len(apiManagers.Items) != 1 {
+		return backup.AWSCLIImageURL()
+	}
+	return apiManagers.Items[0].MirroredImageURL(backup.AWSCLIImageURL())
+}
+
+// reconcileSchedule creates the backup of the most recent scheduled time missed since the
+// last scheduled backup. No backup is created while a backup is in progress, the missed
+// backup is created once it finishes
+func (r *APIManagerBackupScheduleReconciler) reconcileSchedule(logger logr.Logger, schedule *appsv1alpha1.APIManagerBackupSchedule) (reconcile.Result, error) {
+	cronSchedule, err := helper.ParseCronSchedule(schedule.Spec.Schedule)
+	if err != nil {
+		// Not retried until the schedule is updated
+		logger.Info("Invalid schedule", "error", err.Error())
+		scheduleError := err.Error()
+		if schedule.Status.ScheduleError == nil || *schedule.Status.ScheduleError != scheduleError {
+			schedule.Status.ScheduleError = &scheduleError
+			return reconcile.Result{}, r.UpdateResourceStatus(schedule)
+		}
+		return reconcile.Result{}, nil
+	}
+	if schedule.Status.ScheduleError != nil {
+		schedule.Status.ScheduleError = nil
+		return reconcile.Result{Requeue: true}, r.UpdateResourceStatus(schedule)
+	}
+
+	now := apimanagerbackupscheduleClock.Now().UTC()
+	lastScheduleTime := schedule.CreationTimestamp.Time.UTC()
+	if schedule.Status.LastScheduleTime != nil {
+		lastScheduleTime = schedule.Status.LastScheduleTime.Time.UTC()
+	}
+
+	scheduledTime := cronSchedule.Prev(lastScheduleTime, now)
+	if !scheduledTime.IsZero() && !schedule.Spec.Suspend && schedule.Status.ActiveBackup == nil {
+		desired := backup.ScheduledBackup(schedule, scheduledTime)
+		if err := r.SetOwnerReference(schedule, desired); err != nil {
+			return reconcile.Result{}, err
+		}
+		logger.Info("Creating scheduled backup", "APIManagerBackup", desired.Name)
+		err := r.CreateResource(desired)
+		if err != nil && !errors.IsAlreadyExists(err) {
+			return reconcile.Result{}, err
+		}
+		r.EventRecorder().Eventf(schedule, v1.EventTypeNormal, "BackupCreated", "Created backup %s", desired.Name)
+
+		schedule.Status.LastScheduleTime = &metav1.Time{Time: scheduledTime}
+		schedule.Status.ActiveBackup = &desired.Name
+		return reconcile.Result{Requeue: true}, r.UpdateResourceStatus(schedule)
+	}
+
+	if schedule.Spec.Suspend {
+		return reconcile.Result{}, nil
+	}
+
+	next := cronSchedule.Next(now)
+	if next.IsZero() {
+		return reconcile.Result{}, nil
+	}
+	return reconcile.Result{RequeueAfter: next.Sub(now)}, nil
+}
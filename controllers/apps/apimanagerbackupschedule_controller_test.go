@@ -0,0 +1,228 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/backup"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+)
+
+func backupScheduleTestReconciler(t *testing.T, objs ...client.Object) (*APIManagerBackupScheduleReconciler, client.Client) {
+	s := scheme.Scheme
+	if err := appsv1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build()
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+	log := logf.Log.WithName("backupschedule_test")
+	baseReconciler := reconcilers.NewBaseReconciler(context.TODO(), cl, s, cl, log, clientset.Discovery(), recorder)
+	return &APIManagerBackupScheduleReconciler{BaseReconciler: baseReconciler}, cl
+}
+
+func reconcileBackupSchedule(t *testing.T, r *APIManagerBackupScheduleReconciler, key types.NamespacedName) ctrl.Result {
+	for i := 0; i < 20; i++ {
+		res, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: key})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.Requeue {
+			return res
+		}
+	}
+	t.Fatal("reconciliation did not finish")
+	return ctrl.Result{}
+}
+
+func TestAPIManagerBackupScheduleReconciler(t *testing.T) {
+	created := time.Date(2023, time.January, 2, 1, 0, 0, 0, time.UTC)
+	fakeClock := clocktesting.NewFakeClock(created.Add(30 * time.Minute))
+	previousClock := apimanagerbackupscheduleClock
+	apimanagerbackupscheduleClock = fakeClock
+	defer func() { apimanagerbackupscheduleClock = previousClock }()
+
+	schedule := &appsv1alpha1.APIManagerBackupSchedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "nightly",
+			Namespace:         "3scale",
+			CreationTimestamp: metav1.Time{Time: created},
+		},
+		Spec: appsv1alpha1.APIManagerBackupScheduleSpec{
+			Schedule: "0 2 * * *",
+			BackupTemplate: appsv1alpha1.APIManagerBackupSpec{
+				BackupDestination: appsv1alpha1.APIManagerBackupDestination{
+					PersistentVolumeClaim: &appsv1alpha1.PersistentVolumeClaimBackupDestination{},
+				},
+			},
+			Retention:     &appsv1alpha1.APIManagerBackupRetention{KeepLast: pointer.Int32(1)},
+			SuccessWindow: &metav1.Duration{Duration: 26 * time.Hour},
+		},
+	}
+	key := types.NamespacedName{Name: schedule.Name, Namespace: schedule.Namespace}
+	r, cl := backupScheduleTestReconciler(t, schedule)
+
+	// Before the first scheduled time
+	res := reconcileBackupSchedule(t, r, key)
+	if res.RequeueAfter != 30*time.Minute {
+		t.Errorf("expected requeue after 30m, got %s", res.RequeueAfter)
+	}
+	if value := testutil.ToFloat64(backupScheduleLastSuccessTimestamp.WithLabelValues("3scale", "nightly")); value != float64(created.Unix()) {
+		t.Errorf("expected the creation time as last success, got %f", value)
+	}
+	if value := testutil.ToFloat64(backupScheduleSuccessWindow.WithLabelValues("3scale", "nightly")); value != 26*3600 {
+		t.Errorf("unexpected success window %f", value)
+	}
+
+	// The first backup is created
+	fakeClock.SetTime(created.Add(70 * time.Minute))
+	reconcileBackupSchedule(t, r, key)
+	first := &appsv1alpha1.APIManagerBackup{}
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: "nightly-20230102020000", Namespace: "3scale"}, first); err != nil {
+		t.Fatal(err)
+	}
+	if first.Labels[appsv1alpha1.APIManagerBackupScheduleLabel] != "nightly" || !metav1.IsControlledBy(first, schedule) {
+		t.Errorf("unexpected backup metadata %v", first.ObjectMeta)
+	}
+	if err := cl.Get(context.TODO(), key, schedule); err != nil {
+		t.Fatal(err)
+	}
+	if schedule.Status.ActiveBackup == nil || *schedule.Status.ActiveBackup != first.Name {
+		t.Errorf("unexpected active backup %v", schedule.Status.ActiveBackup)
+	}
+
+	// No backup is created while the first one is in progress
+	fakeClock.SetTime(created.Add(25 * time.Hour))
+	reconcileBackupSchedule(t, r, key)
+	backups := &appsv1alpha1.APIManagerBackupList{}
+	if err := cl.List(context.TODO(), backups); err != nil {
+		t.Fatal(err)
+	}
+	if len(backups.Items) != 1 {
+		t.Errorf("expected 1 backup, got %d", len(backups.Items))
+	}
+
+	// The first backup succeeds. The missed backup is created
+	completed := true
+	first.Status.Completed = &completed
+	first.Status.CompletionTime = &metav1.Time{Time: created.Add(24 * time.Hour)}
+	first.Status.BackupPersistentVolumeClaimName = &first.Name
+	if err := cl.Status().Update(context.TODO(), first); err != nil {
+		t.Fatal(err)
+	}
+	pvc := &v1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: first.Name, Namespace: "3scale"}}
+	if err := cl.Create(context.TODO(), pvc); err != nil {
+		t.Fatal(err)
+	}
+	reconcileBackupSchedule(t, r, key)
+	if err := cl.Get(context.TODO(), key, schedule); err != nil {
+		t.Fatal(err)
+	}
+	if schedule.Status.LastSuccessfulBackup == nil || schedule.Status.LastSuccessfulBackup.Name != first.Name {
+		t.Errorf("unexpected last successful backup %v", schedule.Status.LastSuccessfulBackup)
+	}
+	if schedule.Status.ActiveBackup == nil || *schedule.Status.ActiveBackup != "nightly-20230103020000" {
+		t.Errorf("unexpected active backup %v", schedule.Status.ActiveBackup)
+	}
+	if value := testutil.ToFloat64(backupScheduleLastSuccessTimestamp.WithLabelValues("3scale", "nightly")); value != float64(created.Add(24*time.Hour).Unix()) {
+		t.Errorf("unexpected last success %f", value)
+	}
+
+	// The second backup succeeds. The first one is pruned with its PersistentVolumeClaim
+	second := &appsv1alpha1.APIManagerBackup{}
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: "nightly-20230103020000", Namespace: "3scale"}, second); err != nil {
+		t.Fatal(err)
+	}
+	second.Status.Completed = &completed
+	second.Status.CompletionTime = &metav1.Time{Time: created.Add(26 * time.Hour)}
+	if err := cl.Status().Update(context.TODO(), second); err != nil {
+		t.Fatal(err)
+	}
+	reconcileBackupSchedule(t, r, key)
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: first.Name, Namespace: "3scale"}, first); !errors.IsNotFound(err) {
+		t.Errorf("expected the first backup to be pruned, got %v", err)
+	}
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: pvc.Name, Namespace: "3scale"}, pvc); !errors.IsNotFound(err) {
+		t.Errorf("expected the first backup PersistentVolumeClaim to be deleted, got %v", err)
+	}
+	if err := cl.Get(context.TODO(), types.NamespacedName{Name: second.Name, Namespace: "3scale"}, second); err != nil {
+		t.Errorf("expected the second backup to be kept, got %v", err)
+	}
+	if err := cl.Get(context.TODO(), key, schedule); err != nil {
+		t.Fatal(err)
+	}
+	if schedule.Status.LastSuccessfulBackup == nil || schedule.Status.LastSuccessfulBackup.Name != second.Name {
+		t.Errorf("unexpected last successful backup %v", schedule.Status.LastSuccessfulBackup)
+	}
+}
+
+func TestAPIManagerBackupScheduleReconcilerInvalidSchedule(t *testing.T) {
+	schedule := &appsv1alpha1.APIManagerBackupSchedule{
+		ObjectMeta: metav1.ObjectMeta{Name: "invalid", Namespace: "3scale"},
+		Spec:       appsv1alpha1.APIManagerBackupScheduleSpec{Schedule: "0 25 * * *"},
+	}
+	key := types.NamespacedName{Name: schedule.Name, Namespace: schedule.Namespace}
+	r, cl := backupScheduleTestReconciler(t, schedule)
+
+	res := reconcileBackupSchedule(t, r, key)
+	if res.RequeueAfter != 0 {
+		t.Errorf("unexpected requeue after %s", res.RequeueAfter)
+	}
+	if err := cl.Get(context.TODO(), key, schedule); err != nil {
+		t.Fatal(err)
+	}
+	if schedule.Status.ScheduleError == nil {
+		t.Error("expected schedule error")
+	}
+}
+
+func TestAPIManagerBackupScheduleAWSCLIImageURL(t *testing.T) {
+	schedule := &appsv1alpha1.APIManagerBackupSchedule{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "3scale"},
+	}
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-apimanager", Namespace: "3scale"},
+		Spec: appsv1alpha1.APIManagerSpec{
+			APIManagerCommonSpec: appsv1alpha1.APIManagerCommonSpec{
+				ImageRegistryMirrors: []appsv1alpha1.ImageRegistryMirrorSpec{{Source: "docker.io", Mirror: "apimanager.example.com"}},
+			},
+		},
+	}
+
+	// Without APIManager, the image is used as is
+	r, _ := backupScheduleTestReconciler(t, schedule)
+	if image := r.awsCLIImageURL(schedule); image != backup.AWSCLIImageURL() {
+		t.Errorf("unexpected image %s", image)
+	}
+
+	// The mirrors of the APIManager apply by default
+	r, _ = backupScheduleTestReconciler(t, schedule, apimanager)
+	if image := r.awsCLIImageURL(schedule); !strings.HasPrefix(image, "apimanager.example.com/") {
+		t.Errorf("expected the APIManager mirror, got %s", image)
+	}
+
+	// The mirrors of the schedule take precedence
+	schedule.Spec.ImageRegistryMirrors = []appsv1alpha1.ImageRegistryMirrorSpec{{Source: "docker.io", Mirror: "schedule.example.com"}}
+	if image := r.awsCLIImageURL(schedule); !strings.HasPrefix(image, "schedule.example.com/") {
+		t.Errorf("expected the schedule mirror, got %s", image)
+	}
+}
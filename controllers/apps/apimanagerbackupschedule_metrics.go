@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+// The labels do not use "namespace", overwritten by the namespace of the scraped operator pod
+var backupScheduleMetricsLabels = []string{"backup_schedule_namespace", "backup_schedule"}
+
+var (
+	backupScheduleLastSuccessTimestamp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "threescale_backup_schedule_last_success_timestamp_seconds",
+			Help: "Completion time of the last successful backup of the APIManagerBackupSchedule, or its creation time until a backup succeeds",
+		},
+		backupScheduleMetricsLabels,
+	)
+	backupScheduleLastFailureTimestamp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "threescale_backup_schedule_last_failure_timestamp_seconds",
+			Help: "Completion time of the last failed backup of the APIManagerBackupSchedule",
+		},
+		backupScheduleMetricsLabels,
+	)
+	backupScheduleSuccessWindow = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "threescale_backup_schedule_success_window_seconds",
+			Help: "Maximum time expected between two successful backups of the APIManagerBackupSchedule",
+		},
+		backupScheduleMetricsLabels,
+	)
+)
+
+// RegisterBackupScheduleMetrics registers the APIManagerBackupSchedule metrics
+func RegisterBackupScheduleMetrics(registerer prometheus.Registerer) {
+	registerer.MustRegister(
+		backupScheduleLastSuccessTimestamp,
+		backupScheduleLastFailureTimestamp,
+		backupScheduleSuccessWindow,
+	)
+}
+
+func updateBackupScheduleMetrics(schedule *appsv1alpha1.APIManagerBackupSchedule) {
+	labels := prometheus.Labels{"backup_schedule_namespace": schedule.Namespace, "backup_schedule": schedule.Name}
+
+	lastSuccess := schedule.CreationTimestamp.Time
+	if schedule.Status.LastSuccessfulBackup != nil {
+		lastSuccess = schedule.Status.LastSuccessfulBackup.CompletionTime.Time
+	}
+	backupScheduleLastSuccessTimestamp.With(labels).Set(float64(lastSuccess.Unix()))
+
+	if schedule.Status.LastFailedBackup != nil {
+		backupScheduleLastFailureTimestamp.With(labels).Set(float64(schedule.Status.LastFailedBackup.CompletionTime.Unix()))
+	}
+
+	if schedule.Spec.SuccessWindow != nil {
+		backupScheduleSuccessWindow.With(labels).Set(schedule.Spec.SuccessWindow.Seconds())
+	} else {
+		backupScheduleSuccessWindow.Delete(labels)
+	}
+}
+
+func deleteBackupScheduleMetrics(namespace, name string) {
+	labels := prometheus.Labels{"backup_schedule_namespace": namespace, "backup_schedule": name}
+	backupScheduleLastSuccessTimestamp.Delete(labels)
+	backupScheduleLastFailureTimestamp.Delete(labels)
+	backupScheduleSuccessWindow.Delete(labels)
+}
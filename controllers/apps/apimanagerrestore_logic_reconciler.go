@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -17,8 +18,10 @@ import (
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/operator"
 	"github.com/3scale/3scale-operator/pkg/backup"
 	"github.com/3scale/3scale-operator/pkg/common"
+	oprand "github.com/3scale/3scale-operator/pkg/crypto/rand"
 	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/pkg/restore"
@@ -50,6 +53,11 @@ func (r *APIManagerRestoreLogicReconciler) Reconcile() (reconcile.Result, error)
 		return reconcile.Result{}, nil
 	}
 
+	if r.cr.RestoreFailed() {
+		r.Logger().Info("Restore failed. End of reconciliation")
+		return reconcile.Result{}, nil
+	}
+
 	if !r.cr.MainStepsCompleted() {
 		r.Logger().Info("Reconciling restore steps")
 		result, err := r.reconcileMainSteps()
@@ -75,7 +83,7 @@ func (r *APIManagerRestoreLogicReconciler) reconcileMainSteps() (reconcile.Resul
 		return result, err
 	}
 
-	result, err = r.reconcileRestoreFromPVCSource()
+	result, err = r.reconcileRestoreFromSource()
 	if result.Requeue || err != nil {
 		return result, err
 	}
@@ -126,7 +134,7 @@ func (r *APIManagerRestoreLogicReconciler) reconcileStartTimeField() (reconcile.
 	return reconcile.Result{}, nil
 }
 
-func (r *APIManagerRestoreLogicReconciler) reconcileRestoreFromPVCSource() (reconcile.Result, error) {
+func (r *APIManagerRestoreLogicReconciler) reconcileRestoreFromSource() (reconcile.Result, error) {
 	var res reconcile.Result
 	var err error
 
@@ -135,7 +143,12 @@ func (r *APIManagerRestoreLogicReconciler) reconcileRestoreFromPVCSource() (reco
 		return res, err
 	}
 
-	res, err = r.reconcileRestoreSecretsAndConfigMapsFromPVCJob()
+	res, err = r.reconcileBackupVerification()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	res, err = r.reconcileRestoreSecretsAndConfigMapsJob()
 	if res.Requeue || err != nil {
 		return res, err
 	}
@@ -145,7 +158,12 @@ func (r *APIManagerRestoreLogicReconciler) reconcileRestoreFromPVCSource() (reco
 		return res, err
 	}
 
-	res, err = r.reconcileRestoreSystemFileStoragePVCFromPVCJob()
+	res, err = r.reconcileRestoreSystemFileStorageJob()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	res, err = r.reconcileRestoreDatabases()
 	if res.Requeue || err != nil {
 		return res, err
 	}
@@ -155,6 +173,11 @@ func (r *APIManagerRestoreLogicReconciler) reconcileRestoreFromPVCSource() (reco
 		return res, err
 	}
 
+	res, err = r.reconcileRestoreZyncDatabase()
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
 	res, err = r.reconcileResynchronizeZyncDomains()
 	if res.Requeue || err != nil {
 		return res, err
@@ -216,8 +239,62 @@ func (r *APIManagerRestoreLogicReconciler) reconcileJob(desired *batchv1.Job) (r
 	return reconcile.Result{}, nil
 }
 
-func (r *APIManagerRestoreLogicReconciler) reconcileRestoreSecretsAndConfigMapsFromPVCJob() (reconcile.Result, error) {
-	desired := r.apiManagerRestore.RestoreSecretsAndConfigMapsFromPVCJob()
+// reconcileBackupVerification verifies the backup manifest before any data is restored.
+// The restore fails when the verification fails
+func (r *APIManagerRestoreLogicReconciler) reconcileBackupVerification() (reconcile.Result, error) {
+	if r.cr.Status.BackupVerification != nil {
+		return reconcile.Result{}, nil
+	}
+
+	desired := r.apiManagerRestore.VerifyBackupJob()
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	res, err := r.reconcileJob(desired)
+	if res.Requeue || err != nil {
+		return res, err
+	}
+
+	configMap := &v1.ConfigMap{}
+	err = r.GetResource(types.NamespacedName{Name: r.apiManagerRestore.BackupVerificationConfigMapName(), Namespace: r.cr.Namespace}, configMap)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			r.Logger().Info("Backup verification ConfigMap not found. Waiting", "ConfigMap", r.apiManagerRestore.BackupVerificationConfigMapName())
+			return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	verification := &restore.BackupVerification{}
+	if err := json.Unmarshal([]byte(configMap.Data[restore.BackupVerificationFileName]), verification); err != nil {
+		return reconcile.Result{}, fmt.Errorf("Failed to parse the backup verification result in ConfigMap '%s': %w", configMap.Name, err)
+	}
+
+	r.cr.Status.BackupVerification = verification.Status(r.cr)
+	r.Logger().Info("Backup verified", "Result", r.cr.Status.BackupVerification.Result, "Message", r.cr.Status.BackupVerification.Message)
+	if r.cr.Status.BackupVerification.Result == appsv1alpha1.BackupVerificationFailed {
+		restoreFailed := true
+		completionTimeUTC := metav1.Time{Time: apimanagerbackupClock.Now().UTC()}
+		r.cr.Status.Failed = &restoreFailed
+		r.cr.Status.CompletionTime = &completionTimeUTC
+	}
+	err = r.UpdateResourceStatus(r.cr)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// The result is kept in the status
+	err = r.DeleteResource(configMap)
+	if err != nil && !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{Requeue: true}, nil
+}
+
+func (r *APIManagerRestoreLogicReconciler) reconcileRestoreSecretsAndConfigMapsJob() (reconcile.Result, error) {
+	desired := r.apiManagerRestore.RestoreSecretsAndConfigMapsJob()
 	if desired == nil {
 		return reconcile.Result{}, nil
 	}
@@ -261,8 +338,8 @@ func (r *APIManagerRestoreLogicReconciler) runtimeRestoreInfoFromAPIManager(apim
 	return restoreInfo, nil
 }
 
-func (r *APIManagerRestoreLogicReconciler) reconcileRestoreSystemFileStoragePVCFromPVCJob() (reconcile.Result, error) {
-	desired := r.apiManagerRestore.RestoreSystemFileStoragePVCFromPVCJob()
+func (r *APIManagerRestoreLogicReconciler) reconcileRestoreSystemFileStorageJob() (reconcile.Result, error) {
+	desired := r.apiManagerRestore.RestoreSystemFileStorageJob()
 	if desired == nil {
 		return reconcile.Result{}, nil
 	}
@@ -414,11 +491,138 @@ func (r *APIManagerRestoreLogicReconciler) reconcileRestoreAPIManager() (reconci
 		return reconcile.Result{}, err
 	}
 
+	// Workers paused by a resource while backed up are not paused in the restored APIManager
+	apimanager.ResumeWorkers(apimanager.PauseWorkersOwner())
+
+	// The workers stay paused until the zync database is restored
+	if r.apiManagerRestore.RestoreZyncDatabaseJob(apimanager) != nil {
+		apimanager.PauseWorkers(r.cr.UID)
+	}
+
 	existing := &appsv1alpha1.APIManager{}
 	err = r.ReconcileResource(existing, apimanager, reconcilers.CreateOnlyMutator)
 	return reconcile.Result{}, err
 }
 
+// reconcileRestoreDatabases loads the database dumps and the Redis RDB copies
+// into the database PVCs, before the APIManager is created
+func (r *APIManagerRestoreLogicReconciler) reconcileRestoreDatabases() (reconcile.Result, error) {
+	existing, err := r.restoredAPIManager()
+	if err != nil || existing != nil {
+		return reconcile.Result{}, err
+	}
+
+	apimanager, err := r.apiManagerFromSharedBackupSecret()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if job := r.apiManagerRestore.RestoreSystemDatabaseJob(apimanager); job != nil {
+		var pvc *v1.PersistentVolumeClaim
+		if apimanager.IsSystemPostgreSQLEnabled() {
+			postgreSQL, err := operator.SystemPostgreSQL(apimanager, r.Client(), oprand.DefaultGenerator())
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			pvc = postgreSQL.DataPersistentVolumeClaim()
+		} else {
+			mysql, err := operator.SystemMySQL(apimanager, r.Client(), oprand.DefaultGenerator())
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			pvc = mysql.PersistentVolumeClaim()
+		}
+
+		res, err := r.reconcileRestoreDatabaseJob(job, pvc)
+		if res.Requeue || err != nil {
+			return res, err
+		}
+	}
+
+	redis, err := operator.Redis(apimanager, r.Client())
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if job := r.apiManagerRestore.RestoreBackendRedisJob(apimanager); job != nil {
+		res, err := r.reconcileRestoreDatabaseJob(job, redis.BackendPVC())
+		if res.Requeue || err != nil {
+			return res, err
+		}
+	}
+
+	if job := r.apiManagerRestore.RestoreSystemRedisJob(apimanager); job != nil {
+		res, err := r.reconcileRestoreDatabaseJob(job, redis.SystemPVC())
+		if res.Requeue || err != nil {
+			return res, err
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+func (r *APIManagerRestoreLogicReconciler) reconcileRestoreDatabaseJob(job *batchv1.Job, pvc *v1.PersistentVolumeClaim) (reconcile.Result, error) {
+	pvc.Namespace = r.cr.Namespace
+	err := r.ReconcileResource(&v1.PersistentVolumeClaim{}, pvc, reconcilers.CreateOnlyMutator)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return r.reconcileJob(job)
+}
+
+// reconcileRestoreZyncDatabase loads the zync database dump once zync-database is ready,
+// and resumes the APIManager workers afterwards
+func (r *APIManagerRestoreLogicReconciler) reconcileRestoreZyncDatabase() (reconcile.Result, error) {
+	apimanager, err := r.restoredAPIManager()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if apimanager == nil {
+		r.Logger().Info("APIManager not found. Waiting until it exists", "APIManager", r.cr.Status.APIManagerToRestoreRef.Name)
+		return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
+	}
+
+	desired := r.apiManagerRestore.RestoreZyncDatabaseJob(apimanager)
+	if desired == nil {
+		return reconcile.Result{}, nil
+	}
+
+	existing := &batchv1.Job{}
+	err = r.GetResource(types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	if err != nil && !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+	if err == nil && existing.Status.Succeeded == *desired.Spec.Completions {
+		if apimanager.ResumeWorkers(r.cr.UID) {
+			r.Logger().Info("Resuming APIManager workers", "APIManager", apimanager.Name)
+			err := r.UpdateResource(apimanager)
+			return reconcile.Result{Requeue: true}, err
+		}
+		return reconcile.Result{}, nil
+	}
+
+	if !helper.ArrayContains(apimanager.Status.Deployments.Ready, component.ZyncDatabaseDeploymentName) {
+		r.Logger().Info("zync-database deployment not ready. Waiting", "APIManager", apimanager.Name)
+		return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
+	}
+
+	return r.reconcileJob(desired)
+}
+
+// restoredAPIManager returns the restored APIManager. Nil when it does not exist
+func (r *APIManagerRestoreLogicReconciler) restoredAPIManager() (*appsv1alpha1.APIManager, error) {
+	apimanager := &appsv1alpha1.APIManager{}
+	err := r.GetResource(types.NamespacedName{Name: r.cr.Status.APIManagerToRestoreRef.Name, Namespace: r.cr.Namespace}, apimanager)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return apimanager, nil
+}
+
 func (r *APIManagerRestoreLogicReconciler) reconcileAPIManagerBackupSharedInSecretCleanup() (reconcile.Result, error) {
 	desiredSecret, err := r.sharedBackupSecret()
 	existingSecret := &v1.Secret{}
@@ -510,14 +714,31 @@ func (r *APIManagerRestoreLogicReconciler) waitForSystemSidekiq() (reconcile.Res
 // K8s jobs we allow the cleanup to be possible
 func (r *APIManagerRestoreLogicReconciler) reconcileJobsCleanup() (reconcile.Result, error) {
 	jobsToDelete := []*batchv1.Job{
-		r.apiManagerRestore.RestoreSecretsAndConfigMapsFromPVCJob(),
-		r.apiManagerRestore.RestoreSystemFileStoragePVCFromPVCJob(),
+		r.apiManagerRestore.VerifyBackupJob(),
+		r.apiManagerRestore.RestoreSecretsAndConfigMapsJob(),
+		r.apiManagerRestore.RestoreSystemFileStorageJob(),
 		r.apiManagerRestore.CreateAPIManagerSharedSecretJob(),
 		r.apiManagerRestore.ZyncResyncDomainsJob(),
 	}
 
+	apimanager, err := r.restoredAPIManager()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if apimanager != nil {
+		jobsToDelete = append(jobsToDelete,
+			r.apiManagerRestore.RestoreSystemDatabaseJob(apimanager),
+			r.apiManagerRestore.RestoreBackendRedisJob(apimanager),
+			r.apiManagerRestore.RestoreSystemRedisJob(apimanager),
+			r.apiManagerRestore.RestoreZyncDatabaseJob(apimanager),
+		)
+	}
+
 	existingJobFound := false
 	for _, job := range jobsToDelete {
+		if job == nil {
+			continue
+		}
 		existingJob := &batchv1.Job{}
 		err := r.GetResource(types.NamespacedName{Name: job.Name, Namespace: job.Namespace}, existingJob)
 		if err != nil && !errors.IsNotFound(err) {
@@ -13,6 +13,8 @@ import (
 
 const (
 	APImanagerSecretLabelPrefix = "secret.apimanager.apps.3scale.net/"
+	// SystemSecretLabelPrefix labels the APIManager with the secrets referenced by the system configuration
+	SystemSecretLabelPrefix = "system.secret.apimanager.apps.3scale.net/"
 )
 
 // SecretToApimanagerEventMapper is an EventHandler that maps secret object to apimanager CR's
@@ -27,32 +29,42 @@ func apimanagerSecretLabelKey(uid string) string {
 }
 
 func (s *SecretToApimanagerEventMapper) Map(obj client.Object) []reconcile.Request {
+	requests := []reconcile.Request{}
+	requested := map[types.NamespacedName]bool{}
 
-	apimanagerList := &appsv1alpha1.APIManagerList{}
-
-	// filter by Secret UID
-	opts := []client.ListOption{client.HasLabels{apimanagerSecretLabelKey(string(obj.GetUID()))}}
+	for _, labelKey := range []string{
+		apimanagerSecretLabelKey(string(obj.GetUID())),
+		SystemSecretLabelPrefix + string(obj.GetUID()),
+	} {
+		apimanagerList := &appsv1alpha1.APIManagerList{}
 
-	// Support namespace scope or cluster scoped
-	if s.Namespace != "" {
-		opts = append(opts, client.InNamespace(s.Namespace))
-	}
+		// filter by Secret UID
+		opts := []client.ListOption{client.HasLabels{labelKey}}
 
-	err := s.K8sClient.List(context.Background(), apimanagerList, opts...)
-	if err != nil {
-		s.Logger.Error(err, "reading apimanager list")
-		return nil
-	}
+		// Support namespace scope or cluster scoped
+		if s.Namespace != "" {
+			opts = append(opts, client.InNamespace(s.Namespace))
+		}
 
-	s.Logger.V(1).Info("Processing object", "key", client.ObjectKeyFromObject(obj), "accepted", len(apimanagerList.Items) > 0)
+		err := s.K8sClient.List(context.Background(), apimanagerList, opts...)
+		if err != nil {
+			s.Logger.Error(err, "reading apimanager list")
+			return nil
+		}
 
-	requests := []reconcile.Request{}
-	for idx := range apimanagerList.Items {
-		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{
-			Name:      apimanagerList.Items[idx].GetName(),
-			Namespace: apimanagerList.Items[idx].GetNamespace(),
-		}})
+		for idx := range apimanagerList.Items {
+			key := types.NamespacedName{
+				Name:      apimanagerList.Items[idx].GetName(),
+				Namespace: apimanagerList.Items[idx].GetNamespace(),
+			}
+			if !requested[key] {
+				requested[key] = true
+				requests = append(requests, reconcile.Request{NamespacedName: key})
+			}
+		}
 	}
 
+	s.Logger.V(1).Info("Processing object", "key", client.ObjectKeyFromObject(obj), "accepted", len(requests) > 0)
+
 	return requests
 }
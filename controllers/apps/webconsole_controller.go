@@ -74,6 +74,15 @@ func (r *WebConsoleReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 }
 
 func (r *WebConsoleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	hasRoutes, err := r.HasRoutes()
+	if err != nil {
+		return err
+	}
+	if !hasRoutes {
+		// Master console link is built from the master Route, which is not available
+		return nil
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&routev1.Route{}).
 		Complete(r)
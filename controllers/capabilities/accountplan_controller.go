@@ -0,0 +1,241 @@
+/*
+Copyright 2020 Red Hat.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	"github.com/3scale/3scale-operator/version"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const accountPlanFinalizer = "accountplan.capabilities.3scale.net/finalizer"
+
+// AccountPlanReconciler reconciles a AccountPlan object
+type AccountPlanReconciler struct {
+	*reconcilers.BaseReconciler
+}
+
+// blank assignment to verify that AccountPlanReconciler implements reconcile.Reconciler
+var _ reconcile.Reconciler = &AccountPlanReconciler{}
+
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=accountplans,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=accountplans/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=capabilities.3scale.net,namespace=placeholder,resources=accountplans/finalizers,verbs=get;list;watch;create;update;patch;delete
+
+func (r *AccountPlanReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := r.Logger().WithValues("accountplan", req.NamespacedName)
+	reqLogger.Info("Reconcile AccountPlan", "Operator version", version.Version)
+
+	// Fetch the instance
+	accountPlanCR := &capabilitiesv1beta1.AccountPlan{}
+	err := r.Client().Get(context.TODO(), req.NamespacedName, accountPlanCR)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			// Return and don't requeue
+			reqLogger.Info("resource not found. Ignoring since object must have been deleted")
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	if reqLogger.V(1).Enabled() {
+		jsonData, err := json.MarshalIndent(accountPlanCR, "", "  ")
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		reqLogger.V(1).Info(string(jsonData))
+	}
+
+	// AccountPlan has been marked for deletion
+	if accountPlanCR.GetDeletionTimestamp() != nil && controllerutil.ContainsFinalizer(accountPlanCR, accountPlanFinalizer) {
+		err = r.removeAccountPlanFrom3scale(accountPlanCR)
+		if err != nil {
+			r.EventRecorder().Eventf(accountPlanCR, corev1.EventTypeWarning, "Failed to delete account plan", "%v", err)
+			return ctrl.Result{}, err
+		}
+
+		controllerutil.RemoveFinalizer(accountPlanCR, accountPlanFinalizer)
+		err = r.UpdateResource(accountPlanCR)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	// Ignore deleted resource, this can happen when foregroundDeletion is enabled
+	// https://kubernetes.io/docs/concepts/workloads/controllers/garbage-collection/#foreground-cascading-deletion
+	if accountPlanCR.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(accountPlanCR, accountPlanFinalizer) {
+		controllerutil.AddFinalizer(accountPlanCR, accountPlanFinalizer)
+		err = r.UpdateResource(accountPlanCR)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	statusReconciler, reconcileErr := r.reconcileSpec(accountPlanCR, reqLogger)
+	statusResult, statusUpdateErr := statusReconciler.Reconcile()
+	if statusUpdateErr != nil {
+		if reconcileErr != nil {
+			return ctrl.Result{}, fmt.Errorf("Failed to reconcile account plan: %v. Failed to update status: %w", reconcileErr, statusUpdateErr)
+		}
+
+		return ctrl.Result{}, fmt.Errorf("Failed to update account plan status: %w", statusUpdateErr)
+	}
+
+	if statusResult.Requeue {
+		return statusResult, nil
+	}
+
+	if reconcileErr != nil {
+		if helper.IsInvalidSpecError(reconcileErr) {
+			// On Validation error, no need to retry as spec is not valid and needs to be changed
+			reqLogger.Info("ERROR", "spec validation error", reconcileErr)
+			r.EventRecorder().Eventf(accountPlanCR, corev1.EventTypeWarning, "Invalid account plan spec", "%v", reconcileErr)
+			return ctrl.Result{}, nil
+		}
+
+		reqLogger.Error(reconcileErr, "Failed to reconcile")
+		r.EventRecorder().Eventf(accountPlanCR, corev1.EventTypeWarning, "ReconcileError", "%v", reconcileErr)
+		return ctrl.Result{}, reconcileErr
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *AccountPlanReconciler) reconcileSpec(accountPlanCR *capabilitiesv1beta1.AccountPlan, logger logr.Logger) (*AccountPlanStatusReconciler, error) {
+	err := r.validateSpec(accountPlanCR)
+	if err != nil {
+		statusReconciler := NewAccountPlanStatusReconciler(r.BaseReconciler, accountPlanCR, "", nil, false, err)
+		return statusReconciler, err
+	}
+
+	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), accountPlanCR.Namespace, accountPlanCR.Spec.ProviderAccountRef, logger)
+	if err != nil {
+		statusReconciler := NewAccountPlanStatusReconciler(r.BaseReconciler, accountPlanCR, "", nil, false, err)
+		return statusReconciler, err
+	}
+
+	insecureSkipVerify := controllerhelper.GetInsecureSkipVerifyAnnotation(accountPlanCR.GetAnnotations())
+	plansClient, err := controllerhelper.PortaPlansClient(providerAccount, insecureSkipVerify)
+	if err != nil {
+		statusReconciler := NewAccountPlanStatusReconciler(r.BaseReconciler, accountPlanCR, providerAccount.AdminURLStr, nil, false, err)
+		return statusReconciler, err
+	}
+
+	reconciler := NewAccountPlanThreescaleReconciler(r.BaseReconciler, accountPlanCR, plansClient, providerAccount.AdminURLStr, logger)
+	planEntity, err := reconciler.Reconcile()
+
+	statusReconciler := NewAccountPlanStatusReconciler(r.BaseReconciler, accountPlanCR, providerAccount.AdminURLStr, planEntity, reconciler.Created(), err)
+	return statusReconciler, err
+}
+
+func (r *AccountPlanReconciler) validateSpec(resource *capabilitiesv1beta1.AccountPlan) error {
+	errors := field.ErrorList{}
+	errors = append(errors, resource.Validate()...)
+
+	if len(errors) == 0 {
+		return nil
+	}
+
+	return &helper.SpecFieldError{
+		ErrorType:      helper.InvalidError,
+		FieldErrorList: errors,
+	}
+}
+
+func (r *AccountPlanReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&capabilitiesv1beta1.AccountPlan{}).
+		Complete(r)
+}
+
+func (r *AccountPlanReconciler) removeAccountPlanFrom3scale(accountPlanCR *capabilitiesv1beta1.AccountPlan) error {
+	logger := r.Logger().WithValues("accountplan", client.ObjectKey{Name: accountPlanCR.Name, Namespace: accountPlanCR.Namespace})
+
+	// Attempt to remove account plan only if accountPlanCR.Status.ID is present
+	if accountPlanCR.Status.ID == nil {
+		logger.Info("could not remove account plan because ID is missing in status")
+		return nil
+	}
+
+	// Account plans adopted by the resource are left in 3scale
+	if !accountPlanCR.Status.Created {
+		logger.Info("account plan not deleted from 3scale, it was not created by the resource", "ID", *accountPlanCR.Status.ID)
+		return nil
+	}
+
+	providerAccount, err := controllerhelper.LookupProviderAccount(r.Client(), accountPlanCR.Namespace, accountPlanCR.Spec.ProviderAccountRef, r.Logger())
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("account plan not deleted from 3scale, provider account not found")
+			return nil
+		}
+		return err
+	}
+
+	insecureSkipVerify := controllerhelper.GetInsecureSkipVerifyAnnotation(accountPlanCR.GetAnnotations())
+	plansClient, err := controllerhelper.PortaPlansClient(providerAccount, insecureSkipVerify)
+	if err != nil {
+		return err
+	}
+
+	return deleteAccountPlan(plansClient, *accountPlanCR.Status.ID, logger)
+}
+
+// deleteAccountPlan deletes the account plan from 3scale. Account plans already deleted,
+// or with developer accounts subscribed, are skipped
+func deleteAccountPlan(plansClient *controllerhelper.PlansClient, planID int64, logger logr.Logger) error {
+	err := plansClient.DeleteAccountPlan(planID)
+	if err != nil {
+		if controllerhelper.IsAdminAPINotFound(err) {
+			return nil
+		}
+		if controllerhelper.IsAdminAPIUnprocessableEntity(err) {
+			logger.Info("account plan not deleted from 3scale, it has contracts", "ID", planID, "reason", err.Error())
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// mockHttpClientDeleteAccountPlans deletes the account plan 11, and refuses to delete the account plan 10
+// as it has contracts. The delete requests are recorded
+func mockHttpClientDeleteAccountPlans(requests *[]string) *http.Client {
+	return NewTestClient(func(req *http.Request) *http.Response {
+		var (
+			statusCode = http.StatusOK
+			body       = []byte("")
+		)
+
+		switch req.Method + " " + req.URL.Path {
+		case "DELETE /admin/api/account_plans/10.json":
+			statusCode = http.StatusUnprocessableEntity
+			body = []byte(`{"errors":{"base":["This plan cannot be deleted"]}}`)
+		case "DELETE /admin/api/account_plans/11.json":
+		case "DELETE /admin/api/account_plans/12.json":
+			statusCode = http.StatusInternalServerError
+		default:
+			statusCode = http.StatusNotFound
+		}
+
+		*requests = append(*requests, req.Method+" "+req.URL.Path)
+		return &http.Response{StatusCode: statusCode, Header: make(http.Header), Body: ioutil.NopCloser(bytes.NewBuffer(body))}
+	})
+}
+
+func TestDeleteAccountPlan(t *testing.T) {
+	adminURL, _ := url.Parse("https://3scale-admin.test.3scale.net")
+
+	cases := []struct {
+		name    string
+		planID  int64
+		wantErr bool
+	}{
+		{"Deleted", 11, false},
+		{"HasContracts", 10, false},
+		{"NotFound", 13, false},
+		{"Error", 12, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			requests := []string{}
+			plansClient := controllerhelper.NewPlansClient(adminURL, "test", mockHttpClientDeleteAccountPlans(&requests))
+
+			err := deleteAccountPlan(plansClient, tc.planID, logr.Discard())
+			if (err != nil) != tc.wantErr {
+				subT.Errorf("deleteAccountPlan() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if len(requests) != 1 {
+				subT.Errorf("requests got = %v, want a single delete request", requests)
+			}
+		})
+	}
+}
+
+func TestAccountPlanReconciler_removeAccountPlanFrom3scale(t *testing.T) {
+	planID := int64(11)
+	// The provider account secret has no admin URL, so looking it up fails
+	providerAccountSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "provider", Namespace: "test"}}
+
+	cases := []struct {
+		name    string
+		created bool
+		wantErr bool
+	}{
+		{"Adopted", false, false},
+		{"Created", true, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			accountPlanResource := &capabilitiesv1beta1.AccountPlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "accountplan", Namespace: "test"},
+				Spec: capabilitiesv1beta1.AccountPlanSpec{
+					SystemName:         "gold",
+					ProviderAccountRef: &corev1.LocalObjectReference{Name: "provider"},
+				},
+				Status: capabilitiesv1beta1.AccountPlanStatus{ID: &planID, Created: tc.created},
+			}
+
+			r := &AccountPlanReconciler{BaseReconciler: getBaseReconciler(providerAccountSecret)}
+			err := r.removeAccountPlanFrom3scale(accountPlanResource)
+			if (err != nil) != tc.wantErr {
+				subT.Errorf("removeAccountPlanFrom3scale() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestAccountPlanStatusReconciler_calculateStatus(t *testing.T) {
+	adminURL, _ := url.Parse("https://3scale-admin.test.3scale.net")
+	plansClient := controllerhelper.NewPlansClient(adminURL, "test", nil)
+	goldID := int64(11)
+	silverID := int64(12)
+
+	cases := []struct {
+		name            string
+		statusID        *int64
+		statusCreated   bool
+		planID          int64
+		created         bool
+		expectedCreated bool
+	}{
+		{"Created", nil, false, 12, true, true},
+		{"Adopted", nil, false, 11, false, false},
+		{"CreatedKept", &silverID, true, 12, false, true},
+		{"AdoptedKept", &goldID, false, 11, false, false},
+		{"ReplacedByAdopted", &silverID, true, 11, false, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			accountPlanResource := &capabilitiesv1beta1.AccountPlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "accountplan", Namespace: "test"},
+				Status:     capabilitiesv1beta1.AccountPlanStatus{ID: tc.statusID, Created: tc.statusCreated},
+			}
+			planEntity := controllerhelper.NewAccountPlanEntity(controllerhelper.PlanItem{ID: tc.planID}, plansClient, logr.Discard())
+
+			s := NewAccountPlanStatusReconciler(getBaseReconciler(), accountPlanResource, adminURL.String(), planEntity, tc.created, nil)
+			newStatus := s.calculateStatus()
+			if newStatus.ID == nil || *newStatus.ID != tc.planID {
+				subT.Errorf("ID got = %v, want %d", newStatus.ID, tc.planID)
+			}
+			if newStatus.Created != tc.expectedCreated {
+				subT.Errorf("created got = %t, want %t", newStatus.Created, tc.expectedCreated)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	"net/url"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	"github.com/go-logr/logr"
+)
+
+type AccountPlanThreescaleReconciler struct {
+	*reconcilers.BaseReconciler
+	resource            *capabilitiesv1beta1.AccountPlan
+	plansClient         *controllerhelper.PlansClient
+	providerAccountHost string
+	logger              logr.Logger
+	created             bool
+}
+
+func NewAccountPlanThreescaleReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.AccountPlan, plansClient *controllerhelper.PlansClient, providerAccountHost string, logger logr.Logger) *AccountPlanThreescaleReconciler {
+	return &AccountPlanThreescaleReconciler{
+		BaseReconciler:      b,
+		resource:            resource,
+		plansClient:         plansClient,
+		providerAccountHost: providerAccountHost,
+		logger:              logger.WithValues("3scale Reconciler", providerAccountHost),
+	}
+}
+
+func (s *AccountPlanThreescaleReconciler) Reconcile() (*controllerhelper.PlanEntity, error) {
+	s.logger.V(1).Info("START")
+
+	remoteAccountPlans, err := s.plansClient.AccountPlans()
+	if err != nil {
+		return nil, err
+	}
+
+	var remoteAccountPlan *controllerhelper.PlanItem
+	for idx := range remoteAccountPlans {
+		// Look for ID. If it does not exist, look for system name
+		foundByID := s.resource.Status.ID != nil && remoteAccountPlans[idx].ID == *s.resource.Status.ID
+		foundBySystemName := remoteAccountPlans[idx].SystemName == s.resource.Spec.SystemName
+		if foundByID || foundBySystemName {
+			remoteAccountPlan = &remoteAccountPlans[idx]
+			break
+		}
+	}
+
+	if remoteAccountPlan == nil {
+		s.logger.V(1).Info("AccountPlan does not exist", "SystemName", s.resource.Spec.SystemName)
+		// Create Account Plan using system_name.
+		// it cannot be modified later
+		params := url.Values{"system_name": []string{s.resource.Spec.SystemName}, "name": []string{s.resource.Spec.SystemName}}
+		remoteAccountPlan, err = s.plansClient.CreateAccountPlan(params)
+		if err != nil {
+			return nil, err
+		}
+		s.created = true
+	}
+
+	planEntity := controllerhelper.NewAccountPlanEntity(*remoteAccountPlan, s.plansClient, s.logger)
+	reconciler := newPlanReconciler(s.BaseReconciler, s.resource.Spec.SystemName, s.resource.Spec.PlanSpec, planEntity, s.logger)
+	err = reconciler.Reconcile()
+	if err != nil {
+		// The account plan is returned along with the error, so its ID is kept in the status
+		return planEntity, err
+	}
+
+	return planEntity, nil
+}
+
+// Created returns true when the account plan was created by the last reconciliation,
+// false when an existing account plan was found
+func (s *AccountPlanThreescaleReconciler) Created() bool {
+	return s.created
+}
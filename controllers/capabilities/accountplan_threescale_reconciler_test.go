@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// mockHttpClientAccountPlans serves the account plans "default" and "gold" of the provider account,
+// recording the write requests
+func mockHttpClientAccountPlans(requests *[]string) *http.Client {
+	return NewTestClient(func(req *http.Request) *http.Response {
+		var (
+			statusCode = http.StatusOK
+			body       = []byte("")
+		)
+
+		switch req.Method + " " + req.URL.Path {
+		case "GET /admin/api/account_plans.json":
+			body = []byte(`{"plans":[` +
+				`{"account_plan":{"id":10,"system_name":"default","name":"Default","state":"published"}},` +
+				`{"account_plan":{"id":11,"system_name":"gold","name":"Gold","state":"hidden"}}]}`)
+		case "POST /admin/api/account_plans.json":
+			statusCode = http.StatusCreated
+			body = []byte(`{"account_plan":{"id":12,"system_name":"silver","name":"silver","state":"hidden"}}`)
+		case "PUT /admin/api/account_plans/11.json":
+			body = []byte(`{"account_plan":{"id":11,"system_name":"gold","name":"Gold","state":"published"}}`)
+		case "PUT /admin/api/account_plans/12.json":
+			body = []byte(`{"account_plan":{"id":12,"system_name":"silver","name":"Silver","state":"hidden"}}`)
+		default:
+			statusCode = http.StatusNotFound
+		}
+
+		if req.Method != "GET" {
+			*requests = append(*requests, req.Method+" "+req.URL.Path)
+		}
+		return &http.Response{StatusCode: statusCode, Header: make(http.Header), Body: ioutil.NopCloser(bytes.NewBuffer(body))}
+	})
+}
+
+func TestAccountPlanThreescaleReconciler_Reconcile(t *testing.T) {
+	adminURL, _ := url.Parse("https://3scale-admin.test.3scale.net")
+	goldName := "Gold"
+	silverName := "Silver"
+	published := true
+	goldID := int64(11)
+
+	cases := []struct {
+		name             string
+		systemName       string
+		planSpec         capabilitiesv1beta1.PlanSpec
+		statusID         *int64
+		expectedID       int64
+		expectedCreated  bool
+		expectedRequests []string
+	}{
+		{"Unchanged", "gold", capabilitiesv1beta1.PlanSpec{Name: &goldName}, nil, 11, false, nil},
+		{"Create", "silver", capabilitiesv1beta1.PlanSpec{}, nil, 12, true, []string{"POST /admin/api/account_plans.json"}},
+		{"CreateAndUpdate", "silver", capabilitiesv1beta1.PlanSpec{Name: &silverName}, nil, 12, true,
+			[]string{
+				"POST /admin/api/account_plans.json",
+				"PUT /admin/api/account_plans/12.json",
+			}},
+		{"Update", "gold", capabilitiesv1beta1.PlanSpec{Published: &published}, nil, 11, false, []string{"PUT /admin/api/account_plans/11.json"}},
+		{"FoundByID", "other", capabilitiesv1beta1.PlanSpec{Published: &published}, &goldID, 11, false, []string{"PUT /admin/api/account_plans/11.json"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			requests := []string{}
+			plansClient := controllerhelper.NewPlansClient(adminURL, "test", mockHttpClientAccountPlans(&requests))
+			accountPlanResource := &capabilitiesv1beta1.AccountPlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "accountplan", Namespace: "test"},
+				Spec: capabilitiesv1beta1.AccountPlanSpec{
+					SystemName: tc.systemName,
+					PlanSpec:   tc.planSpec,
+				},
+				Status: capabilitiesv1beta1.AccountPlanStatus{ID: tc.statusID},
+			}
+
+			s := NewAccountPlanThreescaleReconciler(getBaseReconciler(), accountPlanResource, plansClient, adminURL.String(), logr.Discard())
+			planEntity, err := s.Reconcile()
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if planEntity.ID() != tc.expectedID {
+				subT.Errorf("account plan ID got = %d, want %d", planEntity.ID(), tc.expectedID)
+			}
+			if s.Created() != tc.expectedCreated {
+				subT.Errorf("account plan created got = %t, want %t", s.Created(), tc.expectedCreated)
+			}
+			if len(requests) != len(tc.expectedRequests) || (len(requests) > 0 && !reflect.DeepEqual(requests, tc.expectedRequests)) {
+				subT.Errorf("requests got = %v, want %v", requests, tc.expectedRequests)
+			}
+		})
+	}
+}
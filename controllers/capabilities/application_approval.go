@@ -0,0 +1,31 @@
+package controllers
+
+import (
+	"fmt"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+)
+
+// Application state in 3scale when its application plan requires approval
+const applicationPendingState = "pending"
+
+// reconcileApplicationApproval accepts the application pending of approval when it is approved.
+// 3scale has no operation to reject applications, rejected applications are kept pending
+func (r *ApplicationReconciler) reconcileApplicationApproval(applicationResource *capabilitiesv1beta1.Application, accountResource *capabilitiesv1beta1.DeveloperAccount, applicationEntity *controllerhelper.ApplicationEntity, approvalClient *controllerhelper.ApprovalClient) error {
+	if applicationEntity.ApplicationState() != applicationPendingState {
+		return nil
+	}
+
+	if applicationResource.Spec.Approval == nil || *applicationResource.Spec.Approval != capabilitiesv1beta1.ApprovalApproved {
+		return nil
+	}
+
+	application, err := approvalClient.AcceptApplication(*accountResource.Status.ID, applicationEntity.ID())
+	if err != nil {
+		return fmt.Errorf("error accepting application [%s;%d]: %w", applicationResource.Spec.Name, applicationEntity.ID(), err)
+	}
+	applicationEntity.SetApplicationState(application.State)
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-porta-go-client/client"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// mockHttpClientApplicationAccept accepts the application 3 of the account 3, recording the requests
+func mockHttpClientApplicationAccept(requests *[]string) *http.Client {
+	return NewTestClient(func(req *http.Request) *http.Response {
+		if req.Method != "PUT" || req.URL.Path != "/admin/api/accounts/3/applications/3/accept.json" {
+			return &http.Response{StatusCode: http.StatusNotFound, Header: make(http.Header), Body: ioutil.NopCloser(bytes.NewBufferString(""))}
+		}
+		*requests = append(*requests, req.Method+" "+req.URL.Path)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     make(http.Header),
+			Body:       ioutil.NopCloser(bytes.NewBuffer(responseBody(&client.ApplicationElem{Application: client.Application{ID: 3, State: "live"}}))),
+		}
+	})
+}
+
+func TestApplicationReconciler_reconcileApplicationApproval(t *testing.T) {
+	approved := capabilitiesv1beta1.ApprovalApproved
+	rejected := capabilitiesv1beta1.ApprovalRejected
+	adminURL, _ := url.Parse("https://3scale-admin.test.3scale.net")
+
+	cases := []struct {
+		name             string
+		state            string
+		approval         *capabilitiesv1beta1.ApprovalState
+		expectedState    string
+		expectedAccepted bool
+	}{
+		{"LiveApplication", "live", &approved, "live", false},
+		{"PendingNotDecided", "pending", nil, "pending", false},
+		{"PendingRejected", "pending", &rejected, "pending", false},
+		{"PendingApproved", "pending", &approved, "live", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			requests := []string{}
+			approvalClient := controllerhelper.NewApprovalClient(adminURL, "test", mockHttpClientApplicationAccept(&requests))
+			applicationResource := getApplicationCR()
+			applicationResource.Spec.Approval = tc.approval
+			entity := controllerhelper.NewApplicationEntity(&client.Application{ID: 3, State: tc.state}, nil, logr.Discard())
+
+			r := &ApplicationReconciler{BaseReconciler: getBaseReconciler()}
+			err := r.reconcileApplicationApproval(applicationResource, getApplicationDeveloperAccount(), entity, approvalClient)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if entity.ApplicationState() != tc.expectedState {
+				subT.Errorf("application state got = %s, want %s", entity.ApplicationState(), tc.expectedState)
+			}
+			if (len(requests) > 0) != tc.expectedAccepted {
+				subT.Errorf("application accepted got = %v, want %v", requests, tc.expectedAccepted)
+			}
+		})
+	}
+}
+
+func TestApplicationStatusReconciler_pendingApprovalCondition(t *testing.T) {
+	approved := capabilitiesv1beta1.ApprovalApproved
+	rejected := capabilitiesv1beta1.ApprovalRejected
+	pending := capabilitiesv1beta1.ApprovalPending
+
+	cases := []struct {
+		name           string
+		state          string
+		approval       *capabilitiesv1beta1.ApprovalState
+		expectedStatus corev1.ConditionStatus
+	}{
+		{"Live", "live", nil, corev1.ConditionFalse},
+		{"PendingNotDecided", "pending", nil, corev1.ConditionTrue},
+		{"PendingKeptPending", "pending", &pending, corev1.ConditionTrue},
+		{"PendingApproved", "pending", &approved, corev1.ConditionFalse},
+		{"PendingRejected", "pending", &rejected, corev1.ConditionFalse},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			applicationResource := getApplicationCR()
+			applicationResource.Spec.Approval = tc.approval
+			s := NewApplicationStatusReconciler(getBaseReconciler(), applicationResource, nil, "", nil)
+
+			condition := s.pendingApprovalCondition(tc.state)
+			if condition.Type != capabilitiesv1beta1.ApplicationPendingApprovalConditionType {
+				subT.Errorf("condition type got = %s", condition.Type)
+			}
+			if condition.Status != tc.expectedStatus {
+				subT.Errorf("condition status got = %s, want %s", condition.Status, tc.expectedStatus)
+			}
+		})
+	}
+}
@@ -32,6 +32,8 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 )
@@ -109,6 +111,16 @@ func (r *ApplicationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
+	credentialsClient, err := controllerhelper.PortaApplicationCredentialsClient(providerAccount, insecureSkipVerify)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	approvalClient, err := controllerhelper.PortaApprovalClient(providerAccount, insecureSkipVerify)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Ignore deleted Applications, this can happen when foregroundDeletion is enabled
 	// https://kubernetes.io/docs/concepts/workloads/controllers/garbage-collection/#foreground-cascading-deletion
 	if application.GetDeletionTimestamp() != nil && controllerutil.ContainsFinalizer(application, applicationFinalizer) {
@@ -140,7 +152,19 @@ func (r *ApplicationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, nil
 	}
 
-	statusReconciler, reconcileErr := r.applicationReconciler(application, req, threescaleAPIClient, providerAccount.AdminURLStr, accountResource)
+	secretLabelsChanged, err := r.reconcileApplicationKeySecretLabels(application)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if secretLabelsChanged {
+		err = r.UpdateResource(application)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	statusReconciler, reconcileErr := r.applicationReconciler(application, req, threescaleAPIClient, credentialsClient, approvalClient, providerAccount.AdminURLStr, accountResource)
 	statusResult, statusUpdateErr := statusReconciler.Reconcile()
 	if statusUpdateErr != nil {
 		if reconcileErr != nil {
@@ -175,10 +199,15 @@ func (r *ApplicationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	reqLogger.Info("END", "error", reconcileErr)
 
+	// Requeue for the rotation of the generated key, or the removal of a replaced key
+	if requeueAfter := applicationKeysRequeueAfter(application, applicationKeysClock.Now()); requeueAfter > 0 {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
-func (r *ApplicationReconciler) applicationReconciler(applicationResource *capabilitiesv1beta1.Application, req ctrl.Request, threescaleAPIClient *threescaleapi.ThreeScaleClient, providerAccountAdminURLStr string, accountResource *capabilitiesv1beta1.DeveloperAccount) (*ApplicationStatusReconciler, error) {
+func (r *ApplicationReconciler) applicationReconciler(applicationResource *capabilitiesv1beta1.Application, req ctrl.Request, threescaleAPIClient *threescaleapi.ThreeScaleClient, credentialsClient *controllerhelper.ApplicationCredentialsClient, approvalClient *controllerhelper.ApprovalClient, providerAccountAdminURLStr string, accountResource *capabilitiesv1beta1.DeveloperAccount) (*ApplicationStatusReconciler, error) {
 
 	// get product
 	productResource := &capabilitiesv1beta1.Product{}
@@ -201,12 +230,49 @@ func (r *ApplicationReconciler) applicationReconciler(applicationResource *capab
 		return statusReconciler, err
 	}
 
-	reconciler := NewApplicationReconciler(r.BaseReconciler, applicationResource, accountResource, productResource, threescaleAPIClient)
+	err = validateApplicationCustomizations(applicationResource, productResource)
+	if err != nil {
+		statusReconciler := NewApplicationStatusReconciler(r.BaseReconciler, applicationResource, nil, providerAccountAdminURLStr, err)
+		return statusReconciler, err
+	}
+
+	reconciler := NewApplicationReconciler(r.BaseReconciler, applicationResource, accountResource, productResource, threescaleAPIClient, credentialsClient)
 	ApplicationEntity, err := reconciler.Reconcile()
 	if err != nil {
 		statusReconciler := NewApplicationStatusReconciler(r.BaseReconciler, applicationResource, nil, providerAccountAdminURLStr, err)
 		return statusReconciler, err
 	}
+
+	err = r.reconcileApplicationApproval(applicationResource, accountResource, ApplicationEntity, approvalClient)
+	if err != nil {
+		statusReconciler := NewApplicationStatusReconciler(r.BaseReconciler, applicationResource, ApplicationEntity, providerAccountAdminURLStr, err)
+		return statusReconciler, err
+	}
+
+	err = r.reconcilePlanCustomization(applicationResource, accountResource, productResource, ApplicationEntity, threescaleAPIClient)
+	if err != nil {
+		statusReconciler := NewApplicationStatusReconciler(r.BaseReconciler, applicationResource, ApplicationEntity, providerAccountAdminURLStr, err)
+		return statusReconciler, err
+	}
+
+	err = r.reconcileApplicationCustomizations(applicationResource, accountResource, ApplicationEntity, threescaleAPIClient, credentialsClient)
+	if err != nil {
+		statusReconciler := NewApplicationStatusReconciler(r.BaseReconciler, applicationResource, ApplicationEntity, providerAccountAdminURLStr, err)
+		return statusReconciler, err
+	}
+
+	err = r.reconcileApplicationKeys(applicationResource, accountResource, productResource, ApplicationEntity, credentialsClient)
+	if err != nil {
+		statusReconciler := NewApplicationStatusReconciler(r.BaseReconciler, applicationResource, ApplicationEntity, providerAccountAdminURLStr, err)
+		return statusReconciler, err
+	}
+
+	err = r.reconcileCredentialsSecret(applicationResource, accountResource, productResource, ApplicationEntity, credentialsClient)
+	if err != nil {
+		statusReconciler := NewApplicationStatusReconciler(r.BaseReconciler, applicationResource, ApplicationEntity, providerAccountAdminURLStr, err)
+		return statusReconciler, err
+	}
+
 	statusReconciler := NewApplicationStatusReconciler(r.BaseReconciler, applicationResource, ApplicationEntity, providerAccountAdminURLStr, err)
 	return statusReconciler, err
 }
@@ -279,5 +345,8 @@ func (r *ApplicationReconciler) checkExternalResources(applicationResource *capa
 func (r *ApplicationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&capabilitiesv1beta1.Application{}).
+		Owns(&corev1.Secret{}).
+		// The secrets of the application keys are not owned, the applications are labeled with their UIDs
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.secretToApplications)).
 		Complete(r)
 }
@@ -227,7 +227,7 @@ func TestApplicationReconciler_applicationReconciler(t *testing.T) {
 			r := &ApplicationReconciler{
 				BaseReconciler: tt.fields.BaseReconciler,
 			}
-			got, err := r.applicationReconciler(tt.args.applicationResource, tt.args.req, tt.args.threescaleApiClient, tt.args.providerAccountAdminURL, tt.args.accountResource)
+			got, err := r.applicationReconciler(tt.args.applicationResource, tt.args.req, tt.args.threescaleApiClient, nil, nil, tt.args.providerAccountAdminURL, tt.args.accountResource)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("applicationReconciler() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"fmt"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Application's credentials secret field names for user key authentication
+const ApplicationUserKeySecretField = "user_key"
+
+// Application's credentials secret field names for app id and app key authentication
+const (
+	ApplicationAppIDSecretField  = "app_id"
+	ApplicationAppKeySecretField = "app_key"
+)
+
+// Application's credentials secret field names for OpenID Connect authentication
+const (
+	ApplicationClientIDSecretField     = "client_id"
+	ApplicationClientSecretSecretField = "client_secret"
+)
+
+// reconcileCredentialsSecret writes the credentials of the application into the secret
+// referenced by the application, owned by it. Nothing is done without secret reference
+func (r *ApplicationReconciler) reconcileCredentialsSecret(applicationResource *capabilitiesv1beta1.Application, accountResource *capabilitiesv1beta1.DeveloperAccount, productResource *capabilitiesv1beta1.Product, applicationEntity *controllerhelper.ApplicationEntity, credentialsClient *controllerhelper.ApplicationCredentialsClient) error {
+	if applicationResource.Spec.CredentialsSecretRef == nil {
+		return nil
+	}
+
+	existing := &v1.Secret{}
+	err := r.Client().Get(r.Context(), types.NamespacedName{Name: applicationResource.Spec.CredentialsSecretRef.Name, Namespace: applicationResource.Namespace}, existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil && !metav1.IsControlledBy(existing, applicationResource) {
+		return &helper.SpecFieldError{
+			ErrorType: helper.InvalidError,
+			FieldErrorList: field.ErrorList{
+				field.Invalid(field.NewPath("spec").Child("credentialsSecretRef"), applicationResource.Spec.CredentialsSecretRef, "secret exists and is not owned by the application"),
+			},
+		}
+	}
+
+	credentials, err := credentialsClient.ApplicationCredentials(*accountResource.Status.ID, applicationEntity.ID())
+	if err != nil {
+		return fmt.Errorf("error reading application [%s;%d] credentials: %w", applicationResource.Spec.Name, applicationEntity.ID(), err)
+	}
+
+	keysStatus := applicationResource.Status.Keys
+	if applicationEntity.KeysStatus() != nil {
+		keysStatus = applicationEntity.KeysStatus()
+	}
+
+	desired := applicationCredentialsSecret(applicationResource, productResource, credentials, keysStatus)
+	err = r.SetOwnerReference(applicationResource, desired)
+	if err != nil {
+		return err
+	}
+
+	return r.ReconcileResource(&v1.Secret{}, desired, reconcilers.DeploymentSecretMutator(reconcilers.SecretReconcileData))
+}
+
+// applicationCredentialsSecret returns the secret with the application credentials,
+// in the format of the authentication mode of the product
+func applicationCredentialsSecret(applicationResource *capabilitiesv1beta1.Application, productResource *capabilitiesv1beta1.Product, credentials *controllerhelper.ApplicationCredentials, keysStatus []capabilitiesv1beta1.ApplicationKeyStatus) *v1.Secret {
+	firstKey := preferredApplicationKey(credentials.ApplicationKeys, keysStatus)
+
+	data := map[string][]byte{}
+	// User key authentication is the default of the products
+	authenticationMode := "1"
+	if mode := productResource.Spec.AuthenticationMode(); mode != nil {
+		authenticationMode = *mode
+	}
+	switch authenticationMode {
+	case "2":
+		data[ApplicationAppIDSecretField] = []byte(credentials.ApplicationID)
+		data[ApplicationAppKeySecretField] = []byte(firstKey)
+	case "oidc":
+		data[ApplicationClientIDSecretField] = []byte(credentials.ApplicationID)
+		data[ApplicationClientSecretSecretField] = []byte(firstKey)
+	default:
+		data[ApplicationUserKeySecretField] = []byte(credentials.UserKey)
+	}
+
+	return &v1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: applicationResource.Namespace,
+			Name:      applicationResource.Spec.CredentialsSecretRef.Name,
+			Labels:    map[string]string{"app": "3scale-operator"},
+		},
+		Data: data,
+		Type: v1.SecretTypeOpaque,
+	}
+}
+
+// preferredApplicationKey returns the most recent key managed by the operator, not being replaced.
+// The first key of the application when the keys are not managed by the operator
+func preferredApplicationKey(keys []string, keysStatus []capabilitiesv1beta1.ApplicationKeyStatus) string {
+	var preferred *capabilitiesv1beta1.ApplicationKeyStatus
+	for idx := range keysStatus {
+		keyStatus := &keysStatus[idx]
+		if keyStatus.ExpirationTimestamp != nil {
+			continue
+		}
+		if preferred == nil || preferred.CreationTimestamp.Before(&keyStatus.CreationTimestamp) {
+			preferred = keyStatus
+		}
+	}
+
+	for _, key := range keys {
+		if preferred != nil && applicationKeyHash(key) == preferred.Hash {
+			return key
+		}
+	}
+	if len(keys) > 0 {
+		return keys[0]
+	}
+	return ""
+}
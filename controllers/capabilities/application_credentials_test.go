@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-porta-go-client/client"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func mockHttpClientApplicationCredentials() *http.Client {
+	return NewTestClient(func(req *http.Request) *http.Response {
+		body := ""
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/admin/api/accounts/3/applications/3.json":
+			body = `{"application": {"id": 3, "user_key": "", "application_id": "a1b2c3"}}`
+		case req.Method == "GET" && req.URL.Path == "/admin/api/accounts/3/applications/3/keys.json":
+			body = `{"keys": [{"key": {"value": "key1"}}, {"key": {"value": "key2"}}]}`
+		default:
+			return &http.Response{StatusCode: http.StatusNotFound, Header: make(http.Header), Body: ioutil.NopCloser(bytes.NewBufferString(""))}
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     make(http.Header),
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+	})
+}
+
+func TestApplicationCredentialsSecret(t *testing.T) {
+	credentials := &controllerhelper.ApplicationCredentials{
+		UserKey:         "userkey1",
+		ApplicationID:   "a1b2c3",
+		ApplicationKeys: []string{"key1", "key2"},
+	}
+
+	tests := []struct {
+		name           string
+		authentication *capabilitiesv1beta1.AuthenticationSpec
+		want           map[string][]byte
+	}{
+		{"Default", nil, map[string][]byte{"user_key": []byte("userkey1")}},
+		{"UserKey", &capabilitiesv1beta1.AuthenticationSpec{UserKeyAuthentication: &capabilitiesv1beta1.UserKeyAuthenticationSpec{}},
+			map[string][]byte{"user_key": []byte("userkey1")}},
+		{"AppKeyAppID", &capabilitiesv1beta1.AuthenticationSpec{AppKeyAppIDAuthentication: &capabilitiesv1beta1.AppKeyAppIDAuthenticationSpec{}},
+			map[string][]byte{"app_id": []byte("a1b2c3"), "app_key": []byte("key1")}},
+		{"OIDC", &capabilitiesv1beta1.AuthenticationSpec{OIDC: &capabilitiesv1beta1.OIDCSpec{}},
+			map[string][]byte{"client_id": []byte("a1b2c3"), "client_secret": []byte("key1")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(subT *testing.T) {
+			applicationResource := getApplicationCR()
+			applicationResource.Spec.CredentialsSecretRef = &corev1.LocalObjectReference{Name: "test-credentials"}
+			productResource := getApplicationProductCR()
+			if tt.authentication != nil {
+				productResource.Spec.Deployment = &capabilitiesv1beta1.ProductDeploymentSpec{
+					ApicastHosted: &capabilitiesv1beta1.ApicastHostedSpec{Authentication: tt.authentication},
+				}
+			}
+
+			secret := applicationCredentialsSecret(applicationResource, productResource, credentials, nil)
+			if secret.Name != "test-credentials" || secret.Namespace != "test" {
+				subT.Errorf("unexpected secret %s/%s", secret.Namespace, secret.Name)
+			}
+			if !reflect.DeepEqual(secret.Data, tt.want) {
+				subT.Errorf("applicationCredentialsSecret() got = %v, want %v", secret.Data, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplicationReconciler_reconcileCredentialsSecret(t *testing.T) {
+	ap, _ := client.NewAdminPortal("https", "3scale-admin.test.3scale.net", 443)
+	adminURL, _ := url.Parse("https://3scale-admin.test.3scale.net")
+	credentialsClient := controllerhelper.NewApplicationCredentialsClient(adminURL, "test", mockHttpClientApplicationCredentials())
+
+	applicationResource := getApplicationCR()
+	applicationResource.UID = "application-uid"
+	applicationResource.Spec.CredentialsSecretRef = &corev1.LocalObjectReference{Name: "test-credentials"}
+	productResource := getApplicationProductCR()
+	productResource.Spec.Deployment = &capabilitiesv1beta1.ProductDeploymentSpec{
+		ApicastHosted: &capabilitiesv1beta1.ApicastHostedSpec{
+			Authentication: &capabilitiesv1beta1.AuthenticationSpec{AppKeyAppIDAuthentication: &capabilitiesv1beta1.AppKeyAppIDAuthenticationSpec{}},
+		},
+	}
+	entity := controllerhelper.NewApplicationEntity(&client.Application{ID: 3}, client.NewThreeScale(ap, "test", mockHttpClientApplicationCredentials()), logr.Discard())
+
+	r := &ApplicationReconciler{BaseReconciler: getBaseReconciler()}
+	err := r.reconcileCredentialsSecret(applicationResource, getApplicationDeveloperAccount(), productResource, entity, credentialsClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret := &corev1.Secret{}
+	err = r.Client().Get(context.TODO(), types.NamespacedName{Name: "test-credentials", Namespace: "test"}, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !metav1.IsControlledBy(secret, applicationResource) {
+		t.Errorf("expected secret owned by the application, got %v", secret.OwnerReferences)
+	}
+	want := map[string][]byte{"app_id": []byte("a1b2c3"), "app_key": []byte("key1")}
+	if !reflect.DeepEqual(secret.Data, want) {
+		t.Errorf("reconcileCredentialsSecret() got = %v, want %v", secret.Data, want)
+	}
+
+	// A secret not owned by the application is not overwritten
+	applicationResource.Spec.CredentialsSecretRef = &corev1.LocalObjectReference{Name: "user-secret"}
+	err = r.Client().Create(context.TODO(), &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "user-secret", Namespace: "test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.reconcileCredentialsSecret(applicationResource, getApplicationDeveloperAccount(), productResource, entity, credentialsClient)
+	if !helper.IsInvalidSpecError(err) {
+		t.Errorf("expected invalid spec error, got %v", err)
+	}
+}
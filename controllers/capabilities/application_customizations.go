@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"fmt"
+	"net/url"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// validateApplicationCustomizations checks the credentials and the redirect URL of the application
+// match the authentication mode of the product
+func validateApplicationCustomizations(applicationResource *capabilitiesv1beta1.Application, productResource *capabilitiesv1beta1.Product) error {
+	// User key authentication is the default of the products
+	authenticationMode := "1"
+	if mode := productResource.Spec.AuthenticationMode(); mode != nil {
+		authenticationMode = *mode
+	}
+
+	errors := field.ErrorList{}
+	specFldPath := field.NewPath("spec")
+	if applicationResource.Spec.UserKeySecretRef != nil && authenticationMode != "1" {
+		errors = append(errors, field.Invalid(specFldPath.Child("userKeySecretRef"), applicationResource.Spec.UserKeySecretRef, "user key requires a product with user key authentication"))
+	}
+	if applicationResource.Spec.ApplicationIDSecretRef != nil && authenticationMode != "2" && authenticationMode != "oidc" {
+		errors = append(errors, field.Invalid(specFldPath.Child("applicationIDSecretRef"), applicationResource.Spec.ApplicationIDSecretRef, "application ID requires a product with app id and app key or OpenID Connect authentication"))
+	}
+	if applicationResource.Spec.RedirectURL != nil && authenticationMode != "oidc" {
+		errors = append(errors, field.Invalid(specFldPath.Child("redirectURL"), applicationResource.Spec.RedirectURL, "redirect URL requires a product with OpenID Connect authentication"))
+	}
+
+	if len(errors) == 0 {
+		return nil
+	}
+
+	return &helper.SpecFieldError{
+		ErrorType:      helper.InvalidError,
+		FieldErrorList: errors,
+	}
+}
+
+// applicationCreateParams returns the params the application is created with:
+// the user key, the application ID and the redirect URL. Empty when none is set
+func applicationCreateParams(cl client.Client, applicationResource *capabilitiesv1beta1.Application) (url.Values, error) {
+	params := url.Values{}
+
+	if applicationResource.Spec.UserKeySecretRef != nil {
+		userKey, err := applicationSecretKeyValue(cl, applicationResource.Namespace, applicationResource.Spec.UserKeySecretRef)
+		if err != nil {
+			return nil, err
+		}
+		params.Set("user_key", userKey)
+	}
+
+	if applicationResource.Spec.ApplicationIDSecretRef != nil {
+		applicationID, err := applicationSecretKeyValue(cl, applicationResource.Namespace, applicationResource.Spec.ApplicationIDSecretRef)
+		if err != nil {
+			return nil, err
+		}
+		params.Set("application_id", applicationID)
+	}
+
+	if applicationResource.Spec.RedirectURL != nil {
+		params.Set("redirect_url", *applicationResource.Spec.RedirectURL)
+	}
+
+	return params, nil
+}
+
+// reconcileApplicationCustomizations syncs the user key, the redirect URL and the referrer filters of the application.
+// The application ID is only set when the application is created
+func (r *ApplicationReconciler) reconcileApplicationCustomizations(applicationResource *capabilitiesv1beta1.Application, accountResource *capabilitiesv1beta1.DeveloperAccount, applicationEntity *controllerhelper.ApplicationEntity, threescaleAPIClient *threescaleapi.ThreeScaleClient, credentialsClient *controllerhelper.ApplicationCredentialsClient) error {
+	accountID := *accountResource.Status.ID
+	params := threescaleapi.Params{}
+
+	if applicationResource.Spec.UserKeySecretRef != nil {
+		userKey, err := applicationSecretKeyValue(r.Client(), applicationResource.Namespace, applicationResource.Spec.UserKeySecretRef)
+		if err != nil {
+			return err
+		}
+		if applicationEntity.UserKey() != userKey {
+			params["user_key"] = userKey
+		}
+	}
+
+	if applicationResource.Spec.RedirectURL != nil {
+		credentials, err := credentialsClient.ApplicationCredentials(accountID, applicationEntity.ID())
+		if err != nil {
+			return fmt.Errorf("error reading application [%s;%d] credentials: %w", applicationResource.Spec.Name, applicationEntity.ID(), err)
+		}
+		if credentials.RedirectURL != *applicationResource.Spec.RedirectURL {
+			params["redirect_url"] = *applicationResource.Spec.RedirectURL
+		}
+	}
+
+	if len(params) > 0 {
+		_, err := threescaleAPIClient.UpdateApplication(accountID, applicationEntity.ID(), params)
+		if err != nil {
+			return fmt.Errorf("error sync application [%s;%d]: %w", applicationResource.Spec.Name, applicationEntity.ID(), err)
+		}
+	}
+
+	return r.syncReferrerFilters(applicationResource, accountID, applicationEntity.ID(), credentialsClient)
+}
+
+// syncReferrerFilters adds the referrer filters of the spec and removes the ones not listed.
+// Nothing is done when the referrer filters are not set
+func (r *ApplicationReconciler) syncReferrerFilters(applicationResource *capabilitiesv1beta1.Application, accountID, applicationID int64, credentialsClient *controllerhelper.ApplicationCredentialsClient) error {
+	if applicationResource.Spec.ReferrerFilters == nil {
+		return nil
+	}
+
+	existingList, err := credentialsClient.ReferrerFilters(accountID, applicationID)
+	if err != nil {
+		return fmt.Errorf("error reading application [%s;%d] referrer filters: %w", applicationResource.Spec.Name, applicationID, err)
+	}
+
+	desired := map[string]bool{}
+	for _, value := range applicationResource.Spec.ReferrerFilters {
+		desired[value] = true
+	}
+
+	existing := map[string]bool{}
+	for _, filter := range existingList {
+		existing[filter.Value] = true
+		if desired[filter.Value] {
+			continue
+		}
+		err = credentialsClient.DeleteReferrerFilter(accountID, applicationID, filter.ID)
+		if err != nil {
+			return fmt.Errorf("error removing application [%s;%d] referrer filter: %w", applicationResource.Spec.Name, applicationID, err)
+		}
+	}
+
+	for _, value := range applicationResource.Spec.ReferrerFilters {
+		if existing[value] {
+			continue
+		}
+		err = credentialsClient.CreateReferrerFilter(accountID, applicationID, value)
+		if err != nil {
+			return fmt.Errorf("error adding application [%s;%d] referrer filter: %w", applicationResource.Spec.Name, applicationID, err)
+		}
+		existing[value] = true
+	}
+
+	return nil
+}
+
+// applicationSecretKeyValue returns the value of the secret key. The secret and the key are required
+func applicationSecretKeyValue(cl client.Client, namespace string, selector *corev1.SecretKeySelector) (string, error) {
+	value, err := helper.NewSecretSource(cl, namespace).RequiredFieldValueFromRequiredSecret(selector.Name, selector.Key)
+	if err != nil {
+		return "", err
+	}
+	if value == "" {
+		return "", fmt.Errorf("secret [%s] has an empty key [%s]", selector.Name, selector.Key)
+	}
+	return value, nil
+}
@@ -0,0 +1,164 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// mockHttpClientReferrerFilters serves the referrer filters of the application 3 of the account 3, recording the changes
+func mockHttpClientReferrerFilters(filters *[]controllerhelper.ApplicationReferrerFilter) *http.Client {
+	return NewTestClient(func(req *http.Request) *http.Response {
+		filtersPath := "/admin/api/accounts/3/applications/3/referrer_filters"
+		statusCode := http.StatusOK
+		body := ""
+		switch {
+		case req.Method == "GET" && req.URL.Path == filtersPath+".json":
+			values := []string{}
+			for _, filter := range *filters {
+				values = append(values, fmt.Sprintf(`{"referrer_filter": {"id": %d, "value": "%s"}}`, filter.ID, filter.Value))
+			}
+			body = fmt.Sprintf(`{"referrer_filters": [%s]}`, strings.Join(values, ","))
+		case req.Method == "POST" && req.URL.Path == filtersPath+".json":
+			_ = req.ParseForm()
+			*filters = append(*filters, controllerhelper.ApplicationReferrerFilter{ID: int64(len(*filters) + 10), Value: req.PostForm.Get("referrer_filter")})
+			statusCode = http.StatusCreated
+		case req.Method == "DELETE" && strings.HasPrefix(req.URL.Path, filtersPath+"/"):
+			deleted := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, filtersPath+"/"), ".json")
+			remaining := []controllerhelper.ApplicationReferrerFilter{}
+			for _, filter := range *filters {
+				if fmt.Sprint(filter.ID) != deleted {
+					remaining = append(remaining, filter)
+				}
+			}
+			*filters = remaining
+		default:
+			statusCode = http.StatusNotFound
+		}
+		return &http.Response{
+			StatusCode: statusCode,
+			Header:     make(http.Header),
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+	})
+}
+
+func TestValidateApplicationCustomizations(t *testing.T) {
+	secretRef := &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"}, Key: "key"}
+	redirectURL := "https://example.com/callback"
+	appKeyAppID := &capabilitiesv1beta1.AuthenticationSpec{AppKeyAppIDAuthentication: &capabilitiesv1beta1.AppKeyAppIDAuthenticationSpec{}}
+	oidc := &capabilitiesv1beta1.AuthenticationSpec{OIDC: &capabilitiesv1beta1.OIDCSpec{}}
+
+	cases := []struct {
+		name           string
+		spec           capabilitiesv1beta1.ApplicationSpec
+		authentication *capabilitiesv1beta1.AuthenticationSpec
+		expectedErr    bool
+	}{
+		{"NoCustomization", capabilitiesv1beta1.ApplicationSpec{}, nil, false},
+		{"UserKey", capabilitiesv1beta1.ApplicationSpec{UserKeySecretRef: secretRef}, nil, false},
+		{"UserKeyWithAppKeyAppID", capabilitiesv1beta1.ApplicationSpec{UserKeySecretRef: secretRef}, appKeyAppID, true},
+		{"ApplicationID", capabilitiesv1beta1.ApplicationSpec{ApplicationIDSecretRef: secretRef}, appKeyAppID, false},
+		{"ApplicationIDWithUserKey", capabilitiesv1beta1.ApplicationSpec{ApplicationIDSecretRef: secretRef}, nil, true},
+		{"RedirectURL", capabilitiesv1beta1.ApplicationSpec{ApplicationIDSecretRef: secretRef, RedirectURL: &redirectURL}, oidc, false},
+		{"RedirectURLWithAppKeyAppID", capabilitiesv1beta1.ApplicationSpec{RedirectURL: &redirectURL}, appKeyAppID, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			applicationResource := getApplicationCR()
+			applicationResource.Spec.UserKeySecretRef = tc.spec.UserKeySecretRef
+			applicationResource.Spec.ApplicationIDSecretRef = tc.spec.ApplicationIDSecretRef
+			applicationResource.Spec.RedirectURL = tc.spec.RedirectURL
+			productResource := getApplicationProductCR()
+			if tc.authentication != nil {
+				productResource.Spec.Deployment = &capabilitiesv1beta1.ProductDeploymentSpec{
+					ApicastHosted: &capabilitiesv1beta1.ApicastHostedSpec{Authentication: tc.authentication},
+				}
+			}
+
+			err := validateApplicationCustomizations(applicationResource, productResource)
+			if tc.expectedErr != helper.IsInvalidSpecError(err) || (!tc.expectedErr && err != nil) {
+				subT.Errorf("unexpected error %v", err)
+			}
+		})
+	}
+}
+
+func TestApplicationCreateParams(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "test"},
+		Data:       map[string][]byte{"user_key": []byte("userkey1"), "app_id": []byte("a1b2c3")},
+	}
+	r := &ApplicationReconciler{BaseReconciler: getBaseReconciler(secret)}
+
+	applicationResource := getApplicationCR()
+	params, err := applicationCreateParams(r.Client(), applicationResource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(params) != 0 {
+		t.Errorf("expected no params, got %v", params)
+	}
+
+	redirectURL := "https://example.com/callback"
+	applicationResource.Spec.UserKeySecretRef = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"}, Key: "user_key"}
+	applicationResource.Spec.ApplicationIDSecretRef = &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"}, Key: "app_id"}
+	applicationResource.Spec.RedirectURL = &redirectURL
+	params, err = applicationCreateParams(r.Client(), applicationResource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := url.Values{"user_key": {"userkey1"}, "application_id": {"a1b2c3"}, "redirect_url": {redirectURL}}
+	if !reflect.DeepEqual(params, expected) {
+		t.Errorf("applicationCreateParams() got = %v, want %v", params, expected)
+	}
+
+	// The secret key is required
+	applicationResource.Spec.UserKeySecretRef.Key = "missing"
+	_, err = applicationCreateParams(r.Client(), applicationResource)
+	if err == nil {
+		t.Error("expected error for a missing secret key")
+	}
+}
+
+func TestApplicationReconciler_syncReferrerFilters(t *testing.T) {
+	filters := []controllerhelper.ApplicationReferrerFilter{{ID: 1, Value: "*.example.com"}, {ID: 2, Value: "old.example.net"}}
+	adminURL, _ := url.Parse("https://3scale-admin.test.3scale.net")
+	credentialsClient := controllerhelper.NewApplicationCredentialsClient(adminURL, "test", mockHttpClientReferrerFilters(&filters))
+	r := &ApplicationReconciler{BaseReconciler: getBaseReconciler()}
+
+	// Nothing is done when the referrer filters are not set
+	applicationResource := getApplicationCR()
+	err := r.syncReferrerFilters(applicationResource, 3, 3, credentialsClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filters) != 2 {
+		t.Errorf("expected referrer filters unchanged, got %v", filters)
+	}
+
+	applicationResource.Spec.ReferrerFilters = []string{"*.example.com", "new.example.org"}
+	err = r.syncReferrerFilters(applicationResource, 3, 3, credentialsClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := []string{}
+	for _, filter := range filters {
+		values = append(values, filter.Value)
+	}
+	if !reflect.DeepEqual(values, applicationResource.Spec.ReferrerFilters) {
+		t.Errorf("referrer filters got = %v, want %v", values, applicationResource.Spec.ReferrerFilters)
+	}
+}
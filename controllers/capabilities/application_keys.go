@@ -0,0 +1,280 @@
+package controllers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// ApplicationKeySecretLabelPrefix labels the application with the UIDs of the secrets referenced by its application keys
+	ApplicationKeySecretLabelPrefix = "secret.application.capabilities.3scale.net/"
+	applicationKeySecretLabelValue  = "true"
+)
+
+// applicationKeysClock is the clock of the application keys rotation. Replaced in tests
+var applicationKeysClock clock.Clock = clock.RealClock{}
+
+// reconcileApplicationKeySecretLabels labels the application with the UIDs of the secrets referenced by
+// its application keys, so that changes in the secrets trigger the reconciliation of the application.
+// Missing secrets are skipped, they are reported when reading the keys. Returns true when the labels changed
+func (r *ApplicationReconciler) reconcileApplicationKeySecretLabels(applicationResource *capabilitiesv1beta1.Application) (bool, error) {
+	desiredLabels := map[string]string{}
+	if applicationResource.Spec.ApplicationKeys != nil {
+		for _, selector := range applicationResource.Spec.ApplicationKeys.SecretKeyRefs {
+			secret := &v1.Secret{}
+			err := r.Client().Get(r.Context(), types.NamespacedName{Name: selector.Name, Namespace: applicationResource.Namespace}, secret)
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return false, fmt.Errorf("error reading application key secret [%s]: %w", selector.Name, err)
+			}
+			desiredLabels[ApplicationKeySecretLabelPrefix+string(secret.GetUID())] = applicationKeySecretLabelValue
+		}
+	}
+
+	labels := applicationResource.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	changed := false
+	for k := range labels {
+		if strings.HasPrefix(k, ApplicationKeySecretLabelPrefix) && desiredLabels[k] == "" {
+			delete(labels, k)
+			changed = true
+		}
+	}
+	for k, v := range desiredLabels {
+		if labels[k] != v {
+			labels[k] = v
+			changed = true
+		}
+	}
+	applicationResource.SetLabels(labels)
+
+	return changed, nil
+}
+
+// secretToApplications maps a secret to the applications labeled with its UID
+func (r *ApplicationReconciler) secretToApplications(obj client.Object) []reconcile.Request {
+	applicationList := &capabilitiesv1beta1.ApplicationList{}
+	err := r.Client().List(r.Context(), applicationList, client.InNamespace(obj.GetNamespace()), client.HasLabels{ApplicationKeySecretLabelPrefix + string(obj.GetUID())})
+	if err != nil {
+		r.Logger().Error(err, "reading application list", "secret", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for idx := range applicationList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&applicationList.Items[idx])})
+	}
+	return requests
+}
+
+// reconcileApplicationKeys syncs the keys of the application with the keys spec, rotating the
+// generated key and removing the replaced keys once their grace period has passed.
+// The status of the keys is set in the application entity. Nothing is done without keys spec
+func (r *ApplicationReconciler) reconcileApplicationKeys(applicationResource *capabilitiesv1beta1.Application, accountResource *capabilitiesv1beta1.DeveloperAccount, productResource *capabilitiesv1beta1.Product, applicationEntity *controllerhelper.ApplicationEntity, credentialsClient *controllerhelper.ApplicationCredentialsClient) error {
+	keysSpec := applicationResource.Spec.ApplicationKeys
+	if keysSpec == nil {
+		return nil
+	}
+
+	authenticationMode := productResource.Spec.AuthenticationMode()
+	if authenticationMode == nil || (*authenticationMode != "2" && *authenticationMode != "oidc") {
+		return &helper.SpecFieldError{
+			ErrorType: helper.InvalidError,
+			FieldErrorList: field.ErrorList{
+				field.Invalid(field.NewPath("spec").Child("applicationKeys"), applicationResource.Spec.ProductCR, "application keys require a product with app id and app key or OpenID Connect authentication"),
+			},
+		}
+	}
+
+	secretValues := map[string]string{}
+	for _, selector := range keysSpec.SecretKeyRefs {
+		secret := &v1.Secret{}
+		err := r.Client().Get(r.Context(), types.NamespacedName{Name: selector.Name, Namespace: applicationResource.Namespace}, secret)
+		if err != nil {
+			return fmt.Errorf("error reading application key secret [%s]: %w", selector.Name, err)
+		}
+		value := string(secret.Data[selector.Key])
+		if value == "" {
+			return fmt.Errorf("application key secret [%s] has no key [%s]", selector.Name, selector.Key)
+		}
+		secretValues[capabilitiesv1beta1.ApplicationKeySecretSource(selector)] = value
+	}
+
+	accountID := *accountResource.Status.ID
+	applicationID := applicationEntity.ID()
+	existingKeys, err := credentialsClient.ApplicationKeys(accountID, applicationID)
+	if err != nil {
+		return fmt.Errorf("error reading application [%s;%d] keys: %w", applicationResource.Spec.Name, applicationID, err)
+	}
+	existingHashes := map[string]bool{}
+	for _, key := range existingKeys {
+		existingHashes[applicationKeyHash(key)] = true
+	}
+
+	keysStatus, newKeys, err := nextApplicationKeys(keysSpec, applicationResource.Status.Keys, secretValues, existingHashes, applicationKeysClock.Now(), generateApplicationKey)
+	if err != nil {
+		return err
+	}
+
+	// The new keys are added before the replaced keys are removed, so the application always has a valid key
+	for _, key := range newKeys {
+		if existingHashes[applicationKeyHash(key)] {
+			continue
+		}
+		err = credentialsClient.CreateApplicationKey(accountID, applicationID, key)
+		if err != nil {
+			return fmt.Errorf("error adding application [%s;%d] key: %w", applicationResource.Spec.Name, applicationID, err)
+		}
+	}
+
+	managedHashes := map[string]bool{}
+	for _, keyStatus := range keysStatus {
+		managedHashes[keyStatus.Hash] = true
+	}
+	for _, key := range existingKeys {
+		if managedHashes[applicationKeyHash(key)] {
+			continue
+		}
+		err = credentialsClient.DeleteApplicationKey(accountID, applicationID, key)
+		if err != nil {
+			return fmt.Errorf("error removing application [%s;%d] key: %w", applicationResource.Spec.Name, applicationID, err)
+		}
+	}
+
+	applicationEntity.SetKeysStatus(keysStatus)
+	return nil
+}
+
+// nextApplicationKeys returns the status of the keys of the application, and the values of the keys
+// to add. secretValues holds the values of the keys read from secrets, by source. existingHashes holds
+// the hashes of the keys of the application. A replaced key is kept for the grace period
+func nextApplicationKeys(keysSpec *capabilitiesv1beta1.ApplicationKeysSpec, current []capabilitiesv1beta1.ApplicationKeyStatus, secretValues map[string]string, existingHashes map[string]bool, now time.Time, generate func() (string, error)) ([]capabilitiesv1beta1.ApplicationKeyStatus, []string, error) {
+	active := map[string]capabilitiesv1beta1.ApplicationKeyStatus{}
+	for _, keyStatus := range current {
+		if keyStatus.ExpirationTimestamp == nil {
+			active[keyStatus.Source] = keyStatus
+		}
+	}
+
+	next := []capabilitiesv1beta1.ApplicationKeyStatus{}
+	retiring := []capabilitiesv1beta1.ApplicationKeyStatus{}
+	newKeys := []string{}
+	gracePeriod := keysSpec.GracePeriod()
+
+	replace := func(source, value string) {
+		newKeys = append(newKeys, value)
+		next = append(next, capabilitiesv1beta1.ApplicationKeyStatus{
+			Source:            source,
+			Hash:              applicationKeyHash(value),
+			CreationTimestamp: metav1.NewTime(now),
+		})
+		previous, ok := active[source]
+		if ok && gracePeriod > 0 && existingHashes[previous.Hash] && previous.Hash != applicationKeyHash(value) {
+			expiration := metav1.NewTime(now.Add(gracePeriod))
+			previous.ExpirationTimestamp = &expiration
+			retiring = append(retiring, previous)
+		}
+	}
+
+	for _, selector := range keysSpec.SecretKeyRefs {
+		source := capabilitiesv1beta1.ApplicationKeySecretSource(selector)
+		value := secretValues[source]
+		previous, ok := active[source]
+		if ok && previous.Hash == applicationKeyHash(value) && existingHashes[previous.Hash] {
+			next = append(next, previous)
+			continue
+		}
+		replace(source, value)
+	}
+
+	if keysSpec.Generate {
+		source := capabilitiesv1beta1.ApplicationKeyGeneratedSource
+		previous, ok := active[source]
+		rotationInterval := keysSpec.RotationInterval()
+		rotationDue := rotationInterval > 0 && !now.Before(previous.CreationTimestamp.Add(rotationInterval))
+		if ok && existingHashes[previous.Hash] && !rotationDue {
+			next = append(next, previous)
+		} else {
+			value, err := generate()
+			if err != nil {
+				return nil, nil, err
+			}
+			replace(source, value)
+		}
+	}
+
+	// Keys replaced in previous reconciliations, until their grace period has passed
+	for _, keyStatus := range current {
+		if keyStatus.ExpirationTimestamp != nil && now.Before(keyStatus.ExpirationTimestamp.Time) && existingHashes[keyStatus.Hash] {
+			retiring = append(retiring, keyStatus)
+		}
+	}
+
+	return append(next, retiring...), newKeys, nil
+}
+
+// applicationKeysRequeueAfter returns the time until the next rotation of the generated key,
+// or the removal of a replaced key. Zero when there is none
+func applicationKeysRequeueAfter(applicationResource *capabilitiesv1beta1.Application, now time.Time) time.Duration {
+	keysSpec := applicationResource.Spec.ApplicationKeys
+	if keysSpec == nil {
+		return 0
+	}
+
+	var next *time.Time
+	setNext := func(t time.Time) {
+		if next == nil || t.Before(*next) {
+			next = &t
+		}
+	}
+	for _, keyStatus := range applicationResource.Status.Keys {
+		if keyStatus.ExpirationTimestamp != nil {
+			setNext(keyStatus.ExpirationTimestamp.Time)
+		} else if keyStatus.Source == capabilitiesv1beta1.ApplicationKeyGeneratedSource && keysSpec.RotationInterval() > 0 {
+			setNext(keyStatus.CreationTimestamp.Add(keysSpec.RotationInterval()))
+		}
+	}
+
+	if next == nil {
+		return 0
+	}
+	if next.Before(now) {
+		return time.Second
+	}
+	return next.Sub(now) + time.Second
+}
+
+// applicationKeyHash returns the hex encoded SHA-256 hash of the key
+func applicationKeyHash(key string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(key)))
+}
+
+// generateApplicationKey returns a random key with the format of the keys generated by 3scale
+func generateApplicationKey() (string, error) {
+	buf := make([]byte, 16)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,325 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-porta-go-client/client"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/clock"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+// mockHttpClientApplicationKeys serves the keys of the application 3 of the account 3, recording the changes
+func mockHttpClientApplicationKeys(keys *[]string) *http.Client {
+	return NewTestClient(func(req *http.Request) *http.Response {
+		keysPath := "/admin/api/accounts/3/applications/3/keys"
+		statusCode := http.StatusOK
+		body := ""
+		switch {
+		case req.Method == "GET" && req.URL.Path == keysPath+".json":
+			values := []string{}
+			for _, key := range *keys {
+				values = append(values, fmt.Sprintf(`{"key": {"value": "%s"}}`, key))
+			}
+			body = fmt.Sprintf(`{"keys": [%s]}`, strings.Join(values, ","))
+		case req.Method == "POST" && req.URL.Path == keysPath+".json":
+			_ = req.ParseForm()
+			*keys = append(*keys, req.PostForm.Get("key"))
+			statusCode = http.StatusCreated
+		case req.Method == "DELETE" && strings.HasPrefix(req.URL.Path, keysPath+"/"):
+			deleted := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, keysPath+"/"), ".json")
+			remaining := []string{}
+			for _, key := range *keys {
+				if key != deleted {
+					remaining = append(remaining, key)
+				}
+			}
+			*keys = remaining
+		default:
+			statusCode = http.StatusNotFound
+		}
+		return &http.Response{
+			StatusCode: statusCode,
+			Header:     make(http.Header),
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		}
+	})
+}
+
+func TestNextApplicationKeys(t *testing.T) {
+	now := time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC)
+	days := func(d int32) *int32 { return &d }
+	generate := func() (string, error) { return "generated2", nil }
+	secretRef := corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "keys"}, Key: "key"}
+	secretSource := capabilitiesv1beta1.ApplicationKeySecretSource(secretRef)
+	generatedStatus := capabilitiesv1beta1.ApplicationKeyStatus{
+		Source:            capabilitiesv1beta1.ApplicationKeyGeneratedSource,
+		Hash:              applicationKeyHash("generated1"),
+		CreationTimestamp: metav1.NewTime(now.Add(-5 * 24 * time.Hour)),
+	}
+	expiration := metav1.NewTime(now.Add(24 * time.Hour))
+	expired := metav1.NewTime(now.Add(-time.Hour))
+
+	cases := []struct {
+		name             string
+		keysSpec         *capabilitiesv1beta1.ApplicationKeysSpec
+		current          []capabilitiesv1beta1.ApplicationKeyStatus
+		secretValues     map[string]string
+		existingKeys     []string
+		expectedHashes   []string
+		expectedExpiring []string
+		expectedNewKeys  []string
+	}{
+		{"NewGeneratedKey", &capabilitiesv1beta1.ApplicationKeysSpec{Generate: true},
+			nil, nil, nil,
+			[]string{applicationKeyHash("generated2")}, nil, []string{"generated2"}},
+		{"KeepGeneratedKey", &capabilitiesv1beta1.ApplicationKeysSpec{Generate: true, Rotation: &capabilitiesv1beta1.ApplicationKeyRotationSpec{IntervalDays: days(30)}},
+			[]capabilitiesv1beta1.ApplicationKeyStatus{generatedStatus}, nil, []string{"generated1"},
+			[]string{applicationKeyHash("generated1")}, nil, []string{}},
+		{"GeneratedKeyRemoved", &capabilitiesv1beta1.ApplicationKeysSpec{Generate: true},
+			[]capabilitiesv1beta1.ApplicationKeyStatus{generatedStatus}, nil, nil,
+			[]string{applicationKeyHash("generated2")}, nil, []string{"generated2"}},
+		{"RotationDueWithGracePeriod", &capabilitiesv1beta1.ApplicationKeysSpec{Generate: true, Rotation: &capabilitiesv1beta1.ApplicationKeyRotationSpec{IntervalDays: days(5), GracePeriodDays: days(1)}},
+			[]capabilitiesv1beta1.ApplicationKeyStatus{generatedStatus}, nil, []string{"generated1"},
+			[]string{applicationKeyHash("generated2"), applicationKeyHash("generated1")}, []string{applicationKeyHash("generated1")}, []string{"generated2"}},
+		{"RotationDueWithoutGracePeriod", &capabilitiesv1beta1.ApplicationKeysSpec{Generate: true, Rotation: &capabilitiesv1beta1.ApplicationKeyRotationSpec{IntervalDays: days(5)}},
+			[]capabilitiesv1beta1.ApplicationKeyStatus{generatedStatus}, nil, []string{"generated1"},
+			[]string{applicationKeyHash("generated2")}, nil, []string{"generated2"}},
+		{"SecretKeyChanged", &capabilitiesv1beta1.ApplicationKeysSpec{SecretKeyRefs: []corev1.SecretKeySelector{secretRef}, Rotation: &capabilitiesv1beta1.ApplicationKeyRotationSpec{GracePeriodDays: days(1)}},
+			[]capabilitiesv1beta1.ApplicationKeyStatus{{Source: secretSource, Hash: applicationKeyHash("secret1"), CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))}},
+			map[string]string{secretSource: "secret2"}, []string{"secret1"},
+			[]string{applicationKeyHash("secret2"), applicationKeyHash("secret1")}, []string{applicationKeyHash("secret1")}, []string{"secret2"}},
+		{"ReplacedKeyKept", &capabilitiesv1beta1.ApplicationKeysSpec{SecretKeyRefs: []corev1.SecretKeySelector{secretRef}},
+			[]capabilitiesv1beta1.ApplicationKeyStatus{
+				{Source: secretSource, Hash: applicationKeyHash("secret2"), CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))},
+				{Source: secretSource, Hash: applicationKeyHash("secret1"), CreationTimestamp: metav1.NewTime(now.Add(-48 * time.Hour)), ExpirationTimestamp: &expiration},
+			},
+			map[string]string{secretSource: "secret2"}, []string{"secret1", "secret2"},
+			[]string{applicationKeyHash("secret2"), applicationKeyHash("secret1")}, []string{applicationKeyHash("secret1")}, []string{}},
+		{"ReplacedKeyExpired", &capabilitiesv1beta1.ApplicationKeysSpec{SecretKeyRefs: []corev1.SecretKeySelector{secretRef}},
+			[]capabilitiesv1beta1.ApplicationKeyStatus{
+				{Source: secretSource, Hash: applicationKeyHash("secret2"), CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))},
+				{Source: secretSource, Hash: applicationKeyHash("secret1"), CreationTimestamp: metav1.NewTime(now.Add(-48 * time.Hour)), ExpirationTimestamp: &expired},
+			},
+			map[string]string{secretSource: "secret2"}, []string{"secret1", "secret2"},
+			[]string{applicationKeyHash("secret2")}, nil, []string{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			existingHashes := map[string]bool{}
+			for _, key := range tc.existingKeys {
+				existingHashes[applicationKeyHash(key)] = true
+			}
+			keysStatus, newKeys, err := nextApplicationKeys(tc.keysSpec, tc.current, tc.secretValues, existingHashes, now, generate)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			hashes := []string{}
+			expiring := []string{}
+			for _, keyStatus := range keysStatus {
+				hashes = append(hashes, keyStatus.Hash)
+				if keyStatus.ExpirationTimestamp != nil {
+					expiring = append(expiring, keyStatus.Hash)
+				}
+			}
+			if fmt.Sprint(hashes) != fmt.Sprint(tc.expectedHashes) {
+				subT.Errorf("keys status hashes got = %v, want %v", hashes, tc.expectedHashes)
+			}
+			if fmt.Sprint(expiring) != fmt.Sprint(tc.expectedExpiring) {
+				subT.Errorf("expiring hashes got = %v, want %v", expiring, tc.expectedExpiring)
+			}
+			if fmt.Sprint(newKeys) != fmt.Sprint(tc.expectedNewKeys) {
+				subT.Errorf("new keys got = %v, want %v", newKeys, tc.expectedNewKeys)
+			}
+		})
+	}
+}
+
+func TestApplicationKeysRequeueAfter(t *testing.T) {
+	now := time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC)
+	days := int32(5)
+	expiration := metav1.NewTime(now.Add(time.Hour))
+
+	applicationResource := getApplicationCR()
+	if got := applicationKeysRequeueAfter(applicationResource, now); got != 0 {
+		t.Errorf("expected no requeue without keys spec, got %v", got)
+	}
+
+	applicationResource.Spec.ApplicationKeys = &capabilitiesv1beta1.ApplicationKeysSpec{
+		Generate: true,
+		Rotation: &capabilitiesv1beta1.ApplicationKeyRotationSpec{IntervalDays: &days},
+	}
+	applicationResource.Status.Keys = []capabilitiesv1beta1.ApplicationKeyStatus{
+		{Source: capabilitiesv1beta1.ApplicationKeyGeneratedSource, CreationTimestamp: metav1.NewTime(now.Add(-24 * time.Hour))},
+	}
+	if got, want := applicationKeysRequeueAfter(applicationResource, now), 4*24*time.Hour+time.Second; got != want {
+		t.Errorf("applicationKeysRequeueAfter() got = %v, want %v", got, want)
+	}
+
+	applicationResource.Status.Keys = append(applicationResource.Status.Keys, capabilitiesv1beta1.ApplicationKeyStatus{
+		Source: capabilitiesv1beta1.ApplicationKeyGeneratedSource, ExpirationTimestamp: &expiration,
+	})
+	if got, want := applicationKeysRequeueAfter(applicationResource, now), time.Hour+time.Second; got != want {
+		t.Errorf("applicationKeysRequeueAfter() got = %v, want %v", got, want)
+	}
+
+	if got := applicationKeysRequeueAfter(applicationResource, now.Add(2*time.Hour)); got != time.Second {
+		t.Errorf("expected requeue for a past expiration, got %v", got)
+	}
+}
+
+func TestApplicationReconciler_reconcileApplicationKeys(t *testing.T) {
+	now := time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC)
+	applicationKeysClock = clocktesting.NewFakeClock(now)
+	defer func() { applicationKeysClock = clock.RealClock{} }()
+
+	keys := []string{"unmanaged"}
+	ap, _ := client.NewAdminPortal("https", "3scale-admin.test.3scale.net", 443)
+	adminURL, _ := url.Parse("https://3scale-admin.test.3scale.net")
+	credentialsClient := controllerhelper.NewApplicationCredentialsClient(adminURL, "test", mockHttpClientApplicationKeys(&keys))
+	entity := controllerhelper.NewApplicationEntity(&client.Application{ID: 3}, client.NewThreeScale(ap, "test", mockHttpClientApplicationKeys(&keys)), logr.Discard())
+
+	keySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "keys", Namespace: "test"},
+		Data:       map[string][]byte{"key": []byte("secretkey")},
+	}
+	applicationResource := getApplicationCR()
+	applicationResource.Spec.ApplicationKeys = &capabilitiesv1beta1.ApplicationKeysSpec{
+		SecretKeyRefs: []corev1.SecretKeySelector{{LocalObjectReference: corev1.LocalObjectReference{Name: "keys"}, Key: "key"}},
+		Generate:      true,
+	}
+	productResource := getApplicationProductCR()
+
+	r := &ApplicationReconciler{BaseReconciler: getBaseReconciler(keySecret)}
+
+	// Keys require app id and app key, or OpenID Connect authentication
+	err := r.reconcileApplicationKeys(applicationResource, getApplicationDeveloperAccount(), productResource, entity, credentialsClient)
+	if !helper.IsInvalidSpecError(err) {
+		t.Fatalf("expected invalid spec error, got %v", err)
+	}
+
+	productResource.Spec.Deployment = &capabilitiesv1beta1.ProductDeploymentSpec{
+		ApicastHosted: &capabilitiesv1beta1.ApicastHostedSpec{
+			Authentication: &capabilitiesv1beta1.AuthenticationSpec{AppKeyAppIDAuthentication: &capabilitiesv1beta1.AppKeyAppIDAuthenticationSpec{}},
+		},
+	}
+	err = r.reconcileApplicationKeys(applicationResource, getApplicationDeveloperAccount(), productResource, entity, credentialsClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(keys) != 2 || keys[0] != "secretkey" {
+		t.Fatalf("expected the secret key and a generated key, got %v", keys)
+	}
+	keysStatus := entity.KeysStatus()
+	if len(keysStatus) != 2 {
+		t.Fatalf("expected 2 keys in status, got %v", keysStatus)
+	}
+	if keysStatus[0].Hash != applicationKeyHash("secretkey") || keysStatus[1].Source != capabilitiesv1beta1.ApplicationKeyGeneratedSource ||
+		keysStatus[1].Hash != applicationKeyHash(keys[1]) || !keysStatus[1].CreationTimestamp.Time.Equal(now) {
+		t.Errorf("unexpected keys status %v", keysStatus)
+	}
+
+	// A missing key secret is reported
+	missing := applicationResource.DeepCopy()
+	missing.Spec.ApplicationKeys.SecretKeyRefs[0].Name = "missing"
+	err = r.reconcileApplicationKeys(missing, getApplicationDeveloperAccount(), productResource, entity, credentialsClient)
+	if err == nil {
+		t.Error("expected error for a missing key secret")
+	}
+
+	// Nothing is done without keys spec
+	applicationResource.Spec.ApplicationKeys = nil
+	err = r.reconcileApplicationKeys(applicationResource, getApplicationDeveloperAccount(), productResource, entity, credentialsClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 {
+		t.Errorf("expected keys unchanged without keys spec, got %v", keys)
+	}
+}
+
+func TestApplicationReconciler_reconcileApplicationKeySecretLabels(t *testing.T) {
+	keySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "keys", Namespace: "test", UID: "keysuid"},
+		Data:       map[string][]byte{"key": []byte("secretkey")},
+	}
+	applicationResource := getApplicationCR()
+	applicationResource.Labels = map[string]string{
+		"app":                                   "test",
+		ApplicationKeySecretLabelPrefix + "old": applicationKeySecretLabelValue,
+	}
+	applicationResource.Spec.ApplicationKeys = &capabilitiesv1beta1.ApplicationKeysSpec{
+		SecretKeyRefs: []corev1.SecretKeySelector{
+			{LocalObjectReference: corev1.LocalObjectReference{Name: "keys"}, Key: "key"},
+			{LocalObjectReference: corev1.LocalObjectReference{Name: "missing"}, Key: "key"},
+		},
+	}
+	otherApplication := getApplicationCR()
+	otherApplication.Name = "other"
+
+	r := &ApplicationReconciler{BaseReconciler: getBaseReconciler(keySecret, applicationResource, otherApplication)}
+
+	changed, err := r.reconcileApplicationKeySecretLabels(applicationResource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedLabels := map[string]string{
+		"app": "test",
+		ApplicationKeySecretLabelPrefix + "keysuid": applicationKeySecretLabelValue,
+	}
+	if !changed || !reflect.DeepEqual(applicationResource.Labels, expectedLabels) {
+		t.Fatalf("labels got = %v (changed %t), want %v", applicationResource.Labels, changed, expectedLabels)
+	}
+
+	changed, err = r.reconcileApplicationKeySecretLabels(applicationResource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed {
+		t.Error("expected unchanged labels")
+	}
+
+	err = r.Client().Update(r.Context(), applicationResource)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The secret is mapped to the applications referencing it
+	requests := r.secretToApplications(keySecret)
+	if len(requests) != 1 || requests[0].NamespacedName != (types.NamespacedName{Name: "test", Namespace: "test"}) {
+		t.Errorf("requests got = %v, want the application %s", requests, types.NamespacedName{Name: "test", Namespace: "test"})
+	}
+
+	// Other secrets are not mapped
+	otherSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "test", UID: "otheruid"}}
+	if requests := r.secretToApplications(otherSecret); len(requests) != 0 {
+		t.Errorf("requests got = %v, want none", requests)
+	}
+
+	// The labels are removed along with the keys spec
+	applicationResource.Spec.ApplicationKeys = nil
+	changed, err = r.reconcileApplicationKeySecretLabels(applicationResource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed || !reflect.DeepEqual(applicationResource.Labels, map[string]string{"app": "test"}) {
+		t.Errorf("labels got = %v (changed %t), want only the app label", applicationResource.Labels, changed)
+	}
+}
@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"fmt"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+// reconcilePlanCustomization customizes the application plan for the application, and syncs the customized plan
+// with the plan customization spec. The plan is decustomized when the plan customization is removed,
+// or when the application plan changes. The status of the customized plan is set in the application entity
+func (r *ApplicationReconciler) reconcilePlanCustomization(applicationResource *capabilitiesv1beta1.Application, accountResource *capabilitiesv1beta1.DeveloperAccount, productResource *capabilitiesv1beta1.Product, applicationEntity *controllerhelper.ApplicationEntity, threescaleAPIClient *threescaleapi.ThreeScaleClient) error {
+	accountID := *accountResource.Status.ID
+	productID := *productResource.Status.ID
+	customizationSpec := applicationResource.Spec.PlanCustomization
+
+	// The customized plan is ignored when the application plan has been changed out of the operator
+	planCustomization := applicationResource.Status.PlanCustomization
+	if !applicationResource.Status.IsPlanCustomized(applicationEntity.PlanID()) {
+		planCustomization = nil
+	}
+
+	planID, err := findApplicationPlanID(threescaleAPIClient, productID, applicationResource.Spec.ApplicationPlanName)
+	if err != nil {
+		return fmt.Errorf("error sync application [%s] plan customization: %w", applicationResource.Spec.Name, err)
+	}
+
+	if planCustomization != nil && (customizationSpec == nil || planCustomization.OriginalPlanID != planID) {
+		err = threescaleAPIClient.DeleteApplicationCustomPlan(accountID, applicationEntity.ID())
+		if err != nil {
+			return fmt.Errorf("error decustomizing application [%s;%d] plan: %w", applicationResource.Spec.Name, applicationEntity.ID(), err)
+		}
+		applicationEntity.SetPlanID(planCustomization.OriginalPlanID)
+		applicationEntity.SetPlanCustomization(nil)
+
+		if planCustomization.OriginalPlanID != planID {
+			_, err = threescaleAPIClient.ChangeApplicationPlan(accountID, applicationEntity.ID(), planID)
+			if err != nil {
+				return fmt.Errorf("error sync application [%s;%d]: %w", applicationResource.Spec.Name, applicationEntity.ID(), err)
+			}
+			applicationEntity.SetPlanID(planID)
+		}
+		planCustomization = nil
+	}
+
+	if customizationSpec == nil {
+		applicationEntity.SetPlanCustomization(nil)
+		return nil
+	}
+
+	if planCustomization == nil {
+		customPlan, err := threescaleAPIClient.CreateApplicationCustomPlan(accountID, applicationEntity.ID())
+		if err != nil {
+			return fmt.Errorf("error customizing application [%s;%d] plan: %w", applicationResource.Spec.Name, applicationEntity.ID(), err)
+		}
+		planCustomization = &capabilitiesv1beta1.ApplicationPlanCustomizationStatus{
+			PlanID:         customPlan.ID,
+			OriginalPlanID: planID,
+		}
+		applicationEntity.SetPlanID(customPlan.ID)
+	}
+	// Set before the customized plan is synced, so it is kept in the status when the sync fails
+	applicationEntity.SetPlanCustomization(planCustomization)
+
+	customPlan, err := threescaleAPIClient.ApplicationPlan(productID, planCustomization.PlanID)
+	if err != nil {
+		return fmt.Errorf("error reading application [%s;%d] customized plan: %w", applicationResource.Spec.Name, applicationEntity.ID(), err)
+	}
+
+	product, err := threescaleAPIClient.Product(productID)
+	if err != nil {
+		return fmt.Errorf("error reading product [%d]: %w", productID, err)
+	}
+	productEntity := controllerhelper.NewProductEntity(product, threescaleAPIClient, r.Logger())
+
+	backendRemoteIndex, err := controllerhelper.NewBackendAPIRemoteIndex(threescaleAPIClient, r.Logger())
+	if err != nil {
+		return err
+	}
+
+	planEntity := controllerhelper.NewApplicationPlanEntity(productID, customPlan.Element, threescaleAPIClient, r.Logger())
+	reconciler := newApplicationPlanReconciler(r.BaseReconciler, customPlan.Element.SystemName, customizedApplicationPlanSpec(customizationSpec, planEntity), threescaleAPIClient, productEntity, backendRemoteIndex, planEntity, r.Logger())
+	// The customized plan is copied from the application plan. Limits and pricing rules not customized are kept
+	reconciler.keepLimits = customizationSpec.Limits == nil
+	reconciler.keepPricingRules = customizationSpec.PricingRules == nil
+	err = reconciler.Reconcile()
+	if err != nil {
+		return fmt.Errorf("error sync application [%s;%d] customized plan: %w", applicationResource.Spec.Name, applicationEntity.ID(), err)
+	}
+
+	return nil
+}
+
+// customizedApplicationPlanSpec returns the application plan spec of the customized plan.
+// The name, the approval and the state of the customized plan are not changed
+func customizedApplicationPlanSpec(customizationSpec *capabilitiesv1beta1.ApplicationPlanCustomizationSpec, planEntity *controllerhelper.ApplicationPlanEntity) capabilitiesv1beta1.ApplicationPlanSpec {
+	published := planEntity.State() == "published"
+	return capabilitiesv1beta1.ApplicationPlanSpec{
+		TrialPeriod:  customizationSpec.TrialPeriod,
+		SetupFee:     customizationSpec.SetupFee,
+		CostMonth:    customizationSpec.CostMonth,
+		PricingRules: customizationSpec.PricingRules,
+		Limits:       customizationSpec.Limits,
+		Published:    &published,
+	}
+}
+
+// findApplicationPlanID returns the ID of the application plan of the product with the given system name
+func findApplicationPlanID(threescaleAPIClient *threescaleapi.ThreeScaleClient, productID int64, systemName string) (int64, error) {
+	planList, err := threescaleAPIClient.ListApplicationPlansByProduct(productID)
+	if err != nil {
+		return -1, err
+	}
+
+	for _, item := range planList.Plans {
+		if item.Element.SystemName == systemName {
+			return item.Element.ID, nil
+		}
+	}
+	return -1, fmt.Errorf("plan [%s] doesnt exist in product [%d]", systemName, productID)
+}
@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-porta-go-client/client"
+	"github.com/go-logr/logr"
+)
+
+// mockHttpClientPlanCustomization serves the plans of the product 3, the plan 10 and the customized plan 20.
+// The customized plan has the limit and the pricing rule copied from the plan 10.
+// The requests changing the application are recorded
+func mockHttpClientPlanCustomization(requests *[]string) *http.Client {
+	return NewTestClient(func(req *http.Request) *http.Response {
+		var body interface{}
+		statusCode := http.StatusOK
+		switch req.Method + " " + req.URL.Path {
+		case "GET /admin/api/services/3/application_plans.json":
+			body = &client.ApplicationPlanJSONList{Plans: []client.ApplicationPlan{
+				{Element: client.ApplicationPlanItem{ID: 10, SystemName: "test", State: "published"}},
+				{Element: client.ApplicationPlanItem{ID: 11, SystemName: "other", State: "published"}},
+			}}
+		case "PUT /admin/api/accounts/3/applications/3/customize_plan.json":
+			body = &client.ApplicationPlan{Element: client.ApplicationPlanItem{ID: 20, SystemName: "test_custom", State: "published", Custom: true}}
+		case "PUT /admin/api/accounts/3/applications/3/decustomize_plan.json", "PUT /admin/api/accounts/3/applications/3/change_plan.json":
+			body = &client.ApplicationElem{Application: client.Application{ID: 3}}
+		case "GET /admin/api/services/3/application_plans/20.json":
+			body = &client.ApplicationPlan{Element: client.ApplicationPlanItem{ID: 20, SystemName: "test_custom", State: "published", Custom: true}}
+		case "GET /admin/api/services/3.json":
+			body = &client.Product{Element: client.ProductItem{ID: 3, SystemName: "test"}}
+		case "GET /admin/api/backend_apis.json":
+			body = &client.BackendApiList{}
+		case "GET /admin/api/services/3/metrics.json":
+			body = &client.MetricJSONList{Metrics: []client.MetricJSON{{Element: client.MetricItem{ID: 1, SystemName: "hits"}}}}
+		case "PUT /admin/api/services/3/application_plans/20.json":
+			body = &client.ApplicationPlan{Element: client.ApplicationPlanItem{ID: 20, SystemName: "test_custom", State: "published", Custom: true, SetupFee: 10}}
+		case "GET /admin/api/application_plans/20/limits.json":
+			body = &client.ApplicationPlanLimitList{Limits: []client.ApplicationPlanLimit{
+				{Element: client.ApplicationPlanLimitItem{ID: 5, MetricID: 1, Period: "month", Value: 1000}},
+			}}
+		case "GET /admin/api/application_plans/20/pricing_rules.json":
+			body = &client.ApplicationPlanPricingRuleList{Rules: []client.ApplicationPlanPricingRule{
+				{Element: client.ApplicationPlanPricingRuleItem{ID: 6, MetricID: 1, CostPerUnit: "0.01", Min: 1, Max: 100}},
+			}}
+		case "DELETE /admin/api/application_plans/20/metrics/1/limits/5.json", "DELETE /admin/api/application_plans/20/metrics/1/pricing_rules/6.json":
+		case "POST /admin/api/application_plans/20/metrics/1/limits.json":
+			body = &client.ApplicationPlanLimit{Element: client.ApplicationPlanLimitItem{ID: 1, MetricID: 1, Period: "day", Value: 100}}
+			statusCode = http.StatusCreated
+		default:
+			return &http.Response{StatusCode: http.StatusNotFound, Header: make(http.Header), Body: ioutil.NopCloser(bytes.NewBufferString(""))}
+		}
+		if req.Method != "GET" {
+			*requests = append(*requests, req.Method+" "+req.URL.Path)
+		}
+		return &http.Response{
+			StatusCode: statusCode,
+			Header:     make(http.Header),
+			Body:       ioutil.NopCloser(bytes.NewBuffer(responseBody(body))),
+		}
+	})
+}
+
+func TestApplicationReconciler_reconcilePlanCustomization(t *testing.T) {
+	ap, _ := client.NewAdminPortal("https", "3scale-admin.test.3scale.net", 443)
+	limits := []capabilitiesv1beta1.LimitSpec{
+		{Period: "day", Value: 100, MetricMethodRef: capabilitiesv1beta1.MetricMethodRefSpec{SystemName: "hits"}},
+	}
+	setupFee := "10.00"
+
+	cases := []struct {
+		name              string
+		customizationSpec *capabilitiesv1beta1.ApplicationPlanCustomizationSpec
+		planName          string
+		planID            int64
+		status            *capabilitiesv1beta1.ApplicationPlanCustomizationStatus
+		expectedStatus    *capabilitiesv1beta1.ApplicationPlanCustomizationStatus
+		expectedPlanID    int64
+		expectedRequests  []string
+	}{
+		{"NoCustomization", nil, "test", 10, nil, nil, 10, nil},
+		{"Customize", &capabilitiesv1beta1.ApplicationPlanCustomizationSpec{Limits: limits}, "test", 10, nil,
+			&capabilitiesv1beta1.ApplicationPlanCustomizationStatus{PlanID: 20, OriginalPlanID: 10}, 20,
+			[]string{
+				"PUT /admin/api/accounts/3/applications/3/customize_plan.json",
+				"DELETE /admin/api/application_plans/20/metrics/1/limits/5.json",
+				"POST /admin/api/application_plans/20/metrics/1/limits.json",
+			}},
+		{"CustomizeFees", &capabilitiesv1beta1.ApplicationPlanCustomizationSpec{SetupFee: &setupFee}, "test", 10, nil,
+			&capabilitiesv1beta1.ApplicationPlanCustomizationStatus{PlanID: 20, OriginalPlanID: 10}, 20,
+			[]string{
+				"PUT /admin/api/accounts/3/applications/3/customize_plan.json",
+				"PUT /admin/api/services/3/application_plans/20.json",
+			}},
+		{"RemoveLimits", &capabilitiesv1beta1.ApplicationPlanCustomizationSpec{Limits: []capabilitiesv1beta1.LimitSpec{}}, "test", 20,
+			&capabilitiesv1beta1.ApplicationPlanCustomizationStatus{PlanID: 20, OriginalPlanID: 10},
+			&capabilitiesv1beta1.ApplicationPlanCustomizationStatus{PlanID: 20, OriginalPlanID: 10}, 20,
+			[]string{"DELETE /admin/api/application_plans/20/metrics/1/limits/5.json"}},
+		{"Decustomize", nil, "test", 20, &capabilitiesv1beta1.ApplicationPlanCustomizationStatus{PlanID: 20, OriginalPlanID: 10}, nil, 10,
+			[]string{"PUT /admin/api/accounts/3/applications/3/decustomize_plan.json"}},
+		{"PlanChanged", nil, "other", 20, &capabilitiesv1beta1.ApplicationPlanCustomizationStatus{PlanID: 20, OriginalPlanID: 10}, nil, 11,
+			[]string{
+				"PUT /admin/api/accounts/3/applications/3/decustomize_plan.json",
+				"PUT /admin/api/accounts/3/applications/3/change_plan.json",
+			}},
+		{"CustomizedPlanReplaced", nil, "test", 10, &capabilitiesv1beta1.ApplicationPlanCustomizationStatus{PlanID: 20, OriginalPlanID: 10}, nil, 10, nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			requests := []string{}
+			threescaleAPIClient := client.NewThreeScale(ap, "test", mockHttpClientPlanCustomization(&requests))
+
+			applicationResource := getApplicationCR()
+			applicationResource.Spec.ApplicationPlanName = tc.planName
+			applicationResource.Spec.PlanCustomization = tc.customizationSpec
+			applicationResource.Status.PlanCustomization = tc.status
+			entity := controllerhelper.NewApplicationEntity(&client.Application{ID: 3, PlanID: tc.planID}, threescaleAPIClient, logr.Discard())
+
+			r := &ApplicationReconciler{BaseReconciler: getBaseReconciler()}
+			err := r.reconcilePlanCustomization(applicationResource, getApplicationDeveloperAccount(), getApplicationProductCR(), entity, threescaleAPIClient)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			planCustomization, synced := entity.PlanCustomization()
+			if !synced || !reflect.DeepEqual(planCustomization, tc.expectedStatus) {
+				subT.Errorf("plan customization got = %v, want %v", planCustomization, tc.expectedStatus)
+			}
+			if entity.PlanID() != tc.expectedPlanID {
+				subT.Errorf("plan ID got = %d, want %d", entity.PlanID(), tc.expectedPlanID)
+			}
+			if len(requests) != len(tc.expectedRequests) || (len(requests) > 0 && !reflect.DeepEqual(requests, tc.expectedRequests)) {
+				subT.Errorf("requests got = %v, want %v", requests, tc.expectedRequests)
+			}
+		})
+	}
+}
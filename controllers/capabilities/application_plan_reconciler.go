@@ -35,6 +35,10 @@ type applicationPlanReconciler struct {
 	planEntity          *controllerhelper.ApplicationPlanEntity
 	threescaleAPIClient *threescaleapi.ThreeScaleClient
 	logger              logr.Logger
+	// keepLimits skips the sync of the limits, keeping the ones of the plan
+	keepLimits bool
+	// keepPricingRules skips the sync of the pricing rules, keeping the ones of the plan
+	keepPricingRules bool
 }
 
 func newApplicationPlanReconciler(b *reconcilers.BaseReconciler,
@@ -63,8 +67,12 @@ func newApplicationPlanReconciler(b *reconcilers.BaseReconciler,
 func (a *applicationPlanReconciler) Reconcile() error {
 	taskRunner := helper.NewTaskRunner(nil, a.logger)
 	taskRunner.AddTask("SyncPlan", a.syncPlan)
-	taskRunner.AddTask("SyncLimits", a.syncLimits)
-	taskRunner.AddTask("SyncPricingRules", a.syncPricingRules)
+	if !a.keepLimits {
+		taskRunner.AddTask("SyncLimits", a.syncLimits)
+	}
+	if !a.keepPricingRules {
+		taskRunner.AddTask("SyncPricingRules", a.syncPricingRules)
+	}
 
 	err := taskRunner.Run()
 	if err != nil {
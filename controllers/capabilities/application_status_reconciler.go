@@ -79,12 +79,30 @@ func (s *ApplicationStatusReconciler) calculateStatus() *capabilitiesv1beta1.App
 		newStatus.State = s.entity.ApplicationState()
 	}
 
+	// The keys status is kept when the keys could not be synced
+	newStatus.Keys = s.applicationResource.Status.Keys
+	if s.entity != nil && s.entity.KeysStatus() != nil {
+		newStatus.Keys = s.entity.KeysStatus()
+	}
+	if s.applicationResource.Spec.ApplicationKeys == nil {
+		newStatus.Keys = nil
+	}
+
+	// The customized plan is kept when the plan customization could not be synced
+	newStatus.PlanCustomization = s.applicationResource.Status.PlanCustomization
+	if s.entity != nil {
+		if planCustomization, synced := s.entity.PlanCustomization(); synced {
+			newStatus.PlanCustomization = planCustomization
+		}
+	}
+
 	newStatus.ProviderAccountHost = s.providerAccountHost
 
 	newStatus.ObservedGeneration = s.applicationResource.Status.ObservedGeneration
 
 	newStatus.Conditions = s.applicationResource.Status.Conditions.Copy()
 	newStatus.Conditions.SetCondition(s.ReadyCondition())
+	newStatus.Conditions.SetCondition(s.pendingApprovalCondition(newStatus.State))
 
 	return newStatus
 }
@@ -106,3 +124,27 @@ func (s *ApplicationStatusReconciler) ReadyCondition() common.Condition {
 
 	return condition
 }
+
+// pendingApprovalCondition is true when the application is pending of approval in 3scale,
+// and the approval is not decided in the spec
+func (s *ApplicationStatusReconciler) pendingApprovalCondition(state string) common.Condition {
+	condition := common.Condition{
+		Type:   capabilitiesv1beta1.ApplicationPendingApprovalConditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	if state != applicationPendingState {
+		return condition
+	}
+
+	if s.applicationResource.Spec.Approval.IsDecided() {
+		if *s.applicationResource.Spec.Approval == capabilitiesv1beta1.ApprovalRejected {
+			condition.Message = "application rejected, it is kept pending of approval"
+		}
+		return condition
+	}
+
+	condition.Status = corev1.ConditionTrue
+	condition.Message = "application pending of approval, set spec.approval to approved or rejected"
+	return condition
+}
@@ -18,16 +18,18 @@ type ApplicationThreescaleReconciler struct {
 	accountResource     *capabilitiesv1beta1.DeveloperAccount
 	productResource     *capabilitiesv1beta1.Product
 	threescaleAPIClient *threescaleapi.ThreeScaleClient
+	credentialsClient   *controllerhelper.ApplicationCredentialsClient
 	logger              logr.Logger
 }
 
-func NewApplicationReconciler(b *reconcilers.BaseReconciler, applicationResource *capabilitiesv1beta1.Application, accountResource *capabilitiesv1beta1.DeveloperAccount, productResource *capabilitiesv1beta1.Product, threescaleAPIClient *threescaleapi.ThreeScaleClient) *ApplicationThreescaleReconciler {
+func NewApplicationReconciler(b *reconcilers.BaseReconciler, applicationResource *capabilitiesv1beta1.Application, accountResource *capabilitiesv1beta1.DeveloperAccount, productResource *capabilitiesv1beta1.Product, threescaleAPIClient *threescaleapi.ThreeScaleClient, credentialsClient *controllerhelper.ApplicationCredentialsClient) *ApplicationThreescaleReconciler {
 	return &ApplicationThreescaleReconciler{
 		BaseReconciler:      b,
 		applicationResource: applicationResource,
 		accountResource:     accountResource,
 		productResource:     productResource,
 		threescaleAPIClient: threescaleAPIClient,
+		credentialsClient:   credentialsClient,
 		logger:              b.Logger().WithValues("3scale Reconciler", applicationResource.Name),
 	}
 }
@@ -81,6 +83,19 @@ func (t *ApplicationThreescaleReconciler) reconcile3scaleApplication() (*control
 	if exists {
 		applicationObj = &applicationList.Applications[idx].Application
 	} else {
+		// The user key, the application ID and the redirect URL are set when the application is created
+		createParams, err := applicationCreateParams(t.Client(), t.applicationResource)
+		if err != nil {
+			return nil, fmt.Errorf("reconcile3scaleApplication application [%s]: %w", t.applicationResource.Spec.Name, err)
+		}
+		if len(createParams) > 0 {
+			application, err := t.credentialsClient.CreateApplication(*t.accountResource.Status.ID, planObj.Element.ID, t.applicationResource.Spec.Name, t.applicationResource.Spec.Description, createParams)
+			if err != nil {
+				return nil, fmt.Errorf("reconcile3scaleApplication application [%s]: %w", t.applicationResource.Spec.Name, err)
+			}
+			return controllerhelper.NewApplicationEntity(application, t.threescaleAPIClient, t.logger), nil
+		}
+
 		application, err := t.threescaleAPIClient.CreateApp(strconv.FormatInt(*t.accountResource.Status.ID, 10), strconv.FormatInt(planObj.Element.ID, 10), t.applicationResource.Spec.Name, t.applicationResource.Spec.Description)
 		if err != nil {
 			return nil, fmt.Errorf("reconcile3scaleApplication application [%s]: %w", t.applicationResource.Spec.Name, err)
@@ -21,7 +21,8 @@ func (t *ApplicationThreescaleReconciler) syncApplication(_ interface{}) error {
 	if err != nil {
 		return fmt.Errorf("error finding plan ID for plan : [%s]", t.applicationResource.Spec.ApplicationPlanName)
 	}
-	if t.applicationEntity.PlanID() != planID {
+	// The customized plan of the application is reconciled with the plan customization
+	if t.applicationEntity.PlanID() != planID && !t.applicationResource.Status.IsPlanCustomized(t.applicationEntity.PlanID()) {
 		_, err := t.threescaleAPIClient.ChangeApplicationPlan(*t.accountResource.Status.ID, *t.applicationResource.Status.ID, planID)
 		if err != nil {
 			return fmt.Errorf("error sync applicaiton [%s;%d]: %w", t.applicationResource.Spec.Name, t.applicationEntity.ID(), err)
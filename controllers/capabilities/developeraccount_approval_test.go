@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-porta-go-client/client"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// mockHttpClientDeveloperAccountApproval transitions the developer account 3, recording the requests
+func mockHttpClientDeveloperAccountApproval(requests *[]string) *http.Client {
+	return NewTestClient(func(req *http.Request) *http.Response {
+		states := map[string]string{
+			"/admin/api/accounts/3/approve.json":      "approved",
+			"/admin/api/accounts/3/reject.json":       "rejected",
+			"/admin/api/accounts/3/make_pending.json": "pending",
+		}
+		state, ok := states[req.URL.Path]
+		if req.Method != "PUT" || !ok {
+			return &http.Response{StatusCode: http.StatusNotFound, Header: make(http.Header), Body: ioutil.NopCloser(bytes.NewBufferString(""))}
+		}
+		*requests = append(*requests, req.Method+" "+req.URL.Path)
+		accountID := int64(3)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     make(http.Header),
+			Body:       ioutil.NopCloser(bytes.NewBuffer(responseBody(&client.DeveloperAccount{Element: client.DeveloperAccountItem{ID: &accountID, State: &state}}))),
+		}
+	})
+}
+
+func TestDeveloperAccountThreescaleReconciler_syncApproval(t *testing.T) {
+	pending := capabilitiesv1beta1.ApprovalPending
+	approved := capabilitiesv1beta1.ApprovalApproved
+	rejected := capabilitiesv1beta1.ApprovalRejected
+	adminURL, _ := url.Parse("https://3scale-admin.test.3scale.net")
+
+	cases := []struct {
+		name             string
+		state            string
+		approval         *capabilitiesv1beta1.ApprovalState
+		expectedState    string
+		expectedRequests []string
+	}{
+		{"NotDecided", "pending", nil, "pending", nil},
+		{"Approve", "pending", &approved, "approved", []string{"PUT /admin/api/accounts/3/approve.json"}},
+		{"Reject", "pending", &rejected, "rejected", []string{"PUT /admin/api/accounts/3/reject.json"}},
+		{"MakePending", "approved", &pending, "pending", []string{"PUT /admin/api/accounts/3/make_pending.json"}},
+		{"AlreadyApproved", "approved", &approved, "approved", nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			requests := []string{}
+			approvalClient := controllerhelper.NewApprovalClient(adminURL, "test", mockHttpClientDeveloperAccountApproval(&requests))
+			accountResource := getApplicationDeveloperAccount()
+			accountResource.Spec.Approval = tc.approval
+
+			s := NewDeveloperAccountThreescaleReconciler(getBaseReconciler(), accountResource, nil, approvalClient, nil, nil, "", logr.Discard())
+			accountID := int64(3)
+			state := tc.state
+			devAccount, err := s.syncApproval(&client.DeveloperAccount{Element: client.DeveloperAccountItem{ID: &accountID, State: &state}})
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if *devAccount.Element.State != tc.expectedState {
+				subT.Errorf("account state got = %s, want %s", *devAccount.Element.State, tc.expectedState)
+			}
+			if len(requests) != len(tc.expectedRequests) || (len(requests) > 0 && !reflect.DeepEqual(requests, tc.expectedRequests)) {
+				subT.Errorf("requests got = %v, want %v", requests, tc.expectedRequests)
+			}
+		})
+	}
+}
+
+func TestDeveloperAccountStatusReconciler_pendingApprovalCondition(t *testing.T) {
+	approved := capabilitiesv1beta1.ApprovalApproved
+	pendingState := "pending"
+	approvedState := "approved"
+
+	cases := []struct {
+		name           string
+		accountState   *string
+		approval       *capabilitiesv1beta1.ApprovalState
+		expectedStatus corev1.ConditionStatus
+	}{
+		{"NoState", nil, nil, corev1.ConditionFalse},
+		{"Approved", &approvedState, nil, corev1.ConditionFalse},
+		{"PendingNotDecided", &pendingState, nil, corev1.ConditionTrue},
+		{"PendingApproved", &pendingState, &approved, corev1.ConditionFalse},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			accountResource := getApplicationDeveloperAccount()
+			accountResource.Spec.Approval = tc.approval
+			s := NewDeveloperAccountStatusReconciler(getBaseReconciler(), accountResource, "", nil, nil)
+
+			condition := s.pendingApprovalCondition(tc.accountState)
+			if condition.Type != capabilitiesv1beta1.DeveloperAccountPendingApprovalConditionType {
+				subT.Errorf("condition type got = %s", condition.Type)
+			}
+			if condition.Status != tc.expectedStatus {
+				subT.Errorf("condition status got = %s, want %s", condition.Status, tc.expectedStatus)
+			}
+		})
+	}
+}
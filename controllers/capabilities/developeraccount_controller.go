@@ -196,7 +196,25 @@ func (r *DeveloperAccountReconciler) reconcileSpec(accountCR *capabilitiesv1beta
 		return statusReconciler, err
 	}
 
-	reconciler := NewDeveloperAccountThreescaleReconciler(r.BaseReconciler, accountCR, threescaleAPIClient, providerAccount.AdminURLStr, logger)
+	approvalClient, err := controllerhelper.PortaApprovalClient(providerAccount, insecureSkipVerify)
+	if err != nil {
+		statusReconciler := NewDeveloperAccountStatusReconciler(r.BaseReconciler, accountCR, providerAccount.AdminURLStr, nil, err)
+		return statusReconciler, err
+	}
+
+	plansClient, err := controllerhelper.PortaPlansClient(providerAccount, insecureSkipVerify)
+	if err != nil {
+		statusReconciler := NewDeveloperAccountStatusReconciler(r.BaseReconciler, accountCR, providerAccount.AdminURLStr, nil, err)
+		return statusReconciler, err
+	}
+
+	planRefs, err := r.resolvePlanRefs(accountCR, providerAccount.AdminURLStr)
+	if err != nil {
+		statusReconciler := NewDeveloperAccountStatusReconciler(r.BaseReconciler, accountCR, providerAccount.AdminURLStr, nil, err)
+		return statusReconciler, err
+	}
+
+	reconciler := NewDeveloperAccountThreescaleReconciler(r.BaseReconciler, accountCR, threescaleAPIClient, approvalClient, plansClient, planRefs, providerAccount.AdminURLStr, logger)
 	accountObj, err := reconciler.Reconcile()
 
 	statusReconciler := NewDeveloperAccountStatusReconciler(r.BaseReconciler, accountCR, providerAccount.AdminURLStr, accountObj, err)
@@ -217,6 +235,65 @@ func (r *DeveloperAccountReconciler) validateSpec(resource *capabilitiesv1beta1.
 	}
 }
 
+// resolvePlanRefs looks up the AccountPlan and Product CRs referenced by the developer account
+// and returns their 3scale IDs. Referenced CRs not synchronized yet result in a wait error.
+func (r *DeveloperAccountReconciler) resolvePlanRefs(accountCR *capabilitiesv1beta1.DeveloperAccount, providerAccountHost string) (*developerAccountPlanRefs, error) {
+	planRefs := &developerAccountPlanRefs{}
+	specFldPath := field.NewPath("spec")
+
+	if accountCR.Spec.AccountPlanRef != nil {
+		accountPlanFldPath := specFldPath.Child("accountPlanRef")
+		accountPlanCR := &capabilitiesv1beta1.AccountPlan{}
+		err := r.Client().Get(r.Context(), client.ObjectKey{Name: accountCR.Spec.AccountPlanRef.Name, Namespace: accountCR.Namespace}, accountPlanCR)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, &helper.WaitError{Err: fmt.Errorf("account plan CR [%s] not found", accountCR.Spec.AccountPlanRef.Name)}
+			}
+			return nil, err
+		}
+
+		if accountPlanCR.Status.ID == nil || !accountPlanCR.Status.IsReady() {
+			return nil, &helper.WaitError{Err: fmt.Errorf("account plan CR [%s] not ready", accountPlanCR.Name)}
+		}
+
+		if accountPlanCR.Status.ProviderAccountHost != providerAccountHost {
+			return nil, &helper.SpecFieldError{
+				ErrorType:      helper.InvalidError,
+				FieldErrorList: field.ErrorList{field.Invalid(accountPlanFldPath, accountCR.Spec.AccountPlanRef.Name, "account plan and account providerAccounts dont match")},
+			}
+		}
+
+		planRefs.accountPlanID = accountPlanCR.Status.ID
+	}
+
+	if accountCR.Spec.ServicePlanRef != nil {
+		productFldPath := specFldPath.Child("servicePlanRef", "productCR")
+		productCR := &capabilitiesv1beta1.Product{}
+		err := r.Client().Get(r.Context(), client.ObjectKey{Name: accountCR.Spec.ServicePlanRef.ProductCR.Name, Namespace: accountCR.Namespace}, productCR)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, &helper.WaitError{Err: fmt.Errorf("product CR [%s] not found", accountCR.Spec.ServicePlanRef.ProductCR.Name)}
+			}
+			return nil, err
+		}
+
+		if productCR.Status.ID == nil || !productCR.Status.Conditions.IsTrueFor(capabilitiesv1beta1.ProductSyncedConditionType) {
+			return nil, &helper.WaitError{Err: fmt.Errorf("product CR [%s] not synced", productCR.Name)}
+		}
+
+		if productCR.Status.ProviderAccountHost != providerAccountHost {
+			return nil, &helper.SpecFieldError{
+				ErrorType:      helper.InvalidError,
+				FieldErrorList: field.ErrorList{field.Invalid(productFldPath, accountCR.Spec.ServicePlanRef.ProductCR.Name, "product and account providerAccounts dont match")},
+			}
+		}
+
+		planRefs.servicePlanProductID = productCR.Status.ID
+	}
+
+	return planRefs, nil
+}
+
 func (r *DeveloperAccountReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&capabilitiesv1beta1.DeveloperAccount{}).
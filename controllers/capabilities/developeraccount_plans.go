@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"fmt"
+
+	"github.com/3scale/3scale-operator/pkg/helper"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+// developerAccountPlanRefs holds the 3scale IDs resolved from the plan references of the developer account spec
+type developerAccountPlanRefs struct {
+	// accountPlanID is the 3scale ID of the referenced account plan
+	accountPlanID *int64
+	// servicePlanProductID is the 3scale ID of the product owning the referenced service plan
+	servicePlanProductID *int64
+}
+
+// syncPlans changes the account plan and the service subscription of the developer account
+// when they are referenced in the spec
+func (s *DeveloperAccountThreescaleReconciler) syncPlans(devAccount *threescaleapi.DeveloperAccount) error {
+	if s.planRefs == nil {
+		return nil
+	}
+
+	accountID := *devAccount.Element.ID
+
+	if s.planRefs.accountPlanID != nil {
+		err := s.syncAccountPlan(accountID, *s.planRefs.accountPlanID)
+		if err != nil {
+			return fmt.Errorf("error sync developer account [%d] account plan: %w", accountID, err)
+		}
+	}
+
+	if s.planRefs.servicePlanProductID != nil {
+		err := s.syncServiceSubscription(accountID, *s.planRefs.servicePlanProductID)
+		if helper.IsWaitError(err) {
+			return err
+		}
+		if err != nil {
+			return fmt.Errorf("error sync developer account [%d] service subscription: %w", accountID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *DeveloperAccountThreescaleReconciler) syncAccountPlan(accountID, accountPlanID int64) error {
+	existingPlan, err := s.plansClient.DeveloperAccountPlan(accountID)
+	if err != nil {
+		return err
+	}
+
+	if existingPlan.ID == accountPlanID {
+		return nil
+	}
+
+	s.logger.V(1).Info("syncAccountPlan", "from", existingPlan.ID, "to", accountPlanID)
+	return s.plansClient.ChangeDeveloperAccountPlan(accountID, accountPlanID)
+}
+
+func (s *DeveloperAccountThreescaleReconciler) syncServiceSubscription(accountID, productID int64) error {
+	servicePlans, err := s.plansClient.ServicePlans(productID)
+	if err != nil {
+		return err
+	}
+
+	var desiredPlanID *int64
+	productPlanIDs := map[int64]bool{}
+	for idx := range servicePlans {
+		productPlanIDs[servicePlans[idx].ID] = true
+		if servicePlans[idx].SystemName == s.resource.Spec.ServicePlanRef.SystemName {
+			desiredPlanID = &servicePlans[idx].ID
+		}
+	}
+
+	if desiredPlanID == nil {
+		// The service plan may not have been created yet by the product controller
+		return &helper.WaitError{
+			Err: fmt.Errorf("service plan [%s] not found in product [%d]", s.resource.Spec.ServicePlanRef.SystemName, productID),
+		}
+	}
+
+	subscriptions, err := s.plansClient.ServiceSubscriptions(accountID)
+	if err != nil {
+		return err
+	}
+
+	// A developer account can be subscribed to one service plan per product
+	for _, subscription := range subscriptions {
+		if !productPlanIDs[subscription.PlanID] {
+			continue
+		}
+
+		if subscription.PlanID == *desiredPlanID {
+			return nil
+		}
+
+		s.logger.V(1).Info("syncServiceSubscription", "from", subscription.PlanID, "to", *desiredPlanID)
+		return s.plansClient.ChangeServiceSubscriptionPlan(accountID, subscription.ID, *desiredPlanID)
+	}
+
+	s.logger.V(1).Info("syncServiceSubscription", "subscribe", *desiredPlanID)
+	return s.plansClient.CreateServiceSubscription(accountID, *desiredPlanID)
+}
@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-porta-go-client/client"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// mockHttpClientDeveloperAccountPlans serves the plans of the developer account 3 and the product 3,
+// recording the write requests
+func mockHttpClientDeveloperAccountPlans(subscribedPlanID int64, requests *[]string) *http.Client {
+	return NewTestClient(func(req *http.Request) *http.Response {
+		var (
+			statusCode = http.StatusOK
+			body       = []byte("")
+		)
+
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/admin/api/accounts/3/plan.json":
+			body = []byte(`{"account_plan":{"id":10,"system_name":"default"}}`)
+		case req.Method == "GET" && req.URL.Path == "/admin/api/services/3/service_plans.json":
+			body = []byte(`{"plans":[{"service_plan":{"id":20,"system_name":"basic"}},{"service_plan":{"id":21,"system_name":"premium"}}]}`)
+		case req.Method == "GET" && req.URL.Path == "/admin/api/accounts/3/service_contracts.json":
+			body = []byte(`{"service_contracts":[]}`)
+			if subscribedPlanID != 0 {
+				body = []byte(fmt.Sprintf(`{"service_contracts":[{"service_contract":{"id":30,"plan_id":%d}}]}`, subscribedPlanID))
+			}
+		case req.Method == "POST" && req.URL.Path == "/admin/api/accounts/3/service_subscriptions.json":
+			statusCode = http.StatusCreated
+			*requests = append(*requests, req.Method+" "+req.URL.Path)
+		case req.Method == "PUT" && (req.URL.Path == "/admin/api/accounts/3/change_plan.json" ||
+			req.URL.Path == "/admin/api/accounts/3/service_subscriptions/30/change_plan.json"):
+			*requests = append(*requests, req.Method+" "+req.URL.Path)
+		default:
+			statusCode = http.StatusNotFound
+		}
+
+		return &http.Response{StatusCode: statusCode, Header: make(http.Header), Body: ioutil.NopCloser(bytes.NewBuffer(body))}
+	})
+}
+
+func TestDeveloperAccountThreescaleReconciler_syncPlans(t *testing.T) {
+	adminURL, _ := url.Parse("https://3scale-admin.test.3scale.net")
+	defaultPlanID := int64(10)
+	goldPlanID := int64(11)
+	productID := int64(3)
+
+	cases := []struct {
+		name             string
+		accountPlanID    *int64
+		servicePlan      string
+		subscribedPlanID int64
+		expectedRequests []string
+		expectedWait     bool
+	}{
+		{"NoPlanRefs", nil, "", 0, nil, false},
+		{"AccountPlanUnchanged", &defaultPlanID, "", 0, nil, false},
+		{"AccountPlanChanged", &goldPlanID, "", 0, []string{"PUT /admin/api/accounts/3/change_plan.json"}, false},
+		{"Subscribe", nil, "premium", 0, []string{"POST /admin/api/accounts/3/service_subscriptions.json"}, false},
+		{"SubscriptionUnchanged", nil, "premium", 21, nil, false},
+		{"SubscriptionChanged", nil, "premium", 20, []string{"PUT /admin/api/accounts/3/service_subscriptions/30/change_plan.json"}, false},
+		{"UnknownServicePlan", nil, "unknown", 0, nil, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			requests := []string{}
+			plansClient := controllerhelper.NewPlansClient(adminURL, "test", mockHttpClientDeveloperAccountPlans(tc.subscribedPlanID, &requests))
+			accountResource := getApplicationDeveloperAccount()
+			planRefs := &developerAccountPlanRefs{accountPlanID: tc.accountPlanID}
+			if tc.servicePlan != "" {
+				accountResource.Spec.ServicePlanRef = &capabilitiesv1beta1.ServicePlanRefSpec{
+					ProductCR:  corev1.LocalObjectReference{Name: "product-cr"},
+					SystemName: tc.servicePlan,
+				}
+				planRefs.servicePlanProductID = &productID
+			}
+
+			s := NewDeveloperAccountThreescaleReconciler(getBaseReconciler(), accountResource, nil, nil, plansClient, planRefs, "", logr.Discard())
+			accountID := int64(3)
+			err := s.syncPlans(&client.DeveloperAccount{Element: client.DeveloperAccountItem{ID: &accountID}})
+			if tc.expectedWait {
+				if !helper.IsWaitError(err) {
+					subT.Fatalf("expected wait error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if len(requests) != len(tc.expectedRequests) || (len(requests) > 0 && !reflect.DeepEqual(requests, tc.expectedRequests)) {
+				subT.Errorf("requests got = %v, want %v", requests, tc.expectedRequests)
+			}
+		})
+	}
+}
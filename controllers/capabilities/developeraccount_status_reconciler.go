@@ -100,6 +100,7 @@ func (s *DeveloperAccountStatusReconciler) calculateStatus() (*capabilitiesv1bet
 	newStatus.Conditions.SetCondition(s.readyCondition())
 	newStatus.Conditions.SetCondition(s.waitingCondition())
 	newStatus.Conditions.SetCondition(s.failedCondition())
+	newStatus.Conditions.SetCondition(s.pendingApprovalCondition(newStatus.AccountState))
 
 	return newStatus, nil
 }
@@ -167,3 +168,19 @@ func (s *DeveloperAccountStatusReconciler) waitingCondition() common.Condition {
 
 	return condition
 }
+
+// pendingApprovalCondition is true when the account is pending of approval in 3scale,
+// and the approval is not decided in the spec
+func (s *DeveloperAccountStatusReconciler) pendingApprovalCondition(accountState *string) common.Condition {
+	condition := common.Condition{
+		Type:   capabilitiesv1beta1.DeveloperAccountPendingApprovalConditionType,
+		Status: corev1.ConditionFalse,
+	}
+
+	if accountState != nil && *accountState == "pending" && !s.resource.Spec.Approval.IsDecided() {
+		condition.Status = corev1.ConditionTrue
+		condition.Message = "account pending of approval, set spec.approval to approved or rejected"
+	}
+
+	return condition
+}
@@ -22,15 +22,21 @@ type DeveloperAccountThreescaleReconciler struct {
 	*reconcilers.BaseReconciler
 	resource            *capabilitiesv1beta1.DeveloperAccount
 	threescaleAPIClient *threescaleapi.ThreeScaleClient
+	approvalClient      *controllerhelper.ApprovalClient
+	plansClient         *controllerhelper.PlansClient
+	planRefs            *developerAccountPlanRefs
 	providerAccountHost string
 	logger              logr.Logger
 }
 
-func NewDeveloperAccountThreescaleReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.DeveloperAccount, threescaleAPIClient *threescaleapi.ThreeScaleClient, providerAccountHost string, logger logr.Logger) *DeveloperAccountThreescaleReconciler {
+func NewDeveloperAccountThreescaleReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.DeveloperAccount, threescaleAPIClient *threescaleapi.ThreeScaleClient, approvalClient *controllerhelper.ApprovalClient, plansClient *controllerhelper.PlansClient, planRefs *developerAccountPlanRefs, providerAccountHost string, logger logr.Logger) *DeveloperAccountThreescaleReconciler {
 	return &DeveloperAccountThreescaleReconciler{
 		BaseReconciler:      b,
 		resource:            resource,
 		threescaleAPIClient: threescaleAPIClient,
+		approvalClient:      approvalClient,
+		plansClient:         plansClient,
+		planRefs:            planRefs,
 		providerAccountHost: providerAccountHost,
 		logger:              logger.WithValues("3scale Reconciler", providerAccountHost),
 	}
@@ -53,13 +59,29 @@ func (s *DeveloperAccountThreescaleReconciler) Reconcile() (*threescaleapi.Devel
 		s.logger.V(1).Info("DeveloperAccount does not exist", "OrgName", s.resource.Spec.OrgName)
 		// ID not in status field
 		// developer account has to be created in 3scale
-		return s.createDevAccount()
+		devAccount, err = s.createDevAccount()
+		if err != nil {
+			return nil, err
+		}
+		err = s.syncPlans(devAccount)
+		if err != nil {
+			return devAccount, err
+		}
+		return s.syncApproval(devAccount)
 	}
 
 	s.logger.V(1).Info("DeveloperAccount already exists", "ID", *devAccount.Element.ID)
 
 	// reconcile developer account
-	return s.syncDeveloperAccount(devAccount)
+	devAccount, err = s.syncDeveloperAccount(devAccount)
+	if err != nil {
+		return nil, err
+	}
+	err = s.syncPlans(devAccount)
+	if err != nil {
+		return devAccount, err
+	}
+	return s.syncApproval(devAccount)
 }
 
 func (s *DeveloperAccountThreescaleReconciler) findDevAccountByID() (*threescaleapi.DeveloperAccount, error) {
@@ -110,6 +132,10 @@ func (s *DeveloperAccountThreescaleReconciler) createDevAccount() (*threescaleap
 		params["monthly_charging_enabled"] = strconv.FormatBool(*s.resource.Spec.MonthlyChargingEnabled)
 	}
 
+	if s.planRefs != nil && s.planRefs.accountPlanID != nil {
+		params["account_plan_id"] = strconv.FormatInt(*s.planRefs.accountPlanID, 10)
+	}
+
 	return s.threescaleAPIClient.Signup(params)
 }
 
@@ -207,6 +233,41 @@ func (s *DeveloperAccountThreescaleReconciler) syncDeveloperAccount(devAccount *
 	return updatedDevAccount, nil
 }
 
+// syncApproval approves, rejects or sets back to pending the developer account, as set in the approval of the spec.
+// On error, the developer account is returned along with the error, so its ID is kept in the status
+func (s *DeveloperAccountThreescaleReconciler) syncApproval(devAccount *threescaleapi.DeveloperAccount) (*threescaleapi.DeveloperAccount, error) {
+	if s.resource.Spec.Approval == nil {
+		return devAccount, nil
+	}
+
+	desiredState := map[capabilitiesv1beta1.ApprovalState]string{
+		capabilitiesv1beta1.ApprovalPending:  "pending",
+		capabilitiesv1beta1.ApprovalApproved: "approved",
+		capabilitiesv1beta1.ApprovalRejected: "rejected",
+	}[*s.resource.Spec.Approval]
+	if devAccount.Element.State != nil && *devAccount.Element.State == desiredState {
+		return devAccount, nil
+	}
+
+	var (
+		updatedDevAccount *threescaleapi.DeveloperAccount
+		err               error
+	)
+	switch *s.resource.Spec.Approval {
+	case capabilitiesv1beta1.ApprovalApproved:
+		updatedDevAccount, err = s.approvalClient.ApproveDeveloperAccount(*devAccount.Element.ID)
+	case capabilitiesv1beta1.ApprovalRejected:
+		updatedDevAccount, err = s.approvalClient.RejectDeveloperAccount(*devAccount.Element.ID)
+	default:
+		updatedDevAccount, err = s.approvalClient.MakeDeveloperAccountPending(*devAccount.Element.ID)
+	}
+	if err != nil {
+		return devAccount, fmt.Errorf("error sync developer account [%d] approval: %w", *devAccount.Element.ID, err)
+	}
+
+	return updatedDevAccount, nil
+}
+
 func (s *DeveloperAccountThreescaleReconciler) getAdminUserPassword(adminUserCR *capabilitiesv1beta1.DeveloperUser) (string, error) {
 	// Get password from secret reference
 	secret := &corev1.Secret{}
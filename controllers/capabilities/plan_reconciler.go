@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+	"github.com/go-logr/logr"
+)
+
+// planReconciler reconciles service plans and account plans.
+// Unlike application plans, they have no limits nor pricing rules
+type planReconciler struct {
+	*reconcilers.BaseReconciler
+	systemName string
+	resource   capabilitiesv1beta1.PlanSpec
+	planEntity *controllerhelper.PlanEntity
+	logger     logr.Logger
+}
+
+func newPlanReconciler(b *reconcilers.BaseReconciler,
+	systemName string,
+	resource capabilitiesv1beta1.PlanSpec,
+	planEntity *controllerhelper.PlanEntity,
+	logger logr.Logger,
+) *planReconciler {
+
+	return &planReconciler{
+		BaseReconciler: b,
+		systemName:     systemName,
+		resource:       resource,
+		planEntity:     planEntity,
+		logger:         logger.WithValues("Plan", systemName),
+	}
+}
+
+// Reconcile ensures plan attrs are reconciled
+func (a *planReconciler) Reconcile() error {
+	taskRunner := helper.NewTaskRunner(nil, a.logger)
+	taskRunner.AddTask("SyncPlan", a.syncPlan)
+
+	err := taskRunner.Run()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *planReconciler) syncPlan(_ interface{}) error {
+	params := threescaleapi.Params{}
+
+	if a.resource.Name != nil {
+		if a.planEntity.Name() != *a.resource.Name {
+			params["name"] = *a.resource.Name
+		}
+	}
+
+	if a.resource.ApprovalRequired != nil {
+		if a.planEntity.ApprovalRequired() != *a.resource.ApprovalRequired {
+			params["approval_required"] = strconv.FormatBool(*a.resource.ApprovalRequired)
+		}
+	}
+
+	if a.resource.TrialPeriod != nil {
+		if a.planEntity.TrialPeriodDays() != *a.resource.TrialPeriod {
+			params["trial_period_days"] = strconv.Itoa(*a.resource.TrialPeriod)
+		}
+	}
+
+	if a.resource.SetupFee != nil {
+		// Field CRD openapiV3 validation should ensure no error parsing
+		desiredValue, _ := strconv.ParseFloat(*a.resource.SetupFee, 10)
+		if a.planEntity.SetupFee() != desiredValue {
+			params["setup_fee"] = *a.resource.SetupFee
+		}
+	}
+
+	if a.resource.CostMonth != nil {
+		// Field CRD openapiV3 validation should ensure no error parsing
+		desiredValue, _ := strconv.ParseFloat(*a.resource.CostMonth, 10)
+		if a.planEntity.CostPerMonth() != desiredValue {
+			params["cost_per_month"] = *a.resource.CostMonth
+		}
+	}
+
+	planEntityStateIsPublished := a.planEntity.State() == "published" // If the state is not published then we assume it is "hidden"
+	desiredPlanIsPublished := a.resource.IsPublished()
+	if planEntityStateIsPublished != desiredPlanIsPublished {
+		var stateEventValue string
+		if desiredPlanIsPublished {
+			stateEventValue = "publish"
+		} else {
+			stateEventValue = "hide"
+		}
+		params["state_event"] = stateEventValue
+	}
+
+	if len(params) > 0 {
+		err := a.planEntity.Update(params)
+		if err != nil {
+			return fmt.Errorf("Error sync plan [%s;%d]: %w", a.systemName, a.planEntity.ID(), err)
+		}
+	}
+
+	return nil
+}
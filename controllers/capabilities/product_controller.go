@@ -214,13 +214,19 @@ func (r *ProductReconciler) reconcile(productResource *capabilitiesv1beta1.Produ
 		return statusReconciler, err
 	}
 
+	plansClient, err := controllerhelper.PortaPlansClient(providerAccount, insecureSkipVerify)
+	if err != nil {
+		statusReconciler := NewProductStatusReconciler(r.BaseReconciler, productResource, nil, providerAccount.AdminURLStr, err)
+		return statusReconciler, err
+	}
+
 	backendRemoteIndex, err := controllerhelper.NewBackendAPIRemoteIndex(threescaleAPIClient, logger)
 	if err != nil {
 		statusReconciler := NewProductStatusReconciler(r.BaseReconciler, productResource, nil, providerAccount.AdminURLStr, err)
 		return statusReconciler, err
 	}
 
-	reconciler := NewProductThreescaleReconciler(r.BaseReconciler, productResource, threescaleAPIClient, backendRemoteIndex)
+	reconciler := NewProductThreescaleReconciler(r.BaseReconciler, productResource, threescaleAPIClient, plansClient, backendRemoteIndex)
 	productEntity, err := reconciler.Reconcile()
 	statusReconciler := NewProductStatusReconciler(r.BaseReconciler, productResource, productEntity, providerAccount.AdminURLStr, err)
 	return statusReconciler, err
@@ -18,14 +18,16 @@ type ProductThreescaleReconciler struct {
 	productEntity       *controllerhelper.ProductEntity
 	backendRemoteIndex  *controllerhelper.BackendAPIRemoteIndex
 	threescaleAPIClient *threescaleapi.ThreeScaleClient
+	plansClient         *controllerhelper.PlansClient
 	logger              logr.Logger
 }
 
-func NewProductThreescaleReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.Product, threescaleAPIClient *threescaleapi.ThreeScaleClient, backendRemoteIndex *controllerhelper.BackendAPIRemoteIndex) *ProductThreescaleReconciler {
+func NewProductThreescaleReconciler(b *reconcilers.BaseReconciler, resource *capabilitiesv1beta1.Product, threescaleAPIClient *threescaleapi.ThreeScaleClient, plansClient *controllerhelper.PlansClient, backendRemoteIndex *controllerhelper.BackendAPIRemoteIndex) *ProductThreescaleReconciler {
 	return &ProductThreescaleReconciler{
 		BaseReconciler:      b,
 		resource:            resource,
 		threescaleAPIClient: threescaleAPIClient,
+		plansClient:         plansClient,
 		backendRemoteIndex:  backendRemoteIndex,
 		logger:              b.Logger().WithValues("3scale Reconciler", resource.Name),
 	}
@@ -50,6 +52,7 @@ func (t *ProductThreescaleReconciler) Reconcile() (*controllerhelper.ProductEnti
 	taskRunner.AddTask("SyncMetrics", t.syncMetrics)
 	taskRunner.AddTask("SyncMappingRules", t.syncMappingRules)
 	taskRunner.AddTask("SyncApplicationPlans", t.syncApplicationPlans)
+	taskRunner.AddTask("SyncServicePlans", t.syncServicePlans)
 	taskRunner.AddTask("SyncPolicies", t.syncPolicies)
 	taskRunner.AddTask("SyncOIDCConfiguration", t.syncOIDCConfiguration)
 
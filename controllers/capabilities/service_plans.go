@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"fmt"
+	"net/url"
+
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+// defaultServicePlanSystemName is the system name of the service plan 3scale creates with the product
+const defaultServicePlanSystemName = "default"
+
+// syncServicePlans syncs the service plans of the product. Service plans are not managed when not set in the spec.
+// Service plans not listed in the spec are deleted, except the default service plan
+// and the service plans developer accounts are subscribed to
+func (t *ProductThreescaleReconciler) syncServicePlans(_ interface{}) error {
+	if t.resource.Spec.ServicePlans == nil {
+		return nil
+	}
+
+	desiredKeys := make([]string, 0, len(t.resource.Spec.ServicePlans))
+	for systemName := range t.resource.Spec.ServicePlans {
+		desiredKeys = append(desiredKeys, systemName)
+	}
+
+	existingList, err := t.plansClient.ServicePlans(t.productEntity.ID())
+	if err != nil {
+		return fmt.Errorf("Error sync product [%s] service plans: %w", t.resource.Spec.SystemName, err)
+	}
+
+	existingKeys := make([]string, 0, len(existingList))
+	existingMap := map[string]controllerhelper.PlanItem{}
+	for _, existing := range existingList {
+		systemName := existing.SystemName
+		existingKeys = append(existingKeys, systemName)
+		existingMap[systemName] = existing
+	}
+
+	//
+	// Deleted existing and not desired
+	//
+
+	// The default service plan is kept
+	notDesiredExistingKeys := helper.ArrayStringDifference(existingKeys, append([]string{defaultServicePlanSystemName}, desiredKeys...))
+	t.logger.V(1).Info("syncServicePlans", "notDesiredExistingKeys", notDesiredExistingKeys)
+	if len(notDesiredExistingKeys) > 0 {
+		subscribedPlanIDs, err := t.subscribedServicePlanIDs()
+		if err != nil {
+			return fmt.Errorf("Error sync product [%s] service plans: %w", t.resource.Spec.SystemName, err)
+		}
+
+		for _, systemName := range notDesiredExistingKeys {
+			// key is expected to exist
+			// notDesiredExistingKeys is a subset of the existingMap key set
+			planID := existingMap[systemName].ID
+			if subscribedPlanIDs[planID] {
+				t.logger.Info("service plan not deleted, developer accounts are subscribed to it", "servicePlan", systemName)
+				continue
+			}
+
+			err := t.plansClient.DeleteServicePlan(t.productEntity.ID(), planID)
+			if err != nil {
+				return fmt.Errorf("Error sync product [%s] service plans: %w", t.resource.Spec.SystemName, err)
+			}
+		}
+	}
+
+	//
+	// Reconcile existing
+	//
+	matchedKeys := helper.ArrayStringIntersection(existingKeys, desiredKeys)
+	t.logger.V(1).Info("syncServicePlans", "matchedKeys", matchedKeys)
+	for _, systemName := range matchedKeys {
+		// interface to remote entity
+		planEntity := controllerhelper.NewServicePlanEntity(t.productEntity.ID(), existingMap[systemName], t.plansClient, t.logger)
+		// desired spec
+		planSpec := t.resource.Spec.ServicePlans[systemName]
+		reconciler := newPlanReconciler(t.BaseReconciler, systemName, planSpec, planEntity, t.logger)
+		err := reconciler.Reconcile()
+		if err != nil {
+			return fmt.Errorf("Error sync product [%s] service plan [%s]: %w", t.resource.Spec.SystemName, systemName, err)
+		}
+	}
+
+	//
+	// Create not existing and desired
+	//
+
+	desiredNewKeys := helper.ArrayStringDifference(desiredKeys, existingKeys)
+	t.logger.V(1).Info("syncServicePlans", "desiredNewKeys", desiredNewKeys)
+	for _, systemName := range desiredNewKeys {
+		// key is expected to exist
+		// desiredNewKeys is a subset of the Spec.ServicePlans map key set
+		planSpec := t.resource.Spec.ServicePlans[systemName]
+
+		// Create Service Plan using system_name.
+		// it cannot be modified later
+		params := url.Values{"system_name": []string{systemName}, "name": []string{systemName}}
+		obj, err := t.plansClient.CreateServicePlan(t.productEntity.ID(), params)
+		if err != nil {
+			return fmt.Errorf("Error sync product [%s] service plan [%s]: %w", t.resource.Spec.SystemName, systemName, err)
+		}
+		// interface to remote entity
+		planEntity := controllerhelper.NewServicePlanEntity(t.productEntity.ID(), *obj, t.plansClient, t.logger)
+
+		reconciler := newPlanReconciler(t.BaseReconciler, systemName, planSpec, planEntity, t.logger)
+		err = reconciler.Reconcile()
+		if err != nil {
+			return fmt.Errorf("Error sync product [%s] service plan [%s]: %w", t.resource.Spec.SystemName, systemName, err)
+		}
+	}
+
+	return nil
+}
+
+// subscribedServicePlanIDs returns the IDs of the service plans the developer accounts of the provider account are subscribed to
+func (t *ProductThreescaleReconciler) subscribedServicePlanIDs() (map[int64]bool, error) {
+	accountList, err := t.threescaleAPIClient.ListDeveloperAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	planIDs := map[int64]bool{}
+	for idx := range accountList.Items {
+		subscriptions, err := t.plansClient.ServiceSubscriptions(*accountList.Items[idx].Element.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, subscription := range subscriptions {
+			planIDs[subscription.PlanID] = true
+		}
+	}
+
+	return planIDs, nil
+}
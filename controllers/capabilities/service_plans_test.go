@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
+	controllerhelper "github.com/3scale/3scale-operator/pkg/controller/helper"
+	"github.com/3scale/3scale-porta-go-client/client"
+	"github.com/go-logr/logr"
+)
+
+// mockHttpClientServicePlans serves the service plans of the product 3 and the developer account 3,
+// subscribed to the service plan 24. The write requests are recorded
+func mockHttpClientServicePlans(requests *[]string) *http.Client {
+	return NewTestClient(func(req *http.Request) *http.Response {
+		var (
+			statusCode = http.StatusOK
+			body       = []byte("")
+		)
+
+		switch req.Method + " " + req.URL.Path {
+		case "GET /admin/api/services/3/service_plans.json":
+			body = []byte(`{"plans":[` +
+				`{"service_plan":{"id":20,"system_name":"default","name":"Default","state":"hidden"}},` +
+				`{"service_plan":{"id":21,"system_name":"basic","name":"basic","state":"hidden"}},` +
+				`{"service_plan":{"id":23,"system_name":"old","name":"old","state":"hidden"}},` +
+				`{"service_plan":{"id":24,"system_name":"subscribed","name":"subscribed","state":"hidden"}}]}`)
+		case "GET /admin/api/accounts.json":
+			accountID := int64(3)
+			body = responseBody(&client.DeveloperAccountList{Items: []client.DeveloperAccount{{Element: client.DeveloperAccountItem{ID: &accountID}}}})
+		case "GET /admin/api/accounts/3/service_contracts.json":
+			body = []byte(`{"service_contracts":[{"service_contract":{"id":30,"plan_id":24}}]}`)
+		case "POST /admin/api/services/3/service_plans.json":
+			statusCode = http.StatusCreated
+			body = []byte(`{"service_plan":{"id":25,"system_name":"gold","name":"gold","state":"hidden"}}`)
+		case "PUT /admin/api/services/3/service_plans/21.json":
+			body = []byte(`{"service_plan":{"id":21,"system_name":"basic","name":"Basic Plan","state":"hidden"}}`)
+		case "DELETE /admin/api/services/3/service_plans/21.json", "DELETE /admin/api/services/3/service_plans/23.json":
+		default:
+			statusCode = http.StatusNotFound
+		}
+
+		if req.Method != "GET" {
+			*requests = append(*requests, req.Method+" "+req.URL.Path)
+		}
+		return &http.Response{StatusCode: statusCode, Header: make(http.Header), Body: ioutil.NopCloser(bytes.NewBuffer(body))}
+	})
+}
+
+func TestProductThreescaleReconciler_syncServicePlans(t *testing.T) {
+	ap, _ := client.NewAdminPortal("https", "3scale-admin.test.3scale.net", 443)
+	adminURL, _ := url.Parse("https://3scale-admin.test.3scale.net")
+	planName := "Basic Plan"
+
+	cases := []struct {
+		name             string
+		servicePlans     map[string]capabilitiesv1beta1.PlanSpec
+		expectedRequests []string
+	}{
+		{"NotManaged", nil, nil},
+		{"Unchanged", map[string]capabilitiesv1beta1.PlanSpec{"basic": {}, "old": {}, "subscribed": {}}, nil},
+		{"Create", map[string]capabilitiesv1beta1.PlanSpec{"basic": {}, "old": {}, "subscribed": {}, "gold": {}},
+			[]string{"POST /admin/api/services/3/service_plans.json"}},
+		{"Update", map[string]capabilitiesv1beta1.PlanSpec{"basic": {Name: &planName}, "old": {}, "subscribed": {}},
+			[]string{"PUT /admin/api/services/3/service_plans/21.json"}},
+		{"Delete", map[string]capabilitiesv1beta1.PlanSpec{"basic": {}},
+			[]string{"DELETE /admin/api/services/3/service_plans/23.json"}},
+		{"DeleteAll", map[string]capabilitiesv1beta1.PlanSpec{},
+			[]string{
+				"DELETE /admin/api/services/3/service_plans/21.json",
+				"DELETE /admin/api/services/3/service_plans/23.json",
+			}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			requests := []string{}
+			httpClient := mockHttpClientServicePlans(&requests)
+			threescaleAPIClient := client.NewThreeScale(ap, "test", httpClient)
+
+			productResource := getApplicationProductCR()
+			productResource.Spec.ServicePlans = tc.servicePlans
+			productEntity := controllerhelper.NewProductEntity(&client.Product{Element: client.ProductItem{ID: 3, SystemName: "test"}}, threescaleAPIClient, logr.Discard())
+
+			s := NewProductThreescaleReconciler(getBaseReconciler(), productResource, threescaleAPIClient, controllerhelper.NewPlansClient(adminURL, "test", httpClient), nil)
+			s.productEntity = productEntity
+			err := s.syncServicePlans(nil)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if len(requests) != len(tc.expectedRequests) || (len(requests) > 0 && !reflect.DeepEqual(requests, tc.expectedRequests)) {
+				subT.Errorf("requests got = %v, want %v", requests, tc.expectedRequests)
+			}
+		})
+	}
+}
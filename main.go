@@ -48,6 +48,7 @@ import (
 	appscontroller "github.com/3scale/3scale-operator/controllers/apps"
 	capabilitiescontroller "github.com/3scale/3scale-operator/controllers/capabilities"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
+	certmanagerv1 "github.com/3scale/3scale-operator/pkg/certmanager/v1"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/version"
 	// +kubebuilder:scaffold:imports
@@ -77,6 +78,7 @@ func init() {
 	utilruntime.Must(monitoringv1.AddToScheme(scheme))
 	utilruntime.Must(grafanav1alpha1.AddToScheme(scheme))
 	utilruntime.Must(configv1.AddToScheme(scheme))
+	utilruntime.Must(certmanagerv1.AddToScheme(scheme))
 
 	// +kubebuilder:scaffold:scheme
 }
@@ -165,6 +167,22 @@ func main() {
 		os.Exit(1)
 	}
 
+	discoveryClientAPIManagerBackupSchedule, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+	if err = (&appscontroller.APIManagerBackupScheduleReconciler{
+		BaseReconciler: reconcilers.NewBaseReconciler(
+			context.Background(), mgr.GetClient(), mgr.GetScheme(), mgr.GetAPIReader(),
+			ctrl.Log.WithName("controllers").WithName("APIManagerBackupSchedule"),
+			discoveryClientAPIManagerBackupSchedule,
+			mgr.GetEventRecorderFor("APIManagerBackupSchedule")),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "APIManagerBackupSchedule")
+		os.Exit(1)
+	}
+
 	discoveryClientAPIManagerRestore, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
 	if err != nil {
 		setupLog.Error(err, "unable to create discovery client")
@@ -311,6 +329,23 @@ func main() {
 		os.Exit(1)
 	}
 
+	discoveryClientAccountPlan, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+
+	if err = (&capabilitiescontroller.AccountPlanReconciler{
+		BaseReconciler: reconcilers.NewBaseReconciler(
+			context.Background(), mgr.GetClient(), mgr.GetScheme(), mgr.GetAPIReader(),
+			ctrl.Log.WithName("controllers").WithName("AccountPlan"),
+			discoveryClientAccountPlan,
+			mgr.GetEventRecorderFor("AccountPlan")),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AccountPlan")
+		os.Exit(1)
+	}
+
 	discoveryClientDeveloperUser, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
 	if err != nil {
 		setupLog.Error(err, "unable to create discovery client")
@@ -363,6 +398,13 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "Application")
 		os.Exit(1)
 	}
+
+	if webhooksEnabled() {
+		if err = (&appsv1alpha1.APIManager{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "APIManager")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	setupLog.Info("starting manager")
@@ -386,6 +428,13 @@ func getWatchNamespace() (string, error) {
 	return ns, nil
 }
 
+// webhooksEnabled returns true when the admission webhooks are enabled.
+// The webhook server requires the serving certificates to be mounted,
+// so webhooks are only enabled setting the ENABLE_WEBHOOKS env variable to true
+func webhooksEnabled() bool {
+	return os.Getenv("ENABLE_WEBHOOKS") == "true"
+}
+
 func printVersion() {
 	setupLog.Info(fmt.Sprintf("Operator Version: %s", version.Version))
 	setupLog.Info(fmt.Sprintf("Go Version: %s", runtime.Version()))
@@ -394,6 +443,7 @@ func printVersion() {
 
 func registerThreescaleMetricsIntoControllerRuntimeMetricsRegistry() {
 	register3scaleVersionInfoMetric()
+	appscontroller.RegisterBackupScheduleMetrics(controllerruntimemetrics.Registry)
 }
 
 func register3scaleVersionInfoMetric() {
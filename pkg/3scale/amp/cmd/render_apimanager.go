@@ -0,0 +1,168 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/render"
+)
+
+var renderAPIManagerFilenames []string
+
+var renderAPIManagerNamespace string
+
+// Render the objects for a vanilla Kubernetes cluster
+var renderAPIManagerKubernetes bool
+
+// renderAPIManagerCmd represents the render apimanager command
+var renderAPIManagerCmd = &cobra.Command{
+	Use:   "apimanager -f <apimanager.yaml>",
+	Short: "render the objects the operator creates for an APIManager",
+	Long: `render the objects the operator creates for an APIManager
+
+The APIManager is read from the files provided with the -f flag.
+The same files can include the objects existing in the cluster, like the secrets referenced
+by the APIManager or the secrets holding the 3scale configuration.
+Referenced secrets not provided are replaced by stand-in secrets.
+Secret values are not printed, as they are generated randomly when not provided.`,
+	Args: cobra.NoArgs,
+	RunE: runRenderAPIManagerCommand,
+}
+
+func runRenderAPIManagerCommand(cmd *cobra.Command, args []string) error {
+	objects := []client.Object{}
+	for _, filename := range renderAPIManagerFilenames {
+		fileObjects, err := readObjects(filename)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", filename, err)
+		}
+		objects = append(objects, fileObjects...)
+	}
+
+	var apimanager *appsv1alpha1.APIManager
+	clusterObjects := []client.Object{}
+	for _, obj := range objects {
+		if a, ok := obj.(*appsv1alpha1.APIManager); ok {
+			if apimanager != nil {
+				return fmt.Errorf("more than one APIManager found")
+			}
+			apimanager = a
+			continue
+		}
+		clusterObjects = append(clusterObjects, obj)
+	}
+
+	if apimanager == nil {
+		return fmt.Errorf("APIManager not found")
+	}
+
+	if renderAPIManagerNamespace != "" {
+		apimanager.Namespace = renderAPIManagerNamespace
+	}
+	if apimanager.Namespace == "" {
+		return fmt.Errorf("APIManager namespace not set. Set the APIManager namespace or use the --namespace flag")
+	}
+
+	for _, obj := range clusterObjects {
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(apimanager.Namespace)
+		}
+	}
+
+	renderedObjects, err := render.APIManager(apimanager, render.APIManagerOptions{
+		OpenShift: !renderAPIManagerKubernetes,
+		Objects:   clusterObjects,
+	})
+	if err != nil {
+		return err
+	}
+
+	serializer := json.NewSerializerWithOptions(json.DefaultMetaFactory, nil, nil,
+		json.SerializerOptions{Yaml: true, Pretty: true, Strict: true})
+	for _, obj := range renderedObjects {
+		if _, err := fmt.Fprintln(os.Stdout, "---"); err != nil {
+			return err
+		}
+		if err := serializer.Encode(obj, os.Stdout); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readObjects decodes the objects of a multi-document YAML or JSON file.
+// "-" reads the standard input
+func readObjects(filename string) ([]client.Object, error) {
+	var reader io.Reader = os.Stdin
+	if filename != "-" {
+		f, err := os.Open(filename)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		reader = f
+	}
+
+	decoder := serializer.NewCodecFactory(render.Scheme()).UniversalDeserializer()
+	yamlReader := yaml.NewYAMLReader(bufio.NewReader(reader))
+
+	objects := []client.Object{}
+	for {
+		doc, err := yamlReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		runtimeObj, _, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		obj, ok := runtimeObj.(client.Object)
+		if !ok {
+			return nil, fmt.Errorf("%T is not a kubernetes object", runtimeObj)
+		}
+		objects = append(objects, obj)
+	}
+
+	return objects, nil
+}
+
+func init() {
+	renderAPIManagerCmd.Flags().StringSliceVarP(&renderAPIManagerFilenames, "filename", "f", nil, "Files with the APIManager and the objects existing in the cluster. Use - to read the standard input")
+	renderAPIManagerCmd.Flags().StringVar(&renderAPIManagerNamespace, "namespace", "", "Namespace of the APIManager. Overrides the APIManager namespace")
+	renderAPIManagerCmd.Flags().BoolVar(&renderAPIManagerKubernetes, "kubernetes", false, "Render the objects for a Kubernetes cluster without the OpenShift APIs")
+	renderAPIManagerCmd.MarkFlagRequired("filename")
+	renderCmd.AddCommand(renderAPIManagerCmd)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/3scale/3scale-operator/pkg/helper"
 
 	appsv1 "github.com/openshift/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -469,6 +470,10 @@ func (apicast *Apicast) EnvironmentConfigMap() *v1.ConfigMap {
 	}
 }
 
+func (apicast *Apicast) ProductionHorizontalPodAutoscaler() *autoscalingv2.HorizontalPodAutoscaler {
+	return horizontalPodAutoscaler(ApicastProductionName, apicast.Options.CommonProductionLabels, apicast.Options.ProductionHPA)
+}
+
 func (apicast *Apicast) StagingPodDisruptionBudget() *policyv1.PodDisruptionBudget {
 	return &policyv1.PodDisruptionBudget{
 		TypeMeta: metav1.TypeMeta{
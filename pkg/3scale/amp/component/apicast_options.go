@@ -67,6 +67,7 @@ type ApicastOptions struct {
 	ProductionResourceRequirements      v1.ResourceRequirements `validate:"-"`
 	StagingResourceRequirements         v1.ResourceRequirements `validate:"-"`
 	ProductionReplicas                  int32
+	ProductionHPA                       *HorizontalPodAutoscalerOptions `validate:"-"`
 	StagingReplicas                     int32
 	CommonLabels                        map[string]string             `validate:"required"`
 	CommonStagingLabels                 map[string]string             `validate:"required"`
@@ -8,6 +8,7 @@ import (
 
 	appsv1 "github.com/openshift/api/apps/v1"
 	routev1 "github.com/openshift/api/route/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -346,7 +347,7 @@ func (backend *Backend) ListenerRoute() *routev1.Route {
 			Labels: backend.Options.CommonLabels,
 		},
 		Spec: routev1.RouteSpec{
-			Host: "backend-" + backend.Options.TenantName + "." + backend.Options.WildcardDomain,
+			Host: BackendHost(backend.Options.TenantName, backend.Options.WildcardDomain),
 			To: routev1.RouteTargetReference{
 				Kind: "Service",
 				Name: BackendListenerName,
@@ -524,6 +525,14 @@ func (backend *Backend) ListenerPodDisruptionBudget() *policyv1.PodDisruptionBud
 	}
 }
 
+func (backend *Backend) ListenerHorizontalPodAutoscaler() *autoscalingv2.HorizontalPodAutoscaler {
+	return horizontalPodAutoscaler(BackendListenerName, backend.Options.CommonListenerLabels, backend.Options.ListenerHPA)
+}
+
+func (backend *Backend) WorkerHorizontalPodAutoscaler() *autoscalingv2.HorizontalPodAutoscaler {
+	return horizontalPodAutoscaler(BackendWorkerName, backend.Options.CommonWorkerLabels, backend.Options.WorkerHPA)
+}
+
 func (backend *Backend) listenerPorts() []v1.ContainerPort {
 	ports := []v1.ContainerPort{
 		v1.ContainerPort{HostPort: 0, ContainerPort: 3000, Protocol: v1.ProtocolTCP},
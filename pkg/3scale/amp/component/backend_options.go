@@ -49,6 +49,9 @@ type BackendOptions struct {
 	WorkerPodTemplateAnnotations   map[string]string `validate:"-"`
 	CronPodTemplateAnnotations     map[string]string `validate:"-"`
 
+	ListenerHPA *HorizontalPodAutoscalerOptions `validate:"-"`
+	WorkerHPA   *HorizontalPodAutoscalerOptions `validate:"-"`
+
 	// Used for monitoring objects
 	// Those objects are namespaced. However, objects includes labels, rules and expressions
 	// that need namespace filtering because they are "global" once imported
@@ -112,6 +115,6 @@ func DefaultSystemBackendUsername() string {
 	return "3scale_api_user"
 }
 
-func DefaultSystemBackendPassword() string {
-	return oprand.String(8)
+func DefaultSystemBackendPassword(generator *oprand.Generator) string {
+	return generator.String(8)
 }
@@ -0,0 +1,49 @@
+package component
+
+import (
+	"fmt"
+
+	"github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// BackupSchedulePrometheusRules alert on the APIManagerBackupSchedule metrics exposed by the operator.
+// Only schedules with a success window are monitored
+func BackupSchedulePrometheusRules(ns, appLabel string) *monitoringv1.PrometheusRule {
+	return &monitoringv1.PrometheusRule{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       monitoringv1.PrometheusRuleKind,
+			APIVersion: fmt.Sprintf("%s/%s", monitoring.GroupName, monitoringv1.Version),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "threescale-backup-schedule",
+			Labels: map[string]string{
+				"prometheus": "application-monitoring",
+				"role":       "alert-rules",
+				"app":        appLabel,
+			},
+		},
+		Spec: monitoringv1.PrometheusRuleSpec{
+			Groups: []monitoringv1.RuleGroup{
+				{
+					Name: fmt.Sprintf("%s/threescale-backup-schedule.rules", ns),
+					Rules: []monitoringv1.Rule{
+						{
+							Alert: "ThreescaleBackupScheduleNoRecentSuccess",
+							Annotations: map[string]string{
+								"message": `APIManagerBackupSchedule {{ $labels.backup_schedule_namespace }}/{{ $labels.backup_schedule }} has not completed a backup successfully within its success window.`,
+							},
+							Expr: intstr.FromString(fmt.Sprintf(`time() - max by (backup_schedule_namespace, backup_schedule) (threescale_backup_schedule_last_success_timestamp_seconds{backup_schedule_namespace="%s"}) > max by (backup_schedule_namespace, backup_schedule) (threescale_backup_schedule_success_window_seconds{backup_schedule_namespace="%s"})`, ns, ns)),
+							For:  "5m",
+							Labels: map[string]string{
+								"severity": "critical",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
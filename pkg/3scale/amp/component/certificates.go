@@ -0,0 +1,77 @@
+package component
+
+import (
+	certmanagerv1 "github.com/3scale/3scale-operator/pkg/certmanager/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ApicastStagingCertificateName    = "apicast-staging-tls"
+	ApicastProductionCertificateName = "apicast-production-tls"
+	BackendCertificateName           = "backend-tls"
+	SystemCertificateName            = "system-tls"
+)
+
+// Certificates builds the cert-manager Certificates of the APIcast HTTPS ports
+// and of the public 3scale hosts. Each certificate is stored in a secret
+// named after the certificate
+type Certificates struct {
+	Options *CertificatesOptions
+}
+
+func NewCertificates(options *CertificatesOptions) *Certificates {
+	return &Certificates{Options: options}
+}
+
+func (c *Certificates) Certificates() []*certmanagerv1.Certificate {
+	return []*certmanagerv1.Certificate{
+		c.ApicastStagingCertificate(),
+		c.ApicastProductionCertificate(),
+		c.BackendCertificate(),
+		c.SystemCertificate(),
+	}
+}
+
+func (c *Certificates) ApicastStagingCertificate() *certmanagerv1.Certificate {
+	return c.buildCertificate(ApicastStagingCertificateName, ApicastStagingHost(c.Options.TenantName, c.Options.WildcardDomain))
+}
+
+func (c *Certificates) ApicastProductionCertificate() *certmanagerv1.Certificate {
+	return c.buildCertificate(ApicastProductionCertificateName, ApicastProductionHost(c.Options.TenantName, c.Options.WildcardDomain))
+}
+
+func (c *Certificates) BackendCertificate() *certmanagerv1.Certificate {
+	return c.buildCertificate(BackendCertificateName, BackendHost(c.Options.TenantName, c.Options.WildcardDomain))
+}
+
+// SystemCertificate covers the master, admin portal and developer portal hosts
+func (c *Certificates) SystemCertificate() *certmanagerv1.Certificate {
+	return c.buildCertificate(SystemCertificateName,
+		SystemMasterHost(c.Options.WildcardDomain),
+		SystemProviderHost(c.Options.TenantName, c.Options.WildcardDomain),
+		SystemDeveloperHost(c.Options.TenantName, c.Options.WildcardDomain),
+	)
+}
+
+func (c *Certificates) buildCertificate(name string, dnsNames ...string) *certmanagerv1.Certificate {
+	return &certmanagerv1.Certificate{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       certmanagerv1.CertificateKind,
+			APIVersion: certmanagerv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: c.Options.CommonLabels,
+		},
+		Spec: certmanagerv1.CertificateSpec{
+			SecretName: name,
+			CommonName: dnsNames[0],
+			DNSNames:   dnsNames,
+			IssuerRef: certmanagerv1.ObjectReference{
+				Name:  c.Options.IssuerName,
+				Kind:  c.Options.IssuerKind,
+				Group: c.Options.IssuerGroup,
+			},
+		},
+	}
+}
@@ -0,0 +1,25 @@
+package component
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+type CertificatesOptions struct {
+	TenantName     string `validate:"required"`
+	WildcardDomain string `validate:"required"`
+
+	IssuerName  string `validate:"-"`
+	IssuerKind  string `validate:"-"`
+	IssuerGroup string `validate:"-"`
+
+	CommonLabels map[string]string `validate:"required"`
+}
+
+func NewCertificatesOptions() *CertificatesOptions {
+	return &CertificatesOptions{}
+}
+
+func (c *CertificatesOptions) Validate() error {
+	validate := validator.New()
+	return validate.Struct(c)
+}
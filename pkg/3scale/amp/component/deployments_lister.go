@@ -12,6 +12,10 @@ type DeploymentsLister struct {
 	SystemDatabaseType     SystemDatabaseType
 	ExternalRedisDatabases bool
 	ExternalZyncDatabase   bool
+	// SystemAppSplitDeployments lists the system-app split deployments instead of system-app
+	SystemAppSplitDeployments bool
+	// SidekiqPools lists the names of the system-sidekiq pools
+	SidekiqPools []string
 }
 
 func (d *DeploymentsLister) DeploymentNames() []string {
@@ -23,8 +27,13 @@ func (d *DeploymentsLister) DeploymentNames() []string {
 		BackendWorkerName,
 		BackendCronName,
 		SystemMemcachedDeploymentName,
-		SystemAppDeploymentName,
-		SystemSidekiqName,
+	)
+	deployments = append(deployments, SystemAppDeploymentNames(d.SystemAppSplitDeployments)...)
+	deployments = append(deployments, SystemSidekiqName)
+	for _, pool := range d.SidekiqPools {
+		deployments = append(deployments, SidekiqPoolName(pool))
+	}
+	deployments = append(deployments,
 		SystemSearchdDeploymentName,
 		ZyncName,
 		ZyncQueDeploymentName,
@@ -0,0 +1,104 @@
+package component
+
+import (
+	appsv1 "github.com/openshift/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	DefaultHPAMinReplicas           int32 = 1
+	DefaultHPACPUAverageUtilization int32 = 80
+)
+
+type HorizontalPodAutoscalerOptions struct {
+	MinReplicas              int32
+	MaxReplicas              int32
+	CPUAverageUtilization    *int32
+	MemoryAverageUtilization *int32
+	PodsMetrics              []HorizontalPodAutoscalerPodsMetric
+}
+
+type HorizontalPodAutoscalerPodsMetric struct {
+	Name         string
+	AverageValue resource.Quantity
+}
+
+// horizontalPodAutoscaler returns the HPA scaling the DeploymentConfig with the given name.
+// When opts is nil, the HPA has an empty spec, it is only meant to be deleted.
+func horizontalPodAutoscaler(name string, labels map[string]string, opts *HorizontalPodAutoscalerOptions) *autoscalingv2.HorizontalPodAutoscaler {
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "HorizontalPodAutoscaler",
+			APIVersion: "autoscaling/v2",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				Kind:       "DeploymentConfig",
+				Name:       name,
+				APIVersion: appsv1.GroupVersion.String(),
+			},
+		},
+	}
+
+	if opts == nil {
+		return hpa
+	}
+
+	hpa.Spec.MinReplicas = &[]int32{opts.MinReplicas}[0]
+	hpa.Spec.MaxReplicas = opts.MaxReplicas
+	hpa.Spec.Metrics = horizontalPodAutoscalerMetrics(opts)
+
+	return hpa
+}
+
+func horizontalPodAutoscalerMetrics(opts *HorizontalPodAutoscalerOptions) []autoscalingv2.MetricSpec {
+	metrics := []autoscalingv2.MetricSpec{}
+
+	cpuAverageUtilization := opts.CPUAverageUtilization
+	if cpuAverageUtilization == nil && opts.MemoryAverageUtilization == nil && len(opts.PodsMetrics) == 0 {
+		cpuAverageUtilization = &[]int32{DefaultHPACPUAverageUtilization}[0]
+	}
+
+	if cpuAverageUtilization != nil {
+		metrics = append(metrics, resourceMetric(v1.ResourceCPU, *cpuAverageUtilization))
+	}
+
+	if opts.MemoryAverageUtilization != nil {
+		metrics = append(metrics, resourceMetric(v1.ResourceMemory, *opts.MemoryAverageUtilization))
+	}
+
+	for idx := range opts.PodsMetrics {
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: autoscalingv2.MetricIdentifier{Name: opts.PodsMetrics[idx].Name},
+				Target: autoscalingv2.MetricTarget{
+					Type:         autoscalingv2.AverageValueMetricType,
+					AverageValue: &opts.PodsMetrics[idx].AverageValue,
+				},
+			},
+		})
+	}
+
+	return metrics
+}
+
+func resourceMetric(name v1.ResourceName, averageUtilization int32) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: name,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: &[]int32{averageUtilization}[0],
+			},
+		},
+	}
+}
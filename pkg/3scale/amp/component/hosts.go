@@ -0,0 +1,28 @@
+package component
+
+// Public hosts of the 3scale default tenant, derived from the wildcard domain.
+// They match the hosts of the routes created by the operator and by zync
+
+func BackendHost(tenantName, wildcardDomain string) string {
+	return "backend-" + tenantName + "." + wildcardDomain
+}
+
+func ApicastStagingHost(tenantName, wildcardDomain string) string {
+	return "api-" + tenantName + "-apicast-staging." + wildcardDomain
+}
+
+func ApicastProductionHost(tenantName, wildcardDomain string) string {
+	return "api-" + tenantName + "-apicast-production." + wildcardDomain
+}
+
+func SystemMasterHost(wildcardDomain string) string {
+	return "master." + wildcardDomain
+}
+
+func SystemProviderHost(tenantName, wildcardDomain string) string {
+	return tenantName + "-admin." + wildcardDomain
+}
+
+func SystemDeveloperHost(tenantName, wildcardDomain string) string {
+	return tenantName + "." + wildcardDomain
+}
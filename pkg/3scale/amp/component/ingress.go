@@ -0,0 +1,112 @@
+package component
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	BackendIngressName           = "backend"
+	ApicastStagingIngressName    = ApicastStagingName
+	ApicastProductionIngressName = ApicastProductionName
+	SystemMasterIngressName      = "system-master"
+	SystemProviderIngressName    = "system-provider"
+	SystemDeveloperIngressName   = "system-developer"
+)
+
+// Ingress builds the Kubernetes Ingress resources exposing the same hosts
+// as the OpenShift Routes created by the operator and by zync
+// for the default tenant
+type Ingress struct {
+	Options *IngressOptions
+}
+
+func NewIngress(options *IngressOptions) *Ingress {
+	return &Ingress{Options: options}
+}
+
+func (i *Ingress) Ingresses() []*networkingv1.Ingress {
+	return []*networkingv1.Ingress{
+		i.BackendIngress(),
+		i.ApicastStagingIngress(),
+		i.ApicastProductionIngress(),
+		i.SystemMasterIngress(),
+		i.SystemProviderIngress(),
+		i.SystemDeveloperIngress(),
+	}
+}
+
+func (i *Ingress) BackendIngress() *networkingv1.Ingress {
+	return i.buildIngress(BackendIngressName, BackendHost(i.Options.TenantName, i.Options.WildcardDomain), BackendListenerName, "http")
+}
+
+func (i *Ingress) ApicastStagingIngress() *networkingv1.Ingress {
+	return i.buildIngress(ApicastStagingIngressName, ApicastStagingHost(i.Options.TenantName, i.Options.WildcardDomain), ApicastStagingName, "gateway")
+}
+
+func (i *Ingress) ApicastProductionIngress() *networkingv1.Ingress {
+	return i.buildIngress(ApicastProductionIngressName, ApicastProductionHost(i.Options.TenantName, i.Options.WildcardDomain), ApicastProductionName, "gateway")
+}
+
+func (i *Ingress) SystemMasterIngress() *networkingv1.Ingress {
+	return i.buildIngress(SystemMasterIngressName, SystemMasterHost(i.Options.WildcardDomain), "system-master", "http")
+}
+
+func (i *Ingress) SystemProviderIngress() *networkingv1.Ingress {
+	return i.buildIngress(SystemProviderIngressName, SystemProviderHost(i.Options.TenantName, i.Options.WildcardDomain), "system-provider", "http")
+}
+
+func (i *Ingress) SystemDeveloperIngress() *networkingv1.Ingress {
+	return i.buildIngress(SystemDeveloperIngressName, SystemDeveloperHost(i.Options.TenantName, i.Options.WildcardDomain), "system-developer", "http")
+}
+
+func (i *Ingress) buildIngress(name, host, serviceName, servicePortName string) *networkingv1.Ingress {
+	pathType := networkingv1.PathTypePrefix
+
+	ingress := &networkingv1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Ingress",
+			APIVersion: "networking.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      i.Options.CommonLabels,
+			Annotations: i.Options.Annotations,
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: i.Options.IngressClassName,
+			Rules: []networkingv1.IngressRule{
+				{
+					Host: host,
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{
+								{
+									Path:     "/",
+									PathType: &pathType,
+									Backend: networkingv1.IngressBackend{
+										Service: &networkingv1.IngressServiceBackend{
+											Name: serviceName,
+											Port: networkingv1.ServiceBackendPort{Name: servicePortName},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if i.Options.TLSSecretName != nil {
+		ingress.Spec.TLS = []networkingv1.IngressTLS{
+			{
+				Hosts:      []string{host},
+				SecretName: *i.Options.TLSSecretName,
+			},
+		}
+	}
+
+	return ingress
+}
@@ -0,0 +1,25 @@
+package component
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+type IngressOptions struct {
+	TenantName     string `validate:"required"`
+	WildcardDomain string `validate:"required"`
+
+	IngressClassName *string           `validate:"-"`
+	TLSSecretName    *string           `validate:"-"`
+	Annotations      map[string]string `validate:"-"`
+
+	CommonLabels map[string]string `validate:"required"`
+}
+
+func NewIngressOptions() *IngressOptions {
+	return &IngressOptions{}
+}
+
+func (i *IngressOptions) Validate() error {
+	validate := validator.New()
+	return validate.Struct(i)
+}
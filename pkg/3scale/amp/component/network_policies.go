@@ -0,0 +1,188 @@
+package component
+
+import (
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// OpenShiftIngressPolicyGroupLabel is set by OpenShift on the namespaces of the router pods
+	OpenShiftIngressPolicyGroupLabel = "network.openshift.io/policy-group"
+	// DeploymentConfigHookPodTypeLabel is set on the DeploymentConfig lifecycle hook pods,
+	// which do not have the pod template labels
+	DeploymentConfigHookPodTypeLabel = "openshift.io/deployer-pod.type"
+	// SystemJobPodLabel is set on the pods of the jobs the operator runs with the system image
+	SystemJobPodLabel = "apps.3scale.net/system-job"
+)
+
+// NetworkPolicies builds the NetworkPolicies isolating the internal 3scale components.
+// Each policy selects the pods of one component and allows ingress traffic
+// only from the components using it, on the component port
+type NetworkPolicies struct {
+	Options *NetworkPoliciesOptions
+}
+
+func NewNetworkPolicies(options *NetworkPoliciesOptions) *NetworkPolicies {
+	return &NetworkPolicies{Options: options}
+}
+
+func (n *NetworkPolicies) SystemMySQLNetworkPolicy() *networkingv1.NetworkPolicy {
+	return n.buildNetworkPolicy(SystemMySQLDeploymentName, 3306, n.systemPeers())
+}
+
+func (n *NetworkPolicies) SystemPostgreSQLNetworkPolicy() *networkingv1.NetworkPolicy {
+	return n.buildNetworkPolicy(SystemPostgreSQLDeploymentName, 5432, n.systemPeers())
+}
+
+func (n *NetworkPolicies) SystemRedisNetworkPolicy() *networkingv1.NetworkPolicy {
+	return n.buildNetworkPolicy(SystemRedisDeploymentName, 6379, n.systemPeers())
+}
+
+// BackendRedisNetworkPolicy allows system, which reads the backend storage for analytics, as well
+func (n *NetworkPolicies) BackendRedisNetworkPolicy() *networkingv1.NetworkPolicy {
+	peers := append(n.backendPeers(), n.systemPeers()...)
+	return n.buildNetworkPolicy(BackendRedisDeploymentName, 6379, peers)
+}
+
+func (n *NetworkPolicies) SystemMemcachedNetworkPolicy() *networkingv1.NetworkPolicy {
+	return n.buildNetworkPolicy(SystemMemcachedDeploymentName, 11211, n.systemPeers())
+}
+
+func (n *NetworkPolicies) SystemSearchdNetworkPolicy() *networkingv1.NetworkPolicy {
+	return n.buildNetworkPolicy(SystemSearchdDeploymentName, 9306, n.systemPeers())
+}
+
+func (n *NetworkPolicies) ZyncDatabaseNetworkPolicy() *networkingv1.NetworkPolicy {
+	return n.buildNetworkPolicy(ZyncDatabaseDeploymentName, 5432, n.podPeers(ZyncName, ZyncQueDeploymentName))
+}
+
+// ZyncNetworkPolicy allows system, which notifies zync of the domain changes
+func (n *NetworkPolicies) ZyncNetworkPolicy() *networkingv1.NetworkPolicy {
+	return n.buildNetworkPolicy(ZyncName, 8080, append(n.podPeers(systemDeploymentNames()...), n.sidekiqPoolPeer()))
+}
+
+// BackendListenerNetworkPolicy allows the gateways, system, the OpenShift router
+// and the ingress controller, when backend is exposed with an ingress.
+// Gateways deployed out of the namespace reach backend through the router or the ingress controller
+func (n *NetworkPolicies) BackendListenerNetworkPolicy() *networkingv1.NetworkPolicy {
+	peers := append(n.podPeers(ApicastStagingName, ApicastProductionName), n.systemPeers()...)
+	peers = append(peers, networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{OpenShiftIngressPolicyGroupLabel: "ingress"},
+		},
+	})
+	if n.Options.IngressControllerNamespace != nil {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{v1.LabelMetadataName: *n.Options.IngressControllerNamespace},
+			},
+		})
+	}
+	return n.buildNetworkPolicy(BackendListenerName, 3000, peers)
+}
+
+// systemPeers includes the system-app lifecycle hook pods, which run the database migrations,
+// the sidekiq pools and the system jobs run by the operator
+func (n *NetworkPolicies) systemPeers() []networkingv1.NetworkPolicyPeer {
+	return append(n.podPeers(systemDeploymentNames()...),
+		n.sidekiqPoolPeer(),
+		networkingv1.NetworkPolicyPeer{
+			PodSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      DeploymentConfigHookPodTypeLabel,
+						Operator: metav1.LabelSelectorOpIn,
+						Values:   []string{"hook-pre", "hook-mid", "hook-post"},
+					},
+				},
+			},
+		},
+		networkingv1.NetworkPolicyPeer{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{SystemJobPodLabel: "true"},
+			},
+		},
+	)
+}
+
+func (n *NetworkPolicies) backendPeers() []networkingv1.NetworkPolicyPeer {
+	return n.podPeers(BackendListenerName, BackendWorkerName, BackendCronName)
+}
+
+// systemDeploymentNames returns system-app, its split deployments and system-sidekiq
+func systemDeploymentNames() []string {
+	return append([]string{SystemAppDeploymentName, SystemSidekiqName}, SystemAppSplitDeploymentNames...)
+}
+
+// sidekiqPoolPeer selects the pods of every sidekiq pool
+func (n *NetworkPolicies) sidekiqPoolPeer() networkingv1.NetworkPolicyPeer {
+	return networkingv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: SystemSidekiqPoolLabelKey, Operator: metav1.LabelSelectorOpExists},
+			},
+		},
+	}
+}
+
+func (n *NetworkPolicies) podPeers(deploymentNames ...string) []networkingv1.NetworkPolicyPeer {
+	peers := []networkingv1.NetworkPolicyPeer{}
+	for _, name := range deploymentNames {
+		peers = append(peers, networkingv1.NetworkPolicyPeer{
+			PodSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"deploymentConfig": name},
+			},
+		})
+	}
+	return peers
+}
+
+func (n *NetworkPolicies) buildNetworkPolicy(deploymentName string, port int, peers []networkingv1.NetworkPolicyPeer) *networkingv1.NetworkPolicy {
+	protocol := v1.ProtocolTCP
+	targetPort := intstr.FromInt(port)
+
+	rules := []networkingv1.NetworkPolicyIngressRule{
+		{
+			From:  peers,
+			Ports: []networkingv1.NetworkPolicyPort{{Protocol: &protocol, Port: &targetPort}},
+		},
+	}
+
+	if len(n.Options.AllowedNamespaces) > 0 {
+		rules = append(rules, networkingv1.NetworkPolicyIngressRule{
+			From: []networkingv1.NetworkPolicyPeer{
+				{
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{
+								Key:      v1.LabelMetadataName,
+								Operator: metav1.LabelSelectorOpIn,
+								Values:   n.Options.AllowedNamespaces,
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	return &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "NetworkPolicy",
+			APIVersion: "networking.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   deploymentName,
+			Labels: n.Options.CommonLabels,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"deploymentConfig": deploymentName},
+			},
+			Ingress:     rules,
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+}
@@ -0,0 +1,30 @@
+package component
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+type NetworkPoliciesOptions struct {
+	// Internal dependencies deployed by the operator
+	SystemMySQLEnabled      bool
+	SystemPostgreSQLEnabled bool
+	SystemRedisEnabled      bool
+	BackendRedisEnabled     bool
+	ZyncDatabaseEnabled     bool
+
+	AllowedNamespaces []string `validate:"-"`
+
+	// IngressControllerNamespace is allowed to reach backend-listener, exposed with an ingress
+	IngressControllerNamespace *string `validate:"-"`
+
+	CommonLabels map[string]string `validate:"required"`
+}
+
+func NewNetworkPoliciesOptions() *NetworkPoliciesOptions {
+	return &NetworkPoliciesOptions{}
+}
+
+func (n *NetworkPoliciesOptions) Validate() error {
+	validate := validator.New()
+	return validate.Struct(n)
+}
@@ -16,6 +16,8 @@ import (
 const (
 	BackendRedisDeploymentName = "backend-redis"
 	SystemRedisDeploymentName  = "system-redis"
+	BackendRedisPVCName        = backendRedisStorageVolumeName
+	SystemRedisPVCName         = "system-redis-storage"
 )
 
 type Redis struct {
@@ -477,7 +479,7 @@ func (redis *Redis) SystemDeploymentConfig() *appsv1.DeploymentConfig {
 						v1.Volume{
 							Name: "system-redis-storage",
 							VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
-								ClaimName: "system-redis-storage",
+								ClaimName: SystemRedisPVCName,
 								ReadOnly:  false}},
 						}, v1.Volume{
 							Name: "redis-config",
@@ -571,7 +573,7 @@ func (redis *Redis) SystemPVC() *v1.PersistentVolumeClaim {
 			APIVersion: "v1",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:   "system-redis-storage",
+			Name:   SystemRedisPVCName,
 			Labels: redis.Options.SystemRedisLabels,
 		},
 		Spec: v1.PersistentVolumeClaimSpec{
@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	appsv1 "github.com/openshift/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -84,8 +85,16 @@ const (
 	SystemSecretSystemSMTPAuthenticationFieldName    = "authentication"
 	SystemSecretSystemSMTPOpenSSLVerifyModeFieldName = "openssl.verify.mode"
 	SystemSecretSystemSMTPFromAddressFieldName       = "from_address"
+	// TLS fields are only set when the secret is managed from spec.system.smtp
+	SystemSecretSystemSMTPTLSFieldName                = "tls"
+	SystemSecretSystemSMTPEnableStartTLSFieldName     = "enable_starttls"
+	SystemSecretSystemSMTPEnableStartTLSAutoFieldName = "enable_starttls_auto"
 )
 
+// SystemSMTPTLSEnvVarNames are read from optional system-smtp secret fields,
+// so user provided secrets without them are still valid
+var SystemSMTPTLSEnvVarNames = []string{"SMTP_TLS", "SMTP_ENABLE_STARTTLS", "SMTP_ENABLE_STARTTLS_AUTO"}
+
 const (
 	SystemFileStoragePVCName = "system-storage"
 )
@@ -94,6 +103,18 @@ const (
 	SystemSidekiqName       = "system-sidekiq"
 	SystemAppDeploymentName = "system-app"
 
+	// system-app split deployments, each one running the system-app container with the same name
+	SystemMasterDeploymentName    = "system-master"
+	SystemProviderDeploymentName  = "system-provider"
+	SystemDeveloperDeploymentName = "system-developer"
+
+	// SystemSidekiqPoolLabelKey labels the sidekiq pods with the name of their pool.
+	// Also set on the sidekiq pool DeploymentConfigs
+	SystemSidekiqPoolLabelKey = "threescale_sidekiq_pool"
+	// SystemSidekiqConfigFile is the sidekiq configuration file of the system image
+	SystemSidekiqConfigFile  = "config/sidekiq.yml"
+	SystemSidekiqConcurrency = 25
+
 	SystemAppMasterContainerName    = "system-master"
 	SystemAppProviderContainerName  = "system-provider"
 	SystemAppDeveloperContainerName = "system-developer"
@@ -150,6 +171,9 @@ func (system *System) getSystemSMTPEnvsFromSMTPSecret() []v1.EnvVar {
 		helper.EnvVarFromSecret("SMTP_PORT", SystemSecretSystemSMTPSecretName, SystemSecretSystemSMTPPortFieldName),
 		helper.EnvVarFromSecret("SMTP_AUTHENTICATION", SystemSecretSystemSMTPSecretName, SystemSecretSystemSMTPAuthenticationFieldName),
 		helper.EnvVarFromSecret("SMTP_OPENSSL_VERIFY_MODE", SystemSecretSystemSMTPSecretName, SystemSecretSystemSMTPOpenSSLVerifyModeFieldName),
+		helper.EnvVarFromSecretOptional("SMTP_TLS", SystemSecretSystemSMTPSecretName, SystemSecretSystemSMTPTLSFieldName),
+		helper.EnvVarFromSecretOptional("SMTP_ENABLE_STARTTLS", SystemSecretSystemSMTPSecretName, SystemSecretSystemSMTPEnableStartTLSFieldName),
+		helper.EnvVarFromSecretOptional("SMTP_ENABLE_STARTTLS_AUTO", SystemSecretSystemSMTPSecretName, SystemSecretSystemSMTPEnableStartTLSAutoFieldName),
 	}
 
 	if system.Options.SmtpSecretOptions.FromAddress != nil &&
@@ -747,6 +771,67 @@ func (system *System) AppDeploymentConfig() *appsv1.DeploymentConfig {
 	}
 }
 
+// SystemAppSplitDeploymentNames are the DeploymentConfigs replacing system-app when split deployments are enabled.
+// system-master goes first, as it runs the system-app lifecycle hooks
+var SystemAppSplitDeploymentNames = []string{
+	SystemMasterDeploymentName,
+	SystemProviderDeploymentName,
+	SystemDeveloperDeploymentName,
+}
+
+// SystemAppDeploymentNames returns the DeploymentConfigs running the system-app containers
+func SystemAppDeploymentNames(split bool) []string {
+	if split {
+		return append([]string{}, SystemAppSplitDeploymentNames...)
+	}
+	return []string{SystemAppDeploymentName}
+}
+
+// AppSplitDeploymentConfig returns the DeploymentConfig running only the system-app container with the given name,
+// one of SystemAppSplitDeploymentNames. Only system-master runs the lifecycle hooks
+func (system *System) AppSplitDeploymentConfig(name string) *appsv1.DeploymentConfig {
+	dc := system.AppDeploymentConfig()
+	dc.Name = name
+	dc.Spec.Selector = map[string]string{"deploymentConfig": name}
+
+	switch name {
+	case SystemMasterDeploymentName:
+		dc.Spec.Replicas = system.Options.AppMasterReplicas
+	case SystemProviderDeploymentName:
+		dc.Spec.Replicas = system.Options.AppProviderReplicas
+	case SystemDeveloperDeploymentName:
+		dc.Spec.Replicas = system.Options.AppDeveloperReplicas
+	}
+
+	if name != SystemMasterDeploymentName {
+		dc.Spec.Strategy.RollingParams.Pre = nil
+		dc.Spec.Strategy.RollingParams.Post = nil
+	}
+
+	for idx := range dc.Spec.Triggers {
+		if dc.Spec.Triggers[idx].ImageChangeParams != nil {
+			dc.Spec.Triggers[idx].ImageChangeParams.ContainerNames = []string{name}
+		}
+	}
+
+	labels := map[string]string{}
+	for k, v := range dc.Spec.Template.Labels {
+		labels[k] = v
+	}
+	labels["deploymentConfig"] = name
+	dc.Spec.Template.Labels = labels
+
+	containers := []v1.Container{}
+	for _, container := range dc.Spec.Template.Spec.Containers {
+		if container.Name == name {
+			containers = append(containers, container)
+		}
+	}
+	dc.Spec.Template.Spec.Containers = containers
+
+	return dc
+}
+
 func (system *System) FileStorageVolume() v1.Volume {
 	return v1.Volume{
 		Name: SystemFileStoragePVCName,
@@ -900,6 +985,58 @@ func (system *System) SidekiqDeploymentConfig() *appsv1.DeploymentConfig {
 	}
 }
 
+// SidekiqPoolName returns the name of the DeploymentConfig of the sidekiq pool
+func SidekiqPoolName(pool string) string {
+	return fmt.Sprintf("%s-%s", SystemSidekiqName, pool)
+}
+
+// SidekiqPoolDeploymentConfig returns the system-sidekiq DeploymentConfig processing only the queues of the pool
+func (system *System) SidekiqPoolDeploymentConfig(pool *SystemSidekiqPoolOptions) *appsv1.DeploymentConfig {
+	name := SidekiqPoolName(pool.Name)
+
+	dc := system.SidekiqDeploymentConfig()
+	dc.Name = name
+	dc.Labels = pool.CommonLabels
+	dc.Spec.Replicas = pool.Replicas
+	dc.Spec.Selector = map[string]string{"deploymentConfig": name}
+	dc.Spec.Template.Labels = pool.PodTemplateLabels
+	dc.Spec.Template.Annotations = pool.PodTemplateAnnotations
+
+	podSpec := &dc.Spec.Template.Spec
+	podSpec.Affinity = pool.Affinity
+	podSpec.Tolerations = pool.Tolerations
+	podSpec.PriorityClassName = pool.PriorityClassName
+	podSpec.TopologySpreadConstraints = pool.TopologySpreadConstraints
+
+	container := &podSpec.Containers[0]
+	container.Resources = *pool.ContainerResourceRequirements
+	container.Args = SidekiqPoolArgs(pool.Queues)
+	container.Env = append(container.Env, helper.EnvVarFromValue("RAILS_MAX_THREADS", strconv.Itoa(SystemSidekiqConcurrency)))
+
+	return dc
+}
+
+// SidekiqPoolArgs returns the sidekiq command line processing the given queues, in <name>[,<weight>] format
+func SidekiqPoolArgs(queues []string) []string {
+	args := []string{"bundle", "exec", "sidekiq", "--config", SystemSidekiqConfigFile, "--concurrency", strconv.Itoa(SystemSidekiqConcurrency)}
+	for _, queue := range queues {
+		args = append(args, "--queue", queue)
+	}
+	return args
+}
+
+func (system *System) SidekiqPoolPodDisruptionBudget(pool *SystemSidekiqPoolOptions) *policyv1.PodDisruptionBudget {
+	name := SidekiqPoolName(pool.Name)
+
+	pdb := system.SidekiqPodDisruptionBudget()
+	pdb.Name = name
+	pdb.Labels = pool.CommonLabels
+	pdb.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{"deploymentConfig": name},
+	}
+	return pdb
+}
+
 func (system *System) systemStorageVolumeMount(readOnly bool) v1.VolumeMount {
 	return v1.VolumeMount{
 		Name:      SystemFileStoragePVCName,
@@ -1022,7 +1159,7 @@ func (system *System) ProviderService() *v1.Service {
 					TargetPort: intstr.FromString("provider"),
 				},
 			},
-			Selector: map[string]string{"deploymentConfig": SystemAppDeploymentName},
+			Selector: system.appServiceSelector(SystemProviderDeploymentName),
 		},
 	}
 }
@@ -1046,7 +1183,7 @@ func (system *System) MasterService() *v1.Service {
 					TargetPort: intstr.FromString("master"),
 				},
 			},
-			Selector: map[string]string{"deploymentConfig": SystemAppDeploymentName},
+			Selector: system.appServiceSelector(SystemMasterDeploymentName),
 		},
 	}
 }
@@ -1070,11 +1207,19 @@ func (system *System) DeveloperService() *v1.Service {
 					TargetPort: intstr.FromString("developer"),
 				},
 			},
-			Selector: map[string]string{"deploymentConfig": SystemAppDeploymentName},
+			Selector: system.appServiceSelector(SystemDeveloperDeploymentName),
 		},
 	}
 }
 
+// appServiceSelector selects the system-app pods or, with split deployments, the pods of the given deployment
+func (system *System) appServiceSelector(splitDeploymentName string) map[string]string {
+	if system.Options.AppSplitDeployments {
+		return map[string]string{"deploymentConfig": splitDeploymentName}
+	}
+	return map[string]string{"deploymentConfig": SystemAppDeploymentName}
+}
+
 func (system *System) MemcachedService() *v1.Service {
 	return &v1.Service{
 		TypeMeta: metav1.TypeMeta{
@@ -1124,6 +1269,16 @@ func (system *System) SMTPSecret() *v1.Secret {
 		res.StringData[SystemSecretSystemSMTPFromAddressFieldName] = *system.Options.SmtpSecretOptions.FromAddress
 	}
 
+	for fieldName, value := range map[string]*string{
+		SystemSecretSystemSMTPTLSFieldName:                system.Options.SmtpSecretOptions.TLS,
+		SystemSecretSystemSMTPEnableStartTLSFieldName:     system.Options.SmtpSecretOptions.EnableStartTLS,
+		SystemSecretSystemSMTPEnableStartTLSAutoFieldName: system.Options.SmtpSecretOptions.EnableStartTLSAuto,
+	} {
+		if value != nil {
+			res.StringData[fieldName] = *value
+		}
+	}
+
 	return res
 }
 
@@ -1199,6 +1354,19 @@ func (system *System) AppPodDisruptionBudget() *policyv1.PodDisruptionBudget {
 	}
 }
 
+func (system *System) AppSplitPodDisruptionBudget(name string) *policyv1.PodDisruptionBudget {
+	pdb := system.AppPodDisruptionBudget()
+	pdb.Name = name
+	pdb.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{"deploymentConfig": name},
+	}
+	return pdb
+}
+
+func (system *System) AppHorizontalPodAutoscaler() *autoscalingv2.HorizontalPodAutoscaler {
+	return horizontalPodAutoscaler(SystemAppDeploymentName, system.Options.CommonAppLabels, system.Options.AppHPA)
+}
+
 func (system *System) SidekiqPodDisruptionBudget() *policyv1.PodDisruptionBudget {
 	return &policyv1.PodDisruptionBudget{
 		TypeMeta: metav1.TypeMeta{
@@ -28,6 +28,8 @@ func (system *System) SystemSidekiqPodMonitor() *monitoringv1.PodMonitor {
 			Selector: metav1.LabelSelector{
 				MatchLabels: system.Options.CommonSidekiqLabels,
 			},
+			// The metrics of each sidekiq pool are labeled with the pool name
+			PodTargetLabels: []string{SystemSidekiqPoolLabelKey},
 		},
 	}
 }
@@ -117,7 +119,7 @@ func (system *System) SystemAppPrometheusRules() *monitoringv1.PrometheusRule {
 								"summary":     "Job {{ $labels.job }} on {{ $labels.namespace }} has more than 50 HTTP 5xx requests in the last minute",
 								"description": "Job {{ $labels.job }} on {{ $labels.namespace }} has more than 50 HTTP 5xx requests in the last minute",
 							},
-							Expr: intstr.FromString(fmt.Sprintf(`sum(rate(rails_requests_total{namespace="%s",pod=~"system-app-[a-z0-9]+-[a-z0-9]+",status=~"5[0-9]*"}[1m])) by (namespace,job) > 50`, system.Options.Namespace)),
+							Expr: intstr.FromString(fmt.Sprintf(`sum(rate(rails_requests_total{namespace="%s",pod=~"system-(app|master|provider|developer)-[a-z0-9]+-[a-z0-9]+",status=~"5[0-9]*"}[1m])) by (namespace,job) > 50`, system.Options.Namespace)),
 							For:  "1m",
 							Labels: map[string]string{
 								"severity": "warning",
@@ -162,8 +164,8 @@ func (system *System) SystemSidekiqPrometheusRules() *monitoringv1.PrometheusRul
 							Alert: "ThreescaleSystemSidekiqJobDown",
 							Annotations: map[string]string{
 								"sop_url":     ThreescalePrometheusJobDownURL,
-								"summary":     "Job {{ $labels.job }} on {{ $labels.namespace }} is DOWN",
-								"description": "Job {{ $labels.job }} on {{ $labels.namespace }} is DOWN",
+								"summary":     "Job {{ $labels.job }} of sidekiq pool {{ $labels.threescale_sidekiq_pool }} on {{ $labels.namespace }} is DOWN",
+								"description": "Job {{ $labels.job }} of sidekiq pool {{ $labels.threescale_sidekiq_pool }} on {{ $labels.namespace }} is DOWN",
 							},
 							Expr: intstr.FromString(fmt.Sprintf(`up{job=~".*system-sidekiq.*",namespace="%s"} == 0`, system.Options.Namespace)),
 							For:  "1m",
@@ -171,6 +173,19 @@ func (system *System) SystemSidekiqPrometheusRules() *monitoringv1.PrometheusRul
 								"severity": "critical",
 							},
 						},
+						{
+							Alert: "ThreescaleSystemSidekiqPoolDown",
+							Annotations: map[string]string{
+								"sop_url":     ThreescalePrometheusJobDownURL,
+								"summary":     "All the pods of sidekiq pool {{ $labels.threescale_sidekiq_pool }} on {{ $labels.namespace }} are DOWN",
+								"description": "All the pods of sidekiq pool {{ $labels.threescale_sidekiq_pool }} on {{ $labels.namespace }} are DOWN, the queues of the pool are not processed",
+							},
+							Expr: intstr.FromString(fmt.Sprintf(`sum(up{job=~".*system-sidekiq.*",namespace="%s"}) by (namespace,%s) == 0`, system.Options.Namespace, SystemSidekiqPoolLabelKey)),
+							For:  "1m",
+							Labels: map[string]string{
+								"severity": "critical",
+							},
+						},
 					},
 				},
 			},
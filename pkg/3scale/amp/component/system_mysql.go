@@ -12,6 +12,7 @@ import (
 
 const (
 	SystemMySQLDeploymentName = "system-mysql"
+	SystemMySQLPVCName        = "mysql-storage"
 )
 
 type SystemMysql struct {
@@ -99,7 +100,7 @@ func (mysql *SystemMysql) PersistentVolumeClaim() *v1.PersistentVolumeClaim {
 			APIVersion: "v1",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:   "mysql-storage",
+			Name:   SystemMySQLPVCName,
 			Labels: mysql.Options.DeploymentLabels,
 		},
 		Spec: v1.PersistentVolumeClaimSpec{
@@ -163,7 +164,7 @@ func (mysql *SystemMysql) DeploymentConfig() *appsv1.DeploymentConfig {
 						v1.Volume{
 							Name: "mysql-storage",
 							VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
-								ClaimName: "mysql-storage",
+								ClaimName: SystemMySQLPVCName,
 								ReadOnly:  false}},
 						}, v1.Volume{
 							Name: "mysql-extra-conf",
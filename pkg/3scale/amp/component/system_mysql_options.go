@@ -55,12 +55,12 @@ func DefaultSystemMysqlUser() string {
 	return "mysql"
 }
 
-func DefaultSystemMysqlPassword() string {
-	return oprand.String(8)
+func DefaultSystemMysqlPassword(generator *oprand.Generator) string {
+	return generator.String(8)
 }
 
-func DefaultSystemMysqlRootPassword() string {
-	return oprand.String(8)
+func DefaultSystemMysqlRootPassword(generator *oprand.Generator) string {
+	return generator.String(8)
 }
 
 func DefaultSystemMysqlDatabaseName() string {
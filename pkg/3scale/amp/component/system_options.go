@@ -26,6 +26,10 @@ type SystemSMTPSecretOptions struct {
 	Port              *string `validate:"required"`
 	Username          *string `validate:"required"`
 	FromAddress       *string
+	// TLS modes, only set from spec.system.smtp
+	TLS                *string
+	EnableStartTLS     *string
+	EnableStartTLSAuto *string
 }
 
 type PVCFileStorageOptions struct {
@@ -34,6 +38,23 @@ type PVCFileStorageOptions struct {
 	StorageRequests resource.Quantity `validate:"required"`
 }
 
+// SystemSidekiqPoolOptions defines a sidekiq DeploymentConfig processing a set of queues
+type SystemSidekiqPoolOptions struct {
+	Name string `validate:"required"`
+	// Queues in sidekiq --queue format, <name>[,<weight>]
+	Queues                        []string `validate:"min=1"`
+	Replicas                      int32
+	ContainerResourceRequirements *v1.ResourceRequirements `validate:"required"`
+	CommonLabels                  map[string]string        `validate:"required"`
+	PodTemplateLabels             map[string]string        `validate:"required"`
+
+	Affinity                  *v1.Affinity                  `validate:"-"`
+	Tolerations               []v1.Toleration               `validate:"-"`
+	PriorityClassName         string                        `validate:"-"`
+	TopologySpreadConstraints []v1.TopologySpreadConstraint `validate:"-"`
+	PodTemplateAnnotations    map[string]string             `validate:"-"`
+}
+
 type SystemOptions struct {
 	MemcachedServers                       string  `validate:"required"`
 	EventHooksURL                          string  `validate:"required"`
@@ -52,7 +73,15 @@ type SystemOptions struct {
 	PvcFileStorageOptions *PVCFileStorageOptions `validate:"required_without=S3FileStorageOptions"`
 
 	AppReplicas     int32
+	AppHPA          *HorizontalPodAutoscalerOptions `validate:"-"`
 	SidekiqReplicas int32
+	SidekiqPools    []SystemSidekiqPoolOptions `validate:"dive"`
+
+	// AppSplitDeployments replaces system-app with the SystemAppSplitDeploymentNames deployments
+	AppSplitDeployments  bool
+	AppMasterReplicas    int32
+	AppProviderReplicas  int32
+	AppDeveloperReplicas int32
 
 	AdminAccessToken    string  `validate:"required"`
 	AdminPassword       string  `validate:"required"`
@@ -71,6 +100,11 @@ type SystemOptions struct {
 	WildcardDomain      string  `validate:"required"`
 	SmtpSecretOptions   SystemSMTPSecretOptions
 
+	// Secrets declared in the APIManager spec are fully reconciled, instead of only adding the missing fields
+	SMTPSecretManaged       bool
+	RecaptchaSecretManaged  bool
+	EventsHookSecretManaged bool
+
 	AppAffinity        *v1.Affinity    `validate:"-"`
 	AppTolerations     []v1.Toleration `validate:"-"`
 	SidekiqAffinity    *v1.Affinity    `validate:"-"`
@@ -131,22 +165,22 @@ func DefaultRecaptchaPrivatekey() string {
 	return ""
 }
 
-func DefaultBackendSharedSecret() string {
-	return oprand.String(8)
+func DefaultBackendSharedSecret(generator *oprand.Generator) string {
+	return generator.String(8)
 }
 
 func DefaultEventHooksURL() string {
 	return "http://system-master:3000/master/events/import"
 }
 
-func DefaultSystemAppSecretKeyBase() string {
+func DefaultSystemAppSecretKeyBase(generator *oprand.Generator) string {
 	// TODO is not exactly what we were generating
 	// in OpenShift templates. We were generating
 	// '[a-f0-9]{128}' . Ask system if there's some reason
 	// for that and if we can change it. If must be that range
 	// then we should create another function to generate
 	// hexadecimal lowercase string output
-	return oprand.String(128)
+	return generator.String(128)
 }
 
 func DefaultSystemMasterName() string {
@@ -157,24 +191,24 @@ func DefaultSystemMasterUsername() string {
 	return "master"
 }
 
-func DefaultSystemMasterPassword() string {
-	return oprand.String(8)
+func DefaultSystemMasterPassword(generator *oprand.Generator) string {
+	return generator.String(8)
 }
 
 func DefaultSystemAdminUsername() string {
 	return "admin"
 }
 
-func DefaultSystemAdminPassword() string {
-	return oprand.String(8)
+func DefaultSystemAdminPassword(generator *oprand.Generator) string {
+	return generator.String(8)
 }
 
-func DefaultSystemAdminAccessToken() string {
-	return oprand.String(16)
+func DefaultSystemAdminAccessToken(generator *oprand.Generator) string {
+	return generator.String(16)
 }
 
-func DefaultSystemMasterAccessToken() string {
-	return oprand.String(8)
+func DefaultSystemMasterAccessToken(generator *oprand.Generator) string {
+	return generator.String(8)
 }
 
 func DefaultSystemAdminEmail() string {
@@ -185,8 +219,8 @@ func DefaultUserSessionTTL() string {
 	return ""
 }
 
-func DefaultSystemMasterApicastAccessToken() string {
-	return oprand.String(8)
+func DefaultSystemMasterApicastAccessToken(generator *oprand.Generator) string {
+	return generator.String(8)
 }
 
 func DefaultSystemSMTPAddress() string {
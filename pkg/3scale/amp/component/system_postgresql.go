@@ -12,6 +12,7 @@ import (
 
 const (
 	SystemPostgreSQLDeploymentName = "system-postgresql"
+	SystemPostgreSQLPVCName        = "postgresql-data"
 )
 
 type SystemPostgreSQL struct {
@@ -58,7 +59,7 @@ func (p *SystemPostgreSQL) DataPersistentVolumeClaim() *v1.PersistentVolumeClaim
 			APIVersion: "v1",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:   "postgresql-data",
+			Name:   SystemPostgreSQLPVCName,
 			Labels: p.Options.DeploymentLabels,
 		},
 		Spec: v1.PersistentVolumeClaimSpec{
@@ -123,7 +124,7 @@ func (p *SystemPostgreSQL) DeploymentConfig() *appsv1.DeploymentConfig {
 							Name: "postgresql-data",
 							VolumeSource: v1.VolumeSource{
 								PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
-									ClaimName: "postgresql-data",
+									ClaimName: SystemPostgreSQLPVCName,
 								},
 							},
 						},
@@ -54,8 +54,8 @@ func DefaultSystemPostgresqlUser() string {
 	return "system"
 }
 
-func DefaultSystemPostgresqlPassword() string {
-	return oprand.String(8)
+func DefaultSystemPostgresqlPassword(generator *oprand.Generator) string {
+	return generator.String(8)
 }
 
 func DefaultSystemPostgresqlDatabaseName() string {
@@ -116,6 +116,17 @@ func (zync *Zync) QueRole() *rbacv1.Role {
 					"list",
 				},
 			},
+			rbacv1.PolicyRule{
+				APIGroups: []string{"apps"},
+				Resources: []string{
+					"deployments",
+					"replicasets",
+				},
+				Verbs: []string{
+					"get",
+					"list",
+				},
+			},
 			rbacv1.PolicyRule{
 				APIGroups: []string{""},
 				Resources: []string{
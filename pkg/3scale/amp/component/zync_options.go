@@ -69,16 +69,16 @@ func (z *ZyncOptions) Validate() error {
 	return validate.Struct(z)
 }
 
-func DefaultZyncSecretKeyBase() string {
-	return oprand.String(16)
+func DefaultZyncSecretKeyBase(generator *oprand.Generator) string {
+	return generator.String(16)
 }
 
-func DefaultZyncDatabasePassword() string {
-	return oprand.String(16)
+func DefaultZyncDatabasePassword(generator *oprand.Generator) string {
+	return generator.String(16)
 }
 
-func DefaultZyncAuthenticationToken() string {
-	return oprand.String(16)
+func DefaultZyncAuthenticationToken(generator *oprand.Generator) string {
+	return generator.String(16)
 }
 
 func DefaultZyncDatabaseURL(password string) string {
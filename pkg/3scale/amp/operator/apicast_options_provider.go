@@ -8,6 +8,7 @@ import (
 	"strconv"
 
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -82,6 +83,16 @@ func (a *ApicastOptionsProvider) GetApicastOptions() (*component.ApicastOptions,
 		a.apicastOptions.StagingHTTPSCertificateSecretName = &a.apimanager.Spec.Apicast.StagingSpec.HTTPSCertificateSecretRef.Name
 	}
 
+	// HTTPS certificates issued by cert-manager
+	if IsCertificateManaged(a.apimanager, component.ApicastProductionCertificateName) {
+		secretName := component.ApicastProductionCertificateName
+		a.apicastOptions.ProductionHTTPSCertificateSecretName = &secretName
+	}
+	if IsCertificateManaged(a.apimanager, component.ApicastStagingCertificateName) {
+		secretName := component.ApicastStagingCertificateName
+		a.apicastOptions.StagingHTTPSCertificateSecretName = &secretName
+	}
+
 	a.apicastOptions.ProductionServiceCacheSize = a.apimanager.Spec.Apicast.ProductionSpec.ServiceCacheSize
 	a.apicastOptions.StagingServiceCacheSize = a.apimanager.Spec.Apicast.StagingSpec.ServiceCacheSize
 
@@ -113,6 +124,11 @@ func (a *ApicastOptionsProvider) GetApicastOptions() (*component.ApicastOptions,
 	a.apicastOptions.StagingAdditionalPodAnnotations = a.stagingAdditionalPodAnnotations()
 	a.apicastOptions.ProductionAdditionalPodAnnotations = a.productionAdditionalPodAnnotations()
 
+	err = a.setHTTPSCertificateAnnotations()
+	if err != nil {
+		return nil, err
+	}
+
 	err = a.apicastOptions.Validate()
 	if err != nil {
 		return nil, fmt.Errorf("GetApicastOptions validating: %w", err)
@@ -154,6 +170,10 @@ func (a *ApicastOptionsProvider) setReplicas() {
 	if a.apimanager.Spec.Apicast.ProductionSpec.Replicas != nil {
 		a.apicastOptions.ProductionReplicas = int32(*a.apimanager.Spec.Apicast.ProductionSpec.Replicas)
 	}
+	if a.apimanager.IsAPIcastProductionHPAEnabled() {
+		a.apicastOptions.ProductionHPA = horizontalPodAutoscalerOptions(a.apimanager.Spec.Apicast.ProductionSpec.HPA)
+		a.apicastOptions.ProductionReplicas = a.apicastOptions.ProductionHPA.MinReplicas
+	}
 
 	a.apicastOptions.StagingReplicas = 1
 	if a.apimanager.Spec.Apicast.StagingSpec.Replicas != nil {
@@ -498,6 +518,36 @@ func (a *ApicastOptionsProvider) productionAdditionalPodAnnotations() map[string
 	return annotations
 }
 
+// setHTTPSCertificateAnnotations rolls out apicast when cert-manager renews the certificate of the HTTPS port.
+// The annotation is added once the certificate is issued
+func (a *ApicastOptionsProvider) setHTTPSCertificateAnnotations() error {
+	certificates := []struct {
+		name        string
+		annotations map[string]string
+	}{
+		{component.ApicastProductionCertificateName, a.apicastOptions.ProductionAdditionalPodAnnotations},
+		{component.ApicastStagingCertificateName, a.apicastOptions.StagingAdditionalPodAnnotations},
+	}
+
+	for _, certificate := range certificates {
+		if !IsCertificateManaged(a.apimanager, certificate.name) {
+			continue
+		}
+
+		secret := &v1.Secret{}
+		err := a.client.Get(context.TODO(), types.NamespacedName{Name: certificate.name, Namespace: a.apimanager.Namespace}, secret)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		certificate.annotations[HTTPSCertificateSecretResverAnnotation] = secret.ResourceVersion
+	}
+
+	return nil
+}
+
 // APIcast environment hash
 // When any of the fields used to compute the hash change the value, the hash will change
 // and the apicast deployment will rollout
@@ -9,6 +9,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -264,6 +265,26 @@ func TestGetApicastOptionsProvider(t *testing.T) {
 				return opts
 			},
 		},
+		{"WithCertManager",
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanagerTestApicastOptions()
+				httpsPort := appsv1alpha1.DefaultHTTPSPort
+				apimanager.Spec.Apicast.ProductionSpec.HTTPSPort = &httpsPort
+				apimanager.Spec.CertManager = &appsv1alpha1.CertManagerSpec{
+					Enabled:   true,
+					IssuerRef: &appsv1alpha1.CertManagerIssuerReference{Name: "letsencrypt"},
+				}
+				return apimanager
+			},
+			func() *component.ApicastOptions {
+				opts := defaultApicastOptions()
+				httpsPort := appsv1alpha1.DefaultHTTPSPort
+				secretName := component.ApicastProductionCertificateName
+				opts.ProductionHTTPSPort = &httpsPort
+				opts.ProductionHTTPSCertificateSecretName = &secretName
+				return opts
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -282,3 +303,38 @@ func TestGetApicastOptionsProvider(t *testing.T) {
 		})
 	}
 }
+
+func TestGetApicastOptionsProviderCertManagerAnnotation(t *testing.T) {
+	apimanager := basicApimanagerTestApicastOptions()
+	httpsPort := appsv1alpha1.DefaultHTTPSPort
+	apimanager.Spec.Apicast.StagingSpec.HTTPSPort = &httpsPort
+	apimanager.Spec.CertManager = &appsv1alpha1.CertManagerSpec{
+		Enabled:   true,
+		IssuerRef: &appsv1alpha1.CertManagerIssuerReference{Name: "letsencrypt"},
+	}
+
+	certificateSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            component.ApicastStagingCertificateName,
+			Namespace:       namespace,
+			ResourceVersion: "42",
+		},
+	}
+	cl := fake.NewFakeClient(certificateSecret)
+
+	optsProvider := NewApicastOptionsProvider(apimanager, cl)
+	opts, err := optsProvider.GetApicastOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if opts.StagingHTTPSCertificateSecretName == nil || *opts.StagingHTTPSCertificateSecretName != component.ApicastStagingCertificateName {
+		t.Errorf("unexpected staging certificate secret: %v", opts.StagingHTTPSCertificateSecretName)
+	}
+	if opts.StagingAdditionalPodAnnotations[HTTPSCertificateSecretResverAnnotation] != "42" {
+		t.Errorf("unexpected staging pod annotations: %v", opts.StagingAdditionalPodAnnotations)
+	}
+	if _, ok := opts.ProductionAdditionalPodAnnotations[HTTPSCertificateSecretResverAnnotation]; ok {
+		t.Errorf("unexpected production pod annotations: %v", opts.ProductionAdditionalPodAnnotations)
+	}
+}
@@ -122,7 +122,8 @@ func (r *ApicastReconciler) Reconcile() (reconcile.Result, error) {
 		reconcilers.DeploymentConfigPodTemplateAnnotationsMutator,
 	}
 
-	if r.apiManager.Spec.Apicast.ProductionSpec.Replicas != nil {
+	// Replicas are managed by the HPA when enabled
+	if r.apiManager.Spec.Apicast.ProductionSpec.Replicas != nil && !r.apiManager.IsAPIcastProductionHPAEnabled() {
 		productionMutators = append(productionMutators, reconcilers.DeploymentConfigReplicasMutator)
 	}
 
@@ -166,6 +167,16 @@ func (r *ApicastReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
+	// Production HPA
+	productionHPA := apicast.ProductionHorizontalPodAutoscaler()
+	if !r.apiManager.IsAPIcastProductionHPAEnabled() {
+		common.TagObjectToDelete(productionHPA)
+	}
+	err = r.ReconcileHorizontalPodAutoscaler(productionHPA, reconcilers.GenericHPAMutator)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	sumRate, err := helper.SumRateForOpenshiftVersion(r.Context(), r.Client())
 	if err != nil {
 		return reconcile.Result{}, err
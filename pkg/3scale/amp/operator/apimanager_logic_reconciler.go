@@ -0,0 +1,103 @@
+package operator
+
+import (
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// APIManagerLogicReconciler reconciles all the 3scale components of an APIManager
+// in deployment order: images, external or internal dependencies, backend, memcached,
+// system searchd, system, zync, apicast, ingresses, network policies and generic monitoring resources.
+// The maintenance mode and the secret rotation are reconciled last, once the components exist
+type APIManagerLogicReconciler struct {
+	*BaseAPIManagerLogicReconciler
+}
+
+func NewAPIManagerLogicReconciler(baseAPIManagerLogicReconciler *BaseAPIManagerLogicReconciler) *APIManagerLogicReconciler {
+	return &APIManagerLogicReconciler{
+		BaseAPIManagerLogicReconciler: baseAPIManagerLogicReconciler,
+	}
+}
+
+func (r *APIManagerLogicReconciler) Reconcile() (reconcile.Result, error) {
+	componentReconcilers := []DependencyReconciler{
+		NewAMPImagesReconciler(r.BaseAPIManagerLogicReconciler),
+		r.dependencyReconcilerForComponents(),
+		NewBackendReconciler(r.BaseAPIManagerLogicReconciler),
+		NewMemcachedReconciler(r.BaseAPIManagerLogicReconciler),
+		NewSystemSearchdReconciler(r.BaseAPIManagerLogicReconciler),
+		NewSystemReconciler(r.BaseAPIManagerLogicReconciler),
+		NewZyncReconciler(r.BaseAPIManagerLogicReconciler),
+		NewApicastReconciler(r.BaseAPIManagerLogicReconciler),
+		NewIngressReconciler(r.BaseAPIManagerLogicReconciler),
+		NewCertManagerReconciler(r.BaseAPIManagerLogicReconciler),
+		NewNetworkPoliciesReconciler(r.BaseAPIManagerLogicReconciler),
+		NewGenericMonitoringReconciler(r.BaseAPIManagerLogicReconciler),
+		NewMaintenanceModeReconciler(r.BaseAPIManagerLogicReconciler),
+		NewSecretRotationReconciler(r.BaseAPIManagerLogicReconciler),
+	}
+
+	return (&CompositeDependencyReconciler{Reconcilers: componentReconcilers}).Reconcile()
+}
+
+func (r *APIManagerLogicReconciler) dependencyReconcilerForComponents() DependencyReconciler {
+	cr := r.apiManager
+
+	// Helper type that contains the constructors for a dependency reconciler
+	// whether it's external or internal
+	type constructors struct {
+		External DependencyReconcilerConstructor
+		Internal DependencyReconcilerConstructor
+	}
+
+	// Helper function that instantiates a dependency reconciler depending
+	// on whether it's external or internal
+	selectReconciler := func(cs constructors, selectIsExternal func(*appsv1alpha1.ExternalComponentsSpec) bool) DependencyReconciler {
+		constructor := cs.Internal
+		if selectIsExternal(cr.Spec.ExternalComponents) {
+			constructor = cs.External
+		}
+
+		return constructor(r.BaseAPIManagerLogicReconciler)
+	}
+
+	// Select whether to use PostgreSQL or MySQL for the System database
+	var systemDatabaseReconcilerConstructor DependencyReconcilerConstructor
+	if cr.Spec.System.DatabaseSpec != nil && cr.Spec.System.DatabaseSpec.PostgreSQL != nil {
+		systemDatabaseReconcilerConstructor = CompositeDependencyReconcilerConstructor(
+			NewSystemPostgreSQLReconciler,
+			NewSystemPostgreSQLImageReconciler,
+		)
+	} else {
+		systemDatabaseReconcilerConstructor = CompositeDependencyReconcilerConstructor(
+			NewSystemMySQLReconciler,
+			NewSystemMySQLImageReconciler,
+		)
+	}
+
+	systemDatabaseConstructors := constructors{
+		External: NewSystemExternalDatabaseReconciler,
+		Internal: systemDatabaseReconcilerConstructor,
+	}
+	systemRedisConstructors := constructors{
+		External: NewSystemExternalRedisReconciler,
+		Internal: NewSystemRedisDependencyReconciler,
+	}
+	backendRedisConstructors := constructors{
+		External: NewBackendExternalRedisReconciler,
+		Internal: NewBackendRedisDependencyReconciler,
+	}
+
+	// Build the final reconciler composed by the chosen external/internal
+	// combination
+	result := []DependencyReconciler{
+		selectReconciler(systemDatabaseConstructors, appsv1alpha1.SystemDatabase),
+		selectReconciler(systemRedisConstructors, appsv1alpha1.SystemRedis),
+		selectReconciler(backendRedisConstructors, appsv1alpha1.BackendRedis),
+	}
+
+	return &CompositeDependencyReconciler{
+		Reconcilers: result,
+	}
+}
@@ -1,23 +1,52 @@
 package operator
 
 import (
-	"fmt"
 	"reflect"
 	"strings"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 )
 
 const (
 	APIManagerSecretLabelPrefix = "secret.apimanager.apps.3scale.net/"
 	APIManagerSecretLabelValue  = "true"
+
+	// SystemSecretLabelPrefix labels the APIManager with the UIDs of the secrets
+	// referenced by the system SMTP, reCAPTCHA and events hook configuration
+	SystemSecretLabelPrefix = "system.secret.apimanager.apps.3scale.net/"
 )
 
-func apimanagerSecretLabelKey(uid string) string {
-	return fmt.Sprintf("%s%s", APIManagerSecretLabelPrefix, uid)
+// horizontalPodAutoscalerOptions returns the HPA component options from the APIManager HPA spec
+func horizontalPodAutoscalerOptions(spec *appsv1alpha1.HorizontalPodAutoscalerSpec) *component.HorizontalPodAutoscalerOptions {
+	opts := &component.HorizontalPodAutoscalerOptions{
+		MinReplicas:              component.DefaultHPAMinReplicas,
+		MaxReplicas:              spec.MaxReplicas,
+		CPUAverageUtilization:    spec.CPUAverageUtilization,
+		MemoryAverageUtilization: spec.MemoryAverageUtilization,
+	}
+
+	if spec.MinReplicas != nil {
+		opts.MinReplicas = *spec.MinReplicas
+	}
+
+	for _, podsMetric := range spec.PodsMetrics {
+		opts.PodsMetrics = append(opts.PodsMetrics, component.HorizontalPodAutoscalerPodsMetric{
+			Name:         podsMetric.Name,
+			AverageValue: podsMetric.AverageValue,
+		})
+	}
+
+	return opts
 }
 
 func replaceAPIManagerSecretLabels(apimanager *appsv1alpha1.APIManager, desiredSecretUIDs []string) bool {
+	return replaceAPIManagerPrefixedSecretLabels(apimanager, APIManagerSecretLabelPrefix, desiredSecretUIDs)
+}
+
+// replaceAPIManagerPrefixedSecretLabels replaces the secret labels with the given prefix.
+// Each prefix is owned by a single reconciler, so they do not remove each other's labels
+func replaceAPIManagerPrefixedSecretLabels(apimanager *appsv1alpha1.APIManager, prefix string, desiredSecretUIDs []string) bool {
 
 	existingLabels := apimanager.GetLabels()
 
@@ -29,7 +58,7 @@ func replaceAPIManagerSecretLabels(apimanager *appsv1alpha1.APIManager, desiredS
 
 	// existing Secret UIDs not included in desiredAPIUIDs are deleted
 	for k := range existingLabels {
-		if strings.HasPrefix(k, APIManagerSecretLabelPrefix) {
+		if strings.HasPrefix(k, prefix) {
 			existingSecretLabels[k] = APIManagerSecretLabelValue
 			// it is safe to remove keys while looping in range
 			delete(existingLabels, k)
@@ -38,8 +67,8 @@ func replaceAPIManagerSecretLabels(apimanager *appsv1alpha1.APIManager, desiredS
 
 	desiredSecretLabels := map[string]string{}
 	for _, uid := range desiredSecretUIDs {
-		desiredSecretLabels[apimanagerSecretLabelKey(uid)] = APIManagerSecretLabelValue
-		existingLabels[apimanagerSecretLabelKey(uid)] = APIManagerSecretLabelValue
+		desiredSecretLabels[prefix+uid] = APIManagerSecretLabelValue
+		existingLabels[prefix+uid] = APIManagerSecretLabelValue
 	}
 
 	apimanager.SetLabels(existingLabels)
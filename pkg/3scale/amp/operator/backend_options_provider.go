@@ -6,6 +6,7 @@ import (
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
+	oprand "github.com/3scale/3scale-operator/pkg/crypto/rand"
 	"github.com/3scale/3scale-operator/pkg/helper"
 
 	v1 "k8s.io/api/core/v1"
@@ -13,20 +14,22 @@ import (
 )
 
 type OperatorBackendOptionsProvider struct {
-	apimanager     *appsv1alpha1.APIManager
-	namespace      string
-	client         client.Client
-	backendOptions *component.BackendOptions
-	secretSource   *helper.SecretSource
+	apimanager      *appsv1alpha1.APIManager
+	namespace       string
+	client          client.Client
+	backendOptions  *component.BackendOptions
+	secretSource    *helper.SecretSource
+	randomGenerator *oprand.Generator
 }
 
-func NewOperatorBackendOptionsProvider(apimanager *appsv1alpha1.APIManager, namespace string, client client.Client) *OperatorBackendOptionsProvider {
+func NewOperatorBackendOptionsProvider(apimanager *appsv1alpha1.APIManager, namespace string, client client.Client, randomGenerator *oprand.Generator) *OperatorBackendOptionsProvider {
 	return &OperatorBackendOptionsProvider{
-		apimanager:     apimanager,
-		namespace:      namespace,
-		client:         client,
-		backendOptions: component.NewBackendOptions(),
-		secretSource:   helper.NewSecretSource(client, namespace),
+		apimanager:      apimanager,
+		namespace:       namespace,
+		client:          client,
+		backendOptions:  component.NewBackendOptions(),
+		secretSource:    helper.NewSecretSource(client, namespace),
+		randomGenerator: randomGenerator,
 	}
 }
 
@@ -46,6 +49,10 @@ func (o *OperatorBackendOptionsProvider) GetBackendOptions() (*component.Backend
 	o.setPriorityClassNames()
 	o.setTopologySpreadConstraints()
 	o.setPodTemplateAnnotations()
+	err = o.setEventsHookConfigHashAnnotation()
+	if err != nil {
+		return nil, err
+	}
 
 	o.backendOptions.CommonLabels = o.commonLabels()
 	o.backendOptions.CommonListenerLabels = o.commonListenerLabels()
@@ -83,7 +90,7 @@ func (o *OperatorBackendOptionsProvider) setSecretBasedOptions() error {
 			&o.backendOptions.SystemBackendPassword,
 			component.BackendSecretInternalApiSecretName,
 			component.BackendSecretInternalApiPasswordFieldName,
-			component.DefaultSystemBackendPassword(),
+			component.DefaultSystemBackendPassword(o.randomGenerator),
 		},
 		{
 			&o.backendOptions.ServiceEndpoint,
@@ -149,11 +156,19 @@ func (o *OperatorBackendOptionsProvider) setReplicas() {
 	if o.apimanager.Spec.Backend.ListenerSpec.Replicas != nil {
 		o.backendOptions.ListenerReplicas = int32(*o.apimanager.Spec.Backend.ListenerSpec.Replicas)
 	}
+	if o.apimanager.IsBackendListenerHPAEnabled() {
+		o.backendOptions.ListenerHPA = horizontalPodAutoscalerOptions(o.apimanager.Spec.Backend.ListenerSpec.HPA)
+		o.backendOptions.ListenerReplicas = o.backendOptions.ListenerHPA.MinReplicas
+	}
 
 	o.backendOptions.WorkerReplicas = 1
 	if o.apimanager.Spec.Backend.WorkerSpec.Replicas != nil {
 		o.backendOptions.WorkerReplicas = int32(*o.apimanager.Spec.Backend.WorkerSpec.Replicas)
 	}
+	if o.apimanager.IsBackendWorkerHPAEnabled() {
+		o.backendOptions.WorkerHPA = horizontalPodAutoscalerOptions(o.apimanager.Spec.Backend.WorkerSpec.HPA)
+		o.backendOptions.WorkerReplicas = o.backendOptions.WorkerHPA.MinReplicas
+	}
 
 	o.backendOptions.CronReplicas = 1
 	if o.apimanager.Spec.Backend.CronSpec.Replicas != nil {
@@ -252,6 +267,18 @@ func (o *OperatorBackendOptionsProvider) setPodTemplateAnnotations() {
 	o.backendOptions.CronPodTemplateAnnotations = o.apimanager.Spec.Backend.CronSpec.Annotations
 }
 
+// setEventsHookConfigHashAnnotation rolls out backend-worker, which calls the events hook,
+// when the events hook configuration declared in the APIManager changes
+func (o *OperatorBackendOptionsProvider) setEventsHookConfigHashAnnotation() error {
+	hash, err := EventsHookConfigHash(o.apimanager, o.client)
+	if err != nil || hash == "" {
+		return err
+	}
+
+	o.backendOptions.WorkerPodTemplateAnnotations = podTemplateAnnotationsWith(o.backendOptions.WorkerPodTemplateAnnotations, EventsHookConfigHashAnnotation, hash)
+	return nil
+}
+
 func (o *OperatorBackendOptionsProvider) setTopologySpreadConstraints() {
 	if o.apimanager.Spec.Backend.ListenerSpec.TopologySpreadConstraints != nil {
 		o.backendOptions.TopologySpreadConstraintsListener = o.apimanager.Spec.Backend.ListenerSpec.TopologySpreadConstraints
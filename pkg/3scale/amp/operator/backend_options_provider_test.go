@@ -8,6 +8,7 @@ import (
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
+	oprand "github.com/3scale/3scale-operator/pkg/crypto/rand"
 	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -306,6 +307,33 @@ func TestGetBackendOptionsProvider(t *testing.T) {
 				return opts
 			},
 		},
+		{"WithHPA", nil, nil,
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanagerTestBackendOptions()
+				apimanager.Spec.Backend.WorkerSpec.HPA = &appsv1alpha1.HorizontalPodAutoscalerSpec{
+					Enabled:     true,
+					MinReplicas: &[]int32{2}[0],
+					MaxReplicas: 10,
+					PodsMetrics: []appsv1alpha1.HorizontalPodAutoscalerPodsMetricSpec{
+						{Name: "apisonator_worker_job_count", AverageValue: resource.MustParse("100")},
+					},
+				}
+				return apimanager
+			},
+			func(in *component.BackendOptions) *component.BackendOptions {
+				opts := defaultBackendOptions(in)
+
+				opts.WorkerReplicas = 2
+				opts.WorkerHPA = &component.HorizontalPodAutoscalerOptions{
+					MinReplicas: 2,
+					MaxReplicas: 10,
+					PodsMetrics: []component.HorizontalPodAutoscalerPodsMetric{
+						{Name: "apisonator_worker_job_count", AverageValue: resource.MustParse("100")},
+					},
+				}
+				return opts
+			},
+		},
 		{"WithBackendCustomResourceRequirementsAndGlobalResourceRequirementsDisabled", nil, nil,
 			func() *appsv1alpha1.APIManager {
 				apimanager := basicApimanagerTestBackendOptions()
@@ -337,7 +365,7 @@ func TestGetBackendOptionsProvider(t *testing.T) {
 			}
 
 			cl := fake.NewFakeClient(objs...)
-			optsProvider := NewOperatorBackendOptionsProvider(tc.apimanagerFactory(), namespace, cl)
+			optsProvider := NewOperatorBackendOptionsProvider(tc.apimanagerFactory(), namespace, cl, oprand.DefaultGenerator())
 			opts, err := optsProvider.GetBackendOptions()
 			if err != nil {
 				t.Error(err)
@@ -3,6 +3,8 @@ package operator
 import (
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/common"
+	oprand "github.com/3scale/3scale-operator/pkg/crypto/rand"
 	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 
@@ -21,7 +23,7 @@ func NewBackendReconciler(baseAPIManagerLogicReconciler *BaseAPIManagerLogicReco
 }
 
 func (r *BackendReconciler) Reconcile() (reconcile.Result, error) {
-	backend, err := Backend(r.apiManager, r.Client())
+	backend, err := Backend(r.apiManager, r.Client(), r.randomGenerator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -39,7 +41,8 @@ func (r *BackendReconciler) Reconcile() (reconcile.Result, error) {
 
 	// Listener DC
 	listenerConfigMutator := reconcilers.GenericBackendMutators()
-	if r.apiManager.Spec.Backend.ListenerSpec.Replicas != nil {
+	// Replicas are managed by the HPA when enabled
+	if r.apiManager.Spec.Backend.ListenerSpec.Replicas != nil && !r.apiManager.IsBackendListenerHPAEnabled() {
 		listenerConfigMutator = append(listenerConfigMutator, reconcilers.DeploymentConfigReplicasMutator)
 	}
 
@@ -62,7 +65,8 @@ func (r *BackendReconciler) Reconcile() (reconcile.Result, error) {
 
 	// Worker DC
 	workerConfigMutator := reconcilers.GenericBackendMutators()
-	if r.apiManager.Spec.Backend.WorkerSpec.Replicas != nil {
+	// Replicas are managed by the HPA when enabled
+	if r.apiManager.Spec.Backend.WorkerSpec.Replicas != nil && !r.apiManager.IsBackendWorkerHPAEnabled() {
 		workerConfigMutator = append(workerConfigMutator, reconcilers.DeploymentConfigReplicasMutator)
 	}
 
@@ -107,6 +111,26 @@ func (r *BackendReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
+	// Worker HPA
+	workerHPA := backend.WorkerHorizontalPodAutoscaler()
+	if !r.apiManager.IsBackendWorkerHPAEnabled() {
+		common.TagObjectToDelete(workerHPA)
+	}
+	err = r.ReconcileHorizontalPodAutoscaler(workerHPA, reconcilers.GenericHPAMutator)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// Listener HPA
+	listenerHPA := backend.ListenerHorizontalPodAutoscaler()
+	if !r.apiManager.IsBackendListenerHPAEnabled() {
+		common.TagObjectToDelete(listenerHPA)
+	}
+	err = r.ReconcileHorizontalPodAutoscaler(listenerHPA, reconcilers.GenericHPAMutator)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	err = r.ReconcilePodMonitor(backend.BackendWorkerPodMonitor(), reconcilers.CreateOnlyMutator)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -140,8 +164,8 @@ func (r *BackendReconciler) Reconcile() (reconcile.Result, error) {
 	return reconcile.Result{}, nil
 }
 
-func Backend(apimanager *appsv1alpha1.APIManager, client client.Client) (*component.Backend, error) {
-	optsProvider := NewOperatorBackendOptionsProvider(apimanager, apimanager.Namespace, client)
+func Backend(apimanager *appsv1alpha1.APIManager, client client.Client, randomGenerator *oprand.Generator) (*component.Backend, error) {
+	optsProvider := NewOperatorBackendOptionsProvider(apimanager, apimanager.Namespace, client, randomGenerator)
 	opts, err := optsProvider.GetBackendOptions()
 	if err != nil {
 		return nil, err
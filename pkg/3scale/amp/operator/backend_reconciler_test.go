@@ -12,8 +12,10 @@ import (
 	configv1 "github.com/openshift/api/config/v1"
 	imagev1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -220,6 +222,93 @@ func TestReplicaBackendReconciler(t *testing.T) {
 	}
 }
 
+func TestHPABackendReconciler(t *testing.T) {
+	var (
+		namespace        = "operator-unittest"
+		log              = logf.Log.WithName("operator_test")
+		oneValue64 int64 = 1
+		fiveValue  int32 = 5
+	)
+	ctx := context.TODO()
+	s := scheme.Scheme
+
+	err := appsv1alpha1.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = appsv1.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = routev1.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	apimanager := backendApiManagerCreator(nil, nil, &oneValue64)
+	apimanager.Spec.Backend.WorkerSpec.HPA = &appsv1alpha1.HorizontalPodAutoscalerSpec{
+		Enabled:     true,
+		MaxReplicas: 10,
+	}
+
+	objs := []runtime.Object{apimanager}
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+	baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, clientAPIReader, log, clientset.Discovery(), recorder)
+	baseAPIManagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager)
+
+	backendReconciler := NewBackendReconciler(baseAPIManagerLogicReconciler)
+	_, err = backendReconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: "backend-worker", Namespace: namespace}, hpa)
+	if err != nil {
+		t.Fatalf("error fetching worker HPA: %v", err)
+	}
+	if hpa.Spec.ScaleTargetRef.Kind != "DeploymentConfig" || hpa.Spec.ScaleTargetRef.Name != "backend-worker" {
+		t.Errorf("unexpected HPA scale target: %v", hpa.Spec.ScaleTargetRef)
+	}
+	if hpa.Spec.MaxReplicas != 10 {
+		t.Errorf("unexpected HPA max replicas: %d", hpa.Spec.MaxReplicas)
+	}
+
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: "backend-listener", Namespace: namespace}, &autoscalingv2.HorizontalPodAutoscaler{})
+	if !errors.IsNotFound(err) {
+		t.Errorf("listener HPA not expected, got: %v", err)
+	}
+
+	// The autoscaler scales the dc: replicas are not reconciled
+	dc := &appsv1.DeploymentConfig{}
+	namespacedName := types.NamespacedName{Name: "backend-worker", Namespace: namespace}
+	err = cl.Get(context.TODO(), namespacedName, dc)
+	if err != nil {
+		t.Fatalf("error fetching worker dc: %v", err)
+	}
+	dc.Spec.Replicas = fiveValue
+	err = cl.Update(context.TODO(), dc)
+	if err != nil {
+		t.Fatalf("error updating worker dc: %v", err)
+	}
+
+	_, err = backendReconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = cl.Get(context.TODO(), namespacedName, dc)
+	if err != nil {
+		t.Fatalf("error fetching worker dc: %v", err)
+	}
+	if dc.Spec.Replicas != fiveValue {
+		t.Errorf("expected replicas do not match. expected: %d actual: %d", fiveValue, dc.Spec.Replicas)
+	}
+}
+
 func backendApiManagerCreator(listenerReplicas, cronReplicas, workerReplicas *int64) *appsv1alpha1.APIManager {
 	var (
 		name           = "example-apimanager"
@@ -4,7 +4,10 @@ import (
 	"fmt"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	certmanagerv1 "github.com/3scale/3scale-operator/pkg/certmanager/v1"
 	"github.com/3scale/3scale-operator/pkg/common"
+	oprand "github.com/3scale/3scale-operator/pkg/crypto/rand"
 	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 
@@ -14,11 +17,18 @@ import (
 	imagev1 "github.com/openshift/api/image/v1"
 	routev1 "github.com/openshift/api/route/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type BaseAPIManagerLogicReconciler struct {
@@ -26,6 +36,8 @@ type BaseAPIManagerLogicReconciler struct {
 	apiManager           *appsv1alpha1.APIManager
 	logger               logr.Logger
 	crdAvailabilityCache *baseAPIManagerLogicReconcilerCRDAvailabilityCache
+	imageStreamImages    map[string]string
+	randomGenerator      *oprand.Generator
 }
 
 type baseAPIManagerLogicReconcilerCRDAvailabilityCache struct {
@@ -33,6 +45,7 @@ type baseAPIManagerLogicReconcilerCRDAvailabilityCache struct {
 	prometheusRuleCRDAvailable   *bool
 	podMonitorCRDAvailable       *bool
 	serviceMonitorCRDAvailable   *bool
+	certificateCRDAvailable      *bool
 }
 
 func NewBaseAPIManagerLogicReconciler(b *reconcilers.BaseReconciler, apiManager *appsv1alpha1.APIManager) *BaseAPIManagerLogicReconciler {
@@ -41,9 +54,16 @@ func NewBaseAPIManagerLogicReconciler(b *reconcilers.BaseReconciler, apiManager
 		apiManager:           apiManager,
 		logger:               b.Logger().WithValues("APIManager Controller", apiManager.Name),
 		crdAvailabilityCache: &baseAPIManagerLogicReconcilerCRDAvailabilityCache{},
+		randomGenerator:      oprand.DefaultGenerator(),
 	}
 }
 
+// SetRandomGenerator sets the generator of the values generated when not found in the cluster,
+// like the passwords and the tokens of the secrets
+func (r *BaseAPIManagerLogicReconciler) SetRandomGenerator(randomGenerator *oprand.Generator) {
+	r.randomGenerator = randomGenerator
+}
+
 func (r *BaseAPIManagerLogicReconciler) NamespacedNameWithAPIManagerNamespace(obj metav1.Object) types.NamespacedName {
 	return types.NamespacedName{Namespace: r.apiManager.GetNamespace(), Name: obj.GetName()}
 }
@@ -55,14 +75,207 @@ func (r *BaseAPIManagerLogicReconciler) ReconcilePodDisruptionBudget(desired *po
 	return r.ReconcileResource(&policyv1.PodDisruptionBudget{}, desired, mutatefn)
 }
 
+// ReconcileHorizontalPodAutoscaler reconciles the desired HPA. The scale target
+// is the Deployment replacing the DeploymentConfig when Kubernetes Deployments are enabled
+func (r *BaseAPIManagerLogicReconciler) ReconcileHorizontalPodAutoscaler(desired *autoscalingv2.HorizontalPodAutoscaler, mutatefn reconcilers.MutateFn) error {
+	if r.apiManager.IsKubernetesDeploymentEnabled() && desired.Spec.ScaleTargetRef.Kind == "DeploymentConfig" {
+		desired.Spec.ScaleTargetRef.Kind = "Deployment"
+		desired.Spec.ScaleTargetRef.APIVersion = k8sappsv1.SchemeGroupVersion.String()
+	}
+	return r.ReconcileResource(&autoscalingv2.HorizontalPodAutoscaler{}, desired, mutatefn)
+}
+
+// ReconcileImagestream reconciles the desired ImageStream with the APIManager
+// registry mirrors and pinned digests applied. When Kubernetes Deployments
+// are enabled ImageStreams are skipped if the cluster does not support them,
+// as Deployments get the images directly
 func (r *BaseAPIManagerLogicReconciler) ReconcileImagestream(desired *imagev1.ImageStream, mutatefn reconcilers.MutateFn) error {
+	SetImageStreamImageURLs(r.apiManager, desired)
+	if r.apiManager.IsKubernetesDeploymentEnabled() {
+		hasImageStreams, err := r.HasImageStreams()
+		if err != nil {
+			return err
+		}
+		if !hasImageStreams {
+			return nil
+		}
+	}
 	return r.ReconcileResource(&imagev1.ImageStream{}, desired, mutatefn)
 }
 
+// ReconcileDeploymentConfig reconciles the desired DeploymentConfig or,
+// when Kubernetes Deployments are enabled, the equivalent Deployment.
+// The component pod customization is merged into the desired state.
+// Components scaled down by the maintenance mode keep zero replicas
 func (r *BaseAPIManagerLogicReconciler) ReconcileDeploymentConfig(desired *appsv1.DeploymentConfig, mutatefn reconcilers.MutateFn) error {
+	ApplyPodCustomization(desired, PodCustomization(r.apiManager, desired.Name))
+	mutatefn = MaintenanceModeMutator(mutatefn)
+	if r.apiManager.IsKubernetesDeploymentEnabled() {
+		return r.reconcileDeploymentFromDeploymentConfig(desired, mutatefn)
+	}
 	return r.ReconcileResource(&appsv1.DeploymentConfig{}, desired, mutatefn)
 }
 
+// reconcileDeploymentFromDeploymentConfig reconciles the Deployment equivalent to the desired DeploymentConfig.
+// An existing DeploymentConfig with the same name is migrated:
+//   - Rolling strategy: the Deployment is created with the same replicas and the DeploymentConfig
+//     is deleted once the Deployment is available. Services select both by label meanwhile.
+//   - Recreate strategy: the DeploymentConfig is scaled down before creating the Deployment,
+//     as both would share the same volumes.
+//
+// The Deployment is only created or updated once the pre lifecycle hook job
+// of the desired pod template is done, see reconcileDeploymentPreHookJob.
+func (r *BaseAPIManagerLogicReconciler) reconcileDeploymentFromDeploymentConfig(desiredDC *appsv1.DeploymentConfig, mutatefn reconcilers.MutateFn) error {
+	images, err := r.ImageStreamImages()
+	if err != nil {
+		return err
+	}
+
+	desired, err := helper.DeploymentFromDeploymentConfig(desiredDC, images)
+	if err != nil {
+		return err
+	}
+
+	existingDC := &appsv1.DeploymentConfig{}
+	err = r.Client().Get(r.Context(), r.NamespacedNameWithAPIManagerNamespace(desiredDC), existingDC)
+	// There is nothing to migrate when the cluster does not support DeploymentConfigs
+	if err != nil && !errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return err
+	}
+	migrating := err == nil && metav1.IsControlledBy(existingDC, r.apiManager) && !helper.IsDeploymentConfigDeleting(existingDC)
+	recreate := desired.Spec.Strategy.Type == k8sappsv1.RecreateDeploymentStrategyType
+	deleting := common.IsObjectTaggedToDelete(desired)
+
+	if migrating && !deleting {
+		if recreate {
+			if existingDC.Spec.Replicas != 0 {
+				r.logger.Info("Scaling down DeploymentConfig to migrate it to Deployment", "name", existingDC.Name)
+				existingDC.Spec.Replicas = 0
+				return r.UpdateResource(existingDC)
+			}
+
+			if existingDC.Status.Replicas != 0 {
+				r.logger.Info("Waiting for DeploymentConfig pods to terminate to migrate it to Deployment", "name", existingDC.Name)
+				return nil
+			}
+		} else {
+			desired.Spec.Replicas = &[]int32{existingDC.Spec.Replicas}[0]
+		}
+	}
+
+	if !deleting {
+		done, err := r.reconcileDeploymentPreHookJob(desiredDC, desired)
+		if err != nil || !done {
+			return err
+		}
+	}
+
+	err = r.ReconcileResource(&k8sappsv1.Deployment{}, desired, mutatefn)
+	if err != nil {
+		return err
+	}
+
+	if !migrating {
+		return nil
+	}
+
+	if !deleting && !recreate {
+		existing := &k8sappsv1.Deployment{}
+		err = r.Client().Get(r.Context(), client.ObjectKeyFromObject(desired), existing)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+
+		if err != nil || !helper.IsDeploymentAvailable(existing) {
+			r.logger.Info("Waiting for Deployment to be available to delete DeploymentConfig", "name", existingDC.Name)
+			return nil
+		}
+	}
+
+	err = r.DeleteResource(existingDC)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	if !deleting {
+		r.EventRecorder().Eventf(r.apiManager, v1.EventTypeNormal, "DeploymentConfigMigrated",
+			"DeploymentConfig %s migrated to Deployment", existingDC.Name)
+	}
+
+	return nil
+}
+
+// reconcileDeploymentPreHookJob runs the DeploymentConfig pre lifecycle hook as a job,
+// once per desired pod template. The job of a previous pod template is replaced.
+// Returns true once the job of the desired pod template is done: completed, or failed
+// with the Ignore failure policy. Failed jobs are run again with the Retry failure policy,
+// while the Abort failure policy keeps the Deployment on the previous pod template
+func (r *BaseAPIManagerLogicReconciler) reconcileDeploymentPreHookJob(desiredDC *appsv1.DeploymentConfig, desired *k8sappsv1.Deployment) (bool, error) {
+	if desiredDC.Spec.Strategy.RollingParams == nil {
+		return true, nil
+	}
+
+	hook := desiredDC.Spec.Strategy.RollingParams.Pre
+	// The hook pods are labeled as the DeploymentConfig hook pods, allowed by the network policies
+	podLabels := map[string]string{component.DeploymentConfigHookPodTypeLabel: "hook-pre"}
+	desiredJob, err := helper.DeploymentPreHookJob(desired, hook, podLabels)
+	if err != nil {
+		return false, err
+	}
+	if desiredJob == nil {
+		return true, nil
+	}
+	desiredJob.Namespace = r.apiManager.Namespace
+
+	job := &batchv1.Job{}
+	err = r.Client().Get(r.Context(), client.ObjectKeyFromObject(desiredJob), job)
+	if errors.IsNotFound(err) {
+		if err := r.SetOwnerReference(r.apiManager, desiredJob); err != nil {
+			return false, err
+		}
+		r.logger.Info("Running Deployment pre hook job", "name", desiredJob.Name)
+		return false, r.CreateResource(desiredJob)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case job.Annotations[helper.DeploymentPreHookTemplateHashAnnotation] != desiredJob.Annotations[helper.DeploymentPreHookTemplateHashAnnotation]:
+		// Recreated on the next reconciliation, triggered by the job deletion
+		r.logger.Info("Deleting Deployment pre hook job of a previous pod template", "name", job.Name)
+		return false, r.DeleteResource(job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	case helper.JobConditionTrue(job, batchv1.JobComplete):
+		return true, nil
+	case helper.JobConditionTrue(job, batchv1.JobFailed):
+		switch hook.FailurePolicy {
+		case appsv1.LifecycleHookFailurePolicyIgnore:
+			return true, nil
+		case appsv1.LifecycleHookFailurePolicyRetry:
+			r.logger.Info("Deployment pre hook job failed. Retrying", "name", job.Name)
+			return false, r.DeleteResource(job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		}
+		r.logger.Info("Deployment pre hook job failed. Deployment not updated", "name", job.Name)
+		return false, nil
+	}
+
+	r.logger.Info("Waiting for Deployment pre hook job to complete", "name", job.Name)
+	return false, nil
+}
+
+// ImageStreamImages returns the image URLs of the APIManager imagestreams, indexed by imagestream name
+func (r *BaseAPIManagerLogicReconciler) ImageStreamImages() (map[string]string, error) {
+	if r.imageStreamImages == nil {
+		images, err := ImageStreamImages(r.apiManager, r.Client())
+		if err != nil {
+			return nil, err
+		}
+		r.imageStreamImages = images
+	}
+
+	return r.imageStreamImages, nil
+}
+
 func (r *BaseAPIManagerLogicReconciler) ReconcileService(desired *v1.Service, mutateFn reconcilers.MutateFn) error {
 	return r.ReconcileResource(&v1.Service{}, desired, mutateFn)
 }
@@ -75,10 +288,35 @@ func (r *BaseAPIManagerLogicReconciler) ReconcileServiceAccount(desired *v1.Serv
 	return r.ReconcileResource(&v1.ServiceAccount{}, desired, mutateFn)
 }
 
+// ReconcileRoute reconciles the desired Route. When ingresses are enabled
+// the Route is deleted, if the cluster supports Routes at all
 func (r *BaseAPIManagerLogicReconciler) ReconcileRoute(desired *routev1.Route, mutateFn reconcilers.MutateFn) error {
+	if r.apiManager.IsIngressEnabled() {
+		hasRoutes, err := r.HasRoutes()
+		if err != nil {
+			return err
+		}
+		if !hasRoutes {
+			return nil
+		}
+		common.TagObjectToDelete(desired)
+	}
 	return r.ReconcileResource(&routev1.Route{}, desired, mutateFn)
 }
 
+// ReconcileIngress reconciles the desired Ingress when ingresses are enabled,
+// deleting it otherwise
+func (r *BaseAPIManagerLogicReconciler) ReconcileIngress(desired *networkingv1.Ingress, mutateFn reconcilers.MutateFn) error {
+	if !r.apiManager.IsIngressEnabled() {
+		common.TagObjectToDelete(desired)
+	}
+	return r.ReconcileResource(&networkingv1.Ingress{}, desired, mutateFn)
+}
+
+func (r *BaseAPIManagerLogicReconciler) ReconcileNetworkPolicy(desired *networkingv1.NetworkPolicy, mutateFn reconcilers.MutateFn) error {
+	return r.ReconcileResource(&networkingv1.NetworkPolicy{}, desired, mutateFn)
+}
+
 func (r *BaseAPIManagerLogicReconciler) ReconcileSecret(desired *v1.Secret, mutateFn reconcilers.MutateFn) error {
 	return r.ReconcileResource(&v1.Secret{}, desired, mutateFn)
 }
@@ -179,6 +417,29 @@ func (r *BaseAPIManagerLogicReconciler) ReconcilePodMonitor(desired *monitoringv
 	return r.ReconcileResource(&monitoringv1.PodMonitor{}, desired, mutateFn)
 }
 
+// ReconcileCertificate reconciles the desired cert-manager Certificate when
+// cert-manager is enabled, deleting it otherwise
+func (r *BaseAPIManagerLogicReconciler) ReconcileCertificate(desired *certmanagerv1.Certificate, mutateFn reconcilers.MutateFn) error {
+	kindExists, err := r.HasCertificates()
+	if err != nil {
+		return err
+	}
+
+	if !kindExists {
+		if r.apiManager.IsCertManagerEnabled() {
+			errToLog := fmt.Errorf("Error creating certificate object '%s'. Install cert-manager in your cluster to create certificate objects", desired.Name)
+			r.EventRecorder().Eventf(r.apiManager, v1.EventTypeWarning, "ReconcileError", errToLog.Error())
+			r.logger.Error(errToLog, "ReconcileError")
+		}
+		return nil
+	}
+
+	if !r.apiManager.IsCertManagerEnabled() {
+		common.TagObjectToDelete(desired)
+	}
+	return r.ReconcileResource(&certmanagerv1.Certificate{}, desired, mutateFn)
+}
+
 func (r *BaseAPIManagerLogicReconciler) ReconcileResource(obj, desired common.KubernetesObject, mutatefn reconcilers.MutateFn) error {
 	desired.SetNamespace(r.apiManager.GetNamespace())
 
@@ -282,3 +543,16 @@ func (b *BaseAPIManagerLogicReconciler) HasPodMonitors() (bool, error) {
 	}
 	return *b.crdAvailabilityCache.podMonitorCRDAvailable, nil
 }
+
+// HasCertificates checks if the cert-manager Certificate CRD is supported in current cluster
+func (b *BaseAPIManagerLogicReconciler) HasCertificates() (bool, error) {
+	if b.crdAvailabilityCache.certificateCRDAvailable == nil {
+		res, err := b.BaseReconciler.HasCertificates()
+		if err != nil {
+			return res, err
+		}
+		b.crdAvailabilityCache.certificateCRDAvailable = &res
+		return res, err
+	}
+	return *b.crdAvailabilityCache.certificateCRDAvailable, nil
+}
@@ -5,14 +5,20 @@ import (
 	"testing"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	oprand "github.com/3scale/3scale-operator/pkg/crypto/rand"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 
 	grafanav1alpha1 "github.com/grafana-operator/grafana-operator/v4/api/integreatly/v1alpha1"
 	appsv1 "github.com/openshift/api/apps/v1"
+	imagev1 "github.com/openshift/api/image/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
@@ -502,3 +508,282 @@ func TestBaseAPIManagerLogicReconcilerHasServiceMonitors(t *testing.T) {
 		t.Fatalf("Unexpected exists value received. Expected: %t, got: %t", false, exists)
 	}
 }
+
+func TestBaseAPIManagerLogicReconcilerDeploymentConfigMigration(t *testing.T) {
+	var (
+		log            = logf.Log.WithName("operator_test")
+		deploymentType = appsv1alpha1.DeploymentTypeDeployment
+	)
+
+	ctx := context.TODO()
+
+	apimanager := basicApimanager()
+	apimanager.UID = "apimanager-uid"
+	apimanager.Spec.DeploymentType = &deploymentType
+
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+	if err := appsv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := imagev1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	cl := fake.NewFakeClient(apimanager)
+	clientAPIReader := fake.NewFakeClient(apimanager)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+
+	baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, clientAPIReader, log, clientset.Discovery(), recorder)
+	apimanagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager)
+
+	backend, err := Backend(apimanager, cl, oprand.DefaultGenerator())
+	if err != nil {
+		t.Fatal(err)
+	}
+	redis, err := Redis(apimanager, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createExistingDC := func(desired *appsv1.DeploymentConfig, replicas int32) {
+		existing := desired.DeepCopy()
+		existing.Namespace = apimanager.Namespace
+		existing.Spec.Replicas = replicas
+		existing.Status.Replicas = replicas
+		if err := apimanagerLogicReconciler.SetOwnerReference(apimanager, existing); err != nil {
+			t.Fatal(err)
+		}
+		if err := cl.Create(ctx, existing); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dcExists := func(name string) bool {
+		err := cl.Get(ctx, types.NamespacedName{Name: name, Namespace: apimanager.Namespace}, &appsv1.DeploymentConfig{})
+		if err != nil && !errors.IsNotFound(err) {
+			t.Fatal(err)
+		}
+		return err == nil
+	}
+
+	t.Run("RollingStrategy", func(subT *testing.T) {
+		desiredDC := backend.ListenerDeploymentConfig()
+		createExistingDC(desiredDC, 3)
+		mutator := reconcilers.DeploymentConfigMutator(reconcilers.GenericBackendMutators()...)
+
+		if err := apimanagerLogicReconciler.ReconcileDeploymentConfig(backend.ListenerDeploymentConfig(), mutator); err != nil {
+			subT.Fatal(err)
+		}
+
+		deployment := &k8sappsv1.Deployment{}
+		if err := cl.Get(ctx, types.NamespacedName{Name: desiredDC.Name, Namespace: apimanager.Namespace}, deployment); err != nil {
+			subT.Fatal(err)
+		}
+		if deployment.Spec.Replicas == nil || *deployment.Spec.Replicas != 3 {
+			subT.Errorf("deployment replicas do not match existing DeploymentConfig replicas: %v", deployment.Spec.Replicas)
+		}
+		if !dcExists(desiredDC.Name) {
+			subT.Fatal("DeploymentConfig deleted before the Deployment is available")
+		}
+
+		deployment.Status.UpdatedReplicas = 3
+		deployment.Status.Conditions = []k8sappsv1.DeploymentCondition{
+			{Type: k8sappsv1.DeploymentAvailable, Status: v1.ConditionTrue},
+		}
+		if err := cl.Update(ctx, deployment); err != nil {
+			subT.Fatal(err)
+		}
+
+		if err := apimanagerLogicReconciler.ReconcileDeploymentConfig(backend.ListenerDeploymentConfig(), mutator); err != nil {
+			subT.Fatal(err)
+		}
+		if dcExists(desiredDC.Name) {
+			subT.Error("DeploymentConfig not deleted once the Deployment is available")
+		}
+	})
+
+	t.Run("RecreateStrategy", func(subT *testing.T) {
+		desiredDC := redis.BackendDeploymentConfig()
+		createExistingDC(desiredDC, 1)
+		mutator := reconcilers.DeploymentConfigMutator(reconcilers.DeploymentConfigContainerResourcesMutator)
+
+		if err := apimanagerLogicReconciler.ReconcileDeploymentConfig(redis.BackendDeploymentConfig(), mutator); err != nil {
+			subT.Fatal(err)
+		}
+
+		existingDC := &appsv1.DeploymentConfig{}
+		if err := cl.Get(ctx, types.NamespacedName{Name: desiredDC.Name, Namespace: apimanager.Namespace}, existingDC); err != nil {
+			subT.Fatal(err)
+		}
+		if existingDC.Spec.Replicas != 0 {
+			subT.Errorf("DeploymentConfig not scaled down. Replicas: %d", existingDC.Spec.Replicas)
+		}
+
+		// Pods still running
+		if err := apimanagerLogicReconciler.ReconcileDeploymentConfig(redis.BackendDeploymentConfig(), mutator); err != nil {
+			subT.Fatal(err)
+		}
+		err := cl.Get(ctx, types.NamespacedName{Name: desiredDC.Name, Namespace: apimanager.Namespace}, &k8sappsv1.Deployment{})
+		if !errors.IsNotFound(err) {
+			subT.Fatalf("Deployment created before DeploymentConfig pods terminated: %v", err)
+		}
+
+		existingDC.Status.Replicas = 0
+		if err := cl.Update(ctx, existingDC); err != nil {
+			subT.Fatal(err)
+		}
+
+		if err := apimanagerLogicReconciler.ReconcileDeploymentConfig(redis.BackendDeploymentConfig(), mutator); err != nil {
+			subT.Fatal(err)
+		}
+		deployment := &k8sappsv1.Deployment{}
+		if err := cl.Get(ctx, types.NamespacedName{Name: desiredDC.Name, Namespace: apimanager.Namespace}, deployment); err != nil {
+			subT.Fatal(err)
+		}
+		if deployment.Spec.Template.Spec.Containers[0].Image != BackendRedisImageURL() {
+			subT.Errorf("unexpected deployment image: %s", deployment.Spec.Template.Spec.Containers[0].Image)
+		}
+		if dcExists(desiredDC.Name) {
+			subT.Error("DeploymentConfig not deleted")
+		}
+	})
+}
+
+func TestBaseAPIManagerLogicReconcilerDeploymentPreHookJob(t *testing.T) {
+	var (
+		log            = logf.Log.WithName("operator_test")
+		deploymentType = appsv1alpha1.DeploymentTypeDeployment
+	)
+
+	ctx := context.TODO()
+
+	apimanager := basicApimanager()
+	apimanager.UID = "apimanager-uid"
+	apimanager.Spec.DeploymentType = &deploymentType
+
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+	if err := appsv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := imagev1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	cl := fake.NewFakeClient(apimanager)
+	clientAPIReader := fake.NewFakeClient(apimanager)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+
+	baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, clientAPIReader, log, clientset.Discovery(), recorder)
+	apimanagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager)
+
+	desiredDC := func(image string) *appsv1.DeploymentConfig {
+		return &appsv1.DeploymentConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "system-app", Namespace: apimanager.Namespace},
+			Spec: appsv1.DeploymentConfigSpec{
+				Replicas: 1,
+				Selector: map[string]string{"deploymentConfig": "system-app"},
+				Strategy: appsv1.DeploymentStrategy{
+					Type: appsv1.DeploymentStrategyTypeRolling,
+					RollingParams: &appsv1.RollingDeploymentStrategyParams{
+						Pre: &appsv1.LifecycleHook{
+							FailurePolicy: appsv1.LifecycleHookFailurePolicyRetry,
+							ExecNewPod:    &appsv1.ExecNewPodHook{Command: []string{"migrate"}, ContainerName: "master"},
+						},
+					},
+				},
+				Template: &v1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"deploymentConfig": "system-app"}},
+					Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "master", Image: image}}},
+				},
+			},
+		}
+	}
+	mutator := reconcilers.DeploymentConfigMutator(reconcilers.DeploymentConfigImageChangeTriggerMutator)
+	key := types.NamespacedName{Name: "system-app", Namespace: apimanager.Namespace}
+	jobKey := types.NamespacedName{Name: "system-app-pre-hook", Namespace: apimanager.Namespace}
+
+	reconcile := func() {
+		if err := apimanagerLogicReconciler.ReconcileDeploymentConfig(desiredDC("system:new"), mutator); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	setJobCondition := func(conditionType batchv1.JobConditionType) {
+		job := &batchv1.Job{}
+		if err := cl.Get(ctx, jobKey, job); err != nil {
+			t.Fatal(err)
+		}
+		job.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: v1.ConditionTrue}}
+		if err := cl.Update(ctx, job); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deploymentImage := func() string {
+		deployment := &k8sappsv1.Deployment{}
+		err := cl.Get(ctx, key, deployment)
+		if errors.IsNotFound(err) {
+			return ""
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		return deployment.Spec.Template.Spec.Containers[0].Image
+	}
+
+	// The Deployment is created once the pre hook job completes
+	if err := apimanagerLogicReconciler.ReconcileDeploymentConfig(desiredDC("system:old"), mutator); err != nil {
+		t.Fatal(err)
+	}
+	job := &batchv1.Job{}
+	if err := cl.Get(ctx, jobKey, job); err != nil {
+		t.Fatal(err)
+	}
+	if job.Spec.Template.Spec.Containers[0].Image != "system:old" || job.Spec.Template.Labels["deploymentConfig"] != "" {
+		t.Errorf("unexpected pre hook job pod template: %v", job.Spec.Template)
+	}
+	if image := deploymentImage(); image != "" {
+		t.Fatal("Deployment created before the pre hook job completed")
+	}
+	setJobCondition(batchv1.JobComplete)
+	if err := apimanagerLogicReconciler.ReconcileDeploymentConfig(desiredDC("system:old"), mutator); err != nil {
+		t.Fatal(err)
+	}
+	if image := deploymentImage(); image != "system:old" {
+		t.Fatalf("unexpected Deployment image %q", image)
+	}
+
+	// The hook job of a previous pod template is replaced
+	reconcile()
+	if err := cl.Get(ctx, jobKey, &batchv1.Job{}); !errors.IsNotFound(err) {
+		t.Fatalf("expected pre hook job of the previous pod template deleted, got %v", err)
+	}
+	reconcile()
+	if err := cl.Get(ctx, jobKey, job); err != nil {
+		t.Fatal(err)
+	}
+	if job.Spec.Template.Spec.Containers[0].Image != "system:new" {
+		t.Errorf("unexpected pre hook job image: %s", job.Spec.Template.Spec.Containers[0].Image)
+	}
+
+	// Failed jobs are run again with the Retry failure policy
+	setJobCondition(batchv1.JobFailed)
+	reconcile()
+	if err := cl.Get(ctx, jobKey, &batchv1.Job{}); !errors.IsNotFound(err) {
+		t.Fatalf("expected failed pre hook job deleted, got %v", err)
+	}
+	if image := deploymentImage(); image != "system:old" {
+		t.Fatalf("Deployment updated before the pre hook job completed: %q", image)
+	}
+
+	reconcile()
+	setJobCondition(batchv1.JobComplete)
+	reconcile()
+	if image := deploymentImage(); image != "system:new" {
+		t.Fatalf("unexpected Deployment image %q", image)
+	}
+}
@@ -0,0 +1,230 @@
+package operator
+
+import (
+	"context"
+	"sort"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	certmanagerv1 "github.com/3scale/3scale-operator/pkg/certmanager/v1"
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	routev1 "github.com/openshift/api/route/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// RouteCertificateAnnotation is set on the routes serving a certificate issued by cert-manager.
+	// The value is the name of the certificate
+	RouteCertificateAnnotation = "apps.3scale.net/certificate"
+
+	// HTTPSCertificateSecretResverAnnotation rolls out APIcast when cert-manager renews the certificate of the HTTPS port
+	HTTPSCertificateSecretResverAnnotation = "apimanager.apps.3scale.net/https-certificate-secret-resource-version"
+)
+
+type CertManagerReconciler struct {
+	*BaseAPIManagerLogicReconciler
+}
+
+func NewCertManagerReconciler(baseAPIManagerLogicReconciler *BaseAPIManagerLogicReconciler) *CertManagerReconciler {
+	return &CertManagerReconciler{
+		BaseAPIManagerLogicReconciler: baseAPIManagerLogicReconciler,
+	}
+}
+
+func (r *CertManagerReconciler) Reconcile() (reconcile.Result, error) {
+	certificates, err := Certificates(r.apiManager)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	desiredCertificates := certificates.Certificates()
+	for _, desired := range desiredCertificates {
+		if !IsCertificateManaged(r.apiManager, desired.Name) {
+			common.TagObjectToDelete(desired)
+		}
+
+		err = r.ReconcileCertificate(desired, reconcilers.GenericCertificateMutator)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	err = r.reconcileRoutesTLS(desiredCertificates)
+	return reconcile.Result{}, err
+}
+
+// reconcileRoutesTLS sets the issued certificates on the routes serving their hosts.
+// The routes are created by the operator and by zync, so they are matched by host.
+// Routes serving a certificate no longer managed get back the router default certificate
+func (r *CertManagerReconciler) reconcileRoutesTLS(certificates []*certmanagerv1.Certificate) error {
+	hasRoutes, err := r.HasRoutes()
+	if err != nil {
+		return err
+	}
+	if !hasRoutes {
+		return nil
+	}
+
+	certificatesByHost := map[string]*routeCertificate{}
+	for _, certificate := range certificates {
+		if common.IsObjectTaggedToDelete(certificate) {
+			continue
+		}
+
+		secret := &v1.Secret{}
+		err := r.Client().Get(r.Context(), types.NamespacedName{Name: certificate.Spec.SecretName, Namespace: r.apiManager.Namespace}, secret)
+		if errors.IsNotFound(err) {
+			// Not issued yet
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, host := range certificate.Spec.DNSNames {
+			certificatesByHost[host] = &routeCertificate{
+				name:        certificate.Name,
+				certificate: string(secret.Data[v1.TLSCertKey]),
+				key:         string(secret.Data[v1.TLSPrivateKeyKey]),
+			}
+		}
+	}
+
+	routeList := &routev1.RouteList{}
+	err = r.Client().List(r.Context(), routeList, client.InNamespace(r.apiManager.Namespace))
+	if err != nil {
+		return err
+	}
+
+	for idx := range routeList.Items {
+		route := &routeList.Items[idx]
+		if !routeCertificateMutator(route, certificatesByHost[route.Spec.Host]) {
+			continue
+		}
+
+		r.Logger().Info("Updating route certificate", "route", route.Name)
+		err = r.UpdateResource(route)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type routeCertificate struct {
+	name        string
+	certificate string
+	key         string
+}
+
+// routeCertificateMutator sets the certificate on the route. When the certificate is nil,
+// the certificate previously set by the operator is removed
+func routeCertificateMutator(route *routev1.Route, certificate *routeCertificate) bool {
+	if route.Spec.TLS == nil || route.Spec.TLS.Termination == routev1.TLSTerminationPassthrough {
+		return false
+	}
+
+	if certificate == nil {
+		if _, ok := route.Annotations[RouteCertificateAnnotation]; !ok {
+			return false
+		}
+		delete(route.Annotations, RouteCertificateAnnotation)
+		route.Spec.TLS.Certificate = ""
+		route.Spec.TLS.Key = ""
+		return true
+	}
+
+	updated := false
+	if route.Annotations[RouteCertificateAnnotation] != certificate.name {
+		if route.Annotations == nil {
+			route.Annotations = map[string]string{}
+		}
+		route.Annotations[RouteCertificateAnnotation] = certificate.name
+		updated = true
+	}
+
+	if route.Spec.TLS.Certificate != certificate.certificate || route.Spec.TLS.Key != certificate.key {
+		route.Spec.TLS.Certificate = certificate.certificate
+		route.Spec.TLS.Key = certificate.key
+		updated = true
+	}
+
+	return updated
+}
+
+func Certificates(apimanager *appsv1alpha1.APIManager) (*component.Certificates, error) {
+	optsProvider := NewCertificatesOptionsProvider(apimanager)
+	opts, err := optsProvider.GetCertificatesOptions()
+	if err != nil {
+		return nil, err
+	}
+	return component.NewCertificates(opts), nil
+}
+
+// IsCertificateManaged returns true when the operator requests the certificate from cert-manager.
+// APIcast certificates are only requested for HTTPS ports without a user provided certificate
+func IsCertificateManaged(apimanager *appsv1alpha1.APIManager, name string) bool {
+	if !apimanager.IsCertManagerEnabled() {
+		return false
+	}
+
+	switch name {
+	case component.ApicastProductionCertificateName:
+		spec := apimanager.Spec.Apicast.ProductionSpec
+		return spec.HTTPSPort != nil && spec.HTTPSCertificateSecretRef == nil
+	case component.ApicastStagingCertificateName:
+		spec := apimanager.Spec.Apicast.StagingSpec
+		return spec.HTTPSPort != nil && spec.HTTPSCertificateSecretRef == nil
+	}
+
+	return true
+}
+
+// CertificatesStatus returns the state of the certificates requested from cert-manager, sorted by name.
+// Certificates not created yet are not reported
+func CertificatesStatus(apimanager *appsv1alpha1.APIManager, cl client.Client) ([]appsv1alpha1.CertificateStatus, error) {
+	if !apimanager.IsCertManagerEnabled() {
+		return nil, nil
+	}
+
+	certificates, err := Certificates(apimanager)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []appsv1alpha1.CertificateStatus
+	for _, desired := range certificates.Certificates() {
+		if !IsCertificateManaged(apimanager, desired.Name) {
+			continue
+		}
+
+		certificate := &certmanagerv1.Certificate{}
+		err := cl.Get(context.TODO(), types.NamespacedName{Name: desired.Name, Namespace: apimanager.Namespace}, certificate)
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, appsv1alpha1.CertificateStatus{
+			Name:        certificate.Name,
+			SecretName:  certificate.Spec.SecretName,
+			Ready:       certificate.IsReady(),
+			NotAfter:    certificate.Status.NotAfter,
+			RenewalTime: certificate.Status.RenewalTime,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return result, nil
+}
@@ -0,0 +1,250 @@
+package operator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	certmanagerv1 "github.com/3scale/3scale-operator/pkg/certmanager/v1"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	routev1 "github.com/openshift/api/route/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func certManagerTestApimanager() *appsv1alpha1.APIManager {
+	httpsPort := appsv1alpha1.DefaultHTTPSPort
+	apimanager := basicApimanager()
+	apimanager.Spec.Apicast = &appsv1alpha1.ApicastSpec{
+		ProductionSpec: &appsv1alpha1.ApicastProductionSpec{HTTPSPort: &httpsPort},
+		StagingSpec:    &appsv1alpha1.ApicastStagingSpec{},
+	}
+	apimanager.Spec.CertManager = &appsv1alpha1.CertManagerSpec{
+		Enabled: true,
+		IssuerRef: &appsv1alpha1.CertManagerIssuerReference{
+			Name: "letsencrypt",
+			Kind: "ClusterIssuer",
+		},
+	}
+	return apimanager
+}
+
+func certManagerTestScheme(t *testing.T) *runtime.Scheme {
+	s := scheme.Scheme
+	if err := routev1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := certmanagerv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func certManagerTestRoute(name, host string) *routev1.Route {
+	return &routev1.Route{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: routev1.RouteSpec{
+			Host: host,
+			TLS:  &routev1.TLSConfig{Termination: routev1.TLSTerminationEdge},
+		},
+	}
+}
+
+func TestCertManagerReconciler(t *testing.T) {
+	log := logf.Log.WithName("operator_test")
+	ctx := context.TODO()
+	apimanager := certManagerTestApimanager()
+	s := certManagerTestScheme(t)
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+
+	backendSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: component.BackendCertificateName, Namespace: namespace},
+		Type:       v1.SecretTypeTLS,
+		Data: map[string][]byte{
+			v1.TLSCertKey:       []byte("backend-cert"),
+			v1.TLSPrivateKeyKey: []byte("backend-key"),
+		},
+	}
+	objs := []runtime.Object{
+		apimanager,
+		backendSecret,
+		certManagerTestRoute("backend", "backend-someTenant.test.3scale.net"),
+		certManagerTestRoute("zync-3scale-provider-abcde", "someTenant-admin.test.3scale.net"),
+	}
+
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+	clientset := fakeclientset.NewSimpleClientset()
+	clientset.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: certmanagerv1.GroupVersion.String(),
+			APIResources: []metav1.APIResource{{Name: "certificates", Kind: certmanagerv1.CertificateKind}},
+		},
+		{
+			GroupVersion: routev1.GroupVersion.String(),
+			APIResources: []metav1.APIResource{{Name: "routes", Kind: "Route"}},
+		},
+	}
+	recorder := record.NewFakeRecorder(10000)
+
+	baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, clientAPIReader, log, clientset.Discovery(), recorder)
+	baseAPIManagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager)
+
+	reconciler := NewCertManagerReconciler(baseAPIManagerLogicReconciler)
+	_, err := reconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		testName         string
+		objName          string
+		expectedDNSNames []string
+	}{
+		{"apicastProductionCertificate", "apicast-production-tls", []string{"api-someTenant-apicast-production.test.3scale.net"}},
+		{"backendCertificate", "backend-tls", []string{"backend-someTenant.test.3scale.net"}},
+		{"systemCertificate", "system-tls", []string{"master.test.3scale.net", "someTenant-admin.test.3scale.net", "someTenant.test.3scale.net"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			certificate := &certmanagerv1.Certificate{}
+			err := cl.Get(ctx, types.NamespacedName{Name: tc.objName, Namespace: namespace}, certificate)
+			if err != nil {
+				subT.Fatalf("error fetching object %s: %v", tc.objName, err)
+			}
+
+			if certificate.Spec.SecretName != tc.objName {
+				subT.Errorf("unexpected secret name: %s", certificate.Spec.SecretName)
+			}
+			if !reflect.DeepEqual(certificate.Spec.DNSNames, tc.expectedDNSNames) {
+				subT.Errorf("unexpected dns names: %v", certificate.Spec.DNSNames)
+			}
+			expectedIssuerRef := certmanagerv1.ObjectReference{Name: "letsencrypt", Kind: "ClusterIssuer", Group: "cert-manager.io"}
+			if certificate.Spec.IssuerRef != expectedIssuerRef {
+				subT.Errorf("unexpected issuer ref: %v", certificate.Spec.IssuerRef)
+			}
+		})
+	}
+
+	// No HTTPS port on APIcast staging
+	err = cl.Get(ctx, types.NamespacedName{Name: component.ApicastStagingCertificateName, Namespace: namespace}, &certmanagerv1.Certificate{})
+	if !errors.IsNotFound(err) {
+		t.Fatalf("apicast staging certificate not expected, got: %v", err)
+	}
+
+	// Issued certificates are set on the routes serving their hosts
+	route := &routev1.Route{}
+	err = cl.Get(ctx, types.NamespacedName{Name: "backend", Namespace: namespace}, route)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if route.Spec.TLS.Certificate != "backend-cert" || route.Spec.TLS.Key != "backend-key" {
+		t.Errorf("unexpected backend route TLS: %v", route.Spec.TLS)
+	}
+	if route.Annotations[RouteCertificateAnnotation] != component.BackendCertificateName {
+		t.Errorf("unexpected backend route annotations: %v", route.Annotations)
+	}
+
+	// Not issued yet
+	err = cl.Get(ctx, types.NamespacedName{Name: "zync-3scale-provider-abcde", Namespace: namespace}, route)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if route.Spec.TLS.Certificate != "" {
+		t.Errorf("unexpected provider route TLS: %v", route.Spec.TLS)
+	}
+
+	// Disabling cert-manager deletes the certificates and restores the routes
+	apimanager.Spec.CertManager.Enabled = false
+	_, err = reconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificateList := &certmanagerv1.CertificateList{}
+	err = cl.List(ctx, certificateList, client.InNamespace(namespace))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(certificateList.Items) != 0 {
+		t.Errorf("certificates expected to be deleted, got: %d", len(certificateList.Items))
+	}
+
+	err = cl.Get(ctx, types.NamespacedName{Name: "backend", Namespace: namespace}, route)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if route.Spec.TLS.Certificate != "" || route.Spec.TLS.Key != "" {
+		t.Errorf("unexpected backend route TLS: %v", route.Spec.TLS)
+	}
+	if _, ok := route.Annotations[RouteCertificateAnnotation]; ok {
+		t.Errorf("unexpected backend route annotations: %v", route.Annotations)
+	}
+}
+
+func TestCertificatesStatus(t *testing.T) {
+	apimanager := certManagerTestApimanager()
+	s := certManagerTestScheme(t)
+
+	notAfter := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	renewalTime := metav1.NewTime(time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC))
+	backendCertificate := &certmanagerv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Name: component.BackendCertificateName, Namespace: namespace},
+		Spec:       certmanagerv1.CertificateSpec{SecretName: component.BackendCertificateName},
+		Status: certmanagerv1.CertificateStatus{
+			Conditions: []certmanagerv1.CertificateCondition{
+				{Type: certmanagerv1.CertificateConditionReady, Status: metav1.ConditionTrue},
+			},
+			NotAfter:    &notAfter,
+			RenewalTime: &renewalTime,
+		},
+	}
+	systemCertificate := &certmanagerv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{Name: component.SystemCertificateName, Namespace: namespace},
+		Spec:       certmanagerv1.CertificateSpec{SecretName: component.SystemCertificateName},
+	}
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(systemCertificate, backendCertificate).Build()
+
+	status, err := CertificatesStatus(apimanager, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []appsv1alpha1.CertificateStatus{
+		{Name: component.BackendCertificateName, SecretName: component.BackendCertificateName, Ready: true, NotAfter: &notAfter, RenewalTime: &renewalTime},
+		{Name: component.SystemCertificateName, SecretName: component.SystemCertificateName},
+	}
+	if len(status) != len(expected) {
+		t.Fatalf("unexpected status: %v", status)
+	}
+	for idx := range expected {
+		if status[idx].Name != expected[idx].Name || status[idx].SecretName != expected[idx].SecretName ||
+			status[idx].Ready != expected[idx].Ready ||
+			!status[idx].NotAfter.Equal(expected[idx].NotAfter) || !status[idx].RenewalTime.Equal(expected[idx].RenewalTime) {
+			t.Errorf("unexpected status. Expected: %v, got: %v", expected[idx], status[idx])
+		}
+	}
+
+	apimanager.Spec.CertManager.Enabled = false
+	status, err = CertificatesStatus(apimanager, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != nil {
+		t.Errorf("status expected to be nil when cert-manager is disabled, got: %v", status)
+	}
+}
@@ -0,0 +1,52 @@
+package operator
+
+import (
+	"fmt"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	certmanagerv1 "github.com/3scale/3scale-operator/pkg/certmanager/v1"
+)
+
+type CertificatesOptionsProvider struct {
+	apimanager          *appsv1alpha1.APIManager
+	certificatesOptions *component.CertificatesOptions
+}
+
+func NewCertificatesOptionsProvider(apimanager *appsv1alpha1.APIManager) *CertificatesOptionsProvider {
+	return &CertificatesOptionsProvider{
+		apimanager:          apimanager,
+		certificatesOptions: component.NewCertificatesOptions(),
+	}
+}
+
+func (c *CertificatesOptionsProvider) GetCertificatesOptions() (*component.CertificatesOptions, error) {
+	c.certificatesOptions.TenantName = *c.apimanager.Spec.TenantName
+	c.certificatesOptions.WildcardDomain = c.apimanager.Spec.WildcardDomain
+	c.certificatesOptions.CommonLabels = c.commonLabels()
+
+	c.certificatesOptions.IssuerKind = certmanagerv1.IssuerKind
+	c.certificatesOptions.IssuerGroup = certmanagerv1.GroupVersion.Group
+	if c.apimanager.Spec.CertManager != nil && c.apimanager.Spec.CertManager.IssuerRef != nil {
+		issuerRef := c.apimanager.Spec.CertManager.IssuerRef
+		c.certificatesOptions.IssuerName = issuerRef.Name
+		if issuerRef.Kind != "" {
+			c.certificatesOptions.IssuerKind = issuerRef.Kind
+		}
+		if issuerRef.Group != "" {
+			c.certificatesOptions.IssuerGroup = issuerRef.Group
+		}
+	}
+
+	err := c.certificatesOptions.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("GetCertificatesOptions validating: %w", err)
+	}
+	return c.certificatesOptions, nil
+}
+
+func (c *CertificatesOptionsProvider) commonLabels() map[string]string {
+	return map[string]string{
+		"app": *c.apimanager.Spec.AppLabel,
+	}
+}
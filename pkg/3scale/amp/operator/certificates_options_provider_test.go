@@ -0,0 +1,80 @@
+package operator
+
+import (
+	"reflect"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/google/go-cmp/cmp"
+)
+
+func defaultCertificatesOptions() *component.CertificatesOptions {
+	return &component.CertificatesOptions{
+		TenantName:     tenantName,
+		WildcardDomain: wildcardDomain,
+		IssuerKind:     "Issuer",
+		IssuerGroup:    "cert-manager.io",
+		CommonLabels:   testIngressCommonLabels(),
+	}
+}
+
+func TestCertificatesOptionsProvider(t *testing.T) {
+	cases := []struct {
+		testName               string
+		apimanagerFactory      func() *appsv1alpha1.APIManager
+		expectedOptionsFactory func() *component.CertificatesOptions
+	}{
+		{"Default", basicApimanager, defaultCertificatesOptions},
+		{"WithIssuer",
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanager()
+				apimanager.Spec.CertManager = &appsv1alpha1.CertManagerSpec{
+					Enabled:   true,
+					IssuerRef: &appsv1alpha1.CertManagerIssuerReference{Name: "letsencrypt"},
+				}
+				return apimanager
+			},
+			func() *component.CertificatesOptions {
+				opts := defaultCertificatesOptions()
+				opts.IssuerName = "letsencrypt"
+				return opts
+			},
+		},
+		{"WithClusterIssuer",
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanager()
+				apimanager.Spec.CertManager = &appsv1alpha1.CertManagerSpec{
+					Enabled: true,
+					IssuerRef: &appsv1alpha1.CertManagerIssuerReference{
+						Name:  "letsencrypt",
+						Kind:  "ClusterIssuer",
+						Group: "example.com",
+					},
+				}
+				return apimanager
+			},
+			func() *component.CertificatesOptions {
+				opts := defaultCertificatesOptions()
+				opts.IssuerName = "letsencrypt"
+				opts.IssuerKind = "ClusterIssuer"
+				opts.IssuerGroup = "example.com"
+				return opts
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			optsProvider := NewCertificatesOptionsProvider(tc.apimanagerFactory())
+			opts, err := optsProvider.GetCertificatesOptions()
+			if err != nil {
+				subT.Error(err)
+			}
+			expectedOptions := tc.expectedOptionsFactory()
+			if !reflect.DeepEqual(expectedOptions, opts) {
+				subT.Errorf("Resulting expected options differ: %s", cmp.Diff(expectedOptions, opts))
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package operator
+
+import (
+	appsv1 "github.com/openshift/api/apps/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+// componentWorkload gives access to the replicas and the pod template
+// of a DeploymentConfig or a Deployment
+type componentWorkload struct {
+	object         common.KubernetesObject
+	specReplicas   int32
+	statusReplicas int32
+	available      bool
+	// rolledOut is true when all the pods run the current pod template and are available
+	rolledOut   bool
+	template    *v1.PodTemplateSpec
+	setReplicas func(int32)
+}
+
+// componentWorkload returns the Deployment or DeploymentConfig of the component, depending on the APIManager deployment type.
+// Returns nil when it does not exist
+func (r *BaseAPIManagerLogicReconciler) componentWorkload(name string) (*componentWorkload, error) {
+	key := types.NamespacedName{Name: name, Namespace: r.apiManager.Namespace}
+
+	if r.apiManager.IsKubernetesDeploymentEnabled() {
+		deployment := &k8sappsv1.Deployment{}
+		err := r.Client().Get(r.Context(), key, deployment)
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return newDeploymentWorkload(deployment), nil
+	}
+
+	dc := &appsv1.DeploymentConfig{}
+	err := r.Client().Get(r.Context(), key, dc)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newDeploymentConfigWorkload(dc), nil
+}
+
+func newDeploymentConfigWorkload(dc *appsv1.DeploymentConfig) *componentWorkload {
+	available := helper.IsDeploymentConfigAvailable(dc)
+	if dc.Spec.Template == nil {
+		dc.Spec.Template = &v1.PodTemplateSpec{}
+	}
+
+	return &componentWorkload{
+		object:         dc,
+		specReplicas:   dc.Spec.Replicas,
+		statusReplicas: dc.Status.Replicas,
+		available:      available,
+		rolledOut: available && dc.Status.ObservedGeneration >= dc.Generation &&
+			dc.Status.UpdatedReplicas == dc.Spec.Replicas && dc.Status.Replicas == dc.Spec.Replicas,
+		template:    dc.Spec.Template,
+		setReplicas: func(replicas int32) { dc.Spec.Replicas = replicas },
+	}
+}
+
+func newDeploymentWorkload(deployment *k8sappsv1.Deployment) *componentWorkload {
+	specReplicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		specReplicas = *deployment.Spec.Replicas
+	}
+	available := helper.IsDeploymentAvailable(deployment)
+
+	return &componentWorkload{
+		object:         deployment,
+		specReplicas:   specReplicas,
+		statusReplicas: deployment.Status.Replicas,
+		available:      available,
+		rolledOut: available && deployment.Status.ObservedGeneration >= deployment.Generation &&
+			deployment.Status.UpdatedReplicas == specReplicas && deployment.Status.Replicas == specReplicas,
+		template:    &deployment.Spec.Template,
+		setReplicas: func(replicas int32) { deployment.Spec.Replicas = &replicas },
+	}
+}
@@ -36,13 +36,19 @@ type CompositeDependencyReconciler struct {
 
 var _ DependencyReconciler = &CompositeDependencyReconciler{}
 
+// Reconcile stops at the first reconciler returning an error or requesting a requeue.
+// Otherwise, the shortest RequeueAfter requested is returned
 func (r *CompositeDependencyReconciler) Reconcile() (reconcile.Result, error) {
+	compositeResult := reconcile.Result{}
 	for _, ir := range r.Reconcilers {
 		result, err := ir.Reconcile()
 		if result.Requeue || err != nil {
 			return result, err
 		}
+		if result.RequeueAfter > 0 && (compositeResult.RequeueAfter == 0 || result.RequeueAfter < compositeResult.RequeueAfter) {
+			compositeResult.RequeueAfter = result.RequeueAfter
+		}
 	}
 
-	return reconcile.Result{}, nil
+	return compositeResult, nil
 }
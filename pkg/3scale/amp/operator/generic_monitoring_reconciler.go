@@ -42,5 +42,11 @@ func (r *GenericMonitoringReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
+	prometheusRule = component.BackupSchedulePrometheusRules(r.apiManager.Namespace, *r.apiManager.Spec.AppLabel)
+	err = r.ReconcilePrometheusRules(prometheusRule, reconcilers.CreateOnlyMutator)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	return reconcile.Result{}, nil
 }
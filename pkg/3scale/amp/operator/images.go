@@ -1,6 +1,16 @@
 package operator
 
 import (
+	"context"
+	"sort"
+	"strings"
+
+	imagev1 "github.com/openshift/api/image/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/helper"
 )
@@ -48,3 +58,160 @@ func SystemSearchdImageURL() string {
 func ZyncPostgreSQLImageURL() string {
 	return helper.GetEnvVar("RELATED_IMAGE_ZYNC_POSTGRESQL", component.ZyncPostgreSQLImageURL())
 }
+
+// ImageStreamImages returns the image URLs imported by the APIManager imagestreams,
+// indexed by imagestream name. Used to deploy the components as Kubernetes Deployments,
+// which do not support imagestream triggers.
+func ImageStreamImages(apimanager *appsv1alpha1.APIManager, cl client.Client) (map[string]string, error) {
+	imageStreams, err := apimanagerImageStreams(apimanager, cl)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]string{}
+	for _, imageStream := range imageStreams {
+		SetImageStreamImageURLs(apimanager, imageStream)
+		if image := imageStreamDockerImage(imageStream); image != "" {
+			result[imageStream.Name] = image
+		}
+	}
+
+	return result, nil
+}
+
+// SetImageStreamImageURLs applies the APIManager registry mirrors and,
+// when image digest pinning is enabled, the pinned digests to the imagestream DockerImage tags
+func SetImageStreamImageURLs(apimanager *appsv1alpha1.APIManager, imageStream *imagev1.ImageStream) {
+	for idx := range imageStream.Spec.Tags {
+		from := imageStream.Spec.Tags[idx].From
+		if from == nil || from.Kind != "DockerImage" {
+			continue
+		}
+		from.Name = apimanager.PinnedImageURL(imageStream.Name, apimanager.MirroredImageURL(from.Name))
+	}
+}
+
+// PinnedImages resolves the digests of the APIManager imagestreams from the existing imagestreams status.
+// Digests already pinned are kept as long as the source image does not change.
+// Imagestreams not imported yet are not included
+func PinnedImages(apimanager *appsv1alpha1.APIManager, cl client.Client) ([]appsv1alpha1.PinnedImage, error) {
+	if !apimanager.IsImageDigestPinningEnabled() {
+		return nil, nil
+	}
+
+	imageStreams, err := apimanagerImageStreams(apimanager, cl)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []appsv1alpha1.PinnedImage{}
+	for _, desired := range imageStreams {
+		tagName, image := imageStreamDockerImageTag(desired)
+		if image == "" {
+			continue
+		}
+		image = apimanager.MirroredImageURL(image)
+
+		if digest := apimanager.PinnedImageURL(desired.Name, image); digest != image {
+			result = append(result, appsv1alpha1.PinnedImage{Name: desired.Name, Image: image, Digest: digest})
+			continue
+		}
+
+		existing := &imagev1.ImageStream{}
+		err := cl.Get(context.TODO(), types.NamespacedName{Name: desired.Name, Namespace: apimanager.Namespace}, existing)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if digest := importedDigest(existing, tagName, image); digest != "" {
+			result = append(result, appsv1alpha1.PinnedImage{Name: desired.Name, Image: image, Digest: digest})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return result, nil
+}
+
+// importedDigest returns the pull spec by digest of the latest import of the image in the imagestream tag.
+// Returns empty when the tag was not imported from the image yet
+func importedDigest(imageStream *imagev1.ImageStream, tagName, image string) string {
+	var specTag *imagev1.TagReference
+	for idx := range imageStream.Spec.Tags {
+		if imageStream.Spec.Tags[idx].Name == tagName {
+			specTag = &imageStream.Spec.Tags[idx]
+		}
+	}
+	if specTag == nil || specTag.From == nil || specTag.From.Name != image {
+		return ""
+	}
+
+	for _, statusTag := range imageStream.Status.Tags {
+		if statusTag.Tag != tagName || len(statusTag.Items) == 0 {
+			continue
+		}
+		item := statusTag.Items[0]
+		if specTag.Generation != nil && item.Generation < *specTag.Generation {
+			return ""
+		}
+		if !strings.Contains(item.DockerImageReference, "@sha256:") {
+			return ""
+		}
+		return item.DockerImageReference
+	}
+
+	return ""
+}
+
+func imageStreamDockerImage(imageStream *imagev1.ImageStream) string {
+	_, image := imageStreamDockerImageTag(imageStream)
+	return image
+}
+
+func imageStreamDockerImageTag(imageStream *imagev1.ImageStream) (string, string) {
+	for _, tag := range imageStream.Spec.Tags {
+		if tag.From != nil && tag.From.Kind == "DockerImage" {
+			return tag.Name, tag.From.Name
+		}
+	}
+	return "", ""
+}
+
+func apimanagerImageStreams(apimanager *appsv1alpha1.APIManager, cl client.Client) ([]*imagev1.ImageStream, error) {
+	ampImages, err := AmpImages(apimanager)
+	if err != nil {
+		return nil, err
+	}
+
+	redis, err := Redis(apimanager, cl)
+	if err != nil {
+		return nil, err
+	}
+
+	systemMySQLImage, err := SystemMySQLImage(apimanager)
+	if err != nil {
+		return nil, err
+	}
+
+	systemPostgreSQLImage, err := SystemPostgreSQLImage(apimanager)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*imagev1.ImageStream{
+		ampImages.APICastImageStream(),
+		ampImages.BackendImageStream(),
+		ampImages.SystemImageStream(),
+		ampImages.ZyncImageStream(),
+		ampImages.ZyncDatabasePostgreSQLImageStream(),
+		ampImages.SystemMemcachedImageStream(),
+		ampImages.SystemSearchdImageStream(),
+		redis.BackendImageStream(),
+		redis.SystemImageStream(),
+		systemMySQLImage.ImageStream(),
+		systemPostgreSQLImage.ImageStream(),
+	}, nil
+}
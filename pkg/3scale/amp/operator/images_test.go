@@ -4,7 +4,15 @@ import (
 	"os"
 	"testing"
 
+	imagev1 "github.com/openshift/api/image/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
 )
 
 func TestImageURLFromEnv(t *testing.T) {
@@ -68,3 +76,98 @@ func TestImageURLDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestImageStreamImagesMirrored(t *testing.T) {
+	apimanager := basicApimanager()
+	apimanager.Spec.ImageRegistryMirrors = []appsv1alpha1.ImageRegistryMirrorSpec{
+		{Source: "quay.io/3scale", Mirror: "mirror.example.com/3scale"},
+	}
+
+	images, err := ImageStreamImages(apimanager, fake.NewFakeClient())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if images["amp-apicast"] != "mirror.example.com/3scale/apicast:latest" {
+		t.Errorf("Unexpected amp-apicast image: %s", images["amp-apicast"])
+	}
+	if images["system-memcached"] != component.SystemMemcachedImageURL() {
+		t.Errorf("Unexpected system-memcached image: %s", images["system-memcached"])
+	}
+}
+
+func TestPinnedImages(t *testing.T) {
+	apimanager := basicApimanager()
+	apimanager.Spec.ImageDigestPinningEnabled = &[]bool{true}[0]
+	// backend is already pinned
+	apimanager.Status.PinnedImages = []appsv1alpha1.PinnedImage{
+		{Name: "amp-backend", Image: component.BackendImageURL(), Digest: "quay.io/3scale/apisonator@sha256:backend"},
+	}
+
+	generation := int64(2)
+	importedImageStream := func(name, image, digest string, importedGeneration int64) *imagev1.ImageStream {
+		return &imagev1.ImageStream{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: imagev1.ImageStreamSpec{
+				Tags: []imagev1.TagReference{
+					{
+						Name:       product.ThreescaleRelease,
+						From:       &v1.ObjectReference{Kind: "DockerImage", Name: image},
+						Generation: &generation,
+					},
+				},
+			},
+			Status: imagev1.ImageStreamStatus{
+				Tags: []imagev1.NamedTagEventList{
+					{
+						Tag: product.ThreescaleRelease,
+						Items: []imagev1.TagEvent{
+							{DockerImageReference: digest, Generation: importedGeneration},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	s := scheme.Scheme
+	if err := imagev1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	cl := fake.NewFakeClient(
+		importedImageStream("amp-apicast", component.ApicastImageURL(), "quay.io/3scale/apicast@sha256:apicast", generation),
+		// import of the current tag generation pending
+		importedImageStream("amp-system", component.SystemImageURL(), "quay.io/3scale/porta@sha256:old", generation-1),
+		// imported from a different image
+		importedImageStream("amp-zync", "quay.io/3scale/zync:old", "quay.io/3scale/zync@sha256:old", generation),
+	)
+
+	pinnedImages, err := PinnedImages(apimanager, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []appsv1alpha1.PinnedImage{
+		{Name: "amp-apicast", Image: component.ApicastImageURL(), Digest: "quay.io/3scale/apicast@sha256:apicast"},
+		{Name: "amp-backend", Image: component.BackendImageURL(), Digest: "quay.io/3scale/apisonator@sha256:backend"},
+	}
+	if len(pinnedImages) != len(expected) {
+		t.Fatalf("Expected %v, got: %v", expected, pinnedImages)
+	}
+	for idx := range expected {
+		if pinnedImages[idx] != expected[idx] {
+			t.Errorf("Expected %v, got: %v", expected[idx], pinnedImages[idx])
+		}
+	}
+
+	// Pinned digests are applied to the imagestreams
+	ampImages, err := AmpImages(apimanager)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backendImageStream := ampImages.BackendImageStream()
+	SetImageStreamImageURLs(apimanager, backendImageStream)
+	if backendImageStream.Spec.Tags[0].From.Name != "quay.io/3scale/apisonator@sha256:backend" {
+		t.Errorf("Unexpected amp-backend image: %s", backendImageStream.Spec.Tags[0].From.Name)
+	}
+}
@@ -0,0 +1,46 @@
+package operator
+
+import (
+	"fmt"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+)
+
+type IngressOptionsProvider struct {
+	apimanager     *appsv1alpha1.APIManager
+	ingressOptions *component.IngressOptions
+}
+
+func NewIngressOptionsProvider(apimanager *appsv1alpha1.APIManager) *IngressOptionsProvider {
+	return &IngressOptionsProvider{
+		apimanager:     apimanager,
+		ingressOptions: component.NewIngressOptions(),
+	}
+}
+
+func (i *IngressOptionsProvider) GetIngressOptions() (*component.IngressOptions, error) {
+	i.ingressOptions.TenantName = *i.apimanager.Spec.TenantName
+	i.ingressOptions.WildcardDomain = i.apimanager.Spec.WildcardDomain
+	i.ingressOptions.CommonLabels = i.commonLabels()
+
+	if i.apimanager.Spec.Ingress != nil {
+		i.ingressOptions.IngressClassName = i.apimanager.Spec.Ingress.IngressClassName
+		i.ingressOptions.Annotations = i.apimanager.Spec.Ingress.Annotations
+		if i.apimanager.Spec.Ingress.TLSSecretRef != nil {
+			i.ingressOptions.TLSSecretName = &i.apimanager.Spec.Ingress.TLSSecretRef.Name
+		}
+	}
+
+	err := i.ingressOptions.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("GetIngressOptions validating: %w", err)
+	}
+	return i.ingressOptions, nil
+}
+
+func (i *IngressOptionsProvider) commonLabels() map[string]string {
+	return map[string]string{
+		"app": *i.apimanager.Spec.AppLabel,
+	}
+}
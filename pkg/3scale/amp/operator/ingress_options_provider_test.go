@@ -0,0 +1,71 @@
+package operator
+
+import (
+	"reflect"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+)
+
+func testIngressCommonLabels() map[string]string {
+	return map[string]string{
+		"app": appLabel,
+	}
+}
+
+func defaultIngressOptions() *component.IngressOptions {
+	return &component.IngressOptions{
+		TenantName:     tenantName,
+		WildcardDomain: wildcardDomain,
+		CommonLabels:   testIngressCommonLabels(),
+	}
+}
+
+func TestIngressOptionsProvider(t *testing.T) {
+	ingressClassName := "nginx"
+	tlsSecretName := "wildcard-tls"
+
+	cases := []struct {
+		testName               string
+		apimanagerFactory      func() *appsv1alpha1.APIManager
+		expectedOptionsFactory func() *component.IngressOptions
+	}{
+		{"Default", basicApimanager, defaultIngressOptions},
+		{"WithIngressSettings",
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanager()
+				apimanager.Spec.Ingress = &appsv1alpha1.IngressSpec{
+					Enabled:          true,
+					IngressClassName: &ingressClassName,
+					TLSSecretRef:     &v1.LocalObjectReference{Name: tlsSecretName},
+					Annotations:      map[string]string{"anno1": "value1"},
+				}
+				return apimanager
+			},
+			func() *component.IngressOptions {
+				opts := defaultIngressOptions()
+				opts.IngressClassName = &ingressClassName
+				opts.TLSSecretName = &tlsSecretName
+				opts.Annotations = map[string]string{"anno1": "value1"}
+				return opts
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			optsProvider := NewIngressOptionsProvider(tc.apimanagerFactory())
+			opts, err := optsProvider.GetIngressOptions()
+			if err != nil {
+				subT.Error(err)
+			}
+			expectedOptions := tc.expectedOptionsFactory()
+			if !reflect.DeepEqual(expectedOptions, opts) {
+				subT.Errorf("Resulting expected options differ: %s", cmp.Diff(expectedOptions, opts))
+			}
+		})
+	}
+}
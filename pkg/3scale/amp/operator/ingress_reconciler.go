@@ -0,0 +1,43 @@
+package operator
+
+import (
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type IngressReconciler struct {
+	*BaseAPIManagerLogicReconciler
+}
+
+func NewIngressReconciler(baseAPIManagerLogicReconciler *BaseAPIManagerLogicReconciler) *IngressReconciler {
+	return &IngressReconciler{
+		BaseAPIManagerLogicReconciler: baseAPIManagerLogicReconciler,
+	}
+}
+
+func (r *IngressReconciler) Reconcile() (reconcile.Result, error) {
+	ingress, err := Ingress(r.apiManager)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	for _, desired := range ingress.Ingresses() {
+		err = r.ReconcileIngress(desired, reconcilers.GenericIngressMutator)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+func Ingress(apimanager *appsv1alpha1.APIManager) (*component.Ingress, error) {
+	optsProvider := NewIngressOptionsProvider(apimanager)
+	opts, err := optsProvider.GetIngressOptions()
+	if err != nil {
+		return nil, err
+	}
+	return component.NewIngress(opts), nil
+}
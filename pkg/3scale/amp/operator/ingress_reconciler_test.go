@@ -0,0 +1,104 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestIngressReconciler(t *testing.T) {
+	ingressClassName := "nginx"
+
+	log := logf.Log.WithName("operator_test")
+	ctx := context.TODO()
+	apimanager := basicApimanager()
+	apimanager.Spec.Ingress = &appsv1alpha1.IngressSpec{
+		Enabled:          true,
+		IngressClassName: &ingressClassName,
+		TLSSecretRef:     &v1.LocalObjectReference{Name: "wildcard-tls"},
+	}
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+
+	// Objects to track in the fake client.
+	objs := []runtime.Object{apimanager}
+
+	// Create a fake client to mock API calls.
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+
+	baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, clientAPIReader, log, clientset.Discovery(), recorder)
+	baseAPIManagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager)
+
+	reconciler := NewIngressReconciler(baseAPIManagerLogicReconciler)
+	_, err := reconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		testName        string
+		objName         string
+		expectedHost    string
+		expectedService string
+	}{
+		{"backendIngress", "backend", "backend-someTenant.test.3scale.net", "backend-listener"},
+		{"apicastStagingIngress", "apicast-staging", "api-someTenant-apicast-staging.test.3scale.net", "apicast-staging"},
+		{"apicastProductionIngress", "apicast-production", "api-someTenant-apicast-production.test.3scale.net", "apicast-production"},
+		{"systemMasterIngress", "system-master", "master.test.3scale.net", "system-master"},
+		{"systemProviderIngress", "system-provider", "someTenant-admin.test.3scale.net", "system-provider"},
+		{"systemDeveloperIngress", "system-developer", "someTenant.test.3scale.net", "system-developer"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			ingress := &networkingv1.Ingress{}
+			namespacedName := types.NamespacedName{Name: tc.objName, Namespace: namespace}
+			err := cl.Get(context.TODO(), namespacedName, ingress)
+			if err != nil {
+				subT.Fatalf("error fetching object %s: %v", tc.objName, err)
+			}
+
+			if ingress.Spec.IngressClassName == nil || *ingress.Spec.IngressClassName != ingressClassName {
+				subT.Errorf("unexpected ingress class name: %v", ingress.Spec.IngressClassName)
+			}
+			if len(ingress.Spec.Rules) != 1 || ingress.Spec.Rules[0].Host != tc.expectedHost {
+				subT.Fatalf("unexpected ingress rules: %v", ingress.Spec.Rules)
+			}
+			if ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name != tc.expectedService {
+				subT.Errorf("unexpected ingress backend service: %s", ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name)
+			}
+			if len(ingress.Spec.TLS) != 1 || ingress.Spec.TLS[0].SecretName != "wildcard-tls" {
+				subT.Errorf("unexpected ingress TLS: %v", ingress.Spec.TLS)
+			}
+		})
+	}
+
+	// Disabling ingresses deletes them
+	apimanager.Spec.Ingress.Enabled = false
+	_, err = reconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ingress := &networkingv1.Ingress{}
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: "backend", Namespace: namespace}, ingress)
+	if !errors.IsNotFound(err) {
+		t.Fatalf("ingress expected to be deleted, got: %v", err)
+	}
+}
@@ -0,0 +1,269 @@
+package operator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	apispkgcommon "github.com/3scale/3scale-operator/pkg/apispkg/common"
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+)
+
+// MaintenanceModeComponents returns the components scaled down by the maintenance mode in this order.
+// They are restored in reverse order.
+// system-app goes first, so no new jobs are enqueued while the workers are still running
+func MaintenanceModeComponents(apimanager *appsv1alpha1.APIManager) []string {
+	return append(SystemComponents(apimanager),
+		component.ZyncQueDeploymentName,
+		component.BackendWorkerName,
+	)
+}
+
+// PausedWorkersComponents returns the workers scaled down while the APIManager workers are paused:
+// system-sidekiq, the sidekiq pools, zync-que and backend-worker
+func PausedWorkersComponents(apimanager *appsv1alpha1.APIManager) []string {
+	names := []string{component.SystemSidekiqName}
+	if apimanager.Spec.System != nil {
+		for _, pool := range apimanager.Spec.System.SidekiqPools {
+			names = append(names, component.SidekiqPoolName(pool.Name))
+		}
+	}
+	return append(names, component.ZyncQueDeploymentName, component.BackendWorkerName)
+}
+
+// scaledDownComponents returns the components kept scaled down.
+// The maintenance mode has precedence over the paused workers
+func scaledDownComponents(apimanager *appsv1alpha1.APIManager) []string {
+	switch {
+	case apimanager.IsMaintenanceModeEnabled():
+		return MaintenanceModeComponents(apimanager)
+	case apimanager.IsPauseWorkersEnabled():
+		return PausedWorkersComponents(apimanager)
+	}
+	return nil
+}
+
+// MaintenanceModeReconciler scales down the MaintenanceModeComponents, one at a time,
+// while the APIManager maintenance mode is enabled, or the PausedWorkersComponents
+// while the APIManager workers are paused. Each component is scaled down once
+// the previous one has no pods left. The components no longer required to be scaled down
+// are restored in reverse order, once the previous one is available.
+type MaintenanceModeReconciler struct {
+	*BaseAPIManagerLogicReconciler
+}
+
+func NewMaintenanceModeReconciler(baseAPIManagerLogicReconciler *BaseAPIManagerLogicReconciler) *MaintenanceModeReconciler {
+	return &MaintenanceModeReconciler{
+		BaseAPIManagerLogicReconciler: baseAPIManagerLogicReconciler,
+	}
+}
+
+func (r *MaintenanceModeReconciler) Reconcile() (reconcile.Result, error) {
+	result, err := r.scaleDown()
+	if result.Requeue || err != nil {
+		return result, err
+	}
+
+	return r.restore()
+}
+
+func (r *MaintenanceModeReconciler) scaleDown() (reconcile.Result, error) {
+	for _, name := range scaledDownComponents(r.apiManager) {
+		workload, err := r.componentWorkload(name)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if workload == nil {
+			continue
+		}
+
+		if !workload.scaledDownForMaintenance() {
+			annotations := workload.object.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[appsv1alpha1.MaintenanceModeReplicasAnnotation] = strconv.Itoa(int(workload.specReplicas))
+			workload.object.SetAnnotations(annotations)
+			workload.setReplicas(0)
+
+			r.logger.Info("Maintenance mode: scaling down", "name", name, "replicas", workload.specReplicas)
+			if err := r.UpdateResource(workload.object); err != nil {
+				return reconcile.Result{}, err
+			}
+			r.EventRecorder().Eventf(r.apiManager, v1.EventTypeNormal, "MaintenanceModeScaledDown",
+				"%s scaled down from %d replicas for maintenance", name, workload.specReplicas)
+			return reconcile.Result{Requeue: true}, nil
+		}
+
+		if workload.statusReplicas != 0 {
+			r.logger.Info("Maintenance mode: waiting for pods to terminate", "name", name)
+			return reconcile.Result{}, nil
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+func (r *MaintenanceModeReconciler) restore() (reconcile.Result, error) {
+	keep := scaledDownComponents(r.apiManager)
+	names := MaintenanceModeComponents(r.apiManager)
+	workloads := make([]*componentWorkload, len(names))
+	restoring := false
+	for idx, name := range names {
+		if helper.ArrayContains(keep, name) {
+			continue
+		}
+		workload, err := r.componentWorkload(name)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		workloads[idx] = workload
+		if workload != nil && workload.scaledDownForMaintenance() {
+			restoring = true
+		}
+	}
+
+	if !restoring {
+		return reconcile.Result{}, nil
+	}
+
+	for idx := len(workloads) - 1; idx >= 0; idx-- {
+		workload := workloads[idx]
+		if workload == nil {
+			continue
+		}
+
+		if !workload.scaledDownForMaintenance() {
+			if !workload.available {
+				r.logger.Info("Maintenance mode: waiting for component to be available", "name", workload.object.GetName())
+				return reconcile.Result{}, nil
+			}
+			continue
+		}
+
+		annotations := workload.object.GetAnnotations()
+		replicas, err := strconv.ParseInt(annotations[appsv1alpha1.MaintenanceModeReplicasAnnotation], 10, 32)
+		if err != nil {
+			r.logger.Info("Maintenance mode: invalid replicas annotation, restoring one replica", "name", workload.object.GetName())
+			replicas = 1
+		}
+		delete(annotations, appsv1alpha1.MaintenanceModeReplicasAnnotation)
+		workload.object.SetAnnotations(annotations)
+		workload.setReplicas(int32(replicas))
+
+		r.logger.Info("Maintenance mode: restoring", "name", workload.object.GetName(), "replicas", replicas)
+		if err := r.UpdateResource(workload.object); err != nil {
+			return reconcile.Result{}, err
+		}
+		r.EventRecorder().Eventf(r.apiManager, v1.EventTypeNormal, "MaintenanceModeRestored",
+			"%s restored to %d replicas after maintenance", workload.object.GetName(), replicas)
+		return reconcile.Result{Requeue: true}, nil
+	}
+
+	return reconcile.Result{}, nil
+}
+
+func (w *componentWorkload) scaledDownForMaintenance() bool {
+	_, ok := w.object.GetAnnotations()[appsv1alpha1.MaintenanceModeReplicasAnnotation]
+	return ok
+}
+
+// MaintenanceModeMutator keeps the components scaled down by the maintenance mode
+// with zero replicas, regardless of the replicas set by the wrapped mutator
+func MaintenanceModeMutator(mutateFn reconcilers.MutateFn) reconcilers.MutateFn {
+	return func(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+		updated, err := mutateFn(existingObj, desiredObj)
+		if err != nil {
+			return false, err
+		}
+
+		if _, ok := existingObj.GetAnnotations()[appsv1alpha1.MaintenanceModeReplicasAnnotation]; !ok {
+			return updated, nil
+		}
+
+		switch existing := existingObj.(type) {
+		case *appsv1.DeploymentConfig:
+			if existing.Spec.Replicas != 0 {
+				existing.Spec.Replicas = 0
+				updated = true
+			}
+		case *k8sappsv1.Deployment:
+			if existing.Spec.Replicas == nil || *existing.Spec.Replicas != 0 {
+				existing.Spec.Replicas = &[]int32{0}[0]
+				updated = true
+			}
+		}
+
+		return updated, nil
+	}
+}
+
+// MaintenanceModeCondition returns the MaintenanceMode condition of the APIManager
+// from the existing DeploymentConfigs and Deployments of the MaintenanceModeComponents
+func MaintenanceModeCondition(apimanager *appsv1alpha1.APIManager, deploymentConfigs []appsv1.DeploymentConfig, deployments []k8sappsv1.Deployment) apispkgcommon.Condition {
+	keep := scaledDownComponents(apimanager)
+	pending := []string{}
+	scaledDown := []string{}
+	restoring := []string{}
+	for _, name := range MaintenanceModeComponents(apimanager) {
+		var workload *componentWorkload
+		for idx := range deploymentConfigs {
+			if deploymentConfigs[idx].Name == name {
+				workload = newDeploymentConfigWorkload(&deploymentConfigs[idx])
+			}
+		}
+		for idx := range deployments {
+			if deployments[idx].Name == name {
+				workload = newDeploymentWorkload(&deployments[idx])
+			}
+		}
+		if workload == nil {
+			continue
+		}
+
+		kept := helper.ArrayContains(keep, name)
+		if workload.scaledDownForMaintenance() {
+			scaledDown = append(scaledDown, name)
+			if !kept {
+				restoring = append(restoring, name)
+			}
+		}
+		if kept && (!workload.scaledDownForMaintenance() || workload.statusReplicas != 0) {
+			pending = append(pending, name)
+		}
+	}
+
+	condition := apispkgcommon.Condition{Type: appsv1alpha1.APIManagerMaintenanceModeConditionType}
+	switch {
+	case len(keep) > 0 && len(pending) > 0:
+		condition.Status = v1.ConditionTrue
+		condition.Reason = appsv1alpha1.APIManagerMaintenanceScalingDownReason
+		condition.Message = fmt.Sprintf("Waiting for %s to scale down", strings.Join(pending, ", "))
+	case apimanager.IsMaintenanceModeEnabled():
+		condition.Status = v1.ConditionTrue
+		condition.Reason = appsv1alpha1.APIManagerMaintenanceActiveReason
+		condition.Message = fmt.Sprintf("%s scaled down for maintenance", strings.Join(scaledDown, ", "))
+	case len(restoring) > 0:
+		condition.Status = v1.ConditionTrue
+		condition.Reason = appsv1alpha1.APIManagerMaintenanceRestoringReason
+		condition.Message = fmt.Sprintf("Waiting to restore %s", strings.Join(restoring, ", "))
+	case apimanager.IsPauseWorkersEnabled():
+		condition.Status = v1.ConditionTrue
+		condition.Reason = appsv1alpha1.APIManagerWorkersPausedReason
+		condition.Message = fmt.Sprintf("%s scaled down while the workers are paused", strings.Join(scaledDown, ", "))
+	default:
+		condition.Status = v1.ConditionFalse
+		condition.Reason = appsv1alpha1.APIManagerMaintenanceInactiveReason
+	}
+
+	return condition
+}
@@ -0,0 +1,387 @@
+package operator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func maintenanceTestDeploymentConfig(name string, replicas int32, annotations map[string]string) *appsv1.DeploymentConfig {
+	return &appsv1.DeploymentConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Annotations: annotations,
+		},
+		Spec: appsv1.DeploymentConfigSpec{Replicas: replicas},
+		Status: appsv1.DeploymentConfigStatus{
+			Replicas: replicas,
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentAvailable, Status: v1.ConditionTrue},
+			},
+		},
+	}
+}
+
+func maintenanceTestReconciler(t *testing.T, apimanager *appsv1alpha1.APIManager, objs ...runtime.Object) (*MaintenanceModeReconciler, client.Client) {
+	ctx := context.TODO()
+	log := logf.Log.WithName("operator_test")
+
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+	if err := appsv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	cl := fake.NewFakeClient(append(objs, apimanager)...)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+	baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, cl, log, clientset.Discovery(), recorder)
+
+	return NewMaintenanceModeReconciler(NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager)), cl
+}
+
+func maintenanceTestGetDC(t *testing.T, cl client.Client, name string) *appsv1.DeploymentConfig {
+	dc := &appsv1.DeploymentConfig{}
+	err := cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dc
+}
+
+func TestMaintenanceModeReconcilerScaleDown(t *testing.T) {
+	apimanager := basicApimanager()
+	apimanager.Annotations = map[string]string{appsv1alpha1.MaintenanceModeAnnotation: "true"}
+
+	reconciler, cl := maintenanceTestReconciler(t, apimanager,
+		maintenanceTestDeploymentConfig(component.SystemAppDeploymentName, 2, nil),
+		maintenanceTestDeploymentConfig(component.SystemSidekiqName, 1, nil),
+		maintenanceTestDeploymentConfig(component.ZyncQueDeploymentName, 1, nil),
+		maintenanceTestDeploymentConfig(component.BackendWorkerName, 1, nil),
+	)
+
+	// system-app goes first
+	if _, err := reconciler.Reconcile(); err != nil {
+		t.Fatal(err)
+	}
+
+	systemApp := maintenanceTestGetDC(t, cl, component.SystemAppDeploymentName)
+	if systemApp.Spec.Replicas != 0 {
+		t.Errorf("system-app replicas: expected 0, got %d", systemApp.Spec.Replicas)
+	}
+	if systemApp.Annotations[appsv1alpha1.MaintenanceModeReplicasAnnotation] != "2" {
+		t.Errorf("system-app replicas annotation: expected 2, got '%s'", systemApp.Annotations[appsv1alpha1.MaintenanceModeReplicasAnnotation])
+	}
+
+	// sidekiq waits for system-app pods to terminate
+	if _, err := reconciler.Reconcile(); err != nil {
+		t.Fatal(err)
+	}
+	if sidekiq := maintenanceTestGetDC(t, cl, component.SystemSidekiqName); sidekiq.Spec.Replicas != 1 {
+		t.Errorf("sidekiq scaled down before system-app pods terminated")
+	}
+
+	systemApp = maintenanceTestGetDC(t, cl, component.SystemAppDeploymentName)
+	systemApp.Status.Replicas = 0
+	if err := cl.Update(context.TODO(), systemApp); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := reconciler.Reconcile(); err != nil {
+		t.Fatal(err)
+	}
+	if sidekiq := maintenanceTestGetDC(t, cl, component.SystemSidekiqName); sidekiq.Spec.Replicas != 0 {
+		t.Errorf("sidekiq replicas: expected 0, got %d", sidekiq.Spec.Replicas)
+	}
+	if backendWorker := maintenanceTestGetDC(t, cl, component.BackendWorkerName); backendWorker.Spec.Replicas != 1 {
+		t.Errorf("backend-worker scaled down before sidekiq pods terminated")
+	}
+}
+
+func TestMaintenanceModeComponentsSidekiqPools(t *testing.T) {
+	apimanager := basicApimanager()
+	apimanager.Spec.System.SidekiqPools = []appsv1alpha1.SystemSidekiqPoolSpec{
+		{Name: "critical", Queues: []appsv1alpha1.SidekiqQueueSpec{{Name: "critical"}}},
+	}
+
+	expected := []string{
+		component.SystemAppDeploymentName,
+		component.SystemMasterDeploymentName,
+		component.SystemProviderDeploymentName,
+		component.SystemDeveloperDeploymentName,
+		component.SystemSidekiqName,
+		"system-sidekiq-critical",
+		component.ZyncQueDeploymentName,
+		component.BackendWorkerName,
+	}
+	if names := MaintenanceModeComponents(apimanager); !reflect.DeepEqual(names, expected) {
+		t.Errorf("unexpected maintenance mode components: %v", names)
+	}
+}
+
+func TestMaintenanceModeReconcilerRestore(t *testing.T) {
+	apimanager := basicApimanager()
+
+	scaledDown := func(replicas string) map[string]string {
+		return map[string]string{appsv1alpha1.MaintenanceModeReplicasAnnotation: replicas}
+	}
+
+	reconciler, cl := maintenanceTestReconciler(t, apimanager,
+		maintenanceTestDeploymentConfig(component.SystemAppDeploymentName, 0, scaledDown("2")),
+		maintenanceTestDeploymentConfig(component.SystemSidekiqName, 0, scaledDown("1")),
+		maintenanceTestDeploymentConfig(component.ZyncQueDeploymentName, 0, scaledDown("1")),
+		maintenanceTestDeploymentConfig(component.BackendWorkerName, 0, scaledDown("3")),
+	)
+
+	// backend-worker goes first
+	if _, err := reconciler.Reconcile(); err != nil {
+		t.Fatal(err)
+	}
+
+	backendWorker := maintenanceTestGetDC(t, cl, component.BackendWorkerName)
+	if backendWorker.Spec.Replicas != 3 {
+		t.Errorf("backend-worker replicas: expected 3, got %d", backendWorker.Spec.Replicas)
+	}
+	if _, ok := backendWorker.Annotations[appsv1alpha1.MaintenanceModeReplicasAnnotation]; ok {
+		t.Errorf("backend-worker replicas annotation not removed")
+	}
+
+	// zync-que waits for backend-worker to be available
+	backendWorker.Status.Conditions = []appsv1.DeploymentCondition{
+		{Type: appsv1.DeploymentAvailable, Status: v1.ConditionFalse},
+	}
+	if err := cl.Update(context.TODO(), backendWorker); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := reconciler.Reconcile(); err != nil {
+		t.Fatal(err)
+	}
+	if zyncQue := maintenanceTestGetDC(t, cl, component.ZyncQueDeploymentName); zyncQue.Spec.Replicas != 0 {
+		t.Errorf("zync-que restored before backend-worker was available")
+	}
+
+	backendWorker = maintenanceTestGetDC(t, cl, component.BackendWorkerName)
+	backendWorker.Status.Conditions = []appsv1.DeploymentCondition{
+		{Type: appsv1.DeploymentAvailable, Status: v1.ConditionTrue},
+	}
+	if err := cl.Update(context.TODO(), backendWorker); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := reconciler.Reconcile(); err != nil {
+		t.Fatal(err)
+	}
+	if zyncQue := maintenanceTestGetDC(t, cl, component.ZyncQueDeploymentName); zyncQue.Spec.Replicas != 1 {
+		t.Errorf("zync-que replicas: expected 1, got %d", zyncQue.Spec.Replicas)
+	}
+}
+
+func TestMaintenanceModeReconcilerPauseWorkers(t *testing.T) {
+	apimanager := basicApimanager()
+	apimanager.Annotations = map[string]string{appsv1alpha1.PauseWorkersAnnotation: "true"}
+
+	reconciler, cl := maintenanceTestReconciler(t, apimanager,
+		maintenanceTestDeploymentConfig(component.SystemAppDeploymentName, 0, map[string]string{appsv1alpha1.MaintenanceModeReplicasAnnotation: "2"}),
+		maintenanceTestDeploymentConfig(component.SystemSidekiqName, 1, nil),
+		maintenanceTestDeploymentConfig(component.ZyncQueDeploymentName, 1, nil),
+		maintenanceTestDeploymentConfig(component.BackendWorkerName, 1, nil),
+	)
+
+	// system-app is not a worker, so it is not scaled down
+	if _, err := reconciler.Reconcile(); err != nil {
+		t.Fatal(err)
+	}
+	if sidekiq := maintenanceTestGetDC(t, cl, component.SystemSidekiqName); sidekiq.Spec.Replicas != 0 {
+		t.Errorf("sidekiq replicas: expected 0, got %d", sidekiq.Spec.Replicas)
+	}
+
+	sidekiq := maintenanceTestGetDC(t, cl, component.SystemSidekiqName)
+	sidekiq.Status.Replicas = 0
+	if err := cl.Update(context.TODO(), sidekiq); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 4; i++ {
+		if _, err := reconciler.Reconcile(); err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range []string{component.ZyncQueDeploymentName, component.BackendWorkerName} {
+			dc := maintenanceTestGetDC(t, cl, name)
+			if dc.Spec.Replicas == 0 && dc.Status.Replicas != 0 {
+				dc.Status.Replicas = 0
+				if err := cl.Update(context.TODO(), dc); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	}
+
+	for _, name := range []string{component.ZyncQueDeploymentName, component.BackendWorkerName} {
+		if dc := maintenanceTestGetDC(t, cl, name); dc.Spec.Replicas != 0 {
+			t.Errorf("%s replicas: expected 0, got %d", name, dc.Spec.Replicas)
+		}
+	}
+
+	// system-app, scaled down by a previous maintenance, is restored while the workers are paused
+	if systemApp := maintenanceTestGetDC(t, cl, component.SystemAppDeploymentName); systemApp.Spec.Replicas != 2 {
+		t.Errorf("system-app replicas: expected 2, got %d", systemApp.Spec.Replicas)
+	}
+}
+
+func TestMaintenanceModeMutator(t *testing.T) {
+	desired := maintenanceTestDeploymentConfig(component.SystemAppDeploymentName, 3, nil)
+
+	cases := []struct {
+		testName         string
+		existing         client.Object
+		expectedReplicas int32
+	}{
+		{"DeploymentConfigNotInMaintenance", maintenanceTestDeploymentConfig(component.SystemAppDeploymentName, 1, nil), 3},
+		{"DeploymentConfigInMaintenance", maintenanceTestDeploymentConfig(component.SystemAppDeploymentName, 0,
+			map[string]string{appsv1alpha1.MaintenanceModeReplicasAnnotation: "1"}), 0},
+		{"DeploymentInMaintenance", &k8sappsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        component.SystemAppDeploymentName,
+				Annotations: map[string]string{appsv1alpha1.MaintenanceModeReplicasAnnotation: "1"},
+			},
+			Spec: k8sappsv1.DeploymentSpec{Replicas: &[]int32{0}[0]},
+		}, 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			mutator := MaintenanceModeMutator(reconcilers.DeploymentConfigMutator(reconcilers.DeploymentConfigReplicasMutator))
+			if dep, ok := tc.existing.(*k8sappsv1.Deployment); ok {
+				// Deployments are mutated by the Deployment equivalent of the DeploymentConfig mutators
+				desiredDeployment := dep.DeepCopy()
+				desiredDeployment.Spec.Replicas = &[]int32{3}[0]
+				desiredDeployment.Annotations = nil
+				if _, err := mutator(dep, desiredDeployment); err != nil {
+					subT.Fatal(err)
+				}
+				if *dep.Spec.Replicas != tc.expectedReplicas {
+					subT.Errorf("replicas: expected %d, got %d", tc.expectedReplicas, *dep.Spec.Replicas)
+				}
+				return
+			}
+
+			existing := tc.existing.(*appsv1.DeploymentConfig)
+			if _, err := mutator(existing, desired); err != nil {
+				subT.Fatal(err)
+			}
+			if existing.Spec.Replicas != tc.expectedReplicas {
+				subT.Errorf("replicas: expected %d, got %d", tc.expectedReplicas, existing.Spec.Replicas)
+			}
+		})
+	}
+}
+
+func TestMaintenanceModeCondition(t *testing.T) {
+	scaledDown := map[string]string{appsv1alpha1.MaintenanceModeReplicasAnnotation: "1"}
+
+	allScaledDown := []appsv1.DeploymentConfig{
+		*maintenanceTestDeploymentConfig(component.SystemAppDeploymentName, 0, scaledDown),
+		*maintenanceTestDeploymentConfig(component.SystemSidekiqName, 0, scaledDown),
+		*maintenanceTestDeploymentConfig(component.ZyncQueDeploymentName, 0, scaledDown),
+		*maintenanceTestDeploymentConfig(component.BackendWorkerName, 0, scaledDown),
+	}
+	partiallyScaledDown := []appsv1.DeploymentConfig{
+		*maintenanceTestDeploymentConfig(component.SystemAppDeploymentName, 0, scaledDown),
+		*maintenanceTestDeploymentConfig(component.SystemSidekiqName, 1, nil),
+		*maintenanceTestDeploymentConfig(component.ZyncQueDeploymentName, 1, nil),
+		*maintenanceTestDeploymentConfig(component.BackendWorkerName, 1, nil),
+	}
+	noneScaledDown := []appsv1.DeploymentConfig{
+		*maintenanceTestDeploymentConfig(component.SystemAppDeploymentName, 1, nil),
+	}
+
+	cases := []struct {
+		testName          string
+		maintenanceMode   bool
+		deploymentConfigs []appsv1.DeploymentConfig
+		expectedStatus    v1.ConditionStatus
+		expectedReason    string
+	}{
+		{"ScalingDown", true, partiallyScaledDown, v1.ConditionTrue, "ScalingDown"},
+		{"Active", true, allScaledDown, v1.ConditionTrue, "Active"},
+		{"Restoring", false, partiallyScaledDown, v1.ConditionTrue, "Restoring"},
+		{"Inactive", false, noneScaledDown, v1.ConditionFalse, "Inactive"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apimanager := basicApimanager()
+			if tc.maintenanceMode {
+				apimanager.Annotations = map[string]string{appsv1alpha1.MaintenanceModeAnnotation: "true"}
+			}
+
+			condition := MaintenanceModeCondition(apimanager, tc.deploymentConfigs, nil)
+			if condition.Status != tc.expectedStatus {
+				subT.Errorf("status: expected %s, got %s", tc.expectedStatus, condition.Status)
+			}
+			if string(condition.Reason) != tc.expectedReason {
+				subT.Errorf("reason: expected %s, got %s", tc.expectedReason, condition.Reason)
+			}
+		})
+	}
+}
+
+func TestMaintenanceModeConditionPausedWorkers(t *testing.T) {
+	scaledDown := map[string]string{appsv1alpha1.MaintenanceModeReplicasAnnotation: "1"}
+
+	workersScaledDown := []appsv1.DeploymentConfig{
+		*maintenanceTestDeploymentConfig(component.SystemAppDeploymentName, 1, nil),
+		*maintenanceTestDeploymentConfig(component.SystemSidekiqName, 0, scaledDown),
+		*maintenanceTestDeploymentConfig(component.ZyncQueDeploymentName, 0, scaledDown),
+		*maintenanceTestDeploymentConfig(component.BackendWorkerName, 0, scaledDown),
+	}
+	workersRunning := []appsv1.DeploymentConfig{
+		*maintenanceTestDeploymentConfig(component.SystemAppDeploymentName, 1, nil),
+		*maintenanceTestDeploymentConfig(component.SystemSidekiqName, 0, scaledDown),
+		*maintenanceTestDeploymentConfig(component.ZyncQueDeploymentName, 1, nil),
+		*maintenanceTestDeploymentConfig(component.BackendWorkerName, 1, nil),
+	}
+
+	cases := []struct {
+		testName          string
+		deploymentConfigs []appsv1.DeploymentConfig
+		expectedReason    string
+	}{
+		{"ScalingDown", workersRunning, "ScalingDown"},
+		{"WorkersPaused", workersScaledDown, "WorkersPaused"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apimanager := basicApimanager()
+			apimanager.Annotations = map[string]string{appsv1alpha1.PauseWorkersAnnotation: "true"}
+
+			condition := MaintenanceModeCondition(apimanager, tc.deploymentConfigs, nil)
+			if condition.Status != v1.ConditionTrue {
+				subT.Errorf("status: expected True, got %s", condition.Status)
+			}
+			if string(condition.Reason) != tc.expectedReason {
+				subT.Errorf("reason: expected %s, got %s", tc.expectedReason, condition.Reason)
+			}
+		})
+	}
+}
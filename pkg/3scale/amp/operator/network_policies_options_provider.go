@@ -0,0 +1,50 @@
+package operator
+
+import (
+	"fmt"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+)
+
+type NetworkPoliciesOptionsProvider struct {
+	apimanager *appsv1alpha1.APIManager
+	options    *component.NetworkPoliciesOptions
+}
+
+func NewNetworkPoliciesOptionsProvider(apimanager *appsv1alpha1.APIManager) *NetworkPoliciesOptionsProvider {
+	return &NetworkPoliciesOptionsProvider{
+		apimanager: apimanager,
+		options:    component.NewNetworkPoliciesOptions(),
+	}
+}
+
+func (n *NetworkPoliciesOptionsProvider) GetNetworkPoliciesOptions() (*component.NetworkPoliciesOptions, error) {
+	internalSystemDatabase := !n.apimanager.IsExternal(appsv1alpha1.SystemDatabase)
+	n.options.SystemPostgreSQLEnabled = internalSystemDatabase && n.apimanager.IsSystemPostgreSQLEnabled()
+	n.options.SystemMySQLEnabled = internalSystemDatabase && !n.apimanager.IsSystemPostgreSQLEnabled()
+	n.options.SystemRedisEnabled = !n.apimanager.IsExternal(appsv1alpha1.SystemRedis)
+	n.options.BackendRedisEnabled = !n.apimanager.IsExternal(appsv1alpha1.BackendRedis)
+	n.options.ZyncDatabaseEnabled = !n.apimanager.IsExternal(appsv1alpha1.ZyncDatabase)
+	n.options.CommonLabels = n.commonLabels()
+
+	if n.apimanager.Spec.NetworkPolicies != nil {
+		n.options.AllowedNamespaces = n.apimanager.Spec.NetworkPolicies.AllowedNamespaces
+	}
+
+	if n.apimanager.IsIngressEnabled() {
+		n.options.IngressControllerNamespace = n.apimanager.Spec.Ingress.ControllerNamespace
+	}
+
+	err := n.options.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("GetNetworkPoliciesOptions validating: %w", err)
+	}
+	return n.options, nil
+}
+
+func (n *NetworkPoliciesOptionsProvider) commonLabels() map[string]string {
+	return map[string]string{
+		"app": *n.apimanager.Spec.AppLabel,
+	}
+}
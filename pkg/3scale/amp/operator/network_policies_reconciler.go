@@ -0,0 +1,67 @@
+package operator
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+)
+
+// NetworkPoliciesReconciler reconciles the NetworkPolicies of the internal components.
+// Policies of external dependencies are deleted, as well as all the policies when disabled
+type NetworkPoliciesReconciler struct {
+	*BaseAPIManagerLogicReconciler
+}
+
+func NewNetworkPoliciesReconciler(baseAPIManagerLogicReconciler *BaseAPIManagerLogicReconciler) *NetworkPoliciesReconciler {
+	return &NetworkPoliciesReconciler{
+		BaseAPIManagerLogicReconciler: baseAPIManagerLogicReconciler,
+	}
+}
+
+func (r *NetworkPoliciesReconciler) Reconcile() (reconcile.Result, error) {
+	networkPolicies, err := NetworkPolicies(r.apiManager)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	desiredPolicies := []struct {
+		networkPolicy *networkingv1.NetworkPolicy
+		enabled       bool
+	}{
+		{networkPolicies.SystemMySQLNetworkPolicy(), networkPolicies.Options.SystemMySQLEnabled},
+		{networkPolicies.SystemPostgreSQLNetworkPolicy(), networkPolicies.Options.SystemPostgreSQLEnabled},
+		{networkPolicies.SystemRedisNetworkPolicy(), networkPolicies.Options.SystemRedisEnabled},
+		{networkPolicies.BackendRedisNetworkPolicy(), networkPolicies.Options.BackendRedisEnabled},
+		{networkPolicies.ZyncDatabaseNetworkPolicy(), networkPolicies.Options.ZyncDatabaseEnabled},
+		{networkPolicies.SystemMemcachedNetworkPolicy(), true},
+		{networkPolicies.SystemSearchdNetworkPolicy(), true},
+		{networkPolicies.ZyncNetworkPolicy(), true},
+		{networkPolicies.BackendListenerNetworkPolicy(), true},
+	}
+
+	for _, desired := range desiredPolicies {
+		if !desired.enabled || !r.apiManager.IsNetworkPoliciesEnabled() {
+			common.TagObjectToDelete(desired.networkPolicy)
+		}
+
+		err = r.ReconcileNetworkPolicy(desired.networkPolicy, reconcilers.GenericNetworkPolicyMutator)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+func NetworkPolicies(apimanager *appsv1alpha1.APIManager) (*component.NetworkPolicies, error) {
+	optsProvider := NewNetworkPoliciesOptionsProvider(apimanager)
+	opts, err := optsProvider.GetNetworkPoliciesOptions()
+	if err != nil {
+		return nil, err
+	}
+	return component.NewNetworkPolicies(opts), nil
+}
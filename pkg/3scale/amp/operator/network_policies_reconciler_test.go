@@ -0,0 +1,159 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestNetworkPoliciesReconciler(t *testing.T) {
+	log := logf.Log.WithName("operator_test")
+	ctx := context.TODO()
+	apimanager := basicApimanager()
+	apimanager.Spec.NetworkPolicies = &appsv1alpha1.NetworkPoliciesSpec{
+		Enabled:           true,
+		AllowedNamespaces: []string{"openshift-monitoring"},
+	}
+	apimanager.Spec.ExternalComponents = &appsv1alpha1.ExternalComponentsSpec{
+		Backend: &appsv1alpha1.ExternalBackendComponents{Redis: &[]bool{true}[0]},
+	}
+	apimanager.Spec.Ingress = &appsv1alpha1.IngressSpec{Enabled: true, ControllerNamespace: &[]string{"ingress-nginx"}[0]}
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+
+	// Objects to track in the fake client.
+	objs := []runtime.Object{apimanager}
+
+	// Create a fake client to mock API calls.
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+
+	baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, clientAPIReader, log, clientset.Discovery(), recorder)
+	baseAPIManagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager)
+
+	reconciler := NewNetworkPoliciesReconciler(baseAPIManagerLogicReconciler)
+	_, err := reconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	systemPeers := []string{"system-app", "system-sidekiq", "system-master", "system-provider", "system-developer"}
+
+	cases := []struct {
+		testName                  string
+		objName                   string
+		expectedPort              int
+		expectedPeers             []string
+		expectedToExist           bool
+		expectedHookPods          bool
+		expectedIngressController bool
+	}{
+		{"systemMySQL", "system-mysql", 3306, systemPeers, true, true, false},
+		{"systemPostgreSQL", "system-postgresql", 5432, nil, false, false, false},
+		{"systemRedis", "system-redis", 6379, systemPeers, true, true, false},
+		{"backendRedisExternal", "backend-redis", 6379, nil, false, false, false},
+		{"systemMemcached", "system-memcache", 11211, systemPeers, true, true, false},
+		{"systemSearchd", "system-searchd", 9306, systemPeers, true, true, false},
+		{"zyncDatabase", "zync-database", 5432, []string{"zync", "zync-que"}, true, false, false},
+		{"zync", "zync", 8080, systemPeers, true, false, false},
+		{"backendListener", "backend-listener", 3000, append([]string{"apicast-staging", "apicast-production"}, systemPeers...), true, true, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			networkPolicy := &networkingv1.NetworkPolicy{}
+			namespacedName := types.NamespacedName{Name: tc.objName, Namespace: namespace}
+			err := cl.Get(context.TODO(), namespacedName, networkPolicy)
+			if !tc.expectedToExist {
+				if !errors.IsNotFound(err) {
+					subT.Fatalf("network policy %s not expected, got: %v", tc.objName, err)
+				}
+				return
+			}
+			if err != nil {
+				subT.Fatalf("error fetching object %s: %v", tc.objName, err)
+			}
+
+			if networkPolicy.Spec.PodSelector.MatchLabels["deploymentConfig"] != tc.objName {
+				subT.Errorf("unexpected pod selector: %v", networkPolicy.Spec.PodSelector)
+			}
+			if len(networkPolicy.Spec.Ingress) != 2 {
+				subT.Fatalf("unexpected ingress rules: %v", networkPolicy.Spec.Ingress)
+			}
+
+			componentRule := networkPolicy.Spec.Ingress[0]
+			if len(componentRule.Ports) != 1 || componentRule.Ports[0].Port.IntValue() != tc.expectedPort {
+				subT.Errorf("unexpected ports: %v", componentRule.Ports)
+			}
+
+			peers := map[string]bool{}
+			hookPods := false
+			ingressController := false
+			for _, peer := range componentRule.From {
+				if peer.NamespaceSelector != nil && peer.NamespaceSelector.MatchLabels[v1.LabelMetadataName] == "ingress-nginx" {
+					ingressController = true
+				}
+				if peer.PodSelector == nil {
+					continue
+				}
+				if name, ok := peer.PodSelector.MatchLabels["deploymentConfig"]; ok {
+					peers[name] = true
+				}
+				for _, expression := range peer.PodSelector.MatchExpressions {
+					if expression.Key == component.DeploymentConfigHookPodTypeLabel {
+						hookPods = true
+					}
+				}
+			}
+			if len(peers) != len(tc.expectedPeers) {
+				subT.Errorf("unexpected peers: %v", peers)
+			}
+			for _, name := range tc.expectedPeers {
+				if !peers[name] {
+					subT.Errorf("peer %s not allowed", name)
+				}
+			}
+			if hookPods != tc.expectedHookPods {
+				subT.Errorf("hook pods allowed: %t", hookPods)
+			}
+			if ingressController != tc.expectedIngressController {
+				subT.Errorf("ingress controller namespace allowed: %t", ingressController)
+			}
+
+			namespacesRule := networkPolicy.Spec.Ingress[1]
+			if len(namespacesRule.Ports) != 0 || len(namespacesRule.From) != 1 ||
+				namespacesRule.From[0].NamespaceSelector.MatchExpressions[0].Values[0] != "openshift-monitoring" {
+				subT.Errorf("unexpected allowed namespaces rule: %v", namespacesRule)
+			}
+		})
+	}
+
+	// Disabling network policies deletes them
+	apimanager.Spec.NetworkPolicies.Enabled = false
+	_, err = reconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	networkPolicy := &networkingv1.NetworkPolicy{}
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: "system-mysql", Namespace: namespace}, networkPolicy)
+	if !errors.IsNotFound(err) {
+		t.Fatalf("network policy expected to be deleted, got: %v", err)
+	}
+}
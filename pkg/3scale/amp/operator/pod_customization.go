@@ -0,0 +1,214 @@
+package operator
+
+import (
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+// PodCustomization returns the pod customization of the component with the given DeploymentConfig name.
+// The sidekiq pools inherit the customization of system-sidekiq.
+// Returns nil when the component does not support it or it is not set
+func PodCustomization(apimanager *appsv1alpha1.APIManager, name string) *appsv1alpha1.PodCustomizationSpec {
+	spec := apimanager.Spec
+	if isSidekiqPoolName(apimanager, name) {
+		name = component.SystemSidekiqName
+	}
+
+	switch name {
+	case component.ApicastProductionName:
+		if spec.Apicast != nil && spec.Apicast.ProductionSpec != nil {
+			return &spec.Apicast.ProductionSpec.PodCustomizationSpec
+		}
+	case component.ApicastStagingName:
+		if spec.Apicast != nil && spec.Apicast.StagingSpec != nil {
+			return &spec.Apicast.StagingSpec.PodCustomizationSpec
+		}
+	case component.BackendListenerName:
+		if spec.Backend != nil && spec.Backend.ListenerSpec != nil {
+			return &spec.Backend.ListenerSpec.PodCustomizationSpec
+		}
+	case component.BackendWorkerName:
+		if spec.Backend != nil && spec.Backend.WorkerSpec != nil {
+			return &spec.Backend.WorkerSpec.PodCustomizationSpec
+		}
+	case component.BackendCronName:
+		if spec.Backend != nil && spec.Backend.CronSpec != nil {
+			return &spec.Backend.CronSpec.PodCustomizationSpec
+		}
+	case component.SystemAppDeploymentName, component.SystemMasterDeploymentName,
+		component.SystemProviderDeploymentName, component.SystemDeveloperDeploymentName:
+		if spec.System != nil && spec.System.AppSpec != nil {
+			return &spec.System.AppSpec.PodCustomizationSpec
+		}
+	case component.SystemSidekiqName:
+		if spec.System != nil && spec.System.SidekiqSpec != nil {
+			return &spec.System.SidekiqSpec.PodCustomizationSpec
+		}
+	case component.SystemSearchdDeploymentName:
+		if spec.System != nil && spec.System.SearchdSpec != nil {
+			return &spec.System.SearchdSpec.PodCustomizationSpec
+		}
+	case component.ZyncName:
+		if spec.Zync != nil && spec.Zync.AppSpec != nil {
+			return &spec.Zync.AppSpec.PodCustomizationSpec
+		}
+	case component.ZyncQueDeploymentName:
+		if spec.Zync != nil && spec.Zync.QueSpec != nil {
+			return &spec.Zync.QueSpec.PodCustomizationSpec
+		}
+	}
+	return nil
+}
+
+func isSidekiqPoolName(apimanager *appsv1alpha1.APIManager, name string) bool {
+	if apimanager.Spec.System == nil {
+		return false
+	}
+	for _, pool := range apimanager.Spec.System.SidekiqPools {
+		if component.SidekiqPoolName(pool.Name) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyPodCustomization merges the pod customization into the desired DeploymentConfig.
+// The added items are recorded in the DeploymentConfig annotations,
+// so they are removed once no longer desired, see reconcilers.DeploymentConfigPodCustomizationMutator
+func ApplyPodCustomization(dc *appsv1.DeploymentConfig, customization *appsv1alpha1.PodCustomizationSpec) {
+	if customization == nil || dc.Spec.Template == nil {
+		return
+	}
+
+	record := func(kind, name string) {
+		if dc.Annotations == nil {
+			dc.Annotations = map[string]string{}
+		}
+		key, value := reconcilers.PodCustomizationAnnotation(kind, name)
+		dc.Annotations[key] = value
+	}
+
+	podSpec := &dc.Spec.Template.Spec
+
+	// Environment, volume mounts, security context and probes apply to the component containers, not to the sidecars
+	for idx := range podSpec.Containers {
+		container := &podSpec.Containers[idx]
+
+		for _, envVar := range customization.ExtraEnv {
+			container.Env = setEnvVar(container.Env, *envVar.DeepCopy())
+			record(reconcilers.PodCustomizationEnvKind, envVar.Name)
+		}
+
+		for _, volumeMount := range customization.ExtraVolumeMounts {
+			container.VolumeMounts = setVolumeMount(container.VolumeMounts, volumeMount)
+			record(reconcilers.PodCustomizationVolumeMountKind, volumeMount.MountPath)
+		}
+
+		if customization.SecurityContext != nil {
+			container.SecurityContext = customization.SecurityContext.DeepCopy()
+			record(reconcilers.PodCustomizationSecurityContextKind, "")
+		}
+
+		if customization.LivenessProbe != nil {
+			container.LivenessProbe = mergeProbe(container.LivenessProbe, customization.LivenessProbe)
+			record(reconcilers.PodCustomizationLivenessProbeKind, "")
+		}
+
+		if customization.ReadinessProbe != nil {
+			container.ReadinessProbe = mergeProbe(container.ReadinessProbe, customization.ReadinessProbe)
+			record(reconcilers.PodCustomizationReadinessProbeKind, "")
+		}
+
+		if customization.StartupProbe != nil {
+			container.StartupProbe = mergeProbe(container.StartupProbe, customization.StartupProbe)
+			record(reconcilers.PodCustomizationStartupProbeKind, "")
+		}
+	}
+
+	for _, volume := range customization.ExtraVolumes {
+		podSpec.Volumes = setVolume(podSpec.Volumes, *volume.DeepCopy())
+		record(reconcilers.PodCustomizationVolumeKind, volume.Name)
+	}
+
+	for idx := range customization.Sidecars {
+		podSpec.Containers = append(podSpec.Containers, *customization.Sidecars[idx].DeepCopy())
+		record(reconcilers.PodCustomizationSidecarKind, customization.Sidecars[idx].Name)
+	}
+
+	for idx := range customization.InitContainers {
+		podSpec.InitContainers = append(podSpec.InitContainers, *customization.InitContainers[idx].DeepCopy())
+		record(reconcilers.PodCustomizationInitContainerKind, customization.InitContainers[idx].Name)
+	}
+
+	if customization.PodSecurityContext != nil {
+		podSpec.SecurityContext = customization.PodSecurityContext.DeepCopy()
+		record(reconcilers.PodCustomizationPodSecurityContextKind, "")
+	}
+}
+
+func setEnvVar(envVars []v1.EnvVar, envVar v1.EnvVar) []v1.EnvVar {
+	for idx := range envVars {
+		if envVars[idx].Name == envVar.Name {
+			envVars[idx] = envVar
+			return envVars
+		}
+	}
+	return append(envVars, envVar)
+}
+
+func setVolumeMount(volumeMounts []v1.VolumeMount, volumeMount v1.VolumeMount) []v1.VolumeMount {
+	for idx := range volumeMounts {
+		if volumeMounts[idx].MountPath == volumeMount.MountPath {
+			volumeMounts[idx] = volumeMount
+			return volumeMounts
+		}
+	}
+	return append(volumeMounts, volumeMount)
+}
+
+func setVolume(volumes []v1.Volume, volume v1.Volume) []v1.Volume {
+	for idx := range volumes {
+		if volumes[idx].Name == volume.Name {
+			volumes[idx] = volume
+			return volumes
+		}
+	}
+	return append(volumes, volume)
+}
+
+// mergeProbe returns the override when it sets a handler.
+// Otherwise only the timing and threshold fields set in the override are applied to the probe
+func mergeProbe(probe, override *v1.Probe) *v1.Probe {
+	if override.Exec != nil || override.HTTPGet != nil || override.TCPSocket != nil || override.GRPC != nil {
+		return override.DeepCopy()
+	}
+	// Nothing to tune
+	if probe == nil {
+		return nil
+	}
+
+	result := probe.DeepCopy()
+	if override.InitialDelaySeconds != 0 {
+		result.InitialDelaySeconds = override.InitialDelaySeconds
+	}
+	if override.TimeoutSeconds != 0 {
+		result.TimeoutSeconds = override.TimeoutSeconds
+	}
+	if override.PeriodSeconds != 0 {
+		result.PeriodSeconds = override.PeriodSeconds
+	}
+	if override.SuccessThreshold != 0 {
+		result.SuccessThreshold = override.SuccessThreshold
+	}
+	if override.FailureThreshold != 0 {
+		result.FailureThreshold = override.FailureThreshold
+	}
+	if override.TerminationGracePeriodSeconds != nil {
+		result.TerminationGracePeriodSeconds = override.TerminationGracePeriodSeconds
+	}
+	return result
+}
@@ -0,0 +1,173 @@
+package operator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	configv1 "github.com/openshift/api/config/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestApplyPodCustomization(t *testing.T) {
+	dc := &appsv1.DeploymentConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: component.BackendListenerName},
+		Spec: appsv1.DeploymentConfigSpec{
+			Template: &v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: "listener",
+							Env:  []v1.EnvVar{{Name: "CONFIG_LOG_PATH", Value: "/dev/stdout"}},
+							LivenessProbe: &v1.Probe{
+								ProbeHandler:        v1.ProbeHandler{TCPSocket: &v1.TCPSocketAction{}},
+								InitialDelaySeconds: 30,
+								PeriodSeconds:       10,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	customization := &appsv1alpha1.PodCustomizationSpec{
+		ExtraEnv: []v1.EnvVar{
+			{Name: "CONFIG_LOG_PATH", Value: "/tmp/log"},
+			{Name: "POD_NAME", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.name"}}},
+		},
+		ExtraVolumes:      []v1.Volume{{Name: "ca", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{}}}},
+		ExtraVolumeMounts: []v1.VolumeMount{{Name: "ca", MountPath: "/etc/ca"}},
+		Sidecars:          []v1.Container{{Name: "proxy", Image: "proxy:latest"}},
+		InitContainers:    []v1.Container{{Name: "init", Image: "init:latest"}},
+		LivenessProbe:     &v1.Probe{InitialDelaySeconds: 60},
+	}
+
+	ApplyPodCustomization(dc, customization)
+
+	podSpec := dc.Spec.Template.Spec
+	if len(podSpec.Containers) != 2 || podSpec.Containers[1].Name != "proxy" {
+		t.Fatalf("unexpected containers: %v", podSpec.Containers)
+	}
+	if len(podSpec.InitContainers) != 1 || len(podSpec.Volumes) != 1 {
+		t.Fatalf("unexpected init containers or volumes: %v %v", podSpec.InitContainers, podSpec.Volumes)
+	}
+
+	listener := podSpec.Containers[0]
+	if !reflect.DeepEqual(listener.Env, customization.ExtraEnv) {
+		t.Errorf("unexpected env: %v", listener.Env)
+	}
+	if !reflect.DeepEqual(listener.VolumeMounts, customization.ExtraVolumeMounts) {
+		t.Errorf("unexpected volume mounts: %v", listener.VolumeMounts)
+	}
+	if listener.LivenessProbe.TCPSocket == nil || listener.LivenessProbe.InitialDelaySeconds != 60 || listener.LivenessProbe.PeriodSeconds != 10 {
+		t.Errorf("unexpected liveness probe: %v", listener.LivenessProbe)
+	}
+	// Sidecars are not customized
+	if len(podSpec.Containers[1].Env) != 0 || podSpec.Containers[1].LivenessProbe != nil {
+		t.Errorf("unexpected sidecar: %v", podSpec.Containers[1])
+	}
+
+	// env/CONFIG_LOG_PATH, env/POD_NAME, volume/ca, volumeMount//etc/ca, sidecar/proxy, initContainer/init, livenessProbe/
+	if len(dc.Annotations) != 7 {
+		t.Errorf("unexpected annotations: %v", dc.Annotations)
+	}
+}
+
+func TestPodCustomizationSidekiqPools(t *testing.T) {
+	apimanager := &appsv1alpha1.APIManager{
+		Spec: appsv1alpha1.APIManagerSpec{
+			System: &appsv1alpha1.SystemSpec{
+				SidekiqSpec: &appsv1alpha1.SystemSidekiqSpec{
+					PodCustomizationSpec: appsv1alpha1.PodCustomizationSpec{
+						ExtraEnv: []v1.EnvVar{{Name: "RAILS_LOG_LEVEL", Value: "debug"}},
+					},
+				},
+				SidekiqPools: []appsv1alpha1.SystemSidekiqPoolSpec{
+					{Name: "low", Queues: []appsv1alpha1.SidekiqQueueSpec{{Name: "low"}}},
+				},
+			},
+		},
+	}
+
+	customization := PodCustomization(apimanager, component.SidekiqPoolName("low"))
+	if customization == nil || !reflect.DeepEqual(customization.ExtraEnv, apimanager.Spec.System.SidekiqSpec.ExtraEnv) {
+		t.Errorf("expected the pool to inherit the system-sidekiq customization, got %v", customization)
+	}
+
+	if customization := PodCustomization(apimanager, component.SidekiqPoolName("unknown")); customization != nil {
+		t.Errorf("unexpected customization for an unknown pool: %v", customization)
+	}
+}
+
+func TestPodCustomizationReconcile(t *testing.T) {
+	var (
+		log            = logf.Log.WithName("operator_test")
+		oneValue int64 = 1
+	)
+	ctx := context.TODO()
+	s := scheme.Scheme
+	if err := appsv1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := appsv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := configv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := routev1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	apimanager := backendApiManagerCreator(&oneValue, &oneValue, &oneValue)
+	apimanager.Spec.Backend.ListenerSpec.Sidecars = []v1.Container{{Name: "proxy", Image: "proxy:latest"}}
+
+	cl := fake.NewFakeClient(apimanager)
+	clientAPIReader := fake.NewFakeClient(apimanager)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+	baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, clientAPIReader, log, clientset.Discovery(), recorder)
+	backendReconciler := NewBackendReconciler(NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager))
+
+	listenerContainers := func() []v1.Container {
+		dc := &appsv1.DeploymentConfig{}
+		err := cl.Get(ctx, types.NamespacedName{Name: component.BackendListenerName, Namespace: apimanager.Namespace}, dc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return dc.Spec.Template.Spec.Containers
+	}
+
+	// Reconciled twice, the sidecar is kept
+	for i := 0; i < 2; i++ {
+		if _, err := backendReconciler.Reconcile(); err != nil {
+			t.Fatal(err)
+		}
+		containers := listenerContainers()
+		if len(containers) != 2 || containers[1].Name != "proxy" {
+			t.Fatalf("unexpected containers: %v", containers)
+		}
+	}
+
+	apimanager.Spec.Backend.ListenerSpec.Sidecars = nil
+	if _, err := backendReconciler.Reconcile(); err != nil {
+		t.Fatal(err)
+	}
+	if containers := listenerContainers(); len(containers) != 1 {
+		t.Fatalf("sidecar expected to be removed, got: %v", containers)
+	}
+}
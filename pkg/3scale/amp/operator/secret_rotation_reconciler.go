@@ -0,0 +1,593 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+const (
+	// SecretRotationSecretName is the secret recording the secret rotation.
+	// It holds the new values while a rotation is in progress
+	SecretRotationSecretName = "apimanager-secret-rotation"
+
+	// SecretRotationAnnotation identifies the rotation in progress. It is set on the rotation secret
+	// and on the pod templates of the components rolled out by the rotation
+	SecretRotationAnnotation = "apps.3scale.net/secret-rotation"
+
+	// SecretRotationPhaseAnnotation is the phase of the rotation in progress
+	SecretRotationPhaseAnnotation = "apps.3scale.net/secret-rotation-phase"
+
+	// LastSecretRotationAnnotation is the time the last rotation completed
+	LastSecretRotationAnnotation = "apps.3scale.net/last-secret-rotation"
+
+	// SecretRotationJobName is the job updating the system access tokens in the system database
+	SecretRotationJobName = "system-access-token-rotation"
+)
+
+const (
+	// SecretRotationPhaseDatabases rotates the internal database passwords
+	SecretRotationPhaseDatabases = "Databases"
+	// SecretRotationPhaseCredentials rotates the system access tokens,
+	// the backend internal API password and the system secret key base
+	SecretRotationPhaseCredentials = "Credentials"
+)
+
+// accessTokenRotationScript replaces the system-seed access tokens in the system database.
+// It is idempotent: it succeeds when the new tokens are already in place
+const accessTokenRotationScript = `%w[MASTER ADMIN].each do |name|
+  old_value = ENV.fetch("OLD_#{name}_ACCESS_TOKEN")
+  new_value = ENV.fetch("NEW_#{name}_ACCESS_TOKEN")
+  AccessToken.where(value: old_value).update_all(value: new_value)
+  raise "#{name} access token not found" unless AccessToken.exists?(value: new_value)
+end`
+
+// SecretRotationReconciler periodically rotates the secrets generated by the operator.
+// New values are staged in the rotation secret and rolled out in two phases,
+// so the components keep running with matching credentials:
+//   - Databases: the internal database passwords are updated in the secrets, the databases are
+//     rolled out, as the database images set the passwords from the environment on startup,
+//     and then the components connecting to them.
+//   - Credentials: a job replaces the system-seed access tokens in the system database.
+//     The tokens, the backend internal API password and the system secret key base are updated
+//     in the secrets, then the system components and backend-listener are rolled out.
+//
+// A rotation starts only when the APIManager is available and not in maintenance mode.
+// A rotation in progress is completed even when the rotation is disabled.
+type SecretRotationReconciler struct {
+	*BaseAPIManagerLogicReconciler
+	now func() time.Time
+}
+
+func NewSecretRotationReconciler(baseAPIManagerLogicReconciler *BaseAPIManagerLogicReconciler) *SecretRotationReconciler {
+	return &SecretRotationReconciler{
+		BaseAPIManagerLogicReconciler: baseAPIManagerLogicReconciler,
+		now:                           time.Now,
+	}
+}
+
+func (r *SecretRotationReconciler) Reconcile() (reconcile.Result, error) {
+	rotationSecret, err := r.rotationSecret()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	phase := ""
+	if rotationSecret != nil {
+		phase = rotationSecret.Annotations[SecretRotationPhaseAnnotation]
+	}
+
+	switch phase {
+	case SecretRotationPhaseDatabases:
+		return r.rotateDatabases(rotationSecret)
+	case SecretRotationPhaseCredentials:
+		return r.rotateCredentials(rotationSecret)
+	case "":
+	default:
+		return reconcile.Result{}, fmt.Errorf("unknown secret rotation phase '%s' in secret %s", phase, SecretRotationSecretName)
+	}
+
+	if !r.apiManager.IsSecretRotationEnabled() || r.apiManager.IsMaintenanceModeEnabled() {
+		return reconcile.Result{}, nil
+	}
+
+	now := r.now()
+	next := NextSecretRotationTime(r.apiManager, rotationSecret)
+	if now.Before(next) {
+		return reconcile.Result{RequeueAfter: next.Sub(now)}, nil
+	}
+
+	// The components being rolled out by the rotation have to be available
+	if !r.apiManager.Status.Conditions.IsTrueFor(appsv1alpha1.APIManagerAvailableConditionType) {
+		r.logger.Info("Secret rotation: waiting for the APIManager to be available")
+		return reconcile.Result{}, nil
+	}
+
+	return r.start(rotationSecret)
+}
+
+func (r *SecretRotationReconciler) start(rotationSecret *v1.Secret) (reconcile.Result, error) {
+	values, err := r.newSecretValues()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	create := rotationSecret == nil
+	if create {
+		rotationSecret = &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      SecretRotationSecretName,
+				Namespace: r.apiManager.Namespace,
+			},
+		}
+		if err := r.SetOwnerReference(r.apiManager, rotationSecret); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	rotationID := r.now().UTC().Format(time.RFC3339)
+	if rotationSecret.Annotations == nil {
+		rotationSecret.Annotations = map[string]string{}
+	}
+	rotationSecret.Annotations[SecretRotationAnnotation] = rotationID
+	rotationSecret.Annotations[SecretRotationPhaseAnnotation] = SecretRotationPhaseDatabases
+	rotationSecret.Data = values
+
+	r.logger.Info("Secret rotation: starting", "rotation", rotationID)
+	if create {
+		err = r.CreateResource(rotationSecret)
+	} else {
+		err = r.UpdateResource(rotationSecret)
+	}
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	r.EventRecorder().Eventf(r.apiManager, v1.EventTypeNormal, "SecretRotationStarted", "Secret rotation %s started", rotationID)
+
+	return reconcile.Result{Requeue: true}, nil
+}
+
+func (r *SecretRotationReconciler) rotateDatabases(rotationSecret *v1.Secret) (reconcile.Result, error) {
+	rotationID := rotationSecret.Annotations[SecretRotationAnnotation]
+
+	// The component reconcilers pick up the new values before the rollout,
+	// e.g. the system-mysql root password, which is read from the database URL
+	updated, err := r.applySecretValues(rotationSecret, component.SystemSecretSystemDatabaseSecretName, component.ZyncSecretName)
+	if err != nil || updated {
+		return reconcile.Result{Requeue: updated}, err
+	}
+
+	databases := []string{}
+	clients := []string{}
+	if !appsv1alpha1.SystemDatabase(r.apiManager.Spec.ExternalComponents) {
+		if r.apiManager.Spec.System.DatabaseSpec != nil && r.apiManager.Spec.System.DatabaseSpec.PostgreSQL != nil {
+			databases = append(databases, component.SystemPostgreSQLDeploymentName)
+		} else {
+			databases = append(databases, component.SystemMySQLDeploymentName)
+		}
+		clients = append(clients, SystemComponents(r.apiManager)...)
+	}
+	if !appsv1alpha1.ZyncDatabase(r.apiManager.Spec.ExternalComponents) {
+		databases = append(databases, component.ZyncDatabaseDeploymentName)
+		clients = append(clients, component.ZyncName, component.ZyncQueDeploymentName)
+	}
+
+	// The databases are rolled out before the components connecting to them
+	for _, names := range [][]string{databases, clients} {
+		done, err := r.rollOut(rotationID, names...)
+		if err != nil || !done {
+			return reconcile.Result{}, err
+		}
+	}
+
+	rotationSecret.Annotations[SecretRotationPhaseAnnotation] = SecretRotationPhaseCredentials
+	r.logger.Info("Secret rotation: database passwords rotated", "rotation", rotationID)
+	if err := r.UpdateResource(rotationSecret); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{Requeue: true}, nil
+}
+
+func (r *SecretRotationReconciler) rotateCredentials(rotationSecret *v1.Secret) (reconcile.Result, error) {
+	rotationID := rotationSecret.Annotations[SecretRotationAnnotation]
+
+	// The access tokens are replaced in the database before the system-seed secret is updated
+	done, err := r.reconcileAccessTokenJob(rotationSecret)
+	if err != nil || !done {
+		return reconcile.Result{}, err
+	}
+
+	updated, err := r.applySecretValues(rotationSecret,
+		component.SystemSecretSystemSeedSecretName,
+		component.BackendSecretInternalApiSecretName,
+		component.SystemSecretSystemAppSecretName,
+	)
+	if err != nil || updated {
+		return reconcile.Result{Requeue: updated}, err
+	}
+
+	// backend-listener accepts a single internal API password, so the system components are rolled out first:
+	// the old system pods keep calling the old backend-listener during the long system rollout,
+	// and only the new system pods fail the internal API calls until the short backend-listener rollout completes
+	for _, names := range [][]string{
+		SystemComponents(r.apiManager),
+		{component.BackendListenerName},
+	} {
+		done, err := r.rollOut(rotationID, names...)
+		if err != nil || !done {
+			return reconcile.Result{}, err
+		}
+	}
+
+	job := &batchv1.Job{}
+	err = r.Client().Get(r.Context(), types.NamespacedName{Name: SecretRotationJobName, Namespace: r.apiManager.Namespace}, job)
+	if err != nil && !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+	if err == nil {
+		if err := r.DeleteResource(job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	// The new values are in the component secrets, they are not kept in the rotation secret
+	delete(rotationSecret.Annotations, SecretRotationAnnotation)
+	delete(rotationSecret.Annotations, SecretRotationPhaseAnnotation)
+	rotationSecret.Annotations[LastSecretRotationAnnotation] = r.now().UTC().Format(time.RFC3339)
+	rotationSecret.Data = nil
+
+	r.logger.Info("Secret rotation: completed", "rotation", rotationID)
+	if err := r.UpdateResource(rotationSecret); err != nil {
+		return reconcile.Result{}, err
+	}
+	r.EventRecorder().Eventf(r.apiManager, v1.EventTypeNormal, "SecretRotationCompleted", "Secret rotation %s completed", rotationID)
+
+	return reconcile.Result{Requeue: true}, nil
+}
+
+// newSecretValues generates the new values of the rotated secret fields.
+// The values are keyed by "<secret name>.<field name>"
+func (r *SecretRotationReconciler) newSecretValues() (map[string][]byte, error) {
+	values := map[string]string{}
+	secretSource := helper.NewSecretSource(r.Client(), r.apiManager.Namespace)
+
+	if !appsv1alpha1.SystemDatabase(r.apiManager.Spec.ExternalComponents) {
+		databaseURL, err := secretSource.RequiredFieldValueFromRequiredSecret(component.SystemSecretSystemDatabaseSecretName, component.SystemSecretSystemDatabaseURLFieldName)
+		if err != nil {
+			return nil, err
+		}
+
+		var password, urlPassword string
+		if r.apiManager.Spec.System.DatabaseSpec != nil && r.apiManager.Spec.System.DatabaseSpec.PostgreSQL != nil {
+			// The URL has the credentials of the system user
+			password = component.DefaultSystemPostgresqlPassword(r.randomGenerator)
+			urlPassword = password
+		} else {
+			// The URL has the credentials of the root user
+			password = component.DefaultSystemMysqlPassword(r.randomGenerator)
+			urlPassword = component.DefaultSystemMysqlRootPassword(r.randomGenerator)
+		}
+
+		newURL, err := databaseURLWithPassword(databaseURL, urlPassword)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' field of '%s' secret: %w", component.SystemSecretSystemDatabaseURLFieldName, component.SystemSecretSystemDatabaseSecretName, err)
+		}
+		values[secretRotationKey(component.SystemSecretSystemDatabaseSecretName, component.SystemSecretSystemDatabasePasswordFieldName)] = password
+		values[secretRotationKey(component.SystemSecretSystemDatabaseSecretName, component.SystemSecretSystemDatabaseURLFieldName)] = newURL
+	}
+
+	if !appsv1alpha1.ZyncDatabase(r.apiManager.Spec.ExternalComponents) {
+		databaseURL, err := secretSource.RequiredFieldValueFromRequiredSecret(component.ZyncSecretName, component.ZyncSecretDatabaseURLFieldName)
+		if err != nil {
+			return nil, err
+		}
+
+		password := component.DefaultZyncDatabasePassword(r.randomGenerator)
+		newURL, err := databaseURLWithPassword(databaseURL, password)
+		if err != nil {
+			return nil, fmt.Errorf("'%s' field of '%s' secret: %w", component.ZyncSecretDatabaseURLFieldName, component.ZyncSecretName, err)
+		}
+		values[secretRotationKey(component.ZyncSecretName, component.ZyncSecretDatabasePasswordFieldName)] = password
+		values[secretRotationKey(component.ZyncSecretName, component.ZyncSecretDatabaseURLFieldName)] = newURL
+	}
+
+	values[secretRotationKey(component.SystemSecretSystemSeedSecretName, component.SystemSecretSystemSeedMasterAccessTokenFieldName)] = component.DefaultSystemMasterAccessToken(r.randomGenerator)
+	values[secretRotationKey(component.SystemSecretSystemSeedSecretName, component.SystemSecretSystemSeedAdminAccessTokenFieldName)] = component.DefaultSystemAdminAccessToken(r.randomGenerator)
+	values[secretRotationKey(component.BackendSecretInternalApiSecretName, component.BackendSecretInternalApiPasswordFieldName)] = component.DefaultSystemBackendPassword(r.randomGenerator)
+	values[secretRotationKey(component.SystemSecretSystemAppSecretName, component.SystemSecretSystemAppSecretKeyBaseFieldName)] = component.DefaultSystemAppSecretKeyBase(r.randomGenerator)
+
+	data := map[string][]byte{}
+	for key, value := range values {
+		data[key] = []byte(value)
+	}
+	return data, nil
+}
+
+// applySecretValues updates the given secrets with the new values staged in the rotation secret.
+// Returns true when a secret was updated
+func (r *SecretRotationReconciler) applySecretValues(rotationSecret *v1.Secret, secretNames ...string) (bool, error) {
+	anyUpdated := false
+	for _, secretName := range secretNames {
+		fields := map[string][]byte{}
+		for key, value := range rotationSecret.Data {
+			if strings.HasPrefix(key, secretName+".") {
+				fields[strings.TrimPrefix(key, secretName+".")] = value
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		secret := &v1.Secret{}
+		err := r.Client().Get(r.Context(), types.NamespacedName{Name: secretName, Namespace: r.apiManager.Namespace}, secret)
+		if err != nil {
+			return false, err
+		}
+
+		updated := false
+		for field, value := range fields {
+			if string(secret.Data[field]) != string(value) {
+				if secret.Data == nil {
+					secret.Data = map[string][]byte{}
+				}
+				secret.Data[field] = value
+				updated = true
+			}
+		}
+		if !updated {
+			continue
+		}
+
+		// StringData would override the new values
+		secret.StringData = nil
+		r.logger.Info("Secret rotation: updating secret", "name", secretName)
+		if err := r.UpdateResource(secret); err != nil {
+			return false, err
+		}
+		anyUpdated = true
+	}
+
+	return anyUpdated, nil
+}
+
+// rollOut rolls out the given components, setting the rotation on their pod templates.
+// Returns true once all the components run the new pod template and are available
+func (r *SecretRotationReconciler) rollOut(rotationID string, names ...string) (bool, error) {
+	workloads := []*componentWorkload{}
+	for _, name := range names {
+		workload, err := r.componentWorkload(name)
+		if err != nil {
+			return false, err
+		}
+		if workload != nil {
+			workloads = append(workloads, workload)
+		}
+	}
+
+	updated := false
+	for _, workload := range workloads {
+		if workload.template.Annotations[SecretRotationAnnotation] == rotationID {
+			continue
+		}
+
+		if workload.template.Annotations == nil {
+			workload.template.Annotations = map[string]string{}
+		}
+		workload.template.Annotations[SecretRotationAnnotation] = rotationID
+
+		r.logger.Info("Secret rotation: rolling out", "name", workload.object.GetName())
+		if err := r.UpdateResource(workload.object); err != nil {
+			return false, err
+		}
+		updated = true
+	}
+	if updated {
+		return false, nil
+	}
+
+	for _, workload := range workloads {
+		if !workload.rolledOut {
+			r.logger.Info("Secret rotation: waiting for rollout", "name", workload.object.GetName())
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// reconcileAccessTokenJob runs the job replacing the system-seed access tokens in the system database.
+// Returns true once the job succeeded
+func (r *SecretRotationReconciler) reconcileAccessTokenJob(rotationSecret *v1.Secret) (bool, error) {
+	rotationID := rotationSecret.Annotations[SecretRotationAnnotation]
+
+	job := &batchv1.Job{}
+	err := r.Client().Get(r.Context(), types.NamespacedName{Name: SecretRotationJobName, Namespace: r.apiManager.Namespace}, job)
+	if err != nil && !errors.IsNotFound(err) {
+		return false, err
+	}
+
+	if err == nil {
+		switch {
+		case job.Annotations[SecretRotationAnnotation] != rotationID:
+			// Left by a previous rotation
+			r.logger.Info("Secret rotation: deleting job of a previous rotation", "name", job.Name)
+			return false, r.DeleteResource(job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		case helper.JobConditionTrue(job, batchv1.JobComplete):
+			return true, nil
+		case helper.JobConditionTrue(job, batchv1.JobFailed):
+			// Deleted so it is retried
+			r.EventRecorder().Eventf(r.apiManager, v1.EventTypeWarning, "SecretRotationFailed",
+				"Job %s failed to rotate the system access tokens, it will be retried", job.Name)
+			if err := r.DeleteResource(job, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
+				return false, err
+			}
+			return false, fmt.Errorf("job %s failed to rotate the system access tokens", job.Name)
+		default:
+			r.logger.Info("Secret rotation: waiting for job", "name", job.Name)
+			return false, nil
+		}
+	}
+
+	// system-master runs the first system-app container when split deployments are enabled
+	systemAppName := component.SystemAppDeploymentNames(r.apiManager.IsSystemAppSplitEnabled())[0]
+	systemApp, err := r.componentWorkload(systemAppName)
+	if err != nil {
+		return false, err
+	}
+	if systemApp == nil || len(systemApp.template.Spec.Containers) == 0 {
+		return false, fmt.Errorf("%s not found, required to rotate the system access tokens", systemAppName)
+	}
+
+	job = AccessTokenRotationJob(r.apiManager.Namespace, rotationID, systemApp.template)
+	if err := r.SetOwnerReference(r.apiManager, job); err != nil {
+		return false, err
+	}
+
+	r.logger.Info("Secret rotation: creating job", "name", job.Name)
+	return false, r.CreateResource(job)
+}
+
+// AccessTokenRotationJob returns the job replacing the system-seed access tokens in the system database.
+// The job runs the first container of the system-app pod template, with the same volumes,
+// so it has the same image and database configuration. The pod template labels are not kept,
+// so the job pod is not selected by the system services
+func AccessTokenRotationJob(namespace, rotationID string, systemAppTemplate *v1.PodTemplateSpec) *batchv1.Job {
+	container := *systemAppTemplate.Spec.Containers[0].DeepCopy()
+	container.Name = SecretRotationJobName
+	container.Command = []string{"bundle", "exec", "rails", "runner", accessTokenRotationScript}
+	container.Args = nil
+	container.Ports = nil
+	container.LivenessProbe = nil
+	container.ReadinessProbe = nil
+	container.StartupProbe = nil
+	container.Lifecycle = nil
+	for _, name := range []string{"MASTER", "ADMIN"} {
+		fieldName := fmt.Sprintf("%s_ACCESS_TOKEN", name)
+		container.Env = append(container.Env,
+			helper.EnvVarFromSecret("OLD_"+fieldName, component.SystemSecretSystemSeedSecretName, fieldName),
+			helper.EnvVarFromSecret("NEW_"+fieldName, SecretRotationSecretName, secretRotationKey(component.SystemSecretSystemSeedSecretName, fieldName)),
+		)
+	}
+
+	podSpec := *systemAppTemplate.Spec.DeepCopy()
+	podSpec.Containers = []v1.Container{container}
+	podSpec.InitContainers = nil
+	podSpec.RestartPolicy = v1.RestartPolicyNever
+
+	var backoffLimit int32 = 3
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        SecretRotationJobName,
+			Namespace:   namespace,
+			Annotations: map[string]string{SecretRotationAnnotation: rotationID},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{component.SystemJobPodLabel: "true"},
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+}
+
+func (r *SecretRotationReconciler) rotationSecret() (*v1.Secret, error) {
+	return secretRotationSecret(r.Client(), r.apiManager.Namespace)
+}
+
+func secretRotationSecret(cl client.Client, namespace string) (*v1.Secret, error) {
+	secret := &v1.Secret{}
+	err := cl.Get(context.TODO(), types.NamespacedName{Name: SecretRotationSecretName, Namespace: namespace}, secret)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// NextSecretRotationTime returns the time the next rotation is due:
+// one rotation interval after the last rotation or, when there was no rotation, after the APIManager creation
+func NextSecretRotationTime(apimanager *appsv1alpha1.APIManager, rotationSecret *v1.Secret) time.Time {
+	last := apimanager.CreationTimestamp.Time
+	if lastRotation := lastSecretRotationTime(rotationSecret); lastRotation != nil {
+		last = *lastRotation
+	}
+	return last.Add(apimanager.SecretRotationInterval())
+}
+
+func lastSecretRotationTime(rotationSecret *v1.Secret) *time.Time {
+	if rotationSecret == nil {
+		return nil
+	}
+	lastRotation, err := time.Parse(time.RFC3339, rotationSecret.Annotations[LastSecretRotationAnnotation])
+	if err != nil {
+		return nil
+	}
+	return &lastRotation
+}
+
+// SecretRotationStatus returns the secret rotation status of the APIManager from the rotation secret.
+// Returns nil when the rotation is disabled and no rotation is in progress
+func SecretRotationStatus(apimanager *appsv1alpha1.APIManager, cl client.Client) (*appsv1alpha1.SecretRotationStatus, error) {
+	rotationSecret, err := secretRotationSecret(cl, apimanager.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &appsv1alpha1.SecretRotationStatus{}
+	if rotationSecret != nil {
+		status.Phase = rotationSecret.Annotations[SecretRotationPhaseAnnotation]
+	}
+	if !apimanager.IsSecretRotationEnabled() && status.Phase == "" {
+		return nil, nil
+	}
+
+	if lastRotation := lastSecretRotationTime(rotationSecret); lastRotation != nil {
+		status.LastRotationTime = &metav1.Time{Time: *lastRotation}
+	}
+	if apimanager.IsSecretRotationEnabled() {
+		status.NextRotationTime = &metav1.Time{Time: NextSecretRotationTime(apimanager, rotationSecret).UTC().Truncate(time.Second)}
+	}
+
+	return status, nil
+}
+
+func secretRotationKey(secretName, fieldName string) string {
+	return fmt.Sprintf("%s.%s", secretName, fieldName)
+}
+
+// databaseURLWithPassword returns the database URL with the password replaced
+func databaseURLWithPassword(rawURL, password string) (string, error) {
+	urlObj, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	if urlObj.User == nil || urlObj.User.Username() == "" {
+		return "", fmt.Errorf("the URL must contain a username")
+	}
+	urlObj.User = url.UserPassword(urlObj.User.Username(), password)
+	return urlObj.String(), nil
+}
@@ -0,0 +1,187 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	apispkgcommon "github.com/3scale/3scale-operator/pkg/apispkg/common"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+const (
+	SMTPCheckJobName = "system-smtp-check"
+	// SMTPCheckConfigHashAnnotation records the SMTP configuration checked by the job
+	SMTPCheckConfigHashAnnotation = "apps.3scale.net/smtp-config-hash"
+)
+
+// smtpCheckScript connects to the SMTP server with the system SMTP settings,
+// authenticating when credentials are configured
+const smtpCheckScript = `require 'net/smtp'
+require 'openssl'
+smtp = Net::SMTP.new(ENV.fetch('SMTP_ADDRESS'), Integer(ENV.fetch('SMTP_PORT')))
+smtp.open_timeout = 15
+smtp.read_timeout = 15
+context = OpenSSL::SSL::SSLContext.new
+context.verify_mode = ENV['SMTP_OPENSSL_VERIFY_MODE'] == 'none' ? OpenSSL::SSL::VERIFY_NONE : OpenSSL::SSL::VERIFY_PEER
+if ENV['SMTP_TLS'] == 'true'
+  smtp.enable_tls(context)
+elsif ENV['SMTP_ENABLE_STARTTLS'] == 'true'
+  smtp.enable_starttls(context)
+elsif ENV['SMTP_ENABLE_STARTTLS_AUTO'] == 'true'
+  smtp.enable_starttls_auto(context)
+end
+domain = ENV['SMTP_DOMAIN'].to_s.empty? ? 'localhost' : ENV['SMTP_DOMAIN']
+if ENV['SMTP_AUTHENTICATION'].to_s.empty?
+  smtp.start(domain) {}
+else
+  smtp.start(domain, ENV['SMTP_USER_NAME'], ENV['SMTP_PASSWORD'], ENV['SMTP_AUTHENTICATION'].to_sym) {}
+end
+puts "SMTP server #{ENV['SMTP_ADDRESS']}:#{ENV['SMTP_PORT']} is reachable"`
+
+// reconcileSMTPCheckJob runs the system-smtp-check job when the connectivity check is enabled.
+// The job is run again when the SMTP configuration changes
+func (r *SystemReconciler) reconcileSMTPCheckJob() error {
+	job := &batchv1.Job{}
+	err := r.Client().Get(r.Context(), types.NamespacedName{Name: SMTPCheckJobName, Namespace: r.apiManager.Namespace}, job)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	jobExists := err == nil
+
+	hash, err := SMTPConfigHash(r.apiManager, r.Client())
+	if err != nil {
+		return err
+	}
+
+	if jobExists {
+		if r.apiManager.IsSystemSMTPCheckEnabled() && job.Annotations[SMTPCheckConfigHashAnnotation] == hash {
+			return nil
+		}
+		if !metav1.IsControlledBy(job, r.apiManager) {
+			return nil
+		}
+		// Recreated on the next reconciliation, triggered by the job deletion
+		r.Logger().Info("Deleting SMTP check job", "name", job.Name)
+		return r.DeleteResource(job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	}
+
+	if !r.apiManager.IsSystemSMTPCheckEnabled() {
+		return nil
+	}
+
+	// The job runs with the image and the SMTP environment of the system-app pods
+	systemAppName := component.SystemAppDeploymentNames(r.apiManager.IsSystemAppSplitEnabled())[0]
+	systemApp, err := r.componentWorkload(systemAppName)
+	if err != nil || systemApp == nil {
+		return err
+	}
+
+	job = SMTPCheckJob(r.apiManager.Namespace, hash, systemApp.template)
+	if err := r.SetOwnerReference(r.apiManager, job); err != nil {
+		return err
+	}
+
+	r.Logger().Info("Creating SMTP check job", "name", job.Name)
+	return r.CreateResource(job)
+}
+
+// SMTPCheckJob returns the job checking the SMTP server is reachable.
+// The job runs the first container of the system-app pod template, with the same environment
+func SMTPCheckJob(namespace, hash string, systemAppTemplate *v1.PodTemplateSpec) *batchv1.Job {
+	container := *systemAppTemplate.Spec.Containers[0].DeepCopy()
+	container.Name = SMTPCheckJobName
+	container.Command = []string{"ruby", "-e", smtpCheckScript}
+	container.Args = nil
+	container.Ports = nil
+	container.LivenessProbe = nil
+	container.ReadinessProbe = nil
+	container.StartupProbe = nil
+	container.Lifecycle = nil
+	container.VolumeMounts = nil
+
+	var backoffLimit int32 = 0
+	var activeDeadlineSeconds int64 = 120
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        SMTPCheckJobName,
+			Namespace:   namespace,
+			Annotations: map[string]string{SMTPCheckConfigHashAnnotation: hash},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          &backoffLimit,
+			ActiveDeadlineSeconds: &activeDeadlineSeconds,
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{component.SystemJobPodLabel: "true"},
+				},
+				Spec: v1.PodSpec{
+					Containers:         []v1.Container{container},
+					RestartPolicy:      v1.RestartPolicyNever,
+					ServiceAccountName: systemAppTemplate.Spec.ServiceAccountName,
+					ImagePullSecrets:   systemAppTemplate.Spec.ImagePullSecrets,
+					SecurityContext:    systemAppTemplate.Spec.SecurityContext,
+					Affinity:           systemAppTemplate.Spec.Affinity,
+					Tolerations:        systemAppTemplate.Spec.Tolerations,
+				},
+			},
+		},
+	}
+}
+
+// SMTPCheckCondition returns the SMTPReachable condition from the system-smtp-check job.
+// Nil when the connectivity check is not enabled
+func SMTPCheckCondition(apimanager *appsv1alpha1.APIManager, cl client.Client) (*apispkgcommon.Condition, error) {
+	if !apimanager.IsSystemSMTPCheckEnabled() {
+		return nil, nil
+	}
+
+	condition := &apispkgcommon.Condition{
+		Type:    appsv1alpha1.APIManagerSMTPReachableConditionType,
+		Status:  v1.ConditionUnknown,
+		Reason:  appsv1alpha1.APIManagerSMTPCheckInProgressReason,
+		Message: fmt.Sprintf("Waiting for job %s", SMTPCheckJobName),
+	}
+
+	job := &batchv1.Job{}
+	err := cl.Get(context.TODO(), types.NamespacedName{Name: SMTPCheckJobName, Namespace: apimanager.Namespace}, job)
+	if errors.IsNotFound(err) {
+		return condition, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := SMTPConfigHash(apimanager, cl)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case job.Annotations[SMTPCheckConfigHashAnnotation] != hash:
+		// The job checked a previous configuration
+	case helper.JobConditionTrue(job, batchv1.JobComplete):
+		condition.Status = v1.ConditionTrue
+		condition.Reason = appsv1alpha1.APIManagerSMTPCheckSucceededReason
+		condition.Message = fmt.Sprintf("%s:%d is reachable", apimanager.Spec.System.SMTPSpec.Address, apimanager.Spec.System.SMTPSpec.Port)
+	case helper.JobConditionTrue(job, batchv1.JobFailed):
+		condition.Status = v1.ConditionFalse
+		condition.Reason = appsv1alpha1.APIManagerSMTPCheckFailedReason
+		condition.Message = fmt.Sprintf("Job %s failed to connect to %s:%d. See the job logs for details",
+			SMTPCheckJobName, apimanager.Spec.System.SMTPSpec.Address, apimanager.Spec.System.SMTPSpec.Port)
+	}
+
+	return condition, nil
+}
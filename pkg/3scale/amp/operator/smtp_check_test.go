@@ -0,0 +1,173 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	configv1 "github.com/openshift/api/config/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestSystemReconcilerDeclarativeSMTP(t *testing.T) {
+	var (
+		log = logf.Log.WithName("operator_test")
+	)
+	ctx := context.TODO()
+	s := scheme.Scheme
+
+	if err := appsv1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := appsv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := configv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	apimanager := testSystemAPIManagerCreator(nil, nil)
+	apimanager.Spec.System.SMTPSpec = &appsv1alpha1.SystemSMTPSpec{
+		Address:           "smtp.example.com",
+		Port:              587,
+		ConnectivityCheck: true,
+	}
+
+	// Values of a previously user managed secret are replaced by the declared configuration
+	smtpSecret := GetTestSecret(apimanager.Namespace, component.SystemSecretSystemSMTPSecretName, map[string]string{
+		component.SystemSecretSystemSMTPAddressFieldName: "old.example.com",
+	})
+	objs := []runtime.Object{apimanager, smtpSecret}
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+	baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, clientAPIReader, log, clientset.Discovery(), recorder)
+	baseAPIManagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager)
+	reconciler := NewSystemReconciler(baseAPIManagerLogicReconciler)
+
+	_, err := reconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret := &v1.Secret{}
+	err = cl.Get(ctx, types.NamespacedName{Name: component.SystemSecretSystemSMTPSecretName, Namespace: apimanager.Namespace}, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The fake client does not merge StringData into Data
+	secretValue := func(fieldName string) string {
+		if value, ok := secret.StringData[fieldName]; ok {
+			return value
+		}
+		return string(secret.Data[fieldName])
+	}
+	if address := secretValue(component.SystemSecretSystemSMTPAddressFieldName); address != "smtp.example.com" {
+		t.Errorf("expected smtp address smtp.example.com, got %s", address)
+	}
+	if startTLSAuto := secretValue(component.SystemSecretSystemSMTPEnableStartTLSAutoFieldName); startTLSAuto != "true" {
+		t.Errorf("expected starttls auto enabled by default, got %s", startTLSAuto)
+	}
+
+	hash, err := SystemConfigHash(apimanager, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dc := &appsv1.DeploymentConfig{}
+	err = cl.Get(ctx, types.NamespacedName{Name: component.SystemAppDeploymentName, Namespace: apimanager.Namespace}, dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dc.Spec.Template.Annotations[SystemConfigHashAnnotation] != hash {
+		t.Errorf("expected system-app pod template annotation %s=%s, got %v", SystemConfigHashAnnotation, hash, dc.Spec.Template.Annotations)
+	}
+
+	job := &batchv1.Job{}
+	err = cl.Get(ctx, types.NamespacedName{Name: SMTPCheckJobName, Namespace: apimanager.Namespace}, job)
+	if err != nil {
+		t.Fatalf("expected smtp check job: %v", err)
+	}
+
+	condition, err := SMTPCheckCondition(apimanager, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if condition == nil || condition.Status != v1.ConditionUnknown || condition.Reason != appsv1alpha1.APIManagerSMTPCheckInProgressReason {
+		t.Errorf("expected in progress condition, got %v", condition)
+	}
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: v1.ConditionTrue}}
+	if err := cl.Update(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	condition, err = SMTPCheckCondition(apimanager, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if condition == nil || condition.Status != v1.ConditionFalse || condition.Reason != appsv1alpha1.APIManagerSMTPCheckFailedReason {
+		t.Errorf("expected failed condition, got %v", condition)
+	}
+
+	// A configuration change runs the check again
+	apimanager.Spec.System.SMTPSpec.Port = 465
+	_, err = reconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = cl.Get(ctx, types.NamespacedName{Name: SMTPCheckJobName, Namespace: apimanager.Namespace}, &batchv1.Job{})
+	if !errors.IsNotFound(err) {
+		t.Fatalf("expected stale smtp check job to be deleted, got: %v", err)
+	}
+	_, err = reconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	job = &batchv1.Job{}
+	err = cl.Get(ctx, types.NamespacedName{Name: SMTPCheckJobName, Namespace: apimanager.Namespace}, job)
+	if err != nil {
+		t.Fatalf("expected smtp check job to be recreated: %v", err)
+	}
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: v1.ConditionTrue}}
+	if err := cl.Update(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	condition, err = SMTPCheckCondition(apimanager, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if condition == nil || condition.Status != v1.ConditionTrue || condition.Reason != appsv1alpha1.APIManagerSMTPCheckSucceededReason {
+		t.Errorf("expected succeeded condition, got %v", condition)
+	}
+
+	// Disabling the check removes the job and the condition
+	apimanager.Spec.System.SMTPSpec.ConnectivityCheck = false
+	_, err = reconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = cl.Get(ctx, types.NamespacedName{Name: SMTPCheckJobName, Namespace: apimanager.Namespace}, &batchv1.Job{})
+	if !errors.IsNotFound(err) {
+		t.Errorf("expected smtp check job to be deleted, got: %v", err)
+	}
+	condition, err = SMTPCheckCondition(apimanager, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if condition != nil {
+		t.Errorf("expected no condition, got %v", condition)
+	}
+}
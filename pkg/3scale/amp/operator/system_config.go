@@ -0,0 +1,128 @@
+package operator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+const (
+	// SystemConfigHashAnnotation rolls out the system pods when the SMTP, reCAPTCHA or events hook
+	// configuration declared in the APIManager changes
+	SystemConfigHashAnnotation = "apps.3scale.net/system-config-hash"
+	// EventsHookConfigHashAnnotation rolls out backend-worker when the events hook configuration
+	// declared in the APIManager changes
+	EventsHookConfigHashAnnotation = "apps.3scale.net/events-hook-config-hash"
+)
+
+// SystemConfigHash returns the hash of the SMTP, reCAPTCHA and events hook configuration
+// declared in the APIManager. Empty when none of them is declared
+func SystemConfigHash(apimanager *appsv1alpha1.APIManager, cl client.Client) (string, error) {
+	system := apimanager.Spec.System
+	if system == nil || (system.SMTPSpec == nil && system.RecaptchaSpec == nil && system.EventsHookSpec == nil) {
+		return "", nil
+	}
+
+	spec := []interface{}{smtpConfig(system.SMTPSpec), system.RecaptchaSpec, system.EventsHookSpec}
+	return configHash(cl, apimanager.Namespace, spec, SystemSecretRefs(apimanager))
+}
+
+// SMTPConfigHash returns the hash of the SMTP configuration declared in the APIManager.
+// Empty when it is not declared
+func SMTPConfigHash(apimanager *appsv1alpha1.APIManager, cl client.Client) (string, error) {
+	if apimanager.Spec.System == nil || apimanager.Spec.System.SMTPSpec == nil {
+		return "", nil
+	}
+
+	smtp := smtpConfig(apimanager.Spec.System.SMTPSpec)
+	secretRefs := []*v1.SecretKeySelector{}
+	if smtp.PasswordSecretRef != nil {
+		secretRefs = append(secretRefs, smtp.PasswordSecretRef)
+	}
+	return configHash(cl, apimanager.Namespace, smtp, secretRefs)
+}
+
+// EventsHookConfigHash returns the hash of the events hook configuration declared in the APIManager.
+// Empty when it is not declared
+func EventsHookConfigHash(apimanager *appsv1alpha1.APIManager, cl client.Client) (string, error) {
+	if apimanager.Spec.System == nil || apimanager.Spec.System.EventsHookSpec == nil {
+		return "", nil
+	}
+
+	eventsHook := apimanager.Spec.System.EventsHookSpec
+	return configHash(cl, apimanager.Namespace, eventsHook, []*v1.SecretKeySelector{&eventsHook.PasswordSecretRef})
+}
+
+// SystemSecretRefs returns the secret keys referenced by the SMTP, reCAPTCHA and events hook
+// configuration declared in the APIManager
+func SystemSecretRefs(apimanager *appsv1alpha1.APIManager) []*v1.SecretKeySelector {
+	secretRefs := []*v1.SecretKeySelector{}
+	system := apimanager.Spec.System
+	if system == nil {
+		return secretRefs
+	}
+
+	if system.SMTPSpec != nil && system.SMTPSpec.PasswordSecretRef != nil {
+		secretRefs = append(secretRefs, system.SMTPSpec.PasswordSecretRef)
+	}
+	if system.RecaptchaSpec != nil {
+		secretRefs = append(secretRefs, &system.RecaptchaSpec.PrivateKeySecretRef)
+	}
+	if system.EventsHookSpec != nil {
+		secretRefs = append(secretRefs, &system.EventsHookSpec.PasswordSecretRef)
+	}
+
+	return secretRefs
+}
+
+// smtpConfig returns the SMTP spec fields configuring system.
+// The connectivity check does not change the configuration
+func smtpConfig(smtp *appsv1alpha1.SystemSMTPSpec) *appsv1alpha1.SystemSMTPSpec {
+	if smtp == nil {
+		return nil
+	}
+
+	config := smtp.DeepCopy()
+	config.ConnectivityCheck = false
+	return config
+}
+
+// configHash hashes the spec and the resource version of the referenced secrets.
+// The secret values are not hashed, so the hash does not reveal them
+func configHash(cl client.Client, namespace string, spec interface{}, secretRefs []*v1.SecretKeySelector) (string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+
+	h := fnv.New32a()
+	h.Write(data)
+	for _, secretRef := range secretRefs {
+		secret := &v1.Secret{}
+		err := cl.Get(context.TODO(), types.NamespacedName{Name: secretRef.Name, Namespace: namespace}, secret)
+		if err != nil {
+			return "", fmt.Errorf("reading secret %s: %w", secretRef.Name, err)
+		}
+		h.Write([]byte(secret.ResourceVersion))
+	}
+
+	return fmt.Sprint(h.Sum32()), nil
+}
+
+// podTemplateAnnotationsWith returns a copy of the annotations with the given annotation,
+// so the annotations of the APIManager spec are not modified
+func podTemplateAnnotationsWith(annotations map[string]string, key, value string) map[string]string {
+	result := map[string]string{}
+	for k, v := range annotations {
+		result[k] = v
+	}
+	result[key] = value
+	return result
+}
@@ -8,6 +8,7 @@ import (
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
+	oprand "github.com/3scale/3scale-operator/pkg/crypto/rand"
 	"github.com/3scale/3scale-operator/pkg/helper"
 
 	v1 "k8s.io/api/core/v1"
@@ -15,20 +16,22 @@ import (
 )
 
 type SystemMysqlOptionsProvider struct {
-	apimanager   *appsv1alpha1.APIManager
-	namespace    string
-	client       client.Client
-	mysqlOptions *component.SystemMysqlOptions
-	secretSource *helper.SecretSource
+	apimanager      *appsv1alpha1.APIManager
+	namespace       string
+	client          client.Client
+	mysqlOptions    *component.SystemMysqlOptions
+	secretSource    *helper.SecretSource
+	randomGenerator *oprand.Generator
 }
 
-func NewSystemMysqlOptionsProvider(apimanager *appsv1alpha1.APIManager, namespace string, client client.Client) *SystemMysqlOptionsProvider {
+func NewSystemMysqlOptionsProvider(apimanager *appsv1alpha1.APIManager, namespace string, client client.Client, randomGenerator *oprand.Generator) *SystemMysqlOptionsProvider {
 	return &SystemMysqlOptionsProvider{
-		apimanager:   apimanager,
-		namespace:    namespace,
-		client:       client,
-		mysqlOptions: component.NewSystemMysqlOptions(),
-		secretSource: helper.NewSecretSource(client, namespace),
+		apimanager:      apimanager,
+		namespace:       namespace,
+		client:          client,
+		mysqlOptions:    component.NewSystemMysqlOptions(),
+		secretSource:    helper.NewSecretSource(client, namespace),
+		randomGenerator: randomGenerator,
 	}
 }
 
@@ -74,13 +77,13 @@ func (s *SystemMysqlOptionsProvider) setSecretBasedOptions() error {
 			&s.mysqlOptions.Password,
 			component.SystemSecretSystemDatabaseSecretName,
 			component.SystemSecretSystemDatabasePasswordFieldName,
-			component.DefaultSystemMysqlPassword(),
+			component.DefaultSystemMysqlPassword(s.randomGenerator),
 		},
 		{
 			&s.mysqlOptions.DatabaseURL,
 			component.SystemSecretSystemDatabaseSecretName,
 			component.SystemSecretSystemDatabaseURLFieldName,
-			component.DefaultSystemMysqlDatabaseURL(component.DefaultSystemMysqlRootPassword(), component.DefaultSystemMysqlDatabaseName()),
+			component.DefaultSystemMysqlDatabaseURL(component.DefaultSystemMysqlRootPassword(s.randomGenerator), component.DefaultSystemMysqlDatabaseName()),
 		},
 	}
 
@@ -9,6 +9,7 @@ import (
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
+	oprand "github.com/3scale/3scale-operator/pkg/crypto/rand"
 	"github.com/3scale/3scale-operator/pkg/helper"
 
 	"github.com/google/go-cmp/cmp"
@@ -254,7 +255,7 @@ func TestGetMysqlOptionsProvider(t *testing.T) {
 				objs = append(objs, tc.systemDatabaseSecret)
 			}
 			cl := fake.NewFakeClient(objs...)
-			optsProvider := NewSystemMysqlOptionsProvider(tc.apimanagerFactory(), namespace, cl)
+			optsProvider := NewSystemMysqlOptionsProvider(tc.apimanagerFactory(), namespace, cl, oprand.DefaultGenerator())
 			opts, err := optsProvider.GetMysqlOptions()
 			if err != nil {
 				subT.Error(err)
@@ -288,7 +289,7 @@ func TestGetMysqlOptionsInvalidURL(t *testing.T) {
 			secret := getSystemDBSecret(tc.databaseURL, systemMysqlUsername, systemMysqlPassword)
 			objs := []runtime.Object{secret}
 			cl := fake.NewFakeClient(objs...)
-			optsProvider := NewSystemMysqlOptionsProvider(basicApimanager(), namespace, cl)
+			optsProvider := NewSystemMysqlOptionsProvider(basicApimanager(), namespace, cl, oprand.DefaultGenerator())
 			_, err := optsProvider.GetMysqlOptions()
 			if err == nil {
 				subT.Fatal("expected to fail for invalid URL")
@@ -3,7 +3,9 @@ package operator
 import (
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	oprand "github.com/3scale/3scale-operator/pkg/crypto/rand"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
+	appsv1 "github.com/openshift/api/apps/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -19,7 +21,7 @@ func NewSystemMySQLReconciler(baseAPIManagerLogicReconciler *BaseAPIManagerLogic
 }
 
 func (r *SystemMySQLReconciler) Reconcile() (reconcile.Result, error) {
-	systemMySQL, err := SystemMySQL(r.apiManager, r.Client())
+	systemMySQL, err := SystemMySQL(r.apiManager, r.Client(), r.randomGenerator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -34,6 +36,7 @@ func (r *SystemMySQLReconciler) Reconcile() (reconcile.Result, error) {
 		reconcilers.DeploymentConfigPriorityClassMutator,
 		reconcilers.DeploymentConfigTopologySpreadConstraintsMutator,
 		reconcilers.DeploymentConfigPodTemplateAnnotationsMutator,
+		systemMySQLRootPasswordEnvVarMutator,
 	)
 	err = r.ReconcileDeploymentConfig(systemMySQL.DeploymentConfig(), dcMutator)
 	if err != nil {
@@ -73,11 +76,17 @@ func (r *SystemMySQLReconciler) Reconcile() (reconcile.Result, error) {
 	return reconcile.Result{}, nil
 }
 
-func SystemMySQL(apimanager *appsv1alpha1.APIManager, client client.Client) (*component.SystemMysql, error) {
-	optsProvider := NewSystemMysqlOptionsProvider(apimanager, apimanager.Namespace, client)
+func SystemMySQL(apimanager *appsv1alpha1.APIManager, client client.Client, randomGenerator *oprand.Generator) (*component.SystemMysql, error) {
+	optsProvider := NewSystemMysqlOptionsProvider(apimanager, apimanager.Namespace, client, randomGenerator)
 	opts, err := optsProvider.GetMysqlOptions()
 	if err != nil {
 		return nil, err
 	}
 	return component.NewSystemMysql(opts), nil
 }
+
+// systemMySQLRootPasswordEnvVarMutator reconciles the root password, read from the system-database secret URL.
+// The MySQL image sets the root password from the environment on startup
+func systemMySQLRootPasswordEnvVarMutator(desired, existing *appsv1.DeploymentConfig) (bool, error) {
+	return reconcilers.DeploymentConfigEnvVarReconciler(desired, existing, "MYSQL_ROOT_PASSWORD"), nil
+}
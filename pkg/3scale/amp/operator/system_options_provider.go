@@ -3,6 +3,7 @@ package operator
 import (
 	"fmt"
 	"path/filepath"
+	"strconv"
 
 	v1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -11,24 +12,27 @@ import (
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
+	oprand "github.com/3scale/3scale-operator/pkg/crypto/rand"
 	"github.com/3scale/3scale-operator/pkg/helper"
 )
 
 type SystemOptionsProvider struct {
-	apimanager   *appsv1alpha1.APIManager
-	namespace    string
-	client       client.Client
-	options      *component.SystemOptions
-	secretSource *helper.SecretSource
+	apimanager      *appsv1alpha1.APIManager
+	namespace       string
+	client          client.Client
+	options         *component.SystemOptions
+	secretSource    *helper.SecretSource
+	randomGenerator *oprand.Generator
 }
 
-func NewSystemOptionsProvider(apimanager *appsv1alpha1.APIManager, namespace string, client client.Client) *SystemOptionsProvider {
+func NewSystemOptionsProvider(apimanager *appsv1alpha1.APIManager, namespace string, client client.Client, randomGenerator *oprand.Generator) *SystemOptionsProvider {
 	return &SystemOptionsProvider{
-		apimanager:   apimanager,
-		namespace:    namespace,
-		client:       client,
-		options:      component.NewSystemOptions(),
-		secretSource: helper.NewSecretSource(client, namespace),
+		apimanager:      apimanager,
+		namespace:       namespace,
+		client:          client,
+		options:         component.NewSystemOptions(),
+		secretSource:    helper.NewSecretSource(client, namespace),
+		randomGenerator: randomGenerator,
 	}
 }
 
@@ -64,6 +68,11 @@ func (s *SystemOptionsProvider) GetSystemOptions() (*component.SystemOptions, er
 	s.setPriorityClassNames()
 	s.setTopologySpreadConstraints()
 	s.setPodTemplateAnnotations()
+	s.setSidekiqPoolOptions()
+	err = s.setConfigHashAnnotations()
+	if err != nil {
+		return nil, err
+	}
 
 	s.options.SideKiqMetrics = true
 	s.options.AppMetrics = true
@@ -132,6 +141,19 @@ func (s *SystemOptionsProvider) setSystemMemcachedOptions() error {
 }
 
 func (s *SystemOptionsProvider) setSystemRecaptchaOptions() error {
+	if recaptcha := s.apimanager.Spec.System.RecaptchaSpec; recaptcha != nil {
+		recaptchaPrivateKey, err := s.secretSource.RequiredFieldValueFromRequiredSecret(
+			recaptcha.PrivateKeySecretRef.Name, recaptcha.PrivateKeySecretRef.Key)
+		if err != nil {
+			return err
+		}
+		recaptchaPublicKey := recaptcha.PublicKey
+		s.options.RecaptchaPublicKey = &recaptchaPublicKey
+		s.options.RecaptchaPrivateKey = &recaptchaPrivateKey
+		s.options.RecaptchaSecretManaged = true
+		return nil
+	}
+
 	recaptchaPublicKey, err := s.secretSource.FieldValue(
 		component.SystemSecretSystemRecaptchaSecretName,
 		component.SystemSecretSystemRecaptchaPublicKeyFieldName,
@@ -154,10 +176,25 @@ func (s *SystemOptionsProvider) setSystemRecaptchaOptions() error {
 }
 
 func (s *SystemOptionsProvider) setSystemEventHookOptions() error {
+	if eventsHook := s.apimanager.Spec.System.EventsHookSpec; eventsHook != nil {
+		password, err := s.secretSource.RequiredFieldValueFromRequiredSecret(
+			eventsHook.PasswordSecretRef.Name, eventsHook.PasswordSecretRef.Key)
+		if err != nil {
+			return err
+		}
+		s.options.BackendSharedSecret = password
+		s.options.EventHooksURL = component.DefaultEventHooksURL()
+		if eventsHook.URL != nil {
+			s.options.EventHooksURL = *eventsHook.URL
+		}
+		s.options.EventsHookSecretManaged = true
+		return nil
+	}
+
 	val, err := s.secretSource.FieldValue(
 		component.SystemSecretSystemEventsHookSecretName,
 		component.SystemSecretSystemEventsHookPasswordFieldName,
-		component.DefaultBackendSharedSecret())
+		component.DefaultBackendSharedSecret(s.randomGenerator))
 	if err != nil {
 		return err
 	}
@@ -179,7 +216,7 @@ func (s *SystemOptionsProvider) setSystemAppOptions() error {
 	val, err := s.secretSource.FieldValue(
 		component.SystemSecretSystemAppSecretName,
 		component.SystemSecretSystemAppSecretKeyBaseFieldName,
-		component.DefaultSystemAppSecretKeyBase())
+		component.DefaultSystemAppSecretKeyBase(s.randomGenerator))
 	if err != nil {
 		return err
 	}
@@ -220,7 +257,7 @@ func (s *SystemOptionsProvider) setSystemSeedOptions() error {
 			&s.options.MasterPassword,
 			component.SystemSecretSystemSeedSecretName,
 			component.SystemSecretSystemSeedMasterPasswordFieldName,
-			component.DefaultSystemMasterPassword(),
+			component.DefaultSystemMasterPassword(s.randomGenerator),
 		},
 		{
 			&s.options.AdminUsername,
@@ -232,19 +269,19 @@ func (s *SystemOptionsProvider) setSystemSeedOptions() error {
 			&s.options.AdminPassword,
 			component.SystemSecretSystemSeedSecretName,
 			component.SystemSecretSystemSeedAdminPasswordFieldName,
-			component.DefaultSystemAdminPassword(),
+			component.DefaultSystemAdminPassword(s.randomGenerator),
 		},
 		{
 			&s.options.AdminAccessToken,
 			component.SystemSecretSystemSeedSecretName,
 			component.SystemSecretSystemSeedAdminAccessTokenFieldName,
-			component.DefaultSystemAdminAccessToken(),
+			component.DefaultSystemAdminAccessToken(s.randomGenerator),
 		},
 		{
 			&s.options.MasterAccessToken,
 			component.SystemSecretSystemSeedSecretName,
 			component.SystemSecretSystemSeedMasterAccessTokenFieldName,
-			component.DefaultSystemMasterAccessToken(),
+			component.DefaultSystemMasterAccessToken(s.randomGenerator),
 		},
 	}
 
@@ -272,7 +309,7 @@ func (s *SystemOptionsProvider) setSystemMasterApicastOptions() error {
 	val, err := s.secretSource.FieldValue(
 		component.SystemSecretSystemMasterApicastSecretName,
 		component.SystemSecretSystemMasterApicastAccessToken,
-		component.DefaultSystemMasterApicastAccessToken())
+		component.DefaultSystemMasterApicastAccessToken(s.randomGenerator))
 	if err != nil {
 		return err
 	}
@@ -291,6 +328,10 @@ func (s *SystemOptionsProvider) setSystemMasterApicastOptions() error {
 }
 
 func (s *SystemOptionsProvider) setSystemSMTPOptions() error {
+	if smtp := s.apimanager.Spec.System.SMTPSpec; smtp != nil {
+		return s.setSystemSMTPOptionsFromSpec(smtp)
+	}
+
 	smtpSecretOptions := component.SystemSMTPSecretOptions{}
 	cases := []struct {
 		field       **string
@@ -360,6 +401,53 @@ func (s *SystemOptionsProvider) setSystemSMTPOptions() error {
 	return nil
 }
 
+func (s *SystemOptionsProvider) setSystemSMTPOptionsFromSpec(smtp *appsv1alpha1.SystemSMTPSpec) error {
+	password := component.DefaultSystemSMTPPassword()
+	if smtp.PasswordSecretRef != nil {
+		val, err := s.secretSource.RequiredFieldValueFromRequiredSecret(smtp.PasswordSecretRef.Name, smtp.PasswordSecretRef.Key)
+		if err != nil {
+			return err
+		}
+		password = val
+	}
+
+	tlsMode := appsv1alpha1.SMTPTLSModeSTARTTLSAuto
+	if smtp.TLSMode != nil {
+		tlsMode = *smtp.TLSMode
+	}
+
+	stringValue := func(val *string) *string {
+		result := ""
+		if val != nil {
+			result = *val
+		}
+		return &result
+	}
+	boolValue := func(val bool) *string {
+		result := strconv.FormatBool(val)
+		return &result
+	}
+
+	address := smtp.Address
+	port := strconv.Itoa(int(smtp.Port))
+	s.options.SmtpSecretOptions = component.SystemSMTPSecretOptions{
+		Address:            &address,
+		Port:               &port,
+		Domain:             stringValue(smtp.Domain),
+		Authentication:     stringValue(smtp.Authentication),
+		Username:           stringValue(smtp.Username),
+		Password:           &password,
+		OpenSSLVerifyMode:  stringValue(smtp.OpenSSLVerifyMode),
+		FromAddress:        stringValue(smtp.FromAddress),
+		TLS:                boolValue(tlsMode == appsv1alpha1.SMTPTLSModeTLS),
+		EnableStartTLS:     boolValue(tlsMode == appsv1alpha1.SMTPTLSModeSTARTTLS),
+		EnableStartTLSAuto: boolValue(tlsMode == appsv1alpha1.SMTPTLSModeSTARTTLSAuto),
+	}
+	s.options.SMTPSecretManaged = true
+
+	return nil
+}
+
 func (s *SystemOptionsProvider) setResourceRequirementsOptions() {
 	if *s.apimanager.Spec.ResourceRequirementsEnabled {
 		s.options.AppMasterContainerResourceRequirements = component.DefaultAppMasterContainerResourceRequirements()
@@ -515,6 +603,27 @@ func (s *SystemOptionsProvider) setReplicas() {
 	if s.apimanager.Spec.System.AppSpec.Replicas != nil {
 		s.options.AppReplicas = int32(*s.apimanager.Spec.System.AppSpec.Replicas)
 	}
+	if s.apimanager.IsSystemAppHPAEnabled() {
+		s.options.AppHPA = horizontalPodAutoscalerOptions(s.apimanager.Spec.System.AppSpec.HPA)
+		s.options.AppReplicas = s.options.AppHPA.MinReplicas
+	}
+
+	s.options.AppSplitDeployments = s.apimanager.IsSystemAppSplitEnabled()
+	s.options.AppMasterReplicas = s.options.AppReplicas
+	s.options.AppProviderReplicas = s.options.AppReplicas
+	s.options.AppDeveloperReplicas = s.options.AppReplicas
+	if s.options.AppSplitDeployments {
+		splitSpec := s.apimanager.Spec.System.AppSpec.SplitDeployments
+		if splitSpec.MasterReplicas != nil {
+			s.options.AppMasterReplicas = int32(*splitSpec.MasterReplicas)
+		}
+		if splitSpec.ProviderReplicas != nil {
+			s.options.AppProviderReplicas = int32(*splitSpec.ProviderReplicas)
+		}
+		if splitSpec.DeveloperReplicas != nil {
+			s.options.AppDeveloperReplicas = int32(*splitSpec.DeveloperReplicas)
+		}
+	}
 
 	s.options.SidekiqReplicas = 1
 	if s.apimanager.Spec.System.SidekiqSpec.Replicas != nil {
@@ -569,10 +678,72 @@ func (s *SystemOptionsProvider) sidekiqPodTemplateLabels() map[string]string {
 	}
 
 	labels["deploymentConfig"] = "system-sidekiq"
+	labels[component.SystemSidekiqPoolLabelKey] = appsv1alpha1.DefaultSidekiqPoolName
 
 	return labels
 }
 
+func (s *SystemOptionsProvider) setSidekiqPoolOptions() {
+	for idx := range s.apimanager.Spec.System.SidekiqPools {
+		pool := &s.apimanager.Spec.System.SidekiqPools[idx]
+		name := component.SidekiqPoolName(pool.Name)
+
+		commonLabels := s.commonSidekiqLabels()
+		commonLabels[component.SystemSidekiqPoolLabelKey] = pool.Name
+
+		podTemplateLabels := helper.MeteringLabels(name, helper.ApplicationType)
+		for k, v := range commonLabels {
+			podTemplateLabels[k] = v
+		}
+		for k, v := range pool.Labels {
+			podTemplateLabels[k] = v
+		}
+		podTemplateLabels["deploymentConfig"] = name
+
+		queues := []string{}
+		for _, queue := range pool.Queues {
+			if queue.Weight != nil {
+				queues = append(queues, fmt.Sprintf("%s,%d", queue.Name, *queue.Weight))
+			} else {
+				queues = append(queues, queue.Name)
+			}
+		}
+
+		// Same defaults as system-sidekiq
+		resources := &v1.ResourceRequirements{}
+		if *s.apimanager.Spec.ResourceRequirementsEnabled {
+			resources = component.DefaultSidekiqContainerResourceRequirements()
+		}
+		if pool.Resources != nil {
+			resources = pool.Resources
+		}
+
+		var replicas int32 = 1
+		if pool.Replicas != nil {
+			replicas = int32(*pool.Replicas)
+		}
+
+		var priorityClassName string
+		if pool.PriorityClassName != nil {
+			priorityClassName = *pool.PriorityClassName
+		}
+
+		s.options.SidekiqPools = append(s.options.SidekiqPools, component.SystemSidekiqPoolOptions{
+			Name:                          pool.Name,
+			Queues:                        queues,
+			Replicas:                      replicas,
+			ContainerResourceRequirements: resources,
+			CommonLabels:                  commonLabels,
+			PodTemplateLabels:             podTemplateLabels,
+			Affinity:                      pool.Affinity,
+			Tolerations:                   pool.Tolerations,
+			PriorityClassName:             priorityClassName,
+			TopologySpreadConstraints:     pool.TopologySpreadConstraints,
+			PodTemplateAnnotations:        pool.Annotations,
+		})
+	}
+}
+
 func (s *SystemOptionsProvider) providerUILabels() map[string]string {
 	labels := s.commonLabels()
 	labels["threescale_component_element"] = "provider-ui"
@@ -625,3 +796,21 @@ func (s *SystemOptionsProvider) setPodTemplateAnnotations() {
 	s.options.AppPodTemplateAnnotations = s.apimanager.Spec.System.AppSpec.Annotations
 	s.options.SideKiqPodTemplateAnnotations = s.apimanager.Spec.System.SidekiqSpec.Annotations
 }
+
+// setConfigHashAnnotations rolls out the system pods when the SMTP, reCAPTCHA or events hook
+// configuration declared in the APIManager changes
+func (s *SystemOptionsProvider) setConfigHashAnnotations() error {
+	hash, err := SystemConfigHash(s.apimanager, s.client)
+	if err != nil || hash == "" {
+		return err
+	}
+
+	s.options.AppPodTemplateAnnotations = podTemplateAnnotationsWith(s.options.AppPodTemplateAnnotations, SystemConfigHashAnnotation, hash)
+	s.options.SideKiqPodTemplateAnnotations = podTemplateAnnotationsWith(s.options.SideKiqPodTemplateAnnotations, SystemConfigHashAnnotation, hash)
+	for idx := range s.options.SidekiqPools {
+		pool := &s.options.SidekiqPools[idx]
+		pool.PodTemplateAnnotations = podTemplateAnnotationsWith(pool.PodTemplateAnnotations, SystemConfigHashAnnotation, hash)
+	}
+
+	return nil
+}
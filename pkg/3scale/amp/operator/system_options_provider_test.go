@@ -1,6 +1,7 @@
 package operator
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -8,6 +9,7 @@ import (
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
+	oprand "github.com/3scale/3scale-operator/pkg/crypto/rand"
 	"github.com/3scale/3scale-operator/pkg/helper"
 
 	"github.com/google/go-cmp/cmp"
@@ -63,6 +65,7 @@ func testSystemSidekiqPodTemplateLabels() map[string]string {
 		"threescale_component":         "system",
 		"threescale_component_element": "sidekiq",
 		"deploymentConfig":             "system-sidekiq",
+		"threescale_sidekiq_pool":      "default",
 	}
 	addExpectedMeteringLabels(labels, "system-sidekiq", helper.ApplicationType)
 
@@ -310,6 +313,9 @@ func defaultSystemOptions(opts *component.SystemOptions) *component.SystemOption
 		MasterAccessToken:                         opts.MasterAccessToken,
 		ApicastAccessToken:                        opts.ApicastAccessToken,
 		AppReplicas:                               1,
+		AppMasterReplicas:                         1,
+		AppProviderReplicas:                       1,
+		AppDeveloperReplicas:                      1,
 		SidekiqReplicas:                           1,
 		AdminEmail:                                &tmpSystemAdminEmail,
 		UserSessionTTL:                            &tmpSystemUserSessionTTL,
@@ -606,6 +612,67 @@ func TestGetSystemOptionsProvider(t *testing.T) {
 				return expectedOpts
 			},
 		},
+		{"WithSidekiqPools",
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanagerSpecTestSystemOptions()
+				apimanager.Spec.System.SidekiqPools = []appsv1alpha1.SystemSidekiqPoolSpec{
+					{
+						Name:        "critical",
+						Queues:      []appsv1alpha1.SidekiqQueueSpec{{Name: "zync", Weight: &[]int32{40}[0]}, {Name: "backend_sync"}},
+						Replicas:    &[]int64{2}[0],
+						Tolerations: testSystemSidekiqTolerations(),
+						Labels:      map[string]string{"pool": "critical"},
+					},
+				}
+				return apimanager
+			}, nil, nil, nil, nil, nil, nil, nil, nil,
+			func(opts *component.SystemOptions) *component.SystemOptions {
+				expectedOpts := defaultSystemOptions(opts)
+				commonLabels := testSystemCommonSidekiqLabels()
+				commonLabels["threescale_sidekiq_pool"] = "critical"
+				podTemplateLabels := map[string]string{
+					"app":                          appLabel,
+					"threescale_component":         "system",
+					"threescale_component_element": "sidekiq",
+					"threescale_sidekiq_pool":      "critical",
+					"deploymentConfig":             "system-sidekiq-critical",
+					"pool":                         "critical",
+				}
+				addExpectedMeteringLabels(podTemplateLabels, "system-sidekiq-critical", helper.ApplicationType)
+				expectedOpts.SidekiqPools = []component.SystemSidekiqPoolOptions{
+					{
+						Name:                          "critical",
+						Queues:                        []string{"zync,40", "backend_sync"},
+						Replicas:                      2,
+						ContainerResourceRequirements: component.DefaultSidekiqContainerResourceRequirements(),
+						CommonLabels:                  commonLabels,
+						PodTemplateLabels:             podTemplateLabels,
+						Tolerations:                   testSystemSidekiqTolerations(),
+					},
+				}
+				return expectedOpts
+			},
+		},
+		{"WithSplitDeployments",
+			func() *appsv1alpha1.APIManager {
+				apimanager := basicApimanagerSpecTestSystemOptions()
+				apimanager.Spec.System.AppSpec.Replicas = &[]int64{2}[0]
+				apimanager.Spec.System.AppSpec.SplitDeployments = &appsv1alpha1.SystemAppSplitDeploymentsSpec{
+					Enabled:          true,
+					ProviderReplicas: &[]int64{3}[0],
+				}
+				return apimanager
+			}, nil, nil, nil, nil, nil, nil, nil, nil,
+			func(opts *component.SystemOptions) *component.SystemOptions {
+				expectedOpts := defaultSystemOptions(opts)
+				expectedOpts.AppReplicas = 2
+				expectedOpts.AppSplitDeployments = true
+				expectedOpts.AppMasterReplicas = 2
+				expectedOpts.AppProviderReplicas = 3
+				expectedOpts.AppDeveloperReplicas = 2
+				return expectedOpts
+			},
+		},
 		{"WithNoEmptyMailFromAddress",
 			// When no SMTP From mail address is defined in the secret then
 			// the corresponding SystemOption option is set as the empty string.
@@ -653,7 +720,7 @@ func TestGetSystemOptionsProvider(t *testing.T) {
 				objs = append(objs, tc.s3Secret)
 			}
 			cl := fake.NewFakeClient(objs...)
-			optsProvider := NewSystemOptionsProvider(tc.apimanagerFactory(), namespace, cl)
+			optsProvider := NewSystemOptionsProvider(tc.apimanagerFactory(), namespace, cl, oprand.DefaultGenerator())
 			opts, err := optsProvider.GetSystemOptions()
 			if err != nil {
 				subT.Fatal(err)
@@ -665,3 +732,113 @@ func TestGetSystemOptionsProvider(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSystemOptionsProviderDeclarativeConfig(t *testing.T) {
+	smtpAuthentication := "login"
+	smtpUsername := "mailer"
+	smtpFromAddress := "noreply@example.com"
+	tlsMode := appsv1alpha1.SMTPTLSModeTLS
+	eventsHookURL := "http://mymaster:5000/somepath"
+
+	apimanager := basicApimanagerSpecTestSystemOptions()
+	apimanager.Spec.System.SMTPSpec = &appsv1alpha1.SystemSMTPSpec{
+		Address:        "smtp.example.com",
+		Port:           465,
+		Authentication: &smtpAuthentication,
+		Username:       &smtpUsername,
+		PasswordSecretRef: &v1.SecretKeySelector{
+			LocalObjectReference: v1.LocalObjectReference{Name: "mycredentials"}, Key: "smtp-password",
+		},
+		TLSMode:     &tlsMode,
+		FromAddress: &smtpFromAddress,
+	}
+	apimanager.Spec.System.RecaptchaSpec = &appsv1alpha1.SystemRecaptchaSpec{
+		PublicKey: "someCaptchaPK",
+		PrivateKeySecretRef: v1.SecretKeySelector{
+			LocalObjectReference: v1.LocalObjectReference{Name: "mycredentials"}, Key: "recaptcha-private-key",
+		},
+	}
+	apimanager.Spec.System.EventsHookSpec = &appsv1alpha1.SystemEventsHookSpec{
+		URL: &eventsHookURL,
+		PasswordSecretRef: v1.SecretKeySelector{
+			LocalObjectReference: v1.LocalObjectReference{Name: "mycredentials"}, Key: "events-hook-password",
+		},
+	}
+
+	credentials := GetTestSecret(namespace, "mycredentials", map[string]string{
+		"smtp-password":         "smtpPassword",
+		"recaptcha-private-key": "someCaptchaPrivate",
+		"events-hook-password":  "eventsHookPassword",
+	})
+	// Not used when the configuration is declared in the APIManager
+	objs := []runtime.Object{credentials, getRecaptchaSecret(), getEventHookSecret(), getSMTPSecretWithCustomSMTPAddress("other@example.com")}
+	cl := fake.NewFakeClient(objs...)
+
+	opts, err := NewSystemOptionsProvider(apimanager, namespace, cl, oprand.DefaultGenerator()).GetSystemOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash, err := SystemConfigHash(apimanager, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash == "" {
+		t.Fatal("expected system config hash")
+	}
+
+	expectedOptions := defaultSystemOptions(opts)
+	recaptchaPublicKey := "someCaptchaPK"
+	recaptchaPrivateKey := "someCaptchaPrivate"
+	expectedOptions.RecaptchaPublicKey = &recaptchaPublicKey
+	expectedOptions.RecaptchaPrivateKey = &recaptchaPrivateKey
+	expectedOptions.BackendSharedSecret = "eventsHookPassword"
+	expectedOptions.EventHooksURL = eventsHookURL
+	expectedOptions.SMTPSecretManaged = true
+	expectedOptions.RecaptchaSecretManaged = true
+	expectedOptions.EventsHookSecretManaged = true
+	address, port, empty, password := "smtp.example.com", "465", "", "smtpPassword"
+	trueValue, falseValue := "true", "false"
+	expectedOptions.SmtpSecretOptions = component.SystemSMTPSecretOptions{
+		Address:            &address,
+		Port:               &port,
+		Domain:             &empty,
+		Authentication:     &smtpAuthentication,
+		Username:           &smtpUsername,
+		Password:           &password,
+		OpenSSLVerifyMode:  &empty,
+		FromAddress:        &smtpFromAddress,
+		TLS:                &trueValue,
+		EnableStartTLS:     &falseValue,
+		EnableStartTLSAuto: &falseValue,
+	}
+	expectedOptions.AppPodTemplateAnnotations = map[string]string{SystemConfigHashAnnotation: hash}
+	expectedOptions.SideKiqPodTemplateAnnotations = map[string]string{SystemConfigHashAnnotation: hash}
+
+	if !reflect.DeepEqual(expectedOptions, opts) {
+		t.Fatalf("Resulting expected options differ: %s", cmp.Diff(expectedOptions, opts, cmpopts.IgnoreUnexported(resource.Quantity{})))
+	}
+
+	// The connectivity check does not roll out the system pods
+	apimanager.Spec.System.SMTPSpec.ConnectivityCheck = true
+	checkHash, err := SystemConfigHash(apimanager, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if checkHash != hash {
+		t.Errorf("expected hash %s not to change with the connectivity check, got %s", hash, checkHash)
+	}
+
+	// Updating a referenced secret rolls out the system pods
+	credentials.Data["smtp-password"] = []byte("newSMTPPassword")
+	if err := cl.Update(context.TODO(), credentials); err != nil {
+		t.Fatal(err)
+	}
+	updatedHash, err := SystemConfigHash(apimanager, cl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updatedHash == hash {
+		t.Errorf("expected hash to change when a referenced secret is updated")
+	}
+}
@@ -8,26 +8,29 @@ import (
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
+	oprand "github.com/3scale/3scale-operator/pkg/crypto/rand"
 	"github.com/3scale/3scale-operator/pkg/helper"
 	v1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type SystemPostgresqlOptionsProvider struct {
-	apimanager   *appsv1alpha1.APIManager
-	namespace    string
-	client       client.Client
-	options      *component.SystemPostgreSQLOptions
-	secretSource *helper.SecretSource
+	apimanager      *appsv1alpha1.APIManager
+	namespace       string
+	client          client.Client
+	options         *component.SystemPostgreSQLOptions
+	secretSource    *helper.SecretSource
+	randomGenerator *oprand.Generator
 }
 
-func NewSystemPostgresqlOptionsProvider(apimanager *appsv1alpha1.APIManager, namespace string, client client.Client) *SystemPostgresqlOptionsProvider {
+func NewSystemPostgresqlOptionsProvider(apimanager *appsv1alpha1.APIManager, namespace string, client client.Client, randomGenerator *oprand.Generator) *SystemPostgresqlOptionsProvider {
 	return &SystemPostgresqlOptionsProvider{
-		apimanager:   apimanager,
-		namespace:    namespace,
-		client:       client,
-		options:      component.NewSystemPostgreSQLOptions(),
-		secretSource: helper.NewSecretSource(client, namespace),
+		apimanager:      apimanager,
+		namespace:       namespace,
+		client:          client,
+		options:         component.NewSystemPostgreSQLOptions(),
+		secretSource:    helper.NewSecretSource(client, namespace),
+		randomGenerator: randomGenerator,
 	}
 }
 
@@ -69,7 +72,7 @@ func (s *SystemPostgresqlOptionsProvider) setSecretBasedOptions() error {
 	val, err = s.secretSource.FieldValue(
 		component.SystemSecretSystemDatabaseSecretName,
 		component.SystemSecretSystemDatabasePasswordFieldName,
-		component.DefaultSystemPostgresqlPassword())
+		component.DefaultSystemPostgresqlPassword(s.randomGenerator))
 	if err != nil {
 		return err
 	}
@@ -9,6 +9,7 @@ import (
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
+	oprand "github.com/3scale/3scale-operator/pkg/crypto/rand"
 	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -248,7 +249,7 @@ func TestGetSystemPostgreSQLOptionsProvider(t *testing.T) {
 				objs = append(objs, tc.systemDatabaseSecret)
 			}
 			cl := fake.NewFakeClient(objs...)
-			optsProvider := NewSystemPostgresqlOptionsProvider(tc.apimanagerFactory(), namespace, cl)
+			optsProvider := NewSystemPostgresqlOptionsProvider(tc.apimanagerFactory(), namespace, cl, oprand.DefaultGenerator())
 			opts, err := optsProvider.GetSystemPostgreSQLOptions()
 			if err != nil {
 				subT.Error(err)
@@ -284,7 +285,7 @@ func TestGetPostgreSQLOptionsInvalidURL(t *testing.T) {
 			secret := getSystemDBSecret(tc.databaseURL, systemPostgreSQLUsername, systemPostgreSQLPassword)
 			objs := []runtime.Object{secret}
 			cl := fake.NewFakeClient(objs...)
-			optsProvider := NewSystemPostgresqlOptionsProvider(basicApimanager(), namespace, cl)
+			optsProvider := NewSystemPostgresqlOptionsProvider(basicApimanager(), namespace, cl, oprand.DefaultGenerator())
 			_, err := optsProvider.GetSystemPostgreSQLOptions()
 			if err == nil {
 				subT.Fatal("expected to fail for invalid URL")
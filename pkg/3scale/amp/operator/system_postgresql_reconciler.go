@@ -3,6 +3,7 @@ package operator
 import (
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	oprand "github.com/3scale/3scale-operator/pkg/crypto/rand"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -20,7 +21,7 @@ func NewSystemPostgreSQLReconciler(baseAPIManagerLogicReconciler *BaseAPIManager
 }
 
 func (r *SystemPostgreSQLReconciler) Reconcile() (reconcile.Result, error) {
-	systemPostgreSQL, err := SystemPostgreSQL(r.apiManager, r.Client())
+	systemPostgreSQL, err := SystemPostgreSQL(r.apiManager, r.Client(), r.randomGenerator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -62,8 +63,8 @@ func (r *SystemPostgreSQLReconciler) Reconcile() (reconcile.Result, error) {
 	return reconcile.Result{}, nil
 }
 
-func SystemPostgreSQL(apimanager *appsv1alpha1.APIManager, client client.Client) (*component.SystemPostgreSQL, error) {
-	optsProvider := NewSystemPostgresqlOptionsProvider(apimanager, apimanager.Namespace, client)
+func SystemPostgreSQL(apimanager *appsv1alpha1.APIManager, client client.Client, randomGenerator *oprand.Generator) (*component.SystemPostgreSQL, error) {
+	optsProvider := NewSystemPostgresqlOptionsProvider(apimanager, apimanager.Namespace, client, randomGenerator)
 	opts, err := optsProvider.GetSystemPostgreSQLOptions()
 	if err != nil {
 		return nil, err
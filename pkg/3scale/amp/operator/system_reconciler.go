@@ -2,10 +2,12 @@ package operator
 
 import (
 	"fmt"
+	"reflect"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/common"
+	oprand "github.com/3scale/3scale-operator/pkg/crypto/rand"
 	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 	"github.com/3scale/3scale-operator/pkg/upgrade"
@@ -13,7 +15,12 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	appsv1 "github.com/openshift/api/apps/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -42,7 +49,7 @@ func (r *SystemReconciler) reconcileFileStorage(system *component.System) error
 }
 
 func (r *SystemReconciler) Reconcile() (reconcile.Result, error) {
-	system, err := System(r.apiManager, r.Client())
+	system, err := System(r.apiManager, r.Client(), r.randomGenerator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -52,52 +59,77 @@ func (r *SystemReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
-	// Provider Service
-	err = r.ReconcileService(system.ProviderService(), reconcilers.CreateOnlyMutator)
+	// Secrets are reconciled before the DeploymentConfigs,
+	// so the pods rolled out on configuration changes read the new values
+
+	// SMTP Secret
+	err = r.reconcileSystemSecret(system.SMTPSecret(), system.Options.SMTPSecretManaged)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	// Master Service
-	err = r.ReconcileService(system.MasterService(), reconcilers.CreateOnlyMutator)
+	// EventsHook Secret
+	err = r.reconcileSystemSecret(system.EventsHookSecret(), system.Options.EventsHookSecretManaged)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	// Developer Service
-	err = r.ReconcileService(system.DeveloperService(), reconcilers.CreateOnlyMutator)
+	// MasterApicast  Secret
+	err = r.ReconcileSecret(system.MasterApicastSecret(), reconcilers.DefaultsOnlySecretMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	// Memcached Service
-	err = r.ReconcileService(system.MemcachedService(), reconcilers.CreateOnlyMutator)
+	// SystemSeed Secret
+	err = r.ReconcileSecret(system.SeedSecret(), reconcilers.DefaultsOnlySecretMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	// SystemApp DC
-	systemAppDCMutators := []reconcilers.DCMutateFn{
-		reconcilers.DeploymentConfigImageChangeTriggerMutator,
-		reconcilers.DeploymentConfigAffinityMutator,
-		reconcilers.DeploymentConfigTolerationsMutator,
-		reconcilers.DeploymentConfigPodTemplateLabelsMutator,
-		reconcilers.DeploymentConfigPriorityClassMutator,
-		reconcilers.DeploymentConfigTopologySpreadConstraintsMutator,
-		reconcilers.DeploymentConfigPodTemplateAnnotationsMutator,
-		r.systemAppDCResourceMutator,
-		reconcilers.DeploymentConfigRemoveDuplicateEnvVarMutator,
-		// 3scale 2.13 -> 2.14
-		upgrade.SphinxAddressReference,
-		// 3scale 2.13 -> 2.14
-		upgrade.SystemBackendUrls,
+	// Recaptcha Secret
+	err = r.reconcileSystemSecret(system.RecaptchaSecret(), system.Options.RecaptchaSecretManaged)
+	if err != nil {
+		return reconcile.Result{}, err
 	}
 
-	if r.apiManager.Spec.System.AppSpec.Replicas != nil {
-		systemAppDCMutators = append(systemAppDCMutators, reconcilers.DeploymentConfigReplicasMutator)
+	// SystemApp Secret
+	err = r.ReconcileSecret(system.AppSecret(), reconcilers.DefaultsOnlySecretMutator)
+	if err != nil {
+		return reconcile.Result{}, err
 	}
 
-	err = r.ReconcileDeploymentConfig(system.AppDeploymentConfig(), reconcilers.DeploymentConfigMutator(systemAppDCMutators...))
+	// Memcached Secret
+	err = r.ReconcileSecret(system.MemcachedSecret(), reconcilers.DefaultsOnlySecretMutator)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	servedBySplitDeployments, err := r.appServedBySplitDeployments()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// Provider, Master and Developer Services
+	for _, service := range []*v1.Service{system.ProviderService(), system.MasterService(), system.DeveloperService()} {
+		// The services and the split deployments share the name
+		service.Spec.Selector = map[string]string{"deploymentConfig": component.SystemAppDeploymentName}
+		if servedBySplitDeployments {
+			service.Spec.Selector = map[string]string{"deploymentConfig": service.Name}
+		}
+
+		err = r.ReconcileService(service, reconcilers.ServiceSelectorMutator)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	// Memcached Service
+	err = r.ReconcileService(system.MemcachedService(), reconcilers.CreateOnlyMutator)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	err = r.reconcileAppDeployments(system, servedBySplitDeployments)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -115,8 +147,15 @@ func (r *SystemReconciler) Reconcile() (reconcile.Result, error) {
 		reconcilers.DeploymentConfigPriorityClassMutator,
 		reconcilers.DeploymentConfigTopologySpreadConstraintsMutator,
 		reconcilers.DeploymentConfigPodTemplateAnnotationsMutator,
+		systemSMTPTLSEnvVarMutator,
 	}
 
+	// Pool replicas always default to 1
+	sidekiqPoolDCMutators := append([]reconcilers.DCMutateFn{
+		reconcilers.DeploymentConfigReplicasMutator,
+		systemSidekiqPoolArgsMutator,
+	}, sidekiqDCMutators...)
+
 	if r.apiManager.Spec.System.SidekiqSpec.Replicas != nil {
 		sidekiqDCMutators = append(sidekiqDCMutators, reconcilers.DeploymentConfigReplicasMutator)
 	}
@@ -126,6 +165,25 @@ func (r *SystemReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
+	// Sidekiq pool DCs
+	for idx := range system.Options.SidekiqPools {
+		pool := &system.Options.SidekiqPools[idx]
+		err = r.ReconcileDeploymentConfig(system.SidekiqPoolDeploymentConfig(pool), reconcilers.DeploymentConfigMutator(sidekiqPoolDCMutators...))
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+
+		err = r.ReconcilePodDisruptionBudget(system.SidekiqPoolPodDisruptionBudget(pool), reconcilers.GenericPDBMutator)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	err = r.deleteRemovedSidekiqPools(system)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// System CM
 	err = r.ReconcileConfigMap(system.SystemConfigMap(), reconcilers.CreateOnlyMutator)
 	if err != nil {
@@ -138,91 +196,239 @@ func (r *SystemReconciler) Reconcile() (reconcile.Result, error) {
 		return reconcile.Result{}, err
 	}
 
-	// SMTP Secret
-	err = r.ReconcileSecret(system.SMTPSecret(), reconcilers.DefaultsOnlySecretMutator)
+	// Sidekiq PDB
+	err = r.ReconcilePodDisruptionBudget(system.SidekiqPodDisruptionBudget(), reconcilers.GenericPDBMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	// EventsHook Secret
-	err = r.ReconcileSecret(system.EventsHookSecret(), reconcilers.DefaultsOnlySecretMutator)
+	err = r.ReconcilePodMonitor(system.SystemSidekiqPodMonitor(), reconcilers.CreateOnlyMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	// MasterApicast  Secret
-	err = r.ReconcileSecret(system.MasterApicastSecret(), reconcilers.DefaultsOnlySecretMutator)
+	err = r.ReconcilePodMonitor(system.SystemAppPodMonitor(), reconcilers.CreateOnlyMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	// SystemSeed Secret
-	err = r.ReconcileSecret(system.SeedSecret(), reconcilers.DefaultsOnlySecretMutator)
+	sumRate, err := helper.SumRateForOpenshiftVersion(r.Context(), r.Client())
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	// Recaptcha Secret
-	err = r.ReconcileSecret(system.RecaptchaSecret(), reconcilers.DefaultsOnlySecretMutator)
+	err = r.ReconcileGrafanaDashboard(system.SystemGrafanaDashboard(sumRate), reconcilers.GenericGrafanaDashboardsMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	// SystemApp Secret
-	err = r.ReconcileSecret(system.AppSecret(), reconcilers.DefaultsOnlySecretMutator)
+	err = r.ReconcilePrometheusRules(system.SystemAppPrometheusRules(), reconcilers.CreateOnlyMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	// Memcached Secret
-	err = r.ReconcileSecret(system.MemcachedSecret(), reconcilers.DefaultsOnlySecretMutator)
+	err = r.ReconcilePrometheusRules(system.SystemSidekiqPrometheusRules(), reconcilers.CreateOnlyMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	// SystemApp PDB
-	err = r.ReconcilePodDisruptionBudget(system.AppPodDisruptionBudget(), reconcilers.GenericPDBMutator)
+	err = r.reconcileSMTPCheckJob()
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	// Sidekiq PDB
-	err = r.ReconcilePodDisruptionBudget(system.SidekiqPodDisruptionBudget(), reconcilers.GenericPDBMutator)
-	if err != nil {
-		return reconcile.Result{}, err
+	return r.reconcileAPIManagerSecretLabels()
+}
+
+// reconcileAPIManagerSecretLabels labels the APIManager with the UIDs of the secrets referenced
+// by the system configuration, so their changes trigger the APIManager reconciliation
+func (r *SystemReconciler) reconcileAPIManagerSecretLabels() (reconcile.Result, error) {
+	uids := []string{}
+	for _, secretRef := range SystemSecretRefs(r.apiManager) {
+		secret := &v1.Secret{}
+		secretKey := client.ObjectKey{Name: secretRef.Name, Namespace: r.apiManager.Namespace}
+		err := r.Client().Get(r.Context(), secretKey, secret)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		uids = append(uids, string(secret.GetUID()))
 	}
 
-	err = r.ReconcilePodMonitor(system.SystemSidekiqPodMonitor(), reconcilers.CreateOnlyMutator)
-	if err != nil {
-		return reconcile.Result{}, err
+	if !replaceAPIManagerPrefixedSecretLabels(r.apiManager, SystemSecretLabelPrefix, uids) {
+		return reconcile.Result{}, nil
 	}
 
-	err = r.ReconcilePodMonitor(system.SystemAppPodMonitor(), reconcilers.CreateOnlyMutator)
-	if err != nil {
-		return reconcile.Result{}, err
+	err := r.UpdateResource(r.apiManager)
+	return reconcile.Result{Requeue: true}, err
+}
+
+// reconcileSystemSecret fully reconciles the secrets declared in the APIManager spec.
+// Secrets provided by the user only get the missing fields
+func (r *SystemReconciler) reconcileSystemSecret(secret *v1.Secret, managed bool) error {
+	if !managed {
+		return r.ReconcileSecret(secret, reconcilers.DefaultsOnlySecretMutator)
 	}
 
-	sumRate, err := helper.SumRateForOpenshiftVersion(r.Context(), r.Client())
-	if err != nil {
-		return reconcile.Result{}, err
+	fieldMutators := []reconcilers.SecretMutateFn{}
+	for fieldName := range secret.StringData {
+		fieldMutators = append(fieldMutators, reconcilers.SecretReconcileField(fieldName))
 	}
+	return r.ReconcileSecret(secret, reconcilers.DeploymentSecretMutator(fieldMutators...))
+}
 
-	err = r.ReconcileGrafanaDashboard(system.SystemGrafanaDashboard(sumRate), reconcilers.GenericGrafanaDashboardsMutator)
-	if err != nil {
-		return reconcile.Result{}, err
+// systemSMTPTLSEnvVarMutator adds the SMTP TLS env vars to the system DeploymentConfigs created before they existed
+func systemSMTPTLSEnvVarMutator(desired, existing *appsv1.DeploymentConfig) (bool, error) {
+	updated := false
+	for _, envVar := range component.SystemSMTPTLSEnvVarNames {
+		tmpUpdated := reconcilers.DeploymentConfigEnvVarReconciler(desired, existing, envVar)
+		updated = updated || tmpUpdated
 	}
 
-	err = r.ReconcilePrometheusRules(system.SystemAppPrometheusRules(), reconcilers.CreateOnlyMutator)
+	return updated, nil
+}
+
+// reconcileAppDeployments reconciles system-app or, when split deployments are enabled,
+// the system-master, system-provider and system-developer DeploymentConfigs.
+// The replaced DeploymentConfigs are deleted once the services no longer select them
+func (r *SystemReconciler) reconcileAppDeployments(system *component.System, servedBySplitDeployments bool) error {
+	split := r.apiManager.IsSystemAppSplitEnabled()
+
+	// SystemApp DC
+	systemAppDC := system.AppDeploymentConfig()
+	systemAppPDB := system.AppPodDisruptionBudget()
+	if split && servedBySplitDeployments {
+		common.TagObjectToDelete(systemAppDC)
+		common.TagObjectToDelete(systemAppPDB)
+	}
+
+	if !split || servedBySplitDeployments {
+		systemAppDCMutators := []reconcilers.DCMutateFn{
+			reconcilers.DeploymentConfigImageChangeTriggerMutator,
+			reconcilers.DeploymentConfigAffinityMutator,
+			reconcilers.DeploymentConfigTolerationsMutator,
+			reconcilers.DeploymentConfigPodTemplateLabelsMutator,
+			reconcilers.DeploymentConfigPriorityClassMutator,
+			reconcilers.DeploymentConfigTopologySpreadConstraintsMutator,
+			reconcilers.DeploymentConfigPodTemplateAnnotationsMutator,
+			r.systemAppDCResourceMutator,
+			reconcilers.DeploymentConfigRemoveDuplicateEnvVarMutator,
+			systemSMTPTLSEnvVarMutator,
+			// 3scale 2.13 -> 2.14
+			upgrade.SphinxAddressReference,
+			// 3scale 2.13 -> 2.14
+			upgrade.SystemBackendUrls,
+		}
+
+		// Replicas are managed by the HPA when enabled
+		if r.apiManager.Spec.System.AppSpec.Replicas != nil && !r.apiManager.IsSystemAppHPAEnabled() {
+			systemAppDCMutators = append(systemAppDCMutators, reconcilers.DeploymentConfigReplicasMutator)
+		}
+
+		err := r.ReconcileDeploymentConfig(systemAppDC, reconcilers.DeploymentConfigMutator(systemAppDCMutators...))
+		if err != nil {
+			return err
+		}
+
+		// SystemApp PDB
+		err = r.ReconcilePodDisruptionBudget(systemAppPDB, reconcilers.GenericPDBMutator)
+		if err != nil {
+			return err
+		}
+	}
+
+	// SystemApp HPA
+	appHPA := system.AppHorizontalPodAutoscaler()
+	if !r.apiManager.IsSystemAppHPAEnabled() || split {
+		common.TagObjectToDelete(appHPA)
+	}
+	err := r.ReconcileHorizontalPodAutoscaler(appHPA, reconcilers.GenericHPAMutator)
 	if err != nil {
-		return reconcile.Result{}, err
+		return err
 	}
 
-	err = r.ReconcilePrometheusRules(system.SystemSidekiqPrometheusRules(), reconcilers.CreateOnlyMutator)
+	// Split DCs
+	if !split && servedBySplitDeployments {
+		// system-app is not available yet
+		return nil
+	}
+
+	splitReplicasSet := map[string]bool{}
+	if split {
+		splitSpec := r.apiManager.Spec.System.AppSpec.SplitDeployments
+		appReplicasSet := r.apiManager.Spec.System.AppSpec.Replicas != nil
+		splitReplicasSet[component.SystemMasterDeploymentName] = appReplicasSet || splitSpec.MasterReplicas != nil
+		splitReplicasSet[component.SystemProviderDeploymentName] = appReplicasSet || splitSpec.ProviderReplicas != nil
+		splitReplicasSet[component.SystemDeveloperDeploymentName] = appReplicasSet || splitSpec.DeveloperReplicas != nil
+	}
+
+	for _, name := range component.SystemAppSplitDeploymentNames {
+		splitDCMutators := []reconcilers.DCMutateFn{
+			reconcilers.DeploymentConfigImageChangeTriggerMutator,
+			reconcilers.DeploymentConfigContainerResourcesMutator,
+			reconcilers.DeploymentConfigAffinityMutator,
+			reconcilers.DeploymentConfigTolerationsMutator,
+			reconcilers.DeploymentConfigPodTemplateLabelsMutator,
+			reconcilers.DeploymentConfigPriorityClassMutator,
+			reconcilers.DeploymentConfigTopologySpreadConstraintsMutator,
+			reconcilers.DeploymentConfigPodTemplateAnnotationsMutator,
+			reconcilers.DeploymentConfigRemoveDuplicateEnvVarMutator,
+			systemSMTPTLSEnvVarMutator,
+		}
+		if splitReplicasSet[name] {
+			splitDCMutators = append(splitDCMutators, reconcilers.DeploymentConfigReplicasMutator)
+		}
+
+		splitDC := system.AppSplitDeploymentConfig(name)
+		splitPDB := system.AppSplitPodDisruptionBudget(name)
+		if !split {
+			common.TagObjectToDelete(splitDC)
+			common.TagObjectToDelete(splitPDB)
+		}
+
+		err = r.ReconcileDeploymentConfig(splitDC, reconcilers.DeploymentConfigMutator(splitDCMutators...))
+		if err != nil {
+			return err
+		}
+
+		err = r.ReconcilePodDisruptionBudget(splitPDB, reconcilers.GenericPDBMutator)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// appServedBySplitDeployments returns true when the system-provider, system-master and system-developer services
+// select the split deployments instead of system-app.
+// When switching between system-app and the split deployments, the services keep selecting
+// the previous deployments until the new ones are available
+func (r *SystemReconciler) appServedBySplitDeployments() (bool, error) {
+	systemApp, err := r.componentWorkload(component.SystemAppDeploymentName)
 	if err != nil {
-		return reconcile.Result{}, err
+		return false, err
 	}
 
-	return reconcile.Result{}, nil
+	splitExists := false
+	splitAvailable := true
+	for _, name := range component.SystemAppSplitDeploymentNames {
+		workload, err := r.componentWorkload(name)
+		if err != nil {
+			return false, err
+		}
+		if workload != nil {
+			splitExists = true
+		}
+		if workload == nil || !workload.available {
+			splitAvailable = false
+		}
+	}
+
+	if r.apiManager.IsSystemAppSplitEnabled() {
+		return systemApp == nil || splitAvailable, nil
+	}
+
+	return splitExists && (systemApp == nil || !systemApp.available), nil
 }
 
 func (r *SystemReconciler) systemAppDCResourceMutator(desired, existing *appsv1.DeploymentConfig) (bool, error) {
@@ -232,11 +438,12 @@ func (r *SystemReconciler) systemAppDCResourceMutator(desired, existing *appsv1.
 	//
 	// Check containers
 	//
-	if len(desired.Spec.Template.Spec.Containers) != 3 {
-		return false, fmt.Errorf(fmt.Sprintf("%s desired spec.template.spec.containers length changed to '%d', should be 3", desiredName, len(desired.Spec.Template.Spec.Containers)))
+	// Sidecars are added after the system-app containers
+	if len(desired.Spec.Template.Spec.Containers) < 3 {
+		return false, fmt.Errorf(fmt.Sprintf("%s desired spec.template.spec.containers length changed to '%d', should be at least 3", desiredName, len(desired.Spec.Template.Spec.Containers)))
 	}
 
-	if len(existing.Spec.Template.Spec.Containers) != 3 {
+	if len(existing.Spec.Template.Spec.Containers) != len(desired.Spec.Template.Spec.Containers) {
 		r.Logger().Info(fmt.Sprintf("%s spec.template.spec.containers length changed to '%d', recreating dc", desiredName, len(existing.Spec.Template.Spec.Containers)))
 		existing.Spec.Template.Spec.Containers = desired.Spec.Template.Spec.Containers
 		update = true
@@ -258,8 +465,99 @@ func (r *SystemReconciler) systemAppDCResourceMutator(desired, existing *appsv1.
 	return update, nil
 }
 
-func System(cr *appsv1alpha1.APIManager, client client.Client) (*component.System, error) {
-	optsProvider := NewSystemOptionsProvider(cr, cr.Namespace, client)
+// deleteRemovedSidekiqPools deletes the DeploymentConfigs (or Deployments) and PDBs
+// of the sidekiq pools no longer in the APIManager
+func (r *SystemReconciler) deleteRemovedSidekiqPools(system *component.System) error {
+	desiredPools := map[string]bool{}
+	for _, pool := range system.Options.SidekiqPools {
+		desiredPools[component.SidekiqPoolName(pool.Name)] = true
+	}
+
+	listOps := []client.ListOption{
+		client.InNamespace(r.apiManager.Namespace),
+		client.HasLabels{component.SystemSidekiqPoolLabelKey},
+	}
+
+	existingPools := []common.KubernetesObject{}
+	if r.apiManager.IsKubernetesDeploymentEnabled() {
+		deploymentList := &k8sappsv1.DeploymentList{}
+		err := r.Client().List(r.Context(), deploymentList, listOps...)
+		if err != nil {
+			return err
+		}
+		for idx := range deploymentList.Items {
+			existingPools = append(existingPools, &deploymentList.Items[idx])
+		}
+	} else {
+		dcList := &appsv1.DeploymentConfigList{}
+		err := r.Client().List(r.Context(), dcList, listOps...)
+		if err != nil {
+			return err
+		}
+		for idx := range dcList.Items {
+			existingPools = append(existingPools, &dcList.Items[idx])
+		}
+	}
+
+	for _, existing := range existingPools {
+		if desiredPools[existing.GetName()] || !metav1.IsControlledBy(existing, r.apiManager) {
+			continue
+		}
+
+		r.Logger().Info("Deleting removed sidekiq pool", "name", existing.GetName())
+		err := r.DeleteResource(existing)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+
+		pdb := &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      existing.GetName(),
+				Namespace: r.apiManager.Namespace,
+			},
+		}
+		common.TagObjectToDelete(pdb)
+		err = r.ReconcileResource(&policyv1.PodDisruptionBudget{}, pdb, reconcilers.GenericPDBMutator)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// systemSidekiqPoolArgsMutator reconciles the queues processed by the sidekiq pool
+func systemSidekiqPoolArgsMutator(desired, existing *appsv1.DeploymentConfig) (bool, error) {
+	if len(existing.Spec.Template.Spec.Containers) == 0 || len(desired.Spec.Template.Spec.Containers) == 0 {
+		return false, nil
+	}
+
+	existingContainer := &existing.Spec.Template.Spec.Containers[0]
+	desiredContainer := &desired.Spec.Template.Spec.Containers[0]
+	if !reflect.DeepEqual(existingContainer.Args, desiredContainer.Args) {
+		existingContainer.Args = desiredContainer.Args
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// SystemComponents returns the system DeploymentConfigs running the system image:
+// system-app, its split deployments, system-sidekiq and the sidekiq pools.
+// system-app and the split deployments are both listed, as they coexist while switching between them
+func SystemComponents(apimanager *appsv1alpha1.APIManager) []string {
+	names := append([]string{component.SystemAppDeploymentName}, component.SystemAppSplitDeploymentNames...)
+	names = append(names, component.SystemSidekiqName)
+	if apimanager.Spec.System != nil {
+		for _, pool := range apimanager.Spec.System.SidekiqPools {
+			names = append(names, component.SidekiqPoolName(pool.Name))
+		}
+	}
+	return names
+}
+
+func System(cr *appsv1alpha1.APIManager, client client.Client, randomGenerator *oprand.Generator) (*component.System, error) {
+	optsProvider := NewSystemOptionsProvider(cr, cr.Namespace, client, randomGenerator)
 	opts, err := optsProvider.GetSystemOptions()
 	if err != nil {
 		return nil, err
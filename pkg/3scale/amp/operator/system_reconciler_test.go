@@ -2,6 +2,7 @@ package operator
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
@@ -16,6 +17,7 @@ import (
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	v1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -243,3 +245,272 @@ func testSystemAPIManagerCreator(appReplicas, sidekiqReplicas *int64) *appsv1alp
 		},
 	}
 }
+
+func TestSystemReconcilerSidekiqPools(t *testing.T) {
+	var (
+		log          = logf.Log.WithName("operator_test")
+		weight int32 = 2
+	)
+	ctx := context.TODO()
+	s := scheme.Scheme
+
+	err := appsv1alpha1.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = appsv1.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := configv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	apimanager := testSystemAPIManagerCreator(nil, nil)
+	apimanager.Spec.System.SidekiqPools = []appsv1alpha1.SystemSidekiqPoolSpec{
+		{
+			Name: "critical",
+			Queues: []appsv1alpha1.SidekiqQueueSpec{
+				{Name: "critical", Weight: &weight},
+				{Name: "priority"},
+			},
+		},
+	}
+
+	objs := []runtime.Object{apimanager}
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+	baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, clientAPIReader, log, clientset.Discovery(), recorder)
+	baseAPIManagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager)
+
+	reconciler := NewSystemReconciler(baseAPIManagerLogicReconciler)
+	_, err = reconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	poolKey := types.NamespacedName{Name: "system-sidekiq-critical", Namespace: apimanager.Namespace}
+	dc := &appsv1.DeploymentConfig{}
+	err = cl.Get(ctx, poolKey, dc)
+	if err != nil {
+		t.Fatalf("error fetching sidekiq pool dc: %v", err)
+	}
+
+	expectedArgs := []string{
+		"bundle", "exec", "sidekiq", "--config", "config/sidekiq.yml", "--concurrency", "25",
+		"--queue", "critical,2", "--queue", "priority",
+	}
+	if !reflect.DeepEqual(dc.Spec.Template.Spec.Containers[0].Args, expectedArgs) {
+		t.Errorf("unexpected sidekiq pool args: %v", dc.Spec.Template.Spec.Containers[0].Args)
+	}
+	if dc.Spec.Template.Labels[component.SystemSidekiqPoolLabelKey] != "critical" {
+		t.Errorf("sidekiq pool pods not labeled with the pool name: %v", dc.Spec.Template.Labels)
+	}
+	if dc.Spec.Replicas != 1 {
+		t.Errorf("expected 1 sidekiq pool replica, got %d", dc.Spec.Replicas)
+	}
+
+	err = cl.Get(ctx, poolKey, &policyv1.PodDisruptionBudget{})
+	if err != nil {
+		t.Fatalf("error fetching sidekiq pool pdb: %v", err)
+	}
+
+	// Removing the pool deletes its DC and PDB
+	apimanager.Spec.System.SidekiqPools = nil
+	_, err = reconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = cl.Get(ctx, poolKey, &appsv1.DeploymentConfig{})
+	if !errors.IsNotFound(err) {
+		t.Errorf("expected sidekiq pool dc to be deleted, got: %v", err)
+	}
+	err = cl.Get(ctx, poolKey, &policyv1.PodDisruptionBudget{})
+	if !errors.IsNotFound(err) {
+		t.Errorf("expected sidekiq pool pdb to be deleted, got: %v", err)
+	}
+
+	err = cl.Get(ctx, types.NamespacedName{Name: "system-sidekiq", Namespace: apimanager.Namespace}, &appsv1.DeploymentConfig{})
+	if err != nil {
+		t.Errorf("error fetching system-sidekiq dc: %v", err)
+	}
+}
+
+func TestSystemReconcilerSplitDeployments(t *testing.T) {
+	var (
+		log = logf.Log.WithName("operator_test")
+	)
+	ctx := context.TODO()
+	s := scheme.Scheme
+
+	err := appsv1alpha1.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = appsv1.AddToScheme(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := configv1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	apimanager := testSystemAPIManagerCreator(nil, nil)
+
+	objs := []runtime.Object{apimanager}
+	cl := fake.NewFakeClient(objs...)
+	clientAPIReader := fake.NewFakeClient(objs...)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+	baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, clientAPIReader, log, clientset.Discovery(), recorder)
+	baseAPIManagerLogicReconciler := NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager)
+	reconciler := NewSystemReconciler(baseAPIManagerLogicReconciler)
+
+	setDCAvailable := func(name string) {
+		dc := &appsv1.DeploymentConfig{}
+		key := types.NamespacedName{Name: name, Namespace: apimanager.Namespace}
+		if err := cl.Get(ctx, key, dc); err != nil {
+			t.Fatalf("error fetching dc %s: %v", name, err)
+		}
+		dc.Status.Conditions = []appsv1.DeploymentCondition{
+			{Type: appsv1.DeploymentAvailable, Status: v1.ConditionTrue},
+		}
+		if err := cl.Update(ctx, dc); err != nil {
+			t.Fatalf("error updating dc %s: %v", name, err)
+		}
+	}
+
+	checkServiceSelectors := func(expected map[string]string) {
+		for serviceName, selector := range expected {
+			service := &v1.Service{}
+			err := cl.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: apimanager.Namespace}, service)
+			if err != nil {
+				t.Fatalf("error fetching service %s: %v", serviceName, err)
+			}
+			if service.Spec.Selector["deploymentConfig"] != selector {
+				t.Errorf("service %s: expected selector %s, got %v", serviceName, selector, service.Spec.Selector)
+			}
+		}
+	}
+
+	_, err = reconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	setDCAvailable(component.SystemAppDeploymentName)
+
+	// Enabling the split deployments keeps system-app serving until they are available
+	apimanager.Spec.System.AppSpec.SplitDeployments = &appsv1alpha1.SystemAppSplitDeploymentsSpec{Enabled: true}
+	_, err = reconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range component.SystemAppSplitDeploymentNames {
+		dc := &appsv1.DeploymentConfig{}
+		err = cl.Get(ctx, types.NamespacedName{Name: name, Namespace: apimanager.Namespace}, dc)
+		if err != nil {
+			t.Fatalf("error fetching split dc %s: %v", name, err)
+		}
+		if len(dc.Spec.Template.Spec.Containers) != 1 || dc.Spec.Template.Spec.Containers[0].Name != name {
+			t.Errorf("split dc %s: unexpected containers %v", name, dc.Spec.Template.Spec.Containers)
+		}
+		hasHooks := dc.Spec.Strategy.RollingParams != nil && dc.Spec.Strategy.RollingParams.Pre != nil
+		if hasHooks != (name == component.SystemMasterDeploymentName) {
+			t.Errorf("split dc %s: unexpected lifecycle hooks %v", name, dc.Spec.Strategy.RollingParams)
+		}
+		err = cl.Get(ctx, types.NamespacedName{Name: name, Namespace: apimanager.Namespace}, &policyv1.PodDisruptionBudget{})
+		if err != nil {
+			t.Fatalf("error fetching split pdb %s: %v", name, err)
+		}
+	}
+
+	err = cl.Get(ctx, types.NamespacedName{Name: component.SystemAppDeploymentName, Namespace: apimanager.Namespace}, &appsv1.DeploymentConfig{})
+	if err != nil {
+		t.Fatalf("expected system-app dc to be kept, got: %v", err)
+	}
+	checkServiceSelectors(map[string]string{
+		"system-master":    component.SystemAppDeploymentName,
+		"system-provider":  component.SystemAppDeploymentName,
+		"system-developer": component.SystemAppDeploymentName,
+	})
+
+	// Once available, the split deployments serve the traffic and system-app is deleted
+	for _, name := range component.SystemAppSplitDeploymentNames {
+		setDCAvailable(name)
+	}
+	_, err = reconciler.Reconcile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = cl.Get(ctx, types.NamespacedName{Name: component.SystemAppDeploymentName, Namespace: apimanager.Namespace}, &appsv1.DeploymentConfig{})
+	if !errors.IsNotFound(err) {
+		t.Errorf("expected system-app dc to be deleted, got: %v", err)
+	}
+	checkServiceSelectors(map[string]string{
+		"system-master":    component.SystemMasterDeploymentName,
+		"system-provider":  component.SystemProviderDeploymentName,
+		"system-developer": component.SystemDeveloperDeploymentName,
+	})
+}
+
+func TestSystemReconcilerSecretLabels(t *testing.T) {
+	ctx := context.TODO()
+	log := logf.Log.WithName("operator_test")
+
+	apimanager := basicApimanagerSpecTestSystemOptions()
+	apimanager.Labels = map[string]string{APIManagerSecretLabelPrefix + "apicast-policy-uid": APIManagerSecretLabelValue}
+	apimanager.Spec.System.SMTPSpec = &appsv1alpha1.SystemSMTPSpec{
+		Address: "smtp.example.com",
+		Port:    587,
+		PasswordSecretRef: &v1.SecretKeySelector{
+			LocalObjectReference: v1.LocalObjectReference{Name: "smtp-credentials"},
+			Key:                  "password",
+		},
+	}
+	smtpSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "smtp-credentials", Namespace: namespace, UID: "smtp-uid"},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(appsv1alpha1.GroupVersion, apimanager)
+	cl := fake.NewFakeClient(apimanager, smtpSecret)
+	clientset := fakeclientset.NewSimpleClientset()
+	recorder := record.NewFakeRecorder(10000)
+	baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, cl, log, clientset.Discovery(), recorder)
+	reconciler := NewSystemReconciler(NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager))
+
+	result, err := reconciler.reconcileAPIManagerSecretLabels()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Requeue {
+		t.Errorf("expected requeue after labeling the APIManager")
+	}
+
+	updated := &appsv1alpha1.APIManager{}
+	if err := cl.Get(ctx, k8sclient.ObjectKeyFromObject(apimanager), updated); err != nil {
+		t.Fatal(err)
+	}
+	expectedLabels := map[string]string{
+		APIManagerSecretLabelPrefix + "apicast-policy-uid": APIManagerSecretLabelValue,
+		SystemSecretLabelPrefix + "smtp-uid":               APIManagerSecretLabelValue,
+	}
+	if !reflect.DeepEqual(updated.Labels, expectedLabels) {
+		t.Errorf("unexpected labels: %v", updated.Labels)
+	}
+
+	// The APIcast secret labels are left untouched and no update is needed
+	result, err = reconciler.reconcileAPIManagerSecretLabels()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Requeue {
+		t.Errorf("unexpected requeue with the labels in place")
+	}
+}
@@ -80,6 +80,14 @@ func (r *SystemSearchdReconciler) upgradeFromSphinx() error {
 		return err
 	}
 
+	hasDeploymentConfigs, err := r.HasDeploymentConfigs()
+	if err != nil {
+		return err
+	}
+	if !hasDeploymentConfigs {
+		return nil
+	}
+
 	oldDC := &appsv1.DeploymentConfig{
 		ObjectMeta: metav1.ObjectMeta{Name: "system-sphinx", Namespace: r.apiManager.Namespace},
 	}
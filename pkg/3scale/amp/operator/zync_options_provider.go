@@ -7,6 +7,7 @@ import (
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
+	oprand "github.com/3scale/3scale-operator/pkg/crypto/rand"
 	"github.com/3scale/3scale-operator/pkg/helper"
 
 	v1 "k8s.io/api/core/v1"
@@ -14,20 +15,22 @@ import (
 )
 
 type ZyncOptionsProvider struct {
-	apimanager   *appsv1alpha1.APIManager
-	namespace    string
-	client       client.Client
-	zyncOptions  *component.ZyncOptions
-	secretSource *helper.SecretSource
+	apimanager      *appsv1alpha1.APIManager
+	namespace       string
+	client          client.Client
+	zyncOptions     *component.ZyncOptions
+	secretSource    *helper.SecretSource
+	randomGenerator *oprand.Generator
 }
 
-func NewZyncOptionsProvider(apimanager *appsv1alpha1.APIManager, namespace string, client client.Client) *ZyncOptionsProvider {
+func NewZyncOptionsProvider(apimanager *appsv1alpha1.APIManager, namespace string, client client.Client, randomGenerator *oprand.Generator) *ZyncOptionsProvider {
 	return &ZyncOptionsProvider{
-		apimanager:   apimanager,
-		namespace:    namespace,
-		client:       client,
-		zyncOptions:  component.NewZyncOptions(),
-		secretSource: helper.NewSecretSource(client, namespace),
+		apimanager:      apimanager,
+		namespace:       namespace,
+		client:          client,
+		zyncOptions:     component.NewZyncOptions(),
+		secretSource:    helper.NewSecretSource(client, namespace),
+		randomGenerator: randomGenerator,
 	}
 }
 
@@ -84,7 +87,7 @@ func (z *ZyncOptionsProvider) setSecretBasedOptions() error {
 		}
 	} else {
 		var err error
-		defaultInternalZyncDatabasePassword := component.DefaultZyncDatabasePassword()
+		defaultInternalZyncDatabasePassword := component.DefaultZyncDatabasePassword(z.randomGenerator)
 		zyncDatabasePassword, err = z.secretSource.FieldValue(component.ZyncSecretName, component.ZyncSecretDatabasePasswordFieldName, defaultInternalZyncDatabasePassword)
 		if err != nil {
 			return err
@@ -103,14 +106,14 @@ func (z *ZyncOptionsProvider) setSecretBasedOptions() error {
 			&z.zyncOptions.SecretKeyBase,
 			component.ZyncSecretName,
 			component.ZyncSecretKeyBaseFieldName,
-			component.DefaultZyncSecretKeyBase(),
+			component.DefaultZyncSecretKeyBase(z.randomGenerator),
 			false,
 		},
 		{
 			&z.zyncOptions.AuthenticationToken,
 			component.ZyncSecretName,
 			component.ZyncSecretAuthenticationTokenFieldName,
-			component.DefaultZyncAuthenticationToken(),
+			component.DefaultZyncAuthenticationToken(z.randomGenerator),
 			false,
 		},
 		{
@@ -15,6 +15,7 @@ import (
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
+	oprand "github.com/3scale/3scale-operator/pkg/crypto/rand"
 	"github.com/3scale/3scale-operator/pkg/helper"
 )
 
@@ -378,7 +379,7 @@ func TestGetZyncOptionsProvider(t *testing.T) {
 				objs = append(objs, tc.zyncSecret)
 			}
 			cl := fake.NewFakeClient(objs...)
-			optsProvider := NewZyncOptionsProvider(tc.apimanagerFactory(), namespace, cl)
+			optsProvider := NewZyncOptionsProvider(tc.apimanagerFactory(), namespace, cl, oprand.DefaultGenerator())
 			opts, err := optsProvider.GetZyncOptions()
 			if err != nil {
 				t.Error(err)
@@ -3,6 +3,7 @@ package operator
 import (
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	oprand "github.com/3scale/3scale-operator/pkg/crypto/rand"
 	"github.com/3scale/3scale-operator/pkg/helper"
 	"github.com/3scale/3scale-operator/pkg/reconcilers"
 
@@ -21,13 +22,13 @@ func NewZyncReconciler(baseAPIManagerLogicReconciler *BaseAPIManagerLogicReconci
 }
 
 func (r *ZyncReconciler) Reconcile() (reconcile.Result, error) {
-	zync, err := Zync(r.apiManager, r.Client())
+	zync, err := Zync(r.apiManager, r.Client(), r.randomGenerator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
 	// Zync Que Role
-	err = r.ReconcileRole(zync.QueRole(), reconcilers.CreateOnlyMutator)
+	err = r.ReconcileRole(zync.QueRole(), reconcilers.RoleRulesMutator)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -163,8 +164,8 @@ func (r *ZyncReconciler) Reconcile() (reconcile.Result, error) {
 	return reconcile.Result{}, nil
 }
 
-func Zync(apimanager *appsv1alpha1.APIManager, client client.Client) (*component.Zync, error) {
-	optsProvider := NewZyncOptionsProvider(apimanager, apimanager.Namespace, client)
+func Zync(apimanager *appsv1alpha1.APIManager, client client.Client, randomGenerator *oprand.Generator) (*component.Zync, error) {
+	optsProvider := NewZyncOptionsProvider(apimanager, apimanager.Namespace, client, randomGenerator)
 	opts, err := optsProvider.GetZyncOptions()
 	if err != nil {
 		return nil, err
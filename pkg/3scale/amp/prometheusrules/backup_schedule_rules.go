@@ -0,0 +1,27 @@
+package prometheusrules
+
+import (
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+)
+
+func init() {
+	PrometheusRuleFactories = append(PrometheusRuleFactories, NewBackupSchedulePrometheusRuleFactory)
+}
+
+type BackupSchedulePrometheusRuleFactory struct {
+}
+
+func NewBackupSchedulePrometheusRuleFactory() PrometheusRuleFactory {
+	return &BackupSchedulePrometheusRuleFactory{}
+}
+
+func (s *BackupSchedulePrometheusRuleFactory) Type() string {
+	return "threescale-backup-schedule"
+}
+
+func (s *BackupSchedulePrometheusRuleFactory) PrometheusRule(_ bool, ns string) *monitoringv1.PrometheusRule {
+	return component.BackupSchedulePrometheusRules(ns, appsv1alpha1.Default3scaleAppLabel)
+}
@@ -0,0 +1,253 @@
+package render
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	grafanav1alpha1 "github.com/grafana-operator/grafana-operator/v4/api/integreatly/v1alpha1"
+	appsv1 "github.com/openshift/api/apps/v1"
+	configv1 "github.com/openshift/api/config/v1"
+	imagev1 "github.com/openshift/api/image/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/operator"
+	certmanagerv1 "github.com/3scale/3scale-operator/pkg/certmanager/v1"
+	oprand "github.com/3scale/3scale-operator/pkg/crypto/rand"
+	"github.com/3scale/3scale-operator/pkg/reconcilers"
+)
+
+const (
+	// RedactedSecretValue replaces the values of the rendered secrets.
+	// Secret values are either generated randomly or read from the cluster
+	RedactedSecretValue = "<redacted>"
+
+	// maxReconcileIterations bounds the reconcile loop, as the APIManager controller
+	// requeues the APIManager when it updates the APIManager resource
+	maxReconcileIterations = 10
+)
+
+// APIManagerOptions configures the stand-in cluster the APIManager is rendered for
+type APIManagerOptions struct {
+	// OpenShift enables the OpenShift APIs: DeploymentConfigs, Routes and ImageStreams
+	OpenShift bool
+	// Objects existing in the cluster, e.g. the secrets referenced by the APIManager.
+	// Referenced secrets not provided are replaced by stand-in secrets
+	Objects []client.Object
+}
+
+// Scheme returns a scheme including all the kinds managed by the APIManager controller
+func Scheme() *runtime.Scheme {
+	s := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(s))
+	utilruntime.Must(appsv1alpha1.AddToScheme(s))
+	utilruntime.Must(appsv1.AddToScheme(s))
+	utilruntime.Must(routev1.AddToScheme(s))
+	utilruntime.Must(imagev1.AddToScheme(s))
+	utilruntime.Must(configv1.AddToScheme(s))
+	utilruntime.Must(monitoringv1.AddToScheme(s))
+	utilruntime.Must(grafanav1alpha1.AddToScheme(s))
+	utilruntime.Must(certmanagerv1.AddToScheme(s))
+	return s
+}
+
+// APIManager returns every object the APIManager controller would create for the
+// provided APIManager, in creation order.
+// The APIManager controller logic runs against an in-memory stand-in cluster,
+// so the objects are built by the same option providers and component builders.
+// Secret values are redacted.
+func APIManager(apimanager *appsv1alpha1.APIManager, opts APIManagerOptions) ([]client.Object, error) {
+	apimanager = apimanager.DeepCopy()
+	if apimanager.Namespace == "" {
+		return nil, fmt.Errorf("APIManager namespace not set")
+	}
+
+	apimanager.UpdateExternalComponentsFromHighAvailability()
+	if _, err := apimanager.SetDefaults(); err != nil {
+		return nil, err
+	}
+
+	if fieldErrors := apimanager.Validate(); len(fieldErrors) > 0 {
+		return nil, fieldErrors.ToAggregate()
+	}
+
+	s := Scheme()
+
+	objects := []client.Object{apimanager}
+	objects = append(objects, opts.Objects...)
+	for _, secret := range standInSecrets(apimanager, opts.Objects) {
+		objects = append(objects, secret)
+	}
+
+	cl := &recordingClient{
+		Client: fake.NewClientBuilder().WithScheme(s).WithObjects(objects...).Build(),
+	}
+
+	discoveryClient := fakeclientset.NewSimpleClientset().Discovery().(*fakediscovery.FakeDiscovery)
+	discoveryClient.Resources = standInAPIResources(opts.OpenShift)
+
+	ctx := context.TODO()
+	baseReconciler := reconcilers.NewBaseReconciler(ctx, cl, s, cl, logr.Discard(), discoveryClient, &record.FakeRecorder{})
+	baseLogicReconciler := operator.NewBaseAPIManagerLogicReconciler(baseReconciler, apimanager)
+	// Values generated when not found in the cluster are rendered the same way on every run
+	baseLogicReconciler.SetRandomGenerator(oprand.NewGenerator(0))
+	logicReconciler := operator.NewAPIManagerLogicReconciler(baseLogicReconciler)
+
+	// Objects waiting for the objects created in the previous iteration,
+	// e.g. the Deployments waiting for their pre hook jobs, are created in the next one
+	converged := false
+	for i := 0; i < maxReconcileIterations && !converged; i++ {
+		createdCount := len(cl.created)
+		result, err := logicReconciler.Reconcile()
+		if err != nil {
+			return nil, err
+		}
+		converged = !result.Requeue && len(cl.created) == createdCount
+	}
+	if !converged {
+		return nil, fmt.Errorf("APIManager reconciliation did not finish after %d iterations", maxReconcileIterations)
+	}
+
+	result := []client.Object{}
+	for _, created := range cl.created {
+		runtimeObj, err := s.New(created.gvk)
+		if err != nil {
+			return nil, err
+		}
+		obj := runtimeObj.(client.Object)
+
+		err = cl.Get(ctx, created.key, obj)
+		if errors.IsNotFound(err) {
+			// Deleted after being created
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		cleanObject(obj, created.gvk)
+		result = append(result, obj)
+	}
+
+	return result, nil
+}
+
+// cleanObject removes the fields set by the stand-in cluster and redacts secret values
+func cleanObject(obj client.Object, gvk schema.GroupVersionKind) {
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	obj.SetResourceVersion("")
+	obj.SetCreationTimestamp(metav1.Time{})
+	obj.SetManagedFields(nil)
+
+	if job, ok := obj.(*batchv1.Job); ok {
+		job.Status = batchv1.JobStatus{}
+	}
+
+	if secret, ok := obj.(*v1.Secret); ok {
+		stringData := map[string]string{}
+		for key := range secret.Data {
+			stringData[key] = RedactedSecretValue
+		}
+		for key := range secret.StringData {
+			stringData[key] = RedactedSecretValue
+		}
+		secret.Data = nil
+		secret.StringData = stringData
+	}
+}
+
+type createdObject struct {
+	gvk schema.GroupVersionKind
+	key client.ObjectKey
+}
+
+// recordingClient records the objects created through the client.
+// Jobs are created completed, as if they had run in the stand-in cluster
+type recordingClient struct {
+	client.Client
+	created []createdObject
+}
+
+func (c *recordingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if job, ok := obj.(*batchv1.Job); ok {
+		job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{Type: batchv1.JobComplete, Status: v1.ConditionTrue})
+	}
+
+	if err := c.Client.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return err
+	}
+
+	c.created = append(c.created, createdObject{gvk: gvk, key: client.ObjectKeyFromObject(obj)})
+	return nil
+}
+
+func standInAPIResources(openshift bool) []*metav1.APIResourceList {
+	resources := []*metav1.APIResourceList{
+		{
+			GroupVersion: monitoringv1.SchemeGroupVersion.String(),
+			APIResources: []metav1.APIResource{
+				{Name: monitoringv1.PrometheusRuleName, Kind: monitoringv1.PrometheusRuleKind, Namespaced: true},
+				{Name: monitoringv1.ServiceMonitorName, Kind: monitoringv1.ServiceMonitorsKind, Namespaced: true},
+				{Name: monitoringv1.PodMonitorName, Kind: monitoringv1.PodMonitorsKind, Namespaced: true},
+			},
+		},
+		{
+			GroupVersion: grafanav1alpha1.GroupVersion.String(),
+			APIResources: []metav1.APIResource{
+				{Name: "grafanadashboards", Kind: "GrafanaDashboard", Namespaced: true},
+			},
+		},
+		{
+			GroupVersion: certmanagerv1.GroupVersion.String(),
+			APIResources: []metav1.APIResource{
+				{Name: "certificates", Kind: certmanagerv1.CertificateKind, Namespaced: true},
+			},
+		},
+	}
+
+	if openshift {
+		resources = append(resources,
+			&metav1.APIResourceList{
+				GroupVersion: appsv1.GroupVersion.String(),
+				APIResources: []metav1.APIResource{
+					{Name: "deploymentconfigs", Kind: "DeploymentConfig", Namespaced: true},
+				},
+			},
+			&metav1.APIResourceList{
+				GroupVersion: routev1.GroupVersion.String(),
+				APIResources: []metav1.APIResource{
+					{Name: "routes", Kind: "Route", Namespaced: true},
+				},
+			},
+			&metav1.APIResourceList{
+				GroupVersion: imagev1.GroupVersion.String(),
+				APIResources: []metav1.APIResource{
+					{Name: "imagestreams", Kind: "ImageStream", Namespaced: true},
+				},
+			},
+		)
+	}
+
+	return resources
+}
@@ -0,0 +1,289 @@
+package render
+
+import (
+	"reflect"
+	"testing"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	routev1 "github.com/openshift/api/route/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	certmanagerv1 "github.com/3scale/3scale-operator/pkg/certmanager/v1"
+)
+
+func testAPIManager() *appsv1alpha1.APIManager {
+	return &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "example-apimanager",
+			Namespace: "someNS",
+		},
+		Spec: appsv1alpha1.APIManagerSpec{
+			APIManagerCommonSpec: appsv1alpha1.APIManagerCommonSpec{
+				WildcardDomain: "example.com",
+			},
+		},
+	}
+}
+
+func countKind(objects []client.Object, kind string) int {
+	count := 0
+	for _, obj := range objects {
+		if obj.GetObjectKind().GroupVersionKind().Kind == kind {
+			count++
+		}
+	}
+	return count
+}
+
+func findObject(objects []client.Object, kind, name string) client.Object {
+	for _, obj := range objects {
+		if obj.GetObjectKind().GroupVersionKind().Kind == kind && obj.GetName() == name {
+			return obj
+		}
+	}
+	return nil
+}
+
+func TestAPIManagerOpenShift(t *testing.T) {
+	objects, err := APIManager(testAPIManager(), APIManagerOptions{OpenShift: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []struct {
+		testName string
+		kind     string
+		name     string
+	}{
+		{"backendListenerDC", "DeploymentConfig", component.BackendListenerName},
+		{"systemAppDC", "DeploymentConfig", "system-app"},
+		{"apicastProductionDC", "DeploymentConfig", "apicast-production"},
+		{"systemMySQLDC", "DeploymentConfig", "system-mysql"},
+		{"backendListenerService", "Service", component.BackendListenerName},
+		{"systemEnvironmentConfigMap", "ConfigMap", "system-environment"},
+		{"systemStoragePVC", "PersistentVolumeClaim", "system-storage"},
+		{"systemSeedSecret", "Secret", component.SystemSecretSystemSeedSecretName},
+		{"backendRoute", "Route", "backend"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			obj := findObject(objects, tc.kind, tc.name)
+			if obj == nil {
+				subT.Fatalf("%s %s not rendered", tc.kind, tc.name)
+			}
+			if obj.GetNamespace() != "someNS" {
+				subT.Errorf("unexpected namespace: %s", obj.GetNamespace())
+			}
+			if obj.GetResourceVersion() != "" {
+				subT.Errorf("unexpected resourceVersion: %s", obj.GetResourceVersion())
+			}
+		})
+	}
+
+	if _, ok := findObject(objects, "Route", "backend").(*routev1.Route); !ok {
+		t.Errorf("backend Route not rendered")
+	}
+	if countKind(objects, "Deployment") != 0 {
+		t.Errorf("unexpected Deployments rendered with OpenShift APIs")
+	}
+	if countKind(objects, "APIManager") != 0 {
+		t.Errorf("unexpected APIManager rendered")
+	}
+}
+
+func TestAPIManagerKubernetes(t *testing.T) {
+	deploymentType := appsv1alpha1.DeploymentTypeDeployment
+	apimanager := testAPIManager()
+	apimanager.Spec.DeploymentType = &deploymentType
+	apimanager.Spec.Ingress = &appsv1alpha1.IngressSpec{Enabled: true}
+
+	objects, err := APIManager(apimanager, APIManagerOptions{OpenShift: false})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if countKind(objects, "DeploymentConfig") != 0 {
+		t.Errorf("unexpected DeploymentConfigs rendered without OpenShift APIs")
+	}
+	if countKind(objects, "Route") != 0 {
+		t.Errorf("unexpected Routes rendered without OpenShift APIs")
+	}
+	if countKind(objects, "ImageStream") != 0 {
+		t.Errorf("unexpected ImageStreams rendered without OpenShift APIs")
+	}
+
+	if _, ok := findObject(objects, "Deployment", "system-app").(*k8sappsv1.Deployment); !ok {
+		t.Errorf("system-app Deployment not rendered")
+	}
+	if _, ok := findObject(objects, "Ingress", "backend").(*networkingv1.Ingress); !ok {
+		t.Errorf("backend Ingress not rendered")
+	}
+}
+
+func TestAPIManagerCertManager(t *testing.T) {
+	apimanager := testAPIManager()
+	apimanager.Spec.CertManager = &appsv1alpha1.CertManagerSpec{
+		Enabled:   true,
+		IssuerRef: &appsv1alpha1.CertManagerIssuerReference{Name: "letsencrypt"},
+	}
+
+	objects, err := APIManager(apimanager, APIManagerOptions{OpenShift: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, name := range []string{component.BackendCertificateName, component.SystemCertificateName} {
+		certificate, ok := findObject(objects, certmanagerv1.CertificateKind, name).(*certmanagerv1.Certificate)
+		if !ok {
+			t.Fatalf("certificate %s not rendered", name)
+		}
+		if certificate.Spec.IssuerRef.Name != "letsencrypt" {
+			t.Errorf("certificate %s: unexpected issuer: %v", name, certificate.Spec.IssuerRef)
+		}
+	}
+
+	// Without cert-manager, no certificate is requested
+	objects, err = APIManager(testAPIManager(), APIManagerOptions{OpenShift: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if countKind(objects, certmanagerv1.CertificateKind) != 0 {
+		t.Errorf("unexpected certificates rendered with cert-manager disabled")
+	}
+}
+
+func TestAPIManagerRedactsSecrets(t *testing.T) {
+	seed := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      component.SystemSecretSystemSeedSecretName,
+			Namespace: "someNS",
+		},
+		StringData: map[string]string{
+			component.SystemSecretSystemSeedAdminPasswordFieldName: "supersecret",
+		},
+	}
+
+	objects, err := APIManager(testAPIManager(), APIManagerOptions{OpenShift: true, Objects: []client.Object{seed}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if countKind(objects, "Secret") == 0 {
+		t.Fatal("no secrets rendered")
+	}
+
+	for _, obj := range objects {
+		secret, ok := obj.(*v1.Secret)
+		if !ok {
+			continue
+		}
+		if len(secret.Data) != 0 {
+			t.Errorf("secret %s data not redacted", secret.Name)
+		}
+		for key, val := range secret.StringData {
+			if val != RedactedSecretValue {
+				t.Errorf("secret %s field %s not redacted", secret.Name, key)
+			}
+		}
+	}
+}
+
+func TestAPIManagerDeterministic(t *testing.T) {
+	first, err := APIManager(testAPIManager(), APIManagerOptions{OpenShift: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := APIManager(testAPIManager(), APIManagerOptions{OpenShift: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("rendered objects differ between runs")
+	}
+}
+
+func TestAPIManagerStandInSecrets(t *testing.T) {
+	trueVal := true
+	apimanager := testAPIManager()
+	apimanager.Spec.ExternalComponents = appsv1alpha1.AllComponentsExternal()
+	apimanager.Spec.Apicast = &appsv1alpha1.ApicastSpec{
+		ProductionSpec: &appsv1alpha1.ApicastProductionSpec{
+			CustomPolicies: []appsv1alpha1.CustomPolicySpec{
+				{Name: "policy", Version: "0.1", SecretRef: &v1.LocalObjectReference{Name: "policy-secret"}},
+			},
+			OpenTracing: &appsv1alpha1.APIcastOpenTracingSpec{
+				Enabled:                &trueVal,
+				TracingConfigSecretRef: &v1.LocalObjectReference{Name: "tracing-secret"},
+			},
+		},
+	}
+
+	objects, err := APIManager(apimanager, APIManagerOptions{OpenShift: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Internal databases are not deployed when the components are external
+	if findObject(objects, "DeploymentConfig", "system-mysql") != nil {
+		t.Errorf("unexpected system-mysql rendered with external system database")
+	}
+
+	// Stand-in secrets exist in the cluster, they are not created by the operator
+	for _, name := range []string{"policy-secret", "tracing-secret", component.SystemSecretSystemDatabaseSecretName} {
+		if findObject(objects, "Secret", name) != nil {
+			t.Errorf("unexpected stand-in secret %s rendered", name)
+		}
+	}
+
+	dc, ok := findObject(objects, "DeploymentConfig", "apicast-production").(*appsv1.DeploymentConfig)
+	if !ok {
+		t.Fatal("apicast-production DeploymentConfig not rendered")
+	}
+	volumeFound := false
+	for _, volume := range dc.Spec.Template.Spec.Volumes {
+		if volume.Secret != nil && volume.Secret.SecretName == "policy-secret" {
+			volumeFound = true
+		}
+	}
+	if !volumeFound {
+		t.Errorf("custom policy volume not found in apicast-production")
+	}
+}
+
+func TestAPIManagerProvidedSecretOverridesStandIn(t *testing.T) {
+	apimanager := testAPIManager()
+	apimanager.Spec.System = &appsv1alpha1.SystemSpec{
+		FileStorageSpec: &appsv1alpha1.SystemFileStorageSpec{
+			S3: &appsv1alpha1.SystemS3Spec{ConfigurationSecretRef: v1.LocalObjectReference{Name: "s3-secret"}},
+		},
+	}
+	// Provided secret misses the required fields
+	s3Secret := &v1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "s3-secret", Namespace: "someNS"}}
+
+	_, err := APIManager(apimanager, APIManagerOptions{OpenShift: true, Objects: []client.Object{s3Secret}})
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+
+	_, err = APIManager(apimanager, APIManagerOptions{OpenShift: true})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestAPIManagerNamespaceRequired(t *testing.T) {
+	apimanager := testAPIManager()
+	apimanager.Namespace = ""
+
+	_, err := APIManager(apimanager, APIManagerOptions{OpenShift: true})
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
@@ -957,18 +957,18 @@
       "steppedLine": false,
       "targets": [
         {
-          "expr": "sum(rate(sidekiq_jobs_success_total{namespace='$namespace'}[1m]))",
+          "expr": "sum(rate(sidekiq_jobs_success_total{namespace='$namespace',threescale_sidekiq_pool=~'$sidekiqPool'}[1m])) by (threescale_sidekiq_pool)",
           "format": "time_series",
           "hide": false,
           "intervalFactor": 2,
-          "legendFormat": "Successful jobs",
+          "legendFormat": "Successful jobs in {{`{{threescale_sidekiq_pool}}`}} pool",
           "refId": "A"
         },
         {
-          "expr": "sum(rate(sidekiq_jobs_failed_total{namespace='$namespace'}[1m]))",
+          "expr": "sum(rate(sidekiq_jobs_failed_total{namespace='$namespace',threescale_sidekiq_pool=~'$sidekiqPool'}[1m])) by (threescale_sidekiq_pool)",
           "format": "time_series",
           "intervalFactor": 2,
-          "legendFormat": "Failed jobs",
+          "legendFormat": "Failed jobs in {{`{{threescale_sidekiq_pool}}`}} pool",
           "refId": "B"
         }
       ],
@@ -1055,7 +1055,7 @@
       "steppedLine": false,
       "targets": [
         {
-          "expr": "sum(rate(sidekiq_jobs_success_total{namespace='$namespace'}[1m])) by (queue)",
+          "expr": "sum(rate(sidekiq_jobs_success_total{namespace='$namespace',threescale_sidekiq_pool=~'$sidekiqPool'}[1m])) by (queue)",
           "format": "time_series",
           "hide": false,
           "intervalFactor": 2,
@@ -1063,7 +1063,7 @@
           "refId": "A"
         },
         {
-          "expr": "sum(rate(sidekiq_jobs_failed_total{namespace='$namespace'}[1m])) by (queue)",
+          "expr": "sum(rate(sidekiq_jobs_failed_total{namespace='$namespace',threescale_sidekiq_pool=~'$sidekiqPool'}[1m])) by (queue)",
           "format": "time_series",
           "intervalFactor": 2,
           "legendFormat": "Failed in {{`{{queue}}`}}",
@@ -1153,7 +1153,7 @@
       "steppedLine": false,
       "targets": [
         {
-          "expr": "sum(rate(sidekiq_jobs_success_total{namespace='$namespace'}[1m])) by (worker)",
+          "expr": "sum(rate(sidekiq_jobs_success_total{namespace='$namespace',threescale_sidekiq_pool=~'$sidekiqPool'}[1m])) by (worker)",
           "format": "time_series",
           "interval": "",
           "intervalFactor": 2,
@@ -1419,7 +1419,7 @@
       "steppedLine": false,
       "targets": [
         {
-          "expr": "histogram_quantile(0.95, sum(rate(sidekiq_job_runtime_seconds_bucket{namespace='$namespace'}[1m])) by (le)) ",
+          "expr": "histogram_quantile(0.95, sum(rate(sidekiq_job_runtime_seconds_bucket{namespace='$namespace',threescale_sidekiq_pool=~'$sidekiqPool'}[1m])) by (le)) ",
           "format": "time_series",
           "intervalFactor": 2,
           "legendFormat": "95% quantile of sidekiq ",
@@ -1505,7 +1505,7 @@
       "steppedLine": false,
       "targets": [
         {
-          "expr": "histogram_quantile(0.95, sum(rate(sidekiq_job_runtime_seconds_bucket{namespace='$namespace'}[1m])) by (worker, le)) ",
+          "expr": "histogram_quantile(0.95, sum(rate(sidekiq_job_runtime_seconds_bucket{namespace='$namespace',threescale_sidekiq_pool=~'$sidekiqPool'}[1m])) by (worker, le)) ",
           "format": "time_series",
           "intervalFactor": 2,
           "legendFormat": "{{`{{worker}}`}}",
@@ -3531,6 +3531,27 @@
         "tagsQuery": "",
         "type": "query",
         "useTags": false
+      },
+      {
+        "allValue": ".*",
+        "datasource": "$datasource",
+        "definition": "label_values(up{namespace='$namespace',job=~'.*system-sidekiq.*'}, threescale_sidekiq_pool)",
+        "hide": 0,
+        "includeAll": true,
+        "label": "sidekiq pool",
+        "multi": true,
+        "name": "sidekiqPool",
+        "options": [],
+        "query": "label_values(up{namespace='$namespace',job=~'.*system-sidekiq.*'}, threescale_sidekiq_pool)",
+        "refresh": 1,
+        "regex": "",
+        "skipUrlSync": false,
+        "sort": 1,
+        "tagValuesQuery": "",
+        "tags": [],
+        "tagsQuery": "",
+        "type": "query",
+        "useTags": false
       }
     ]
   },
@@ -184,7 +184,7 @@ func monitoringKubernetesResourcesByPodGrafanaDashboard1JsonTpl() (*asset, error
 	return a, nil
 }
 
-var _monitoringSystemGrafanaDashboard1JsonTpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x7d\x6b\x73\xdb\x38\xb2\xf6\xf7\xfc\x0a\xbc\x9c\x99\xb5\x33\x2b\x39\xa2\x2e\xf1\xa5\x2a\xf5\x56\x9c\x4c\xce\xec\x56\x92\xf5\x38\x9e\xd9\x9a\x93\x72\x69\x21\x12\x96\xb0\x26\x01\x06\x00\x6d\x29\x2e\xcf\x6f\x3f\x45\x90\x14\xc1\x9b\xae\xd4\x1d\x5f\x12\x0b\x20\x41\xb0\xbb\xd1\xcf\x03\xa0\xd1\x7c\x7a\x01\x80\x01\x09\xa1\x02\x0a\x4c\x09\x37\x2e\x40\x50\x04\x80\xe1\x60\x2e\x8c\x0b\xf0\x55\xfe\x02\x51\xa9\xac\xe9\xf9\xd8\x11\xff\x20\xc6\x05\x30\x6b\x49\xa9\x0d\x05\xe4\xd4\x67\x16\x32\x2e\x80\x51\xaf\x83\xff\x61\xf0\x0e\x12\x08\xea\x75\x43\xb9\x0c\x11\xd8\x73\x82\x4b\x04\xf3\x91\x52\x3e\xc0\x76\x41\x29\xb6\x28\x79\x47\x1d\xca\x82\x36\x59\xbf\x07\x8f\x1b\x35\xd0\x34\xcd\x1a\x68\x76\x3a\x35\x60\xbe\x54\x9b\x26\xd0\x95\xcf\x7e\x9b\xbc\x0e\xf8\x1b\x78\xeb\x20\x26\xb8\x7a\x9d\x18\x79\xf2\x3a\x1b\xf2\x41\x8f\x42\x66\x1b\x51\xdd\xb3\xfc\xff\xf6\x05\x00\xcf\xc1\xe5\x86\x8d\xb8\xc5\xb0\x17\xb4\x14\x5c\x2f\xdb\x30\x90\x8d\x45\xe6\x15\x8c\x3e\x41\xe2\x1f\xb6\x71\x01\x88\xef\x38\x61\x09\x83\xde\xe0\x86\x52\x47\x60\xcf\xb8\x00\x0d\x59\x88\x83\x4b\x4e\xc3\x3f\x05\x62\x30\x6a\xd8\x7c\xdd\x30\xcf\x4f\x3b\x66\xc7\x34\x5b\xe7\xb2\xd6\xc1\xe4\x3e\x50\xc5\xd7\x5b\xf9\xd3\x83\x04\x39\x7c\xac\x8c\x58\x15\x86\x45\x1d\x07\x7a\x1c\x05\xcd\xde\x41\x87\x8f\x25\x67\xf4\x19\xb6\xaf\x68\xa2\xcd\x50\xc4\x19\x8d\x3d\x1a\x17\xa0\xd9\x56\x0a\x86\x71\x4f\xa3\xdf\xa3\xe0\x77\x2c\x9b\x71\xdb\xf2\x2d\xce\xc6\x3f\x93\xce\xdd\x8e\xcb\x04\x16\x52\x40\xc6\xbf\x51\x0f\xbc\xf5\x3c\x23\xa9\x89\x64\xcf\xe8\x63\x28\xf5\xa8\xdd\xf1\x3b\x41\x07\x43\x2e\x55\x2e\x7b\x9f\x3c\xb6\x07\x65\x49\xfa\x3d\x03\x15\x7e\x44\xa4\x2f\xe4\xbb\x35\x52\xe5\xa8\xe8\x72\xd5\x46\x7f\x54\x7e\x8e\x2f\xb9\xc3\x8e\xa3\xca\xa9\x5c\x94\x67\x19\x51\x9a\xcd\x29\xa2\x34\x8b\x45\x79\x3e\xfe\xe9\xa0\x3e\x22\x76\xfa\x49\xf0\xa1\x9f\x7d\x8d\x40\xf3\x3e\x63\x88\x88\x82\x1a\x17\x0e\x8b\x4a\x31\x29\x28\xe5\x03\xfa\x98\x1f\x73\x82\x0a\xe8\x14\x5c\xfd\x00\x1d\x3f\x91\x69\xee\x5d\x1c\x4c\x64\xad\xda\x9a\x2c\x7c\xc4\xb6\x48\x99\x5e\xc6\xbc\x65\x51\x30\x70\xae\x28\x26\xe2\x13\x95\x7e\x40\x16\x24\x5a\xa1\xde\xd8\x3b\x25\x4f\xf4\x10\xb3\x10\x11\xb0\x8f\x72\x8a\xf6\x82\xa6\x18\xb4\xb1\x1f\xdc\xd3\x4c\x97\xe7\xed\x82\x21\x62\x23\x86\xa4\x97\xb9\x73\xa8\x48\x1e\xcc\x11\xc3\x88\xff\xeb\x01\x31\x86\x6d\x94\xe9\x34\xf7\xa0\x85\x8a\xcc\x8f\x0b\x68\xdd\x67\x65\xc1\x05\xf2\x3c\x64\x7f\xc4\x24\xdf\x5f\x01\x59\x1f\x09\xae\xf8\x5b\xd5\xe3\x06\x5e\x67\xe8\xc9\xde\x71\xdf\x3d\x66\x50\xa0\x63\x06\xb1\xc3\xbb\x0c\x7d\xf3\x11\x17\xbc\x2b\x95\xf6\x14\x78\x40\xd9\xa9\x37\x47\x3f\x8e\xff\x3e\xaa\x79\xd4\x7e\xf3\xd7\x11\x1f\x71\x81\xdc\x3a\xf4\xbc\xfa\x57\x58\xff\xde\xa8\x9f\xdf\xfe\x3d\xf9\xeb\xe8\xf9\xab\xe9\xde\xbe\x7c\x09\x7a\x23\x70\xcc\x05\x14\x3e\x57\x9d\x6b\x30\x32\x28\x73\x61\x60\x72\x86\xc0\x2e\xea\x86\x92\x49\x5f\x82\x89\x40\xec\x41\x5a\x8f\x61\xba\xc5\x75\x1f\xa0\x25\xa4\x3f\x37\x53\xd5\xa1\xed\x7f\x18\x3f\xe3\xe9\xe9\x3f\x4f\x4f\x61\x3f\x9e\x9f\xff\xf3\xfc\x9c\x6e\x8c\xa1\x3b\xe9\x6f\x8d\xb7\xc6\xb8\xf8\x39\xfa\x4b\x71\x43\x03\x86\xf8\x80\x3a\x76\xce\x3d\xb9\xe8\x03\xa3\xae\xe2\xb0\xc7\xe5\xd7\xa8\x1f\x59\x5a\xe6\x86\x2f\x03\x7c\x27\xf2\x77\x44\x8e\xee\x8b\x14\x2e\x88\xf5\x01\x3c\xc4\x00\x47\x16\x25\x36\x38\xee\x8d\x40\x56\xa0\x86\x18\x43\xc3\x93\x3a\x1e\x21\x93\xce\x3c\x33\x22\x39\x65\x22\xe3\x4f\xe4\x60\xec\xc6\xde\x14\x13\x1b\x3f\x60\xdb\x87\x8e\x91\x1b\x97\xf1\x35\x12\x91\x92\x0e\x0c\xe1\x10\x67\x9c\x5a\xcf\xb7\xee\x43\x23\x54\xdf\x31\xf0\x1e\xd1\x98\x0c\xc4\x50\x00\xb8\x99\xab\x8b\xbd\xca\xd8\x7b\x7c\xbd\xcd\x75\x71\x04\x87\x68\x82\xed\xdb\xc8\xc2\x2e\x94\x18\x63\x96\x58\x24\x43\xdf\xbc\x8c\x2d\x3a\xb0\x87\x9c\x31\x6c\x27\xc5\xb4\x7f\x09\x39\xca\xb5\x15\xfa\xcd\xf4\xab\x8c\x1d\x67\xae\x58\x79\xc7\xc4\xfe\x6a\xc5\xdd\x4f\x7a\xc9\x07\x81\x22\x0b\x7b\x99\x7b\xc2\x0a\xfb\x99\x1b\x27\xa3\xbc\x2d\x40\x07\xf7\x8b\x20\x43\x96\x7f\x44\x0f\xe3\x4e\xa7\xa8\xd3\x1e\x83\x79\xaa\x60\x02\x9a\x2b\xfd\xd6\x70\xae\xe1\x7c\x2b\xe0\xdc\xa2\x44\x30\xea\x38\x88\x6d\x1e\xd2\x93\xbe\xec\x01\xac\x17\x09\x56\x43\xbb\x86\x76\xa0\xa1\x7d\x87\xa0\x3d\x3b\x4f\x3f\x2f\x41\x76\x53\x23\xbb\x46\xf6\xcd\x23\x7b\x2d\x9c\x4c\xbe\xf9\xeb\xa8\xf9\x35\x28\xf9\x59\x63\x7d\x95\x58\xdf\x1c\x0e\x35\xde\x8f\xdf\x42\xe3\xbd\xc6\x7b\xb0\xdb\x78\xff\x3a\x03\xf7\xb9\x99\x7c\x19\xde\x37\x35\xde\x57\x80\xf7\xa9\xc7\x6e\x09\xdc\xa7\x9f\xb2\x33\x78\xdf\xd6\x78\x5f\x3d\xde\xb7\x35\xde\x2b\x6f\xb1\x77\x78\x6f\x34\x0c\x0d\xf7\x07\x0d\xf7\x67\x33\xc2\x7d\x4b\xc3\xfd\xbe\x4e\xef\x77\x14\xef\x3b\x1a\xef\xab\xc7\xfb\x8e\xc6\x7b\xe5\x2d\x34\xde\x17\x76\x52\xe3\xfd\xce\xe0\xfd\xf4\xb0\xbb\xd3\x12\xc0\x6f\x6b\xc0\xd7\x80\xbf\x24\xe0\x77\x6d\x3f\x8c\xe3\xed\x86\x30\xc2\xbb\xdc\x77\x2b\xd9\xb8\x7f\x05\x66\x7d\xa2\x45\x7d\x22\xaa\x78\xe6\xc2\x84\x62\x46\xd2\x70\x23\x0d\x7c\x3a\x4b\x28\x71\xcf\x25\x9a\xb0\x7b\x5d\xe6\x93\xa8\x8f\x2b\xd6\x41\xc1\xb3\x76\x46\xfa\x6f\x2d\x81\x1f\xd0\x35\xb2\x28\xb3\x4b\x94\x70\xb9\xb0\x12\x1e\x30\x7a\x5c\x9f\x1a\x0a\x9f\xb6\x33\x8a\xf8\x03\xa3\xc7\x12\x05\xbc\xdb\x12\xae\x7c\x1d\x88\x19\xc0\x07\xc4\x60\x1f\x01\x86\xb8\x47\x09\x47\x20\xc5\x2a\x35\x31\x5e\x8c\x18\xe7\xa8\x9f\x42\x3b\xf5\xbe\xd7\xb6\x11\x63\x0b\x32\x3b\xd3\x70\x50\x74\x05\x6d\x1b\x93\x7e\xde\x72\x82\xca\x6b\xea\x13\x3b\xd3\xf8\xb8\xa7\x56\x74\x9c\x29\xd3\xe0\xf8\x94\xd3\x0f\x9d\xd3\xf3\xf6\x87\xa6\x6a\xa3\xf2\x96\x2f\x16\x0c\xc7\x26\xff\x96\xd2\x40\x5c\x3b\x40\x6e\x34\x8e\x04\x62\x1e\x75\xa0\x40\x97\xd2\x5c\x95\x4b\xd1\xd0\xa3\x24\xa4\xaf\x8d\x93\x4e\xc1\xe8\xa0\x1e\xb4\xb0\x18\xe5\x47\x60\xc0\xd9\x13\x0f\x26\x78\x3c\xce\xe6\xe1\xf8\x45\xc7\xa9\xc0\x72\x5c\x3f\x1f\x95\x9b\x27\xfb\x03\x04\x85\x0b\xbd\x34\x85\x1d\x60\x1b\xfd\x2f\x62\xf4\x72\xec\x2f\xd2\xb4\x70\x80\xfb\x03\x07\xf7\x07\xe2\x5d\xa4\xff\x14\xbb\x0e\xe7\x0f\x9d\x89\xf3\x87\xc8\x6e\x4b\x39\x7b\x96\x88\x17\x32\x6d\x86\x1e\x10\xe3\xe8\xcf\xb2\x6e\xce\xc9\x5e\x27\xd2\xc8\x50\xa5\x33\x03\xe8\xc9\xcf\x47\xcf\xe1\x92\x94\x83\x4a\x21\x33\x96\x7d\x21\x5c\xce\xb6\x0c\xd5\x98\x88\xa5\x72\x1d\xca\x41\x8b\xaf\x3f\x4d\xc0\xce\x29\x10\x59\xb0\x86\x14\xbd\xae\x5c\x4b\x4a\x81\x26\x08\x85\x0b\x30\x89\x2e\x9d\xe1\x2c\x48\x11\x02\x05\xa5\xbf\x62\x2e\x68\x9f\x41\xb7\xd4\xba\x62\xb8\xcc\x4a\xdf\x18\xbe\xcd\x79\xc9\xbc\x7b\x4d\xda\x19\x86\x66\xf7\xd9\x77\x7b\x72\x8a\x96\x12\x44\x54\xf9\x05\x7f\xcf\xe2\xa7\x31\xca\x3f\x46\xc1\x3f\x95\x02\x14\x43\x5f\x31\x72\x14\xe2\x46\x21\x6a\x94\x09\xcf\x73\xb0\x18\x1b\x56\xa1\x73\x1e\x85\x2f\x75\x19\x39\x70\x03\xfa\x82\x1a\xd9\xda\x62\x79\x8c\x72\xf2\xd0\x78\xb2\x04\x9e\x94\x62\xc2\xe9\xbc\xeb\x3f\xcd\xce\xfa\x20\xe1\xf5\xce\x41\xc2\xf4\xe5\x85\x39\x71\xa1\x70\x4b\x23\x59\x58\x7f\x73\x44\xa8\xc0\x77\xd8\x0a\x0f\x68\xa7\xf6\x36\x0e\x13\x48\x3e\xab\xf2\x28\xdc\x9a\xd0\xb0\x02\x34\xac\x68\x58\x59\xd3\x34\x65\x71\xe4\xc9\xcd\x46\xd6\x09\x3d\xa7\x1a\x7a\xa6\x42\x8f\x40\x04\x12\xa1\x41\x07\x18\x37\xa1\x24\x34\xdc\x68\xb8\xd1\x70\xb3\xa3\x70\x93\x9d\xe8\xb4\x9a\xeb\x43\x9b\x33\x8d\x36\x53\xd1\x26\x8c\xe3\x7a\xe5\xe0\x07\xa4\x11\xc7\xf8\x88\x1f\x10\x41\x5c\x43\x8e\x86\x1c\x0d\x39\xbb\x0a\x39\xb9\x19\xce\x3a\x31\x67\x72\xa2\x34\x8d\x39\x0a\xe6\x30\x04\xed\x91\x06\x1d\xe3\x1a\x41\x1b\x6b\xd4\xd1\xa8\xb3\x30\xea\xac\x21\xc5\x66\xab\xe4\x44\x4a\x33\x89\x6b\x9c\x98\x64\xf3\x0b\xb6\xd1\x3d\xfe\x66\xe4\x0c\x6a\x0f\x93\x6c\x4e\x15\x66\xbb\x24\x61\x69\x73\x4a\xf6\x0e\x07\xf7\xc9\x5b\x7e\x53\x9c\x9c\x36\x8c\x05\xce\x14\x26\xa1\xc0\x99\x8a\x79\x22\x81\x59\x80\x79\x5f\x0a\x73\xdf\x2e\x18\x24\x5c\xe8\x1a\x74\x8c\xf0\x7a\x63\x84\x79\x38\x24\xbb\xff\xa5\x3d\xde\xe5\xbe\x65\x21\x3e\xf9\x68\xd0\xa2\xa1\x87\x51\xda\xe4\x8c\x35\x14\x61\x74\x73\x22\x44\x7f\x09\xfb\x78\xe7\x3b\x20\xe8\x73\x85\x41\xba\x29\x51\xdc\x41\xec\x20\x7b\x25\x92\x98\xf3\x85\x3f\xc8\x9e\x4c\x7a\xd9\xcb\x2d\x89\xc5\xfc\x27\xed\x71\xe0\x31\x1a\xe8\x07\xd9\x20\x60\xa8\xa3\x88\x96\x2c\x17\x8b\xd9\xcc\xba\x8f\x03\x89\xc5\x4c\x8c\x89\x96\x9d\x44\xaa\x26\x96\x51\x9f\x45\x52\xcb\xf5\x59\xa4\x0b\xd0\x3e\x2b\x61\x27\x53\x72\x8d\x54\xc8\x4e\x02\xc4\xf8\xc5\xf5\xc4\xa8\xb8\x2a\x98\xa4\x1f\x2a\xa5\x01\x90\x83\xef\xc1\xfb\xef\x3a\xb5\x59\x61\x3a\xb3\x45\x99\x8d\x5c\x84\xf8\xe6\x23\xbf\x7c\x1d\x62\xe5\x24\xe7\x6a\x0c\xa2\x98\x00\xb9\x28\x21\x3b\x34\xeb\xba\xc4\x8a\x19\xcf\x32\x12\x5a\x8c\xfc\xcc\x2c\x85\x2d\xa5\x42\xbd\x11\x90\x5d\xd7\x34\x48\xd3\x20\x4d\x83\xb6\x98\x06\xe5\xb6\x0d\x4a\x79\xd0\x94\x24\x2c\x9a\x07\xad\x83\x07\x69\xfe\x33\x96\x7d\xb5\xfc\xe7\x91\xb2\xfb\x65\x13\xb7\x2c\x05\xfc\x12\xee\xc3\x5e\xec\x42\xca\x96\x3c\xde\xff\x97\xf6\x80\x84\x5e\x0d\xf9\x1a\xf2\x35\xe4\x6f\x2f\xe4\x67\x57\x3e\x3a\xaf\x4b\x10\x7f\x4a\x16\x96\x19\x10\xbf\x3c\x47\xcb\xec\x90\xaf\x81\xfd\x40\x81\xdd\x85\xc3\x34\xa6\x3f\x42\x2c\x30\xe9\x4f\x4c\x27\xf1\xbc\xce\xc9\xfa\xfc\x0b\x15\x9b\x84\xec\xdf\x82\x8e\x02\x8e\xbf\x2b\x6f\xad\x31\x7a\x21\x8c\x5e\x39\xfc\x69\x94\x56\xcb\x77\x06\xa5\x33\x79\x14\xc2\x11\xe7\x40\x81\x88\x35\x02\x98\x03\x31\x40\xc0\xc6\x77\x77\x88\x21\x62\x21\x25\x88\x09\x70\x1c\x14\x04\xf5\xd4\xb1\x11\x17\x92\x4e\x63\x22\x4b\xa4\x87\x01\x8f\x90\x03\x44\xe4\xdf\xf6\x09\xf8\x17\x03\x03\xfa\x08\x1c\x4a\xfa\xe1\xa5\x1c\x44\xee\x11\x60\xc1\x81\xf0\x19\x01\x82\x82\x1e\x02\x68\x88\x2c\x5f\x20\xfb\x64\x3d\xeb\x07\xa5\x6c\x62\x72\x4e\x06\xcd\x26\x34\x9b\x58\x6d\x04\x88\x4a\x27\xe4\x28\xea\x46\x03\x53\x33\x89\xc5\x98\x44\x28\x3e\xac\xd9\xc4\xd2\x6c\x42\x33\x09\xcd\x24\xd6\x37\xdf\x7f\xdd\x2e\x41\xe8\xc9\x29\x32\x74\xd6\x55\x8d\xa7\x2a\x9e\x0e\xe2\xe8\xf9\xee\x37\x1f\x12\x81\x1d\x74\xdc\x38\x39\xef\xd4\x40\xd1\x82\x7c\x2e\x25\xe4\xc4\xd3\x15\x99\xd3\x11\x2f\xc1\xaa\xe1\xf7\xbc\xf3\x13\x88\xdf\x02\xd0\x3b\x10\xf5\x1c\x6c\x39\x0e\xff\x93\xf6\xe2\x35\xf8\x80\xf8\xc7\x49\x21\x75\x2e\x48\x8d\xc9\x1a\x93\xb7\x1a\x93\x73\xd3\xe6\x52\x50\x9e\x9c\x3c\x44\x83\xb2\x06\xe5\x0d\x80\x72\xb8\x4f\x5d\x03\x6b\x01\xe7\xdd\xdb\x19\x2f\x03\x65\xbd\x4d\x0e\x34\x40\x6b\x80\x8e\x0a\xb7\x1a\xa0\xb3\x93\xe6\xd3\xfc\x69\xfa\x10\x9f\x27\xa7\x5b\x59\x53\x58\x9c\x5e\xd5\xde\x09\xc0\x5f\x57\xf0\x5b\xbc\x4d\xb4\x65\xb1\xed\xbb\xb5\xc8\x1d\x9d\xdd\x96\xe7\x10\x81\xe7\xf3\x81\x0e\x6b\xaf\x0e\xbc\xf5\xfe\xb9\x06\xf0\x35\xcf\xb0\x4b\x11\x7c\x72\xf2\x9a\xdd\xdc\x98\xce\xde\xa1\x31\x7c\x7b\x31\x3c\x17\xe7\xc6\x90\x60\xa3\x29\x51\x6e\x1a\xab\xd3\xc9\x84\x84\x3c\x79\x2f\xf4\xb2\xb7\x06\xe6\x7d\x04\xe6\x75\x24\x3b\x3a\x2b\xc9\xcf\xd3\x9a\x9c\xec\x88\x21\x0f\x85\x5a\xb1\x91\xe7\xd0\x91\x8b\x88\x78\x47\xc9\x1d\xee\x1b\xf9\x81\x7a\x45\x6d\x0e\x8e\x7f\xcc\x5e\xf9\x72\x8e\x0c\x49\x16\xb4\x06\xe8\x06\xbb\x88\xfa\x39\x87\x20\xf3\xfc\x5d\x42\xeb\xbe\xcf\xa2\xc4\x53\x29\x77\x2d\xab\xff\x08\xc6\x49\x4e\x8c\x56\x4c\x7a\x92\x21\x62\xfc\xf0\xa1\xd9\x3e\xef\xbc\x53\x07\x24\xeb\xf7\xe0\x71\xb3\x75\x5a\x03\x66\xf3\xbc\x06\xda\x8d\x1a\x68\x9c\x9c\x9d\xab\xee\xd8\xf8\xa1\x79\x7e\x6e\xb5\x5f\x1b\x39\xeb\x98\x29\x80\x30\x9f\xdd\x4b\xb1\x7b\x42\x89\x9a\x2c\x10\xfa\x12\x27\x9f\x52\x2c\x22\x7e\x3f\xb3\xd1\x48\x13\x89\xb8\xa2\x31\x03\x5d\x88\x5d\xf7\xc7\x60\x54\xe5\xc0\x55\xbd\xe2\x13\x64\xf7\x88\x95\x52\x88\x52\xf3\x6c\x65\xcc\x33\xfb\x71\xf8\x9c\x75\x9a\xb9\xb6\x03\x36\x15\x18\x42\x0c\xe5\x85\xb9\x09\x5b\x09\x47\x29\x3c\xab\x55\xc4\x5c\x5c\xe8\x79\x98\xf4\x6f\x42\x53\x34\x8b\xca\x27\x78\xd3\xc8\x69\x87\x0e\x19\x08\x0a\x04\x1a\x66\x9c\xd5\x43\xac\xa3\xa9\x3e\x2f\x6e\x8c\x41\xd2\x9f\xd2\x58\x73\x82\x63\x72\xe1\xf0\x3d\x14\xf0\x2a\xe6\x4a\x8a\x71\xe4\x79\x9a\x45\x09\x41\x96\x40\x4a\x12\x9b\xe0\x9a\x9b\xe0\xc9\x99\x01\x57\x4c\xe2\x1c\xbf\x8f\xc9\x1f\x88\xf1\x28\x0e\xf6\xf5\x49\xf3\xa4\x6d\x28\x8c\x8d\x8b\x3b\x3c\x4c\xab\x21\x2a\xfc\x40\x49\x9c\xf9\xcd\xe8\x34\x7e\x52\xea\x19\xca\xdf\x23\xcb\x4a\x6f\x91\x32\xfb\x04\xbd\x49\xc8\x17\xf2\x90\x34\x35\x95\x35\x22\x7c\x5b\xe3\xf3\xab\xb7\x99\x0a\x3a\xbe\x61\x82\xc0\xb9\x07\xd9\xbd\x13\xf2\x42\xc5\xf2\x83\x09\xce\x38\x8d\xa9\x74\x26\x2d\xb3\x06\x4c\xf3\xac\x06\xcc\xb3\xf3\xc0\x99\x98\x67\x29\x67\x72\xe7\x3b\x45\xbc\x3d\x68\x59\x6d\x27\x6c\xa6\xd9\xa8\x01\xf3\xbc\x95\x6a\x60\x62\xf2\x4d\x11\xcc\x6a\xde\x51\xc7\x77\x33\xdf\x7d\x9b\x6b\x01\xea\xde\xef\xa1\x2e\x43\x9e\x13\x7d\x2a\x25\xc9\x7a\xd9\x0d\x93\x5e\x76\x65\xd2\xcb\xf8\x12\x5e\x96\x53\xb3\xb0\x89\x37\x7f\x1d\xe5\xe0\xa2\xfe\x35\xfa\x2a\x68\xe5\x1f\x04\x5d\x80\xd9\x1a\x66\xad\x69\x14\x91\x5b\xa3\xd5\xe0\x46\x21\x89\xcd\xd6\xc4\x2c\xd6\x27\x04\x93\x3e\xf0\xa8\xcd\xf3\x80\xc8\x31\xe9\x3b\x28\x10\x68\x52\x27\x87\xbe\x6a\xff\x67\xaa\xfd\xcb\xda\xc9\xf6\x4f\x03\x82\x6c\xbc\x29\x36\xfd\x46\xb1\x9f\x99\x6a\xfb\xf2\xc2\xcf\x91\xe3\x0a\x66\xc8\x2b\xc1\xf1\xab\xd8\x21\x14\x00\xf9\x1c\x18\x1f\x81\xf5\xbc\x18\x1f\x51\x03\x8d\xf1\xcb\x60\xfc\xeb\xaa\x30\xbe\x59\x84\xf1\x29\x8b\xd2\x28\x5f\x39\xca\x6b\x14\x3f\x18\x14\xf7\x90\xb5\x0a\xf4\x06\x75\xa0\xf9\x43\x75\xfc\xe1\x77\x02\x1f\x20\x76\x02\x6b\xd0\x1c\x42\xaf\x05\xec\x0f\x4f\xc8\xed\xe5\x2c\x4c\x14\x5a\x7a\x31\x40\x2f\x06\x68\x1a\xb1\x22\x1a\x21\xb7\xac\x8e\xe3\x7f\x89\x80\x98\x20\xd6\x75\x91\x4b\xd9\xa8\xeb\x73\xd8\x47\xdd\xde\x48\xa0\x52\x00\x0f\x3f\xaa\x51\x00\xd7\x05\xdf\xd6\x88\x8e\x67\x12\x6a\xa3\x2a\x52\x52\x6f\x11\x8c\xcb\x35\x72\x1b\x73\xc1\x70\xcf\x17\xc8\x06\x94\x80\x01\xe5\x42\xe3\x79\x95\x78\xbe\xe0\xbc\xdf\x6e\xb7\x61\x0b\x6a\x3c\x5f\x0e\xcf\xcf\xaa\xc2\xf3\xb6\x9e\xf8\xaf\x1f\xd1\x35\x62\xef\x0d\x62\xcb\xd8\x13\xdf\x3d\xb6\x91\x23\x60\x38\x11\xf7\xa8\xdd\x4d\xc0\x7b\x3c\x01\xe7\x02\x32\x31\x39\x9f\xe2\x7c\xf8\xfd\xb5\xe3\xde\xbe\x8c\xe2\x4f\x3d\x6a\x57\x8e\xe1\xd9\x88\x96\x92\x10\x96\xd9\x13\x28\x57\x87\xf0\x9f\xe0\x50\x4e\xd0\x41\x2c\x56\x70\xec\x40\x2e\x40\x07\xb8\x98\xf8\x02\xf1\x82\xfd\xf0\x7d\x83\xfa\xdd\x09\x35\xcc\x7e\x7a\x6f\x7a\x20\x45\xc9\x59\xbe\xd6\x94\x14\x38\x73\x9e\xe5\x5b\x79\x9a\xdc\x2d\x3a\x00\xe8\xc2\xe1\x15\x62\xd7\xb2\x3f\xad\x72\x68\x5b\xc5\xa7\x02\x3a\xe9\xf2\x79\x22\x0d\xc7\xa1\x31\x29\x50\x0d\x4b\xdf\x63\x86\xac\x28\x51\x54\xaa\x7a\xeb\xce\x14\x6e\x68\x71\x78\xd5\x11\x8e\xd2\x8e\xeb\xa9\x75\x52\xe5\xba\x8f\x98\xdc\x17\xc5\x81\x29\x33\xc3\x54\x79\x20\x69\xa9\x91\xa9\x94\x6f\x4f\x77\xce\xb3\xe2\x95\x4b\xd1\x05\xe2\x2d\x04\xde\x25\x45\xa5\xb7\x27\x16\x54\x92\x4f\xa6\xaa\xe9\xdd\xec\x6a\xda\x9b\xe5\x9f\x9c\x98\x38\xb2\xeb\xe9\x25\x98\xb4\x90\xde\x6f\x49\x1c\xf4\x15\xb5\x81\x94\x3f\x38\x96\xee\xad\x06\xa4\x7e\x6b\xc0\x27\xc1\xff\x2f\x01\x24\x76\xc8\x3c\xe5\xdb\x24\xab\x4c\x01\x0e\x25\xcd\xe9\x33\x4d\x33\x87\x4e\x17\x7f\x8e\x75\xbd\xc1\xca\xe9\x07\xef\x58\x44\xb5\xca\x16\xf5\x59\xa7\xc2\x09\xc8\xeb\x79\x27\x20\xe7\xf9\xe5\xb4\x70\x02\x52\x6d\x86\x2f\x3d\x01\xd9\xd6\x23\x4e\xfb\x33\xf1\xd8\xd8\xc2\xd4\xda\xd6\xa5\xe4\x49\x2b\x8f\xda\xbb\x70\xce\xea\x6a\xd6\x45\x2b\x7d\xf8\x4a\x1f\xbe\xaa\x9c\x2a\xec\xf3\xe1\xab\xf3\xd3\x12\xc8\x4e\xf2\x7f\x2d\x7d\xf8\xea\xdd\xd5\xef\xe0\xf7\x60\x1a\xb6\xe4\x09\xac\xdd\x61\x4e\x73\x2f\xdd\x9e\x97\x7c\xfd\xac\x35\x25\xcd\xcb\x61\xa4\x61\xdb\xef\x55\x58\x43\xc1\x8f\xad\xe4\x42\x84\xda\xa8\x3b\x66\x35\x69\x3e\x74\x91\x30\x23\xcb\xf3\xa3\xd5\x96\x38\xaf\x9b\x34\x96\x8b\xa7\x27\x70\xf2\xc5\x77\xaf\xa1\x40\xe0\xf9\x59\xa1\x4a\x7f\x2d\xbb\x0a\x53\x0d\x4d\x9a\xe1\x40\x7a\x31\x4d\xaa\x6c\xc5\x76\x93\xd4\x6a\xec\x9c\xf5\x3a\xcc\x96\xb3\x28\xbd\xdc\xa2\x94\x6f\x15\x89\x32\x1b\x9d\x12\xf8\x3e\xaf\x96\x45\xfd\xe6\x53\x01\xd7\xca\xa2\x2c\x19\xe5\x91\xe9\xf7\x1a\xa8\xd5\x9d\x12\x5d\x60\x36\xd4\xf0\x82\xea\x48\x97\xd9\x28\xf9\x66\x4c\xbb\xa1\x59\xd7\x4a\x58\x17\xec\xa3\x48\xa9\x29\x30\xda\x5a\x36\x66\x31\x2a\xed\x32\x9d\x7c\x67\x22\x47\x1b\xd0\xc7\x5f\x11\xb4\x65\x17\xd2\xb7\x85\x40\xa9\xd8\x91\x45\x9d\x8c\xb7\xb1\x11\xb7\x4a\xb5\x59\x21\xfd\xe3\x62\xe4\x4c\x42\x35\xe9\x23\x02\x61\xdc\xa4\x51\x35\x1c\xcc\x28\x61\x47\x7f\xfe\xf9\xe7\x9f\xf5\x4f\x9f\xea\xef\xdf\x83\x5f\x7f\xbd\x70\xdd\x0b\x9e\x61\x5b\x1e\x14\x02\x31\x52\xdc\x56\xec\xab\x06\xd8\xb6\x11\x99\xbe\xe1\x37\xee\x56\x9e\xb4\xc4\x02\xa5\x2c\xb2\xcb\x1c\x10\x25\xd1\xb8\xb7\xcb\xbc\x90\xb2\xf1\x92\x21\x8e\x21\x0f\xcc\x0c\xd1\xa8\xe2\x66\x4c\xa9\x8c\xf7\x0c\x3b\x0e\xb0\xe9\x23\x31\x72\x97\xfd\xce\x0a\x3e\xe8\xab\x88\x50\x86\xdb\x82\x1f\xb2\xa1\x85\xc5\x6c\x31\x25\x62\xe2\xbb\x3d\xc4\xd2\xf7\xf9\x04\x2b\xa4\x60\x3e\xe9\x5f\xa3\x6f\x3e\xca\x6d\x4c\x1e\x8a\x02\x2e\xb7\x47\x01\xe0\xa7\xc3\x54\xc1\xbb\x6a\x55\x10\x41\x90\xfc\x39\x9f\x22\x3e\x62\x17\x1f\xea\x38\x78\xbf\xf9\x71\x10\x8a\xff\x50\x47\xc1\x2f\xdb\x30\x0a\xae\xa8\xbd\x7d\xd2\x4f\x33\xe8\x85\x84\xff\xca\x7e\xf5\xf4\x04\x4e\x3e\xc7\x6b\x54\xe0\xf9\x39\x57\x50\x6f\x71\x0b\x3a\xa8\x7e\xef\xf7\x10\x23\x48\x20\x5e\xb7\xa8\xeb\xf9\x02\xd5\x19\x0a\x67\x39\xbc\xee\x51\xfb\xff\x3f\x40\x56\x4f\x96\xbe\x92\x85\xaf\xbf\x05\x15\x1e\xb5\xdf\xfc\xd8\xed\x5a\x28\x7b\x9c\x40\xd1\xb8\x97\x95\xf2\xba\x47\xdb\x16\xe9\x58\x11\xcb\xab\x93\x9f\x5f\xcd\x2f\x17\x2e\x18\x26\xfd\xd9\xe4\x12\xfd\xa5\x2c\xaa\xed\xff\xe2\xa9\xcc\x3a\x9b\xaa\xc4\x84\x0b\x58\x90\x5b\x76\xfe\x15\xd5\xd2\xbd\xe6\x54\xf9\x12\x81\xad\xe9\x0d\xfb\x78\x18\x76\x59\xc4\x98\xa4\xac\x2d\xca\x52\x01\x81\x7b\x27\xce\xcb\x2a\xc4\xb9\xd5\xc6\x0b\x5e\x01\xad\xf2\x74\xc8\xec\x8a\x47\x90\x23\xb9\xd6\x61\x08\xf3\xbd\x1e\x3f\x87\xa5\xf0\x5f\xd6\xb3\x4d\x37\x7d\x37\x4e\x2e\xf2\x6f\x24\xa6\x89\x41\xc2\x03\x25\xe4\x55\x30\x26\x4e\x99\x62\xbd\x53\x77\x28\x3b\x75\x2f\xc0\xa6\x36\xd7\xcc\x56\x4e\xd5\xe1\x2e\x4d\xb2\x7b\xb1\xf4\xe6\xda\x27\x79\x58\x44\x47\x29\x4d\x53\x45\xc9\x09\xd3\x76\x12\x33\x7c\xc0\x1b\x66\xdb\x1e\x90\xb4\x95\xc1\x45\x33\x1e\xd8\x5a\x9c\x6e\xd4\xc0\xf8\x11\xff\xef\xcd\x91\x0e\x1d\xaa\x2e\x74\x48\x75\x9a\x3a\x7a\x68\x39\x4e\x22\xed\x5d\x73\x92\x83\x8c\x1e\x6a\x96\x1c\x9b\x6a\xb7\x2a\x27\x38\x3a\x80\xa8\x52\x3e\xd4\x2c\xf9\xb6\x57\xbb\xad\xf9\xd0\xee\x86\x0a\x6d\x2a\xec\xa7\x30\xd9\x99\x8e\xfa\x19\x77\xab\x90\x6f\xc4\x22\xdd\xf8\x86\xe3\x0e\x06\xfe\xd8\xc8\x0a\x79\xc7\xdc\x3a\xd0\xe1\x3f\x5b\xa5\x86\x43\x0d\x7f\xd8\x8a\x20\xa0\x48\x17\x3a\x0e\xa8\x32\x3d\x2c\x3e\x20\x74\x34\x50\x85\x6a\xd0\xd1\x40\x3a\x1a\x48\x47\x03\x55\x1a\x0d\xb4\x8d\xab\x9d\x7b\x1d\xeb\x13\x89\xb9\x3a\x01\x6f\xa9\x44\x2b\x09\xf7\xd9\xa0\x75\xce\x13\xcc\x73\x28\x3a\x5d\x57\x3c\xcf\xa1\xc8\xb3\x92\x90\x9e\xed\x1e\x23\x07\xa6\xd1\xad\x88\xd9\x51\x97\xd6\x75\xd8\x8e\x0e\xdb\xd9\xaa\x2d\xb2\x17\x60\x63\xbb\x5a\xe7\x39\x55\x87\x7b\x23\xca\xca\xfc\xb2\xbb\x5a\x9f\x91\x78\xa4\xec\x5e\xc7\xed\x4c\xd1\x45\xab\xe4\x13\xfb\xed\x6a\x13\x33\xee\xd3\x3e\x55\x55\xf1\x3a\x4b\x64\x53\xdc\xca\x78\x1d\xcc\xa0\x40\x0a\x0b\x22\xe1\x10\xec\x32\x64\x21\xfc\x10\x11\xa1\x5c\x56\xc4\x25\x98\x47\xe5\x69\x11\x17\x0e\xda\xd9\xba\xb4\x88\xd7\xa1\xcc\xc1\x25\x24\x76\x68\xe9\x4b\xd1\x8f\x43\x8d\xc2\x51\x16\x96\x72\xe8\x9a\x18\xd8\xa5\xb7\xd2\xf8\x9c\x1d\xcf\x91\xa8\xd3\x29\xaf\x02\xb6\x4b\x92\x33\xb6\x4f\x35\x6c\x6b\xd8\xae\x04\xb6\xe5\xe4\xd1\xc5\x42\xe3\xf6\x1a\x71\xfb\x26\x12\xba\x06\xee\xaa\xd6\x06\x34\x38\x6f\x25\x38\xaf\x65\xa1\xa1\x5d\x92\x7c\x4f\xfd\x18\xe5\xd2\x2b\x0d\x6f\x2d\x11\x30\xed\x6b\x64\x51\x66\xaf\x37\x80\x76\xc2\xb7\x69\x37\x49\x67\xce\xe6\x57\x54\x59\xba\x3d\x9d\xe4\x78\x05\x74\x66\xea\xf7\xcf\xf7\x99\xee\x48\xbf\x2a\xbf\x8c\x05\x2d\x74\xcc\x20\x76\x78\x37\xfa\xfe\x2c\xa6\xa4\xeb\x51\xea\x28\xbf\x2b\xda\x23\xa9\x1d\x25\x4d\x1e\xd5\xc0\xd1\x8f\x66\xf0\x2f\x17\x50\xa0\xe0\x8f\xe3\x93\x9f\x5f\x1e\x45\x8c\x28\xb9\x70\x71\x62\x24\x23\xdb\x5d\xa3\x06\x26\xb2\xa6\x19\x3e\x02\x91\x74\x66\x17\xc8\xd3\x75\xa0\x4c\x70\x45\x6d\xf0\x2e\x51\xe0\x46\xf6\x5d\x76\x9e\x3f\xcd\xf0\x11\x29\x87\x5a\x30\xbb\xad\x17\x93\x96\xcc\xae\xdc\x21\x32\xab\x4d\x7d\x1a\x62\x77\x56\x3d\xb2\x34\xc1\x6c\x4e\xa3\x09\x9d\x92\x43\xc6\x9d\x8d\x1f\x32\xce\x16\xef\x05\x4f\xd8\x67\x1e\x00\x4a\xa0\x9f\xe3\xef\xa8\xa2\xb8\x88\x05\xe0\x3b\x0e\x8a\xc8\x87\x04\x67\xa1\x7d\x09\x64\xdf\x09\x14\x4f\x10\x1c\x5c\x51\xea\x00\x79\xe2\x4b\x43\xf9\x72\x50\x6e\x6e\x08\xca\x67\xc3\x48\x8d\xe5\x7b\x8c\xe5\xa9\x82\xc9\x60\x6e\x6a\x30\xd7\x60\x5e\x09\x98\x3f\x42\x2c\x30\xe9\x6f\x0e\xcf\x0f\x1b\xb3\xff\x1d\x8a\x5f\xc3\xb6\x9e\x81\xef\x11\x6a\x77\xd2\x90\xfd\x22\x6a\x33\x18\x94\xc1\x78\x93\x83\xbd\x11\x7a\x02\x83\x5b\x03\xe4\xc2\x64\xa9\xd5\x3c\x0b\x8b\xc5\x28\x1c\x37\x36\x64\xf7\xe1\x95\x02\xf6\x13\x63\x30\xc2\xc3\x62\x91\xd0\x0c\x3e\xe2\x02\xb9\xc6\xf8\x49\x02\xb9\x9e\x03\xe5\xd0\x8a\xbb\x6f\x38\x98\x0b\xc5\x98\x54\x78\xc6\xd2\x8e\xd5\x71\x84\x89\xe5\xf8\x36\x7a\xeb\x14\xa1\x5a\xb1\x72\x0c\xd7\x77\x04\x2e\xb8\x3c\x1a\x0e\x46\x01\x7f\x48\xc1\x94\x7a\xc0\xca\xf8\xe6\x23\x36\x92\x87\xd5\x18\x75\x91\x18\x20\x5f\x75\x9d\x8a\x28\xcd\x54\x69\x1f\x0d\x33\xa6\x6d\xf0\x7b\xec\xfd\xce\x9c\x2f\x23\x62\x15\x21\x77\xe4\x06\x94\xce\x65\xc7\x61\x4a\xfd\x8e\x3c\x2e\x99\x7f\xf9\x84\x5e\xa4\x8c\x3c\x56\x5a\xe6\xec\x18\x1a\x46\x2b\xa8\xe9\x23\x80\xe9\x31\xf0\x10\x3d\x29\x7f\x59\xd1\xd8\x5a\x58\x8b\xc6\x18\xee\x8c\x39\x94\x59\x78\x93\xa2\x4b\xe5\x45\x54\x81\x48\x7a\xe0\x20\x4b\x14\xf8\xf3\xd9\x45\x33\x9b\x70\x92\x21\xad\x0c\x6a\xd5\xb4\x26\x3c\x63\x46\xab\xb1\x7c\x2e\xa8\x3b\xa3\xc5\xa4\xac\x72\x3a\xb7\x96\x1e\xfe\x0e\x13\x1c\x7f\x5c\x28\xdc\x97\x08\x01\x23\x39\xc8\x81\xc9\x1d\x9d\xfc\x09\xe9\xd0\x39\xd4\x4f\x7e\x3e\x7a\xae\x81\x4c\x38\xc5\x54\xab\xc9\xc0\xd5\xd8\x68\x4a\xb7\x3d\x67\x71\x04\x13\xee\x9d\xe6\x0e\x56\x22\x84\x29\xde\xe4\xd5\x71\x40\xf2\x22\xae\x77\xfb\xf7\x97\xf5\xaf\x01\xe1\x4b\x1d\x16\x9d\x62\x2f\x11\x53\x51\x5b\x17\xb0\x2f\x0d\x83\xff\x16\xbf\x9a\x91\xae\xcd\xc9\x20\x28\x2b\xbe\x38\xb2\xc6\x50\x48\x4a\x85\xcf\xd1\x4d\xd8\x50\x6a\xfe\x21\xff\x0f\xe8\xc6\x73\x08\x16\x58\xaa\x25\x82\x89\xbb\x90\xf7\x19\x84\x3e\xd6\xcd\x98\x18\x19\x82\x46\x65\x46\xea\x36\x0f\x5b\xf7\x72\x0a\x10\xdd\x1c\x09\xb2\x1b\x73\x57\xd5\x15\x18\x1d\x65\xa7\x25\xc6\x3f\xf9\xa3\xa5\xfe\x50\x68\xb0\xd1\x51\xfe\x36\xd5\x1f\xad\x86\x5a\xa3\xd0\xb7\xa6\xf2\xb7\x69\x87\x03\xf3\x36\x7e\x87\x80\x85\xe7\x5d\x54\xf9\x53\xd4\x86\x5f\xab\x0d\xab\x4f\x69\xb6\xd5\x1f\xc9\xc1\x6a\xe3\xd4\x56\xfb\x1b\xf7\x25\x25\xbe\xef\x94\x24\x8e\x21\xe1\xca\x21\xb6\x83\x57\xe0\x4b\x08\xeb\xb2\xf6\x21\xa1\x08\x2f\x9e\x5f\xfc\x5f\x00\x00\x00\xff\xff\x57\xeb\xf6\xdb\x69\x4d\x01\x00")
+var _monitoringSystemGrafanaDashboard1JsonTpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x7d\x6b\x73\xdb\x38\xb2\xf6\xf7\xfc\x0a\xbc\x9c\xcc\xc6\x9e\x57\x72\x44\xd9\xb2\x2d\x57\xa5\x4e\x25\xce\xe4\xcc\x6e\x25\x59\x6f\x92\x99\x53\x39\xa9\x94\x16\x22\xdb\x12\x36\x14\xc0\x00\xa0\x2d\x4d\x4a\xf3\xdb\x4f\x01\xbc\x5f\x74\xa7\x6c\xcb\xc2\x7a\x6b\x22\x82\x14\x08\x74\x37\xfa\x79\xd0\x68\x40\x3f\x9e\x20\x64\x61\x4a\x99\xc4\x92\x30\x2a\xac\x0b\xa4\x8a\x10\xb2\x3c\x22\xa4\x75\x81\xbe\xe8\x2b\x14\x95\xaa\xff\x5b\xfd\x80\x78\xf2\xef\xd4\xba\x40\x76\x23\x2d\x75\xb1\xc4\x82\x05\xdc\x01\xeb\x02\x59\xcd\x26\xfa\x6f\x8e\xaf\x31\xc5\xa8\xd9\xb4\x32\x8f\x01\xc5\x7d\x4f\x3d\x22\x79\x00\x99\xf2\x21\x71\x2b\x4a\x89\xc3\xe8\x25\xf3\x18\x57\x75\xf2\x41\x1f\x1f\xb4\x1a\xa8\x6d\xdb\x0d\xd4\xee\x74\x1a\xc8\x3e\xcc\x56\x4d\xf1\x48\x55\x61\xbd\x4c\xbb\x83\xfe\x86\x5e\x7a\xc0\xa5\xc8\x3e\x27\x27\xbe\x7e\xce\xc5\x62\xd8\x67\x98\xbb\x56\x74\x6f\xaa\xff\xfd\xfa\x04\xa1\xa9\x7a\xdc\x72\x41\x38\x9c\xf8\x4a\x30\xea\x79\x5d\x87\x05\x2e\x91\x85\x2e\x58\x03\x0a\xf2\xef\xae\x75\x81\x68\xe0\x79\xfa\xa9\x01\xc7\xfe\xf0\x13\x63\x9e\x24\xbe\x75\x81\x5a\xba\x90\xa8\x47\xce\xc2\x8f\x12\x38\x8e\x2a\xb6\x4f\x5b\x76\xf7\xac\x63\x77\x6c\xfb\xb8\xab\xef\x7a\x84\x7e\x53\xaa\xf8\xf2\x55\x5f\xfa\x98\x82\x27\x12\x65\xc4\xaa\xb0\x1c\xe6\x79\xd8\x17\xa0\xaa\xbd\xc6\x9e\x48\x24\x67\x0d\x38\x71\xaf\x58\xaa\x4d\xf5\x67\x0d\x0b\x1a\xbb\xb5\x2e\x50\xfb\x24\x53\x30\x8e\x5b\x1a\x5d\x4f\xd4\x75\x74\x39\x8d\xcb\xc3\x5e\x9c\x27\x97\x69\xe3\xbe\x26\x65\x92\x48\x2d\x20\xeb\x7f\xa0\x8f\x5e\xfa\x7e\x22\xfd\x44\xf6\x9c\xdd\x5a\x4f\x32\xf5\x26\x7d\xc2\x1e\xc1\x42\xab\x5c\xb7\x3e\x7d\x6d\x1f\x73\x51\xea\xa7\x52\xe1\x5b\xa0\x03\xa9\xfb\x96\xb4\x5d\x97\x43\xd5\xe3\x59\x1b\x7d\x9a\xb9\x4c\x1e\xb9\x26\x9e\x97\x95\xd3\x6c\x51\x9e\x17\x44\x69\xb7\x17\x88\xd2\x8e\x2e\xd3\x3e\x69\x51\x76\x13\x51\x7a\x30\x00\xea\xe6\xdf\x84\x6f\x06\xc5\x6e\x28\xcd\x07\x9c\x03\x95\x15\x77\x46\x78\x5c\x55\x4a\x68\x45\xa9\x18\xb2\xdb\xf2\x98\x93\x4c\x62\xaf\xe2\xe9\x1b\xec\x05\xa9\x4c\x4b\x7d\xf1\x08\x05\x51\xa8\x4d\x17\xde\x12\x57\xe6\x4c\xaf\x60\xde\xba\x48\x0d\x9c\x2b\x46\xa8\x7c\xc7\xb4\x1f\xd0\x05\xa9\xd9\x30\x3f\xf1\x4e\xe9\x1b\x7d\xe0\x0e\x50\x89\x07\x50\x6c\xad\xe5\xab\xaa\x38\x76\x49\xa0\x9a\x94\x28\x26\x2c\x2f\xdb\x05\x07\xea\x02\x07\xed\x65\xae\x3d\x26\xd3\x17\x0b\xe0\x04\xc4\x3f\x6f\x80\x73\xe2\x42\xa1\xd1\xc2\xc7\x0e\x54\x99\x9f\x90\xd8\xf9\x56\x94\x85\x90\xe0\xfb\xe0\xbe\x25\xb4\xdc\x5e\x89\xf9\x00\x64\x3a\xc4\xb3\x43\x42\xfd\x59\x30\xf6\x75\xeb\x44\x30\x3a\xe0\x58\xc2\x01\xc7\xc4\x13\x3d\x0e\xdf\x03\x10\x52\xf4\xb4\xd2\x7e\x28\x0f\xa8\x1b\xf5\xe2\xd9\xd3\xe4\xf3\xb3\x86\xcf\xdc\x17\x7f\x3d\x13\x13\x21\x61\xd4\xc4\xbe\xdf\xfc\x82\x9b\x7f\xb6\x9a\xdd\xaf\xff\x3f\xfd\xf4\x6c\xfa\xc5\x1e\x7d\x3d\x3c\x44\xfd\x09\x3a\x10\x12\xcb\x40\x64\x9d\xab\x1a\x19\x8c\x8f\xb0\x32\x39\x4b\x92\x11\xf4\x42\xc9\xe4\x1f\x21\x54\x02\xbf\xd1\xd6\x63\xd9\xa3\xea\x7b\x6f\xb0\x23\x19\xcf\xda\x43\xc6\xf6\xdf\x24\xef\xf8\xf1\xe3\xdf\x3f\x7e\x84\xed\x98\x4e\xff\x3d\x9d\xe6\x2b\xe3\x70\xad\xfd\xad\xf5\x32\xf6\xdd\xb1\xf7\x46\x28\xd5\x8f\x1c\x72\x10\x43\xe6\xb9\x05\xbd\xa9\xf6\xbf\xe1\x6c\x94\x71\xd8\x49\xf9\x07\x18\x44\x96\x56\xf8\xc2\xc7\x21\xb9\x96\xe5\x6f\x44\x8e\xee\xa3\x16\x2e\x8a\xf5\x81\x7c\xe0\x48\x80\xc3\xa8\x8b\x0e\xfa\x13\x54\x14\xa8\x25\x13\x68\xc8\x8c\x75\x31\xc4\x1c\xdc\x82\xdd\xa8\x72\xc6\x65\xc1\x9f\xe8\xc1\xd8\x8b\xbd\x29\xa1\x2e\xb9\x21\x6e\x80\xbd\x04\xca\x4a\x1e\x57\x23\x52\xda\x80\x31\x1e\x93\x82\x53\xeb\x07\xce\xb7\xd0\x08\xb3\x7d\x44\xc8\x1a\x45\x63\x52\xc9\xad\x02\x70\x0b\x4f\x57\x7b\x95\xc4\x7b\x7c\xf9\x1a\x15\xa6\x03\x79\x82\xc7\x30\xc7\xf6\x5d\x70\xc8\x08\x7b\xa2\x64\x33\xa9\x45\x72\xf8\xee\x17\x6c\xd1\xc3\x7d\xf0\x12\xd8\x8e\xff\x67\x79\x6c\xf0\x0a\x0b\x28\xd5\x15\xfa\xcd\x7c\x57\x12\xc7\x59\x2a\xce\xf4\x31\x29\x9e\x36\xaa\x9b\x9f\xb6\x52\x0c\x95\x22\x2b\x5b\x59\x7a\xc3\x16\xdb\x19\x7d\x4a\xcd\x7b\x52\xb6\x05\xec\x91\x41\x15\x64\xe8\xf2\xb7\x70\x93\x34\x3a\xba\x35\x7d\xec\x60\x6e\xb7\x97\x43\x73\xbb\x65\xe0\xdc\xc0\xf9\xc3\x82\x73\x87\x51\xc9\x99\xe7\x01\xbf\x7f\x48\x4f\xdb\xf2\x08\x60\xbd\x4a\xb0\x06\xda\x0d\xb4\x1b\x68\xdf\x25\x68\xcf\x0e\x3e\x15\xf2\xe8\x46\x97\x69\x9f\xf4\x3c\xdd\xb6\x0d\xb2\x1b\x64\xbf\x7f\x64\x6f\x84\x93\xc9\x17\x7f\x3d\x6b\x7f\x51\x58\xff\x8b\xc1\xfa\x3a\xb1\xbe\x3d\x1e\x1b\xbc\x37\x78\x6f\xf0\xfe\xb1\xe0\xfd\xe9\xa2\x99\xfc\x2c\xbc\x6f\x1b\xbc\xaf\x01\xef\x73\xaf\x7d\x20\x70\x9f\x7f\xcb\xce\xe0\xfd\x89\xc1\xfb\xfa\xf1\xfe\xc4\xe0\xfd\x63\xc6\x7b\xab\x95\xaf\x24\xd3\xc5\xa4\x78\xda\xa8\x6e\xbd\x81\xfb\xc7\x00\xf7\xe7\x4b\xc2\xfd\xb1\x81\xfb\x1a\xe0\xfe\x41\x4e\xef\x77\x14\xef\x3b\x06\xef\xeb\xc7\xfb\x8e\xc1\x7b\x83\xf7\x06\xef\x1f\x0d\xde\x2f\x0c\xe7\xdb\x67\xd1\x75\xda\xa9\x10\xf0\x4f\x0c\xe0\x1b\xc0\xdf\x10\xf0\x7b\x6e\x10\xe6\xf1\xf6\x42\x18\x11\x3d\x11\x8c\x36\x82\xff\x18\xec\x9f\xa3\x65\xdf\xe8\xb0\x80\xca\x3a\xde\x69\x35\xaa\xbd\xe1\x42\x42\xb1\x24\x69\xf8\xa4\xb8\xd1\x12\x2c\xa1\xb1\x92\x26\xdc\x7e\x8f\x07\x34\x6a\xe3\x96\x75\x50\xf1\xae\x9d\x91\xfe\x4b\x47\x92\x1b\xf8\x00\x0e\xe3\xee\x0c\x25\xbc\x5a\x5b\x09\x37\x04\x6e\xef\x4e\x0d\x95\x6f\xdb\x19\x45\xfc\x41\xe0\x76\x86\x02\x2e\x33\x0a\x88\x3e\xdd\x0b\x57\xfe\xa0\x94\x8a\xf0\x0d\x70\x3c\x00\xc4\x41\xf8\x8c\x0a\x40\x39\x56\x69\x88\xf1\x7a\xc4\x38\xff\xaa\x9c\x85\x89\x6a\xca\x59\x07\x2f\x2e\x15\x67\xba\x98\x14\x4f\x1b\xd5\xad\xdf\x63\x62\xec\x60\xee\x16\x2a\x56\x45\x57\xd8\x75\x09\x1d\x94\x1a\xac\x6f\x7e\x60\x01\x8d\x77\x03\x3d\x29\x48\x56\x6d\xda\x61\xbc\x5c\x61\xb2\xcb\xe9\xa7\xce\x59\xf7\xe4\x4d\x3b\x23\xe3\xf0\x2b\x1f\x1d\x1c\xe6\xa4\x89\xef\x5c\x56\xdc\x1d\xc2\x28\x1a\x47\x12\xb8\xcf\x3c\x2c\xe1\x95\x36\xd7\xcc\xa3\x30\xf6\x19\x0d\xe9\x6b\xeb\xa8\x53\x31\x3a\x98\x8f\x1d\x22\x27\xe5\x11\xa8\x28\x7c\xea\xc1\xa4\x88\xc7\xd9\x2a\x1c\xbf\x6a\x3b\xd5\x86\x5c\xdf\x6e\x2f\x26\xfb\x43\xc0\x72\x84\xfd\x3c\x85\x55\xdb\xcd\xfe\x17\x38\x7b\x95\xf8\x8b\x3c\x2d\x1c\x92\xc1\xd0\x23\x83\xa1\xbc\x8c\xf4\x9f\x75\x05\xd1\xfc\x21\x11\x60\xe4\xe1\xf3\xed\x8f\xec\x76\x26\x67\x2f\x46\xde\x2a\x99\x36\x87\x1b\xe0\x02\x3e\xcf\x6a\xe6\x8a\xec\x75\x2e\x8d\x0c\x55\xba\x34\x80\x1e\xfd\xf2\x6c\x1a\xa6\x97\x7a\x30\x13\x32\x63\xd9\x57\xc2\xe5\x72\x61\xa8\xd6\x5c\x2c\xd5\x71\x28\x0f\xd6\x8f\x3f\xcd\xc1\xce\x05\x10\x59\x11\x43\x8a\xba\xab\x63\x49\x39\xd0\x44\xa1\x70\x11\xa1\xd1\xa3\x4b\xec\x05\x49\xdc\x5e\x23\x5f\xfa\x1b\x11\x92\x0d\x38\x1e\xcd\xb4\xae\x18\x2e\x8b\xd2\xb7\xc6\x2f\x4b\x5e\x32\xf3\x9e\x52\x3d\xe3\xd0\xec\xde\x07\xa3\x3e\xf0\xa2\x20\xa2\x9b\x1f\xc9\x9f\x45\xfc\xb4\x26\xe5\xd7\x64\xf0\x2f\x4b\x01\x32\xc0\x92\xb4\x72\x16\x72\x54\xe2\x46\x25\x6a\xcc\x12\x9e\xef\x11\x99\x18\x56\xa5\x73\x9e\x84\x9d\x7a\x15\x39\x70\x0b\x07\x92\x59\xc5\xbb\xd5\xf2\x98\x94\xe4\x61\xf0\x64\x03\x3c\x99\x89\x09\x67\x8b\x30\x21\x6b\x5f\x6a\xbd\xa7\xdd\x29\xbd\x7d\x6b\x90\x70\xba\x73\x90\x20\xa1\x66\x5c\xa8\x9a\x58\x35\xd2\xc0\xfa\x8b\x67\x94\x49\x72\x4d\x1c\xfd\x12\x91\xcc\xf1\xf6\x17\x48\xde\x67\xe5\x51\xb9\x34\x61\x60\xc5\xc0\x8a\x81\x95\x5a\x60\x65\x89\x69\xca\xfa\xc8\x63\xb7\xef\x11\x7a\xce\x0c\xf4\x2c\x84\x1e\x09\x14\x53\xb9\xe7\xa0\xa3\x41\xe7\x53\x28\x09\x03\x37\x06\x6e\x0c\xdc\xec\x28\xdc\x64\x4d\x50\x4d\x74\x8e\xdb\xa5\x06\x6e\x0d\x6d\xce\x0d\xda\x2c\x44\x9b\x30\x8f\xeb\xb9\x47\x6e\x60\xcf\x11\x47\x21\xce\x5b\x72\x03\x14\x84\x81\x1c\x03\x39\x06\x72\x76\x15\x72\xec\xf6\x3d\x62\xce\xfc\x83\xd2\x0c\xe6\x64\x30\x87\x03\x76\x27\x7b\x0e\x3a\x0a\x74\x3e\x00\x76\x89\x41\x1d\x83\x3a\x6b\xa3\xce\x1d\x1c\xb1\x79\x3c\x63\x47\x4a\x3b\x79\x70\xfe\x21\x9b\x1f\x89\x0b\xdf\xc8\x77\xab\x64\x50\x8f\xf0\x90\xcd\x85\xc2\x3c\x99\x71\x60\x69\xdb\x9e\x8b\x1e\x7a\x33\xf4\x4b\xf1\xa9\xfa\x70\xda\x30\x17\xb8\x50\x98\xa6\x02\x17\x6e\xac\x92\x09\xcc\x15\xe6\x7d\xac\x3c\xfb\x76\xcd\x24\xe1\x4a\xd7\x60\x72\x84\xef\x36\x47\x58\x84\x43\xb2\xf7\x1f\xd6\x57\xd9\xa8\x8e\x03\x62\xc1\xd6\x20\x95\xdc\x07\x42\x45\x33\x7a\xf1\x97\x7d\xc6\xbc\x17\x7f\x3d\x7b\x1a\x5d\x5f\x31\xe6\xe5\x20\x7d\xc6\x57\x56\xcf\x5f\x8c\xce\x5e\xce\x75\xba\x1a\xe8\xdb\x73\x71\xfe\x63\xd8\xd1\xeb\xc0\x43\xaa\xe3\x0a\x3e\x75\x84\x73\x46\x43\x35\x1f\x40\xaa\x97\x4b\x90\x82\xc6\x3a\x92\xbf\xc6\xc4\x03\xf7\xe1\x0a\x7e\x45\xf9\xbe\xd1\xdd\xa9\x47\xb6\xd9\x54\xdf\xe8\xd3\xbd\x64\x9a\xfe\x43\x75\xc6\xe7\x4c\x19\x0e\xb8\x48\xf1\xef\x49\x44\xba\x16\x52\xae\xb9\x99\xa6\xed\x3d\xcd\x34\x4d\xcd\x8e\xcd\xda\x67\x95\x6f\xc2\x2c\x02\x37\x83\xc2\x99\x9d\x56\x66\xa7\xd5\x8c\x9d\x56\x27\xe7\xd1\x75\xda\xa9\x90\x7b\x25\x5f\x8c\x5c\xda\xf6\xb8\x97\x82\xb2\x5f\x47\xbe\x9c\x54\xdf\x52\x29\x9f\xfb\x4a\xd8\x10\x16\xe8\x4f\xd5\xff\x5d\x27\x6e\x39\x99\xec\x20\x6f\xfb\x1e\x40\x00\xab\x93\x85\xba\x58\xda\x55\x02\xb6\x31\x87\xd0\x0d\x5a\x36\x3a\xd3\x58\x47\x94\x5b\x22\x62\x6b\x4a\x72\x3d\xda\xb5\xb4\xb4\x1e\x28\xb5\xea\x4f\x90\x6e\xba\xa1\x55\x86\x56\x19\x5a\xf5\x80\x69\x95\xdd\x5e\x96\x57\x1d\x1b\x5e\x75\xff\xbc\xca\xf0\xa9\x7b\xe6\x53\xb7\x8c\x7f\xdb\xf4\x38\x9c\x8d\x08\x82\x0e\x72\x85\xad\x58\x96\x45\x45\x9f\x1e\x08\x2f\xf8\x0f\xeb\x23\x0d\xd1\x86\x1a\x18\x6a\x60\xa8\xc1\xc3\xa5\x06\xad\x02\x33\xe8\x9c\x46\xd7\x69\xa7\x88\x9b\x5b\x26\x5b\x9b\x19\xe4\xba\xb9\x26\x35\x30\x04\x60\x4f\x09\xc0\x08\x8f\xf3\xd8\x7f\x8b\x89\x24\x74\x30\xf7\xac\x9a\xe9\x5d\x4e\xea\x57\x0f\x7c\x44\x9f\xee\x05\xb2\xff\xa5\x1a\x8a\x04\xf9\x13\x84\xc1\xe8\xcd\x30\x7a\xeb\xf0\x67\x50\x7a\x27\x51\xba\x70\x3a\x45\x38\xe2\xd4\x7e\x01\xea\x4c\x10\x11\x48\x0e\x01\xb9\xe4\xfa\x1a\x38\x50\x07\x32\xa9\x61\x48\x10\x55\xa0\xee\x33\xcf\x05\x21\xd5\x02\xad\xba\xaf\x4a\x74\xc4\x0d\xdd\x62\x81\x80\xea\xcf\xee\x11\xfa\x27\x47\x43\x76\x8b\x3c\x46\x07\xe1\xa3\x02\x45\xee\x11\x11\x29\x90\x0c\x38\x45\x92\xa1\x3e\x20\x18\x83\x13\x48\x70\x8f\xb6\x42\x26\xec\xf6\xb2\x6c\xa2\x63\xd8\x84\x61\x13\xcb\xb2\x89\xfc\x5b\x6a\xa7\x13\x7a\x14\xf5\xa2\x81\x69\x98\xc4\x7a\x4c\x22\x14\x1f\x31\x6c\x62\x63\x36\xb1\x4d\x88\x36\xf3\x7d\x33\xdf\xcf\xcf\xf7\x4f\x4f\xa2\xeb\xb4\x53\x21\x42\xcf\x3f\x78\x64\x11\x06\x17\xef\xac\x82\xb4\x19\x65\xaf\x02\xa7\x33\xf7\x69\x18\x3c\xdd\x3e\x9e\x0e\xe3\x3d\x09\xbd\xef\x01\xa6\x92\x78\x70\xd0\x3a\xea\x76\x1a\xe9\x39\x9b\x99\xc9\x7b\xe9\xa0\xcd\xf9\x7b\x56\xd6\x08\xe0\x7b\x70\x78\x88\xac\x46\xb5\xfb\xaa\x0b\xa6\xbb\x9d\x9f\x51\xdc\x5b\xc4\xae\x51\xd4\x18\xf4\xc0\xf1\xfa\x1f\xac\x1f\xc7\xea\xd5\x04\x21\x3e\x92\x33\x07\x7c\x6b\x61\x77\xcb\x60\xb7\xc1\x6e\x83\xdd\xdb\xc3\x6e\xbb\xbd\x2c\x78\x9f\x19\xf0\x36\xe0\xbd\xab\xe0\x1d\xae\x7b\x37\xd0\x9d\x80\xf8\xee\xad\xb4\xcf\x02\x6f\xb3\xec\x5e\xdf\xb2\xbb\x01\x72\x03\xe4\x5b\x04\xf2\x56\x01\xc7\xcf\xca\x67\x1e\x84\x38\x7e\x3e\x1f\xc7\x97\x08\x93\x17\x0a\x53\x90\x37\x51\xf2\x47\x16\x25\xcf\xc9\xa2\x16\x5e\x50\x05\xff\xa2\x17\x2f\x3b\xcd\xcd\xba\xcb\xe2\xb9\x09\x9a\x97\x83\xe6\xd1\x0e\x7b\xb5\x5a\x27\x90\x1f\x88\xa1\x49\xa7\xaf\x2d\x9d\x7e\xeb\xc8\x68\x66\xe2\x66\x26\x5e\x98\x89\xcf\x44\xf0\xee\x23\x5c\xe8\x2e\x7e\xc3\xe4\xcd\xed\x50\xde\x1c\x07\xc9\x27\x0b\xb2\xe6\xac\x46\xb5\xcb\xda\x4f\xac\xfe\xa0\x24\x86\x04\xc8\xcd\x80\xb9\x55\x1c\x19\x06\x98\x0d\x30\x3f\x08\x60\xbe\x8b\x23\xa9\xce\x67\x9c\xa2\x74\x3c\xff\x48\x2a\x0e\x3e\x84\x5a\x71\xc1\xf7\xd8\x64\x04\x54\x5e\x32\x7a\x4d\x06\x56\x79\xa0\x5e\x31\x57\xa0\x83\xa7\xc5\x27\x0f\xad\xd2\x68\x9a\x79\x8e\x95\x83\x9d\x21\x7c\x22\x23\x60\x41\xc9\x21\xe8\xe3\x1d\x5f\x61\xe7\xdb\x80\x47\xc7\x83\x65\x07\x89\x3a\xd8\x8b\xf1\x3f\x14\xe3\x2e\x89\xd1\x89\x49\x4f\x3a\x44\xac\x9f\xde\xb4\x4f\xba\x9d\xcb\xa4\x6d\xca\xf3\x0d\xfa\xf8\xa0\x7d\x7c\xd6\x40\x76\xbb\xdb\x40\x27\xad\x06\x6a\x1d\x9d\x77\xb3\x53\x27\xeb\xa7\x76\xb7\xeb\x9c\x9c\x5a\x25\xeb\x58\x82\x00\x55\x9d\xc1\x96\xb1\x7b\xca\x68\xe6\xe1\x01\x0e\x06\x90\x57\xfc\x08\x8f\xe3\xfe\xd9\xad\xa4\x86\xd0\xac\xe3\x1b\xd9\xe2\xc8\xac\x73\xb2\xc8\xba\xee\xb7\x6a\x54\x89\x79\x4f\xbc\xc3\x2a\x14\x3c\x93\x42\xcc\x34\xcf\xe3\x82\x79\x9e\x2e\xb2\x4e\xbb\x54\xb7\xda\x84\xa0\x0c\x21\x86\xf2\xa2\xba\x43\xeb\x4d\xc6\x41\xf5\xde\xaf\x2a\xe6\x32\xc2\xbe\x4f\xe8\xe0\x53\x68\x8a\x76\x55\xf9\x1c\x6f\x1a\x39\xed\x70\x6a\xa7\x92\x3a\x25\x8c\x0b\xce\xea\x26\xd6\x51\x52\x38\x6d\xcc\xaf\x8c\x63\x3a\x58\x50\x59\x7b\x8e\x63\x1a\xe1\xf1\x6b\x2c\xf1\x55\xcc\x95\x32\xc6\x51\xe6\x69\x0e\xa3\x14\x1c\x09\xe9\x2f\x86\xea\x67\x3e\xc1\xb8\x34\xe0\xaa\x49\x9c\x17\x0c\x08\xfd\x03\xb8\x88\xf2\x6a\x4f\x8f\xda\x47\x27\x69\x65\x3e\x13\xf2\x9a\x8c\xf3\x6a\x88\x0a\xdf\x30\x1a\x9f\xcf\x67\x75\x5a\x3f\x67\xee\x73\x28\x7f\x87\xc3\xbc\xaf\x68\x99\xbd\xc3\xfe\x1c\x5d\x5d\x87\x3c\x24\x4f\x4d\xd5\x9f\xa5\x95\x76\x81\xac\xf7\xcf\x5f\x16\x6e\xb0\xe4\x0b\x73\x04\x2e\x7c\xcc\xbf\x29\xfe\x9c\xb7\x7c\x15\xa1\x4c\x0e\x9b\xd5\xce\xe4\xd8\x6e\x20\xdb\x3e\x6f\x20\xfb\xbc\xab\x9c\x89\x7d\x9e\x73\x26\xd7\xea\x3d\xe5\xe1\xa7\x6a\xce\xd6\x13\x56\xd3\x6e\x35\x90\xdd\x3d\x3e\xb4\x66\x8c\xf0\x27\x05\x5b\xb3\xa4\x9a\xd5\x5c\x32\x2f\x18\x15\x7e\x9d\x6f\xa5\x00\xd4\xb7\xa0\x0f\x3d\x0e\xbe\x17\xfd\xa0\x4d\x7a\x36\x69\x2f\x3c\x9a\xb4\xa7\x8f\x26\x8d\x1f\x11\x33\x48\x6d\xa3\xb2\x0a\x75\x18\x5a\x11\x2e\x9a\x5f\xa2\xdf\x6e\xcd\xf6\x74\x4d\x1a\x6c\x6f\xca\x6c\x2d\xbb\xd1\xb6\xaa\xc8\xad\x75\xdc\x12\x56\x25\x89\x2d\xde\x89\x59\x6c\x40\xa9\x5a\x32\xf2\x99\x2b\xca\x80\x28\x08\x1d\x78\xa0\x04\x9a\xde\xd3\x7e\x24\x6b\xff\xe7\x59\xfb\xd7\x77\xe7\xdb\x3f\x53\xcb\x4e\xd6\x8b\x6a\xd3\x6f\x55\xfb\x99\x85\xb6\xaf\xdf\xfb\x3e\x72\x5c\x6a\x86\xbc\x15\x1c\xbf\x8a\x1d\x42\x6e\x6c\xac\x8c\xf1\x11\x58\xaf\x8a\xf1\x11\x35\x28\xf5\xdd\x60\xfc\x0a\x18\x7f\x5a\x17\xc6\xb7\xab\x30\x3e\x67\x51\x06\xe5\x6b\x47\x79\x83\xe2\x7b\x83\xe2\x3e\x38\xdb\x40\x6f\xd4\x44\x86\x3f\xd4\xc7\x1f\x7e\xa7\xf8\x06\x13\x4f\x59\x83\xe1\x10\x4b\x71\x88\x18\x2e\x97\xe0\x09\x26\x16\x70\x7f\xb1\x00\xbb\x5d\x17\x51\x38\xae\x22\x0a\x96\x65\x68\xc2\xf6\x68\x82\x09\x06\xec\x4d\x30\x40\x2f\x59\x1d\xc4\xff\xa5\x12\x13\x0a\xbc\x37\x82\x11\xe3\x93\x5e\x20\xf0\x00\x7a\xfd\x89\x84\x99\x00\x1e\xfe\xf4\x49\x05\x5c\x57\xfc\x02\x4a\xb4\xdd\x93\x32\x17\x0e\x1f\x17\x8c\xeb\x18\xb9\x4b\x84\xe4\xa4\xaf\x36\x82\x23\x46\xd1\x90\x09\x69\xf0\xbc\x4e\x3c\x5f\x73\xde\xef\x9e\x9c\xe0\x63\x6c\x95\xfa\x67\xf0\x7c\x15\x3c\x3f\xaf\x0b\xcf\x4f\xaa\xf0\xdc\x4c\xfc\xcd\xc4\xdf\x4c\xfc\x97\x9a\xf8\xeb\xdc\x93\x60\x74\xe0\x82\x27\x71\x38\x11\xf7\x99\xdb\x4b\xc1\x3b\x99\x80\x0b\x89\xb9\x5c\x70\x8e\xe3\x4a\xf8\xfd\xa5\xa3\xb6\x93\x84\x28\xee\x33\xb7\x76\x0c\x2f\x66\xb4\x58\xd5\x08\xff\xea\x1e\x10\xfe\x1d\x1e\xeb\x09\x3a\x8a\xc5\x8a\x0e\x3c\x2c\x24\xea\xa0\x11\xa1\x81\x04\x71\xf8\xf8\xa1\x7e\x77\x52\x0d\xcf\x56\x4e\xa4\x98\xb1\xe7\xef\xb8\x53\xeb\x9e\xbf\xad\x1f\xcf\x1b\xf9\x9e\x55\xb7\x03\x54\xfb\xaa\xcd\x72\x09\x47\x78\x7c\x05\xfc\x03\xbb\xcd\xb2\x8b\x32\xb4\x6d\xe3\x27\x0f\x3a\xeb\x67\x1a\x26\xa9\x31\x39\x50\x0d\x4b\x5f\x13\x0e\x4e\x74\xf0\x54\xee\xf6\x66\xe9\x89\xf9\xa6\xd5\xb6\xc7\xe0\x1e\x82\xc3\x9b\xe3\x41\x7b\x2e\x1e\x68\x24\x6b\xe6\xe2\xa4\x99\xe7\xde\x12\xfa\xad\xa0\x99\x3c\x6e\x14\x68\x85\x92\xb4\xd6\x48\x52\x38\x6d\x6c\x26\xd6\x1d\x8b\x7c\x17\xc5\xab\x43\xd1\x15\xe2\xad\x04\xde\x0d\x45\x65\x96\x27\x96\x5c\x9e\x28\x2a\x29\xa0\x0b\xd5\x74\xb9\xbc\x9a\x1e\x4d\xf8\xa7\x24\x26\x01\x6e\x33\x1f\x82\xc9\x0b\xe9\x75\x46\x48\xd1\xa7\x19\x24\xf2\xcb\xd7\x2a\x06\x59\x57\x1e\xf4\x15\x73\x91\x96\x3f\x3a\xd0\xee\xad\x81\xb4\x7e\x1b\x28\xa0\xea\xdf\x43\x84\xa9\x1b\x32\x4f\xdd\x9b\x34\xca\xa4\x70\x68\xa3\xd4\xe9\xf6\x9e\xa6\x4e\x57\x05\x75\x0a\x56\xb8\xf5\x64\xe5\xfc\x8b\x33\x5d\x4c\x8a\xa7\x8d\xea\xd6\xdf\x61\x23\x4b\xc5\x15\x33\xdb\x3b\x4d\xa9\xde\x9d\x09\xc8\xe9\xaa\x13\x90\x6e\x39\x9c\x16\x4e\x40\x92\x9a\x22\x96\x63\x26\x20\x5b\x98\x80\xd4\x35\xf1\x68\xef\xf9\xc4\x43\x4f\x3c\xee\x2d\x30\x75\x67\x71\x29\xbd\xd3\xca\x67\xee\x2e\xec\xb3\xba\x5a\x36\x68\x65\x36\x5f\x99\xcd\x57\x66\xf3\xd5\x0a\x9b\xaf\xba\x67\x4f\x0a\xf2\x8f\x20\x3b\x3d\x27\x6c\xe3\xcd\x57\x97\x57\xbf\xa3\xdf\xd5\x34\x6c\xc3\x1d\x58\x8f\x38\x74\xdb\x9d\xf1\xab\x6b\xc7\xe7\xb5\x32\x27\x13\x85\x7d\x80\x51\x58\x6b\x68\x3d\x6c\x2e\x44\x99\x0b\xbd\x84\xd5\xe4\xf9\xd0\x45\xca\x8c\x1c\x3f\x88\xa2\x2d\xf1\xf9\x6f\xda\x58\x2e\x7e\xfc\x40\x47\x1f\x83\xd1\x07\x2c\x01\x4d\xa7\x19\xaa\xf4\xd7\xa6\x51\x98\x7a\x68\x52\x7b\x5d\x9a\x54\x5b\xc4\x36\xfa\x74\x2f\xd4\x2a\x71\xce\x9b\xb1\xa8\xb6\x61\x51\xdb\x66\x51\x19\xcc\xc8\xf7\x24\x29\x9e\x36\xee\xbd\x91\xa5\xe2\xa8\x9d\x59\x58\x78\x74\x24\xca\x6e\x75\x9e\x14\x34\x10\xc1\x77\xb7\x5e\x16\xf5\xaf\x80\x49\x7c\xa7\x2c\xca\xd1\x59\x1e\x85\x76\xdf\x01\xb5\xba\xce\x64\x17\xd8\xad\x6c\x7a\x41\x7d\xa4\xcb\x6e\xcd\xf8\x0d\x9a\x93\x96\x61\x5d\x5b\x61\x5d\x78\x00\x91\x52\xb3\x7e\xe2\xe1\xb2\x31\x47\xad\xb7\x15\x05\x38\x9f\xa3\x0d\xd9\xed\x6f\x80\x5d\xe0\xa5\xaf\x29\xcf\x9b\xb3\x23\x87\xe5\x6c\x5e\x8d\x14\x10\x4e\xdc\xa7\x92\x36\x6b\xa4\x7f\x42\x4e\xbc\x79\xa8\xa6\x67\x5a\x4a\x18\x9f\xf2\xa8\xaa\x9a\x88\x25\xa4\xec\xe8\xf3\xe7\xcf\x9f\x9b\xef\xde\x35\x5f\xbf\x46\xbf\xfd\x76\x31\x1a\x5d\x88\x02\xdb\xf2\xb1\x94\xc0\x69\x75\x5d\xb1\xaf\x1a\x12\xd7\x05\x1a\xd3\x83\x4c\x9f\x67\x35\xab\x4c\x5a\x62\x81\x32\x1e\xd9\x65\x56\xcd\xe9\xcd\xbc\xb6\xd6\xe9\x50\x66\xe1\xa5\x40\x1c\x09\x2d\x29\x21\xb9\xf1\x29\xa1\x54\xd6\x6b\x4e\x3c\x0f\xb9\xec\x96\x5a\xa5\xc7\x7e\xe7\xf9\x7d\x21\x45\x11\xea\x3c\x5c\xf4\x53\x81\x51\xce\x60\x8b\x39\x11\xd3\x60\xd4\x07\x9e\xff\x5e\x40\x49\x86\x14\xac\x26\xfd\x0f\xf0\x3d\x80\xd2\xc2\xe4\xbe\x28\xe0\xd5\xc3\x51\x00\xfa\xd9\x2a\x4b\x79\x0f\x54\x70\x59\xaf\x0a\x22\x08\xd2\x1a\x59\x4d\x11\x6f\xc9\x88\xec\xeb\x38\x78\x5d\xaf\x12\xd6\x19\x07\xa1\xf8\xf7\x75\x14\xfc\x5a\xaf\x02\xd6\x1b\x05\x57\xcc\xb5\xca\x02\xbe\x67\xe9\x67\xb9\xd7\x9a\xc2\x7f\xee\x3e\x57\xb1\xab\xf7\x71\x8c\x0a\x4d\xa7\xa5\x82\xe6\xb1\xfe\x05\x83\xa6\x5a\x29\xe4\x14\x24\x88\xa6\xc3\x46\x7e\x20\xa1\xc9\x21\x9c\xe5\x08\x95\xe6\xf4\x5f\x37\x98\x37\xd3\xd0\x57\x1a\xf8\xfa\x9b\xba\xa1\xd6\x09\x9f\xf6\x7a\x0e\x78\xde\x4c\x8d\xfb\xcc\xad\x57\xd9\xab\x8e\xb6\x07\xa4\xe3\x8c\x58\x9e\x1f\xfd\xf2\x7c\x75\xb9\xa8\xf4\x23\x3a\x58\x4e\x2e\xd1\xa7\xaf\xfb\x14\x3c\x55\x1b\x3c\xf2\x37\x09\x15\x12\x57\x9c\x2d\xbb\x7a\x44\xd5\x5a\x37\x4c\xda\x58\x2c\xed\x8a\x05\xfb\x78\x18\xf6\x78\xc4\x98\xb4\xac\x1d\xc6\x41\x3c\x66\x71\xbe\xaa\x43\x9c\x0f\xda\x78\xd1\x73\x64\x54\x9e\x55\xf9\x65\x1d\x2a\x9f\x27\x4e\x4f\x73\xad\xfd\x10\xe6\xeb\x3a\x84\xb9\xeb\xe3\x67\x9f\x14\xfe\xeb\x42\x85\x97\x89\xc0\x1a\xcb\x74\x8b\x57\xe3\x74\x90\x7f\xb3\xd5\xb8\x56\x31\x46\xbc\xdc\x6a\x1c\xc7\x54\x28\x25\x94\x55\x90\x10\xa7\x42\xb1\x59\xa9\xdb\x97\x95\xba\xfb\x5b\x5c\xb3\x8f\x9f\x14\x84\x16\xad\xd2\xd8\xf5\x2d\xae\xbd\xd3\x67\x85\x98\x2c\xa5\xf9\x59\x4a\xb6\x3d\x63\x87\xe9\x49\xe2\x71\x23\x6f\xbb\x97\x0b\x66\x0f\x3d\x21\xe9\x41\x26\x17\x2d\xb9\x61\x6b\x7d\xba\xd1\x40\xc9\x2b\xfe\xdf\x8b\x67\x26\x75\xa8\xbe\xd4\xa1\xac\xd3\x5c\x82\xaf\xcc\xe1\x2b\xed\xf5\xf8\xca\x23\xca\x1e\xd2\xe7\x53\x6d\x13\xee\x33\xbe\x3c\xdf\x93\xa4\x78\xda\x58\xd4\xc8\x87\xc6\x49\x1e\x49\xf6\x50\x7b\xc6\xb6\xa9\x93\xe3\xda\x09\x8e\x49\x20\xaa\x35\x81\xa8\x1d\x1f\x8e\x94\x0a\x21\xd4\x5c\xf2\xcd\xc8\xd3\x1b\x3e\xb4\x2a\x1f\xba\xc7\x54\xa1\xfb\x4a\xfb\xc9\x78\xe3\xe9\x36\x78\xd9\xae\x67\xfd\x54\xf2\x8d\xfb\x5f\x8c\xba\xc3\xe5\xde\x02\x1f\x9c\xc1\xf5\x96\x5e\x01\x74\xc1\x09\x79\xc7\xca\x3a\x30\xe9\x3f\x35\xa6\xff\x6c\xae\x06\x93\x04\x74\x8f\x49\x40\x91\x2e\x4c\x1e\x50\x6d\x79\x40\xeb\x0f\x08\x93\x0d\x64\xb2\x81\x4c\x36\x90\xc9\x06\x7a\xa8\xd9\x40\x0f\x31\xda\x99\x5b\xd1\xbb\x9b\xb5\xd6\xc5\x71\xcd\xc6\x62\x59\x2e\x95\xf8\x11\x89\xb9\x3e\x01\x3f\xe6\x74\x9f\x7b\xb4\xce\x55\x92\x79\xf6\x45\xa7\x77\x95\xcf\xb3\x2f\xf2\xac\x25\xa5\x27\x15\xe3\x43\x1c\x23\x7b\xa6\xd1\x07\x91\xb3\x13\x4d\x3d\x4c\xda\x8e\x49\xdb\x31\x69\x3b\x69\xda\x4e\xbb\xfb\xa4\x20\xb4\x68\x6d\xa4\x53\xdf\xaa\xd6\x7b\x90\xb7\x8c\x7f\x33\x79\x3b\x0b\xf2\x76\x8e\x63\x8f\x98\xf6\x2a\xd4\xc5\xa9\x59\xa7\x9a\xb1\x4e\xb5\x68\x7d\x6a\xd9\x75\xa8\xf6\x06\xeb\x50\x0f\x31\x5f\x87\x70\x2c\x21\x33\x53\xa0\xe1\x10\xec\x71\x70\x80\xdc\x44\x44\xa8\x74\x2a\xe2\x5f\x0f\xe8\x58\xc4\xb5\x93\x76\x1e\xdc\xb1\x88\x1f\x42\x99\xa3\x57\x98\xba\xa1\xa5\x6f\x44\x3f\xda\xeb\xd1\x8f\x9d\xcf\xc2\xc9\x04\x96\xf2\xaf\xca\x19\xd8\x2b\x5f\x6c\x13\xd7\x77\xfc\x8c\xc4\x19\xf4\x63\xfb\x19\x3a\x8f\x1a\xb6\x67\x1c\xce\x78\x72\x66\x60\xdb\xc0\x76\x2d\xb0\xad\x27\x8f\x23\x22\x0d\x6e\xdf\x21\x6e\x7f\x8a\x84\x6e\x80\x7b\x33\xe0\x36\xe0\xfc\xc0\xc1\xf9\x4e\x02\x0d\x27\x33\x0e\xdf\xcb\xfe\x18\xe5\xc6\x91\x86\x97\x8e\x24\x37\x80\x3e\x80\xc3\xb8\x7b\xb7\x09\xb4\x73\x7e\x9b\xf6\x3e\xe9\xcc\xf9\xea\x8a\x9a\x75\xdc\xde\xb9\xa1\x33\xf5\xd3\x99\x85\xbf\x7f\xfe\x98\xe9\x8e\xf6\xab\xfa\xc7\xb3\xb0\x03\x07\x1c\x13\x4f\xf4\xa2\xdf\x9f\x25\x8c\xf6\x7c\xc6\xbc\xcc\x75\x4d\x6b\x24\x8d\x67\x69\x95\xcf\x1a\xe8\xd9\x53\x5b\xfd\x57\xfd\x74\x26\xa8\x0f\x07\x47\xbf\x1c\x3e\x8b\x18\x51\xfa\xe0\xfa\xc4\x48\xf5\xd5\x1e\x59\x0d\x34\x97\x35\xd9\x8b\x59\x53\xda\x98\x9d\x08\x7a\x28\x65\x22\xf5\x8b\x53\x97\x49\xbb\xc5\x66\xfc\xa9\xb5\xa7\xfc\x29\x13\xf8\x68\xcd\xb0\x42\x8f\xa9\x84\xa6\x6a\xd2\x62\x59\x9b\x53\x96\x1d\x67\x56\xf9\x76\x46\x9f\xee\x80\x58\xed\x4e\xd4\xa3\x48\x13\xec\xf6\x22\x9a\xd0\x99\xb1\xc9\xb8\xd3\xbe\x6f\x9a\x50\x2c\x36\x61\x8f\x07\x1e\xf6\x40\x33\xa0\x5f\x90\x3f\xa1\x1e\xcc\x5f\x07\xbe\xe3\xa4\x88\x5c\xbf\x2a\xa1\x7d\x03\x64\xdf\x09\x14\x4f\x11\x1c\x5d\x31\xe6\x21\xbd\x61\xcf\x40\xf9\x66\x50\x6e\xcf\xb0\xc8\x6d\x43\x79\xa9\x38\xd3\xc9\xa4\x78\xda\xa8\x6e\xbf\xc1\xf2\xc7\x80\xe5\x76\x7b\x69\x30\xb7\x0d\x98\x1b\x30\xaf\x05\xcc\x6f\x31\x91\x84\x0e\xee\x0f\xcf\xf7\x1b\xb3\xff\x27\x14\xbf\x81\xed\x0d\x61\xbb\x35\xc3\xf0\xcc\x0c\xfc\x3e\x66\xe0\xc9\x9a\xc2\x93\xf8\xbf\xba\x4e\x35\x28\x15\x47\x56\x5d\xb6\x5b\x61\xd8\xc9\x12\xce\x10\x46\x38\x0d\xb5\xda\x7a\xce\x1b\x6e\xb7\x57\x0f\xba\x98\x7f\x0b\x9f\x94\x78\x90\x1a\x83\x15\x6e\x16\x8b\x84\x66\x89\x89\x90\x30\xb2\x92\x37\x49\x18\xf9\x1e\xd6\x43\x2b\x6e\xbe\xe5\x11\x21\x33\xc6\x94\xe9\x94\xfa\x4d\xe4\xc2\x38\x22\xd4\xf1\x02\x17\x5e\x7a\x55\xa8\x56\xad\x1c\x6b\x14\x78\x92\x54\x3c\x1e\x0d\x87\x2c\x9d\xc8\xdc\x4d\x61\x2a\xf5\x27\x08\x59\xdf\x03\xe0\x6a\x46\x6f\xf9\x9c\x8d\x40\x0e\x21\xc8\xba\xce\x8c\x28\x33\x66\x60\x71\x18\xc0\xb8\x60\xda\x96\xf8\x46\xfc\xdf\xb9\xf7\x71\x42\x93\x9f\x47\xc9\xdc\x8d\xdd\x40\xa6\x71\x4f\x0a\x76\x9b\x53\xbf\xa7\x77\xd3\x97\x3b\x9f\xd2\x8b\xf4\xf1\x8c\xd2\x32\x5d\x53\xa5\x30\x8e\x22\xa8\xf9\x2d\x80\x99\x66\xc7\xae\xa1\xf2\xb1\xaa\xb1\xb5\xb6\x16\xad\x04\xee\xac\x15\x94\x59\xf9\xa5\x8c\x2e\x93\xc2\xac\xfc\x22\x7a\xe0\x81\x23\x2b\xfc\xf9\xf2\xa2\x59\x4e\x38\xe9\x90\x8e\x86\x45\xd1\xb4\xe6\xbc\x63\x49\xab\x71\x02\x21\xd9\xa8\x04\x2e\xd5\x16\x93\xb3\xca\xc5\xdc\x5a\x3d\x04\xd7\x84\x92\xf8\xc7\x85\xf4\xb0\xeb\x69\x84\x13\xe9\x46\x0e\x42\xaf\x59\x86\xbe\x54\xb0\x97\xd0\x39\x34\x8f\x7e\x79\x36\x6d\xa0\x42\x3a\xc5\x42\xab\x29\xc0\x55\x62\x34\x45\x46\xb4\x8a\xed\xcc\xfb\xee\x22\x77\xb0\x15\x21\x2c\xf0\x26\xcf\x0f\xd4\x42\x4d\xc4\xf5\xbe\xfe\xff\xc3\xe6\x17\xb5\x68\xf3\xcb\xf3\xe5\xed\x25\x62\x2a\xd9\xda\x25\x1e\x68\xc3\x10\xff\x8a\xbb\x96\xad\xae\xc2\x6f\x68\x5f\x52\xfd\x70\x64\x8d\xa1\xcf\xcc\xdc\x08\x04\x7c\xc2\x83\x99\xf3\x8f\x19\x66\x7a\xf4\x4b\x9d\x86\x1a\xf8\xb3\x14\xf3\x1f\xd6\x7f\xf1\xd7\xb3\xa3\x5f\x22\xd5\x08\xe2\xc2\x37\xf2\x3d\x34\x53\x15\x4d\x02\x0d\x71\xbd\xa8\x5c\xf3\xf5\x5a\x4c\x37\xaa\x10\xa9\x0a\xab\xcc\xb6\xf0\xbd\xd8\x6a\xa3\xaf\x5d\x15\xbe\xb5\x92\xc1\x6e\x4f\x18\xb5\x01\xe2\xc3\x32\xd5\x27\xf1\x46\x14\x6d\xb4\x21\xd3\x4e\x18\xcd\x75\x38\x45\xb1\x28\xbb\x6d\xda\x31\x87\xb7\x24\x8b\xca\xac\xdc\xd7\x7c\xe2\x7c\x03\x9e\x7e\x39\x12\x58\x2f\x9e\x66\x65\x51\xcb\xea\xa4\x64\x23\xa1\x6a\xfa\xe2\x38\x7b\x91\x99\xb1\x59\x9d\xcc\x67\x3b\x7b\x71\xdc\xca\xde\xc9\xcc\x34\xda\x99\xcf\xb6\x6b\x3d\xc9\xc0\x94\xee\x67\x2f\x63\x59\x0b\xdf\x92\xad\xf8\x34\x5b\x71\xf6\x2d\xed\x93\xec\x45\x7a\x06\x80\x75\x96\xf9\x7c\xdc\x72\xad\x0a\xa9\xff\xc9\x68\x8a\x61\xe9\xb4\x2e\xa4\xa1\xe8\x39\xfa\xa8\x07\x71\x78\xf7\x26\x65\xb3\x4f\xa6\x4f\xfe\x6f\x00\x94\xcf\x41\x83\xba\x51\x01\x00")
 
 func monitoringSystemGrafanaDashboard1JsonTplBytes() ([]byte, error) {
 	return bindataRead(
@@ -17,6 +17,7 @@ const BackupPVCMountPath = "/backup"
 const SystemFileStoragePVCMountPath = "/system-filestorage-pvc"
 const APIManagerSerializedBackupFileName = "apimanager-backup.json"
 const ServiceAccountName = "apimanager-backup"
+const BackupDataVolumeName = "backup-data"
 
 var secretsToBackup map[string]string = map[string]string{
 	"SystemSMTP":          "system-smtp",
@@ -100,124 +101,129 @@ func (b *APIManagerBackup) BackupDestinationPVC() *v1.PersistentVolumeClaim {
 	return res
 }
 
-func (b *APIManagerBackup) BackupSecretsAndConfigMapsToPVCJob() *batchv1.Job {
-	if b.options.APIManagerBackupPVCOptions == nil {
-		return nil
-	}
+func (b *APIManagerBackup) BackupSecretsAndConfigMapsJob() *batchv1.Job {
+	return b.backupJob("backup-cfgmaps-secrets", v1.Container{
+		Name:  "backup-cfgmaps-secrets",
+		Image: b.options.OCCLIImageURL,
+		Command: []string{
+			"/bin/bash",
+		},
+		Args: []string{
+			"-c",
+			"-e",
+			b.backupSecretsAndConfigMapsContainerArgs() + checksumsScript("cfgmaps-secrets", "secrets", "configmaps"),
+		},
+	}, nil)
+}
 
-	jobName, err := helper.UIDBasedJobName("backup-cfgmaps-secrets", b.options.APIManagerBackupUID)
-	if err != nil {
-		panic(err)
-	}
+func (b *APIManagerBackup) BackupAPIManagerCustomResourceJob() *batchv1.Job {
+	return b.backupJob("backup-apimanager-cr", v1.Container{
+		Name:  "backup-apimanager-cr",
+		Image: b.options.OCCLIImageURL,
+		Command: []string{
+			"/bin/bash",
+		},
+		Args: []string{
+			"-c",
+			"-e",
+			b.backupAPIManagerCustomResourceContainerArgs() + checksumsScript("apimanager-cr", "apimanager"),
+		},
+	}, nil)
+}
 
-	var completions int32 = 1
-	return &batchv1.Job{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: "batch/v1",
-			Kind:       "Job",
+func (b *APIManagerBackup) BackupSystemFileStorageJob() *batchv1.Job {
+	return b.backupJob("backup-system-fs-pvc", v1.Container{
+		Name:  "backup-system-filestorage-pvc",
+		Image: b.options.OCCLIImageURL,
+		Command: []string{
+			"/bin/bash",
 		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      jobName,
-			Namespace: b.options.Namespace,
+		Args: []string{
+			"-c",
+			"-e",
+			b.backupSystemFilestoragePVCContainerArgs() + checksumsScript("system-filestorage-pvc", "system-filestorage-pvc"),
 		},
-		Spec: batchv1.JobSpec{
-			Completions: &completions,
-			// TODO BackoffLimit field controls how many times the job is retried
-			Template: v1.PodTemplateSpec{
-				Spec: v1.PodSpec{
-					Volumes: []v1.Volume{
-						b.pvcBackupDestinationPodVolume(),
-					},
-					Containers: []v1.Container{
-						v1.Container{
-							Name:  "backup-cfgmaps-secrets",
-							Image: b.options.OCCLIImageURL,
-							Command: []string{
-								"/bin/bash",
-							},
-							Args: []string{
-								"-c",
-								"-e",
-								b.backupSecretsAndConfigMapsContainerArgs(),
-							},
-							//Env: []v1.EnvVar{},
-							VolumeMounts: []v1.VolumeMount{
-								b.pvcBackupDestinationContainerVolumeMount(),
-							},
-						},
-					},
-					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
-					ServiceAccountName: ServiceAccountName,
-				},
-			},
+		VolumeMounts: []v1.VolumeMount{
+			b.systemFileStorageContainerVolumeMount(),
 		},
-	}
+	}, []v1.Volume{
+		b.systemFileStoragePodVolume(),
+	})
 }
 
-func (b *APIManagerBackup) BackupAPIManagerCustomResourceToPVCJob() *batchv1.Job {
-	if b.options.APIManagerBackupPVCOptions == nil {
+// BackupDatabasesJob returns the job dumping the internal databases and Redis instances.
+// Nil when all of them are external
+func (b *APIManagerBackup) BackupDatabasesJob() *batchv1.Job {
+	apimanager := b.APIManager()
+	if BackedUpSystemDatabase(apimanager) == "" && !BackedUpZyncDatabase(apimanager) && len(BackedUpRedis(apimanager)) == 0 {
 		return nil
 	}
 
-	jobName, err := helper.UIDBasedJobName("backup-apimanager-cr", b.options.APIManagerBackupUID)
-	if err != nil {
-		panic(err)
-	}
-
-	var completions int32 = 1
-	return &batchv1.Job{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: "batch/v1",
-			Kind:       "Job",
+	job := b.backupJob("backup-databases", v1.Container{
+		Name:  "backup-databases",
+		Image: b.options.OCCLIImageURL,
+		Command: []string{
+			"/bin/bash",
 		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      jobName,
-			Namespace: b.options.Namespace,
-		},
-		Spec: batchv1.JobSpec{
-			Completions: &completions,
-			// TODO BackoffLimit field controls how many times the job is retried
-			Template: v1.PodTemplateSpec{
-				Spec: v1.PodSpec{
-					Volumes: []v1.Volume{
-						b.pvcBackupDestinationPodVolume(),
-					},
-					Containers: []v1.Container{
-						v1.Container{
-							Name:  "backup-apimanager-cr",
-							Image: b.options.OCCLIImageURL,
-							Command: []string{
-								"/bin/bash",
-							},
-							Args: []string{
-								"-c",
-								"-e",
-								b.backupAPIManagerCustomResourceContainerArgs(),
-							},
-							//Env: []v1.EnvVar{},
-							VolumeMounts: []v1.VolumeMount{
-								b.pvcBackupDestinationContainerVolumeMount(),
-							},
-						},
-					},
-					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
-					ServiceAccountName: ServiceAccountName,
-				},
-			},
+		Args: []string{
+			"-c",
+			"-e",
+			b.backupDatabasesContainerArgs() + checksumsScript("databases", DatabasesBackupSubdir),
 		},
+	}, nil)
+	if job != nil {
+		// The job fails once the deadline is exceeded, so the paused workers are resumed
+		activeDeadlineSeconds := BackupDatabasesJobActiveDeadlineSeconds
+		job.Spec.ActiveDeadlineSeconds = &activeDeadlineSeconds
 	}
+
+	return job
 }
 
-func (b *APIManagerBackup) BackupSystemFileStoragePVCToPVCJob() *batchv1.Job {
-	if b.options.APIManagerBackupPVCOptions == nil {
+// backupJob returns the job running the container with the backup data volume mounted.
+// With an S3 destination, the container writes the backup data into an emptyDir volume,
+// uploaded to the object storage once the container finishes. With encryption, the container
+// writes the backup data into a plaintext emptyDir volume, encrypted into the data volume
+func (b *APIManagerBackup) backupJob(jobNamePrefix string, container v1.Container, volumes []v1.Volume) *batchv1.Job {
+	return b.job(jobNamePrefix, container, volumes, b.options.BackupCipher)
+}
+
+func (b *APIManagerBackup) job(jobNamePrefix string, container v1.Container, volumes []v1.Volume, cipher *BackupCipher) *batchv1.Job {
+	if b.options.APIManagerBackupPVCOptions == nil && b.options.APIManagerBackupS3Options == nil {
 		return nil
 	}
 
-	jobName, err := helper.UIDBasedJobName("backup-system-fs-pvc", b.options.APIManagerBackupUID)
+	jobName, err := helper.UIDBasedJobName(jobNamePrefix, b.options.APIManagerBackupUID)
 	if err != nil {
 		panic(err)
 	}
 
+	// The containers run sequentially, the last one as the pod container
+	containers := []v1.Container{container}
+	podVolumes := append([]v1.Volume{b.backupDestinationPodVolume()}, volumes...)
+	if cipher == nil {
+		containers[0].VolumeMounts = append([]v1.VolumeMount{b.backupDestinationContainerVolumeMount()}, container.VolumeMounts...)
+	} else {
+		containers[0].VolumeMounts = append([]v1.VolumeMount{cipher.PlaintextDataVolumeMount(BackupPVCMountPath)}, container.VolumeMounts...)
+		containers = append(containers, cipher.EncryptContainer(b.backupDestinationContainerVolumeMount()))
+		podVolumes = append(podVolumes, cipher.Volumes()...)
+	}
+
+	if s3Options := b.options.APIManagerBackupS3Options; s3Options != nil {
+		containers = append(containers, s3Options.S3Location.UploadContainer(b.backupDestinationContainerVolumeMount()))
+		podVolumes = append(podVolumes, s3Options.S3Location.S3Volumes()...)
+	}
+
+	podSpec := v1.PodSpec{
+		Volumes:            podVolumes,
+		Containers:         containers[len(containers)-1:],
+		RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
+		ServiceAccountName: ServiceAccountName,
+	}
+	if len(containers) > 1 {
+		podSpec.InitContainers = containers[:len(containers)-1]
+	}
+
 	var completions int32 = 1
 	return &batchv1.Job{
 		TypeMeta: metav1.TypeMeta{
@@ -230,40 +236,23 @@ func (b *APIManagerBackup) BackupSystemFileStoragePVCToPVCJob() *batchv1.Job {
 		},
 		Spec: batchv1.JobSpec{
 			Completions: &completions,
-			// TODO BackoffLimit field controls how many times the job is retried. Should we limit to 1?
+			// TODO BackoffLimit field controls how many times the job is retried
 			Template: v1.PodTemplateSpec{
-				Spec: v1.PodSpec{
-					Volumes: []v1.Volume{
-						b.pvcBackupDestinationPodVolume(),
-						b.systemFileStoragePodVolume(),
-					},
-					Containers: []v1.Container{
-						v1.Container{
-							Name:  "backup-system-filestorage-pvc",
-							Image: b.options.OCCLIImageURL,
-							Command: []string{
-								"/bin/bash",
-							},
-							Args: []string{
-								"-c",
-								"-e",
-								b.backupSystemFilestoragePVCContainerArgs(),
-							},
-							//Env: []v1.EnvVar{},
-							VolumeMounts: []v1.VolumeMount{
-								b.pvcBackupDestinationContainerVolumeMount(),
-								b.systemFileStorageContainerVolumeMount(),
-							},
-						},
-					},
-					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
-					ServiceAccountName: ServiceAccountName,
-				},
+				Spec: podSpec,
 			},
 		},
 	}
 }
 
+// BackupS3URL returns the location of the backup data in the object storage.
+// Empty when S3 is not the backup data destination
+func (b *APIManagerBackup) BackupS3URL() string {
+	if b.options.APIManagerBackupS3Options == nil {
+		return ""
+	}
+	return b.options.APIManagerBackupS3Options.S3Location.URL()
+}
+
 func (b *APIManagerBackup) systemFileStoragePodVolume() v1.Volume {
 	return v1.Volume{
 		Name: "system-storage",
@@ -283,7 +272,18 @@ func (b *APIManagerBackup) systemFileStorageContainerVolumeMount() v1.VolumeMoun
 	}
 }
 
-func (b *APIManagerBackup) pvcBackupDestinationPodVolume() v1.Volume {
+// backupDestinationPodVolume returns the volume the backup data is written to.
+// An emptyDir when the backup data is uploaded to S3
+func (b *APIManagerBackup) backupDestinationPodVolume() v1.Volume {
+	if b.options.APIManagerBackupS3Options != nil {
+		return v1.Volume{
+			Name: BackupDataVolumeName,
+			VolumeSource: v1.VolumeSource{
+				EmptyDir: &v1.EmptyDirVolumeSource{},
+			},
+		}
+	}
+
 	return v1.Volume{
 		Name: b.BackupDestinationPVC().Name,
 		VolumeSource: v1.VolumeSource{
@@ -294,9 +294,9 @@ func (b *APIManagerBackup) pvcBackupDestinationPodVolume() v1.Volume {
 	}
 }
 
-func (b *APIManagerBackup) pvcBackupDestinationContainerVolumeMount() v1.VolumeMount {
+func (b *APIManagerBackup) backupDestinationContainerVolumeMount() v1.VolumeMount {
 	return v1.VolumeMount{
-		Name:      b.BackupDestinationPVC().Name,
+		Name:      b.backupDestinationPodVolume().Name,
 		MountPath: BackupPVCMountPath,
 	}
 }
@@ -410,6 +410,25 @@ func (b *APIManagerBackup) Role() *rbacv1.Role {
 					"list",
 				},
 			},
+			rbacv1.PolicyRule{
+				APIGroups: []string{""},
+				Resources: []string{
+					"pods",
+				},
+				Verbs: []string{
+					"get",
+					"list",
+				},
+			},
+			rbacv1.PolicyRule{
+				APIGroups: []string{""},
+				Resources: []string{
+					"pods/exec",
+				},
+				Verbs: []string{
+					"create",
+				},
+			},
 			rbacv1.PolicyRule{
 				APIGroups: []string{appsv1alpha1.GroupVersion.Group},
 				Resources: []string{
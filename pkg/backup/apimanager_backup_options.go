@@ -12,8 +12,10 @@ type APIManagerBackupOptions struct {
 	APIManagerBackupUID        types.UID                   `validate:"required"` // UID of the APIManagerBackup CR
 	APIManagerName             string                      `validate:"required"` // Name of the APIManager CR. NOT the APIManagerBackup cr name
 	APIManager                 *appsv1alpha1.APIManager    `validate:"required"`
-	APIManagerBackupPVCOptions *APIManagerBackupPVCOptions `validate:"required"`
+	APIManagerBackupPVCOptions *APIManagerBackupPVCOptions `validate:"required_without=APIManagerBackupS3Options"`
+	APIManagerBackupS3Options  *APIManagerBackupS3Options  `validate:"required_without=APIManagerBackupPVCOptions"`
 	OCCLIImageURL              string                      `validate:"required"`
+	BackupCipher               *BackupCipher               // Encrypts the backup data. Nil without encryption
 }
 
 func NewAPIManagerBackupOptions() *APIManagerBackupOptions {
@@ -38,19 +38,34 @@ func (a *APIManagerBackupOptionsProvider) Options() (*APIManagerBackupOptions, e
 	}
 	res.APIManager = apiManager
 	res.APIManagerName = apiManager.Name
-	res.OCCLIImageURL = a.ocCLIImageURL()
+	res.OCCLIImageURL = apiManager.MirroredImageURL(a.ocCLIImageURL())
 
 	pvcOptions, err := a.pvcBackupOptions()
 	if err != nil {
 		return nil, err
 	}
 
+	s3Options, err := a.s3BackupOptions(apiManager)
+	if err != nil {
+		return nil, err
+	}
+
 	// TODO can this checks be omitted and just rely on the validator package in the APIManagerBackup struct?
-	if pvcOptions == nil {
+	if pvcOptions == nil && s3Options == nil {
 		return nil, fmt.Errorf("At least one backup destination has to be specified")
 	}
+	if pvcOptions != nil && s3Options != nil {
+		return nil, fmt.Errorf("Only one backup destination can be specified")
+	}
 
 	res.APIManagerBackupPVCOptions = pvcOptions
+	res.APIManagerBackupS3Options = s3Options
+
+	cipher, err := NewBackupEncryptionCipher(a.APIManagerBackupCR.Spec.Encryption, res.OCCLIImageURL)
+	if err != nil {
+		return nil, err
+	}
+	res.BackupCipher = cipher
 
 	return res, res.Validate()
 }
@@ -71,6 +86,19 @@ func (a *APIManagerBackupOptionsProvider) pvcBackupOptions() (*APIManagerBackupP
 	return res, res.Validate()
 }
 
+func (a *APIManagerBackupOptionsProvider) s3BackupOptions(apiManager *appsv1alpha1.APIManager) (*APIManagerBackupS3Options, error) {
+	s3 := a.APIManagerBackupCR.Spec.BackupDestination.S3
+	if s3 == nil {
+		return nil, nil
+	}
+
+	res := NewAPIManagerBackupS3Options()
+	res.S3Location = NewS3Location(&s3.S3ObjectStorage, S3BackupKey(s3.Prefix, a.APIManagerBackupCR.Name),
+		apiManager.MirroredImageURL(AWSCLIImageURL()))
+
+	return res, res.Validate()
+}
+
 func (a *APIManagerBackupOptionsProvider) apiManager() (*appsv1alpha1.APIManager, error) {
 	return a.autodiscoveredAPIManager()
 }
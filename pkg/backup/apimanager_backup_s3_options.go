@@ -0,0 +1,71 @@
+package backup
+
+import (
+	"fmt"
+	"strings"
+
+	validator "github.com/go-playground/validator/v10"
+	v1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+type APIManagerBackupS3Options struct {
+	S3Location S3Location `validate:"required"`
+}
+
+// S3Location is the location of the backup data in an S3 compatible object storage
+type S3Location struct {
+	Bucket                string `validate:"required"`
+	Key                   string `validate:"required"` // Key prefix of the backup data objects
+	Region                *string
+	Endpoint              *string
+	ForcePathStyle        bool
+	CredentialsSecretName string `validate:"required"`
+	CASecretKeySelector   *v1.SecretKeySelector
+	AWSCLIImageURL        string `validate:"required"`
+}
+
+func NewAPIManagerBackupS3Options() *APIManagerBackupS3Options {
+	return &APIManagerBackupS3Options{}
+}
+
+func (a *APIManagerBackupS3Options) Validate() error {
+	validate := validator.New()
+	return validate.Struct(a)
+}
+
+// NewS3Location returns the location of the backup data stored under the given key
+// of the object storage, transferred with the given AWS CLI image
+func NewS3Location(storage *appsv1alpha1.S3ObjectStorage, key, awsCLIImageURL string) S3Location {
+	return S3Location{
+		Bucket:                storage.Bucket,
+		Key:                   key,
+		Region:                storage.Region,
+		Endpoint:              storage.Endpoint,
+		ForcePathStyle:        storage.ForcePathStyle,
+		CredentialsSecretName: storage.CredentialsSecretRef.Name,
+		CASecretKeySelector:   storage.CASecretRef,
+		AWSCLIImageURL:        awsCLIImageURL,
+	}
+}
+
+// URL returns the backup data location in the s3://<bucket>/<key> form
+func (l *S3Location) URL() string {
+	return fmt.Sprintf("s3://%s/%s", l.Bucket, l.Key)
+}
+
+// AWSCLIImageURL returns the image used to transfer the backup data
+func AWSCLIImageURL() string {
+	return helper.GetEnvVar("RELATED_IMAGE_AWS_CLI", component.AWSCLIImageURL())
+}
+
+// S3BackupKey returns the key prefix of the objects written by the named APIManagerBackup
+func S3BackupKey(prefix *string, backupName string) string {
+	if prefix == nil || strings.Trim(*prefix, "/") == "" {
+		return backupName
+	}
+	return fmt.Sprintf("%s/%s", strings.Trim(*prefix, "/"), backupName)
+}
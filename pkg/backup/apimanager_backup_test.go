@@ -0,0 +1,241 @@
+package backup
+
+import (
+	"strings"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func testS3APIManagerBackup() *appsv1alpha1.APIManagerBackup {
+	prefix := "/backups/"
+	endpoint := "https://minio.example.com:9000"
+	return &appsv1alpha1.APIManagerBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "mybackup", Namespace: "operator-unittest", UID: "backup-uid"},
+		Spec: appsv1alpha1.APIManagerBackupSpec{
+			BackupDestination: appsv1alpha1.APIManagerBackupDestination{
+				S3: &appsv1alpha1.S3BackupDestination{
+					S3ObjectStorage: appsv1alpha1.S3ObjectStorage{
+						Bucket:               "mybucket",
+						Prefix:               &prefix,
+						Endpoint:             &endpoint,
+						ForcePathStyle:       true,
+						CredentialsSecretRef: v1.LocalObjectReference{Name: "s3-credentials"},
+						CASecretRef: &v1.SecretKeySelector{
+							LocalObjectReference: v1.LocalObjectReference{Name: "s3-ca"}, Key: "ca.pem",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestAPIManagerBackupS3Destination(t *testing.T) {
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-apimanager", Namespace: "operator-unittest"},
+	}
+	s := runtime.NewScheme()
+	if err := appsv1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(apimanager).Build()
+
+	cr := testS3APIManagerBackup()
+	options, err := NewAPIManagerBackupOptionsProvider(cr, cl).Options()
+	if err != nil {
+		t.Fatal(err)
+	}
+	apiManagerBackup := NewAPIManagerBackup(options)
+
+	if pvc := apiManagerBackup.BackupDestinationPVC(); pvc != nil {
+		t.Errorf("unexpected backup destination pvc %v", pvc)
+	}
+	if url := apiManagerBackup.BackupS3URL(); url != "s3://mybucket/backups/mybackup" {
+		t.Errorf("unexpected backup url %s", url)
+	}
+
+	for _, job := range []*batchv1.Job{
+		apiManagerBackup.BackupSecretsAndConfigMapsJob(),
+		apiManagerBackup.BackupAPIManagerCustomResourceJob(),
+		apiManagerBackup.BackupSystemFileStorageJob(),
+	} {
+		if job == nil {
+			t.Fatal("expected backup job")
+		}
+	}
+
+	job := apiManagerBackup.BackupSystemFileStorageJob()
+	podSpec := job.Spec.Template.Spec
+	if len(podSpec.InitContainers) != 1 || podSpec.InitContainers[0].Name != "backup-system-filestorage-pvc" {
+		t.Fatalf("expected backup init container, got %v", podSpec.InitContainers)
+	}
+	if len(podSpec.Containers) != 1 || podSpec.Containers[0].Name != "upload-backup-data" {
+		t.Fatalf("expected upload container, got %v", podSpec.Containers)
+	}
+
+	volumes := map[string]v1.Volume{}
+	for _, volume := range podSpec.Volumes {
+		volumes[volume.Name] = volume
+	}
+	if volumes[BackupDataVolumeName].EmptyDir == nil {
+		t.Errorf("expected %s emptyDir volume, got %v", BackupDataVolumeName, podSpec.Volumes)
+	}
+	if volumes[S3CAVolumeName].Secret == nil || volumes[S3CAVolumeName].Secret.SecretName != "s3-ca" {
+		t.Errorf("expected %s secret volume, got %v", S3CAVolumeName, podSpec.Volumes)
+	}
+	if _, ok := volumes["system-storage"]; !ok {
+		t.Errorf("expected system-storage volume, got %v", podSpec.Volumes)
+	}
+
+	upload := podSpec.Containers[0]
+	script := upload.Args[len(upload.Args)-1]
+	for _, expected := range []string{
+		`--endpoint-url "${S3_ENDPOINT}"`,
+		"addressing_style path",
+		`s3 cp --recursive --only-show-errors '/backup' "${S3_URL}"`,
+	} {
+		if !strings.Contains(script, expected) {
+			t.Errorf("expected upload script to contain %q, got %s", expected, script)
+		}
+	}
+
+	env := map[string]v1.EnvVar{}
+	for _, envVar := range upload.Env {
+		env[envVar.Name] = envVar
+	}
+	if env["AWS_ACCESS_KEY_ID"].ValueFrom == nil || env["AWS_ACCESS_KEY_ID"].ValueFrom.SecretKeyRef.Name != "s3-credentials" {
+		t.Errorf("expected AWS_ACCESS_KEY_ID from the credentials secret, got %v", env["AWS_ACCESS_KEY_ID"])
+	}
+	if env["S3_URL"].Value != "s3://mybucket/backups/mybackup" {
+		t.Errorf("expected S3_URL env var, got %v", env["S3_URL"])
+	}
+	if env["S3_ENDPOINT"].Value != "https://minio.example.com:9000" {
+		t.Errorf("expected S3_ENDPOINT env var, got %v", env["S3_ENDPOINT"])
+	}
+	if env["AWS_CA_BUNDLE"].Value != S3CAMountPath+"/"+S3CAFileName {
+		t.Errorf("expected AWS_CA_BUNDLE env var, got %v", env["AWS_CA_BUNDLE"])
+	}
+}
+
+func TestAPIManagerBackupDestinationValidation(t *testing.T) {
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-apimanager", Namespace: "operator-unittest"},
+	}
+	s := runtime.NewScheme()
+	if err := appsv1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(apimanager).Build()
+
+	withoutDestination := testS3APIManagerBackup()
+	withoutDestination.Spec.BackupDestination.S3 = nil
+	withBothDestinations := testS3APIManagerBackup()
+	withBothDestinations.Spec.BackupDestination.PersistentVolumeClaim = &appsv1alpha1.PersistentVolumeClaimBackupDestination{}
+
+	for _, cr := range []*appsv1alpha1.APIManagerBackup{withoutDestination, withBothDestinations} {
+		if _, err := NewAPIManagerBackupOptionsProvider(cr, cl).Options(); err == nil {
+			t.Errorf("expected error with destination %v", cr.Spec.BackupDestination)
+		}
+	}
+}
+
+func TestS3BackupKey(t *testing.T) {
+	emptyPrefix := ""
+	nestedPrefix := "/team/3scale/"
+	cases := []struct {
+		prefix   *string
+		expected string
+	}{
+		{nil, "mybackup"},
+		{&emptyPrefix, "mybackup"},
+		{&nestedPrefix, "team/3scale/mybackup"},
+	}
+
+	for _, tc := range cases {
+		if key := S3BackupKey(tc.prefix, "mybackup"); key != tc.expected {
+			t.Errorf("expected key %s, got %s", tc.expected, key)
+		}
+	}
+}
+
+func TestAPIManagerBackupDatabasesJob(t *testing.T) {
+	trueVal := true
+	postgreSQL := &appsv1alpha1.SystemDatabaseSpec{PostgreSQL: &appsv1alpha1.SystemPostgreSQLSpec{}}
+
+	cases := []struct {
+		testName   string
+		system     *appsv1alpha1.SystemSpec
+		external   *appsv1alpha1.ExternalComponentsSpec
+		expected   []string
+		unexpected []string
+	}{
+		{"Internal", nil, nil,
+			[]string{"-l deploymentConfig=${1}", "mysqldump", "${DATABASES_SUBDIR}/system-mysql.sql", "${DATABASES_SUBDIR}/zync-database.sql", "${DATABASES_SUBDIR}/backend-redis.rdb", "${DATABASES_SUBDIR}/system-redis.rdb"},
+			[]string{"system-postgresql", "-l deploymentconfig="}},
+		{"SystemPostgreSQL", &appsv1alpha1.SystemSpec{DatabaseSpec: postgreSQL}, nil,
+			[]string{"pg_dump", "${DATABASES_SUBDIR}/system-postgresql.sql"},
+			[]string{"mysqldump"}},
+		{"ExternalSystemDatabase", nil, &appsv1alpha1.ExternalComponentsSpec{System: &appsv1alpha1.ExternalSystemComponents{Database: &trueVal}},
+			[]string{"${DATABASES_SUBDIR}/zync-database.sql", "BGSAVE", "Timed out waiting for the BGSAVE of Deployment backend-redis"},
+			[]string{"mysqldump", "system-postgresql"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			apimanager := &appsv1alpha1.APIManager{
+				ObjectMeta: metav1.ObjectMeta{Name: "example-apimanager", Namespace: "operator-unittest"},
+				Spec:       appsv1alpha1.APIManagerSpec{System: tc.system, ExternalComponents: tc.external},
+			}
+			s := runtime.NewScheme()
+			if err := appsv1alpha1.AddToScheme(s); err != nil {
+				subT.Fatal(err)
+			}
+			cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(apimanager).Build()
+
+			options, err := NewAPIManagerBackupOptionsProvider(testS3APIManagerBackup(), cl).Options()
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			job := NewAPIManagerBackup(options).BackupDatabasesJob()
+			if job == nil {
+				subT.Fatal("expected databases backup job")
+			}
+			if job.Spec.ActiveDeadlineSeconds == nil || *job.Spec.ActiveDeadlineSeconds != BackupDatabasesJobActiveDeadlineSeconds {
+				subT.Errorf("expected databases backup job deadline, got %v", job.Spec.ActiveDeadlineSeconds)
+			}
+			podSpec := job.Spec.Template.Spec
+			if len(podSpec.InitContainers) != 1 || podSpec.InitContainers[0].Name != "backup-databases" {
+				subT.Fatalf("expected backup init container, got %v", podSpec.InitContainers)
+			}
+
+			script := podSpec.InitContainers[0].Args[len(podSpec.InitContainers[0].Args)-1]
+			for _, expected := range tc.expected {
+				if !strings.Contains(script, expected) {
+					subT.Errorf("expected backup script to contain %q, got %s", expected, script)
+				}
+			}
+			for _, unexpected := range tc.unexpected {
+				if strings.Contains(script, unexpected) {
+					subT.Errorf("unexpected %q in backup script %s", unexpected, script)
+				}
+			}
+		})
+	}
+
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-apimanager", Namespace: "operator-unittest"},
+		Spec:       appsv1alpha1.APIManagerSpec{ExternalComponents: appsv1alpha1.AllComponentsExternal()},
+	}
+	options := &APIManagerBackupOptions{APIManager: apimanager, APIManagerBackupS3Options: &APIManagerBackupS3Options{}}
+	if job := NewAPIManagerBackup(options).BackupDatabasesJob(); job != nil {
+		t.Errorf("expected no databases backup job with external databases, got %v", job)
+	}
+}
@@ -0,0 +1,126 @@
+package backup
+
+import (
+	"fmt"
+	"strings"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+)
+
+// DatabasesBackupSubdir is the subdirectory of the backup data holding
+// the database dumps and the Redis RDB copies
+const DatabasesBackupSubdir = "databases"
+
+// BackupDatabasesJobActiveDeadlineSeconds bounds the run of the databases backup job.
+// The APIManager workers are paused while it runs
+const BackupDatabasesJobActiveDeadlineSeconds int64 = 4 * 60 * 60
+
+// redisBGSaveWaitRetries bounds the wait for the Redis BGSAVE, polled every 5 seconds
+const redisBGSaveWaitRetries = 360
+
+// SQLDumpFileName returns the file name of the SQL dump of the given database deployment
+func SQLDumpFileName(deploymentName string) string {
+	return fmt.Sprintf("%s.sql", deploymentName)
+}
+
+// RedisDumpFileName returns the file name of the RDB copy of the given Redis deployment
+func RedisDumpFileName(deploymentName string) string {
+	return fmt.Sprintf("%s.rdb", deploymentName)
+}
+
+// BackedUpSystemDatabase returns the name of the internal system database deployment.
+// Empty when the system database is external
+func BackedUpSystemDatabase(apimanager *appsv1alpha1.APIManager) string {
+	system := apimanager.Spec.System
+	switch {
+	case apimanager.IsExternal(appsv1alpha1.SystemDatabase):
+		return ""
+	case system != nil && system.DatabaseSpec != nil && system.DatabaseSpec.PostgreSQL != nil:
+		return component.SystemPostgreSQLDeploymentName
+	}
+	return component.SystemMySQLDeploymentName
+}
+
+// BackedUpRedis returns the names of the internal Redis deployments
+func BackedUpRedis(apimanager *appsv1alpha1.APIManager) []string {
+	names := []string{}
+	if !apimanager.IsExternal(appsv1alpha1.BackendRedis) {
+		names = append(names, component.BackendRedisDeploymentName)
+	}
+	if !apimanager.IsExternal(appsv1alpha1.SystemRedis) {
+		names = append(names, component.SystemRedisDeploymentName)
+	}
+	return names
+}
+
+// BackedUpZyncDatabase tells whether the zync database is internal
+func BackedUpZyncDatabase(apimanager *appsv1alpha1.APIManager) bool {
+	return !apimanager.IsExternal(appsv1alpha1.ZyncDatabase)
+}
+
+// backupDatabasesContainerArgs dumps the internal databases from their running pods.
+// mysqldump runs in a single transaction and pg_dump always takes a consistent snapshot.
+// Redis writes a new RDB file with BGSAVE, copied once LASTSAVE changes.
+// The wait for LASTSAVE to change is bounded, as it never changes when BGSAVE fails
+func (b *APIManagerBackup) backupDatabasesContainerArgs() string {
+	script := []string{fmt.Sprintf(`
+BASEPATH='%s';
+DATABASES_SUBDIR="${BASEPATH}/%s";
+mkdir -p ${DATABASES_SUBDIR};
+pod_name() {
+	pod=$(oc get pods -l deploymentConfig=${1} --field-selector=status.phase=Running --no-headers=true -o custom-columns=:metadata.name | head -n 1)
+	if [ -z "${pod}" ]; then
+		echo "No running pods found for Deployment ${1}" >&2
+		return 1
+	fi
+	echo -n ${pod}
+}
+`,
+		BackupPVCMountPath,
+		DatabasesBackupSubdir,
+	)}
+
+	switch systemDatabase := BackedUpSystemDatabase(b.APIManager()); systemDatabase {
+	case component.SystemMySQLDeploymentName:
+		script = append(script, fmt.Sprintf(`
+pod=$(pod_name %s)
+oc exec ${pod} -- bash -c 'MYSQL_PWD="${MYSQL_ROOT_PASSWORD}" mysqldump -u root --single-transaction --routines --triggers "${MYSQL_DATABASE}"' > ${DATABASES_SUBDIR}/%s;
+`, systemDatabase, SQLDumpFileName(systemDatabase)))
+	case component.SystemPostgreSQLDeploymentName:
+		script = append(script, postgreSQLDumpScript(systemDatabase))
+	}
+
+	if BackedUpZyncDatabase(b.APIManager()) {
+		script = append(script, postgreSQLDumpScript(component.ZyncDatabaseDeploymentName))
+	}
+
+	for _, redis := range BackedUpRedis(b.APIManager()) {
+		script = append(script, fmt.Sprintf(`
+pod=$(pod_name %s)
+lastsave=$(oc exec ${pod} -- redis-cli LASTSAVE)
+sleep 1;
+oc exec ${pod} -- redis-cli BGSAVE SCHEDULE;
+retries=0;
+until [ "$(oc exec ${pod} -- redis-cli LASTSAVE)" != "${lastsave}" ]; do
+	retries=$((retries+1));
+	if [ ${retries} -gt %d ]; then
+		echo "Timed out waiting for the BGSAVE of Deployment %s" >&2;
+		exit 1;
+	fi
+	sleep 5;
+done;
+oc exec ${pod} -- bash -c 'redis-cli INFO persistence | grep -q "rdb_last_bgsave_status:ok"';
+oc exec ${pod} -- cat /var/lib/redis/data/dump.rdb > ${DATABASES_SUBDIR}/%s;
+`, redis, redisBGSaveWaitRetries, redis, RedisDumpFileName(redis)))
+	}
+
+	return strings.Join(script, "")
+}
+
+func postgreSQLDumpScript(deploymentName string) string {
+	return fmt.Sprintf(`
+pod=$(pod_name %s)
+oc exec ${pod} -- bash -c 'pg_dump --clean --if-exists --no-owner --no-privileges "${POSTGRESQL_DATABASE}"' > ${DATABASES_SUBDIR}/%s;
+`, deploymentName, SQLDumpFileName(deploymentName))
+}
@@ -0,0 +1,253 @@
+package backup
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+const (
+	BackupKeyVolumeName = "backup-key"
+	BackupKeyMountPath  = "/etc/backup-key"
+	BackupKeyFileName   = "key"
+
+	// PlaintextDataVolumeName is the emptyDir volume holding the backup data
+	// before it is encrypted, or after it is decrypted
+	PlaintextDataVolumeName = "backup-plaintext-data"
+	PlaintextDataMountPath  = "/backup-plaintext"
+
+	// EncryptedFileSuffix is appended to the name of the encrypted files.
+	// With an RSA key, the encrypted random key of each file is stored
+	// in a file with the EncryptedKeyFileSuffix appended
+	EncryptedFileSuffix    = ".enc"
+	EncryptedKeyFileSuffix = ".enc.key"
+
+	// DecryptionErrorFileName is written to the plaintext data root
+	// when the decryption fails and decryption failures are tolerated
+	DecryptionErrorFileName = ".decryption-error"
+
+	EncryptionAES = "aes-256-cbc"
+	EncryptionRSA = "rsa-oaep+aes-256-cbc"
+)
+
+// BackupCipher encrypts or decrypts the backup data with the key in a secret,
+// using openssl from the OC CLI image
+type BackupCipher struct {
+	KeySecretKeySelector v1.SecretKeySelector
+	// RSA key pair. The public key encrypts, the private key decrypts
+	RSA           bool
+	OCCLIImageURL string
+}
+
+// NewBackupEncryptionCipher returns the cipher encrypting the backup data. Nil without encryption
+func NewBackupEncryptionCipher(encryption *appsv1alpha1.BackupEncryption, ocCLIImageURL string) (*BackupCipher, error) {
+	if encryption == nil {
+		return nil, nil
+	}
+	return newBackupCipher(encryption.KeySecretRef, encryption.PublicKeySecretRef, ocCLIImageURL)
+}
+
+// NewBackupDecryptionCipher returns the cipher decrypting the backup data. Nil without decryption
+func NewBackupDecryptionCipher(decryption *appsv1alpha1.BackupDecryption, ocCLIImageURL string) (*BackupCipher, error) {
+	if decryption == nil {
+		return nil, nil
+	}
+	return newBackupCipher(decryption.KeySecretRef, decryption.PrivateKeySecretRef, ocCLIImageURL)
+}
+
+func newBackupCipher(key, rsaKey *v1.SecretKeySelector, ocCLIImageURL string) (*BackupCipher, error) {
+	if key == nil && rsaKey == nil {
+		return nil, fmt.Errorf("A symmetric key or an RSA key has to be specified")
+	}
+	if key != nil && rsaKey != nil {
+		return nil, fmt.Errorf("Only one of a symmetric key or an RSA key can be specified")
+	}
+
+	if key != nil {
+		return &BackupCipher{KeySecretKeySelector: *key, OCCLIImageURL: ocCLIImageURL}, nil
+	}
+	return &BackupCipher{KeySecretKeySelector: *rsaKey, RSA: true, OCCLIImageURL: ocCLIImageURL}, nil
+}
+
+// Encryption returns the encryption recorded in the backup manifest
+func (c *BackupCipher) Encryption() string {
+	if c.RSA {
+		return EncryptionRSA
+	}
+	return EncryptionAES
+}
+
+// Volumes returns the volumes required by the encryption and decryption containers
+func (c *BackupCipher) Volumes() []v1.Volume {
+	return []v1.Volume{
+		{
+			Name: BackupKeyVolumeName,
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: c.KeySecretKeySelector.Name,
+					Items: []v1.KeyToPath{
+						{Key: c.KeySecretKeySelector.Key, Path: BackupKeyFileName},
+					},
+				},
+			},
+		},
+		{
+			Name: PlaintextDataVolumeName,
+			VolumeSource: v1.VolumeSource{
+				EmptyDir: &v1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+}
+
+// PlaintextDataVolumeMount returns the mount of the plaintext backup data
+// for the containers reading or writing it
+func (c *BackupCipher) PlaintextDataVolumeMount(mountPath string) v1.VolumeMount {
+	return v1.VolumeMount{
+		Name:      PlaintextDataVolumeName,
+		MountPath: mountPath,
+	}
+}
+
+// EncryptContainer returns the container encrypting the plaintext backup data into the
+// mounted data volume. The backup manifest is copied as is, so it can be read without the key
+func (c *BackupCipher) EncryptContainer(dataVolumeMount v1.VolumeMount) v1.Container {
+	script := fmt.Sprintf(`
+%s
+cd %s;
+find . -type f -print0 > /tmp/files;
+while IFS= read -r -d '' f; do
+	mkdir -p "${DATA}/$(dirname "${f}")";
+	case "${f}" in
+		./%s/*) cp "${f}" "${DATA}/${f}";;
+		*) encrypt_file "${f}" "${DATA}/${f}%s";;
+	esac
+done < /tmp/files;
+echo "Backup data encrypted";
+`,
+		c.functionsScript(dataVolumeMount.MountPath),
+		PlaintextDataMountPath,
+		ManifestBackupSubdir,
+		EncryptedFileSuffix,
+	)
+
+	return c.container("encrypt-backup-data", script, dataVolumeMount)
+}
+
+// DecryptContainer returns the container decrypting the given subdirectories of the mounted
+// data volume into the plaintext backup data. When decryption failures are tolerated, the
+// error is written into DecryptionErrorFileName and the container finishes successfully
+func (c *BackupCipher) DecryptContainer(dataVolumeMount v1.VolumeMount, subdirs []string, tolerateFailure bool) v1.Container {
+	exitCode := 1
+	if tolerateFailure {
+		exitCode = 0
+	}
+
+	script := fmt.Sprintf(`
+%s
+SUBDIRS='%s';
+decryption_failed() {
+	echo -n "Failed to decrypt ${CURRENT}. Check the decryption key" > %s/%s;
+	exit %d;
+}
+set -E;
+trap decryption_failed ERR;
+cd ${DATA};
+for i in $(echo -n $SUBDIRS); do
+	if [ ! -d "./${i}" ]; then
+		continue;
+	fi
+	find "./${i}" -type f -print0 > /tmp/files;
+	while IFS= read -r -d '' f; do
+		CURRENT="${f#./}";
+		mkdir -p "%s/$(dirname "${f}")";
+		case "${f}" in
+			*%s) ;;
+			*%s) decrypt_file "${f}" "%s/${f%%%s}";;
+			*) cp "${f}" "%s/${f}";;
+		esac
+	done < /tmp/files;
+done;
+echo "Backup data decrypted";
+`,
+		c.functionsScript(dataVolumeMount.MountPath),
+		strings.Join(subdirs, " "),
+		PlaintextDataMountPath, DecryptionErrorFileName,
+		exitCode,
+		PlaintextDataMountPath,
+		EncryptedKeyFileSuffix,
+		EncryptedFileSuffix, PlaintextDataMountPath, EncryptedFileSuffix,
+		PlaintextDataMountPath,
+	)
+
+	return c.container("decrypt-backup-data", script, dataVolumeMount)
+}
+
+// functionsScript defines the encrypt_file and decrypt_file functions. The AES key is derived
+// from the SHA-256 hash of the key file, so the key can hold any binary content
+func (c *BackupCipher) functionsScript(dataPath string) string {
+	keyPath := fmt.Sprintf("%s/%s", BackupKeyMountPath, BackupKeyFileName)
+	script := fmt.Sprintf(`
+DATA='%s';
+KEY='%s';
+aes_key() {
+	export AES_KEY=$(sha256sum < "${1}" | cut -d ' ' -f 1);
+}
+aes() {
+	openssl enc -aes-256-cbc -md sha256 -pbkdf2 -iter 100000 -pass env:AES_KEY "$@";
+}
+`,
+		dataPath,
+		keyPath,
+	)
+
+	if c.RSA {
+		return script + fmt.Sprintf(`
+rsa() {
+	openssl pkeyutl -pkeyopt rsa_padding_mode:oaep -pkeyopt rsa_oaep_md:sha256 "$@";
+}
+encrypt_file() {
+	openssl rand -hex 32 > /tmp/file-key;
+	rsa -encrypt -pubin -inkey ${KEY} -in /tmp/file-key -out "${2%%%s}%s";
+	aes_key /tmp/file-key;
+	aes -salt -in "${1}" -out "${2}";
+}
+decrypt_file() {
+	rsa -decrypt -inkey ${KEY} -in "${1%%%s}%s" -out /tmp/file-key;
+	aes_key /tmp/file-key;
+	aes -d -in "${1}" -out "${2}";
+}
+`, EncryptedFileSuffix, EncryptedKeyFileSuffix, EncryptedFileSuffix, EncryptedKeyFileSuffix)
+	}
+
+	return script + `
+aes_key ${KEY};
+encrypt_file() {
+	aes -salt -in "${1}" -out "${2}";
+}
+decrypt_file() {
+	aes -d -in "${1}" -out "${2}";
+}
+`
+}
+
+func (c *BackupCipher) container(name, script string, dataVolumeMount v1.VolumeMount) v1.Container {
+	return v1.Container{
+		Name:    name,
+		Image:   c.OCCLIImageURL,
+		Command: []string{"/bin/bash"},
+		Args:    []string{"-c", "-e", script},
+		VolumeMounts: []v1.VolumeMount{
+			dataVolumeMount,
+			c.PlaintextDataVolumeMount(PlaintextDataMountPath),
+			{
+				Name:      BackupKeyVolumeName,
+				MountPath: BackupKeyMountPath,
+				ReadOnly:  true,
+			},
+		},
+	}
+}
@@ -0,0 +1,108 @@
+package backup
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNewBackupCipher(t *testing.T) {
+	key := &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "backup-key"}, Key: "key"}
+
+	cases := []struct {
+		name        string
+		encryption  *appsv1alpha1.BackupEncryption
+		expectedErr bool
+		expected    string
+	}{
+		{"NoEncryption", nil, false, ""},
+		{"NoKey", &appsv1alpha1.BackupEncryption{}, true, ""},
+		{"BothKeys", &appsv1alpha1.BackupEncryption{KeySecretRef: key, PublicKeySecretRef: key}, true, ""},
+		{"SymmetricKey", &appsv1alpha1.BackupEncryption{KeySecretRef: key}, false, EncryptionAES},
+		{"PublicKey", &appsv1alpha1.BackupEncryption{PublicKeySecretRef: key}, false, EncryptionRSA},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			cipher, err := NewBackupEncryptionCipher(tc.encryption, "quay.io/openshift/origin-cli:4.7")
+			if (err != nil) != tc.expectedErr {
+				subT.Fatalf("unexpected error %v", err)
+			}
+			if tc.expected == "" {
+				if cipher != nil {
+					subT.Errorf("unexpected cipher %v", cipher)
+				}
+				return
+			}
+			if cipher == nil || cipher.Encryption() != tc.expected {
+				subT.Fatalf("expected %s cipher, got %v", tc.expected, cipher)
+			}
+			secret := cipher.Volumes()[0].Secret
+			if secret.SecretName != "backup-key" || secret.Items[0].Key != "key" || secret.Items[0].Path != BackupKeyFileName {
+				subT.Errorf("unexpected key volume %v", secret)
+			}
+		})
+	}
+}
+
+func TestAPIManagerBackupEncryption(t *testing.T) {
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-apimanager", Namespace: "operator-unittest"},
+	}
+	s := runtime.NewScheme()
+	if err := appsv1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(apimanager).Build()
+
+	cr := testS3APIManagerBackup()
+	cr.Spec.Encryption = &appsv1alpha1.BackupEncryption{
+		PublicKeySecretRef: &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "backup-key"}, Key: "public.pem"},
+	}
+	options, err := NewAPIManagerBackupOptionsProvider(cr, cl).Options()
+	if err != nil {
+		t.Fatal(err)
+	}
+	apiManagerBackup := NewAPIManagerBackup(options)
+
+	podSpec := apiManagerBackup.BackupSecretsAndConfigMapsJob().Spec.Template.Spec
+	if len(podSpec.InitContainers) != 2 || podSpec.InitContainers[0].Name != "backup-cfgmaps-secrets" || podSpec.InitContainers[1].Name != "encrypt-backup-data" {
+		t.Fatalf("expected backup and encryption init containers, got %v", podSpec.InitContainers)
+	}
+	if mount := podSpec.InitContainers[0].VolumeMounts[0]; mount.Name != PlaintextDataVolumeName || mount.MountPath != BackupPVCMountPath {
+		t.Errorf("expected the backup container to write the plaintext data, got %v", mount)
+	}
+	if script := podSpec.InitContainers[1].Args[2]; !strings.Contains(script, "rsa -encrypt -pubin") {
+		t.Errorf("expected files encrypted with the public key, got %s", script)
+	}
+	if len(podSpec.Containers) != 1 || podSpec.Containers[0].Name != "upload-backup-data" {
+		t.Fatalf("expected upload container, got %v", podSpec.Containers)
+	}
+	for _, volume := range podSpec.Volumes {
+		if volume.Name == BackupKeyVolumeName && volume.Secret.SecretName != "backup-key" {
+			t.Errorf("unexpected key volume %v", volume)
+		}
+	}
+
+	// The manifest records the encryption, and is not encrypted
+	manifestPodSpec := apiManagerBackup.BackupManifestJob().Spec.Template.Spec
+	for _, container := range manifestPodSpec.InitContainers {
+		if container.Name == "encrypt-backup-data" {
+			t.Errorf("unexpected encryption of the manifest")
+		}
+	}
+	manifest := &BackupManifest{}
+	if err := json.Unmarshal([]byte(manifestPodSpec.InitContainers[1].Env[0].Value), manifest); err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Encryption != EncryptionRSA {
+		t.Errorf("expected %s encryption in the manifest, got %q", EncryptionRSA, manifest.Encryption)
+	}
+}
@@ -0,0 +1,184 @@
+package backup
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	"github.com/3scale/3scale-operator/version"
+)
+
+// ManifestBackupSubdir is the subdirectory of the backup data holding
+// the backup manifest and the checksums written by each backup job
+const ManifestBackupSubdir = "manifest"
+
+// ManifestFileName is the file name of the backup manifest
+const ManifestFileName = "manifest.json"
+
+// BackupManifest describes the backup data: what produced it
+// and the SHA-256 checksum of every backed up file
+type BackupManifest struct {
+	BackupName         string                        `json:"backupName"`
+	CreationTimestamp  string                        `json:"creationTimestamp,omitempty"`
+	OperatorVersion    string                        `json:"operatorVersion"`
+	ThreescaleRelease  string                        `json:"threescaleRelease"`
+	APIManagerName     string                        `json:"apiManagerName"`
+	APIManagerSpecHash string                        `json:"apiManagerSpecHash"`
+	Components         []appsv1alpha1.ComponentImage `json:"components,omitempty"`
+	Encryption         string                        `json:"encryption,omitempty"`
+	Files              []BackupManifestFile          `json:"files,omitempty"`
+}
+
+// BackupManifestFile is a backed up file, relative to the backup data root
+type BackupManifestFile struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// APIManagerSpecHash returns the hex encoded SHA-256 hash of the JSON serialized APIManager spec
+func APIManagerSpecHash(apimanager *appsv1alpha1.APIManager) string {
+	serialized, err := json.Marshal(apimanager.Spec)
+	if err != nil {
+		panic(err)
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(serialized))
+}
+
+// Manifest returns the backup manifest without its file list and creation
+// timestamp, both added by the backup manifest job
+func (b *APIManagerBackup) Manifest() *BackupManifest {
+	manifest := &BackupManifest{
+		BackupName:         b.options.APIManagerBackupName,
+		OperatorVersion:    version.Version,
+		ThreescaleRelease:  product.ThreescaleRelease,
+		APIManagerName:     b.options.APIManagerName,
+		APIManagerSpecHash: APIManagerSpecHash(b.APIManager()),
+		Components:         b.APIManager().Status.Images,
+	}
+	if b.options.BackupCipher != nil {
+		manifest.Encryption = b.options.BackupCipher.Encryption()
+	}
+	return manifest
+}
+
+// BackupManifestJob returns the job writing the backup manifest from the checksums
+// written by the previous backup jobs. It has to run once all of them have finished.
+// The manifest and the checksums are not encrypted
+func (b *APIManagerBackup) BackupManifestJob() *batchv1.Job {
+	manifest, err := json.Marshal(b.Manifest())
+	if err != nil {
+		panic(err)
+	}
+
+	job := b.job("backup-manifest", v1.Container{
+		Name:  "backup-manifest",
+		Image: b.options.OCCLIImageURL,
+		Command: []string{
+			"/bin/bash",
+		},
+		Args: []string{
+			"-c",
+			"-e",
+			b.backupManifestContainerArgs(),
+		},
+		Env: []v1.EnvVar{
+			helper.EnvVarFromValue("BACKUP_MANIFEST", string(manifest)),
+		},
+	}, nil, nil)
+	if job == nil {
+		return nil
+	}
+
+	// Only the checksums are downloaded. The manifest is uploaded along with them
+	if s3Options := b.options.APIManagerBackupS3Options; s3Options != nil {
+		podSpec := &job.Spec.Template.Spec
+		podSpec.InitContainers = append([]v1.Container{
+			s3Options.S3Location.DownloadContainer(b.backupDestinationContainerVolumeMount(), []string{ManifestBackupSubdir}),
+		}, podSpec.InitContainers...)
+	}
+
+	return job
+}
+
+func (b *APIManagerBackup) backupManifestContainerArgs() string {
+	return fmt.Sprintf(`
+BASEPATH='%s';
+PYTHON_MANIFEST_SUBSCRIPT="%s"
+python -c "${PYTHON_MANIFEST_SUBSCRIPT}" ${BASEPATH} > ${BASEPATH}/%s/%s;
+`,
+		BackupPVCMountPath,
+		pythonManifestScript,
+		ManifestBackupSubdir,
+		ManifestFileName,
+	)
+}
+
+// checksumsScript writes the checksums of the files in the given subdirectories of
+// the backup data, merged into the manifest by the backup manifest job
+func checksumsScript(name string, subdirs ...string) string {
+	return fmt.Sprintf(`
+PYTHON_CHECKSUMS_SUBSCRIPT="%s"
+mkdir -p %s;
+python -c "${PYTHON_CHECKSUMS_SUBSCRIPT}" %s %s > %s;
+`,
+		pythonChecksumsScript,
+		path.Join(BackupPVCMountPath, ManifestBackupSubdir, "checksums"),
+		BackupPVCMountPath,
+		strings.Join(subdirs, " "),
+		path.Join(BackupPVCMountPath, ManifestBackupSubdir, "checksums", fmt.Sprintf("%s.json", name)),
+	)
+}
+
+// PythonSHA256Function defines the sha256 python function returning the
+// hex encoded SHA-256 checksum of a file. Scripts embedding it are run
+// within double quotes, so they cannot contain double quotes
+const PythonSHA256Function = `
+import hashlib
+
+def sha256(path):
+  digest=hashlib.sha256()
+  with open(path, 'rb') as f:
+    for chunk in iter(lambda: f.read(1048576), b''):
+      digest.update(chunk)
+  return digest.hexdigest()
+`
+
+const pythonChecksumsScript = PythonSHA256Function + `
+import json, os, sys
+
+basepath=sys.argv[1]
+files=[]
+for subdir in sys.argv[2:]:
+  for root, dirs, filenames in os.walk(os.path.join(basepath, subdir)):
+    for filename in filenames:
+      filepath=os.path.join(root, filename)
+      if os.path.islink(filepath):
+        continue
+      files.append({'path': os.path.relpath(filepath, basepath), 'sha256': sha256(filepath), 'size': os.path.getsize(filepath)})
+
+print(json.dumps(files, indent=4, sort_keys=True))
+`
+
+const pythonManifestScript = `
+import datetime, json, os, sys
+
+checksumspath=os.path.join(sys.argv[1], 'manifest', 'checksums')
+manifest=json.loads(os.environ['BACKUP_MANIFEST'])
+manifest['creationTimestamp']=datetime.datetime.utcnow().strftime('%Y-%m-%dT%H:%M:%SZ')
+files=[]
+for filename in sorted(os.listdir(checksumspath)):
+  with open(os.path.join(checksumspath, filename)) as f:
+    files.extend(json.load(f))
+manifest['files']=sorted(files, key=lambda f: f['path'])
+
+print(json.dumps(manifest, indent=4, sort_keys=True))
+`
@@ -0,0 +1,104 @@
+package backup
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
+	"github.com/3scale/3scale-operator/version"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAPIManagerBackupManifestJob(t *testing.T) {
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-apimanager", Namespace: "operator-unittest"},
+		Status: appsv1alpha1.APIManagerStatus{
+			Images: []appsv1alpha1.ComponentImage{{Name: "apicast-production", Image: "quay.io/3scale/apicast:latest"}},
+		},
+	}
+	s := runtime.NewScheme()
+	if err := appsv1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(apimanager).Build()
+
+	options, err := NewAPIManagerBackupOptionsProvider(testS3APIManagerBackup(), cl).Options()
+	if err != nil {
+		t.Fatal(err)
+	}
+	apiManagerBackup := NewAPIManagerBackup(options)
+
+	job := apiManagerBackup.BackupManifestJob()
+	if job == nil {
+		t.Fatal("expected backup manifest job")
+	}
+	podSpec := job.Spec.Template.Spec
+	if len(podSpec.InitContainers) != 2 || podSpec.InitContainers[0].Name != "download-backup-data" || podSpec.InitContainers[1].Name != "backup-manifest" {
+		t.Fatalf("expected download and manifest init containers, got %v", podSpec.InitContainers)
+	}
+	if script := podSpec.InitContainers[0].Args[2]; !strings.Contains(script, "SUBDIRS='manifest'") {
+		t.Errorf("expected only the manifest subdirectory downloaded, got %s", script)
+	}
+	if len(podSpec.Containers) != 1 || podSpec.Containers[0].Name != "upload-backup-data" {
+		t.Fatalf("expected upload container, got %v", podSpec.Containers)
+	}
+
+	manifest := &BackupManifest{}
+	if err := json.Unmarshal([]byte(podSpec.InitContainers[1].Env[0].Value), manifest); err != nil {
+		t.Fatal(err)
+	}
+	if manifest.BackupName != "mybackup" || manifest.APIManagerName != "example-apimanager" ||
+		manifest.OperatorVersion != version.Version || manifest.ThreescaleRelease != product.ThreescaleRelease {
+		t.Errorf("unexpected manifest %v", manifest)
+	}
+	if manifest.APIManagerSpecHash != APIManagerSpecHash(apimanager) || len(manifest.APIManagerSpecHash) != 64 {
+		t.Errorf("unexpected APIManager spec hash %s", manifest.APIManagerSpecHash)
+	}
+	if len(manifest.Components) != 1 || manifest.Components[0].Image != "quay.io/3scale/apicast:latest" {
+		t.Errorf("unexpected components %v", manifest.Components)
+	}
+
+	cases := []struct {
+		name      string
+		script    string
+		checksums string
+	}{
+		{"SecretsAndConfigMaps", apiManagerBackup.BackupSecretsAndConfigMapsJob().Spec.Template.Spec.InitContainers[0].Args[2], "/backup secrets configmaps > /backup/manifest/checksums/cfgmaps-secrets.json"},
+		{"APIManager", apiManagerBackup.BackupAPIManagerCustomResourceJob().Spec.Template.Spec.InitContainers[0].Args[2], "/backup apimanager > /backup/manifest/checksums/apimanager-cr.json"},
+		{"SystemFileStorage", apiManagerBackup.BackupSystemFileStorageJob().Spec.Template.Spec.InitContainers[0].Args[2], "/backup system-filestorage-pvc > /backup/manifest/checksums/system-filestorage-pvc.json"},
+		{"Databases", apiManagerBackup.BackupDatabasesJob().Spec.Template.Spec.InitContainers[0].Args[2], "/backup databases > /backup/manifest/checksums/databases.json"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			if !strings.Contains(tc.script, tc.checksums) {
+				subT.Errorf("expected checksums written with %q, got %s", tc.checksums, tc.script)
+			}
+		})
+	}
+}
+
+func TestAPIManagerSpecHash(t *testing.T) {
+	apimanager := &appsv1alpha1.APIManager{Spec: appsv1alpha1.APIManagerSpec{APIManagerCommonSpec: appsv1alpha1.APIManagerCommonSpec{WildcardDomain: "example.com"}}}
+	hash := APIManagerSpecHash(apimanager)
+	if hash != APIManagerSpecHash(apimanager.DeepCopy()) {
+		t.Error("expected the same hash for the same spec")
+	}
+
+	apimanager.Spec.WildcardDomain = "example.org"
+	if hash == APIManagerSpecHash(apimanager) {
+		t.Error("expected a different hash for a different spec")
+	}
+}
+
+func TestManifestScriptsQuoting(t *testing.T) {
+	for _, script := range []string{pythonChecksumsScript, pythonManifestScript} {
+		if strings.ContainsAny(script, "\"$`") {
+			t.Errorf("the python scripts run within double quotes, got %s", script)
+		}
+	}
+}
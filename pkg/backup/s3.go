@@ -0,0 +1,130 @@
+package backup
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+const (
+	S3CAVolumeName = "s3-ca"
+	S3CAMountPath  = "/etc/s3-ca"
+	S3CAFileName   = "ca.crt"
+)
+
+// S3Volumes returns the volumes required by the S3 transfer containers
+func (l *S3Location) S3Volumes() []v1.Volume {
+	if l.CASecretKeySelector == nil {
+		return nil
+	}
+
+	return []v1.Volume{
+		{
+			Name: S3CAVolumeName,
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: l.CASecretKeySelector.Name,
+					Items: []v1.KeyToPath{
+						{Key: l.CASecretKeySelector.Key, Path: S3CAFileName},
+					},
+				},
+			},
+		},
+	}
+}
+
+// UploadContainer returns the container copying the backup data in the
+// mounted data volume to the object storage
+func (l *S3Location) UploadContainer(dataVolumeMount v1.VolumeMount) v1.Container {
+	script := fmt.Sprintf(`
+%s
+aws "${ENDPOINT_ARGS[@]}" s3 cp --recursive --only-show-errors '%s' "${S3_URL}";
+echo "Backup data uploaded to ${S3_URL}";
+`,
+		l.configScript(),
+		dataVolumeMount.MountPath,
+	)
+
+	return l.container("upload-backup-data", script, []v1.VolumeMount{dataVolumeMount})
+}
+
+// DownloadContainer returns the container copying the given subdirectories of
+// the backup data from the object storage to the mounted data volume
+func (l *S3Location) DownloadContainer(dataVolumeMount v1.VolumeMount, subdirs []string) v1.Container {
+	script := fmt.Sprintf(`
+%s
+SUBDIRS='%s';
+for i in $(echo -n $SUBDIRS); do
+	aws "${ENDPOINT_ARGS[@]}" s3 cp --recursive --only-show-errors "${S3_URL}/${i}" '%s'/"${i}";
+done;
+`,
+		l.configScript(),
+		strings.Join(subdirs, " "),
+		dataVolumeMount.MountPath,
+	)
+
+	return l.container("download-backup-data", script, []v1.VolumeMount{dataVolumeMount})
+}
+
+// DeleteContainer returns the container deleting the backup data from the object storage
+func (l *S3Location) DeleteContainer() v1.Container {
+	script := fmt.Sprintf(`
+%s
+aws "${ENDPOINT_ARGS[@]}" s3 rm --recursive --only-show-errors "${S3_URL}/";
+echo "Backup data deleted from ${S3_URL}";
+`,
+		l.configScript(),
+	)
+
+	return l.container("delete-backup-data", script, nil)
+}
+
+// configScript sets the AWS CLI arguments and configuration. The values of the spec
+// are read from the container env vars, so they are never parsed by the shell
+func (l *S3Location) configScript() string {
+	script := `ENDPOINT_ARGS=();`
+	if l.Endpoint != nil {
+		script = `ENDPOINT_ARGS=(--endpoint-url "${S3_ENDPOINT}");`
+	}
+	if l.ForcePathStyle {
+		script += "\naws configure set default.s3.addressing_style path;"
+	}
+	return script
+}
+
+func (l *S3Location) container(name, script string, volumeMounts []v1.VolumeMount) v1.Container {
+	env := []v1.EnvVar{
+		helper.EnvVarFromSecret("AWS_ACCESS_KEY_ID", l.CredentialsSecretName, "AWS_ACCESS_KEY_ID"),
+		helper.EnvVarFromSecret("AWS_SECRET_ACCESS_KEY", l.CredentialsSecretName, "AWS_SECRET_ACCESS_KEY"),
+		// The container user might not own its home directory
+		helper.EnvVarFromValue("AWS_CONFIG_FILE", "/tmp/.aws/config"),
+		helper.EnvVarFromValue("S3_URL", l.URL()),
+	}
+	if l.Endpoint != nil {
+		env = append(env, helper.EnvVarFromValue("S3_ENDPOINT", *l.Endpoint))
+	}
+	if l.Region != nil {
+		env = append(env, helper.EnvVarFromValue("AWS_DEFAULT_REGION", *l.Region))
+	}
+
+	if l.CASecretKeySelector != nil {
+		env = append(env, helper.EnvVarFromValue("AWS_CA_BUNDLE", fmt.Sprintf("%s/%s", S3CAMountPath, S3CAFileName)))
+		volumeMounts = append(volumeMounts, v1.VolumeMount{
+			Name:      S3CAVolumeName,
+			MountPath: S3CAMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	return v1.Container{
+		Name:         name,
+		Image:        l.AWSCLIImageURL,
+		Command:      []string{"/bin/bash"},
+		Args:         []string{"-c", "-e", script},
+		Env:          env,
+		VolumeMounts: volumeMounts,
+	}
+}
@@ -0,0 +1,140 @@
+package backup
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+// ScheduledBackup returns the APIManagerBackup created by the schedule at the given time
+func ScheduledBackup(schedule *appsv1alpha1.APIManagerBackupSchedule, scheduledTime time.Time) *appsv1alpha1.APIManagerBackup {
+	return &appsv1alpha1.APIManagerBackup{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1alpha1.GroupVersion.String(),
+			Kind:       "APIManagerBackup",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", schedule.Name, scheduledTime.UTC().Format("20060102150405")),
+			Namespace: schedule.Namespace,
+			Labels: map[string]string{
+				appsv1alpha1.APIManagerBackupScheduleLabel: schedule.Name,
+			},
+		},
+		Spec: *schedule.Spec.BackupTemplate.DeepCopy(),
+	}
+}
+
+// BackupsToPrune returns the finished backups not kept by the retention policy.
+// The most recent successful backup is always kept, and failed backups are
+// pruned once a later backup succeeds. Nothing is pruned without retention policy
+func BackupsToPrune(backups []appsv1alpha1.APIManagerBackup, retention *appsv1alpha1.APIManagerBackupRetention) []*appsv1alpha1.APIManagerBackup {
+	if retention == nil || (retention.KeepLast == nil && retention.KeepDaily == nil && retention.KeepWeekly == nil) {
+		return nil
+	}
+
+	sorted := make([]*appsv1alpha1.APIManagerBackup, 0, len(backups))
+	for idx := range backups {
+		sorted = append(sorted, &backups[idx])
+	}
+	// Most recent first. The names of the scheduled backups end with the scheduled time
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].CreationTimestamp.Equal(&sorted[j].CreationTimestamp) {
+			return sorted[i].Name > sorted[j].Name
+		}
+		return sorted[j].CreationTimestamp.Before(&sorted[i].CreationTimestamp)
+	})
+
+	keepLast := int32Value(retention.KeepLast)
+	keepDaily := int32Value(retention.KeepDaily)
+	keepWeekly := int32Value(retention.KeepWeekly)
+	days := map[string]bool{}
+	weeks := map[string]bool{}
+	successful := 0
+
+	prune := []*appsv1alpha1.APIManagerBackup{}
+	for _, backup := range sorted {
+		if backup.BackupFailed() {
+			if successful > 0 {
+				prune = append(prune, backup)
+			}
+			continue
+		}
+		if !backup.BackupCompleted() {
+			continue
+		}
+
+		successful++
+		created := backup.CreationTimestamp.UTC()
+		day := created.Format("2006-01-02")
+		year, week := created.ISOWeek()
+		isoWeek := fmt.Sprintf("%d-%d", year, week)
+
+		keep := successful == 1 || successful <= keepLast
+		if !days[day] && len(days) < keepDaily {
+			days[day] = true
+			keep = true
+		}
+		if !weeks[isoWeek] && len(weeks) < keepWeekly {
+			weeks[isoWeek] = true
+			keep = true
+		}
+		if !keep {
+			prune = append(prune, backup)
+		}
+	}
+
+	return prune
+}
+
+// S3PruneJob returns the job deleting the data of the given backup from the object storage.
+// Nil when S3 is not the backup data destination
+func S3PruneJob(cr *appsv1alpha1.APIManagerBackup, awsCLIImageURL string) *batchv1.Job {
+	s3 := cr.Spec.BackupDestination.S3
+	if s3 == nil {
+		return nil
+	}
+
+	jobName, err := helper.UIDBasedJobName("prune-backup", cr.UID)
+	if err != nil {
+		panic(err)
+	}
+
+	s3Location := NewS3Location(&s3.S3ObjectStorage, S3BackupKey(s3.Prefix, cr.Name), awsCLIImageURL)
+	var completions int32 = 1
+	var backoffLimit int32 = 2
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: cr.Namespace,
+		},
+		Spec: batchv1.JobSpec{
+			Completions:  &completions,
+			BackoffLimit: &backoffLimit,
+			Template: v1.PodTemplateSpec{
+				Spec: v1.PodSpec{
+					Volumes:       s3Location.S3Volumes(),
+					Containers:    []v1.Container{s3Location.DeleteContainer()},
+					RestartPolicy: v1.RestartPolicyNever,
+				},
+			},
+		},
+	}
+}
+
+func int32Value(v *int32) int {
+	if v == nil {
+		return 0
+	}
+	return int(*v)
+}
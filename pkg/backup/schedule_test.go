@@ -0,0 +1,167 @@
+package backup
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+)
+
+func scheduledBackupWithStatus(created time.Time, completed, failed bool) appsv1alpha1.APIManagerBackup {
+	return appsv1alpha1.APIManagerBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              fmt.Sprintf("schedule-%s", created.Format("20060102150405")),
+			CreationTimestamp: metav1.Time{Time: created},
+		},
+		Status: appsv1alpha1.APIManagerBackupStatus{
+			Completed: &completed,
+			Failed:    &failed,
+		},
+	}
+}
+
+func prunedBackupNames(backups []*appsv1alpha1.APIManagerBackup) []string {
+	names := []string{}
+	for _, backup := range backups {
+		names = append(names, backup.Name)
+	}
+	return names
+}
+
+func TestBackupsToPrune(t *testing.T) {
+	// Daily backups at 02:00, from Monday 2023-01-02 to Sunday 2023-01-22
+	start := time.Date(2023, time.January, 2, 2, 0, 0, 0, time.UTC)
+	daily := []appsv1alpha1.APIManagerBackup{}
+	for day := 0; day < 21; day++ {
+		daily = append(daily, scheduledBackupWithStatus(start.AddDate(0, 0, day), true, false))
+	}
+	name := func(day int) string { return daily[day].Name }
+
+	cases := []struct {
+		name      string
+		backups   []appsv1alpha1.APIManagerBackup
+		retention *appsv1alpha1.APIManagerBackupRetention
+		expected  []string
+	}{
+		{"noRetention", daily, nil, []string{}},
+		{"emptyRetention", daily, &appsv1alpha1.APIManagerBackupRetention{}, []string{}},
+		{
+			"keepLast", daily[:5],
+			&appsv1alpha1.APIManagerBackupRetention{KeepLast: pointer.Int32(3)},
+			[]string{name(1), name(0)},
+		},
+		{
+			"keepLastZeroKeepsMostRecent", daily[:3],
+			&appsv1alpha1.APIManagerBackupRetention{KeepLast: pointer.Int32(0)},
+			[]string{name(1), name(0)},
+		},
+		{
+			"keepDailyAndWeekly", daily,
+			&appsv1alpha1.APIManagerBackupRetention{KeepDaily: pointer.Int32(2), KeepWeekly: pointer.Int32(3)},
+			// Kept: 22 and 21 (daily), 22, 15 and 8 (weekly, most recent of each ISO week)
+			[]string{name(18), name(17), name(16), name(15), name(14), name(12), name(11), name(10),
+				name(9), name(8), name(7), name(5), name(4), name(3), name(2), name(1), name(0)},
+		},
+		{
+			"inProgressNotPruned",
+			append([]appsv1alpha1.APIManagerBackup{scheduledBackupWithStatus(start.AddDate(0, 0, 30), false, false)}, daily[:2]...),
+			&appsv1alpha1.APIManagerBackupRetention{KeepLast: pointer.Int32(1)},
+			[]string{name(0)},
+		},
+		{
+			"failedPrunedOnceLaterSucceeds",
+			[]appsv1alpha1.APIManagerBackup{
+				scheduledBackupWithStatus(start.AddDate(0, 0, 3), false, true),
+				daily[2],
+				scheduledBackupWithStatus(start.AddDate(0, 0, 1), false, true),
+				daily[0],
+			},
+			&appsv1alpha1.APIManagerBackupRetention{KeepLast: pointer.Int32(2)},
+			[]string{scheduledBackupWithStatus(start.AddDate(0, 0, 1), false, true).Name},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			pruned := prunedBackupNames(BackupsToPrune(tc.backups, tc.retention))
+			if !reflect.DeepEqual(pruned, tc.expected) {
+				subT.Errorf("expected %v, got %v", tc.expected, pruned)
+			}
+		})
+	}
+}
+
+func TestScheduledBackup(t *testing.T) {
+	schedule := &appsv1alpha1.APIManagerBackupSchedule{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "3scale"},
+		Spec: appsv1alpha1.APIManagerBackupScheduleSpec{
+			Schedule: "0 2 * * *",
+			BackupTemplate: appsv1alpha1.APIManagerBackupSpec{
+				BackupDestination: appsv1alpha1.APIManagerBackupDestination{
+					S3: &appsv1alpha1.S3BackupDestination{S3ObjectStorage: appsv1alpha1.S3ObjectStorage{Bucket: "backups"}},
+				},
+			},
+		},
+	}
+
+	backup := ScheduledBackup(schedule, time.Date(2023, time.January, 2, 2, 0, 0, 0, time.UTC))
+	if backup.Name != "nightly-20230102020000" || backup.Namespace != "3scale" {
+		t.Errorf("unexpected backup %s/%s", backup.Namespace, backup.Name)
+	}
+	if backup.Labels[appsv1alpha1.APIManagerBackupScheduleLabel] != "nightly" {
+		t.Errorf("unexpected labels %v", backup.Labels)
+	}
+	if !reflect.DeepEqual(backup.Spec, schedule.Spec.BackupTemplate) {
+		t.Errorf("unexpected spec %v", backup.Spec)
+	}
+	if backup.Spec.BackupDestination.S3 == schedule.Spec.BackupTemplate.BackupDestination.S3 {
+		t.Error("expected the template to be copied")
+	}
+}
+
+func TestS3PruneJob(t *testing.T) {
+	cr := &appsv1alpha1.APIManagerBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly-20230102020000", Namespace: "3scale", UID: "f5f4a1e0-3f5f-4c8e-9a53-3c1e2a1b7d2f"},
+		Spec: appsv1alpha1.APIManagerBackupSpec{
+			BackupDestination: appsv1alpha1.APIManagerBackupDestination{
+				S3: &appsv1alpha1.S3BackupDestination{S3ObjectStorage: appsv1alpha1.S3ObjectStorage{
+					Bucket:               "backups",
+					Prefix:               pointer.String("production"),
+					CredentialsSecretRef: v1.LocalObjectReference{Name: "s3-credentials"},
+				}},
+			},
+		},
+	}
+
+	job := S3PruneJob(cr, "aws-cli:latest")
+	if job == nil {
+		t.Fatal("expected prune job")
+	}
+	if job.Name != "prune-backup-f5f4a1e0-3f5f-4c8e-9a53-3c1e2a1b7d2f" {
+		t.Errorf("unexpected job name %s", job.Name)
+	}
+	container := job.Spec.Template.Spec.Containers[0]
+	if container.Image != "aws-cli:latest" {
+		t.Errorf("unexpected image %s", container.Image)
+	}
+	if script := container.Args[len(container.Args)-1]; !strings.Contains(script, `s3 rm --recursive --only-show-errors "${S3_URL}/"`) {
+		t.Errorf("unexpected script %s", script)
+	}
+	for _, envVar := range container.Env {
+		if envVar.Name == "S3_URL" && envVar.Value != "s3://backups/production/nightly-20230102020000" {
+			t.Errorf("unexpected S3_URL env var %s", envVar.Value)
+		}
+	}
+
+	cr.Spec.BackupDestination = appsv1alpha1.APIManagerBackupDestination{PersistentVolumeClaim: &appsv1alpha1.PersistentVolumeClaimBackupDestination{}}
+	if job := S3PruneJob(cr, "aws-cli:latest"); job != nil {
+		t.Errorf("expected no prune job for a PersistentVolumeClaim destination, got %s", job.Name)
+	}
+}
@@ -0,0 +1,110 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	CertificateKind = "Certificate"
+
+	IssuerKind        = "Issuer"
+	ClusterIssuerKind = "ClusterIssuer"
+
+	// CertificateConditionReady is True when the certificate stored in the secret is up to date
+	CertificateConditionReady = "Ready"
+)
+
+// CertificateSpec defines the desired state of Certificate
+type CertificateSpec struct {
+	// SecretName is the name of the secret resource that will be
+	// automatically created and managed by cert-manager
+	SecretName string `json:"secretName"`
+
+	// +optional
+	CommonName string `json:"commonName,omitempty"`
+
+	// +optional
+	DNSNames []string `json:"dnsNames,omitempty"`
+
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
+	IssuerRef ObjectReference `json:"issuerRef"`
+}
+
+// ObjectReference is a reference to a cert-manager issuer
+type ObjectReference struct {
+	Name string `json:"name"`
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// +optional
+	Group string `json:"group,omitempty"`
+}
+
+// CertificateCondition contains condition information for a Certificate
+type CertificateCondition struct {
+	Type   string                 `json:"type"`
+	Status metav1.ConditionStatus `json:"status"`
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// CertificateStatus defines the observed state of Certificate
+type CertificateStatus struct {
+	// +optional
+	Conditions []CertificateCondition `json:"conditions,omitempty"`
+
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// +optional
+	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
+
+	// Revision is incremented every time the certificate is issued
+	// +optional
+	Revision *int `json:"revision,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// Certificate is a cert-manager certificate request
+type Certificate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CertificateSpec   `json:"spec,omitempty"`
+	Status CertificateStatus `json:"status,omitempty"`
+}
+
+// IsReady returns true when the Ready condition is True
+func (c *Certificate) IsReady() bool {
+	for idx := range c.Status.Conditions {
+		if c.Status.Conditions[idx].Type == CertificateConditionReady {
+			return c.Status.Conditions[idx].Status == metav1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// +kubebuilder:object:root=true
+
+// CertificateList contains a list of Certificate
+type CertificateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Certificate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Certificate{}, &CertificateList{})
+}
@@ -0,0 +1,22 @@
+// Package v1 contains the subset of the cert-manager.io/v1 API used by the operator.
+// The upstream module is not a dependency, only the fields the operator reads
+// and writes are declared.
+// +kubebuilder:object:generate=true
+// +kubebuilder:skip
+package v1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+var (
+	// GroupVersion is group version used to register these objects
+	GroupVersion = schema.GroupVersion{Group: "cert-manager.io", Version: "v1"}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
+	SchemeBuilder = &scheme.Builder{GroupVersion: GroupVersion}
+
+	// AddToScheme adds the types in this group-version to the given scheme.
+	AddToScheme = SchemeBuilder.AddToScheme
+)
@@ -0,0 +1,104 @@
+package helper
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// AdminAPIError is returned when the 3scale admin API does not respond with the expected code
+type AdminAPIError struct {
+	code   int
+	reason string
+}
+
+func (e *AdminAPIError) Error() string {
+	return fmt.Sprintf("error calling 3scale system - reason: %s - code: %d", e.reason, e.code)
+}
+
+// IsAdminAPINotFound returns true when the 3scale admin API responded not found
+func IsAdminAPINotFound(err error) bool {
+	apiErr := &AdminAPIError{}
+	return errors.As(err, &apiErr) && apiErr.code == http.StatusNotFound
+}
+
+// IsAdminAPIUnprocessableEntity returns true when the 3scale admin API refused the request,
+// for example deleting a plan with contracts
+func IsAdminAPIUnprocessableEntity(err error) bool {
+	apiErr := &AdminAPIError{}
+	return errors.As(err, &apiErr) && apiErr.code == http.StatusUnprocessableEntity
+}
+
+// adminAPIClient calls the endpoints of the 3scale admin API the porta client does not expose
+type adminAPIClient struct {
+	adminURL   string
+	token      string
+	httpClient *http.Client
+}
+
+func newAdminAPIClient(adminURL *url.URL, token string, httpClient *http.Client) *adminAPIClient {
+	return &adminAPIClient{
+		adminURL:   strings.TrimSuffix(adminURL.String(), "/"),
+		token:      token,
+		httpClient: httpClient,
+	}
+}
+
+func (c *adminAPIClient) get(endpoint string, decodeInto interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, c.adminURL+endpoint, nil)
+	if err != nil {
+		return err
+	}
+	return c.do(req, http.StatusOK, decodeInto)
+}
+
+func (c *adminAPIClient) put(endpoint string, params url.Values, decodeInto interface{}) error {
+	req, err := http.NewRequest(http.MethodPut, c.adminURL+endpoint, strings.NewReader(params.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return c.do(req, http.StatusOK, decodeInto)
+}
+
+func (c *adminAPIClient) post(endpoint string, params url.Values, decodeInto interface{}) error {
+	req, err := http.NewRequest(http.MethodPost, c.adminURL+endpoint, strings.NewReader(params.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return c.do(req, http.StatusCreated, decodeInto)
+}
+
+func (c *adminAPIClient) delete(endpoint string) error {
+	req, err := http.NewRequest(http.MethodDelete, c.adminURL+endpoint, nil)
+	if err != nil {
+		return err
+	}
+	return c.do(req, http.StatusOK, nil)
+}
+
+func (c *adminAPIClient) do(req *http.Request, expectCode int, decodeInto interface{}) error {
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth("", c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != expectCode {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return &AdminAPIError{code: resp.StatusCode, reason: string(body)}
+	}
+
+	if decodeInto == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(decodeInto)
+}
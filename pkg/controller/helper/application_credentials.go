@@ -0,0 +1,198 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+const (
+	applicationCreate          = "/admin/api/accounts/%d/applications.json"
+	applicationCredentialsRead = "/admin/api/accounts/%d/applications/%d.json"
+	applicationKeysList        = "/admin/api/accounts/%d/applications/%d/keys.json"
+	applicationKeyCreate       = "/admin/api/accounts/%d/applications/%d/keys.json"
+	applicationKeyDelete       = "/admin/api/accounts/%d/applications/%d/keys/%s.json"
+	referrerFilterList         = "/admin/api/accounts/%d/applications/%d/referrer_filters.json"
+	referrerFilterCreate       = "/admin/api/accounts/%d/applications/%d/referrer_filters.json"
+	referrerFilterDelete       = "/admin/api/accounts/%d/applications/%d/referrer_filters/%d.json"
+)
+
+// ApplicationCredentials holds the credentials of a 3scale application.
+// Which ones are used depends on the authentication mode of the product:
+// the user key, or the application ID and keys. With OpenID Connect, the application ID
+// is the client ID and the first key is the client secret, and the redirect URL is the
+// redirect URL of the client
+type ApplicationCredentials struct {
+	UserKey         string
+	ApplicationID   string
+	ApplicationKeys []string
+	RedirectURL     string
+}
+
+// ApplicationReferrerFilter holds a referrer filter of a 3scale application
+type ApplicationReferrerFilter struct {
+	ID    int64  `json:"id"`
+	Value string `json:"value"`
+}
+
+// ApplicationCredentialsClient reads the credentials of the 3scale applications and manages
+// their keys and referrer filters from the admin API. The porta client does not expose them
+type ApplicationCredentialsClient struct {
+	*adminAPIClient
+}
+
+// NewApplicationCredentialsClient returns the credentials client for the given admin URL
+func NewApplicationCredentialsClient(adminURL *url.URL, token string, httpClient *http.Client) *ApplicationCredentialsClient {
+	return &ApplicationCredentialsClient{adminAPIClient: newAdminAPIClient(adminURL, token, httpClient)}
+}
+
+// PortaApplicationCredentialsClient returns the credentials client for the provider account
+func PortaApplicationCredentialsClient(providerAccount *ProviderAccount, insecureSkipVerify bool) (*ApplicationCredentialsClient, error) {
+	adminURL, err := url.Parse(providerAccount.AdminURLStr)
+	if err != nil {
+		return nil, err
+	}
+	return NewApplicationCredentialsClient(adminURL, providerAccount.Token, portaHTTPClient(insecureSkipVerify)), nil
+}
+
+// ApplicationCredentials returns the credentials of the application. The application keys
+// are only read when the application has an application ID
+func (c *ApplicationCredentialsClient) ApplicationCredentials(accountID, applicationID int64) (*ApplicationCredentials, error) {
+	application := struct {
+		Application struct {
+			UserKey       string `json:"user_key"`
+			ApplicationID string `json:"application_id"`
+			RedirectURL   string `json:"redirect_url"`
+		} `json:"application"`
+	}{}
+	err := c.get(fmt.Sprintf(applicationCredentialsRead, accountID, applicationID), &application)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials := &ApplicationCredentials{
+		UserKey:         application.Application.UserKey,
+		ApplicationID:   application.Application.ApplicationID,
+		ApplicationKeys: []string{},
+		RedirectURL:     application.Application.RedirectURL,
+	}
+	if credentials.ApplicationID == "" {
+		return credentials, nil
+	}
+
+	credentials.ApplicationKeys, err = c.ApplicationKeys(accountID, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials, nil
+}
+
+// CreateApplication creates the application with the given params, like the user key or
+// the application ID. The porta client only creates applications with name and description
+func (c *ApplicationCredentialsClient) CreateApplication(accountID, planID int64, name, description string, params url.Values) (*threescaleapi.Application, error) {
+	values := url.Values{}
+	for key, value := range params {
+		values[key] = value
+	}
+	values.Set("plan_id", strconv.FormatInt(planID, 10))
+	values.Set("name", name)
+	values.Set("description", description)
+
+	req, err := http.NewRequest(http.MethodPost, c.adminURL+fmt.Sprintf(applicationCreate, accountID), strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	application := &threescaleapi.ApplicationElem{}
+	err = c.do(req, http.StatusCreated, application)
+	if err != nil {
+		return nil, err
+	}
+	return &application.Application, nil
+}
+
+// ApplicationKeys returns the keys of the application
+func (c *ApplicationCredentialsClient) ApplicationKeys(accountID, applicationID int64) ([]string, error) {
+	keys := struct {
+		Keys []struct {
+			Key struct {
+				Value string `json:"value"`
+			} `json:"key"`
+		} `json:"keys"`
+	}{}
+	err := c.get(fmt.Sprintf(applicationKeysList, accountID, applicationID), &keys)
+	if err != nil {
+		return nil, err
+	}
+
+	values := []string{}
+	for _, key := range keys.Keys {
+		values = append(values, key.Key.Value)
+	}
+	return values, nil
+}
+
+// CreateApplicationKey adds the key to the application
+func (c *ApplicationCredentialsClient) CreateApplicationKey(accountID, applicationID int64, key string) error {
+	body := url.Values{"key": []string{key}}.Encode()
+	req, err := http.NewRequest(http.MethodPost, c.adminURL+fmt.Sprintf(applicationKeyCreate, accountID, applicationID), strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return c.do(req, http.StatusCreated, nil)
+}
+
+// DeleteApplicationKey removes the key from the application
+func (c *ApplicationCredentialsClient) DeleteApplicationKey(accountID, applicationID int64, key string) error {
+	req, err := http.NewRequest(http.MethodDelete, c.adminURL+fmt.Sprintf(applicationKeyDelete, accountID, applicationID, url.PathEscape(key)), nil)
+	if err != nil {
+		return err
+	}
+	return c.do(req, http.StatusOK, nil)
+}
+
+// ReferrerFilters returns the referrer filters of the application
+func (c *ApplicationCredentialsClient) ReferrerFilters(accountID, applicationID int64) ([]ApplicationReferrerFilter, error) {
+	filters := struct {
+		ReferrerFilters []struct {
+			ReferrerFilter ApplicationReferrerFilter `json:"referrer_filter"`
+		} `json:"referrer_filters"`
+	}{}
+	err := c.get(fmt.Sprintf(referrerFilterList, accountID, applicationID), &filters)
+	if err != nil {
+		return nil, err
+	}
+
+	values := []ApplicationReferrerFilter{}
+	for _, filter := range filters.ReferrerFilters {
+		values = append(values, filter.ReferrerFilter)
+	}
+	return values, nil
+}
+
+// CreateReferrerFilter adds the referrer filter to the application
+func (c *ApplicationCredentialsClient) CreateReferrerFilter(accountID, applicationID int64, value string) error {
+	body := url.Values{"referrer_filter": []string{value}}.Encode()
+	req, err := http.NewRequest(http.MethodPost, c.adminURL+fmt.Sprintf(referrerFilterCreate, accountID, applicationID), strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return c.do(req, http.StatusCreated, nil)
+}
+
+// DeleteReferrerFilter removes the referrer filter from the application
+func (c *ApplicationCredentialsClient) DeleteReferrerFilter(accountID, applicationID, filterID int64) error {
+	req, err := http.NewRequest(http.MethodDelete, c.adminURL+fmt.Sprintf(referrerFilterDelete, accountID, applicationID, filterID), nil)
+	if err != nil {
+		return err
+	}
+	return c.do(req, http.StatusOK, nil)
+}
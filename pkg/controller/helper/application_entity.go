@@ -1,6 +1,7 @@
 package helper
 
 import (
+	capabilitiesv1beta1 "github.com/3scale/3scale-operator/apis/capabilities/v1beta1"
 	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
 	"github.com/go-logr/logr"
 )
@@ -10,7 +11,10 @@ type ApplicationEntity struct {
 	ApplicationObj  *threescaleapi.Application
 	ApplicationList *threescaleapi.ApplicationList
 	*threescaleapi.ApplicationPlanJSONList
-	logger logr.Logger
+	keysStatus              []capabilitiesv1beta1.ApplicationKeyStatus
+	planCustomization       *capabilitiesv1beta1.ApplicationPlanCustomizationStatus
+	planCustomizationSynced bool
+	logger                  logr.Logger
 }
 
 func NewApplicationEntity(ApplicationObj *threescaleapi.Application, client *threescaleapi.ThreeScaleClient, logger logr.Logger) *ApplicationEntity {
@@ -48,3 +52,36 @@ func (b *ApplicationEntity) ProductId() int64 {
 func (b *ApplicationEntity) ApplicationState() string {
 	return b.ApplicationObj.State
 }
+
+// KeysStatus returns the status of the application keys managed by the operator.
+// Nil when the keys have not been synced
+func (b *ApplicationEntity) KeysStatus() []capabilitiesv1beta1.ApplicationKeyStatus {
+	return b.keysStatus
+}
+
+func (b *ApplicationEntity) SetKeysStatus(keysStatus []capabilitiesv1beta1.ApplicationKeyStatus) {
+	b.keysStatus = keysStatus
+}
+
+func (b *ApplicationEntity) UserKey() string {
+	return b.ApplicationObj.UserKey
+}
+
+// PlanCustomization returns the status of the customized plan of the application, and
+// whether the plan customization has been synced. Nil when the plan is not customized
+func (b *ApplicationEntity) PlanCustomization() (*capabilitiesv1beta1.ApplicationPlanCustomizationStatus, bool) {
+	return b.planCustomization, b.planCustomizationSynced
+}
+
+func (b *ApplicationEntity) SetPlanCustomization(planCustomization *capabilitiesv1beta1.ApplicationPlanCustomizationStatus) {
+	b.planCustomization = planCustomization
+	b.planCustomizationSynced = true
+}
+
+func (b *ApplicationEntity) SetPlanID(planID int64) {
+	b.ApplicationObj.PlanID = planID
+}
+
+func (b *ApplicationEntity) SetApplicationState(state string) {
+	b.ApplicationObj.State = state
+}
@@ -0,0 +1,70 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+)
+
+const (
+	applicationAccept           = "/admin/api/accounts/%d/applications/%d/accept.json"
+	developerAccountApprove     = "/admin/api/accounts/%d/approve.json"
+	developerAccountReject      = "/admin/api/accounts/%d/reject.json"
+	developerAccountMakePending = "/admin/api/accounts/%d/make_pending.json"
+)
+
+// ApprovalClient accepts the 3scale applications, and approves or rejects the developer accounts,
+// pending of approval. The porta client does not expose them
+type ApprovalClient struct {
+	*adminAPIClient
+}
+
+// NewApprovalClient returns the approval client for the given admin URL
+func NewApprovalClient(adminURL *url.URL, token string, httpClient *http.Client) *ApprovalClient {
+	return &ApprovalClient{adminAPIClient: newAdminAPIClient(adminURL, token, httpClient)}
+}
+
+// PortaApprovalClient returns the approval client for the provider account
+func PortaApprovalClient(providerAccount *ProviderAccount, insecureSkipVerify bool) (*ApprovalClient, error) {
+	adminURL, err := url.Parse(providerAccount.AdminURLStr)
+	if err != nil {
+		return nil, err
+	}
+	return NewApprovalClient(adminURL, providerAccount.Token, portaHTTPClient(insecureSkipVerify)), nil
+}
+
+// AcceptApplication accepts the application pending of approval
+func (c *ApprovalClient) AcceptApplication(accountID, applicationID int64) (*threescaleapi.Application, error) {
+	application := &threescaleapi.ApplicationElem{}
+	err := c.put(fmt.Sprintf(applicationAccept, accountID, applicationID), nil, application)
+	if err != nil {
+		return nil, err
+	}
+	return &application.Application, nil
+}
+
+// ApproveDeveloperAccount approves the developer account
+func (c *ApprovalClient) ApproveDeveloperAccount(accountID int64) (*threescaleapi.DeveloperAccount, error) {
+	return c.developerAccountTransition(developerAccountApprove, accountID)
+}
+
+// RejectDeveloperAccount rejects the developer account
+func (c *ApprovalClient) RejectDeveloperAccount(accountID int64) (*threescaleapi.DeveloperAccount, error) {
+	return c.developerAccountTransition(developerAccountReject, accountID)
+}
+
+// MakeDeveloperAccountPending sets the developer account back to pending of approval
+func (c *ApprovalClient) MakeDeveloperAccountPending(accountID int64) (*threescaleapi.DeveloperAccount, error) {
+	return c.developerAccountTransition(developerAccountMakePending, accountID)
+}
+
+func (c *ApprovalClient) developerAccountTransition(endpoint string, accountID int64) (*threescaleapi.DeveloperAccount, error) {
+	account := &threescaleapi.DeveloperAccount{}
+	err := c.put(fmt.Sprintf(endpoint, accountID), nil, account)
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
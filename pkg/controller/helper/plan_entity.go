@@ -0,0 +1,89 @@
+package helper
+
+import (
+	"fmt"
+	"net/url"
+
+	threescaleapi "github.com/3scale/3scale-porta-go-client/client"
+
+	"github.com/go-logr/logr"
+)
+
+// PlanEntity is the remote service plan or account plan
+type PlanEntity struct {
+	obj    PlanItem
+	kind   string
+	update func(params url.Values) (*PlanItem, error)
+	logger logr.Logger
+}
+
+func NewServicePlanEntity(productID int64, obj PlanItem, cl *PlansClient, logger logr.Logger) *PlanEntity {
+	return &PlanEntity{
+		obj:  obj,
+		kind: fmt.Sprintf("product [%d] service plan", productID),
+		update: func(params url.Values) (*PlanItem, error) {
+			return cl.UpdateServicePlan(productID, obj.ID, params)
+		},
+		logger: logger.WithValues("ServicePlanEntity", obj.ID),
+	}
+}
+
+func NewAccountPlanEntity(obj PlanItem, cl *PlansClient, logger logr.Logger) *PlanEntity {
+	return &PlanEntity{
+		obj:  obj,
+		kind: "account plan",
+		update: func(params url.Values) (*PlanItem, error) {
+			return cl.UpdateAccountPlan(obj.ID, params)
+		},
+		logger: logger.WithValues("AccountPlanEntity", obj.ID),
+	}
+}
+
+func (b *PlanEntity) ID() int64 {
+	return b.obj.ID
+}
+
+func (b *PlanEntity) Name() string {
+	return b.obj.Name
+}
+
+func (b *PlanEntity) SystemName() string {
+	return b.obj.SystemName
+}
+
+func (b *PlanEntity) ApprovalRequired() bool {
+	return b.obj.ApprovalRequired
+}
+
+func (b *PlanEntity) TrialPeriodDays() int {
+	return b.obj.TrialPeriodDays
+}
+
+func (b *PlanEntity) SetupFee() float64 {
+	return b.obj.SetupFee
+}
+
+func (b *PlanEntity) CostPerMonth() float64 {
+	return b.obj.CostPerMonth
+}
+
+func (b *PlanEntity) State() string {
+	return b.obj.State
+}
+
+func (b *PlanEntity) Update(params threescaleapi.Params) error {
+	b.logger.V(1).Info("Update", "params", params)
+	values := url.Values{}
+	for key, value := range params {
+		values.Set(key, value)
+	}
+
+	updated, err := b.update(values)
+	if err != nil {
+		return fmt.Errorf("%s [%s] update: %w", b.kind, b.obj.SystemName, err)
+	}
+
+	b.obj = *updated
+
+	return nil
+}
@@ -0,0 +1,208 @@
+package helper
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	servicePlanList               = "/admin/api/services/%d/service_plans.json"
+	servicePlanCreate             = "/admin/api/services/%d/service_plans.json"
+	servicePlanUpdate             = "/admin/api/services/%d/service_plans/%d.json"
+	servicePlanDelete             = "/admin/api/services/%d/service_plans/%d.json"
+	accountPlanList               = "/admin/api/account_plans.json"
+	accountPlanCreate             = "/admin/api/account_plans.json"
+	accountPlanUpdate             = "/admin/api/account_plans/%d.json"
+	accountPlanDelete             = "/admin/api/account_plans/%d.json"
+	developerAccountPlanRead      = "/admin/api/accounts/%d/plan.json"
+	developerAccountChangePlan    = "/admin/api/accounts/%d/change_plan.json"
+	serviceSubscriptionList       = "/admin/api/accounts/%d/service_contracts.json"
+	serviceSubscriptionCreate     = "/admin/api/accounts/%d/service_subscriptions.json"
+	serviceSubscriptionChangePlan = "/admin/api/accounts/%d/service_subscriptions/%d/change_plan.json"
+)
+
+// PlanItem is a service plan or an account plan
+type PlanItem struct {
+	ID               int64   `json:"id"`
+	Name             string  `json:"name"`
+	SystemName       string  `json:"system_name"`
+	State            string  `json:"state"`
+	ApprovalRequired bool    `json:"approval_required"`
+	TrialPeriodDays  int     `json:"trial_period_days"`
+	SetupFee         float64 `json:"setup_fee"`
+	CostPerMonth     float64 `json:"cost_per_month"`
+}
+
+// ServiceSubscription is the subscription of a developer account to a service plan of a product
+type ServiceSubscription struct {
+	ID     int64  `json:"id"`
+	PlanID int64  `json:"plan_id"`
+	State  string `json:"state"`
+}
+
+type servicePlanJSONList struct {
+	Plans []servicePlan `json:"plans"`
+}
+
+type servicePlan struct {
+	Plan PlanItem `json:"service_plan"`
+}
+
+type accountPlanJSONList struct {
+	Plans []accountPlan `json:"plans"`
+}
+
+type accountPlan struct {
+	Plan PlanItem `json:"account_plan"`
+}
+
+type serviceSubscription struct {
+	Subscription ServiceSubscription `json:"service_contract"`
+}
+
+// PlansClient manages the service plans, the account plans and the plans of the developer accounts.
+// The porta client does not expose them
+type PlansClient struct {
+	*adminAPIClient
+}
+
+// NewPlansClient returns the plans client for the given admin URL
+func NewPlansClient(adminURL *url.URL, token string, httpClient *http.Client) *PlansClient {
+	return &PlansClient{adminAPIClient: newAdminAPIClient(adminURL, token, httpClient)}
+}
+
+// PortaPlansClient returns the plans client for the provider account
+func PortaPlansClient(providerAccount *ProviderAccount, insecureSkipVerify bool) (*PlansClient, error) {
+	adminURL, err := url.Parse(providerAccount.AdminURLStr)
+	if err != nil {
+		return nil, err
+	}
+	return NewPlansClient(adminURL, providerAccount.Token, portaHTTPClient(insecureSkipVerify)), nil
+}
+
+// ServicePlans returns the service plans of the product
+func (c *PlansClient) ServicePlans(productID int64) ([]PlanItem, error) {
+	list := &servicePlanJSONList{}
+	err := c.get(fmt.Sprintf(servicePlanList, productID), list)
+	if err != nil {
+		return nil, err
+	}
+
+	plans := make([]PlanItem, 0, len(list.Plans))
+	for _, item := range list.Plans {
+		plans = append(plans, item.Plan)
+	}
+	return plans, nil
+}
+
+// CreateServicePlan creates the service plan in the product
+func (c *PlansClient) CreateServicePlan(productID int64, params url.Values) (*PlanItem, error) {
+	plan := &servicePlan{}
+	err := c.post(fmt.Sprintf(servicePlanCreate, productID), params, plan)
+	if err != nil {
+		return nil, err
+	}
+	return &plan.Plan, nil
+}
+
+// UpdateServicePlan updates the service plan of the product
+func (c *PlansClient) UpdateServicePlan(productID, planID int64, params url.Values) (*PlanItem, error) {
+	plan := &servicePlan{}
+	err := c.put(fmt.Sprintf(servicePlanUpdate, productID, planID), params, plan)
+	if err != nil {
+		return nil, err
+	}
+	return &plan.Plan, nil
+}
+
+// DeleteServicePlan deletes the service plan of the product
+func (c *PlansClient) DeleteServicePlan(productID, planID int64) error {
+	return c.delete(fmt.Sprintf(servicePlanDelete, productID, planID))
+}
+
+// AccountPlans returns the account plans of the provider account
+func (c *PlansClient) AccountPlans() ([]PlanItem, error) {
+	list := &accountPlanJSONList{}
+	err := c.get(accountPlanList, list)
+	if err != nil {
+		return nil, err
+	}
+
+	plans := make([]PlanItem, 0, len(list.Plans))
+	for _, item := range list.Plans {
+		plans = append(plans, item.Plan)
+	}
+	return plans, nil
+}
+
+// CreateAccountPlan creates the account plan
+func (c *PlansClient) CreateAccountPlan(params url.Values) (*PlanItem, error) {
+	plan := &accountPlan{}
+	err := c.post(accountPlanCreate, params, plan)
+	if err != nil {
+		return nil, err
+	}
+	return &plan.Plan, nil
+}
+
+// UpdateAccountPlan updates the account plan
+func (c *PlansClient) UpdateAccountPlan(planID int64, params url.Values) (*PlanItem, error) {
+	plan := &accountPlan{}
+	err := c.put(fmt.Sprintf(accountPlanUpdate, planID), params, plan)
+	if err != nil {
+		return nil, err
+	}
+	return &plan.Plan, nil
+}
+
+// DeleteAccountPlan deletes the account plan
+func (c *PlansClient) DeleteAccountPlan(planID int64) error {
+	return c.delete(fmt.Sprintf(accountPlanDelete, planID))
+}
+
+// DeveloperAccountPlan returns the account plan of the developer account
+func (c *PlansClient) DeveloperAccountPlan(accountID int64) (*PlanItem, error) {
+	plan := &accountPlan{}
+	err := c.get(fmt.Sprintf(developerAccountPlanRead, accountID), plan)
+	if err != nil {
+		return nil, err
+	}
+	return &plan.Plan, nil
+}
+
+// ChangeDeveloperAccountPlan changes the account plan of the developer account
+func (c *PlansClient) ChangeDeveloperAccountPlan(accountID, planID int64) error {
+	params := url.Values{"plan_id": []string{strconv.FormatInt(planID, 10)}}
+	return c.put(fmt.Sprintf(developerAccountChangePlan, accountID), params, nil)
+}
+
+// ServiceSubscriptions returns the service subscriptions of the developer account
+func (c *PlansClient) ServiceSubscriptions(accountID int64) ([]ServiceSubscription, error) {
+	list := struct {
+		Subscriptions []serviceSubscription `json:"service_contracts"`
+	}{}
+	err := c.get(fmt.Sprintf(serviceSubscriptionList, accountID), &list)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions := make([]ServiceSubscription, 0, len(list.Subscriptions))
+	for _, item := range list.Subscriptions {
+		subscriptions = append(subscriptions, item.Subscription)
+	}
+	return subscriptions, nil
+}
+
+// CreateServiceSubscription subscribes the developer account to the service plan
+func (c *PlansClient) CreateServiceSubscription(accountID, planID int64) error {
+	params := url.Values{"plan_id": []string{strconv.FormatInt(planID, 10)}}
+	return c.post(fmt.Sprintf(serviceSubscriptionCreate, accountID), params, nil)
+}
+
+// ChangeServiceSubscriptionPlan changes the service plan of the service subscription
+func (c *PlansClient) ChangeServiceSubscriptionPlan(accountID, subscriptionID, planID int64) error {
+	params := url.Values{"plan_id": []string{strconv.FormatInt(planID, 10)}}
+	return c.put(fmt.Sprintf(serviceSubscriptionChangePlan, accountID, subscriptionID), params, nil)
+}
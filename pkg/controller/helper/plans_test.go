@@ -0,0 +1,121 @@
+package helper
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+// plansRoundTripFunc serves the plans endpoints, recording the requests along with their form params
+func plansRoundTripFunc(t *testing.T, requests *[]string) RoundTripFunc {
+	return func(req *http.Request) *http.Response {
+		var (
+			statusCode = http.StatusOK
+			body       = ""
+		)
+
+		switch req.Method + " " + req.URL.Path {
+		case "GET /admin/api/services/3/service_plans.json":
+			body = `{"plans":[{"service_plan":{"id":20,"system_name":"default","name":"Default","state":"published"}},{"service_plan":{"id":21,"system_name":"basic","setup_fee":1.5}}]}`
+		case "POST /admin/api/services/3/service_plans.json":
+			statusCode = http.StatusCreated
+			body = `{"service_plan":{"id":22,"system_name":"gold","name":"gold","state":"hidden"}}`
+		case "PUT /admin/api/services/3/service_plans/22.json":
+			body = `{"service_plan":{"id":22,"system_name":"gold","name":"Gold","state":"hidden"}}`
+		case "GET /admin/api/account_plans.json":
+			body = `{"plans":[{"account_plan":{"id":10,"system_name":"default","approval_required":true}}]}`
+		case "POST /admin/api/account_plans.json":
+			statusCode = http.StatusCreated
+			body = `{"account_plan":{"id":11,"system_name":"gold","name":"gold","state":"hidden"}}`
+		case "PUT /admin/api/account_plans/11.json":
+			body = `{"account_plan":{"id":11,"system_name":"gold","name":"gold","state":"published"}}`
+		case "DELETE /admin/api/services/3/service_plans/22.json", "DELETE /admin/api/account_plans/11.json":
+		default:
+			statusCode = http.StatusNotFound
+			body = `{"status":"Not found"}`
+		}
+
+		if req.Method != http.MethodGet {
+			request := req.Method + " " + req.URL.Path
+			if req.Body != nil {
+				data, err := ioutil.ReadAll(req.Body)
+				ok(t, err)
+				request += " " + string(data)
+			}
+			*requests = append(*requests, request)
+		}
+
+		return &http.Response{
+			StatusCode: statusCode,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			Header:     make(http.Header),
+		}
+	}
+}
+
+func TestPlansClientServicePlans(t *testing.T) {
+	adminURL, err := url.Parse("https://www.test.com:443/")
+	ok(t, err)
+	requests := []string{}
+	client := NewPlansClient(adminURL, "12345", NewTestClient(plansRoundTripFunc(t, &requests)))
+
+	plans, err := client.ServicePlans(3)
+	ok(t, err)
+	equals(t, []PlanItem{
+		{ID: 20, SystemName: "default", Name: "Default", State: "published"},
+		{ID: 21, SystemName: "basic", SetupFee: 1.5},
+	}, plans)
+
+	plan, err := client.CreateServicePlan(3, url.Values{"system_name": []string{"gold"}})
+	ok(t, err)
+	equals(t, &PlanItem{ID: 22, SystemName: "gold", Name: "gold", State: "hidden"}, plan)
+
+	plan, err = client.UpdateServicePlan(3, 22, url.Values{"name": []string{"Gold"}})
+	ok(t, err)
+	equals(t, "Gold", plan.Name)
+
+	ok(t, client.DeleteServicePlan(3, 22))
+
+	err = client.DeleteServicePlan(3, 23)
+	assert(t, IsAdminAPINotFound(err), "expected not found error, got %v", err)
+
+	equals(t, []string{
+		"POST /admin/api/services/3/service_plans.json system_name=gold",
+		"PUT /admin/api/services/3/service_plans/22.json name=Gold",
+		"DELETE /admin/api/services/3/service_plans/22.json",
+		"DELETE /admin/api/services/3/service_plans/23.json",
+	}, requests)
+}
+
+func TestPlansClientAccountPlans(t *testing.T) {
+	adminURL, err := url.Parse("https://www.test.com:443")
+	ok(t, err)
+	requests := []string{}
+	client := NewPlansClient(adminURL, "12345", NewTestClient(plansRoundTripFunc(t, &requests)))
+
+	plans, err := client.AccountPlans()
+	ok(t, err)
+	equals(t, []PlanItem{{ID: 10, SystemName: "default", ApprovalRequired: true}}, plans)
+
+	plan, err := client.CreateAccountPlan(url.Values{"system_name": []string{"gold"}})
+	ok(t, err)
+	equals(t, &PlanItem{ID: 11, SystemName: "gold", Name: "gold", State: "hidden"}, plan)
+
+	plan, err = client.UpdateAccountPlan(11, url.Values{"state_event": []string{"publish"}})
+	ok(t, err)
+	equals(t, "published", plan.State)
+
+	ok(t, client.DeleteAccountPlan(11))
+
+	err = client.DeleteAccountPlan(12)
+	assert(t, IsAdminAPINotFound(err), "expected not found error, got %v", err)
+
+	equals(t, []string{
+		"POST /admin/api/account_plans.json system_name=gold",
+		"PUT /admin/api/account_plans/11.json state_event=publish",
+		"DELETE /admin/api/account_plans/11.json",
+		"DELETE /admin/api/account_plans/12.json",
+	}, requests)
+}
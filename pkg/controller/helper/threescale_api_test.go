@@ -32,3 +32,9 @@ func TestPortaClientFromURL(t *testing.T) {
 	_, err := PortaClientFromURL(url, "some token", false)
 	assert(t, err != nil, "error should not be nil")
 }
+
+func TestPortaApplicationCredentialsClientInvalidURL(t *testing.T) {
+	providerAccount := &ProviderAccount{AdminURLStr: ":foo", Token: "some token"}
+	_, err := PortaApplicationCredentialsClient(providerAccount, false)
+	assert(t, err != nil, "error should not be nil")
+}
@@ -11,26 +11,60 @@ const numericCharset = "0123456789"
 const alphanumericCharset = lowercaseAlphabetCharset + uppercaseAlphabetCharset + numericCharset
 const hexadecimalCharset = numericCharset + "ABCDEF"
 
-var randomGenerator *rand.Rand = rand.New(rand.NewSource(time.Now().UTC().UnixNano()))
+var defaultGenerator = NewGenerator(time.Now().UTC().UnixNano())
+
+// Generator generates random strings from its own source.
+// Generators created with the same seed generate the same strings
+type Generator struct {
+	randomGenerator *rand.Rand
+}
+
+// NewGenerator returns a generator initialized with the provided seed
+func NewGenerator(seed int64) *Generator {
+	return &Generator{randomGenerator: rand.New(rand.NewSource(seed))}
+}
+
+// DefaultGenerator returns the generator used by the package functions,
+// initialized with the time the process started
+func DefaultGenerator() *Generator {
+	return defaultGenerator
+}
 
 // String generates random alphanumeric string of size 'size'.
 func String(length int) string {
-	return StringWithCharset(length, alphanumericCharset)
+	return defaultGenerator.String(length)
 }
 
 // HexadecimalString generates random hexadecimal strings of size 'size'
 func HexadecimalString(length int) string {
-	return StringWithCharset(length, hexadecimalCharset)
+	return defaultGenerator.HexadecimalString(length)
 }
 
 // StringWithCharset generates random string of length 'length' with all of its
 // random characters existing in and only in the 'charset' set of
 // strings
 func StringWithCharset(length int, charset string) string {
+	return defaultGenerator.StringWithCharset(length, charset)
+}
+
+// String generates random alphanumeric string of size 'size'.
+func (g *Generator) String(length int) string {
+	return g.StringWithCharset(length, alphanumericCharset)
+}
+
+// HexadecimalString generates random hexadecimal strings of size 'size'
+func (g *Generator) HexadecimalString(length int) string {
+	return g.StringWithCharset(length, hexadecimalCharset)
+}
+
+// StringWithCharset generates random string of length 'length' with all of its
+// random characters existing in and only in the 'charset' set of
+// strings
+func (g *Generator) StringWithCharset(length int, charset string) string {
 	result := make([]byte, length)
 
 	for i := range result {
-		result[i] = charset[randomGenerator.Int63()%int64(len(charset))]
+		result[i] = charset[g.randomGenerator.Int63()%int64(len(charset))]
 	}
 
 	return string(result)
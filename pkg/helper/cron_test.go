@@ -0,0 +1,82 @@
+package helper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	from := time.Date(2023, time.January, 30, 10, 17, 42, 0, time.UTC) // Monday
+
+	cases := []struct {
+		name     string
+		spec     string
+		expected time.Time
+	}{
+		{"everyMinute", "* * * * *", time.Date(2023, time.January, 30, 10, 18, 0, 0, time.UTC)},
+		{"daily", "30 2 * * *", time.Date(2023, time.January, 31, 2, 30, 0, 0, time.UTC)},
+		{"dailyMacro", "@daily", time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC)},
+		{"step", "*/15 * * * *", time.Date(2023, time.January, 30, 10, 30, 0, 0, time.UTC)},
+		{"startStep", "5/20 * * * *", time.Date(2023, time.January, 30, 10, 25, 0, 0, time.UTC)},
+		{"list", "0 9,18 * * *", time.Date(2023, time.January, 30, 18, 0, 0, 0, time.UTC)},
+		{"weekdayNames", "0 1 * * sat,sun", time.Date(2023, time.February, 4, 1, 0, 0, 0, time.UTC)},
+		{"sunday7", "0 1 * * 7", time.Date(2023, time.February, 5, 1, 0, 0, 0, time.UTC)},
+		{"monthly", "0 0 1 * *", time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"monthNames", "0 0 1 jun-aug *", time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		{"endOfMonth", "0 0 31 * *", time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC)},
+		{"domOrDow", "0 0 15 * fri", time.Date(2023, time.February, 3, 0, 0, 0, 0, time.UTC)},
+		{"never", "0 0 30 2 *", time.Time{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			schedule, err := ParseCronSchedule(tc.spec)
+			if err != nil {
+				subT.Fatalf("unexpected error: %v", err)
+			}
+			if next := schedule.Next(from); !next.Equal(tc.expected) {
+				subT.Errorf("expected %s, got %s", tc.expected, next)
+			}
+		})
+	}
+}
+
+func TestCronSchedulePrev(t *testing.T) {
+	schedule, err := ParseCronSchedule("0 */6 * * *")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	start := time.Date(2023, time.January, 30, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2023, time.January, 31, 13, 0, 0, 0, time.UTC)
+	expected := time.Date(2023, time.January, 31, 12, 0, 0, 0, time.UTC)
+	if prev := schedule.Prev(start, now); !prev.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, prev)
+	}
+
+	if prev := schedule.Prev(expected, now); !prev.IsZero() {
+		t.Errorf("expected no time, got %s", prev)
+	}
+}
+
+func TestParseCronScheduleErrors(t *testing.T) {
+	cases := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"10-5 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+	}
+
+	for _, spec := range cases {
+		if _, err := ParseCronSchedule(spec); err == nil {
+			t.Errorf("expected error parsing '%s'", spec)
+		}
+	}
+}
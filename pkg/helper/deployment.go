@@ -0,0 +1,273 @@
+package helper
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DeploymentPreHookJobSuffix is appended to the Deployment name to build the name of the job
+	// running the DeploymentConfig pre lifecycle hook
+	DeploymentPreHookJobSuffix = "-pre-hook"
+	// DeploymentPreHookTemplateHashAnnotation records the hash of the Deployment pod template
+	// the pre hook job was run for
+	DeploymentPreHookTemplateHashAnnotation = "apps.3scale.net/pre-hook-template-hash"
+)
+
+// IsDeploymentAvailable returns true when the provided Deployment
+// has the "Available" condition set to true and all replicas are up to date
+func IsDeploymentAvailable(d *k8sappsv1.Deployment) bool {
+	if d.Status.ObservedGeneration < d.Generation {
+		return false
+	}
+
+	if d.Spec.Replicas != nil && d.Status.UpdatedReplicas < *d.Spec.Replicas {
+		return false
+	}
+
+	for _, condition := range d.Status.Conditions {
+		if condition.Type == k8sappsv1.DeploymentAvailable && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+func IsDeploymentDeleting(d *k8sappsv1.Deployment) bool {
+	return d.GetDeletionTimestamp() != nil
+}
+
+// DeploymentFromDeploymentConfig builds the Kubernetes Deployment equivalent to the
+// provided DeploymentConfig.
+// Container images are resolved from the image change triggers: images maps
+// image stream names to the image URLs to be deployed.
+// The rolling strategy post lifecycle hook is translated to a postStart lifecycle handler
+// of the hook container. The pre lifecycle hook is run as a job, see DeploymentPreHookJob
+func DeploymentFromDeploymentConfig(dc *appsv1.DeploymentConfig, images map[string]string) (*k8sappsv1.Deployment, error) {
+	// Only name, namespace, labels, annotations and owner references are part of the desired state
+	dcObjectMeta := dc.ObjectMeta.DeepCopy()
+	objectMeta := metav1.ObjectMeta{
+		Name:            dcObjectMeta.Name,
+		Namespace:       dcObjectMeta.Namespace,
+		Labels:          dcObjectMeta.Labels,
+		Annotations:     dcObjectMeta.Annotations,
+		OwnerReferences: dcObjectMeta.OwnerReferences,
+	}
+
+	selector := map[string]string{}
+	for k, v := range dc.Spec.Selector {
+		selector[k] = v
+	}
+
+	deployment := &k8sappsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: objectMeta,
+		Spec: k8sappsv1.DeploymentSpec{
+			Replicas:             &[]int32{dc.Spec.Replicas}[0],
+			Selector:             &metav1.LabelSelector{MatchLabels: selector},
+			Strategy:             DeploymentStrategyFromDeploymentConfigStrategy(dc.Spec.Strategy),
+			MinReadySeconds:      dc.Spec.MinReadySeconds,
+			RevisionHistoryLimit: dc.Spec.RevisionHistoryLimit,
+			Paused:               dc.Spec.Paused,
+		},
+	}
+
+	if dc.Spec.Template != nil {
+		deployment.Spec.Template = *dc.Spec.Template.DeepCopy()
+	}
+
+	for _, trigger := range dc.Spec.Triggers {
+		if trigger.Type != appsv1.DeploymentTriggerOnImageChange || trigger.ImageChangeParams == nil {
+			continue
+		}
+
+		imageStreamName := strings.SplitN(trigger.ImageChangeParams.From.Name, ":", 2)[0]
+		image, ok := images[imageStreamName]
+		if !ok {
+			return nil, fmt.Errorf("DeploymentConfig '%s': image for imagestream '%s' not found", dc.Name, imageStreamName)
+		}
+
+		for _, containerName := range trigger.ImageChangeParams.ContainerNames {
+			setPodSpecContainerImage(&deployment.Spec.Template.Spec, containerName, image)
+		}
+	}
+
+	if dc.Spec.Strategy.RollingParams != nil {
+		if err := addPostHookHandler(deployment, dc.Spec.Strategy.RollingParams.Post); err != nil {
+			return nil, fmt.Errorf("DeploymentConfig '%s': %w", dc.Name, err)
+		}
+	}
+
+	return deployment, nil
+}
+
+// DeploymentStrategyFromDeploymentConfigStrategy returns the Deployment strategy equivalent
+// to the provided DeploymentConfig strategy. Custom strategies are deployed as rolling updates.
+func DeploymentStrategyFromDeploymentConfigStrategy(strategy appsv1.DeploymentStrategy) k8sappsv1.DeploymentStrategy {
+	if strategy.Type == appsv1.DeploymentStrategyTypeRecreate {
+		return k8sappsv1.DeploymentStrategy{Type: k8sappsv1.RecreateDeploymentStrategyType}
+	}
+
+	result := k8sappsv1.DeploymentStrategy{
+		Type:          k8sappsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &k8sappsv1.RollingUpdateDeployment{},
+	}
+
+	if strategy.RollingParams != nil {
+		result.RollingUpdate.MaxUnavailable = strategy.RollingParams.MaxUnavailable
+		result.RollingUpdate.MaxSurge = strategy.RollingParams.MaxSurge
+	}
+
+	return result
+}
+
+func setPodSpecContainerImage(podSpec *corev1.PodSpec, containerName, image string) {
+	for idx := range podSpec.InitContainers {
+		if podSpec.InitContainers[idx].Name == containerName {
+			podSpec.InitContainers[idx].Image = image
+		}
+	}
+
+	for idx := range podSpec.Containers {
+		if podSpec.Containers[idx].Name == containerName {
+			podSpec.Containers[idx].Image = image
+		}
+	}
+}
+
+func findPodSpecContainer(podSpec *corev1.PodSpec, containerName string) *corev1.Container {
+	for idx := range podSpec.Containers {
+		if podSpec.Containers[idx].Name == containerName {
+			return &podSpec.Containers[idx]
+		}
+	}
+	return nil
+}
+
+// DeploymentPreHookJob returns the job running the DeploymentConfig pre lifecycle hook
+// for the pod template of the equivalent Deployment. Nil when there is no pre hook.
+// The job is annotated with the pod template hash, so the hook runs once per pod template,
+// and its pods get the provided labels instead of the pod template ones,
+// not to be selected by the Deployment services
+func DeploymentPreHookJob(deployment *k8sappsv1.Deployment, hook *appsv1.LifecycleHook, podLabels map[string]string) (*batchv1.Job, error) {
+	if hook == nil || hook.ExecNewPod == nil {
+		return nil, nil
+	}
+
+	podSpec := deployment.Spec.Template.Spec.DeepCopy()
+	container := findPodSpecContainer(podSpec, hook.ExecNewPod.ContainerName)
+	if container == nil {
+		return nil, fmt.Errorf("Deployment '%s': pre hook container '%s' not found", deployment.Name, hook.ExecNewPod.ContainerName)
+	}
+
+	hash, err := podTemplateHash(&deployment.Spec.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	// The hook pod gets the container env plus the hook env, overriding existing vars
+	env := append([]corev1.EnvVar{}, container.Env...)
+	for _, hookEnvVar := range hook.ExecNewPod.Env {
+		if idx := FindEnvVar(env, hookEnvVar.Name); idx >= 0 {
+			env[idx] = hookEnvVar
+		} else {
+			env = append(env, hookEnvVar)
+		}
+	}
+
+	// Only the volumes listed in the hook are mounted in the hook pod
+	volumeMounts := []corev1.VolumeMount{}
+	for _, volumeMount := range container.VolumeMounts {
+		if ArrayContains(hook.ExecNewPod.Volumes, volumeMount.Name) {
+			volumeMounts = append(volumeMounts, volumeMount)
+		}
+	}
+
+	podSpec.InitContainers = nil
+	podSpec.Containers = []corev1.Container{
+		{
+			Name:            container.Name,
+			Image:           container.Image,
+			ImagePullPolicy: container.ImagePullPolicy,
+			Command:         hook.ExecNewPod.Command,
+			Env:             env,
+			EnvFrom:         container.EnvFrom,
+			VolumeMounts:    volumeMounts,
+			Resources:       container.Resources,
+			SecurityContext: container.SecurityContext,
+		},
+	}
+	podSpec.RestartPolicy = corev1.RestartPolicyNever
+
+	// Failed jobs are run again by the operator with the Retry failure policy
+	var backoffLimit int32 = 0
+	if hook.FailurePolicy == appsv1.LifecycleHookFailurePolicyRetry {
+		backoffLimit = 6
+	}
+
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "batch/v1",
+			Kind:       "Job",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        deployment.Name + DeploymentPreHookJobSuffix,
+			Namespace:   deployment.Namespace,
+			Labels:      deployment.Labels,
+			Annotations: map[string]string{DeploymentPreHookTemplateHashAnnotation: hash},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      podLabels,
+					Annotations: deployment.Spec.Template.Annotations,
+				},
+				Spec: *podSpec,
+			},
+		},
+	}, nil
+}
+
+// podTemplateHash returns the hash of the pod template
+func podTemplateHash(template *corev1.PodTemplateSpec) (string, error) {
+	data, err := json.Marshal(template)
+	if err != nil {
+		return "", err
+	}
+
+	h := fnv.New32a()
+	h.Write(data)
+	return fmt.Sprint(h.Sum32()), nil
+}
+
+func addPostHookHandler(deployment *k8sappsv1.Deployment, hook *appsv1.LifecycleHook) error {
+	if hook == nil || hook.ExecNewPod == nil {
+		return nil
+	}
+
+	container := findPodSpecContainer(&deployment.Spec.Template.Spec, hook.ExecNewPod.ContainerName)
+	if container == nil {
+		return fmt.Errorf("post hook container '%s' not found", hook.ExecNewPod.ContainerName)
+	}
+
+	if container.Lifecycle == nil {
+		container.Lifecycle = &corev1.Lifecycle{}
+	}
+	container.Lifecycle.PostStart = &corev1.LifecycleHandler{
+		Exec: &corev1.ExecAction{Command: hook.ExecNewPod.Command},
+	}
+
+	return nil
+}
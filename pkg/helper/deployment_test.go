@@ -0,0 +1,245 @@
+package helper
+
+import (
+	"reflect"
+	"testing"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDeploymentFromDeploymentConfig(t *testing.T) {
+	dc := &appsv1.DeploymentConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "system-app",
+			Labels:      map[string]string{"app": "3scale"},
+			Annotations: map[string]string{"some": "annotation"},
+		},
+		Spec: appsv1.DeploymentConfigSpec{
+			Replicas: 2,
+			Selector: map[string]string{"deploymentConfig": "system-app"},
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.DeploymentStrategyTypeRolling,
+				RollingParams: &appsv1.RollingDeploymentStrategyParams{
+					Pre: &appsv1.LifecycleHook{
+						ExecNewPod: &appsv1.ExecNewPodHook{
+							Command:       []string{"pre"},
+							Env:           []corev1.EnvVar{{Name: "HOOK", Value: "hook"}, {Name: "BASE", Value: "overridden"}},
+							ContainerName: "master",
+							Volumes:       []string{"storage"},
+						},
+					},
+					Post: &appsv1.LifecycleHook{
+						ExecNewPod: &appsv1.ExecNewPodHook{
+							Command:       []string{"post"},
+							ContainerName: "master",
+						},
+					},
+				},
+			},
+			Triggers: appsv1.DeploymentTriggerPolicies{
+				{Type: appsv1.DeploymentTriggerOnConfigChange},
+				{
+					Type: appsv1.DeploymentTriggerOnImageChange,
+					ImageChangeParams: &appsv1.DeploymentTriggerImageChangeParams{
+						ContainerNames: []string{"master", "provider"},
+						From:           corev1.ObjectReference{Kind: "ImageStreamTag", Name: "amp-system:2.14"},
+					},
+				},
+			},
+			Template: &corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "master",
+							Image: "amp-system:latest",
+							Env:   []corev1.EnvVar{{Name: "BASE", Value: "base"}},
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "storage", MountPath: "/storage"},
+								{Name: "config", MountPath: "/config"},
+							},
+						},
+						{Name: "provider", Image: "amp-system:latest"},
+					},
+				},
+			},
+		},
+	}
+
+	_, err := DeploymentFromDeploymentConfig(dc, map[string]string{})
+	if err == nil {
+		t.Fatal("expected error when the imagestream image is unknown")
+	}
+
+	deployment, err := DeploymentFromDeploymentConfig(dc, map[string]string{"amp-system": "quay.io/3scale/system:latest"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if deployment.Name != dc.Name || deployment.Annotations["some"] != "annotation" || deployment.Labels["app"] != "3scale" {
+		t.Errorf("unexpected object meta: %v", deployment.ObjectMeta)
+	}
+	if *deployment.Spec.Replicas != 2 {
+		t.Errorf("unexpected replicas: %d", *deployment.Spec.Replicas)
+	}
+	if deployment.Spec.Selector.MatchLabels["deploymentConfig"] != "system-app" {
+		t.Errorf("unexpected selector: %v", deployment.Spec.Selector)
+	}
+	if deployment.Spec.Strategy.Type != k8sappsv1.RollingUpdateDeploymentStrategyType {
+		t.Errorf("unexpected strategy: %s", deployment.Spec.Strategy.Type)
+	}
+
+	containers := deployment.Spec.Template.Spec.Containers
+	if containers[0].Image != "quay.io/3scale/system:latest" || containers[1].Image != "quay.io/3scale/system:latest" {
+		t.Errorf("images not resolved: %s, %s", containers[0].Image, containers[1].Image)
+	}
+	if containers[0].Lifecycle == nil || containers[0].Lifecycle.PostStart == nil ||
+		containers[0].Lifecycle.PostStart.Exec.Command[0] != "post" {
+		t.Errorf("post hook not translated: %v", containers[0].Lifecycle)
+	}
+
+	if len(deployment.Spec.Template.Spec.InitContainers) != 0 {
+		t.Errorf("unexpected init containers: %v", deployment.Spec.Template.Spec.InitContainers)
+	}
+
+	// The source DeploymentConfig is not modified
+	if dc.Spec.Template.Spec.Containers[0].Image != "amp-system:latest" {
+		t.Error("source DeploymentConfig modified")
+	}
+}
+
+func TestDeploymentPreHookJob(t *testing.T) {
+	deployment := &k8sappsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "system-app", Labels: map[string]string{"app": "3scale"}},
+		Spec: k8sappsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"deploymentConfig": "system-app"}},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: "amp",
+					InitContainers:     []corev1.Container{{Name: "init"}},
+					Containers: []corev1.Container{
+						{
+							Name:  "master",
+							Image: "quay.io/3scale/system:latest",
+							Env:   []corev1.EnvVar{{Name: "BASE", Value: "base"}},
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "storage", MountPath: "/storage"},
+								{Name: "config", MountPath: "/config"},
+							},
+							ReadinessProbe: &corev1.Probe{},
+						},
+						{Name: "provider", Image: "quay.io/3scale/system:latest"},
+					},
+					Volumes: []corev1.Volume{{Name: "storage"}, {Name: "config"}},
+				},
+			},
+		},
+	}
+	hook := &appsv1.LifecycleHook{
+		FailurePolicy: appsv1.LifecycleHookFailurePolicyRetry,
+		ExecNewPod: &appsv1.ExecNewPodHook{
+			Command:       []string{"pre"},
+			Env:           []corev1.EnvVar{{Name: "HOOK", Value: "hook"}, {Name: "BASE", Value: "overridden"}},
+			ContainerName: "master",
+			Volumes:       []string{"storage"},
+		},
+	}
+	podLabels := map[string]string{"hook": "pre"}
+
+	job, err := DeploymentPreHookJob(deployment, nil, podLabels)
+	if err != nil || job != nil {
+		t.Fatalf("unexpected job without pre hook: %v, %v", job, err)
+	}
+
+	_, err = DeploymentPreHookJob(deployment, &appsv1.LifecycleHook{ExecNewPod: &appsv1.ExecNewPodHook{ContainerName: "unknown"}}, podLabels)
+	if err == nil {
+		t.Fatal("expected error when the hook container is unknown")
+	}
+
+	job, err = DeploymentPreHookJob(deployment, hook, podLabels)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if job.Name != "system-app"+DeploymentPreHookJobSuffix || job.Labels["app"] != "3scale" {
+		t.Errorf("unexpected job object meta: %v", job.ObjectMeta)
+	}
+	if *job.Spec.BackoffLimit == 0 {
+		t.Error("expected job retries with the Retry failure policy")
+	}
+	podSpec := job.Spec.Template.Spec
+	if !reflect.DeepEqual(job.Spec.Template.Labels, podLabels) {
+		t.Errorf("unexpected job pod labels: %v", job.Spec.Template.Labels)
+	}
+	if podSpec.RestartPolicy != corev1.RestartPolicyNever || podSpec.ServiceAccountName != "amp" ||
+		len(podSpec.InitContainers) != 0 || len(podSpec.Volumes) != 2 {
+		t.Errorf("unexpected job pod spec: %v", podSpec)
+	}
+	if len(podSpec.Containers) != 1 {
+		t.Fatalf("expected pre hook container, got %d containers", len(podSpec.Containers))
+	}
+	preHook := podSpec.Containers[0]
+	if preHook.Name != "master" || preHook.Command[0] != "pre" ||
+		preHook.Image != "quay.io/3scale/system:latest" || preHook.ReadinessProbe != nil {
+		t.Errorf("unexpected pre hook container: %v", preHook)
+	}
+	if len(preHook.VolumeMounts) != 1 || preHook.VolumeMounts[0].Name != "storage" {
+		t.Errorf("unexpected pre hook volume mounts: %v", preHook.VolumeMounts)
+	}
+	if preHook.Env[FindEnvVar(preHook.Env, "BASE")].Value != "overridden" || FindEnvVar(preHook.Env, "HOOK") < 0 {
+		t.Errorf("unexpected pre hook env: %v", preHook.Env)
+	}
+
+	// The job is run again when the pod template changes
+	hash := job.Annotations[DeploymentPreHookTemplateHashAnnotation]
+	deployment.Spec.Template.Spec.Containers[0].Image = "quay.io/3scale/system:next"
+	job, err = DeploymentPreHookJob(deployment, hook, podLabels)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash == "" || job.Annotations[DeploymentPreHookTemplateHashAnnotation] == hash {
+		t.Errorf("expected pod template hash change, got %s", hash)
+	}
+
+	// The source Deployment is not modified
+	if len(deployment.Spec.Template.Spec.Containers) != 2 {
+		t.Error("source Deployment modified")
+	}
+}
+
+func TestIsDeploymentAvailable(t *testing.T) {
+	deploymentFactory := func(generation, observedGeneration int64, updatedReplicas int32, available corev1.ConditionStatus) *k8sappsv1.Deployment {
+		return &k8sappsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Generation: generation},
+			Spec:       k8sappsv1.DeploymentSpec{Replicas: &[]int32{2}[0]},
+			Status: k8sappsv1.DeploymentStatus{
+				ObservedGeneration: observedGeneration,
+				UpdatedReplicas:    updatedReplicas,
+				Conditions: []k8sappsv1.DeploymentCondition{
+					{Type: k8sappsv1.DeploymentAvailable, Status: available},
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		testName   string
+		deployment *k8sappsv1.Deployment
+		expected   bool
+	}{
+		{"Available", deploymentFactory(1, 1, 2, corev1.ConditionTrue), true},
+		{"NotAvailable", deploymentFactory(1, 1, 2, corev1.ConditionFalse), false},
+		{"OutdatedObservedGeneration", deploymentFactory(2, 1, 2, corev1.ConditionTrue), false},
+		{"RolloutInProgress", deploymentFactory(1, 1, 1, corev1.ConditionTrue), false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			if res := IsDeploymentAvailable(tc.deployment); res != tc.expected {
+				subT.Errorf("expected: %t, got: %t", tc.expected, res)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	configv1 "github.com/openshift/api/config/v1"
 	"golang.org/x/mod/semver"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -17,7 +18,8 @@ func GetOpenshiftVersion(ctx context.Context, client client.Client) (string, boo
 	if err := client.Get(ctx, types.NamespacedName{
 		Name: "version",
 	}, clusterVersion); err != nil {
-		if errors.IsNotFound(err) {
+		// ClusterVersion resources are not available out of OpenShift
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
 			return "", false, nil
 		}
 
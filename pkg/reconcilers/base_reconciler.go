@@ -5,11 +5,15 @@ import (
 	"fmt"
 	"strings"
 
+	certmanagerv1 "github.com/3scale/3scale-operator/pkg/certmanager/v1"
 	"github.com/3scale/3scale-operator/pkg/common"
 
 	"github.com/go-logr/logr"
 	grafanav1alpha1 "github.com/grafana-operator/grafana-operator/v4/api/integreatly/v1alpha1"
+	appsv1 "github.com/openshift/api/apps/v1"
 	consolev1 "github.com/openshift/api/console/v1"
+	imagev1 "github.com/openshift/api/image/v1"
+	routev1 "github.com/openshift/api/route/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -174,6 +178,24 @@ func (b *BaseReconciler) HasConsoleLink() (bool, error) {
 		consolev1.GroupVersion.String(), "ConsoleLink")
 }
 
+// HasRoutes checks if the OpenShift Route API is supported in current cluster
+func (b *BaseReconciler) HasRoutes() (bool, error) {
+	return resourceExists(b.DiscoveryClient(),
+		routev1.GroupVersion.String(), "Route")
+}
+
+// HasDeploymentConfigs checks if the OpenShift DeploymentConfig API is supported in current cluster
+func (b *BaseReconciler) HasDeploymentConfigs() (bool, error) {
+	return resourceExists(b.DiscoveryClient(),
+		appsv1.GroupVersion.String(), "DeploymentConfig")
+}
+
+// HasImageStreams checks if the OpenShift ImageStream API is supported in current cluster
+func (b *BaseReconciler) HasImageStreams() (bool, error) {
+	return resourceExists(b.DiscoveryClient(),
+		imagev1.GroupVersion.String(), "ImageStream")
+}
+
 // HasGrafanaDashboards checks if the GrafanaDashboard CRD is supported in current cluster
 func (b *BaseReconciler) HasGrafanaDashboards() (bool, error) {
 	return resourceExists(b.DiscoveryClient(),
@@ -202,6 +224,13 @@ func (b *BaseReconciler) HasPodMonitors() (bool, error) {
 		monitoringv1.PodMonitorsKind)
 }
 
+// HasCertificates checks if the cert-manager Certificate CRD is supported in current cluster
+func (b *BaseReconciler) HasCertificates() (bool, error) {
+	return resourceExists(b.DiscoveryClient(),
+		certmanagerv1.GroupVersion.String(),
+		certmanagerv1.CertificateKind)
+}
+
 // SetOwnerReference sets owner as a Controller OwnerReference on owned
 func (b *BaseReconciler) SetOwnerReference(owner, obj common.KubernetesObject) error {
 	err := controllerutil.SetControllerReference(owner, obj, b.Scheme())
@@ -0,0 +1,28 @@
+package reconcilers
+
+import (
+	"fmt"
+	"reflect"
+
+	certmanagerv1 "github.com/3scale/3scale-operator/pkg/certmanager/v1"
+	"github.com/3scale/3scale-operator/pkg/common"
+)
+
+func GenericCertificateMutator(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*certmanagerv1.Certificate)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *certmanagerv1.Certificate", existingObj)
+	}
+	desired, ok := desiredObj.(*certmanagerv1.Certificate)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *certmanagerv1.Certificate", desiredObj)
+	}
+
+	updated := false
+	if !reflect.DeepEqual(desired.Spec, existing.Spec) {
+		existing.Spec = desired.Spec
+		updated = true
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,47 @@
+package reconcilers
+
+import (
+	"testing"
+
+	certmanagerv1 "github.com/3scale/3scale-operator/pkg/certmanager/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func certificateTestFactory(dnsName string) *certmanagerv1.Certificate {
+	return &certmanagerv1.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myCertificate",
+			Namespace: "someNs",
+		},
+		Spec: certmanagerv1.CertificateSpec{
+			SecretName: "myCertificate",
+			DNSNames:   []string{dnsName},
+			IssuerRef:  certmanagerv1.ObjectReference{Name: "myIssuer"},
+		},
+	}
+}
+
+func TestGenericCertificateMutator(t *testing.T) {
+	existing := certificateTestFactory("old.example.com")
+	desired := certificateTestFactory("new.example.com")
+
+	update, err := GenericCertificateMutator(existing, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !update {
+		t.Fatal("when spec differs, reconciler reported no update needed")
+	}
+
+	if existing.Spec.DNSNames[0] != "new.example.com" {
+		t.Fatalf("dns names not reconciled. Expected: %s, got: %s", "new.example.com", existing.Spec.DNSNames[0])
+	}
+
+	update, err = GenericCertificateMutator(existing, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update {
+		t.Fatal("when spec is equal, reconciler reported update needed")
+	}
+}
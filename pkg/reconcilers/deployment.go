@@ -0,0 +1,148 @@
+package reconcilers
+
+import (
+	"fmt"
+	"reflect"
+
+	appsv1 "github.com/openshift/api/apps/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+// DeploymentMutator returns a MutateFn reconciling Kubernetes Deployments
+// with the DeploymentConfig mutators.
+// Each mutator operates on DeploymentConfig views of the desired and existing Deployments.
+// The views share the pod template with the Deployments, so pod template changes
+// are applied in place. Replicas, strategy and metadata changes are copied back.
+// Container images, which DeploymentConfigs get from image change triggers,
+// are always reconciled.
+func DeploymentMutator(opts ...DCMutateFn) MutateFn {
+	return func(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+		existing, ok := existingObj.(*k8sappsv1.Deployment)
+		if !ok {
+			return false, fmt.Errorf("%T is not a *k8sappsv1.Deployment", existingObj)
+		}
+		desired, ok := desiredObj.(*k8sappsv1.Deployment)
+		if !ok {
+			return false, fmt.Errorf("%T is not a *k8sappsv1.Deployment", desiredObj)
+		}
+
+		desiredView := deploymentConfigView(desired)
+		existingView := deploymentConfigView(existing)
+		existingStrategy := existingView.Spec.Strategy.DeepCopy()
+
+		update := false
+
+		// Loop through each option
+		for _, opt := range opts {
+			tmpUpdate, err := opt(desiredView, existingView)
+			if err != nil {
+				return false, err
+			}
+			update = update || tmpUpdate
+		}
+
+		existing.ObjectMeta = existingView.ObjectMeta
+
+		if existing.Spec.Replicas == nil || *existing.Spec.Replicas != existingView.Spec.Replicas {
+			existing.Spec.Replicas = &[]int32{existingView.Spec.Replicas}[0]
+		}
+
+		if !reflect.DeepEqual(*existingStrategy, existingView.Spec.Strategy) {
+			existing.Spec.Strategy = helper.DeploymentStrategyFromDeploymentConfigStrategy(existingView.Spec.Strategy)
+		}
+
+		tmpUpdate := DeploymentContainerImagesMutator(desired, existing)
+		update = update || tmpUpdate
+
+		return update, nil
+	}
+}
+
+// DeploymentContainerImagesMutator ensures container and init container images are reconciled
+func DeploymentContainerImagesMutator(desired, existing *k8sappsv1.Deployment) bool {
+	updated := false
+
+	reconcileImages := func(desiredContainers []corev1.Container, existingContainers []corev1.Container) {
+		for idx := range existingContainers {
+			for _, desiredContainer := range desiredContainers {
+				if desiredContainer.Name == existingContainers[idx].Name &&
+					desiredContainer.Image != existingContainers[idx].Image {
+					log.Info(fmt.Sprintf("%s container '%s' image has changed: %s -> %s", common.ObjectInfo(desired),
+						desiredContainer.Name, existingContainers[idx].Image, desiredContainer.Image))
+					existingContainers[idx].Image = desiredContainer.Image
+					updated = true
+				}
+			}
+		}
+	}
+
+	reconcileImages(desired.Spec.Template.Spec.InitContainers, existing.Spec.Template.Spec.InitContainers)
+	reconcileImages(desired.Spec.Template.Spec.Containers, existing.Spec.Template.Spec.Containers)
+
+	return updated
+}
+
+// deploymentConfigView returns a DeploymentConfig sharing the pod template with the given Deployment.
+// An image change trigger is synthesized for all the containers, identical for every Deployment,
+// so the image change trigger mutator does not report changes.
+func deploymentConfigView(d *k8sappsv1.Deployment) *appsv1.DeploymentConfig {
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+
+	var selector map[string]string
+	if d.Spec.Selector != nil {
+		selector = d.Spec.Selector.MatchLabels
+	}
+
+	containerNames := []string{}
+	for _, container := range d.Spec.Template.Spec.InitContainers {
+		containerNames = append(containerNames, container.Name)
+	}
+	for _, container := range d.Spec.Template.Spec.Containers {
+		containerNames = append(containerNames, container.Name)
+	}
+
+	strategy := appsv1.DeploymentStrategy{Type: appsv1.DeploymentStrategyTypeRecreate}
+	if d.Spec.Strategy.Type != k8sappsv1.RecreateDeploymentStrategyType {
+		strategy = appsv1.DeploymentStrategy{
+			Type:          appsv1.DeploymentStrategyTypeRolling,
+			RollingParams: &appsv1.RollingDeploymentStrategyParams{},
+		}
+		if d.Spec.Strategy.RollingUpdate != nil {
+			strategy.RollingParams.MaxUnavailable = d.Spec.Strategy.RollingUpdate.MaxUnavailable
+			strategy.RollingParams.MaxSurge = d.Spec.Strategy.RollingUpdate.MaxSurge
+		}
+	}
+
+	return &appsv1.DeploymentConfig{
+		TypeMeta:   d.TypeMeta,
+		ObjectMeta: d.ObjectMeta,
+		Spec: appsv1.DeploymentConfigSpec{
+			Strategy:             strategy,
+			MinReadySeconds:      d.Spec.MinReadySeconds,
+			RevisionHistoryLimit: d.Spec.RevisionHistoryLimit,
+			Paused:               d.Spec.Paused,
+			Replicas:             replicas,
+			Selector:             selector,
+			Template:             &d.Spec.Template,
+			Triggers: appsv1.DeploymentTriggerPolicies{
+				appsv1.DeploymentTriggerPolicy{
+					Type: appsv1.DeploymentTriggerOnImageChange,
+					ImageChangeParams: &appsv1.DeploymentTriggerImageChangeParams{
+						Automatic:      true,
+						ContainerNames: containerNames,
+						From: corev1.ObjectReference{
+							Kind: "ImageStreamTag",
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,126 @@
+package reconcilers
+
+import (
+	"testing"
+
+	k8sappsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestDeploymentMutator(t *testing.T) {
+	deploymentFactory := func() *k8sappsv1.Deployment {
+		return &k8sappsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Deployment",
+				APIVersion: "apps/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "myDeployment",
+				Namespace: "myNS",
+			},
+			Spec: k8sappsv1.DeploymentSpec{
+				Replicas: &[]int32{1}[0],
+				Strategy: k8sappsv1.DeploymentStrategy{
+					Type: k8sappsv1.RollingUpdateDeploymentStrategyType,
+					RollingUpdate: &k8sappsv1.RollingUpdateDeployment{
+						MaxUnavailable: &intstr.IntOrString{Type: intstr.String, StrVal: "25%"},
+					},
+				},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{"a1": "v1"},
+					},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{Name: "container1", Image: "image:1"},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		testName       string
+		desired        func() *k8sappsv1.Deployment
+		mutators       []DCMutateFn
+		expectedResult bool
+		check          func(*k8sappsv1.Deployment) bool
+	}{
+		{"NothingToReconcile", deploymentFactory,
+			[]DCMutateFn{
+				DeploymentConfigImageChangeTriggerMutator, DeploymentConfigReplicasMutator,
+				DeploymentConfigStrategyMutator, DeploymentConfigPodTemplateAnnotationsMutator,
+			},
+			false, func(*k8sappsv1.Deployment) bool { return true },
+		},
+		{"ReplicasReconcile",
+			func() *k8sappsv1.Deployment {
+				desired := deploymentFactory()
+				desired.Spec.Replicas = &[]int32{3}[0]
+				return desired
+			},
+			[]DCMutateFn{DeploymentConfigReplicasMutator}, true,
+			func(existing *k8sappsv1.Deployment) bool { return *existing.Spec.Replicas == 3 },
+		},
+		{"ReplicasNotManaged",
+			func() *k8sappsv1.Deployment {
+				desired := deploymentFactory()
+				desired.Spec.Replicas = &[]int32{3}[0]
+				return desired
+			},
+			[]DCMutateFn{DeploymentConfigPodTemplateAnnotationsMutator}, false,
+			func(existing *k8sappsv1.Deployment) bool { return *existing.Spec.Replicas == 1 },
+		},
+		{"StrategyReconcile",
+			func() *k8sappsv1.Deployment {
+				desired := deploymentFactory()
+				desired.Spec.Strategy = k8sappsv1.DeploymentStrategy{Type: k8sappsv1.RecreateDeploymentStrategyType}
+				return desired
+			},
+			[]DCMutateFn{DeploymentConfigStrategyMutator}, true,
+			func(existing *k8sappsv1.Deployment) bool {
+				return existing.Spec.Strategy.Type == k8sappsv1.RecreateDeploymentStrategyType &&
+					existing.Spec.Strategy.RollingUpdate == nil
+			},
+		},
+		{"PodTemplateAnnotationsReconcile",
+			func() *k8sappsv1.Deployment {
+				desired := deploymentFactory()
+				desired.Spec.Template.Annotations["a2"] = "v2"
+				return desired
+			},
+			[]DCMutateFn{DeploymentConfigPodTemplateAnnotationsMutator}, true,
+			func(existing *k8sappsv1.Deployment) bool { return existing.Spec.Template.Annotations["a2"] == "v2" },
+		},
+		{"ImageReconcile",
+			func() *k8sappsv1.Deployment {
+				desired := deploymentFactory()
+				desired.Spec.Template.Spec.Containers[0].Image = "image:2"
+				return desired
+			},
+			[]DCMutateFn{}, true,
+			func(existing *k8sappsv1.Deployment) bool {
+				return existing.Spec.Template.Spec.Containers[0].Image == "image:2"
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.testName, func(subT *testing.T) {
+			existing := deploymentFactory()
+			update, err := DeploymentConfigMutator(tc.mutators...)(existing, tc.desired())
+			if err != nil {
+				subT.Fatal(err)
+			}
+			if update != tc.expectedResult {
+				subT.Fatalf("result failed, expected: %t, got: %t", tc.expectedResult, update)
+			}
+			if !tc.check(existing) {
+				subT.Fatalf("unexpected existing deployment: %v", existing.Spec)
+			}
+		})
+	}
+}
@@ -1,12 +1,18 @@
 package reconcilers
 
 import (
+	"crypto/md5"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	appsv1 "github.com/openshift/api/apps/v1"
+	k8sappsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -17,8 +23,16 @@ import (
 // DCMutateFn is a function which mutates the existing DeploymentConfig into it's desired state.
 type DCMutateFn func(desired, existing *appsv1.DeploymentConfig) (bool, error)
 
+// DeploymentConfigMutator returns a MutateFn applying the given DeploymentConfig mutators.
+// The pod customization is always reconciled first, see DeploymentConfigPodCustomizationMutator.
+// Kubernetes Deployments are also accepted, see DeploymentMutator.
 func DeploymentConfigMutator(opts ...DCMutateFn) MutateFn {
+	opts = append([]DCMutateFn{DeploymentConfigPodCustomizationMutator}, opts...)
 	return func(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+		if _, ok := existingObj.(*k8sappsv1.Deployment); ok {
+			return DeploymentMutator(opts...)(existingObj, desiredObj)
+		}
+
 		existing, ok := existingObj.(*appsv1.DeploymentConfig)
 		if !ok {
 			return false, fmt.Errorf("%T is not a *appsv1.DeploymentConfig", existingObj)
@@ -98,11 +112,12 @@ func DeploymentConfigContainerResourcesMutator(desired, existing *appsv1.Deploym
 	desiredName := common.ObjectInfo(desired)
 	update := false
 
-	if len(desired.Spec.Template.Spec.Containers) != 1 {
-		return false, fmt.Errorf("%s desired spec.template.spec.containers length changed to '%d', should be 1", desiredName, len(desired.Spec.Template.Spec.Containers))
+	// Sidecars are added after the component container
+	if len(desired.Spec.Template.Spec.Containers) < 1 {
+		return false, fmt.Errorf("%s desired spec.template.spec.containers length changed to '%d', should be at least 1", desiredName, len(desired.Spec.Template.Spec.Containers))
 	}
 
-	if len(existing.Spec.Template.Spec.Containers) != 1 {
+	if len(existing.Spec.Template.Spec.Containers) != len(desired.Spec.Template.Spec.Containers) {
 		log.Info(fmt.Sprintf("%s spec.template.spec.containers length changed to '%d', recreating dc", desiredName, len(existing.Spec.Template.Spec.Containers)))
 		existing.Spec.Template.Spec.Containers = desired.Spec.Template.Spec.Containers
 		update = true
@@ -283,3 +298,325 @@ func DeploymentConfigPodTemplateAnnotationsMutator(desired, existing *appsv1.Dep
 
 	return updated, nil
 }
+
+const (
+	// PodCustomizationAnnotationPartialKey prefixes the DeploymentConfig annotations recording
+	// the pod template items added by the component pod customization. The value is <kind>/<name>
+	PodCustomizationAnnotationPartialKey = "apps.3scale.net/pod-customization"
+
+	PodCustomizationEnvKind                = "env"
+	PodCustomizationVolumeKind             = "volume"
+	PodCustomizationVolumeMountKind        = "volumeMount"
+	PodCustomizationSidecarKind            = "sidecar"
+	PodCustomizationInitContainerKind      = "initContainer"
+	PodCustomizationPodSecurityContextKind = "podSecurityContext"
+	PodCustomizationSecurityContextKind    = "securityContext"
+	PodCustomizationLivenessProbeKind      = "livenessProbe"
+	PodCustomizationReadinessProbeKind     = "readinessProbe"
+	PodCustomizationStartupProbeKind       = "startupProbe"
+)
+
+// PodCustomizationAnnotation returns the annotation key and value recording a pod customization item.
+// The key is hashed to keep it under the annotation name length limit
+func PodCustomizationAnnotation(kind, name string) (string, string) {
+	value := fmt.Sprintf("%s/%s", kind, name)
+	return fmt.Sprintf("%s-%x", PodCustomizationAnnotationPartialKey, md5.Sum([]byte(value))), value
+}
+
+// podCustomizationItems returns the pod customization items recorded in the annotations, by kind and name
+func podCustomizationItems(annotations map[string]string) map[string]map[string]bool {
+	items := map[string]map[string]bool{}
+	for key, value := range annotations {
+		if !strings.HasPrefix(key, PodCustomizationAnnotationPartialKey) {
+			continue
+		}
+		parts := strings.SplitN(value, "/", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		if items[parts[0]] == nil {
+			items[parts[0]] = map[string]bool{}
+		}
+		items[parts[0]][parts[1]] = true
+	}
+	return items
+}
+
+// podCustomizationNames returns the item names following the desired order, then the names no longer desired, sorted
+func podCustomizationNames(items map[string]bool, desiredOrder []string) []string {
+	names := []string{}
+	for _, name := range desiredOrder {
+		if items[name] {
+			names = append(names, name)
+		}
+	}
+
+	removed := []string{}
+	for name := range items {
+		if !helper.ArrayContains(desiredOrder, name) {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(removed)
+
+	return append(names, removed...)
+}
+
+// DeploymentConfigPodCustomizationMutator reconciles the pod template items added by the pod customization.
+// The existing annotations record the items previously added, so the items no longer desired
+// are removed or restored to the operator desired state.
+// It is run before any other mutator, so the existing containers match the desired ones
+func DeploymentConfigPodCustomizationMutator(desired, existing *appsv1.DeploymentConfig) (bool, error) {
+	desiredItems := podCustomizationItems(desired.Annotations)
+	existingItems := podCustomizationItems(existing.Annotations)
+	for kind, names := range desiredItems {
+		for name := range names {
+			if existingItems[kind] == nil {
+				existingItems[kind] = map[string]bool{}
+			}
+			existingItems[kind][name] = true
+		}
+	}
+	if len(existingItems) == 0 {
+		return false, nil
+	}
+
+	desiredSpec := &desired.Spec.Template.Spec
+	existingSpec := &existing.Spec.Template.Spec
+	updated := false
+
+	// Added containers keep the desired order
+	sidecarNames := []string{}
+	for idx := range desiredSpec.Containers {
+		sidecarNames = append(sidecarNames, desiredSpec.Containers[idx].Name)
+	}
+	for _, name := range podCustomizationNames(existingItems[PodCustomizationSidecarKind], sidecarNames) {
+		reconcilePodCustomizationContainer(&updated, desiredSpec.Containers, &existingSpec.Containers, name, desiredItems[PodCustomizationSidecarKind][name])
+	}
+
+	initContainerNames := []string{}
+	for idx := range desiredSpec.InitContainers {
+		initContainerNames = append(initContainerNames, desiredSpec.InitContainers[idx].Name)
+	}
+	for _, name := range podCustomizationNames(existingItems[PodCustomizationInitContainerKind], initContainerNames) {
+		reconcilePodCustomizationContainer(&updated, desiredSpec.InitContainers, &existingSpec.InitContainers, name, desiredItems[PodCustomizationInitContainerKind][name])
+	}
+
+	volumeNames := []string{}
+	for idx := range desiredSpec.Volumes {
+		volumeNames = append(volumeNames, desiredSpec.Volumes[idx].Name)
+	}
+	for _, name := range podCustomizationNames(existingItems[PodCustomizationVolumeKind], volumeNames) {
+		reconcilePodCustomizationVolume(&updated, desiredSpec.Volumes, &existingSpec.Volumes, name, desiredItems[PodCustomizationVolumeKind][name])
+	}
+
+	if len(existingItems[PodCustomizationPodSecurityContextKind]) > 0 {
+		isDesired := len(desiredItems[PodCustomizationPodSecurityContextKind]) > 0
+		if !podCustomizationEqual(desiredSpec.SecurityContext, existingSpec.SecurityContext, isDesired) {
+			existingSpec.SecurityContext = desiredSpec.SecurityContext.DeepCopy()
+			updated = true
+		}
+	}
+
+	for idx := range desiredSpec.Containers {
+		desiredContainer := &desiredSpec.Containers[idx]
+		if desiredItems[PodCustomizationSidecarKind][desiredContainer.Name] {
+			continue
+		}
+		existingContainer := findContainer(existingSpec.Containers, desiredContainer.Name)
+		if existingContainer == nil {
+			continue
+		}
+
+		for name := range existingItems[PodCustomizationEnvKind] {
+			reconcilePodCustomizationEnvVar(&updated, desiredContainer.Env, &existingContainer.Env, name, desiredItems[PodCustomizationEnvKind][name])
+		}
+
+		for mountPath := range existingItems[PodCustomizationVolumeMountKind] {
+			reconcilePodCustomizationVolumeMount(&updated, desiredContainer.VolumeMounts, &existingContainer.VolumeMounts, mountPath, desiredItems[PodCustomizationVolumeMountKind][mountPath])
+		}
+
+		if len(existingItems[PodCustomizationSecurityContextKind]) > 0 {
+			isDesired := len(desiredItems[PodCustomizationSecurityContextKind]) > 0
+			if !podCustomizationEqual(desiredContainer.SecurityContext, existingContainer.SecurityContext, isDesired) {
+				existingContainer.SecurityContext = desiredContainer.SecurityContext.DeepCopy()
+				updated = true
+			}
+		}
+
+		probes := []struct {
+			kind     string
+			desired  *v1.Probe
+			existing **v1.Probe
+		}{
+			{PodCustomizationLivenessProbeKind, desiredContainer.LivenessProbe, &existingContainer.LivenessProbe},
+			{PodCustomizationReadinessProbeKind, desiredContainer.ReadinessProbe, &existingContainer.ReadinessProbe},
+			{PodCustomizationStartupProbeKind, desiredContainer.StartupProbe, &existingContainer.StartupProbe},
+		}
+		for _, probe := range probes {
+			if len(existingItems[probe.kind]) == 0 {
+				continue
+			}
+			isDesired := len(desiredItems[probe.kind]) > 0
+			if !podCustomizationEqual(probe.desired, *probe.existing, isDesired) {
+				*probe.existing = probe.desired.DeepCopy()
+				updated = true
+			}
+		}
+	}
+
+	// Record the desired items and remove the annotations of the items no longer desired
+	for key := range existing.Annotations {
+		if !strings.HasPrefix(key, PodCustomizationAnnotationPartialKey) {
+			continue
+		}
+		if _, ok := desired.Annotations[key]; !ok {
+			delete(existing.Annotations, key)
+			updated = true
+		}
+	}
+
+	for key, val := range desired.Annotations {
+		if !strings.HasPrefix(key, PodCustomizationAnnotationPartialKey) {
+			continue
+		}
+		if existingVal, ok := existing.Annotations[key]; !ok || existingVal != val {
+			if existing.Annotations == nil {
+				existing.Annotations = map[string]string{}
+			}
+			existing.Annotations[key] = val
+			updated = true
+		}
+	}
+
+	return updated, nil
+}
+
+// podCustomizationEqual compares desired items with DeepDerivative, so fields defaulted by the API server
+// do not trigger updates. Items no longer desired are compared strictly to restore the operator desired state
+func podCustomizationEqual(desired, existing interface{}, isDesired bool) bool {
+	if isDesired {
+		return equality.Semantic.DeepDerivative(desired, existing)
+	}
+	return equality.Semantic.DeepEqual(desired, existing)
+}
+
+func findContainer(containers []v1.Container, name string) *v1.Container {
+	for idx := range containers {
+		if containers[idx].Name == name {
+			return &containers[idx]
+		}
+	}
+	return nil
+}
+
+func reconcilePodCustomizationContainer(updated *bool, desired []v1.Container, existing *[]v1.Container, name string, isDesired bool) {
+	desiredContainer := findContainer(desired, name)
+	if !isDesired {
+		// Removed sidecars and init containers
+		desiredContainer = nil
+	}
+
+	for idx := range *existing {
+		if (*existing)[idx].Name != name {
+			continue
+		}
+		if desiredContainer == nil {
+			*existing = append((*existing)[:idx], (*existing)[idx+1:]...)
+			*updated = true
+		} else if !podCustomizationEqual(*desiredContainer, (*existing)[idx], true) {
+			(*existing)[idx] = *desiredContainer.DeepCopy()
+			*updated = true
+		}
+		return
+	}
+
+	if desiredContainer != nil {
+		*existing = append(*existing, *desiredContainer.DeepCopy())
+		*updated = true
+	}
+}
+
+func reconcilePodCustomizationVolume(updated *bool, desired []v1.Volume, existing *[]v1.Volume, name string, isDesired bool) {
+	var desiredVolume *v1.Volume
+	for idx := range desired {
+		if desired[idx].Name == name {
+			desiredVolume = &desired[idx]
+		}
+	}
+
+	for idx := range *existing {
+		if (*existing)[idx].Name != name {
+			continue
+		}
+		if desiredVolume == nil {
+			*existing = append((*existing)[:idx], (*existing)[idx+1:]...)
+			*updated = true
+		} else if !podCustomizationEqual(*desiredVolume, (*existing)[idx], isDesired) {
+			(*existing)[idx] = *desiredVolume.DeepCopy()
+			*updated = true
+		}
+		return
+	}
+
+	if desiredVolume != nil {
+		*existing = append(*existing, *desiredVolume.DeepCopy())
+		*updated = true
+	}
+}
+
+func reconcilePodCustomizationEnvVar(updated *bool, desired []v1.EnvVar, existing *[]v1.EnvVar, name string, isDesired bool) {
+	var desiredEnvVar *v1.EnvVar
+	for idx := range desired {
+		if desired[idx].Name == name {
+			desiredEnvVar = &desired[idx]
+		}
+	}
+
+	for idx := range *existing {
+		if (*existing)[idx].Name != name {
+			continue
+		}
+		if desiredEnvVar == nil {
+			*existing = append((*existing)[:idx], (*existing)[idx+1:]...)
+			*updated = true
+		} else if !podCustomizationEqual(*desiredEnvVar, (*existing)[idx], isDesired) {
+			(*existing)[idx] = *desiredEnvVar.DeepCopy()
+			*updated = true
+		}
+		return
+	}
+
+	if desiredEnvVar != nil {
+		*existing = append(*existing, *desiredEnvVar.DeepCopy())
+		*updated = true
+	}
+}
+
+func reconcilePodCustomizationVolumeMount(updated *bool, desired []v1.VolumeMount, existing *[]v1.VolumeMount, mountPath string, isDesired bool) {
+	var desiredVolumeMount *v1.VolumeMount
+	for idx := range desired {
+		if desired[idx].MountPath == mountPath {
+			desiredVolumeMount = &desired[idx]
+		}
+	}
+
+	for idx := range *existing {
+		if (*existing)[idx].MountPath != mountPath {
+			continue
+		}
+		if desiredVolumeMount == nil {
+			*existing = append((*existing)[:idx], (*existing)[idx+1:]...)
+			*updated = true
+		} else if !podCustomizationEqual(*desiredVolumeMount, (*existing)[idx], isDesired) {
+			(*existing)[idx] = *desiredVolumeMount
+			*updated = true
+		}
+		return
+	}
+
+	if desiredVolumeMount != nil {
+		*existing = append(*existing, *desiredVolumeMount)
+		*updated = true
+	}
+}
@@ -11,6 +11,7 @@ import (
 	appsv1 "github.com/openshift/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -982,3 +983,89 @@ func TestDeploymentConfigPodTemplateAnnotationsMutator(t *testing.T) {
 		})
 	}
 }
+
+func TestDeploymentConfigPodCustomizationMutator(t *testing.T) {
+	dcFactory := func() *appsv1.DeploymentConfig {
+		return &appsv1.DeploymentConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "myDC", Namespace: "myNS"},
+			Spec: appsv1.DeploymentConfigSpec{
+				Template: &v1.PodTemplateSpec{
+					Spec: v1.PodSpec{
+						Containers: []v1.Container{
+							{Name: "main", Env: []v1.EnvVar{{Name: "A", Value: "1"}}},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	customizedDCFactory := func() *appsv1.DeploymentConfig {
+		dc := dcFactory()
+		dc.Annotations = map[string]string{}
+		for _, item := range [][]string{
+			{PodCustomizationEnvKind, "A"},
+			{PodCustomizationEnvKind, "B"},
+			{PodCustomizationSidecarKind, "proxy"},
+			{PodCustomizationVolumeKind, "extra"},
+			{PodCustomizationSecurityContextKind, ""},
+		} {
+			key, value := PodCustomizationAnnotation(item[0], item[1])
+			dc.Annotations[key] = value
+		}
+		podSpec := &dc.Spec.Template.Spec
+		podSpec.Containers[0].Env = []v1.EnvVar{
+			{Name: "A", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "metadata.name"}}},
+			{Name: "B", Value: "2"},
+		}
+		podSpec.Containers[0].SecurityContext = &v1.SecurityContext{RunAsNonRoot: &[]bool{true}[0]}
+		podSpec.Containers = append(podSpec.Containers, v1.Container{Name: "proxy", Image: "proxy:latest"})
+		podSpec.Volumes = []v1.Volume{{Name: "extra", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}}}
+		return dc
+	}
+
+	existing := dcFactory()
+
+	// Customization added
+	desired := customizedDCFactory()
+	update, err := DeploymentConfigPodCustomizationMutator(desired, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !update {
+		t.Fatal("expected update when adding the customization")
+	}
+	if !equality.Semantic.DeepEqual(existing.Spec.Template.Spec, desired.Spec.Template.Spec) {
+		t.Fatalf("unexpected pod spec: %s", cmp.Diff(existing.Spec.Template.Spec, desired.Spec.Template.Spec))
+	}
+	if !reflect.DeepEqual(existing.Annotations, desired.Annotations) {
+		t.Fatalf("unexpected annotations: %v", existing.Annotations)
+	}
+
+	// Fields defaulted by the API server do not trigger updates
+	existing.Spec.Template.Spec.Containers[1].TerminationMessagePath = v1.TerminationMessagePathDefault
+	existing.Spec.Template.Spec.Containers[0].Env[0].ValueFrom.FieldRef.APIVersion = "v1"
+	update, err = DeploymentConfigPodCustomizationMutator(customizedDCFactory(), existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update {
+		t.Fatal("unexpected update when the customization did not change")
+	}
+
+	// Customization removed: the operator desired state is restored
+	desired = dcFactory()
+	update, err = DeploymentConfigPodCustomizationMutator(desired, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !update {
+		t.Fatal("expected update when removing the customization")
+	}
+	if !equality.Semantic.DeepEqual(existing.Spec.Template.Spec, desired.Spec.Template.Spec) {
+		t.Fatalf("unexpected pod spec: %s", cmp.Diff(existing.Spec.Template.Spec, desired.Spec.Template.Spec))
+	}
+	if len(existing.Annotations) != 0 {
+		t.Fatalf("unexpected annotations: %v", existing.Annotations)
+	}
+}
@@ -0,0 +1,43 @@
+package reconcilers
+
+import (
+	"fmt"
+
+	"github.com/3scale/3scale-operator/pkg/common"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+func GenericHPAMutator(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*autoscalingv2.HorizontalPodAutoscaler)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *autoscalingv2.HorizontalPodAutoscaler", existingObj)
+	}
+	desired, ok := desiredObj.(*autoscalingv2.HorizontalPodAutoscaler)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *autoscalingv2.HorizontalPodAutoscaler", desiredObj)
+	}
+
+	updated := false
+	if !equality.Semantic.DeepEqual(desired.Spec.ScaleTargetRef, existing.Spec.ScaleTargetRef) {
+		existing.Spec.ScaleTargetRef = desired.Spec.ScaleTargetRef
+		updated = true
+	}
+
+	if !equality.Semantic.DeepEqual(desired.Spec.MinReplicas, existing.Spec.MinReplicas) {
+		existing.Spec.MinReplicas = desired.Spec.MinReplicas
+		updated = true
+	}
+
+	if desired.Spec.MaxReplicas != existing.Spec.MaxReplicas {
+		existing.Spec.MaxReplicas = desired.Spec.MaxReplicas
+		updated = true
+	}
+
+	if !equality.Semantic.DeepEqual(desired.Spec.Metrics, existing.Spec.Metrics) {
+		existing.Spec.Metrics = desired.Spec.Metrics
+		updated = true
+	}
+
+	return updated, nil
+}
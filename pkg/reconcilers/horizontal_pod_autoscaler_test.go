@@ -0,0 +1,58 @@
+package reconcilers
+
+import (
+	"testing"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func hpaTestFactory(maxReplicas int32) *autoscalingv2.HorizontalPodAutoscaler {
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myHPA",
+			Namespace: "someNs",
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				Kind: "DeploymentConfig", Name: "myDC", APIVersion: "apps.openshift.io/v1",
+			},
+			MinReplicas: &[]int32{1}[0],
+			MaxReplicas: maxReplicas,
+		},
+	}
+}
+
+func TestGenericHPAMutator(t *testing.T) {
+	var existingMaxReplicas int32 = 3
+	var desiredMaxReplicas int32 = 5
+
+	existing := hpaTestFactory(existingMaxReplicas)
+	desired := hpaTestFactory(desiredMaxReplicas)
+	// Behavior is not managed by the operator
+	existing.Spec.Behavior = &autoscalingv2.HorizontalPodAutoscalerBehavior{}
+
+	update, err := GenericHPAMutator(existing, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !update {
+		t.Fatal("when max replicas differ, reconciler reported no update needed")
+	}
+
+	if existing.Spec.MaxReplicas != desiredMaxReplicas {
+		t.Fatalf("MaxReplicas not reconciled. Expected: %d, got: %d", desiredMaxReplicas, existing.Spec.MaxReplicas)
+	}
+
+	if existing.Spec.Behavior == nil {
+		t.Fatal("Behavior not expected to be reconciled")
+	}
+
+	update, err = GenericHPAMutator(existing, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update {
+		t.Fatal("when specs are equal, reconciler reported update needed")
+	}
+}
@@ -0,0 +1,32 @@
+package reconcilers
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/3scale/3scale-operator/pkg/common"
+	"github.com/3scale/3scale-operator/pkg/helper"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// GenericIngressMutator reconciles the Ingress spec. The desired labels and annotations,
+// like the ingress controller settings, are merged into the existing ones
+func GenericIngressMutator(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*networkingv1.Ingress)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *networkingv1.Ingress", existingObj)
+	}
+	desired, ok := desiredObj.(*networkingv1.Ingress)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *networkingv1.Ingress", desiredObj)
+	}
+
+	updated := helper.EnsureObjectMeta(existing, desired)
+
+	if !reflect.DeepEqual(desired.Spec, existing.Spec) {
+		existing.Spec = desired.Spec
+		updated = true
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,78 @@
+package reconcilers
+
+import (
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func ingressTestFactory(host string) *networkingv1.Ingress {
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myIngress",
+			Namespace: "someNs",
+		},
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{
+				{Host: host},
+			},
+		},
+	}
+}
+
+func TestGenericIngressMutator(t *testing.T) {
+	existing := ingressTestFactory("old.example.com")
+	desired := ingressTestFactory("new.example.com")
+
+	update, err := GenericIngressMutator(existing, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !update {
+		t.Fatal("when spec differs, reconciler reported no update needed")
+	}
+
+	if existing.Spec.Rules[0].Host != "new.example.com" {
+		t.Fatalf("host not reconciled. Expected: %s, got: %s", "new.example.com", existing.Spec.Rules[0].Host)
+	}
+
+	update, err = GenericIngressMutator(existing, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update {
+		t.Fatal("when spec is equal, reconciler reported update needed")
+	}
+}
+
+func TestGenericIngressMutatorObjectMeta(t *testing.T) {
+	existing := ingressTestFactory("example.com")
+	existing.Annotations = map[string]string{"other": "value"}
+	desired := ingressTestFactory("example.com")
+	desired.Labels = map[string]string{"app": "3scale-api-management"}
+	desired.Annotations = map[string]string{"nginx.ingress.kubernetes.io/ssl-redirect": "false"}
+
+	update, err := GenericIngressMutator(existing, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !update {
+		t.Fatal("when labels and annotations differ, reconciler reported no update needed")
+	}
+
+	if existing.Labels["app"] != "3scale-api-management" {
+		t.Errorf("labels not reconciled: %v", existing.Labels)
+	}
+	if existing.Annotations["nginx.ingress.kubernetes.io/ssl-redirect"] != "false" || existing.Annotations["other"] != "value" {
+		t.Errorf("annotations not merged: %v", existing.Annotations)
+	}
+
+	update, err = GenericIngressMutator(existing, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if update {
+		t.Fatal("when labels and annotations are equal, reconciler reported update needed")
+	}
+}
@@ -0,0 +1,34 @@
+package reconcilers
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/google/go-cmp/cmp"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"github.com/3scale/3scale-operator/pkg/common"
+)
+
+// RoleRulesMutator ensures role rules are reconciled
+func RoleRulesMutator(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*rbacv1.Role)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *rbacv1.Role", existingObj)
+	}
+	desired, ok := desiredObj.(*rbacv1.Role)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *rbacv1.Role", desiredObj)
+	}
+
+	updated := false
+
+	if !reflect.DeepEqual(existing.Rules, desired.Rules) {
+		diff := cmp.Diff(existing.Rules, desired.Rules)
+		log.Info(fmt.Sprintf("%s rules have changed: %s", common.ObjectInfo(desired), diff))
+		existing.Rules = desired.Rules
+		updated = true
+	}
+
+	return updated, nil
+}
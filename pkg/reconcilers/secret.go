@@ -2,6 +2,7 @@ package reconcilers
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/3scale/3scale-operator/pkg/common"
 
@@ -89,3 +90,14 @@ func SecretReconcileField(fieldName string) func(desired, existing *v1.Secret) b
 		return updated
 	}
 }
+
+// SecretReconcileData reconciles the whole secret data.
+// The fields not in the desired secret are removed
+func SecretReconcileData(desired, existing *v1.Secret) bool {
+	if reflect.DeepEqual(existing.Data, desired.Data) {
+		return false
+	}
+	existing.Data = desired.Data
+	existing.StringData = nil
+	return true
+}
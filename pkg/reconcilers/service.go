@@ -27,3 +27,23 @@ func ServicePortMutator(existingObj, desiredObj common.KubernetesObject) (bool,
 
 	return updated, nil
 }
+
+func ServiceSelectorMutator(existingObj, desiredObj common.KubernetesObject) (bool, error) {
+	existing, ok := existingObj.(*v1.Service)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *v1.Service", existingObj)
+	}
+	desired, ok := desiredObj.(*v1.Service)
+	if !ok {
+		return false, fmt.Errorf("%T is not a *v1.Service", desiredObj)
+	}
+
+	updated := false
+
+	if !reflect.DeepEqual(existing.Spec.Selector, desired.Spec.Selector) {
+		updated = true
+		existing.Spec.Selector = desired.Spec.Selector
+	}
+
+	return updated, nil
+}
@@ -53,14 +53,34 @@ var configMapsToRestore map[string]string = map[string]string{
 	"APIcastEnvironment": "apicast-environment",
 }
 
-func (b *APIManagerRestore) restoreSourcePVCContainerVolumeMount() v1.VolumeMount {
+// restoreSourceContainerVolumeMount returns the mount of the backup data read by the restore
+// containers. The plaintext backup data, decrypted by an init container, with decryption
+func (b *APIManagerRestore) restoreSourceContainerVolumeMount() v1.VolumeMount {
+	if b.options.BackupCipher != nil {
+		return b.options.BackupCipher.PlaintextDataVolumeMount(RestorePVCMountPath)
+	}
+	return b.restoreSourceDataVolumeMount()
+}
+
+func (b *APIManagerRestore) restoreSourceDataVolumeMount() v1.VolumeMount {
 	return v1.VolumeMount{
-		Name:      b.options.APIManagerRestorePVCOptions.PersistentVolumeClaimVolumeSource.ClaimName,
+		Name:      b.restoreSourcePodVolume().Name,
 		MountPath: RestorePVCMountPath,
 	}
 }
 
-func (b *APIManagerRestore) restoreSourcePVCPodVolume() v1.Volume {
+// restoreSourcePodVolume returns the volume the backup data is read from.
+// An emptyDir when the backup data is downloaded from S3
+func (b *APIManagerRestore) restoreSourcePodVolume() v1.Volume {
+	if b.options.APIManagerRestoreS3Options != nil {
+		return v1.Volume{
+			Name: backup.BackupDataVolumeName,
+			VolumeSource: v1.VolumeSource{
+				EmptyDir: &v1.EmptyDirVolumeSource{},
+			},
+		}
+	}
+
 	return v1.Volume{
 		Name: b.options.APIManagerRestorePVCOptions.PersistentVolumeClaimVolumeSource.ClaimName,
 		VolumeSource: v1.VolumeSource{
@@ -87,124 +107,90 @@ func (b *APIManagerRestore) systemFileStoragePVCPodVolume() v1.Volume {
 	}
 }
 
-func (b *APIManagerRestore) RestoreSecretsAndConfigMapsFromPVCJob() *batchv1.Job {
-	if b.options.APIManagerRestorePVCOptions == nil {
-		return nil
-	}
-
-	jobName, err := helper.UIDBasedJobName("restore-cfgmaps-secrets", b.options.APIManagerRestoreUID)
-	if err != nil {
-		panic(err)
-	}
+func (b *APIManagerRestore) RestoreSecretsAndConfigMapsJob() *batchv1.Job {
+	return b.restoreJob("restore-cfgmaps-secrets", v1.Container{
+		Name:  "restore-cfgmaps-secrets",
+		Image: b.options.OCCLIImageURL,
+		Command: []string{
+			"/bin/bash",
+		},
+		Args: []string{
+			"-c",
+			"-e",
+			b.restoreSecretsAndConfigMapsContainerArgs(),
+		},
+	}, nil, []string{"secrets", "configmaps"})
+}
 
-	var completions int32 = 1
-	return &batchv1.Job{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: "batch/v1",
-			Kind:       "Job",
+func (b *APIManagerRestore) RestoreSystemFileStorageJob() *batchv1.Job {
+	return b.restoreJob("restore-system-fs", v1.Container{
+		Name:  "backup-system-filestorage-pvc",
+		Image: b.options.OCCLIImageURL,
+		Command: []string{
+			"/bin/bash",
 		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      jobName,
-			Namespace: b.options.Namespace,
+		Args: []string{
+			"-c",
+			"-e",
+			b.restoreSystemFilestoragePVCContainerArgs(),
 		},
-		Spec: batchv1.JobSpec{
-			Completions: &completions,
-			// TODO BackoffLimit field controls how many times the job is retried
-			Template: v1.PodTemplateSpec{
-				Spec: v1.PodSpec{
-					Volumes: []v1.Volume{
-						b.restoreSourcePVCPodVolume(),
-					},
-					Containers: []v1.Container{
-						v1.Container{
-							Name:  "restore-cfgmaps-secrets",
-							Image: b.options.OCCLIImageURL,
-							Command: []string{
-								"/bin/bash",
-							},
-							Args: []string{
-								"-c",
-								"-e",
-								b.restoreSecretsAndConfigMapsContainerArgs(),
-							},
-							//Env: []v1.EnvVar{},
-							VolumeMounts: []v1.VolumeMount{
-								b.restoreSourcePVCContainerVolumeMount(),
-							},
-						},
-					},
-					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
-					ServiceAccountName: ServiceAccountName,
-				},
-			},
+		VolumeMounts: []v1.VolumeMount{
+			b.systemFileStoragePVCContainerVolumeMount(),
 		},
-	}
+	}, []v1.Volume{
+		b.systemFileStoragePVCPodVolume(),
+	}, []string{"system-filestorage-pvc"})
+}
+
+func (b *APIManagerRestore) CreateAPIManagerSharedSecretJob() *batchv1.Job {
+	return b.restoreJob("restore-apm-tosecret", v1.Container{
+		Name:  "job",
+		Image: b.options.OCCLIImageURL,
+		Command: []string{
+			"/bin/bash",
+		},
+		Args: []string{
+			"-c",
+			"-e",
+			b.createAPIManagerSharedSecretContainerArgs(),
+		},
+	}, nil, []string{"apimanager"})
 }
 
-func (b *APIManagerRestore) RestoreSystemFileStoragePVCFromPVCJob() *batchv1.Job {
-	if b.options.APIManagerRestorePVCOptions == nil {
+// restoreJob returns the job running the container with the backup data volume mounted.
+// With an S3 source, the given subdirectories of the backup data are downloaded into an
+// emptyDir volume before the container starts. With decryption, they are decrypted into a
+// plaintext emptyDir volume, read by the container
+func (b *APIManagerRestore) restoreJob(jobNamePrefix string, container v1.Container, volumes []v1.Volume, subdirs []string) *batchv1.Job {
+	return b.job(jobNamePrefix, container, volumes, subdirs, false)
+}
+
+func (b *APIManagerRestore) job(jobNamePrefix string, container v1.Container, volumes []v1.Volume, subdirs []string, tolerateDecryptionFailure bool) *batchv1.Job {
+	if b.options.APIManagerRestorePVCOptions == nil && b.options.APIManagerRestoreS3Options == nil {
 		return nil
 	}
 
-	jobName, err := helper.UIDBasedJobName("restore-system-fs", b.options.APIManagerRestoreUID)
+	jobName, err := helper.UIDBasedJobName(jobNamePrefix, b.options.APIManagerRestoreUID)
 	if err != nil {
 		panic(err)
 	}
 
-	var completions int32 = 1
-	return &batchv1.Job{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: "batch/v1",
-			Kind:       "Job",
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      jobName,
-			Namespace: b.options.Namespace,
-		},
-		Spec: batchv1.JobSpec{
-			Completions: &completions,
-			// TODO BackoffLimit field controls how many times the job is retried. Should we limit to 1?
-			Template: v1.PodTemplateSpec{
-				Spec: v1.PodSpec{
-					Volumes: []v1.Volume{
-						b.restoreSourcePVCPodVolume(),
-						b.systemFileStoragePVCPodVolume(),
-					},
-					Containers: []v1.Container{
-						v1.Container{
-							Name:  "backup-system-filestorage-pvc",
-							Image: b.options.OCCLIImageURL,
-							Command: []string{
-								"/bin/bash",
-							},
-							Args: []string{
-								"-c",
-								"-e",
-								b.restoreSystemFilestoragePVCContainerArgs(),
-							},
-							//Env: []v1.EnvVar{},
-							VolumeMounts: []v1.VolumeMount{
-								b.restoreSourcePVCContainerVolumeMount(),
-								b.systemFileStoragePVCContainerVolumeMount(),
-							},
-						},
-					},
-					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
-					ServiceAccountName: ServiceAccountName,
-				},
-			},
-		},
+	container.VolumeMounts = append([]v1.VolumeMount{b.restoreSourceContainerVolumeMount()}, container.VolumeMounts...)
+	podSpec := v1.PodSpec{
+		Volumes:            append([]v1.Volume{b.restoreSourcePodVolume()}, volumes...),
+		Containers:         []v1.Container{container},
+		RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
+		ServiceAccountName: ServiceAccountName,
 	}
-}
 
-func (b *APIManagerRestore) CreateAPIManagerSharedSecretJob() *batchv1.Job {
-	if b.options.APIManagerRestorePVCOptions == nil {
-		return nil
+	if s3Options := b.options.APIManagerRestoreS3Options; s3Options != nil {
+		podSpec.InitContainers = append(podSpec.InitContainers, s3Options.S3Location.DownloadContainer(b.restoreSourceDataVolumeMount(), subdirs))
+		podSpec.Volumes = append(podSpec.Volumes, s3Options.S3Location.S3Volumes()...)
 	}
 
-	jobName, err := helper.UIDBasedJobName("restore-apm-tosecret", b.options.APIManagerRestoreUID)
-	if err != nil {
-		panic(err)
+	if cipher := b.options.BackupCipher; cipher != nil {
+		podSpec.InitContainers = append(podSpec.InitContainers, cipher.DecryptContainer(b.restoreSourceDataVolumeMount(), subdirs, tolerateDecryptionFailure))
+		podSpec.Volumes = append(podSpec.Volumes, cipher.Volumes()...)
 	}
 
 	var completions int32 = 1
@@ -221,38 +207,14 @@ func (b *APIManagerRestore) CreateAPIManagerSharedSecretJob() *batchv1.Job {
 			Completions: &completions,
 			// TODO BackoffLimit field controls how many times the job is retried
 			Template: v1.PodTemplateSpec{
-				Spec: v1.PodSpec{
-					Volumes: []v1.Volume{
-						b.restoreSourcePVCPodVolume(),
-					},
-					Containers: []v1.Container{
-						v1.Container{
-							Name:  "job",
-							Image: b.options.OCCLIImageURL,
-							Command: []string{
-								"/bin/bash",
-							},
-							Args: []string{
-								"-c",
-								"-e",
-								b.createAPIManagerSharedSecretContainerArgs(),
-							},
-							//Env: []v1.EnvVar{},
-							VolumeMounts: []v1.VolumeMount{
-								b.restoreSourcePVCContainerVolumeMount(),
-							},
-						},
-					},
-					RestartPolicy:      v1.RestartPolicyNever, // Only "Never" or "OnFailure" are accepted in Kubernetes Jobs
-					ServiceAccountName: ServiceAccountName,
-				},
+				Spec: podSpec,
 			},
 		},
 	}
 }
 
 func (b *APIManagerRestore) ZyncResyncDomainsJob() *batchv1.Job {
-	if b.options.APIManagerRestorePVCOptions == nil {
+	if b.options.APIManagerRestorePVCOptions == nil && b.options.APIManagerRestoreS3Options == nil {
 		return nil
 	}
 
@@ -396,7 +358,7 @@ func (b *APIManagerRestore) restoreSecretsAndConfigMapsContainerArgs() string {
 func (b *APIManagerRestore) zyncResyncDomainsContainerArgs() string {
 	return `
 	dcname="system-sidekiq"
-	dcpods=$(oc get pods --ignore-not-found=true -l deploymentconfig=${dcname} --no-headers=true -o custom-columns=:metadata.name)
+	dcpods=$(oc get pods --ignore-not-found=true -l deploymentConfig=${dcname} --no-headers=true -o custom-columns=:metadata.name)
 	if [ -z "${dcpods}" ]; then
 		echo "No pods found for Deployment ${dcname}"
 		exit 1
@@ -3,6 +3,8 @@ package restore
 import (
 	validator "github.com/go-playground/validator/v10"
 	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/3scale/3scale-operator/pkg/backup"
 )
 
 type APIManagerRestoreOptions struct {
@@ -10,8 +12,10 @@ type APIManagerRestoreOptions struct {
 	APIManagerRestoreName string    `validate:"required"` // Name of the APIManagerRestore CR. NOT the backup or APIManager name
 	APIManagerRestoreUID  types.UID `validate:"required"` // UID of the APIManagerRestore CR
 
-	APIManagerRestorePVCOptions *APIManagerRestorePVCOptions `validate:"required"`
+	APIManagerRestorePVCOptions *APIManagerRestorePVCOptions `validate:"required_without=APIManagerRestoreS3Options"`
+	APIManagerRestoreS3Options  *APIManagerRestoreS3Options  `validate:"required_without=APIManagerRestorePVCOptions"`
 	OCCLIImageURL               string                       `validate:"required"`
+	BackupCipher                *backup.BackupCipher         // Decrypts the backup data. Nil without decryption
 }
 
 func NewAPIManagerRestoreOptions() *APIManagerRestoreOptions {
@@ -1,10 +1,12 @@
 package restore
 
 import (
+	"context"
 	"fmt"
 
 	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
 	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/backup"
 	"github.com/3scale/3scale-operator/pkg/helper"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -27,19 +29,38 @@ func (a *APIManagerRestoreOptionsProvider) Options() (*APIManagerRestoreOptions,
 	res.APIManagerRestoreUID = a.APIManagerRestoreCR.UID
 	res.Namespace = a.APIManagerRestoreCR.Namespace
 
-	res.OCCLIImageURL = a.ocCLIImageURL()
+	ocCLIImageURL, err := a.mirroredImageURL(helper.GetEnvVar("RELATED_IMAGE_OC_CLI", component.OCCLIImageURL()))
+	if err != nil {
+		return nil, err
+	}
+	res.OCCLIImageURL = ocCLIImageURL
 
 	pvcOptions, err := a.pvcRestoreOptions()
 	if err != nil {
 		return nil, err
 	}
 
+	s3Options, err := a.s3RestoreOptions()
+	if err != nil {
+		return nil, err
+	}
+
 	// TODO can this checks be omitted and just rely on the validator package in the APIManagerRestore struct?
-	if pvcOptions == nil {
+	if pvcOptions == nil && s3Options == nil {
 		return nil, fmt.Errorf("At least one restore source has to be specified")
 	}
+	if pvcOptions != nil && s3Options != nil {
+		return nil, fmt.Errorf("Only one restore source can be specified")
+	}
 
 	res.APIManagerRestorePVCOptions = pvcOptions
+	res.APIManagerRestoreS3Options = s3Options
+
+	cipher, err := backup.NewBackupDecryptionCipher(a.APIManagerRestoreCR.Spec.Decryption, res.OCCLIImageURL)
+	if err != nil {
+		return nil, err
+	}
+	res.BackupCipher = cipher
 
 	return res, res.Validate()
 }
@@ -55,6 +76,39 @@ func (a *APIManagerRestoreOptionsProvider) pvcRestoreOptions() (*APIManagerResto
 	return res, res.Validate()
 }
 
-func (a *APIManagerRestoreOptionsProvider) ocCLIImageURL() string {
-	return helper.GetEnvVar("RELATED_IMAGE_OC_CLI", component.OCCLIImageURL())
+func (a *APIManagerRestoreOptionsProvider) s3RestoreOptions() (*APIManagerRestoreS3Options, error) {
+	s3 := a.APIManagerRestoreCR.Spec.RestoreSource.S3
+	if s3 == nil {
+		return nil, nil
+	}
+
+	awsCLIImageURL, err := a.mirroredImageURL(backup.AWSCLIImageURL())
+	if err != nil {
+		return nil, err
+	}
+
+	res := NewAPIManagerRestoreS3Options()
+	res.S3Location = backup.NewS3Location(&s3.S3ObjectStorage, backup.S3BackupKey(s3.Prefix, s3.BackupName), awsCLIImageURL)
+
+	return res, res.Validate()
+}
+
+// mirroredImageURL applies the registry mirrors of the APIManagerRestore. When not set,
+// the mirrors of the APIManager are applied once it exists in the namespace
+func (a *APIManagerRestoreOptionsProvider) mirroredImageURL(imageURL string) (string, error) {
+	if len(a.APIManagerRestoreCR.Spec.ImageRegistryMirrors) > 0 {
+		return appsv1alpha1.MirroredImageURL(a.APIManagerRestoreCR.Spec.ImageRegistryMirrors, imageURL), nil
+	}
+
+	resList := &appsv1alpha1.APIManagerList{}
+	err := a.Client.List(context.TODO(), resList, client.InNamespace(a.APIManagerRestoreCR.Namespace))
+	if err != nil {
+		return "", err
+	}
+
+	if len(resList.Items) != 1 {
+		return imageURL, nil
+	}
+
+	return resList.Items[0].MirroredImageURL(imageURL), nil
 }
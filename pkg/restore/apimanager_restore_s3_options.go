@@ -0,0 +1,20 @@
+package restore
+
+import (
+	validator "github.com/go-playground/validator/v10"
+
+	"github.com/3scale/3scale-operator/pkg/backup"
+)
+
+type APIManagerRestoreS3Options struct {
+	S3Location backup.S3Location `validate:"required"`
+}
+
+func NewAPIManagerRestoreS3Options() *APIManagerRestoreS3Options {
+	return &APIManagerRestoreS3Options{}
+}
+
+func (a *APIManagerRestoreS3Options) Validate() error {
+	validate := validator.New()
+	return validate.Struct(a)
+}
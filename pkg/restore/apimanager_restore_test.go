@@ -0,0 +1,288 @@
+package restore
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/backup"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAPIManagerRestoreS3Source(t *testing.T) {
+	prefix := "backups"
+	region := "us-east-1"
+	cr := &appsv1alpha1.APIManagerRestore{
+		ObjectMeta: metav1.ObjectMeta{Name: "myrestore", Namespace: "operator-unittest", UID: "restore-uid"},
+		Spec: appsv1alpha1.APIManagerRestoreSpec{
+			RestoreSource: appsv1alpha1.APIManagerRestoreSource{
+				S3: &appsv1alpha1.S3RestoreSource{
+					S3ObjectStorage: appsv1alpha1.S3ObjectStorage{
+						Bucket:               "mybucket",
+						Prefix:               &prefix,
+						Region:               &region,
+						CredentialsSecretRef: v1.LocalObjectReference{Name: "s3-credentials"},
+					},
+					BackupName: "mybackup",
+				},
+			},
+		},
+	}
+
+	s := runtime.NewScheme()
+	if err := appsv1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	cl := fake.NewClientBuilder().WithScheme(s).Build()
+
+	options, err := NewAPIManagerRestoreOptionsProvider(cr, cl).Options()
+	if err != nil {
+		t.Fatal(err)
+	}
+	apiManagerRestore := NewAPIManagerRestore(options)
+
+	if apiManagerRestore.ZyncResyncDomainsJob() == nil {
+		t.Error("expected zync resync domains job")
+	}
+
+	cases := []struct {
+		name      string
+		podSpec   v1.PodSpec
+		container string
+		subdirs   string
+	}{
+		{"SecretsAndConfigMaps", apiManagerRestore.RestoreSecretsAndConfigMapsJob().Spec.Template.Spec, "restore-cfgmaps-secrets", "secrets configmaps"},
+		{"SystemFileStorage", apiManagerRestore.RestoreSystemFileStorageJob().Spec.Template.Spec, "backup-system-filestorage-pvc", "system-filestorage-pvc"},
+		{"APIManagerSharedSecret", apiManagerRestore.CreateAPIManagerSharedSecretJob().Spec.Template.Spec, "job", "apimanager"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			if len(tc.podSpec.InitContainers) != 1 || tc.podSpec.InitContainers[0].Name != "download-backup-data" {
+				subT.Fatalf("expected download init container, got %v", tc.podSpec.InitContainers)
+			}
+			if len(tc.podSpec.Containers) != 1 || tc.podSpec.Containers[0].Name != tc.container {
+				subT.Fatalf("expected %s container, got %v", tc.container, tc.podSpec.Containers)
+			}
+			if tc.podSpec.Volumes[0].Name != backup.BackupDataVolumeName || tc.podSpec.Volumes[0].EmptyDir == nil {
+				subT.Errorf("expected %s emptyDir volume, got %v", backup.BackupDataVolumeName, tc.podSpec.Volumes)
+			}
+
+			download := tc.podSpec.InitContainers[0]
+			script := download.Args[len(download.Args)-1]
+			for _, expected := range []string{
+				"SUBDIRS='" + tc.subdirs + "'",
+				`"${S3_URL}/${i}" '/backup'/"${i}"`,
+			} {
+				if !strings.Contains(script, expected) {
+					subT.Errorf("expected download script to contain %q, got %s", expected, script)
+				}
+			}
+			s3URL := ""
+			for _, envVar := range download.Env {
+				if envVar.Name == "S3_URL" {
+					s3URL = envVar.Value
+				}
+			}
+			if s3URL != "s3://mybucket/backups/mybackup" {
+				subT.Errorf("expected S3_URL env var, got %q", s3URL)
+			}
+		})
+	}
+}
+
+func TestAPIManagerRestoreImageRegistryMirrors(t *testing.T) {
+	cr := &appsv1alpha1.APIManagerRestore{
+		ObjectMeta: metav1.ObjectMeta{Name: "myrestore", Namespace: "operator-unittest", UID: "restore-uid"},
+		Spec: appsv1alpha1.APIManagerRestoreSpec{
+			RestoreSource: appsv1alpha1.APIManagerRestoreSource{
+				S3: &appsv1alpha1.S3RestoreSource{
+					S3ObjectStorage: appsv1alpha1.S3ObjectStorage{
+						Bucket:               "mybucket",
+						CredentialsSecretRef: v1.LocalObjectReference{Name: "s3-credentials"},
+					},
+					BackupName: "mybackup",
+				},
+			},
+			ImageRegistryMirrors: []appsv1alpha1.ImageRegistryMirrorSpec{
+				{Source: "quay.io", Mirror: "mirror.example.com"},
+				{Source: "docker.io", Mirror: "mirror.example.com"},
+			},
+		},
+	}
+
+	s := runtime.NewScheme()
+	if err := appsv1alpha1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	// No APIManager in the namespace before the restore
+	cl := fake.NewClientBuilder().WithScheme(s).Build()
+
+	options, err := NewAPIManagerRestoreOptionsProvider(cr, cl).Options()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(options.OCCLIImageURL, "mirror.example.com/") {
+		t.Errorf("expected mirrored oc image, got %s", options.OCCLIImageURL)
+	}
+	if image := options.APIManagerRestoreS3Options.S3Location.AWSCLIImageURL; !strings.HasPrefix(image, "mirror.example.com/") {
+		t.Errorf("expected mirrored aws image, got %s", image)
+	}
+}
+
+func TestAPIManagerRestoreDatabaseJobs(t *testing.T) {
+	options := &APIManagerRestoreOptions{
+		Namespace:             "operator-unittest",
+		APIManagerRestoreName: "myrestore",
+		APIManagerRestoreUID:  "restore-uid",
+		APIManagerRestorePVCOptions: &APIManagerRestorePVCOptions{
+			PersistentVolumeClaimVolumeSource: v1.PersistentVolumeClaimVolumeSource{ClaimName: "mybackup-pvc"},
+		},
+		OCCLIImageURL: "quay.io/openshift/origin-cli:4.7",
+	}
+	apiManagerRestore := NewAPIManagerRestore(options)
+
+	mysqlImage := "quay.io/example/mysql:8"
+	apimanager := &appsv1alpha1.APIManager{
+		ObjectMeta: metav1.ObjectMeta{Name: "example-apimanager", Namespace: "operator-unittest"},
+		Spec: appsv1alpha1.APIManagerSpec{
+			System: &appsv1alpha1.SystemSpec{
+				DatabaseSpec: &appsv1alpha1.SystemDatabaseSpec{MySQL: &appsv1alpha1.SystemMySQLSpec{Image: &mysqlImage}},
+			},
+			APIManagerCommonSpec: appsv1alpha1.APIManagerCommonSpec{
+				ImageRegistryMirrors: []appsv1alpha1.ImageRegistryMirrorSpec{
+					{Source: "quay.io/example", Mirror: "mirror.example.com/example"},
+				},
+			},
+		},
+	}
+
+	cases := []struct {
+		name      string
+		job       *batchv1.Job
+		image     string
+		claimName string
+		secret    string
+		dump      string
+	}{
+		{"SystemDatabase", apiManagerRestore.RestoreSystemDatabaseJob(apimanager), "mirror.example.com/example/mysql:8", "mysql-storage", "system-database", "system-mysql.sql"},
+		{"BackendRedis", apiManagerRestore.RestoreBackendRedisJob(apimanager), "quay.io/centos7/redis-6-centos7:latest", "backend-redis-storage", "backend-redis", "backend-redis.rdb"},
+		{"SystemRedis", apiManagerRestore.RestoreSystemRedisJob(apimanager), "quay.io/centos7/redis-6-centos7:latest", "system-redis-storage", "system-redis", "system-redis.rdb"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			if tc.job == nil {
+				subT.Fatal("expected restore job")
+			}
+			podSpec := tc.job.Spec.Template.Spec
+			if len(podSpec.InitContainers) != 1 || podSpec.InitContainers[0].Name != "restore-secret" {
+				subT.Fatalf("expected restore secret init container, got %v", podSpec.InitContainers)
+			}
+			if script := podSpec.InitContainers[0].Args[2]; !strings.Contains(script, "SECRET='"+tc.secret+"'") {
+				subT.Errorf("expected %s secret restored, got %s", tc.secret, script)
+			}
+
+			container := podSpec.Containers[0]
+			if container.Image != tc.image {
+				subT.Errorf("expected image %s, got %s", tc.image, container.Image)
+			}
+			if script := container.Args[2]; !strings.Contains(script, "/backup/databases/"+tc.dump) {
+				subT.Errorf("expected %s loaded, got %s", tc.dump, script)
+			}
+
+			claimNames := []string{}
+			for _, volume := range podSpec.Volumes {
+				if volume.PersistentVolumeClaim != nil {
+					claimNames = append(claimNames, volume.PersistentVolumeClaim.ClaimName)
+				}
+			}
+			if !reflect.DeepEqual(claimNames, []string{"mybackup-pvc", tc.claimName}) {
+				subT.Errorf("expected backup and %s volumes, got %v", tc.claimName, claimNames)
+			}
+		})
+	}
+
+	if job := apiManagerRestore.RestoreZyncDatabaseJob(apimanager); job == nil || !strings.Contains(job.Spec.Template.Spec.Containers[0].Args[2], "zync-database.sql") {
+		t.Errorf("expected zync database restore job, got %v", job)
+	}
+
+	apimanager.Spec.ExternalComponents = appsv1alpha1.AllComponentsExternal()
+	for _, job := range []*batchv1.Job{
+		apiManagerRestore.RestoreSystemDatabaseJob(apimanager),
+		apiManagerRestore.RestoreBackendRedisJob(apimanager),
+		apiManagerRestore.RestoreSystemRedisJob(apimanager),
+		apiManagerRestore.RestoreZyncDatabaseJob(apimanager),
+	} {
+		if job != nil {
+			t.Errorf("expected no restore job with external databases, got %s", job.Name)
+		}
+	}
+}
+
+func TestAPIManagerRestoreDecryption(t *testing.T) {
+	options := &APIManagerRestoreOptions{
+		Namespace:             "operator-unittest",
+		APIManagerRestoreName: "myrestore",
+		APIManagerRestoreUID:  "restore-uid",
+		APIManagerRestorePVCOptions: &APIManagerRestorePVCOptions{
+			PersistentVolumeClaimVolumeSource: v1.PersistentVolumeClaimVolumeSource{ClaimName: "mybackup-pvc"},
+		},
+		OCCLIImageURL: "quay.io/openshift/origin-cli:4.7",
+	}
+	cipher, err := backup.NewBackupDecryptionCipher(&appsv1alpha1.BackupDecryption{
+		KeySecretRef: &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "backup-key"}, Key: "key"},
+	}, options.OCCLIImageURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	options.BackupCipher = cipher
+	apiManagerRestore := NewAPIManagerRestore(options)
+
+	cases := []struct {
+		name      string
+		podSpec   v1.PodSpec
+		subdirs   string
+		exitCode  string
+		plaintext []string
+	}{
+		{"SecretsAndConfigMaps", apiManagerRestore.RestoreSecretsAndConfigMapsJob().Spec.Template.Spec, "secrets configmaps", "exit 1;", []string{"restore-cfgmaps-secrets"}},
+		{"SystemDatabase", apiManagerRestore.RestoreSystemDatabaseJob(&appsv1alpha1.APIManager{}).Spec.Template.Spec, "secrets databases", "exit 1;", []string{"restore-secret", "restore-system-database"}},
+		{"VerifyBackup", apiManagerRestore.VerifyBackupJob().Spec.Template.Spec, "manifest", "exit 0;", []string{"verify-backup"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			decrypt := tc.podSpec.InitContainers[0]
+			if decrypt.Name != "decrypt-backup-data" {
+				subT.Fatalf("expected decryption init container first, got %v", tc.podSpec.InitContainers)
+			}
+			if mount := decrypt.VolumeMounts[0]; mount.Name != "mybackup-pvc" || mount.MountPath != RestorePVCMountPath {
+				subT.Errorf("expected the backup data decrypted from the backup volume, got %v", mount)
+			}
+			if script := decrypt.Args[2]; !strings.Contains(script, tc.subdirs) || !strings.Contains(script, tc.exitCode) {
+				subT.Errorf("expected %s decrypted with %q on failure, got %s", tc.subdirs, tc.exitCode, script)
+			}
+
+			containers := append(tc.podSpec.InitContainers[1:], tc.podSpec.Containers...)
+			if len(containers) != len(tc.plaintext) {
+				subT.Fatalf("expected %v containers, got %v", tc.plaintext, containers)
+			}
+			for idx, container := range containers {
+				if container.Name != tc.plaintext[idx] {
+					subT.Errorf("expected %s container, got %s", tc.plaintext[idx], container.Name)
+				}
+				if mount := container.VolumeMounts[0]; mount.Name != backup.PlaintextDataVolumeName || mount.MountPath != RestorePVCMountPath {
+					subT.Errorf("expected %s to read the plaintext data, got %v", container.Name, mount)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package restore
+
+import (
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/component"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/operator"
+	"github.com/3scale/3scale-operator/pkg/backup"
+	"github.com/3scale/3scale-operator/pkg/helper"
+)
+
+const (
+	mysqlDataMountPath      = "/var/lib/mysql/data"
+	postgreSQLDataMountPath = "/var/lib/pgsql/data"
+	redisDataMountPath      = "/var/lib/redis/data"
+	databaseDataVolumeName  = "database-data"
+)
+
+// RestoreSystemDatabaseJob returns the job loading the system database dump into the
+// system database PVC, before the APIManager is created. The job runs the database image,
+// starting a temporary database server initialized from the system-database secret.
+// Nil when the system database of the APIManager is external
+func (b *APIManagerRestore) RestoreSystemDatabaseJob(apimanager *appsv1alpha1.APIManager) *batchv1.Job {
+	deploymentName := backup.BackedUpSystemDatabase(apimanager)
+	if deploymentName == "" {
+		return nil
+	}
+
+	var image, pvcName, mountPath, script string
+	var env []v1.EnvVar
+	if deploymentName == component.SystemMySQLDeploymentName {
+		image = operator.SystemMySQLImageURL()
+		if system := apimanager.Spec.System; system != nil && system.DatabaseSpec != nil && system.DatabaseSpec.MySQL != nil && system.DatabaseSpec.MySQL.Image != nil {
+			image = *apimanager.Spec.System.DatabaseSpec.MySQL.Image
+		}
+		pvcName = component.SystemMySQLPVCName
+		mountPath = mysqlDataMountPath
+		env = []v1.EnvVar{
+			helper.EnvVarFromSecret("MYSQL_USER", component.SystemSecretSystemDatabaseSecretName, component.SystemSecretSystemDatabaseUserFieldName),
+			helper.EnvVarFromSecret("MYSQL_PASSWORD", component.SystemSecretSystemDatabaseSecretName, component.SystemSecretSystemDatabasePasswordFieldName),
+			helper.EnvVarFromSecret("DATABASE_URL", component.SystemSecretSystemDatabaseSecretName, component.SystemSecretSystemDatabaseURLFieldName),
+			helper.EnvVarFromValue("MYSQL_LOWER_CASE_TABLE_NAMES", "1"),
+		}
+		script = b.restoreSystemMySQLContainerArgs()
+	} else {
+		image = operator.SystemPostgreSQLImageURL()
+		if apimanager.Spec.System.DatabaseSpec.PostgreSQL.Image != nil {
+			image = *apimanager.Spec.System.DatabaseSpec.PostgreSQL.Image
+		}
+		pvcName = component.SystemPostgreSQLPVCName
+		mountPath = postgreSQLDataMountPath
+		env = []v1.EnvVar{
+			helper.EnvVarFromSecret("POSTGRESQL_USER", component.SystemSecretSystemDatabaseSecretName, component.SystemSecretSystemDatabaseUserFieldName),
+			helper.EnvVarFromSecret("POSTGRESQL_PASSWORD", component.SystemSecretSystemDatabaseSecretName, component.SystemSecretSystemDatabasePasswordFieldName),
+			helper.EnvVarFromSecret("DATABASE_URL", component.SystemSecretSystemDatabaseSecretName, component.SystemSecretSystemDatabaseURLFieldName),
+		}
+		script = b.restoreSystemPostgreSQLContainerArgs()
+	}
+
+	return b.restoreDatabaseJob("restore-system-db", v1.Container{
+		Name:    "restore-system-database",
+		Image:   apimanager.MirroredImageURL(image),
+		Command: []string{"/bin/bash"},
+		Args:    []string{"-c", "-e", script},
+		Env:     env,
+	}, pvcName, mountPath, component.SystemSecretSystemDatabaseSecretName)
+}
+
+// RestoreBackendRedisJob returns the job loading the backend-redis RDB copy into the
+// backend-redis PVC, before the APIManager is created. Nil when backend-redis is external
+func (b *APIManagerRestore) RestoreBackendRedisJob(apimanager *appsv1alpha1.APIManager) *batchv1.Job {
+	if apimanager.IsExternal(appsv1alpha1.BackendRedis) {
+		return nil
+	}
+
+	image := operator.BackendRedisImageURL()
+	if apimanager.Spec.Backend != nil && apimanager.Spec.Backend.RedisImage != nil {
+		image = *apimanager.Spec.Backend.RedisImage
+	}
+
+	return b.restoreRedisJob("restore-backend-redis", component.BackendRedisDeploymentName,
+		apimanager.MirroredImageURL(image), component.BackendRedisPVCName, component.BackendSecretBackendRedisSecretName)
+}
+
+// RestoreSystemRedisJob returns the job loading the system-redis RDB copy into the
+// system-redis PVC, before the APIManager is created. Nil when system-redis is external
+func (b *APIManagerRestore) RestoreSystemRedisJob(apimanager *appsv1alpha1.APIManager) *batchv1.Job {
+	if apimanager.IsExternal(appsv1alpha1.SystemRedis) {
+		return nil
+	}
+
+	image := operator.SystemRedisImageURL()
+	if apimanager.Spec.System != nil && apimanager.Spec.System.RedisImage != nil {
+		image = *apimanager.Spec.System.RedisImage
+	}
+
+	return b.restoreRedisJob("restore-system-redis", component.SystemRedisDeploymentName,
+		apimanager.MirroredImageURL(image), component.SystemRedisPVCName, component.SystemSecretSystemRedisSecretName)
+}
+
+// RestoreZyncDatabaseJob returns the job loading the zync database dump into the running
+// zync-database pod. zync-database stores its data in an emptyDir volume, so the dump can only
+// be loaded once the APIManager has been created. Nil when the zync database is external
+func (b *APIManagerRestore) RestoreZyncDatabaseJob(apimanager *appsv1alpha1.APIManager) *batchv1.Job {
+	if !backup.BackedUpZyncDatabase(apimanager) {
+		return nil
+	}
+
+	return b.restoreJob("restore-zync-db", v1.Container{
+		Name:    "restore-zync-database",
+		Image:   b.options.OCCLIImageURL,
+		Command: []string{"/bin/bash"},
+		Args:    []string{"-c", "-e", b.restoreZyncDatabaseContainerArgs()},
+	}, nil, []string{backup.DatabasesBackupSubdir})
+}
+
+func (b *APIManagerRestore) restoreRedisJob(jobNamePrefix, deploymentName, image, pvcName, secretName string) *batchv1.Job {
+	return b.restoreDatabaseJob(jobNamePrefix, v1.Container{
+		Name:    jobNamePrefix,
+		Image:   image,
+		Command: []string{"/bin/bash"},
+		Args:    []string{"-c", "-e", b.restoreRedisContainerArgs(deploymentName)},
+	}, pvcName, redisDataMountPath, secretName)
+}
+
+// restoreDatabaseJob returns the job running the container with the database PVC mounted.
+// The secret of the database is restored from the backup by an init container,
+// unless it already exists
+func (b *APIManagerRestore) restoreDatabaseJob(jobNamePrefix string, container v1.Container, pvcName, mountPath, secretName string) *batchv1.Job {
+	container.VolumeMounts = []v1.VolumeMount{
+		{Name: databaseDataVolumeName, MountPath: mountPath},
+	}
+
+	job := b.restoreJob(jobNamePrefix, container, []v1.Volume{
+		{
+			Name: databaseDataVolumeName,
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+					ClaimName: pvcName,
+				},
+			},
+		},
+	}, []string{"secrets", backup.DatabasesBackupSubdir})
+	if job == nil {
+		return nil
+	}
+
+	podSpec := &job.Spec.Template.Spec
+	podSpec.InitContainers = append(podSpec.InitContainers, v1.Container{
+		Name:    "restore-secret",
+		Image:   b.options.OCCLIImageURL,
+		Command: []string{"/bin/bash"},
+		Args: []string{"-c", "-e", fmt.Sprintf(`
+	BASEPATH='%s';
+	SECRET='%s';
+	res=$(oc get secret ${SECRET} --ignore-not-found=true)
+	if [ -z "${res}" ]; then
+		oc create -f ${BASEPATH}/secrets/${SECRET}.json
+	else
+		echo "Secret ${SECRET} already exists. Skipping restore of the secret"
+	fi
+`, RestorePVCMountPath, secretName)},
+		VolumeMounts: []v1.VolumeMount{b.restoreSourceContainerVolumeMount()},
+	})
+
+	return job
+}
+
+// dumpFileScript exits successfully when the backup does not include the dump,
+// as backups taken by previous versions do not include the databases
+func dumpFileScript(fileName string) string {
+	return fmt.Sprintf(`
+	DUMP="%s/%s/%s";
+	if [ ! -f "${DUMP}" ]; then
+		echo "${DUMP} not found in the backup. Skipping restore of the data"
+		exit 0
+	fi
+`, RestorePVCMountPath, backup.DatabasesBackupSubdir, fileName)
+}
+
+func (b *APIManagerRestore) restoreSystemMySQLContainerArgs() string {
+	return dumpFileScript(backup.SQLDumpFileName(component.SystemMySQLDeploymentName)) + `
+	export MYSQL_ROOT_PASSWORD=$(echo -n "${DATABASE_URL}" | sed -E 's#^[^:]+://[^:]*:([^@]*)@.*$#\1#')
+	export MYSQL_DATABASE="${DATABASE_URL##*/}"
+	export MYSQL_PWD="${MYSQL_ROOT_PASSWORD}"
+	run-mysqld --default-authentication-plugin=mysql_native_password &
+	# The server initializing the data directory does not listen on TCP
+	until mysql -h 127.0.0.1 -u root -e 'SELECT 1' > /dev/null 2>&1; do sleep 5; done
+	mysql -h 127.0.0.1 -u root "${MYSQL_DATABASE}" < "${DUMP}"
+	mysqladmin -h 127.0.0.1 -u root shutdown
+	wait
+`
+}
+
+func (b *APIManagerRestore) restoreSystemPostgreSQLContainerArgs() string {
+	return dumpFileScript(backup.SQLDumpFileName(component.SystemPostgreSQLDeploymentName)) + `
+	export POSTGRESQL_DATABASE="${DATABASE_URL##*/}"
+	export PGPASSWORD="${POSTGRESQL_PASSWORD}"
+	run-postgresql &
+	# The server initializing the data directory does not listen on TCP
+	until psql -h 127.0.0.1 -U "${POSTGRESQL_USER}" -d "${POSTGRESQL_DATABASE}" -c 'SELECT 1' > /dev/null 2>&1; do sleep 5; done
+	psql -h 127.0.0.1 -U "${POSTGRESQL_USER}" -d "${POSTGRESQL_DATABASE}" -f "${DUMP}"
+	pg_ctl stop
+	wait
+`
+}
+
+// restoreRedisContainerArgs loads the RDB copy with the append only file disabled,
+// then enables it so Redis rewrites the append only file, loaded on the next start
+func (b *APIManagerRestore) restoreRedisContainerArgs(deploymentName string) string {
+	return dumpFileScript(backup.RedisDumpFileName(deploymentName)) + fmt.Sprintf(`
+	DATA_DIR='%s';
+	rm -f ${DATA_DIR}/appendonly.aof
+	cp "${DUMP}" ${DATA_DIR}/dump.rdb
+	redis-server --dir ${DATA_DIR} --dbfilename dump.rdb --appendonly no &
+	until [ "$(redis-cli PING)" = "PONG" ]; do sleep 2; done
+	redis-cli CONFIG SET appendonly yes
+	until redis-cli INFO persistence | grep -q "aof_rewrite_in_progress:0" && redis-cli INFO persistence | grep -q "aof_rewrite_scheduled:0"; do sleep 2; done
+	redis-cli INFO persistence | grep -q "aof_last_bgrewrite_status:ok"
+	redis-cli SHUTDOWN
+	wait
+`, redisDataMountPath)
+}
+
+func (b *APIManagerRestore) restoreZyncDatabaseContainerArgs() string {
+	return dumpFileScript(backup.SQLDumpFileName(component.ZyncDatabaseDeploymentName)) + fmt.Sprintf(`
+	dcname='%s'
+	dcpods=$(oc get pods --ignore-not-found=true -l deploymentConfig=${dcname} --field-selector=status.phase=Running --no-headers=true -o custom-columns=:metadata.name)
+	if [ -z "${dcpods}" ]; then
+		echo "No running pods found for Deployment ${dcname}"
+		exit 1
+	fi
+	podname=$(echo -n $dcpods | awk '{print $1}')
+	oc exec -i ${podname} -- bash -c 'PGPASSWORD="${POSTGRESQL_PASSWORD}" psql -h 127.0.0.1 -U "${POSTGRESQL_USER}" -d "${POSTGRESQL_DATABASE}"' < "${DUMP}"
+`, component.ZyncDatabaseDeploymentName)
+}
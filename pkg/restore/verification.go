@@ -0,0 +1,172 @@
+package restore
+
+import (
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
+	"github.com/3scale/3scale-operator/pkg/backup"
+	"github.com/3scale/3scale-operator/version"
+)
+
+// BackupVerificationFileName is the key of the verification result in the
+// ConfigMap created by the backup verification job
+const BackupVerificationFileName = "verification.json"
+
+const maxReportedInvalidFiles = 10
+
+// BackupVerification is the result of the backup verification job
+type BackupVerification struct {
+	DecryptionError string                 `json:"decryptionError,omitempty"`
+	ManifestFound   bool                   `json:"manifestFound"`
+	Manifest        *backup.BackupManifest `json:"manifest,omitempty"`
+	VerifiedFiles   int32                  `json:"verifiedFiles"`
+	InvalidFiles    []string               `json:"invalidFiles,omitempty"`
+}
+
+// Status returns the verification status of the restore. The backup must
+// have been taken with the same operator minor version and 3scale release,
+// unless incompatible backups are allowed. Decryption failures and invalid
+// files always fail the verification
+func (v *BackupVerification) Status(cr *appsv1alpha1.APIManagerRestore) *appsv1alpha1.APIManagerRestoreBackupVerification {
+	res := &appsv1alpha1.APIManagerRestoreBackupVerification{
+		VerifiedFiles: v.VerifiedFiles,
+	}
+	if v.Manifest != nil {
+		res.OperatorVersion = v.Manifest.OperatorVersion
+		res.ThreescaleRelease = v.Manifest.ThreescaleRelease
+		res.APIManagerSpecHash = v.Manifest.APIManagerSpecHash
+	}
+
+	if v.DecryptionError != "" {
+		res.Result = appsv1alpha1.BackupVerificationFailed
+		res.Message = v.DecryptionError
+		return res
+	}
+
+	if v.Manifest != nil && v.Manifest.Encryption != "" && cr.Spec.Decryption == nil {
+		res.Result = appsv1alpha1.BackupVerificationFailed
+		res.Message = fmt.Sprintf("The backup is encrypted with %s. Set the decryption key", v.Manifest.Encryption)
+		return res
+	}
+
+	if len(v.InvalidFiles) > 0 {
+		res.Result = appsv1alpha1.BackupVerificationFailed
+		res.Message = fmt.Sprintf("%d backed up files are missing or do not match their checksum", len(v.InvalidFiles))
+		res.InvalidFiles = v.InvalidFiles
+		if len(res.InvalidFiles) > maxReportedInvalidFiles {
+			res.InvalidFiles = res.InvalidFiles[:maxReportedInvalidFiles]
+		}
+		return res
+	}
+
+	incompatibility := v.incompatibility()
+	switch {
+	case incompatibility == "":
+		res.Result = appsv1alpha1.BackupVerificationVerified
+		res.Message = fmt.Sprintf("%d backed up files match their checksum", v.VerifiedFiles)
+	case cr.IncompatibleBackupAllowed():
+		res.Result = appsv1alpha1.BackupVerificationOverridden
+		res.Message = fmt.Sprintf("%s. Restoring as incompatible backups are allowed", incompatibility)
+	default:
+		res.Result = appsv1alpha1.BackupVerificationFailed
+		res.Message = fmt.Sprintf("%s. Set allowIncompatibleBackup to restore it", incompatibility)
+	}
+
+	return res
+}
+
+func (v *BackupVerification) incompatibility() string {
+	if !v.ManifestFound || v.Manifest == nil {
+		return "Backup manifest not found"
+	}
+	if v.Manifest.ThreescaleRelease != product.ThreescaleRelease {
+		return fmt.Sprintf("Backup taken from 3scale release %s, the operator deploys 3scale release %s",
+			v.Manifest.ThreescaleRelease, product.ThreescaleRelease)
+	}
+	if minorVersion(v.Manifest.OperatorVersion) != minorVersion(version.Version) {
+		return fmt.Sprintf("Backup taken by operator version %s, incompatible with operator version %s",
+			v.Manifest.OperatorVersion, version.Version)
+	}
+	return ""
+}
+
+// minorVersion returns the major and minor components of the version
+func minorVersion(v string) string {
+	parts := strings.SplitN(strings.TrimPrefix(v, "v"), ".", 3)
+	if len(parts) > 2 {
+		parts = parts[:2]
+	}
+	return strings.Join(parts, ".")
+}
+
+// BackupVerificationConfigMapName returns the name of the ConfigMap where
+// the backup verification job writes its result
+func (b *APIManagerRestore) BackupVerificationConfigMapName() string {
+	return fmt.Sprintf("%s-backup-verification", b.options.APIManagerRestoreName)
+}
+
+// VerifyBackupJob returns the job checking the backed up files against the checksums
+// of the backup manifest. The result is written into a ConfigMap, read by the operator.
+// Decryption failures, as with a wrong key, are reported in the result
+func (b *APIManagerRestore) VerifyBackupJob() *batchv1.Job {
+	return b.job("verify-backup", v1.Container{
+		Name:  "verify-backup",
+		Image: b.options.OCCLIImageURL,
+		Command: []string{
+			"/bin/bash",
+		},
+		Args: []string{
+			"-c",
+			"-e",
+			b.verifyBackupContainerArgs(),
+		},
+	}, nil, []string{"secrets", "configmaps", "apimanager", "system-filestorage-pvc", backup.DatabasesBackupSubdir, backup.ManifestBackupSubdir}, true)
+}
+
+func (b *APIManagerRestore) verifyBackupContainerArgs() string {
+	return fmt.Sprintf(`
+	BASEPATH='%s';
+	CONFIGMAP='%s';
+	VERIFICATION_FILENAME='%s';
+	PYTHON_VERIFY_SUBSCRIPT="%s"
+	python -c "${PYTHON_VERIFY_SUBSCRIPT}" ${BASEPATH} > /tmp/${VERIFICATION_FILENAME};
+	oc create configmap ${CONFIGMAP} --from-file=/tmp/${VERIFICATION_FILENAME};
+`,
+		RestorePVCMountPath,
+		b.BackupVerificationConfigMapName(),
+		BackupVerificationFileName,
+		pythonVerifyScript,
+	)
+}
+
+var pythonVerifyScript = backup.PythonSHA256Function + fmt.Sprintf(`
+import json, os, sys
+
+basepath=sys.argv[1]
+manifestpath=os.path.join(basepath, '%s', '%s')
+decryptionerrorpath=os.path.join(basepath, '%s')
+result={'manifestFound': False, 'verifiedFiles': 0, 'invalidFiles': []}
+if os.path.isfile(decryptionerrorpath):
+  with open(decryptionerrorpath) as f:
+    result['decryptionError']=f.read()
+if os.path.isfile(manifestpath):
+  with open(manifestpath) as f:
+    manifest=json.load(f)
+  result['manifestFound']=True
+  result['manifest']=dict((k, v) for k, v in manifest.items() if k != 'files')
+  if 'decryptionError' in result:
+    manifest['files']=[]
+  for entry in manifest.get('files', []):
+    filepath=os.path.join(basepath, entry['path'])
+    if os.path.isfile(filepath) and sha256(filepath) == entry['sha256']:
+      result['verifiedFiles'] += 1
+    else:
+      result['invalidFiles'].append(entry['path'])
+
+print(json.dumps(result, indent=4, sort_keys=True))
+`, backup.ManifestBackupSubdir, backup.ManifestFileName, backup.DecryptionErrorFileName)
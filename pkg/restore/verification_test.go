@@ -0,0 +1,105 @@
+package restore
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	appsv1alpha1 "github.com/3scale/3scale-operator/apis/apps/v1alpha1"
+	"github.com/3scale/3scale-operator/pkg/3scale/amp/product"
+	"github.com/3scale/3scale-operator/pkg/backup"
+	"github.com/3scale/3scale-operator/version"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+func TestBackupVerificationStatus(t *testing.T) {
+	manifest := func(operatorVersion, threescaleRelease string) *backup.BackupManifest {
+		return &backup.BackupManifest{
+			BackupName:         "mybackup",
+			OperatorVersion:    operatorVersion,
+			ThreescaleRelease:  threescaleRelease,
+			APIManagerSpecHash: "4f2a",
+		}
+	}
+	patchVersion := fmt.Sprintf("%s.99", minorVersion(version.Version))
+	encryptedManifest := manifest(version.Version, product.ThreescaleRelease)
+	encryptedManifest.Encryption = backup.EncryptionAES
+	invalidFiles := []string{}
+	for i := 0; i < 12; i++ {
+		invalidFiles = append(invalidFiles, fmt.Sprintf("system-filestorage-pvc/file-%d", i))
+	}
+
+	cases := []struct {
+		name              string
+		verification      BackupVerification
+		allowIncompatible bool
+		decryption        bool
+		expected          appsv1alpha1.BackupVerificationResult
+		invalidFiles      int
+	}{
+		{"Verified", BackupVerification{ManifestFound: true, Manifest: manifest(version.Version, product.ThreescaleRelease), VerifiedFiles: 3}, false, false, appsv1alpha1.BackupVerificationVerified, 0},
+		{"DifferentPatchVersion", BackupVerification{ManifestFound: true, Manifest: manifest(patchVersion, product.ThreescaleRelease)}, false, false, appsv1alpha1.BackupVerificationVerified, 0},
+		{"DifferentOperatorVersion", BackupVerification{ManifestFound: true, Manifest: manifest("0.1.0", product.ThreescaleRelease)}, false, false, appsv1alpha1.BackupVerificationFailed, 0},
+		{"DifferentThreescaleRelease", BackupVerification{ManifestFound: true, Manifest: manifest(version.Version, "2.0")}, false, false, appsv1alpha1.BackupVerificationFailed, 0},
+		{"DifferentThreescaleReleaseAllowed", BackupVerification{ManifestFound: true, Manifest: manifest(version.Version, "2.0")}, true, false, appsv1alpha1.BackupVerificationOverridden, 0},
+		{"ManifestNotFound", BackupVerification{}, false, false, appsv1alpha1.BackupVerificationFailed, 0},
+		{"ManifestNotFoundAllowed", BackupVerification{}, true, false, appsv1alpha1.BackupVerificationOverridden, 0},
+		{"Encrypted", BackupVerification{ManifestFound: true, Manifest: encryptedManifest, VerifiedFiles: 3}, false, true, appsv1alpha1.BackupVerificationVerified, 0},
+		{"EncryptedWithoutDecryption", BackupVerification{ManifestFound: true, Manifest: encryptedManifest}, true, false, appsv1alpha1.BackupVerificationFailed, 0},
+		{"DecryptionError", BackupVerification{DecryptionError: "Failed to decrypt secrets/system-seed.json.enc. Check the decryption key", ManifestFound: true, Manifest: encryptedManifest}, true, true, appsv1alpha1.BackupVerificationFailed, 0},
+		{"InvalidFiles", BackupVerification{ManifestFound: true, Manifest: manifest(version.Version, product.ThreescaleRelease), InvalidFiles: invalidFiles}, true, false, appsv1alpha1.BackupVerificationFailed, maxReportedInvalidFiles},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(subT *testing.T) {
+			cr := &appsv1alpha1.APIManagerRestore{
+				Spec: appsv1alpha1.APIManagerRestoreSpec{AllowIncompatibleBackup: &tc.allowIncompatible},
+			}
+			if tc.decryption {
+				cr.Spec.Decryption = &appsv1alpha1.BackupDecryption{KeySecretRef: &v1.SecretKeySelector{}}
+			}
+			status := tc.verification.Status(cr)
+			if status.Result != tc.expected {
+				subT.Errorf("expected %s, got %s: %s", tc.expected, status.Result, status.Message)
+			}
+			if len(status.InvalidFiles) != tc.invalidFiles {
+				subT.Errorf("expected %d invalid files reported, got %v", tc.invalidFiles, status.InvalidFiles)
+			}
+			if tc.verification.Manifest != nil && (status.OperatorVersion != tc.verification.Manifest.OperatorVersion || status.APIManagerSpecHash != "4f2a") {
+				subT.Errorf("expected the manifest reported in the status, got %v", status)
+			}
+		})
+	}
+}
+
+func TestVerifyBackupJob(t *testing.T) {
+	options := &APIManagerRestoreOptions{
+		Namespace:             "operator-unittest",
+		APIManagerRestoreName: "myrestore",
+		APIManagerRestoreUID:  "restore-uid",
+		APIManagerRestorePVCOptions: &APIManagerRestorePVCOptions{
+			PersistentVolumeClaimVolumeSource: v1.PersistentVolumeClaimVolumeSource{ClaimName: "mybackup-pvc"},
+		},
+		OCCLIImageURL: "quay.io/openshift/origin-cli:4.7",
+	}
+	apiManagerRestore := NewAPIManagerRestore(options)
+
+	job := apiManagerRestore.VerifyBackupJob()
+	if job == nil {
+		t.Fatal("expected backup verification job")
+	}
+	container := job.Spec.Template.Spec.Containers[0]
+	for _, expected := range []string{
+		"CONFIGMAP='myrestore-backup-verification'",
+		"oc create configmap ${CONFIGMAP} --from-file=/tmp/${VERIFICATION_FILENAME}",
+		"os.path.join(basepath, 'manifest', 'manifest.json')",
+	} {
+		if !strings.Contains(container.Args[2], expected) {
+			t.Errorf("expected verification script to contain %q, got %s", expected, container.Args[2])
+		}
+	}
+	if strings.Contains(pythonVerifyScript, `"`) {
+		t.Error("the verification script runs within double quotes")
+	}
+}
@@ -30,6 +30,59 @@ const (
 	systemSearchdPVCResourceRequestsPath     = "/spec/system/searchdSpec/persistentVolumeClaim/resources/requests"
 	productPoliciesConfigurationPath         = "/spec/policies/configuration"
 	policyConfigurationPath                  = "/spec/schema/configuration"
+	apicastProductionHPAPodsMetricsValuePath = "/spec/apicast/productionSpec/hpa/podsMetrics/averageValue"
+	backendListenerHPAPodsMetricsValuePath   = "/spec/backend/listenerSpec/hpa/podsMetrics/averageValue"
+	backendWorkerHPAPodsMetricsValuePath     = "/spec/backend/workerSpec/hpa/podsMetrics/averageValue"
+	systemAppHPAPodsMetricsValuePath         = "/spec/system/appSpec/hpa/podsMetrics/averageValue"
+	secretRotationIntervalPath               = "/spec/secretRotation/interval"
+	secretRotationLastRotationTimePath       = "/status/secretRotation/lastRotationTime"
+	secretRotationNextRotationTimePath       = "/status/secretRotation/nextRotationTime"
+	certificatesNotAfterPath                 = "/status/certificates/notAfter"
+	certificatesRenewalTimePath              = "/status/certificates/renewalTime"
+	systemSidekiqPoolsAffinityPath           = "/spec/system/sidekiqPools/affinity"
+)
+
+// Pod customization fields of each component spec path not supported by the schema validation:
+// int-or-string ports, quantities and embedded object metadata
+var (
+	podCustomizationSpecPaths = []string{
+		"/spec/apicast/productionSpec",
+		"/spec/apicast/stagingSpec",
+		"/spec/backend/listenerSpec",
+		"/spec/backend/workerSpec",
+		"/spec/backend/cronSpec",
+		"/spec/system/appSpec",
+		"/spec/system/sidekiqSpec",
+		"/spec/system/searchdSpec",
+		"/spec/zync/appSpec",
+		"/spec/zync/queSpec",
+	}
+	podCustomizationOmittedFields = []string{
+		"extraEnv/valueFrom/resourceFieldRef/divisor",
+		"extraVolumes/downwardAPI/items/resourceFieldRef/divisor",
+		"extraVolumes/projected/sources/downwardAPI/items/resourceFieldRef/divisor",
+		"extraVolumes/emptyDir/sizeLimit",
+		"extraVolumes/ephemeral/volumeClaimTemplate",
+		"livenessProbe/httpGet/port",
+		"livenessProbe/tcpSocket/port",
+		"readinessProbe/httpGet/port",
+		"readinessProbe/tcpSocket/port",
+		"startupProbe/httpGet/port",
+		"startupProbe/tcpSocket/port",
+	}
+	podCustomizationContainerOmittedFields = []string{
+		"env/valueFrom/resourceFieldRef/divisor",
+		"lifecycle/postStart/httpGet/port",
+		"lifecycle/postStart/tcpSocket/port",
+		"lifecycle/preStop/httpGet/port",
+		"lifecycle/preStop/tcpSocket/port",
+		"livenessProbe/httpGet/port",
+		"livenessProbe/tcpSocket/port",
+		"readinessProbe/httpGet/port",
+		"readinessProbe/tcpSocket/port",
+		"startupProbe/httpGet/port",
+		"startupProbe/tcpSocket/port",
+	}
 )
 
 type testCRInfo struct {
@@ -89,6 +142,10 @@ func TestSampleCustomResources(t *testing.T) {
 			crPrefix:   "capabilities_v1beta1_developeruser",
 			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
 		},
+		"capabilities.3scale.net_accountplans.yaml": testCRInfo{
+			crPrefix:   "capabilities_v1beta1_accountplan",
+			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
+		},
 	}
 
 	for crd, elem := range crdCrMap {
@@ -170,6 +227,10 @@ func TestCompleteCRD(t *testing.T) {
 			obj:        &capabilitiesv1beta1.DeveloperUser{},
 			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
 		},
+		"capabilities.3scale.net_accountplans.yaml": testCRDInfo{
+			obj:        &capabilitiesv1beta1.AccountPlan{},
+			apiVersion: capabilitiesv1beta1.GroupVersion.Version,
+		},
 	}
 
 	pathOmissions := []string{
@@ -184,6 +245,26 @@ func TestCompleteCRD(t *testing.T) {
 		policyConfigurationPath,
 		systemSearchdResourceRequestsPath,
 		systemSearchdPVCResourceRequestsPath,
+		apicastProductionHPAPodsMetricsValuePath,
+		backendListenerHPAPodsMetricsValuePath,
+		backendWorkerHPAPodsMetricsValuePath,
+		systemAppHPAPodsMetricsValuePath,
+		secretRotationIntervalPath,
+		secretRotationLastRotationTimePath,
+		secretRotationNextRotationTimePath,
+		certificatesNotAfterPath,
+		certificatesRenewalTimePath,
+		systemSidekiqPoolsAffinityPath,
+	}
+	for _, specPath := range podCustomizationSpecPaths {
+		for _, field := range podCustomizationOmittedFields {
+			pathOmissions = append(pathOmissions, fmt.Sprintf("%s/%s", specPath, field))
+		}
+		for _, containers := range []string{"sidecars", "initContainers"} {
+			for _, field := range podCustomizationContainerOmittedFields {
+				pathOmissions = append(pathOmissions, fmt.Sprintf("%s/%s/%s", specPath, containers, field))
+			}
+		}
 	}
 
 	for crd, elem := range crdStructMap {